@@ -0,0 +1,66 @@
+// ABOUTME: Action items command for extracting checklist items from a note
+// ABOUTME: Optionally pushes unchecked items to Apple Reminders with the --push flag
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	actionItemsPush bool
+	actionItemsList string
+)
+
+var actionItemsCmd = &cobra.Command{
+	Use:   "action-items <note-title>",
+	Short: "Extract checklist items from a note",
+	Long:  `Extracts checklist items from a note in Apple Notes and outputs them as JSON. Use --push to create Apple Reminders for all unchecked items.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noteTitle := args[0]
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		// Push unchecked items to Reminders if requested
+		if actionItemsPush {
+			reminders, err := notesService.PushActionItemsToReminders(ctx, noteTitle, actionItemsList)
+			if err != nil {
+				return fmt.Errorf("failed to push action items: %w", err)
+			}
+			printSuccess(cmd.OutOrStdout(), "Created %d reminders from: %s", len(reminders), noteTitle)
+			return nil
+		}
+
+		// Extract action items
+		items, err := notesService.ExtractActionItems(ctx, noteTitle)
+		if err != nil {
+			return fmt.Errorf("failed to extract action items: %w", err)
+		}
+
+		// Output as JSON
+		output, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format action items: %w", err)
+		}
+
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(actionItemsCmd)
+
+	// Add flags
+	actionItemsCmd.Flags().BoolVar(&actionItemsPush, "push", false, "Create Apple Reminders for unchecked items")
+	actionItemsCmd.Flags().StringVar(&actionItemsList, "list", "", "Reminders list to use with --push (default: the default list)")
+}
@@ -0,0 +1,234 @@
+// ABOUTME: Alias commands and resolution of "@name" note references in CLI arguments and MCP tool calls
+// ABOUTME: Aliases live in ~/.config/notes-mcp/aliases.json (or NOTES_MCP_ALIASES_FILE) and point at note IDs
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+// aliasesFileEnvVar overrides the file aliases are stored in
+const aliasesFileEnvVar = "NOTES_MCP_ALIASES_FILE"
+
+// noteTitleCommands are the CLI commands whose first argument is an existing note's title
+// Their argument may be an "@name" alias, and --strict checks that it names only one note.
+var noteTitleCommands = map[string]bool{
+	"action-items":    true,
+	"attachments":     true,
+	"copy":            true,
+	"create-reminder": true,
+	"delete":          true,
+	"export-html":     true,
+	"export-markdown": true,
+	"export-pdf":      true,
+	"export-text":     true,
+	"get":             true,
+	"move-note":       true,
+	"open":            true,
+	"paste":           true,
+	"pin":             true,
+	"preview":         true,
+	"properties":      true,
+	"share":           true,
+	"tag":             true,
+	"update":          true,
+}
+
+// aliasTitleArguments are the tool arguments that name an existing note
+// Tools that create notes are skipped, since their title is the new note's.
+var aliasTitleArguments = []string{"title", "note_title"}
+
+// aliasesPath returns the alias file: NOTES_MCP_ALIASES_FILE or ~/.config/notes-mcp/aliases.json
+func aliasesPath() string {
+	if path := os.Getenv(aliasesFileEnvVar); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "aliases.json")
+}
+
+// newAliasStore returns the store for the configured alias file
+func newAliasStore() *services.AliasStore {
+	return services.NewAliasStore(aliasesPath())
+}
+
+// resolveAliasArgs replaces an "@name" first argument of note commands with the aliased note's title
+// It rewrites args in place, which cobra then passes on to the command's RunE.
+func resolveAliasArgs(cmd *cobra.Command, args []string) error {
+	if !noteTitleCommands[cmd.Name()] || len(args) == 0 {
+		return nil
+	}
+	if _, ok := services.AliasName(args[0]); !ok {
+		return nil
+	}
+
+	ctx, cancel := newCommandContext()
+	defer cancel()
+
+	title, err := newAliasStore().Resolve(ctx, newNotesService(), args[0])
+	if err != nil {
+		return err
+	}
+	args[0] = title
+	return nil
+}
+
+// resolveToolAliases rewrites "@name" title arguments of a tool call to note titles
+// It reports whether any argument changed.
+func resolveToolAliases(ctx context.Context, store *services.AliasStore, notes services.NoteTitleLookup,
+	params *mcp.CallToolParamsRaw) (bool, error) {
+
+	if noteCreatingTools[params.Name] || len(params.Arguments) == 0 {
+		return false, nil
+	}
+
+	var arguments map[string]json.RawMessage
+	if err := json.Unmarshal(params.Arguments, &arguments); err != nil {
+		// Leave malformed arguments for the tool's own validation to report
+		return false, nil
+	}
+
+	changed := false
+	for _, key := range aliasTitleArguments {
+		var ref string
+		if err := json.Unmarshal(arguments[key], &ref); err != nil {
+			continue
+		}
+		if _, ok := services.AliasName(ref); !ok {
+			continue
+		}
+
+		title, err := store.Resolve(ctx, notes, ref)
+		if err != nil {
+			return false, err
+		}
+		if title == ref {
+			continue
+		}
+		if arguments[key], err = json.Marshal(title); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	if changed {
+		raw, err := json.Marshal(arguments)
+		if err != nil {
+			return false, err
+		}
+		params.Arguments = raw
+	}
+	return changed, nil
+}
+
+// aliasMiddleware resolves "@name" note references in tool calls before any handler sees them
+func aliasMiddleware(store *services.AliasStore, notes services.NoteTitleLookup) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			if _, err := resolveToolAliases(ctx, store, notes, params); err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{
+							Text: err.Error(),
+						},
+					},
+					IsError: true,
+				}, nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage short names for frequently used notes",
+	Long: `Manages aliases: short names like "inbox" or "standup" that point at a note.
+Use an alias as "@name" wherever a note title is expected, e.g. "notes-mcp get @inbox" or
+{"title": "@inbox"} in MCP tool calls. Aliases follow the note's ID, so they survive renames.
+Aliases are stored in ~/.config/notes-mcp/aliases.json, or the file named by NOTES_MCP_ALIASES_FILE.`,
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:   "add <name> <note-title>",
+	Short: "Point an alias at a note, replacing any alias with the same name",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		note, err := newNotesService().GetNoteMetadata(ctx, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to add alias: %w", err)
+		}
+		if note.ID == "" {
+			return fmt.Errorf("failed to add alias: note %q has no ID", args[1])
+		}
+
+		alias, err := newAliasStore().Add(services.NoteAlias{Name: args[0], NoteID: note.ID, Title: note.Title})
+		if err != nil {
+			return err
+		}
+
+		printSuccess(cmd.OutOrStdout(), "@%s -> %s", alias.Name, alias.Title)
+		return nil
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		aliases, err := newAliasStore().List()
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(aliases) == 0 {
+			fmt.Fprintln(out, "No aliases")
+			return nil
+		}
+		for _, alias := range aliases {
+			fmt.Fprintf(out, "@%s\t%s\t%s\n", alias.Name, alias.Title, alias.NoteID)
+		}
+		return nil
+	},
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an alias",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := newAliasStore().Remove(args[0]); err != nil {
+			return err
+		}
+
+		printSuccess(cmd.OutOrStdout(), "Removed alias %s", args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(aliasAddCmd, aliasListCmd, aliasRemoveCmd)
+}
@@ -0,0 +1,99 @@
+// ABOUTME: Unit tests for alias resolution in MCP tool calls and CLI arguments
+// ABOUTME: Tests that "@name" titles are rewritten while create tools and unknown aliases pass through
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+// TestAliasMiddleware tests that title arguments naming an alias reach tools as the note's title
+func TestAliasMiddleware(t *testing.T) {
+	store := services.NewAliasStore(filepath.Join(t.TempDir(), "aliases.json"))
+	if _, err := store.Add(services.NoteAlias{Name: "inbox", NoteID: "id1", Title: "Inbox"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotContentTitle, gotCreatedTitle string
+	mock := &mockNotesService{
+		getNoteTitleByID: func(ctx context.Context, noteID string) (string, error) {
+			return "Inbox 2024", nil
+		},
+		getNoteMetadata: func(ctx context.Context, title string) (*services.Note, error) {
+			return &services.Note{Title: title}, nil
+		},
+		getNoteContent: func(ctx context.Context, title string) (string, error) {
+			gotContentTitle = title
+			return "<div>body</div>", nil
+		},
+		createNote: func(ctx context.Context, title, content string, tags []string) (*services.Note, error) {
+			gotCreatedTitle = title
+			return &services.Note{Title: title}, nil
+		},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(aliasMiddleware(store, mock))
+	registerGetNoteContentTool(server, mock)
+	registerCreateNoteTool(server, mock)
+	session := connectTestClient(t, server)
+
+	if result := callToolResult(t, session, "get_note_content", map[string]any{"title": "@inbox"}); result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if gotContentTitle != "Inbox 2024" {
+		t.Errorf("get_note_content title = %q, want the aliased note's title", gotContentTitle)
+	}
+
+	callToolResult(t, session, "get_note_content", map[string]any{"title": "@someone"})
+	if gotContentTitle != "@someone" {
+		t.Errorf("unknown aliases should pass through, got %q", gotContentTitle)
+	}
+
+	callToolResult(t, session, "create_note", map[string]any{"title": "@inbox", "content": "x"})
+	if gotCreatedTitle != "@inbox" {
+		t.Errorf("create_note titles should not be resolved, got %q", gotCreatedTitle)
+	}
+}
+
+// TestAliasMiddlewareMissingNote tests that an alias to a deleted note fails the call
+func TestAliasMiddlewareMissingNote(t *testing.T) {
+	store := services.NewAliasStore(filepath.Join(t.TempDir(), "aliases.json"))
+	if _, err := store.Add(services.NoteAlias{Name: "standup", NoteID: "gone", Title: "Standup"}); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockNotesService{
+		getNoteTitleByID: func(ctx context.Context, noteID string) (string, error) {
+			return "", services.ErrNoteNotFound
+		},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(aliasMiddleware(store, mock))
+	registerGetNoteContentTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "get_note_content", map[string]any{"title": "@standup"})
+	if !result.IsError {
+		t.Fatalf("expected error, got %s", firstText(result))
+	}
+}
+
+// TestResolveAliasArgsSkipsOtherCommands tests that only note title commands have arguments resolved
+func TestResolveAliasArgsSkipsOtherCommands(t *testing.T) {
+	t.Setenv(aliasesFileEnvVar, filepath.Join(t.TempDir(), "aliases.json"))
+
+	args := []string{"@inbox"}
+	if err := resolveAliasArgs(&cobra.Command{Use: "search <query>"}, args); err != nil || args[0] != "@inbox" {
+		t.Errorf("search arguments should be left alone, got %v, %v", args, err)
+	}
+	if err := resolveAliasArgs(&cobra.Command{Use: "get <title>"}, []string{"Plain Title"}); err != nil {
+		t.Errorf("plain titles should not need resolving: %v", err)
+	}
+}
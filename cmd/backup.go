@@ -0,0 +1,227 @@
+// ABOUTME: Backup and restore commands for encrypted, versioned copies of the notes library
+// ABOUTME: Backups go to a local directory or s3://bucket/prefix and are sealed with NOTES_MCP_BACKUP_PASSPHRASE
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// backupPassphraseEnvVar holds the passphrase backups are encrypted with
+const backupPassphraseEnvVar = "NOTES_MCP_BACKUP_PASSPHRASE"
+
+var (
+	backupDest string
+
+	backupDiffSource  string
+	backupDiffContent bool
+	backupDiffJSON    bool
+
+	restoreSource          string
+	restoreVersion         string
+	restoreFolder          string
+	restoreAllowDuplicates bool
+	restoreList            bool
+	restoreDryRun          bool
+)
+
+// backupPassphrase returns the backup passphrase from the environment
+func backupPassphrase() (string, error) {
+	passphrase := os.Getenv(backupPassphraseEnvVar)
+	if passphrase == "" {
+		return "", fmt.Errorf("set %s to the passphrase that encrypts your backups", backupPassphraseEnvVar)
+	}
+	return passphrase, nil
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up every note to an encrypted, versioned archive",
+	Long: `Backs up the full notes library to a local directory or an S3 bucket (--dest s3://bucket/path).
+Each run writes an encrypted manifest listing every note and an encrypted tarball of only the notes
+that changed since the previous run, so every backup can be restored in full.
+
+Files are encrypted with AES-256-GCM using a key derived from NOTES_MCP_BACKUP_PASSPHRASE; keep the
+passphrase somewhere other than this Mac, since backups cannot be restored without it. S3 uses the
+AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION, and AWS_ENDPOINT_URL variables.
+Locked notes are skipped.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := backupPassphrase()
+		if err != nil {
+			return err
+		}
+		store, err := services.OpenBackupStore(backupDest)
+		if err != nil {
+			return err
+		}
+		_, notesService, err := newProviderNotesService()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		manifest, name, err := services.CreateBackup(ctx, notesService, store, passphrase, time.Now())
+		if err != nil {
+			return fmt.Errorf("backup failed: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		for _, skipped := range manifest.Skipped {
+			fmt.Fprintf(out, "skipped: %s (%s)\n", skipped.Title, skipped.Reason)
+		}
+		printSuccess(out, "Backed up %d notes (%d changed) to %s", len(manifest.Notes), manifest.Changed(), name)
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Re-import notes from a backup",
+	Long: `Re-creates the notes of a backup made with "notes-mcp backup", in their original folders
+(created if missing) or all in --folder. The latest backup is used unless --version names one;
+--list shows the available versions. Notes whose titles already exist are skipped unless
+--allow-duplicates is set.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := services.OpenBackupStore(restoreSource)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+		out := cmd.OutOrStdout()
+
+		if restoreList {
+			versions, err := services.ListBackups(ctx, store)
+			if err != nil {
+				return err
+			}
+			for _, version := range versions {
+				fmt.Fprintln(out, strings.TrimSuffix(strings.TrimPrefix(version, "manifest-"), ".json.enc"))
+			}
+			return nil
+		}
+
+		passphrase, err := backupPassphrase()
+		if err != nil {
+			return err
+		}
+		manifest, name, err := services.LoadBackupManifest(ctx, store, passphrase, restoreVersion)
+		if err != nil {
+			return err
+		}
+
+		if restoreDryRun {
+			for _, entry := range manifest.Notes {
+				fmt.Fprintf(out, "%s\t%s\n", entry.Title, entry.Folder)
+			}
+			fmt.Fprintf(out, "%d notes in %s\n", len(manifest.Notes), name)
+			return nil
+		}
+
+		_, notesService, err := newProviderNotesService()
+		if err != nil {
+			return err
+		}
+		result, err := services.RestoreBackup(ctx, notesService, store, passphrase, manifest, services.RestoreOptions{
+			Folder:          restoreFolder,
+			AllowDuplicates: restoreAllowDuplicates,
+		})
+		if err != nil {
+			return fmt.Errorf("restore failed: %w", err)
+		}
+
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format restore result: %w", err)
+		}
+		fmt.Fprintln(out, string(output))
+		return nil
+	},
+}
+
+var backupDiffCmd = &cobra.Command{
+	Use:   "diff <from> <to>",
+	Short: "Compare two backups",
+	Long: `Reports the notes created, modified (including renamed or moved), and deleted between two
+backups, named by the versions "notes-mcp restore --list" shows. --content adds a line diff of
+each modified note's text, for example to audit what changed over a month.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := backupPassphrase()
+		if err != nil {
+			return err
+		}
+		store, err := services.OpenBackupStore(backupDiffSource)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		diff, err := services.DiffBackups(ctx, store, passphrase, args[0], args[1], backupDiffContent)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if backupDiffJSON {
+			output, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format backup diff: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+
+		for _, change := range diff.Changes {
+			line := fmt.Sprintf("%-8s %s (%s)", change.Type, change.Title, change.Folder)
+			if change.RenamedFrom != "" {
+				line += fmt.Sprintf(", renamed from %q", change.RenamedFrom)
+			}
+			if change.MovedFrom != "" {
+				line += fmt.Sprintf(", moved from %s", change.MovedFrom)
+			}
+			fmt.Fprintln(out, line)
+			if change.Diff != "" {
+				fmt.Fprint(out, change.Diff)
+			}
+		}
+		fmt.Fprintf(out, "%d created, %d modified, %d deleted\n", diff.Count(services.ChangeCreated),
+			diff.Count(services.ChangeModified), diff.Count(services.ChangeDeleted))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd, restoreCmd)
+	backupCmd.AddCommand(backupDiffCmd)
+
+	backupCmd.Flags().StringVar(&backupDest, "dest", "", "Backup destination: a local directory or s3://bucket/path")
+	_ = backupCmd.MarkFlagRequired("dest") //nolint:errcheck // the flag is defined just above
+
+	backupDiffCmd.Flags().StringVar(&backupDiffSource, "from", "", "Backup location: a local directory or s3://bucket/path")
+	backupDiffCmd.Flags().BoolVar(&backupDiffContent, "content", false, "Include a line diff of each modified note's text")
+	backupDiffCmd.Flags().BoolVar(&backupDiffJSON, "json", false, "Print the comparison as JSON")
+	_ = backupDiffCmd.MarkFlagRequired("from") //nolint:errcheck // the flag is defined just above
+
+	restoreCmd.Flags().StringVar(&restoreSource, "from", "", "Backup location: a local directory or s3://bucket/path")
+	restoreCmd.Flags().StringVar(&restoreVersion, "version", "", "Backup version to restore, as shown by --list (default: latest)")
+	restoreCmd.Flags().StringVar(&restoreFolder, "folder", "", "Restore every note into this folder instead of its original folder")
+	restoreCmd.Flags().BoolVar(&restoreAllowDuplicates, "allow-duplicates", false, "Restore notes even if a note with the same title exists")
+	restoreCmd.Flags().BoolVar(&restoreList, "list", false, "List the available backup versions")
+	restoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "List the notes in the backup without restoring them")
+	_ = restoreCmd.MarkFlagRequired("from") //nolint:errcheck // the flag is defined just above
+}
@@ -0,0 +1,211 @@
+// ABOUTME: Bookmark tools and the notes:///bookmarks resource for re-finding working context
+// ABOUTME: Bookmarks persist in ~/.config/notes-mcp/bookmarks.json (or NOTES_MCP_BOOKMARKS_FILE) across sessions
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// bookmarksFileEnvVar overrides the file bookmarks are stored in
+const bookmarksFileEnvVar = "NOTES_MCP_BOOKMARKS_FILE"
+
+// bookmarksResourceURI is the resource listing the bookmarks
+const bookmarksResourceURI = "notes:///bookmarks"
+
+// bookmarkAccessTools are the tools whose successful calls count as accessing a bookmarked note
+var bookmarkAccessTools = map[string]bool{
+	"get_note_content":     true,
+	"update_note":          true,
+	"export_note_markdown": true,
+	"export_note_text":     true,
+	"open_note":            true,
+}
+
+// bookmarksPath returns the bookmark file: NOTES_MCP_BOOKMARKS_FILE or ~/.config/notes-mcp/bookmarks.json
+func bookmarksPath() string {
+	if path := os.Getenv(bookmarksFileEnvVar); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "bookmarks.json")
+}
+
+// BookmarkNoteArgs are the arguments for the bookmark_note tool
+type BookmarkNoteArgs struct {
+	Title  string `json:"title" jsonschema:"Title of the note to bookmark"`
+	Remove bool   `json:"remove,omitempty" jsonschema:"Remove the bookmark instead of adding it (title may also be a bookmarked note ID)"`
+}
+
+// ListBookmarksArgs are the arguments for the list_bookmarks tool
+type ListBookmarksArgs struct{}
+
+// registerBookmarkNoteTool registers the bookmark_note tool
+func registerBookmarkNoteTool(server *mcp.Server, notesService services.NotesService, bookmarks *services.BookmarkStore) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input BookmarkNoteArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		if input.Remove {
+			removed, err := bookmarks.Remove(input.Title)
+			if err != nil {
+				return createErrorResult(err), nil, nil
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("Removed bookmark for '%s'.", removed.Title),
+					},
+				},
+			}, nil, nil
+		}
+
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// The note ID keeps the bookmark pointing at the same note if its title changes
+		note, err := notesService.GetNoteMetadata(opCtx, input.Title)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		bookmark, err := bookmarks.Add(*note)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		out, err := json.MarshalIndent(bookmark, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format bookmark: %w", err)), nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "bookmark_note",
+		Description: "Bookmarks a note you keep coming back to, saving its id, title, and folder in a small local list that persists across sessions (see list_bookmarks and the notes:///bookmarks resource). Bookmarking an already bookmarked note refreshes it; set remove to drop a bookmark.",
+	}, handler)
+}
+
+// registerListBookmarksTool registers the list_bookmarks tool
+func registerListBookmarksTool(server *mcp.Server, bookmarks *services.BookmarkStore) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListBookmarksArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		text, err := formatBookmarks(bookmarks)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: text,
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_bookmarks",
+		Description: "Lists bookmarked notes with their id, title, folder, and when they were added and last accessed, most recently accessed first. Use it at the start of a session to pick up earlier working context.",
+	}, handler)
+}
+
+// registerBookmarksResource registers the notes:///bookmarks resource
+func registerBookmarksResource(server *mcp.Server, bookmarks *services.BookmarkStore) {
+	server.AddResource(
+		&mcp.Resource{
+			URI:         bookmarksResourceURI,
+			Name:        "bookmarks",
+			Title:       "Bookmarked Notes",
+			Description: "Notes bookmarked with bookmark_note, most recently accessed first, as JSON.",
+			MIMEType:    "application/json",
+		},
+		func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			text, err := formatBookmarks(bookmarks)
+			if err != nil {
+				return nil, err
+			}
+
+			return &mcp.ReadResourceResult{
+				Contents: []*mcp.ResourceContents{
+					{
+						URI:      req.Params.URI,
+						MIMEType: "application/json",
+						Text:     text,
+					},
+				},
+			}, nil
+		},
+	)
+}
+
+// formatBookmarks returns the bookmark list as indented JSON
+func formatBookmarks(bookmarks *services.BookmarkStore) (string, error) {
+	list, err := bookmarks.List()
+	if err != nil {
+		return "", err
+	}
+
+	out, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format bookmarks: %w", err)
+	}
+	return string(out), nil
+}
+
+// bookmarkAccessMiddleware updates the last accessed time of bookmarked notes read or edited by tool calls
+func bookmarkAccessMiddleware(bookmarks *services.BookmarkStore) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			toolResult, isToolResult := result.(*mcp.CallToolResult)
+			if !ok || !isToolResult || toolResult.IsError || !bookmarkAccessTools[params.Name] {
+				return result, err
+			}
+
+			var args struct {
+				Title     string `json:"title"`
+				NoteTitle string `json:"note_title"`
+			}
+			if json.Unmarshal(params.Arguments, &args) != nil {
+				return result, err
+			}
+			if title := args.Title + args.NoteTitle; title != "" {
+				if _, touchErr := bookmarks.Touch(title); touchErr != nil {
+					log.Printf("Failed to update bookmark access time: %v", touchErr)
+				}
+			}
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// ABOUTME: Unit tests for the bookmark tools, resource, and access tracking
+// ABOUTME: Tests bookmarking by title, listing, removal, and last-accessed updates from note reads
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestBookmarkTools tests bookmarking, listing, reading the resource, and removing
+func TestBookmarkTools(t *testing.T) {
+	bookmarks := services.NewBookmarkStore(filepath.Join(t.TempDir(), "bookmarks.json"))
+	mock := &mockNotesService{
+		getNoteMetadata: func(ctx context.Context, title string) (*services.Note, error) {
+			return &services.Note{ID: "id-" + title, Title: title, Folder: "Work"}, nil
+		},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerBookmarkNoteTool(server, mock, bookmarks)
+	registerListBookmarksTool(server, bookmarks)
+	registerBookmarksResource(server, bookmarks)
+	session := connectTestClient(t, server)
+
+	if result := callToolResult(t, session, "bookmark_note", map[string]any{"title": "Roadmap"}); result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+
+	var list []services.Bookmark
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "list_bookmarks", map[string]any{}))), &list); err != nil {
+		t.Fatalf("list_bookmarks did not return JSON: %v", err)
+	}
+	if len(list) != 1 || list[0].NoteID != "id-Roadmap" || list[0].Folder != "Work" {
+		t.Errorf("unexpected bookmarks %+v", list)
+	}
+
+	resource, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: bookmarksResourceURI})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if !strings.Contains(resource.Contents[0].Text, `"title": "Roadmap"`) {
+		t.Errorf("resource = %s", resource.Contents[0].Text)
+	}
+
+	if result := callToolResult(t, session, "bookmark_note", map[string]any{"title": "Roadmap", "remove": true}); result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if text := firstText(callToolResult(t, session, "list_bookmarks", map[string]any{})); text != "[]" {
+		t.Errorf("expected no bookmarks after removal, got %s", text)
+	}
+
+	if result := callToolResult(t, session, "bookmark_note", map[string]any{"title": "Roadmap", "remove": true}); !result.IsError {
+		t.Error("expected error removing a missing bookmark")
+	}
+}
+
+// TestBookmarkAccessMiddleware tests that reading a bookmarked note updates its last accessed time
+func TestBookmarkAccessMiddleware(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	stale := `[{"note_id": "id1", "title": "Roadmap", "added": "2020-01-01T00:00:00Z", "last_accessed": "2020-01-01T00:00:00Z"}]`
+	if err := os.WriteFile(path, []byte(stale), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bookmarks := services.NewBookmarkStore(path)
+
+	mock := &mockNotesService{
+		exportNoteText: func(ctx context.Context, noteTitle string) (string, error) {
+			return "text", nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(bookmarkAccessMiddleware(bookmarks))
+	registerExportNoteTextTool(server, mock)
+	session := connectTestClient(t, server)
+
+	if result := callToolResult(t, session, "export_note_text", map[string]any{"note_title": "roadmap"}); result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+
+	list, err := bookmarks.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if list[0].LastAccessed.Year() == 2020 {
+		t.Errorf("last accessed was not updated: %v", list[0].LastAccessed)
+	}
+}
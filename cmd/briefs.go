@@ -0,0 +1,186 @@
+// ABOUTME: MCP tools recording and returning each folder's canonical "project brief" note
+// ABOUTME: Briefs live in ~/.config/notes-mcp/briefs.json (or NOTES_MCP_BRIEFS_FILE) so agents know where to start
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// briefsFileEnvVar overrides the file folder briefs are stored in
+const briefsFileEnvVar = "NOTES_MCP_BRIEFS_FILE"
+
+// briefsPath returns the brief file: NOTES_MCP_BRIEFS_FILE or ~/.config/notes-mcp/briefs.json
+func briefsPath() string {
+	if path := os.Getenv(briefsFileEnvVar); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "briefs.json")
+}
+
+// SetFolderBriefArgs are the arguments for the set_folder_brief tool
+type SetFolderBriefArgs struct {
+	Folder    string `json:"folder" jsonschema:"The folder, by name or path such as 'Work/Apollo'"`
+	NoteTitle string `json:"note_title,omitempty" jsonschema:"Title of the note that gives the folder's overview (required unless remove is set)"`
+	Remove    bool   `json:"remove,omitempty" jsonschema:"Clear the folder's brief instead of setting it"`
+}
+
+// GetFolderBriefArgs are the arguments for the get_folder_brief tool
+type GetFolderBriefArgs struct {
+	Folder         string `json:"folder,omitempty" jsonschema:"The folder, by name or path (default: the session root folder)"`
+	IncludeContent bool   `json:"include_content,omitempty" jsonschema:"Also return the brief note's content as markdown"`
+}
+
+// folderBriefResult is the get_folder_brief response
+type folderBriefResult struct {
+	*services.FolderBrief
+	Content string `json:"content,omitempty"`
+}
+
+// resolveFolderPath returns the full path of the folder named by a name or path
+func resolveFolderPath(ctx context.Context, notesService services.NotesService, folder string) (string, error) {
+	root, err := notesService.GetFolderHierarchy(ctx)
+	if err != nil {
+		return "", err
+	}
+	subtree, ok := services.FolderSubtree(root, folder)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", services.ErrFolderNotFound, folder)
+	}
+	return subtree[0].Path, nil
+}
+
+// registerSetFolderBriefTool registers the set_folder_brief tool
+func registerSetFolderBriefTool(server *mcp.Server, notesService services.NotesService, briefs *services.BriefStore) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SetFolderBriefArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Folders may be given as paths longer than one folder name, so only presence is checked
+		if strings.TrimSpace(input.Folder) == "" {
+			return createErrorResult(fmt.Errorf("%w: folder is required", services.ErrInvalidInput)), nil, nil
+		}
+
+		if input.Remove {
+			removed, err := briefs.Remove(input.Folder)
+			if err != nil {
+				return createErrorResult(err), nil, nil
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("Cleared the brief of '%s'.", removed.Folder),
+					},
+				},
+			}, nil, nil
+		}
+
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		folderPath, err := resolveFolderPath(opCtx, notesService, input.Folder)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// The note ID keeps the brief pointing at the same note if its title changes
+		note, err := notesService.GetNoteMetadata(opCtx, input.NoteTitle)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		brief, err := briefs.Set(folderPath, *note)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		out, err := json.MarshalIndent(brief, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format brief: %w", err)), nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_folder_brief",
+		Description: "Records a note as a folder's canonical overview (its 'project brief'), the note to read first when working in that folder. Stored locally by note ID, so it survives renames; setting a new brief replaces the old one, and remove clears it. Returns the saved brief as JSON.",
+	}, handler)
+}
+
+// registerGetFolderBriefTool registers the get_folder_brief tool
+func registerGetFolderBriefTool(server *mcp.Server, notesService services.NotesService, briefs *services.BriefStore) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetFolderBriefArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		folder := scopedFolder(ctx, input.Folder, false)
+		if strings.TrimSpace(folder) == "" {
+			return createErrorResult(fmt.Errorf("%w: folder is required when no root folder is set", services.ErrInvalidInput)), nil, nil
+		}
+
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		brief, err := briefs.Resolve(opCtx, notesService, folder)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		if brief == nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("No brief is set for '%s'. Pick the folder's overview note and record it with set_folder_brief.", folder),
+					},
+				},
+			}, nil, nil
+		}
+
+		result := folderBriefResult{FolderBrief: brief}
+		if input.IncludeContent {
+			if result.Content, err = notesService.ExportNoteMarkdown(opCtx, brief.Title); err != nil {
+				return createErrorResult(err), nil, nil
+			}
+		}
+
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format brief: %w", err)), nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_folder_brief",
+		Description: "Returns the note recorded as a folder's canonical overview with set_folder_brief: its folder, note_id, current title, and when it was set, plus its markdown with include_content. Call it when starting work in a project folder to know where to begin. The folder defaults to the session root folder.",
+	}, handler)
+}
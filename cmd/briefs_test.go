@@ -0,0 +1,67 @@
+// ABOUTME: Unit tests for the folder brief tools
+// ABOUTME: Tests setting a brief by folder name, reading it back by path with content, and clearing it
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestFolderBriefTools tests set_folder_brief and get_folder_brief against the memory service
+func TestFolderBriefTools(t *testing.T) {
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	if err := notesService.CreateFolder(ctx, "Work", ""); err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+	if err := notesService.CreateFolder(ctx, "Apollo", "Work"); err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+	if _, err := notesService.CreateNote(ctx, "Apollo Overview", "<div>Start here</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	briefs := services.NewBriefStore(filepath.Join(t.TempDir(), "briefs.json"))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerSetFolderBriefTool(server, notesService, briefs)
+	registerGetFolderBriefTool(server, notesService, briefs)
+	session := connectTestClient(t, server)
+
+	if text := firstText(callToolResult(t, session, "get_folder_brief", map[string]any{"folder": "Apollo"})); !strings.Contains(text, "No brief is set") {
+		t.Errorf("expected no brief yet, got %s", text)
+	}
+
+	result := callToolResult(t, session, "set_folder_brief", map[string]any{"folder": "apollo", "note_title": "Apollo Overview"})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+
+	var brief struct {
+		services.FolderBrief
+		Content string `json:"content"`
+	}
+	result = callToolResult(t, session, "get_folder_brief", map[string]any{"folder": "Work/Apollo", "include_content": true})
+	if err := json.Unmarshal([]byte(firstText(result)), &brief); err != nil {
+		t.Fatalf("get_folder_brief did not return JSON: %v: %s", err, firstText(result))
+	}
+	if brief.Folder != "Work/Apollo" || brief.Title != "Apollo Overview" || !strings.Contains(brief.Content, "Start here") {
+		t.Errorf("unexpected brief %+v", brief)
+	}
+
+	if result := callToolResult(t, session, "set_folder_brief", map[string]any{"folder": "Nowhere", "note_title": "Apollo Overview"}); !result.IsError {
+		t.Error("expected an unknown folder to be rejected")
+	}
+	if result := callToolResult(t, session, "set_folder_brief", map[string]any{"folder": "Apollo", "remove": true}); result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if text := firstText(callToolResult(t, session, "get_folder_brief", map[string]any{"folder": "Apollo"})); !strings.Contains(text, "No brief is set") {
+		t.Errorf("expected the brief cleared, got %s", text)
+	}
+}
@@ -0,0 +1,88 @@
+// ABOUTME: Check-links command reporting the dead http(s) links in notes
+// ABOUTME: CLI counterpart of the check_links tool, for research note maintenance from the terminal
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkLinksFolder      string
+	checkLinksConcurrency int
+	checkLinksTimeout     time.Duration
+	checkLinksJSON        bool
+)
+
+var checkLinksCmd = &cobra.Command{
+	Use:   "check-links",
+	Short: "Report dead links in notes",
+	Long: `Finds the http(s) links in notes and checks each distinct URL once with a HEAD request,
+falling back to GET when a server refuses HEAD. Links that can't be reached or answer with a
+4xx or 5xx status are listed under the notes that contain them.
+
+--concurrency sets how many links are checked at once (default 8), and --timeout how long
+each one may take (default 10s). At most 200 notes are read, newest first.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		notesService := newNotesService()
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		opts := services.LinkCheckOptions{
+			Folder:      checkLinksFolder,
+			Concurrency: checkLinksConcurrency,
+			Timeout:     checkLinksTimeout,
+		}
+		report, err := services.CheckNoteLinks(ctx, notesService, services.NewHTTPLinkChecker(checkLinksTimeout), opts)
+		if err != nil {
+			return err
+		}
+
+		if checkLinksJSON {
+			output, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format link report: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(output))
+			return nil
+		}
+
+		printLinkReport(cmd.OutOrStdout(), report)
+		return nil
+	},
+}
+
+// printLinkReport writes each note with dead links, one "<url>  <status or error>" line per link, then totals
+func printLinkReport(w io.Writer, report *services.LinkCheckReport) {
+	for _, note := range report.Notes {
+		fmt.Fprintln(w, joinFolderPath(note.Folder, note.Title)) //nolint:errcheck // stdout write failure is non-critical
+		for _, link := range note.DeadLinks {
+			reason := link.Error
+			if link.Status != 0 {
+				reason = fmt.Sprintf("HTTP %d", link.Status)
+			}
+			fmt.Fprintf(w, "  %s  %s\n", link.URL, reason) //nolint:errcheck // stdout write failure is non-critical
+		}
+	}
+	fmt.Fprintf(w, "%d dead of %d links in %d notes\n", report.DeadCount, report.LinksChecked, report.NotesScanned) //nolint:errcheck // stdout write failure is non-critical
+	if report.Truncated {
+		fmt.Fprintf(w, "Only the %d most recent notes were checked.\n", services.MaxLinkCheckNotes) //nolint:errcheck // stdout write failure is non-critical
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(checkLinksCmd)
+
+	checkLinksCmd.Flags().StringVar(&checkLinksFolder, "folder", "", "Only check notes in this folder")
+	checkLinksCmd.Flags().IntVar(&checkLinksConcurrency, "concurrency", services.DefaultLinkCheckConcurrency, "Links checked at once")
+	checkLinksCmd.Flags().DurationVar(&checkLinksTimeout, "timeout", services.DefaultLinkCheckTimeout, "Time allowed for each link")
+	checkLinksCmd.Flags().BoolVar(&checkLinksJSON, "json", false, "Print the report as JSON")
+}
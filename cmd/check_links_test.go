@@ -0,0 +1,76 @@
+// ABOUTME: Unit tests for the check-links command and the check_links tool
+// ABOUTME: Tests the text report and the tool's JSON report against a test HTTP server
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestPrintLinkReport tests the per-note dead link lines and totals
+func TestPrintLinkReport(t *testing.T) {
+	report := &services.LinkCheckReport{
+		NotesScanned: 4,
+		LinksChecked: 6,
+		DeadCount:    2,
+		Notes: []services.NoteLinks{{
+			Title:  "Reading",
+			Folder: "Research",
+			DeadLinks: []services.DeadLink{
+				{URL: "https://gone.example/", Status: 404},
+				{URL: "https://down.example/", Error: "no such host"},
+			},
+		}},
+	}
+
+	var out bytes.Buffer
+	printLinkReport(&out, report)
+	want := "Research/Reading\n" +
+		"  https://gone.example/  HTTP 404\n" +
+		"  https://down.example/  no such host\n" +
+		"2 dead of 6 links in 4 notes\n"
+	if out.String() != want {
+		t.Errorf("unexpected report:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+// TestCheckLinksTool tests that the tool reports only the dead links
+func TestCheckLinksTool(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok" {
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer site.Close()
+
+	notesService := services.NewMemoryNotesService()
+	body := `<div><a href="` + site.URL + `/ok">fine</a> and ` + site.URL + `/missing</div>`
+	if _, err := notesService.CreateNote(context.Background(), "Sources", body, nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerCheckLinksTool(server, notesService, services.NewHTTPLinkChecker(0))
+	session := connectTestClient(t, server)
+
+	var report services.LinkCheckReport
+	text := firstText(callToolResult(t, session, "check_links", map[string]any{"timeout_seconds": 5}))
+	if err := json.Unmarshal([]byte(text), &report); err != nil {
+		t.Fatalf("check_links did not return JSON: %v\n%s", err, text)
+	}
+	if report.LinksChecked != 2 || report.DeadCount != 1 || len(report.Notes) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if dead := report.Notes[0].DeadLinks; len(dead) != 1 || dead[0].URL != site.URL+"/missing" || dead[0].Status != 404 {
+		t.Errorf("expected the missing page reported, got %+v", dead)
+	}
+}
@@ -0,0 +1,122 @@
+// ABOUTME: Cleanup command for reclaiming Apple Notes storage
+// ABOUTME: Reports and optionally empties Recently Deleted, and lists orphaned attachment files
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanupEmptyTrash bool
+	cleanupContainer  string
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Report and reclaim Apple Notes storage",
+	Long: `Reports the notes in Recently Deleted and the media files in the Notes group container that no note references.
+With --empty-trash, permanently deletes the notes in Recently Deleted and prints the space reclaimed from the container.
+Orphaned files are matched by name and only reported; review them before removing anything by hand.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		containerDir := cleanupContainer
+		if containerDir == "" {
+			dir, err := services.NotesContainerDir()
+			if err != nil {
+				return err
+			}
+			containerDir = dir
+		}
+
+		// Scanning every note's attachments can take a while, so each script uses the operation timeout
+		executor := newScriptExecutor(getOperationTimeout())
+		notesService := services.NewAppleNotesService(executor)
+
+		// Create context for a batch operation
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		out := cmd.OutOrStdout()
+
+		// Recently Deleted
+		trashed, err := notesService.ListRecentlyDeleted(ctx)
+		if err != nil {
+			return err
+		}
+		//nolint:errcheck // stdout write failure is non-critical
+		fmt.Fprintf(out, "Recently Deleted: %d notes\n", len(trashed))
+		for _, title := range trashed {
+			fmt.Fprintf(out, "  - %s\n", title) //nolint:errcheck // stdout write failure is non-critical
+		}
+
+		// Orphaned attachment files
+		orphans, err := notesService.FindOrphanedAttachments(ctx, containerDir)
+		if err != nil {
+			return err
+		}
+		printOrphans(out, orphans)
+
+		if !cleanupEmptyTrash || len(trashed) == 0 {
+			return nil
+		}
+
+		// Empty the trash and measure the container before and after
+		before, sizeErr := services.DirSize(containerDir)
+		deleted, err := notesService.EmptyRecentlyDeleted(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "\nEmptied Recently Deleted: %d notes permanently deleted\n", deleted) //nolint:errcheck // stdout write failure is non-critical
+
+		after, afterErr := services.DirSize(containerDir)
+		if sizeErr == nil && afterErr == nil {
+			//nolint:errcheck // stdout write failure is non-critical
+			fmt.Fprintf(out, "Reclaimed: %s (Notes may take a few minutes to purge deleted media)\n", formatBytes(before-after))
+		}
+		return nil
+	},
+}
+
+// printOrphans prints the orphaned attachment report with the total size
+func printOrphans(out io.Writer, orphans []services.OrphanedFile) {
+	var total int64
+	for _, orphan := range orphans {
+		total += orphan.Size
+	}
+
+	//nolint:errcheck // stdout write failure is non-critical
+	fmt.Fprintf(out, "\nOrphaned attachment files: %d (%s)\n", len(orphans), formatBytes(total))
+	for _, orphan := range orphans {
+		fmt.Fprintf(out, "  - %s (%s)\n", orphan.Path, formatBytes(orphan.Size)) //nolint:errcheck // stdout write failure is non-critical
+	}
+}
+
+// formatBytes renders a byte count using binary units, e.g. "1.5 MB"
+func formatBytes(n int64) string {
+	if n < 0 {
+		n = 0
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+
+	// Add flags
+	cleanupCmd.Flags().BoolVar(&cleanupEmptyTrash, "empty-trash", false, "Permanently delete the notes in Recently Deleted")
+	cleanupCmd.Flags().StringVar(&cleanupContainer, "container", "", "Notes group container to scan (default: ~/Library/Group Containers/group.com.apple.notes)")
+}
@@ -0,0 +1,41 @@
+// ABOUTME: Unit tests for the cleanup command
+// ABOUTME: Tests argument validation and byte formatting
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestCleanupCommandArgs tests that the cleanup command takes no positional arguments
+func TestCleanupCommandArgs(t *testing.T) {
+	rootCmd.SetArgs([]string{"cleanup", "extra"})
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	rootCmd.SetArgs([]string{})
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{-5, "0 B"},
+		{512, "512 B"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// ABOUTME: Copy and paste commands moving note text through the macOS clipboard
+// ABOUTME: copy puts a note's plain text or markdown on the clipboard; paste appends the clipboard's text to a note
+
+package cmd
+
+import (
+	"unicode/utf8"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
+	"github.com/spf13/cobra"
+)
+
+var copyMarkdown bool
+
+var copyCmd = &cobra.Command{
+	Use:   "copy <title>",
+	Short: "Copy a note's text to the clipboard",
+	Long: `Puts a note's plain text on the macOS clipboard with pbcopy, or its markdown with --markdown,
+ready to paste into a chat, an email, or another editor.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
+		format := services.ClipboardFormatText
+		if copyMarkdown {
+			format = services.ClipboardFormatMarkdown
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		text, err := services.CopyNoteToClipboard(ctx, notesService, services.NewPasteboardClipboard(0), title, format)
+		if err != nil {
+			return err
+		}
+
+		printSuccess(cmd.OutOrStdout(), "Copied %s (%d characters of %s)", title, utf8.RuneCountInString(text), format)
+		return nil
+	},
+}
+
+var pasteCmd = &cobra.Command{
+	Use:   "paste <title>",
+	Short: "Append the clipboard's text to a note",
+	Long: `Appends the text on the macOS clipboard (read with pbpaste) to the end of a note, one line
+per clipboard line. The note is only written if it hasn't changed while the text was added.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		text, err := services.PasteClipboardToNote(ctx, notesService, services.NewPasteboardClipboard(0), title)
+		if err != nil {
+			return err
+		}
+
+		printSuccess(cmd.OutOrStdout(), "Pasted %d characters into %s", utf8.RuneCountInString(text), title)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd, pasteCmd)
+
+	copyCmd.Flags().BoolVar(&copyMarkdown, "markdown", false, "Copy the note as markdown instead of plain text")
+}
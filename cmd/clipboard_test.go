@@ -0,0 +1,38 @@
+// ABOUTME: Unit tests for the copy and paste commands
+// ABOUTME: Tests CLI argument validation
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestClipboardCommandArgs tests that copy and paste require exactly one non-empty title
+func TestClipboardCommandArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "copy without a title", args: []string{"copy"}},
+		{name: "copy with two titles", args: []string{"copy", "title", "extra"}},
+		{name: "copy with an empty title", args: []string{"copy", "  "}},
+		{name: "paste without a title", args: []string{"paste"}},
+		{name: "paste with an empty title", args: []string{"paste", "  "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd.SetArgs(tt.args)
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+		})
+	}
+}
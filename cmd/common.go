@@ -5,6 +5,9 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log"
 	"os"
 	"strconv"
 	"time"
@@ -20,25 +23,247 @@ const (
 	commandTimeout = 30 * time.Second
 	// maxSearchResults limits search results to prevent timeouts with large result sets
 	maxSearchResults = 100
+	// transcriptionTimeout bounds a single audio transcription, which can take far longer than AppleScript calls
+	transcriptionTimeout = 5 * time.Minute
+	// defaultDeadlineDays is how far ahead get_upcoming_deadlines looks when no window is given
+	defaultDeadlineDays = 7
 )
 
+// timeoutEnvVar overrides the operation and script timeouts, in seconds
+const timeoutEnvVar = "NOTES_MCP_TIMEOUT"
+
+// Environment variables enabling optional integrations with other macOS apps
+const (
+	// remindersEnvVar enables the Apple Reminders integration for the MCP server
+	remindersEnvVar = "NOTES_MCP_ENABLE_REMINDERS"
+	// calendarEnvVar enables Apple Calendar context in the meeting-prep prompt
+	calendarEnvVar = "NOTES_MCP_ENABLE_CALENDAR"
+	// searchBackendEnvVar sets the default search backend ("applescript", "spotlight", or "index")
+	searchBackendEnvVar = "NOTES_MCP_SEARCH_BACKEND"
+	// shortcutsEnvVar lists operations ("pin", "tags", or "all") to run through macOS Shortcuts
+	shortcutsEnvVar = "NOTES_MCP_SHORTCUTS"
+	// concurrencyEnvVar sets how many per-note AppleScript calls multi-note operations run at once
+	concurrencyEnvVar = "NOTES_MCP_CONCURRENCY"
+	// maxBodySearchNotesEnvVar sets how many notes a body search may read through AppleScript (0: no limit)
+	maxBodySearchNotesEnvVar = "NOTES_MCP_MAX_BODY_SEARCH_NOTES"
+)
+
+// Environment variables configuring transcription of audio attachments
+const (
+	// whisperURLEnvVar is an OpenAI-compatible /audio/transcriptions URL
+	whisperURLEnvVar = "NOTES_MCP_WHISPER_URL"
+	// whisperAPIKeyEnvVar is the optional bearer token for the Whisper endpoint
+	whisperAPIKeyEnvVar = "NOTES_MCP_WHISPER_API_KEY"
+	// whisperModelEnvVar overrides the transcription model (default "whisper-1")
+	whisperModelEnvVar = "NOTES_MCP_WHISPER_MODEL"
+	// speechHelperEnvVar is the path of a helper that transcribes with the macOS Speech framework
+	speechHelperEnvVar = "NOTES_MCP_SPEECH_HELPER"
+)
+
+// Environment variables overriding the formats of title placeholders on create
+const (
+	// titleDateFormatEnvVar is the Go time layout for {{date}} (default "2006-01-02")
+	titleDateFormatEnvVar = "NOTES_MCP_TITLE_DATE_FORMAT"
+	// titleTimeFormatEnvVar is the Go time layout for {{time}} (default "15:04")
+	titleTimeFormatEnvVar = "NOTES_MCP_TITLE_TIME_FORMAT"
+	// titleWeekFormatEnvVar is the pattern for {{week}} using %G and %V (default "%G-W%V")
+	titleWeekFormatEnvVar = "NOTES_MCP_TITLE_WEEK_FORMAT"
+)
+
+// Output modes set by the persistent --quiet and --verbose flags
+var (
+	// quietOutput suppresses confirmation messages; results and errors are still printed
+	quietOutput bool
+	// verboseOutput reports script timings, retries, and fallbacks on stderr
+	verboseOutput bool
+)
+
+// verboseWriter receives --verbose diagnostics; tests may replace it
+var verboseWriter io.Writer = os.Stderr
+
+// noticeWriter receives notices that a command read a different note than the one named; tests may replace it
+var noticeWriter io.Writer = os.Stderr
+
+// printSuccess writes a confirmation line such as "Note created: X" unless --quiet is set
+// Command results (note content, listings, JSON) are printed directly, never through this.
+func printSuccess(w io.Writer, format string, args ...any) {
+	if quietOutput {
+		return
+	}
+	fmt.Fprintf(w, format+"\n", args...) //nolint:errcheck // stdout write failure is non-critical
+}
+
+// printVerbose writes a diagnostic line to stderr when --verbose is set
+func printVerbose(format string, args ...any) {
+	if !verboseOutput {
+		return
+	}
+	fmt.Fprintf(verboseWriter, "[verbose] "+format+"\n", args...) //nolint:errcheck // stderr write failure is non-critical
+}
+
+// withVerboseTrace routes service timings and decisions to printVerbose when --verbose is set
+func withVerboseTrace(ctx context.Context) context.Context {
+	if !verboseOutput {
+		return ctx
+	}
+	return services.WithTrace(ctx, printVerbose)
+}
+
+// withTitleMatchNotice reports on stderr when a read was answered by a note whose title only nearly matches
+func withTitleMatchNotice(ctx context.Context) context.Context {
+	return services.WithTitleMatchReport(ctx, func(requested, actual string) {
+		fmt.Fprintf(noticeWriter, "Note %q not found; using %q\n", requested, actual) //nolint:errcheck // stderr write failure is non-critical
+	})
+}
+
+// envEnabled reports whether a boolean environment variable is set to a true value
+func envEnabled(name string) bool {
+	enabled, err := strconv.ParseBool(os.Getenv(name))
+	return err == nil && enabled
+}
+
+// remindersEnabled reports whether the Reminders integration is enabled via NOTES_MCP_ENABLE_REMINDERS
+func remindersEnabled() bool {
+	return envEnabled(remindersEnvVar)
+}
+
+// calendarEnabled reports whether the Calendar integration is enabled via NOTES_MCP_ENABLE_CALENDAR
+func calendarEnabled() bool {
+	return envEnabled(calendarEnvVar)
+}
+
+// defaultSearchBackend returns the search backend to use when a request doesn't name one
+func defaultSearchBackend(requested string) string {
+	if requested != "" {
+		return requested
+	}
+	return os.Getenv(searchBackendEnvVar)
+}
+
+// transcriptionEnabled reports whether a Whisper endpoint or Speech helper is configured
+func transcriptionEnabled() bool {
+	return os.Getenv(whisperURLEnvVar) != "" || os.Getenv(speechHelperEnvVar) != ""
+}
+
 // getOperationTimeout returns the operation timeout, checking NOTES_MCP_TIMEOUT env var first
 func getOperationTimeout() time.Duration {
-	if timeoutStr := os.Getenv("NOTES_MCP_TIMEOUT"); timeoutStr != "" {
+	if timeout, ok := configuredTimeout(); ok {
+		return timeout
+	}
+	return commandTimeout
+}
+
+// getScriptTimeout returns the timeout for a single AppleScript invocation
+// NOTES_MCP_TIMEOUT applies to scripts too, so raising it helps slow individual calls; otherwise osascriptTimeout.
+func getScriptTimeout() time.Duration {
+	if timeout, ok := configuredTimeout(); ok {
+		return timeout
+	}
+	return osascriptTimeout
+}
+
+// configuredTimeout parses NOTES_MCP_TIMEOUT as a positive number of seconds
+func configuredTimeout() (time.Duration, bool) {
+	if timeoutStr := os.Getenv(timeoutEnvVar); timeoutStr != "" {
 		if seconds, err := strconv.Atoi(timeoutStr); err == nil && seconds > 0 {
-			return time.Duration(seconds) * time.Second
+			return time.Duration(seconds) * time.Second, true
 		}
 	}
-	return commandTimeout
+	return 0, false
 }
 
 // newNotesService creates an AppleNotesService with a configured OSAScriptExecutor
 func newNotesService() *services.AppleNotesService {
-	executor := services.NewOSAScriptExecutor(osascriptTimeout)
-	return services.NewAppleNotesService(executor)
+	executor := newScriptExecutor(getScriptTimeout())
+	printVerbose("script timeout %s", getScriptTimeout())
+	notesService := services.NewAppleNotesService(executor)
+	configureAppleNotesService(notesService)
+	return notesService
+}
+
+// configureAppleNotesService applies every environment setting of the AppleScript service
+// Both the CLI and the MCP provider path call it, so a new setting added here reaches both.
+func configureAppleNotesService(notesService *services.AppleNotesService) {
+	configureShortcuts(notesService)
+	configureTitleFormats(notesService)
+	configureTranscriber(notesService)
+	configureConcurrency(notesService)
+	configureBodySearchGuard(notesService)
+}
+
+// configureBodySearchGuard applies NOTES_MCP_MAX_BODY_SEARCH_NOTES, defaulting to DefaultMaxBodySearchNotes
+func configureBodySearchGuard(notesService *services.AppleNotesService) {
+	limit := services.DefaultMaxBodySearchNotes
+	if value := os.Getenv(maxBodySearchNotesEnvVar); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			log.Printf("Ignoring %s=%q: expected a number, or 0 for no limit", maxBodySearchNotesEnvVar, value)
+		} else {
+			limit = n
+		}
+	}
+	notesService.SetMaxBodySearchNotes(limit)
+}
+
+// configureConcurrency applies NOTES_MCP_CONCURRENCY; invalid values keep the default
+func configureConcurrency(notesService *services.AppleNotesService) {
+	value := os.Getenv(concurrencyEnvVar)
+	if value == "" {
+		return
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Ignoring %s=%q: expected a positive number", concurrencyEnvVar, value)
+		return
+	}
+	notesService.SetConcurrency(n)
+}
+
+// configureTranscriber sets up transcription, preferring a Whisper endpoint over the Speech helper
+func configureTranscriber(notesService *services.AppleNotesService) {
+	if endpoint := os.Getenv(whisperURLEnvVar); endpoint != "" {
+		notesService.SetTranscriber(services.NewWhisperTranscriber(endpoint,
+			os.Getenv(whisperAPIKeyEnvVar), os.Getenv(whisperModelEnvVar), transcriptionTimeout))
+		return
+	}
+	if helper := os.Getenv(speechHelperEnvVar); helper != "" {
+		notesService.SetTranscriber(services.NewSpeechHelperTranscriber(helper, transcriptionTimeout))
+	}
+}
+
+// configureTitleFormats applies NOTES_MCP_TITLE_*_FORMAT overrides for title placeholders
+func configureTitleFormats(notesService *services.AppleNotesService) {
+	notesService.SetTitleFormats(services.TitleFormats{
+		Date: os.Getenv(titleDateFormatEnvVar),
+		Time: os.Getenv(titleTimeFormatEnvVar),
+		Week: os.Getenv(titleWeekFormatEnvVar),
+	})
+}
+
+// configureShortcuts routes the operations listed in NOTES_MCP_SHORTCUTS through macOS Shortcuts
+// Unknown operations are logged and ignored so a typo never prevents startup
+func configureShortcuts(notesService *services.AppleNotesService) {
+	value := os.Getenv(shortcutsEnvVar)
+	if value == "" {
+		return
+	}
+
+	operations, err := services.ParseShortcutOperations(value)
+	if err != nil {
+		log.Printf("Ignoring %s: %v", shortcutsEnvVar, err)
+		return
+	}
+
+	notesService.UseShortcuts(services.NewShortcutsCLIRunner(osascriptTimeout), operations)
 }
 
 // newCommandContext creates a context with a timeout for command execution
 func newCommandContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), commandTimeout)
+	return context.WithTimeout(withTitleMatchNotice(withVerboseTrace(context.Background())), commandTimeout)
+}
+
+// newBatchCommandContext creates a cancellable context without an overall deadline
+// Batch commands run many AppleScript invocations; each one is still bounded by osascriptTimeout
+func newBatchCommandContext() (context.Context, context.CancelFunc) {
+	return context.WithCancel(withTitleMatchNotice(withVerboseTrace(context.Background())))
 }
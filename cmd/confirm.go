@@ -0,0 +1,106 @@
+// ABOUTME: Interactive confirmation of destructive MCP tool calls
+// ABOUTME: Uses MCP elicitation to ask the user before deleting, governed by NOTES_MCP_CONFIRM_DESTRUCTIVE
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// confirmDestructiveEnvVar selects how destructive tool calls are confirmed
+const confirmDestructiveEnvVar = "NOTES_MCP_CONFIRM_DESTRUCTIVE"
+
+// Values for NOTES_MCP_CONFIRM_DESTRUCTIVE
+const (
+	// confirmAsk asks the user through elicitation when the client supports it (default)
+	confirmAsk = "ask"
+	// confirmNever runs destructive actions without asking
+	confirmNever = "never"
+	// confirmAlwaysDeny refuses every destructive action
+	confirmAlwaysDeny = "always-deny"
+)
+
+// confirmDestructivePolicy returns the configured confirmation policy, defaulting to "ask"
+func confirmDestructivePolicy() string {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(confirmDestructiveEnvVar)))
+	switch value {
+	case "", confirmAsk:
+		return confirmAsk
+	case confirmNever, confirmAlwaysDeny:
+		return value
+	default:
+		log.Printf("Ignoring %s=%q (must be %s, %s, or %s); asking for confirmation",
+			confirmDestructiveEnvVar, value, confirmAsk, confirmNever, confirmAlwaysDeny)
+		return confirmAsk
+	}
+}
+
+// clientSupportsElicitation reports whether the session's client declared the elicitation capability
+func clientSupportsElicitation(session *mcp.ServerSession) bool {
+	if session == nil {
+		return false
+	}
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// confirmDestructive decides whether a destructive action may run
+// It returns nil to proceed, or a tool error result explaining why the action was not taken.
+// With the "ask" policy, clients without elicitation support proceed as before.
+func confirmDestructive(ctx context.Context, session *mcp.ServerSession, action string) *mcp.CallToolResult {
+	switch confirmDestructivePolicy() {
+	case confirmNever:
+		return nil
+	case confirmAlwaysDeny:
+		return refusedResult(fmt.Sprintf("Not allowed: %s. Destructive actions are disabled (%s=%s).",
+			action, confirmDestructiveEnvVar, confirmAlwaysDeny))
+	}
+
+	if !clientSupportsElicitation(session) {
+		return nil
+	}
+
+	result, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Message: fmt.Sprintf("Allow the assistant to %s? This cannot be undone from here.", action),
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"confirm": map[string]any{
+					"type":        "boolean",
+					"title":       "Confirm",
+					"description": fmt.Sprintf("Yes, %s", action),
+				},
+			},
+			"required": []string{"confirm"},
+		},
+	})
+	if err != nil {
+		return refusedResult(fmt.Sprintf("Not done: could not get confirmation to %s: %v", action, err))
+	}
+
+	if result.Action == "accept" {
+		if confirmed, _ := result.Content["confirm"].(bool); confirmed {
+			return nil
+		}
+	}
+
+	return refusedResult(fmt.Sprintf("Cancelled: the user did not confirm the request to %s.", action))
+}
+
+// refusedResult builds the tool error returned when a destructive action is not confirmed
+func refusedResult(message string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: message,
+			},
+		},
+		IsError: true,
+	}
+}
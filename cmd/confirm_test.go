@@ -0,0 +1,128 @@
+// ABOUTME: Tests for confirmation of destructive tool calls
+// ABOUTME: Drives delete_note over in-memory transports with and without elicitation support
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// callDeleteNote connects a client (with an optional elicitation handler) and calls delete_note
+func callDeleteNote(t *testing.T, elicit func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)) (*mcp.CallToolResult, bool) {
+	t.Helper()
+
+	deleted := false
+	mock := &mockNotesService{
+		deleteNote: func(ctx context.Context, title string) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerDeleteNoteTool(server, mock)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	defer serverSession.Close() //nolint:errcheck // test cleanup
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"},
+		&mcp.ClientOptions{ElicitationHandler: elicit})
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+	defer clientSession.Close() //nolint:errcheck // test cleanup
+
+	result, err := clientSession.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "delete_note",
+		Arguments: map[string]any{"title": "Old Note"},
+	})
+	if err != nil {
+		t.Fatalf("delete_note failed: %v", err)
+	}
+	return result, deleted
+}
+
+// answer returns an elicitation handler that responds with the given action and confirm value
+func answer(action string, confirm bool, asked *string) func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+	return func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		*asked = req.Params.Message
+		return &mcp.ElicitResult{Action: action, Content: map[string]any{"confirm": confirm}}, nil
+	}
+}
+
+// TestDeleteNoteConfirmation tests each policy against clients with and without elicitation
+func TestDeleteNoteConfirmation(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		action      string
+		confirm     bool
+		noElicit    bool
+		wantDeleted bool
+		wantAsked   bool
+	}{
+		{name: "ask and user confirms", policy: "ask", action: "accept", confirm: true, wantDeleted: true, wantAsked: true},
+		{name: "default policy asks", policy: "", action: "accept", confirm: true, wantDeleted: true, wantAsked: true},
+		{name: "ask and user unticks", policy: "ask", action: "accept", confirm: false, wantAsked: true},
+		{name: "ask and user declines", policy: "ask", action: "decline", wantAsked: true},
+		{name: "ask and user cancels", policy: "ask", action: "cancel", wantAsked: true},
+		{name: "ask without elicitation support", policy: "ask", noElicit: true, wantDeleted: true},
+		{name: "never asks", policy: "never", action: "decline", wantDeleted: true},
+		{name: "always deny", policy: "always-deny", action: "accept", confirm: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(confirmDestructiveEnvVar, tt.policy)
+
+			var asked string
+			handler := answer(tt.action, tt.confirm, &asked)
+			if tt.noElicit {
+				handler = nil
+			}
+
+			result, deleted := callDeleteNote(t, handler)
+
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if result.IsError == tt.wantDeleted {
+				t.Errorf("IsError = %v, want %v", result.IsError, !tt.wantDeleted)
+			}
+			if (asked != "") != tt.wantAsked {
+				t.Errorf("asked = %q, want asked %v", asked, tt.wantAsked)
+			}
+			if tt.wantAsked && !strings.Contains(asked, "Old Note") {
+				t.Errorf("confirmation message should name the note, got %q", asked)
+			}
+		})
+	}
+}
+
+// TestConfirmDestructivePolicy tests parsing of NOTES_MCP_CONFIRM_DESTRUCTIVE
+func TestConfirmDestructivePolicy(t *testing.T) {
+	tests := map[string]string{
+		"":            confirmAsk,
+		"ask":         confirmAsk,
+		"NEVER":       confirmNever,
+		"always-deny": confirmAlwaysDeny,
+		"sometimes":   confirmAsk,
+	}
+
+	for value, want := range tests {
+		t.Setenv(confirmDestructiveEnvVar, value)
+		if got := confirmDestructivePolicy(); got != want {
+			t.Errorf("confirmDestructivePolicy() with %q = %q, want %q", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// ABOUTME: Conflicts command listing same-titled notes whose bodies diverged, with a diff of each copy
+// ABOUTME: CLI counterpart of the resolve_conflicts tool; --resolve asks how to resolve each conflict in turn
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	conflictsFolder   string
+	conflictsResolve  bool
+	conflictsStrategy string
+	conflictsYes      bool
+	conflictsJSON     bool
+)
+
+// conflictChoices maps the answers to the --resolve question to strategies; anything else skips
+var conflictChoices = map[string]string{
+	"n": services.ConflictKeepNewest, "newest": services.ConflictKeepNewest,
+	"l": services.ConflictKeepLongest, "longest": services.ConflictKeepLongest,
+	"m": services.ConflictMerge, "merge": services.ConflictMerge,
+}
+
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Find and resolve conflicting copies of notes",
+	Long: `Lists titles shared by notes whose bodies diverged, as iCloud sync conflicts leave. Each
+conflict shows its copies newest first, with their modification date, length, and ID, and a line
+diff from the newest copy to each other one ("-" only in the newest, "+" only in that copy).
+
+--resolve asks for each conflict whether to keep the newest copy, keep the longest, merge (add
+the lines only other copies have to the newest), or skip it. --strategy newest|longest|merge
+resolves every conflict the same way, asking first unless --yes is given. The copies not kept
+are deleted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if conflictsStrategy != "" && !services.ValidConflictStrategy(conflictsStrategy) {
+			return fmt.Errorf("%w: --strategy must be %s, %s, or %s", services.ErrInvalidInput,
+				services.ConflictKeepNewest, services.ConflictKeepLongest, services.ConflictMerge)
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		conflicts, err := services.FindNoteConflicts(ctx, notesService, conflictsFolder)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		resolving := conflictsResolve || conflictsStrategy != ""
+		if conflictsJSON && !resolving {
+			output, err := json.MarshalIndent(conflicts, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format conflicts: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+
+		if len(conflicts) == 0 {
+			fmt.Fprintln(out, "No conflicting copies found.")
+			return nil
+		}
+		if !resolving {
+			for _, conflict := range conflicts {
+				printNoteConflict(out, conflict)
+			}
+			fmt.Fprintf(out, "%d conflicts\n", len(conflicts))
+			return nil
+		}
+
+		// One reader for every answer, so buffered input isn't lost between questions
+		in := bufio.NewReader(cmd.InOrStdin())
+		resolved := 0
+		for _, conflict := range conflicts {
+			printNoteConflict(out, conflict)
+			strategy := conflictsStrategy
+			switch {
+			case strategy == "":
+				fmt.Fprint(out, "Keep [n]ewest, keep [l]ongest, [m]erge, or [s]kip? ")
+				answer, _ := in.ReadString('\n')
+				strategy = conflictChoices[strings.ToLower(strings.TrimSpace(answer))]
+			case !conflictsYes && !confirmPrompt(in, out, fmt.Sprintf("Resolve %q (%s)?", conflict.Title, strategy)):
+				strategy = ""
+			}
+			if strategy == "" {
+				continue
+			}
+
+			resolution, err := services.ResolveNoteConflict(ctx, notesService, conflict, strategy)
+			if err != nil {
+				fmt.Fprintf(out, "skipped: %s (%v)\n", conflict.Title, err)
+				continue
+			}
+			resolved++
+			if resolution.MergedLines > 0 {
+				fmt.Fprintf(out, "merged %d lines into %s\n", resolution.MergedLines, resolution.Kept)
+			}
+		}
+		printSuccess(out, "Resolved %d of %d conflicts", resolved, len(conflicts))
+		return nil
+	},
+}
+
+// printNoteConflict writes a conflict's title, one line per copy, and each older copy's diff, indented
+func printNoteConflict(w io.Writer, conflict services.NoteConflict) {
+	fmt.Fprintln(w, conflict.Title) //nolint:errcheck // stdout write failure is non-critical
+	for i, dup := range conflict.Copies {
+		fmt.Fprintf(w, "  [%d] %s  %6d chars  %s  %s\n", i+1, dup.ModificationDate.Local().Format("2006-01-02 15:04"), //nolint:errcheck // stdout write failure is non-critical
+			dup.TextLength, dup.Folder, dup.ID)
+	}
+	for i, dup := range conflict.Copies {
+		if dup.Diff == "" {
+			continue
+		}
+		fmt.Fprintf(w, "  [1] -> [%d]\n", i+1) //nolint:errcheck // stdout write failure is non-critical
+		for _, line := range strings.Split(strings.TrimRight(dup.Diff, "\n"), "\n") {
+			fmt.Fprintln(w, "    "+line) //nolint:errcheck // stdout write failure is non-critical
+		}
+	}
+	fmt.Fprintln(w) //nolint:errcheck // stdout write failure is non-critical
+}
+
+func init() {
+	rootCmd.AddCommand(conflictsCmd)
+
+	conflictsCmd.Flags().StringVar(&conflictsFolder, "folder", "", "Only check notes in this folder")
+	conflictsCmd.Flags().BoolVar(&conflictsResolve, "resolve", false, "Ask how to resolve each conflict")
+	conflictsCmd.Flags().StringVar(&conflictsStrategy, "strategy", "", "Resolve every conflict this way: newest, longest, or merge")
+	conflictsCmd.Flags().BoolVar(&conflictsYes, "yes", false, "Resolve with --strategy without asking")
+	conflictsCmd.Flags().BoolVar(&conflictsJSON, "json", false, "Print the conflicts as JSON")
+}
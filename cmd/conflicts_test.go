@@ -0,0 +1,78 @@
+// ABOUTME: Unit tests for the conflicts command and the resolve_conflicts tool
+// ABOUTME: Tests the conflict listing and the tool's report, resolution, and strategy validation against the memory service
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestPrintNoteConflict tests the copy lines and the indented diff
+func TestPrintNoteConflict(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 12, 30, 0, 0, time.Local)
+	var out bytes.Buffer
+	printNoteConflict(&out, services.NoteConflict{Title: "Plan", Copies: []services.ConflictCopy{
+		{Note: services.Note{ID: "id-2", Folder: "Work", ModificationDate: modified}, TextLength: 20},
+		{Note: services.Note{ID: "id-1", Folder: "Work", ModificationDate: modified}, TextLength: 12, Diff: "-new\n+old\n"},
+	}})
+	want := "Plan\n" +
+		"  [1] 2024-05-01 12:30      20 chars  Work  id-2\n" +
+		"  [2] 2024-05-01 12:30      12 chars  Work  id-1\n" +
+		"  [1] -> [2]\n    -new\n    +old\n\n"
+	if out.String() != want {
+		t.Errorf("listing = %q, want %q", out.String(), want)
+	}
+}
+
+// TestResolveConflictsTool tests reporting, resolving one title, and rejecting unknown strategies
+func TestResolveConflictsTool(t *testing.T) {
+	t.Setenv(confirmDestructiveEnvVar, confirmNever)
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	for _, note := range []struct{ title, body string }{
+		{"Plan", "<div>Plan</div><div>Old line</div>"},
+		{"Plan", "<div>Plan</div><div>New line</div>"},
+		{"Trip", "<div>Trip</div><div>A</div>"},
+		{"Trip", "<div>Trip</div><div>B</div>"},
+	} {
+		if _, err := notesService.CreateNote(ctx, note.title, note.body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerResolveConflictsTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	var report conflictsResult
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "resolve_conflicts", map[string]any{}))), &report); err != nil {
+		t.Fatalf("resolve_conflicts did not return JSON: %v", err)
+	}
+	if len(report.Conflicts) != 2 || report.Resolutions != nil {
+		t.Fatalf("expected two unresolved conflicts, got %+v", report)
+	}
+
+	report = conflictsResult{}
+	result := callToolResult(t, session, "resolve_conflicts", map[string]any{"title": "plan", "strategy": "merge"})
+	if err := json.Unmarshal([]byte(firstText(result)), &report); err != nil {
+		t.Fatalf("resolve_conflicts did not return JSON: %v: %s", err, firstText(result))
+	}
+	if len(report.Resolutions) != 1 || report.Resolutions[0].MergedLines != 1 || len(report.Skipped) != 0 {
+		t.Fatalf("expected Plan merged, got %+v", report)
+	}
+	conflicts, err := services.FindNoteConflicts(ctx, notesService, "")
+	if err != nil || len(conflicts) != 1 || conflicts[0].Title != "Trip" {
+		t.Errorf("expected only Trip left, got %+v, %v", conflicts, err)
+	}
+
+	if result := callToolResult(t, session, "resolve_conflicts", map[string]any{"strategy": "oldest"}); !result.IsError {
+		t.Error("expected an unknown strategy to be rejected")
+	}
+}
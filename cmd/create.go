@@ -6,22 +6,35 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
 	"github.com/spf13/cobra"
 )
 
 var (
-	createTags []string
+	createTags        []string
+	createContentType string
+	createTemplate    bool
 )
 
 var createCmd = &cobra.Command{
 	Use:   "create <title> <content>",
 	Short: "Create a new note in Apple Notes",
-	Long:  `Creates a new note in Apple Notes with the specified title and content. Optionally add tags using the --tags flag.`,
-	Args:  cobra.ExactArgs(2),
+	Long: `Creates a new note in Apple Notes with the specified title and content. Optionally add tags using the --tags flag, and use --content-type markdown or html to write formatted content.
+With --template, {{date}}, {{time}}, and {{week}} in the title are expanded, e.g. "Standup {{date}}".`,
+	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		title := args[0]
 		content := args[1]
 
+		if err := validation.Check(validation.Title("title", title), validation.Content("content", content)); err != nil {
+			return err
+		}
+		content, err := services.FormatNoteBody(content, createContentType)
+		if err != nil {
+			return err
+		}
+
 		// Create service with real executor
 		notesService := newNotesService()
 
@@ -29,14 +42,17 @@ var createCmd = &cobra.Command{
 		ctx, cancel := newCommandContext()
 		defer cancel()
 
-		// Create the note
+		// Create the note, expanding title placeholders only when asked
+		if createTemplate {
+			ctx = services.WithTitleTemplate(ctx)
+		}
 		note, err := notesService.CreateNote(ctx, title, content, createTags)
 		if err != nil {
 			return fmt.Errorf("failed to create note: %w", err)
 		}
 
 		// Output success message
-		fmt.Printf("Note created: %s\n", note.Title)
+		printSuccess(cmd.OutOrStdout(), "Note created: %s", note.Title)
 		return nil
 	},
 }
@@ -46,4 +62,6 @@ func init() {
 
 	// Add flags
 	createCmd.Flags().StringSliceVar(&createTags, "tags", []string{}, "Comma-separated list of tags")
+	createCmd.Flags().StringVar(&createContentType, "content-type", services.ContentTypePlain, "How to read the content: plain, markdown, or html")
+	createCmd.Flags().BoolVar(&createTemplate, "template", false, "Expand {{date}}, {{time}}, and {{week}} in the title")
 }
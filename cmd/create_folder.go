@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/harper/notes-mcp/validation"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +22,13 @@ var createFolderCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
+		if err := validation.Check(
+			validation.FolderName("name", name),
+			validation.Optional(validation.FolderName, "parent", createFolderParent),
+		); err != nil {
+			return err
+		}
+
 		// Create service with real executor
 		notesService := newNotesService()
 
@@ -36,9 +44,9 @@ var createFolderCmd = &cobra.Command{
 
 		// Output success message
 		if createFolderParent != "" {
-			fmt.Printf("Folder created: %s (under %s)\n", name, createFolderParent)
+			printSuccess(cmd.OutOrStdout(), "Folder created: %s (under %s)", name, createFolderParent)
 		} else {
-			fmt.Printf("Folder created: %s\n", name)
+			printSuccess(cmd.OutOrStdout(), "Folder created: %s", name)
 		}
 		return nil
 	},
@@ -0,0 +1,63 @@
+// ABOUTME: Create reminder command for adding an Apple Reminders item from a note
+// ABOUTME: The reminder references its source note by title and ID
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reminderText string
+	reminderList string
+	reminderDue  string
+)
+
+var createReminderCmd = &cobra.Command{
+	Use:   "create-reminder <note-title>",
+	Short: "Create an Apple Reminders item from a note",
+	Long:  `Creates a reminder in Apple Reminders that references the given note. The reminder name defaults to the note title; use --text, --list, and --due to customize it.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noteTitle := args[0]
+
+		// Parse due date flag if provided
+		var dueDate *time.Time
+		if reminderDue != "" {
+			t, err := time.Parse("2006-01-02", reminderDue)
+			if err != nil {
+				return fmt.Errorf("invalid due date format (use YYYY-MM-DD): %w", err)
+			}
+			dueDate = &t
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		// Create the reminder
+		reminder, err := notesService.CreateReminder(ctx, noteTitle, reminderText, reminderList, dueDate)
+		if err != nil {
+			return fmt.Errorf("failed to create reminder: %w", err)
+		}
+
+		// Output success message
+		printSuccess(cmd.OutOrStdout(), "Reminder created: %s", reminder.Name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(createReminderCmd)
+
+	// Add flags
+	createReminderCmd.Flags().StringVar(&reminderText, "text", "", "Reminder text (default: the note title)")
+	createReminderCmd.Flags().StringVar(&reminderList, "list", "", "Reminders list name (default: the default list)")
+	createReminderCmd.Flags().StringVar(&reminderDue, "due", "", "Due date (YYYY-MM-DD)")
+}
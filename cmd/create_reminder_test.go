@@ -0,0 +1,82 @@
+// ABOUTME: Unit tests for the create-reminder and action-items commands
+// ABOUTME: Tests CLI argument parsing and the Reminders opt-in switch
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestReminderCommandArgs tests that reminder-related commands require exactly 1 argument
+func TestReminderCommandArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			name:        "create-reminder no arguments",
+			args:        []string{"create-reminder"},
+			expectError: true,
+		},
+		{
+			name:        "create-reminder invalid due date",
+			args:        []string{"create-reminder", "title", "--due=tomorrow"},
+			expectError: true,
+		},
+		{
+			name:        "action-items two arguments",
+			args:        []string{"action-items", "title", "extra"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up command
+			rootCmd.SetArgs(tt.args)
+
+			// Silence output
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			err := rootCmd.Execute()
+
+			if tt.expectError && err == nil {
+				t.Error("expected error but got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+			reminderDue = ""
+		})
+	}
+}
+
+// TestRemindersEnabled tests the NOTES_MCP_ENABLE_REMINDERS opt-in switch
+func TestRemindersEnabled(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{value: "", expected: false},
+		{value: "true", expected: true},
+		{value: "1", expected: true},
+		{value: "false", expected: false},
+		{value: "yes please", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(remindersEnvVar, tt.value)
+
+			if got := remindersEnabled(); got != tt.expected {
+				t.Errorf("remindersEnabled() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
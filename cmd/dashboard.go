@@ -0,0 +1,252 @@
+// ABOUTME: Dashboard command summarizing library growth, folders, edits, and attachment storage
+// ABOUTME: Prints a terminal dashboard from backup history or serves the same view as a local HTML page
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// dashboardBarWidth is the width of the longest bar in the terminal dashboard
+const dashboardBarWidth = 40
+
+var (
+	dashboardSource string
+	dashboardServe  string
+	dashboardJSON   bool
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Show statistics about the notes library over time",
+	Long: `Summarizes the library from the backups made with "notes-mcp backup": the number of notes at
+each backup, notes per folder, the most edited notes, and the storage used by attachments. Edits are
+counted per backup, so run backups regularly for a finer history.
+
+--serve 127.0.0.1:8765 serves the dashboard as an HTML page instead of printing it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := backupPassphrase()
+		if err != nil {
+			return err
+		}
+		store, err := services.OpenBackupStore(dashboardSource)
+		if err != nil {
+			return err
+		}
+		containerDir, err := services.NotesContainerDir()
+		if err != nil {
+			containerDir = ""
+		}
+
+		build := func(ctx context.Context) (*services.Dashboard, error) {
+			return services.BuildDashboard(ctx, store, passphrase, containerDir)
+		}
+
+		if dashboardServe != "" {
+			return serveDashboard(cmd.OutOrStdout(), dashboardServe, build)
+		}
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		dashboard, err := build(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if dashboardJSON {
+			output, err := json.MarshalIndent(dashboard, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format dashboard: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+		renderDashboardText(out, dashboard)
+		return nil
+	},
+}
+
+// renderDashboardText prints the dashboard as aligned sections with proportional bars
+func renderDashboardText(out io.Writer, dashboard *services.Dashboard) {
+	fmt.Fprintf(out, "Notes: %d across %d folders (%d backups)\n", dashboard.TotalNotes, len(dashboard.Folders), dashboard.Backups)
+	fmt.Fprintf(out, "Attachments: %d files, %s\n", dashboard.Attachments.Files, formatBytes(dashboard.Attachments.Bytes))
+
+	largest := 0
+	for _, point := range dashboard.Growth {
+		largest = max(largest, point.Notes)
+	}
+	fmt.Fprintln(out, "\nGrowth")
+	for _, point := range dashboard.Growth {
+		fmt.Fprintf(out, "  %s  %-*s %d\n", point.Time.Local().Format("2006-01-02 15:04"),
+			dashboardBarWidth, dashboardBar(point.Notes, largest), point.Notes)
+	}
+
+	largest = 0
+	nameWidth := 0
+	for _, folder := range dashboard.Folders {
+		largest = max(largest, folder.Notes)
+		nameWidth = max(nameWidth, len(folder.Folder))
+	}
+	fmt.Fprintln(out, "\nFolders")
+	for _, folder := range dashboard.Folders {
+		fmt.Fprintf(out, "  %-*s  %-*s %d\n", nameWidth, folder.Folder,
+			dashboardBarWidth, dashboardBar(folder.Notes, largest), folder.Notes)
+	}
+
+	fmt.Fprintln(out, "\nMost edited")
+	if len(dashboard.MostEdited) == 0 {
+		fmt.Fprintln(out, "  (no edits between backups yet)")
+	}
+	for _, edit := range dashboard.MostEdited {
+		fmt.Fprintf(out, "  %3d  %s (%s)\n", edit.Edits, edit.Title, edit.Folder)
+	}
+}
+
+// dashboardBar returns a bar whose length is value's share of largest
+func dashboardBar(value, largest int) string {
+	if largest <= 0 || value <= 0 {
+		return ""
+	}
+	return strings.Repeat("█", max(1, value*dashboardBarWidth/largest))
+}
+
+// dashboardPage renders the dashboard as a standalone HTML page
+var dashboardPage = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"bytes": formatBytes,
+	"pct": func(value, largest int) int {
+		if largest <= 0 {
+			return 0
+		}
+		return value * 100 / largest
+	},
+	"date": func(t time.Time) string { return t.Local().Format("2006-01-02 15:04") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Notes dashboard</title>
+<style>
+body { font-family: -apple-system, sans-serif; max-width: 52rem; margin: 2rem auto; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+td { padding: 0.2rem 0.5rem; vertical-align: middle; }
+td.bar { width: 60%; }
+td.bar div { background: #e2b93b; height: 0.9rem; }
+td.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Notes dashboard</h1>
+<p>{{.TotalNotes}} notes in {{len .Folders}} folders, from {{.Backups}} backups.
+Attachments: {{.Attachments.Files}} files, {{bytes .Attachments.Bytes}}.</p>
+<h2>Growth</h2>
+<table>
+{{- $max := .MaxGrowth}}{{range .Growth}}
+<tr><td>{{date .Time}}</td><td class="bar"><div style="width: {{pct .Notes $max}}%"></div></td><td class="num">{{.Notes}}</td></tr>
+{{- end}}
+</table>
+<h2>Folders</h2>
+<table>
+{{- $max := .MaxFolder}}{{range .Folders}}
+<tr><td>{{.Folder}}</td><td class="bar"><div style="width: {{pct .Notes $max}}%"></div></td><td class="num">{{.Notes}}</td></tr>
+{{- end}}
+</table>
+<h2>Most edited</h2>
+<table>
+{{- range .MostEdited}}
+<tr><td class="num">{{.Edits}}</td><td>{{.Title}}</td><td>{{.Folder}}</td></tr>
+{{- else}}
+<tr><td>No edits between backups yet.</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// dashboardView adds the bar scales the HTML template needs
+type dashboardView struct {
+	*services.Dashboard
+	MaxGrowth int
+	MaxFolder int
+}
+
+// renderDashboardHTML writes the dashboard page
+func renderDashboardHTML(out io.Writer, dashboard *services.Dashboard) error {
+	view := dashboardView{Dashboard: dashboard}
+	for _, point := range dashboard.Growth {
+		view.MaxGrowth = max(view.MaxGrowth, point.Notes)
+	}
+	for _, folder := range dashboard.Folders {
+		view.MaxFolder = max(view.MaxFolder, folder.Notes)
+	}
+	return dashboardPage.Execute(out, view)
+}
+
+// serveDashboard serves the dashboard page until interrupted, rebuilding it on every request
+func serveDashboard(out io.Writer, addr string, build func(context.Context) (*services.Dashboard, error)) error {
+	listener, err := listenGRPC(addr)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+			dashboard, err := build(r.Context())
+			if err != nil {
+				log.Printf("Failed to build dashboard: %v", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if err := renderDashboardHTML(w, dashboard); err != nil {
+				log.Printf("Failed to render dashboard: %v", err)
+			}
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Stop gracefully on interrupt
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-sigCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx) //nolint:errcheck,gosec // best-effort shutdown
+	}()
+
+	fmt.Fprintf(out, "Serving the dashboard on http://%s/ (Ctrl-C to stop)\n", listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("dashboard server failed: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(dashboardCmd)
+
+	dashboardCmd.Flags().StringVar(&dashboardSource, "from", "", "Backup location: a local directory or s3://bucket/path")
+	dashboardCmd.Flags().StringVar(&dashboardServe, "serve", "", "Serve the dashboard as an HTML page on this address, e.g. 127.0.0.1:8765")
+	dashboardCmd.Flags().BoolVar(&dashboardJSON, "json", false, "Print the statistics as JSON")
+	_ = dashboardCmd.MarkFlagRequired("from") //nolint:errcheck // the flag is defined just above
+}
@@ -0,0 +1,57 @@
+// ABOUTME: Tests for the dashboard command's terminal and HTML rendering
+// ABOUTME: Uses a fixed dashboard so output can be checked without backups
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+func testDashboard() *services.Dashboard {
+	return &services.Dashboard{
+		Backups:    2,
+		TotalNotes: 3,
+		Growth: []services.DashboardPoint{
+			{Time: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), Notes: 2},
+			{Time: time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC), Notes: 3},
+		},
+		Folders:     []services.DashboardFolder{{Folder: "Work", Notes: 2}, {Folder: "<Home>", Notes: 1}},
+		MostEdited:  []services.DashboardEdit{{Title: "Plan", Folder: "Work", Edits: 4}},
+		Attachments: services.AttachmentStorage{Files: 2, Bytes: 2048},
+	}
+}
+
+func TestRenderDashboardText(t *testing.T) {
+	var out bytes.Buffer
+	renderDashboardText(&out, testDashboard())
+	text := out.String()
+
+	for _, want := range []string{"Notes: 3 across 2 folders (2 backups)", "Attachments: 2 files, 2.0 KB", "Most edited", "4  Plan (Work)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in output:\n%s", want, text)
+		}
+	}
+	if !strings.Contains(text, strings.Repeat("█", dashboardBarWidth)+" 2") {
+		t.Errorf("expected the largest folder to get a full bar:\n%s", text)
+	}
+}
+
+func TestRenderDashboardHTML(t *testing.T) {
+	var out bytes.Buffer
+	if err := renderDashboardHTML(&out, testDashboard()); err != nil {
+		t.Fatalf("renderDashboardHTML failed: %v", err)
+	}
+	page := out.String()
+
+	if !strings.Contains(page, "&lt;Home&gt;") {
+		t.Error("expected folder names to be escaped")
+	}
+	if !strings.Contains(page, "width: 100%") || !strings.Contains(page, "width: 50%") {
+		t.Errorf("expected bars scaled to the largest folder:\n%s", page)
+	}
+}
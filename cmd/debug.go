@@ -0,0 +1,182 @@
+// ABOUTME: Debug command and settings for auditing the AppleScript the CLI and MCP server generate
+// ABOUTME: NOTES_MCP_DEBUG_SCRIPTS logs a hash (or the full text) of every script; "debug last-scripts" dumps the newest
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// Environment variables configuring the script audit
+const (
+	// debugScriptsEnvVar enables the script audit: "hash" records hashes, "full" the script text as well
+	debugScriptsEnvVar = "NOTES_MCP_DEBUG_SCRIPTS"
+	// scriptLogEnvVar overrides the file the newest script records are kept in
+	scriptLogEnvVar = "NOTES_MCP_SCRIPT_LOG"
+)
+
+// Script audit modes
+const (
+	debugScriptsHash = "hash"
+	debugScriptsFull = "full"
+)
+
+var (
+	lastScriptsCount int
+	lastScriptsJSON  bool
+)
+
+// scriptLogPath returns the script log file: NOTES_MCP_SCRIPT_LOG or ~/.config/notes-mcp/scripts.jsonl
+func scriptLogPath() string {
+	if path := os.Getenv(scriptLogEnvVar); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "scripts.jsonl")
+}
+
+// debugScriptsMode parses NOTES_MCP_DEBUG_SCRIPTS; "true" means hash, and unset or invalid disables the audit
+func debugScriptsMode() string {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(debugScriptsEnvVar)))
+	switch value {
+	case "":
+		return ""
+	case debugScriptsHash, "true", "1":
+		return debugScriptsHash
+	case debugScriptsFull:
+		return debugScriptsFull
+	}
+	log.Printf("Ignoring %s=%q: expected hash or full", debugScriptsEnvVar, value)
+	return ""
+}
+
+// openScriptLog opens the script log, returning nil when it can't be used
+func openScriptLog() *services.ScriptLog {
+	path := scriptLogPath()
+	if path == "" {
+		log.Printf("Script log disabled: no home directory")
+		return nil
+	}
+
+	// Hold the store lock before loading the key, and never fall back to plaintext when encryption was requested
+	if err := lockStoreForWriting(path); err != nil {
+		log.Printf("Script log disabled: %v", err)
+		return nil
+	}
+	encryptor, err := newStoreEncryptor()
+	if err != nil {
+		log.Printf("Script log disabled: %v", err)
+		return nil
+	}
+	return services.NewScriptLog(path, encryptor, services.DefaultScriptLogLimit)
+}
+
+// newScriptExecutor creates the osascript executor, audited when NOTES_MCP_DEBUG_SCRIPTS is set
+func newScriptExecutor(timeout time.Duration) services.ScriptExecutor {
+	executor := services.NewOSAScriptExecutor(timeout)
+	mode := debugScriptsMode()
+	if mode == "" {
+		return executor
+	}
+
+	var record func(services.ScriptRecord)
+	if scripts := openScriptLog(); scripts != nil {
+		record = func(entry services.ScriptRecord) {
+			if err := scripts.Append(entry); err != nil {
+				log.Printf("Failed to record script: %v", err)
+			}
+		}
+	}
+	return services.NewAuditingExecutor(executor, mode == debugScriptsFull, record)
+}
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Inspect what notes-mcp did behind the scenes",
+}
+
+var debugLastScriptsCmd = &cobra.Command{
+	Use:   "last-scripts",
+	Short: "Print the most recently generated AppleScripts",
+	Long: `Prints the newest AppleScripts the CLI and MCP server ran, with the operation that generated each,
+its SHA-256 hash, the request ID for MCP calls, how long it took, and any error.
+
+Scripts are recorded only while NOTES_MCP_DEBUG_SCRIPTS is set: "hash" records hashes alone, and "full"
+also records each script's text, which includes note titles and content. Records are kept in
+~/.config/notes-mcp/scripts.jsonl (or NOTES_MCP_SCRIPT_LOG), newest 200 or so.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lastScriptsCount <= 0 {
+			return fmt.Errorf("%w: --count must be positive", services.ErrInvalidInput)
+		}
+		scripts := openScriptLog()
+		if scripts == nil {
+			return fmt.Errorf("script log unavailable")
+		}
+		records, err := scripts.Last(lastScriptsCount)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if lastScriptsJSON {
+			output, err := json.MarshalIndent(records, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format scripts: %w", err)
+			}
+			fmt.Fprintln(out, string(output)) //nolint:errcheck // stdout write failure is non-critical
+			return nil
+		}
+		if len(records) == 0 {
+			fmt.Fprintf(out, "No scripts recorded. Set %s=hash or %s=full to record them.\n", debugScriptsEnvVar, debugScriptsEnvVar) //nolint:errcheck // stdout write failure is non-critical
+			return nil
+		}
+		printScriptRecords(out, records)
+		return nil
+	},
+}
+
+// printScriptRecords writes one header line per script, followed by its text when it was recorded
+func printScriptRecords(w io.Writer, records []services.ScriptRecord) {
+	for i, record := range records {
+		if i > 0 {
+			fmt.Fprintln(w) //nolint:errcheck // stdout write failure is non-critical
+		}
+		header := fmt.Sprintf("%s  %s  %s  %dms", record.Time.Local().Format("2006-01-02 15:04:05"),
+			record.Hash[:min(12, len(record.Hash))], record.Operation, record.DurationMS)
+		if record.RequestID != "" {
+			header += "  request " + record.RequestID
+		}
+		if record.Error != "" {
+			header += "  error: " + record.Error
+		}
+		fmt.Fprintln(w, header) //nolint:errcheck // stdout write failure is non-critical
+		if record.Script != "" {
+			for _, line := range strings.Split(strings.TrimRight(record.Script, "\n"), "\n") {
+				fmt.Fprintln(w, "    "+line) //nolint:errcheck // stdout write failure is non-critical
+			}
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(debugCmd)
+	debugCmd.AddCommand(debugLastScriptsCmd)
+
+	debugLastScriptsCmd.Flags().IntVarP(&lastScriptsCount, "count", "n", 10, "Number of scripts to print")
+	debugLastScriptsCmd.Flags().BoolVar(&lastScriptsJSON, "json", false, "Print the records as JSON")
+}
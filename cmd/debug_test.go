@@ -0,0 +1,48 @@
+// ABOUTME: Tests for the script audit settings and the debug last-scripts output
+// ABOUTME: Verifies NOTES_MCP_DEBUG_SCRIPTS parsing, executor wrapping, and how recorded scripts are printed
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+func TestNewScriptExecutor(t *testing.T) {
+	t.Setenv(scriptLogEnvVar, t.TempDir()+"/scripts.jsonl")
+	for value, want := range map[string]string{"": "", "hash": debugScriptsHash, "TRUE": debugScriptsHash, "full": debugScriptsFull, "loud": ""} {
+		t.Setenv(debugScriptsEnvVar, value)
+		if got := debugScriptsMode(); got != want {
+			t.Errorf("debugScriptsMode() with %q = %q, want %q", value, got, want)
+		}
+		_, audited := newScriptExecutor(time.Second).(*services.AuditingExecutor)
+		if audited != (want != "") {
+			t.Errorf("expected audited=%v with %q", want != "", value)
+		}
+	}
+}
+
+func TestPrintScriptRecords(t *testing.T) {
+	script := "tell application \"Notes\"\n\tget name of every folder\nend tell"
+	records := []services.ScriptRecord{
+		{Time: time.Now(), Operation: "AppleNotesService.ListFolders", Hash: services.ScriptHash(script), Script: script, DurationMS: 42, RequestID: "req-1"},
+		{Time: time.Now(), Operation: "AppleNotesService.GetNoteContent", Hash: services.ScriptHash("x"), Error: "timed out"},
+	}
+
+	var out bytes.Buffer
+	printScriptRecords(&out, records)
+	text := out.String()
+	for _, want := range []string{
+		records[0].Hash[:12] + "  AppleNotesService.ListFolders  42ms  request req-1",
+		"    \tget name of every folder",
+		"AppleNotesService.GetNoteContent  0ms  error: timed out",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in output:\n%s", want, text)
+		}
+	}
+}
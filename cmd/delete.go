@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/harper/notes-mcp/validation"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +18,10 @@ var deleteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		title := args[0]
 
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
 		// Create service with real executor
 		notesService := newNotesService()
 
@@ -31,7 +36,7 @@ var deleteCmd = &cobra.Command{
 		}
 
 		// Output success message
-		fmt.Printf("Note deleted: %s\n", title)
+		printSuccess(cmd.OutOrStdout(), "Note deleted: %s", title)
 		return nil
 	},
 }
@@ -0,0 +1,107 @@
+// ABOUTME: Empty command listing notes with no text beyond their title, and deleting them on request
+// ABOUTME: CLI counterpart of the find_empty_notes tool; --delete asks before removing anything unless --yes is given
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emptyFolder   string
+	emptyMaxChars int
+	emptyDelete   bool
+	emptyYes      bool
+	emptyJSON     bool
+)
+
+var emptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Find notes that are blank or nearly blank",
+	Long: `Lists notes whose text, not counting the title line, is shorter than --max-chars characters
+(default 10), such as blank notes left by sync glitches or interrupted agents. Notes with
+attachments are never listed, and locked notes are skipped.
+
+--delete removes the notes found after asking for confirmation; --yes skips the question for
+scripts. Notes that share their title with another note are never deleted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		notesService := newNotesService()
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		opts := services.EmptyNoteOptions{Folder: emptyFolder, MaxChars: emptyMaxChars}
+		notes, err := services.FindEmptyNotes(ctx, notesService, opts)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if emptyJSON && !emptyDelete {
+			output, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format notes: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+
+		if len(notes) == 0 {
+			fmt.Fprintln(out, "No empty notes found.")
+			return nil
+		}
+		for _, note := range notes {
+			fmt.Fprintln(out, joinFolderPath(note.Folder, note.Title))
+		}
+		if !emptyDelete {
+			fmt.Fprintf(out, "%d empty notes\n", len(notes))
+			return nil
+		}
+
+		if !emptyYes && !confirmPrompt(cmd.InOrStdin(), out, fmt.Sprintf("Delete these %d notes?", len(notes))) {
+			return fmt.Errorf("not deleted: confirmation declined")
+		}
+
+		report, err := services.DeleteEmptyNotes(ctx, notesService, notes)
+		if report != nil {
+			for _, skipped := range report.Skipped {
+				fmt.Fprintf(out, "skipped: %s (%s)\n", skipped.Title, skipped.Reason)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		printSuccess(out, "Deleted %d empty notes", len(report.Deleted))
+		return nil
+	},
+}
+
+// confirmPrompt asks a yes/no question, reading the answer from in; anything but y or yes is no
+func confirmPrompt(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question) //nolint:errcheck // stdout write failure is non-critical
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(emptyCmd)
+
+	emptyCmd.Flags().StringVar(&emptyFolder, "folder", "", "Only check notes in this folder")
+	emptyCmd.Flags().IntVar(&emptyMaxChars, "max-chars", services.DefaultEmptyNoteChars, "Notes with fewer characters of text than this are empty")
+	emptyCmd.Flags().BoolVar(&emptyDelete, "delete", false, "Delete the empty notes found, after confirmation")
+	emptyCmd.Flags().BoolVar(&emptyYes, "yes", false, "Delete without asking for confirmation")
+	emptyCmd.Flags().BoolVar(&emptyJSON, "json", false, "Print the notes found as JSON")
+}
@@ -0,0 +1,81 @@
+// ABOUTME: Unit tests for the empty command and the find_empty_notes tool
+// ABOUTME: Tests the confirmation prompt and the tool's find and delete report against the memory service
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestConfirmPrompt tests that only an explicit yes confirms
+func TestConfirmPrompt(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"y\n", true},
+		{" YES \n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if got := confirmPrompt(strings.NewReader(tt.answer), &out, "Delete?"); got != tt.want {
+			t.Errorf("confirmPrompt(%q) = %v, want %v", tt.answer, got, tt.want)
+		}
+		if out.String() != "Delete? [y/N] " {
+			t.Errorf("unexpected prompt %q", out.String())
+		}
+	}
+}
+
+// TestFindEmptyNotesTool tests listing and deleting empty notes through the tool
+func TestFindEmptyNotesTool(t *testing.T) {
+	t.Setenv(confirmDestructiveEnvVar, confirmNever)
+	notesService := services.NewMemoryNotesService()
+	for title, body := range map[string]string{"Blank": "<div><br></div>", "Plan": "<div>Ship the release on Friday</div>"} {
+		if _, err := notesService.CreateNote(context.Background(), title, body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerFindEmptyNotesTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	var report emptyNotesResult
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "find_empty_notes", map[string]any{}))), &report); err != nil {
+		t.Fatalf("find_empty_notes did not return JSON: %v", err)
+	}
+	if report.MaxChars != services.DefaultEmptyNoteChars || len(report.Notes) != 1 || report.Notes[0].Title != "Blank" || report.Deletion != nil {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	report = emptyNotesResult{}
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "find_empty_notes", map[string]any{"delete": true}))), &report); err != nil {
+		t.Fatalf("find_empty_notes did not return JSON: %v", err)
+	}
+	if report.Deletion == nil || len(report.Deletion.Deleted) != 1 {
+		t.Fatalf("expected Blank deleted, got %+v", report.Deletion)
+	}
+	if _, err := notesService.GetNoteMetadata(context.Background(), "Plan"); err != nil {
+		t.Errorf("expected Plan to survive, got %v", err)
+	}
+
+	t.Setenv(confirmDestructiveEnvVar, confirmAlwaysDeny)
+	if _, err := notesService.CreateNote(context.Background(), "Blank again", "", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if result := callToolResult(t, session, "find_empty_notes", map[string]any{"delete": true}); !result.IsError {
+		t.Error("expected deletion refused when destructive actions are denied")
+	}
+}
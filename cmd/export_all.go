@@ -0,0 +1,156 @@
+// ABOUTME: Export-all command writing the notes library to a directory of markdown files
+// ABOUTME: --include and --exclude globs on folder paths and titles pick notes before any body is read
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportAllInclude []string
+	exportAllExclude []string
+	exportAllDryRun  bool
+	exportAllResume  bool
+	exportAllSince   string
+)
+
+// exportSinceLast is the --since value that means "since the previous export"
+const exportSinceLast = "last"
+
+// parseExportSince parses --since: "last", an RFC 3339 timestamp, or a local YYYY-MM-DD date
+func parseExportSince(value string) (opts services.ExportAllOptions, err error) {
+	switch {
+	case value == "":
+	case value == exportSinceLast:
+		opts.SinceLast = true
+	default:
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if since, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+				return opts, fmt.Errorf("%w: --since must be 'last', an RFC 3339 timestamp, or YYYY-MM-DD", services.ErrInvalidInput)
+			}
+		}
+		opts.Since = &since
+	}
+	return opts, nil
+}
+
+var exportAllCmd = &cobra.Command{
+	Use:   "export-all <dir>",
+	Short: "Export every note to a directory of markdown files",
+	Long: `Exports the notes library as markdown, one file per note, in subdirectories mirroring the folder
+hierarchy. File names come from note titles with emoji and path characters removed; notes whose
+names collide in a folder are suffixed -2, -3, and so on.
+
+--include and --exclude (both repeatable) are globs matched against "<folder path>/<title>", case
+insensitively: "*" and "?" stay within one segment and "**" spans folders. A pattern matching a
+folder covers every note below it. For example:
+
+  notes-mcp export-all ~/Export --exclude 'Personal/**' --include 'Work/2024*'
+
+Patterns are applied to the folder hierarchy and note list before any note is read. Locked notes are skipped.
+
+Progress is checkpointed to .notes-mcp-export.json in the export directory. If a run stops partway,
+run it again with --resume to skip the notes already exported and unchanged since.
+
+--since exports only notes modified at or after a time (RFC 3339 or YYYY-MM-DD), leaving earlier
+files in place. --since last uses the start of the previous complete export, so a nightly
+
+  notes-mcp export-all ~/Export --since last
+
+only reads what changed. The first such run, with no previous export, exports everything.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := parseExportSince(exportAllSince)
+		if err != nil {
+			return err
+		}
+
+		_, notesService, err := newProviderNotesService()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		filter := services.ExportFilter{Include: exportAllInclude, Exclude: exportAllExclude}
+		out := cmd.OutOrStdout()
+
+		if exportAllDryRun {
+			candidates, err := services.SelectExportNotes(ctx, notesService, filter)
+			if err != nil {
+				return err
+			}
+			since := opts.Since
+			if opts.SinceLast {
+				manifest, err := services.LoadExportManifest(args[0])
+				if err != nil {
+					return err
+				}
+				if manifest != nil {
+					since = manifest.LastExport
+				}
+			}
+			count := 0
+			for _, candidate := range candidates {
+				if since != nil && candidate.Note.ModificationDate.Before(*since) {
+					continue
+				}
+				fmt.Fprintf(out, "would export: %s\n", joinFolderPath(candidate.FolderPath, candidate.Note.Title))
+				count++
+			}
+			fmt.Fprintf(out, "%d notes would be exported\n", count)
+			return nil
+		}
+
+		opts.Filter, opts.Resume = filter, exportAllResume
+		report, err := services.ExportAllNotes(ctx, notesService, args[0], opts, time.Now())
+		if report != nil {
+			for _, skipped := range report.Skipped {
+				fmt.Fprintf(out, "skipped: %s (%s)\n", skipped.Title, skipped.Reason)
+			}
+		}
+		if err != nil {
+			if report != nil && len(report.Exported)+report.Resumed > 0 {
+				return fmt.Errorf("export failed after %d notes; run again with --resume to continue: %w",
+					len(report.Exported)+report.Resumed, err)
+			}
+			return fmt.Errorf("export failed: %w", err)
+		}
+
+		summary := fmt.Sprintf("Exported %d notes to %s", len(report.Exported), report.Dir)
+		if report.Resumed > 0 {
+			summary += fmt.Sprintf(", %d already exported", report.Resumed)
+		}
+		if report.Unchanged > 0 {
+			summary += fmt.Sprintf(", %d unchanged", report.Unchanged)
+		}
+		printSuccess(out, "%s", summary)
+		return nil
+	},
+}
+
+// joinFolderPath returns a note's "<folder path>/<title>" as export patterns see it
+func joinFolderPath(folderPath, title string) string {
+	if folderPath == "" {
+		return title
+	}
+	return folderPath + "/" + title
+}
+
+func init() {
+	rootCmd.AddCommand(exportAllCmd)
+
+	// Add flags
+	exportAllCmd.Flags().StringArrayVar(&exportAllInclude, "include", nil, "Only export notes matching this glob (repeatable)")
+	exportAllCmd.Flags().StringArrayVar(&exportAllExclude, "exclude", nil, "Skip notes matching this glob (repeatable)")
+	exportAllCmd.Flags().BoolVar(&exportAllDryRun, "dry-run", false, "List the notes that would be exported without writing files")
+	exportAllCmd.Flags().BoolVar(&exportAllResume, "resume", false, "Continue from the checkpoint of an interrupted export")
+	exportAllCmd.Flags().StringVar(&exportAllSince, "since", "", "Only export notes modified since this time (RFC 3339, YYYY-MM-DD, or 'last')")
+}
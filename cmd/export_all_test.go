@@ -0,0 +1,38 @@
+// ABOUTME: Tests for the export-all command
+// ABOUTME: Covers parsing --since as 'last', a timestamp, or a date
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+func TestParseExportSince(t *testing.T) {
+	opts, err := parseExportSince("")
+	if err != nil || opts.Since != nil || opts.SinceLast {
+		t.Errorf("expected no since filter, got %+v, %v", opts, err)
+	}
+
+	opts, err = parseExportSince("last")
+	if err != nil || !opts.SinceLast {
+		t.Errorf("expected SinceLast, got %+v, %v", opts, err)
+	}
+
+	opts, err = parseExportSince("2024-03-01T09:30:00Z")
+	if err != nil || opts.Since == nil || !opts.Since.Equal(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected an RFC 3339 time, got %+v, %v", opts, err)
+	}
+
+	opts, err = parseExportSince("2024-03-01")
+	if err != nil || opts.Since == nil || !opts.Since.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("expected local midnight, got %+v, %v", opts, err)
+	}
+
+	if _, err := parseExportSince("yesterday"); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected an invalid --since to be rejected, got %v", err)
+	}
+}
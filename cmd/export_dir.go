@@ -0,0 +1,46 @@
+// ABOUTME: Export root for files the MCP server writes to disk at a client's request
+// ABOUTME: Confines get_attachment_content's copy_to_dir to NOTES_MCP_EXPORT_DIR so clients can't write anywhere
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// exportDirEnvVar names the directory MCP clients may have files copied into; unset refuses copies
+const exportDirEnvVar = "NOTES_MCP_EXPORT_DIR"
+
+// resolveExportDir returns the existing directory dir names inside the export root
+// Relative names are taken from the root; absolute ones must lie under it once symlinks are resolved.
+func resolveExportDir(dir string) (string, error) {
+	root := os.Getenv(exportDirEnvVar)
+	if root == "" {
+		return "", fmt.Errorf("%w: copying to a directory needs %s set to the directory files may be copied into",
+			services.ErrInvalidInput, exportDirEnvVar)
+	}
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", services.ErrInvalidInput, exportDirEnvVar, err)
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", services.ErrInvalidInput, exportDirEnvVar, err)
+	}
+
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("%w: destination %q is not a directory", services.ErrInvalidInput, dir)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: destination %q is outside %s (%s)", services.ErrInvalidInput, dir, exportDirEnvVar, root)
+	}
+	return resolved, nil
+}
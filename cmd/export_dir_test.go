@@ -0,0 +1,43 @@
+// ABOUTME: Tests for the export root confining copy_to_dir
+// ABOUTME: Covers relative and absolute destinations, escapes through ".." and symlinks, and an unset root
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+func TestResolveExportDir(t *testing.T) {
+	t.Setenv(exportDirEnvVar, "")
+	if _, err := resolveExportDir("out"); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected an unset export root to refuse copies, got %v", err)
+	}
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	t.Setenv(exportDirEnvVar, root)
+	if err := os.Mkdir(filepath.Join(root, "out"), 0o700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+	resolvedRoot, _ := filepath.EvalSymlinks(root)
+
+	for _, dir := range []string{"out", filepath.Join(root, "out")} {
+		got, err := resolveExportDir(dir)
+		if err != nil || got != filepath.Join(resolvedRoot, "out") {
+			t.Errorf("resolveExportDir(%q) = %q, %v", dir, got, err)
+		}
+	}
+	for _, dir := range []string{outside, "../" + filepath.Base(outside), "escape", "missing"} {
+		if got, err := resolveExportDir(dir); !errors.Is(err, services.ErrInvalidInput) {
+			t.Errorf("expected %q to be refused, got %q, %v", dir, got, err)
+		}
+	}
+}
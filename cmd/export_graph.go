@@ -0,0 +1,81 @@
+// ABOUTME: Export-graph command writing the graph of note links and folders as Graphviz DOT or JSON
+// ABOUTME: CLI counterpart of the export_graph tool, for visualizing how notes interconnect
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// Note graph formats
+const (
+	graphFormatDOT  = "dot"
+	graphFormatJSON = "json"
+)
+
+var (
+	exportGraphFormat string
+	exportGraphFolder string
+)
+
+// renderNoteGraph formats a note graph as DOT or indented JSON
+func renderNoteGraph(graph *services.NoteGraph, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case graphFormatDOT:
+		return graph.DOT(), nil
+	case graphFormatJSON:
+		output, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to format note graph: %w", err)
+		}
+		return string(output) + "\n", nil
+	}
+	return "", fmt.Errorf("%w: unknown graph format %q, use dot or json", services.ErrInvalidInput, format)
+}
+
+var exportGraphCmd = &cobra.Command{
+	Use:   "export-graph",
+	Short: "Export the graph of links between notes",
+	Long: `Reads every note and writes a graph with a node per note and per folder. Link edges point
+from a note to each note it links to, so a note's incoming edges are its backlinks; folder edges
+join each note to its folder, so notes sharing a folder share a node.
+
+--format dot (the default) writes Graphviz DOT, ready for "dot -Tsvg"; --format json writes the
+nodes, with their link and backlink counts, and the edges. At most 500 notes are read.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check the format before reading every note
+		if _, err := renderNoteGraph(&services.NoteGraph{}, exportGraphFormat); err != nil {
+			return err
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		graph, err := services.BuildNoteGraph(ctx, notesService, services.GraphOptions{Folder: exportGraphFolder})
+		if err != nil {
+			return err
+		}
+
+		output, err := renderNoteGraph(graph, exportGraphFormat)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), output) //nolint:errcheck // stdout write failure is non-critical
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportGraphCmd)
+
+	exportGraphCmd.Flags().StringVar(&exportGraphFormat, "format", graphFormatDOT, "Output format: dot or json")
+	exportGraphCmd.Flags().StringVar(&exportGraphFolder, "folder", "", "Only include notes in this folder")
+}
@@ -0,0 +1,66 @@
+// ABOUTME: Unit tests for the export-graph command and the export_graph tool
+// ABOUTME: Tests format selection and the tool's JSON and DOT graphs against the memory service
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestRenderNoteGraph tests both formats and the rejection of unknown ones
+func TestRenderNoteGraph(t *testing.T) {
+	graph := &services.NoteGraph{
+		Nodes: []services.GraphNode{{ID: "a", Kind: services.GraphNodeNote, Label: "A"}},
+		Edges: []services.GraphEdge{},
+	}
+	if out, err := renderNoteGraph(graph, "DOT"); err != nil || !strings.HasPrefix(out, "digraph notes {") {
+		t.Errorf("renderNoteGraph(dot) = %q, %v", out, err)
+	}
+	if out, err := renderNoteGraph(graph, "json"); err != nil || !strings.Contains(out, `"label": "A"`) {
+		t.Errorf("renderNoteGraph(json) = %q, %v", out, err)
+	}
+	if _, err := renderNoteGraph(graph, "svg"); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for svg, got %v", err)
+	}
+}
+
+// TestExportGraphTool tests the default JSON graph, DOT output, and format validation
+func TestExportGraphTool(t *testing.T) {
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	if _, err := notesService.CreateNote(ctx, "Hub", "<div>Start here</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if _, err := notesService.CreateNote(ctx, "Spoke", `<div><a href="applenotes:note/1">Hub</a></div>`, nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerExportGraphTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	var graph services.NoteGraph
+	result := callToolResult(t, session, "export_graph", map[string]any{})
+	if err := json.Unmarshal([]byte(firstText(result)), &graph); err != nil {
+		t.Fatalf("export_graph did not return JSON: %v: %s", err, firstText(result))
+	}
+	if graph.NotesScanned != 2 || len(graph.Nodes) != 3 || len(graph.Edges) != 3 {
+		t.Errorf("expected 2 notes, a folder, a link, and 2 folder edges, got %+v", graph)
+	}
+
+	result = callToolResult(t, session, "export_graph", map[string]any{"format": "dot"})
+	if text := firstText(result); result.IsError || !strings.Contains(text, `-> "memory://note/1";`) {
+		t.Errorf("expected a DOT edge to Hub, got %s", text)
+	}
+
+	if result := callToolResult(t, session, "export_graph", map[string]any{"format": "png"}); !result.IsError {
+		t.Error("expected an unknown format to be rejected")
+	}
+}
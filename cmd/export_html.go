@@ -0,0 +1,53 @@
+// ABOUTME: Export HTML command for saving notes as standalone HTML documents
+// ABOUTME: Keeps the note's own markup and inlines attachment images so design-heavy notes survive export
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var exportHTMLOutput string
+
+var exportHTMLCmd = &cobra.Command{
+	Use:   "export-html <note-title>",
+	Short: "Export a note to a standalone HTML file",
+	Long: `Exports a note's HTML body wrapped in a minimal standalone document, with images from the note's
+attachments embedded as base64 so the file opens anywhere. Prints to stdout unless --output is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noteTitle := args[0]
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		doc, err := notesService.ExportNoteHTML(ctx, noteTitle)
+		if err != nil {
+			return err
+		}
+
+		if exportHTMLOutput == "" {
+			fmt.Print(doc)
+			return nil
+		}
+		if err := os.WriteFile(exportHTMLOutput, []byte(doc), 0o600); err != nil {
+			return fmt.Errorf("failed to write HTML file: %w", err)
+		}
+		fmt.Println(exportHTMLOutput)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportHTMLCmd)
+
+	// Add flags
+	exportHTMLCmd.Flags().StringVarP(&exportHTMLOutput, "output", "o", "", "Path of the HTML file to write (default: stdout)")
+}
@@ -0,0 +1,24 @@
+// ABOUTME: Unit tests for the export-html command
+// ABOUTME: Tests argument validation
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestExportHTMLCommandArgs tests that exactly one note title is required
+func TestExportHTMLCommandArgs(t *testing.T) {
+	for _, args := range [][]string{{"export-html"}, {"export-html", "title", "extra"}} {
+		rootCmd.SetArgs(args)
+		rootCmd.SetOut(io.Discard)
+		rootCmd.SetErr(io.Discard)
+
+		if err := rootCmd.Execute(); err == nil {
+			t.Errorf("%v: expected error but got nil", args)
+		}
+
+		rootCmd.SetArgs([]string{})
+	}
+}
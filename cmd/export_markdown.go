@@ -6,13 +6,26 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/harper/notes-mcp/services"
 	"github.com/spf13/cobra"
 )
 
+// Markdown export formats
+const (
+	markdownFormatStandard = "markdown"
+	markdownFormatObsidian = "obsidian"
+)
+
+var (
+	exportMarkdownFormat    string
+	exportMarkdownAssetsDir string
+	exportMarkdownImages    string
+)
+
 var exportMarkdownCmd = &cobra.Command{
 	Use:   "export-markdown <note-title>",
 	Short: "Export a note to markdown format",
-	Long:  `Exports a note from Apple Notes to markdown format, converting HTML content to markdown syntax.`,
+	Long:  `Exports a note from Apple Notes to markdown format, converting HTML content to markdown syntax. Use --format=obsidian for YAML front matter, [[wikilinks]], and attachments copied into --assets-dir with ![[...]] embeds. Images in the standard format are attachment:<name> placeholders unless --images=data inlines them or --images=files copies them into --assets-dir.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		noteTitle := args[0]
@@ -24,8 +37,18 @@ var exportMarkdownCmd = &cobra.Command{
 		ctx, cancel := newCommandContext()
 		defer cancel()
 
-		// Export to markdown
-		markdown, err := notesService.ExportNoteMarkdown(ctx, noteTitle)
+		// Export to markdown in the requested format
+		var markdown string
+		var err error
+		switch exportMarkdownFormat {
+		case markdownFormatStandard:
+			markdown, err = notesService.ExportNoteMarkdownWithOptions(ctx, noteTitle,
+				services.MarkdownExportOptions{Images: exportMarkdownImages, AssetsDir: exportMarkdownAssetsDir})
+		case markdownFormatObsidian:
+			markdown, err = notesService.ExportNoteObsidian(ctx, noteTitle, exportMarkdownAssetsDir)
+		default:
+			return fmt.Errorf("invalid format %q (must be '%s' or '%s')", exportMarkdownFormat, markdownFormatStandard, markdownFormatObsidian)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to export note to markdown: %w", err)
 		}
@@ -38,4 +61,9 @@ var exportMarkdownCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(exportMarkdownCmd)
+
+	// Add flags
+	exportMarkdownCmd.Flags().StringVar(&exportMarkdownFormat, "format", markdownFormatStandard, "Markdown flavor: markdown or obsidian")
+	exportMarkdownCmd.Flags().StringVar(&exportMarkdownAssetsDir, "assets-dir", "assets", "Directory to copy attachments into (obsidian format, or --images=files)")
+	exportMarkdownCmd.Flags().StringVar(&exportMarkdownImages, "images", services.MarkdownImagesPlaceholder, "How to export images: placeholder, data, or files (markdown format only)")
 }
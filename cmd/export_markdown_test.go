@@ -0,0 +1,53 @@
+// ABOUTME: Unit tests for the export-markdown command
+// ABOUTME: Tests CLI argument parsing and format flag validation
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestExportMarkdownCommandArgs tests argument and --format validation
+func TestExportMarkdownCommandArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			name:        "no arguments",
+			args:        []string{"export-markdown"},
+			expectError: true,
+		},
+		{
+			name:        "unknown format",
+			args:        []string{"export-markdown", "title", "--format=docx"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up command
+			rootCmd.SetArgs(tt.args)
+
+			// Silence output
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			err := rootCmd.Execute()
+
+			if tt.expectError && err == nil {
+				t.Error("expected error but got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+			exportMarkdownFormat = markdownFormatStandard
+		})
+	}
+}
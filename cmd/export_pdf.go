@@ -0,0 +1,62 @@
+// ABOUTME: Export PDF command for rendering notes to PDF files
+// ABOUTME: Uses the macOS printing pipeline so layout and inline images survive export
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var exportPDFOutput string
+
+var exportPDFCmd = &cobra.Command{
+	Use:   "export-pdf <note-title>",
+	Short: "Export a note to a PDF file",
+	Long: `Renders a note's HTML, including inline images, to PDF through the macOS printing pipeline (cupsfilter).
+Unlike markdown export, this keeps the note's layout. Writes to --output, defaulting to "<note-title>.pdf".`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noteTitle := args[0]
+
+		outputPath := exportPDFOutput
+		if outputPath == "" {
+			outputPath = pdfFileName(noteTitle)
+		}
+		if !strings.EqualFold(filepath.Ext(outputPath), ".pdf") {
+			return fmt.Errorf("%w: output path must end in .pdf", services.ErrInvalidInput)
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		// Render the note
+		renderer := services.NewCUPSFilterRenderer(getOperationTimeout())
+		if err := notesService.ExportNotePDF(ctx, noteTitle, outputPath, renderer); err != nil {
+			return err
+		}
+
+		fmt.Println(outputPath)
+		return nil
+	},
+}
+
+// pdfFileName derives a safe file name from a note title
+func pdfFileName(title string) string {
+	return services.SanitizeFileName(title) + ".pdf"
+}
+
+func init() {
+	rootCmd.AddCommand(exportPDFCmd)
+
+	// Add flags
+	exportPDFCmd.Flags().StringVarP(&exportPDFOutput, "output", "o", "", "Path of the PDF to write (default: <note-title>.pdf)")
+}
@@ -0,0 +1,56 @@
+// ABOUTME: Unit tests for the export-pdf command
+// ABOUTME: Tests argument validation and output file naming
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestExportPDFCommandArgs tests argument and flag validation
+func TestExportPDFCommandArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no arguments", args: []string{"export-pdf"}},
+		{name: "two arguments", args: []string{"export-pdf", "title", "extra"}},
+		{name: "non-pdf output", args: []string{"export-pdf", "title", "--output", "note.html"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd.SetArgs(tt.args)
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+			exportPDFOutput = ""
+		})
+	}
+}
+
+// TestPDFFileName tests that note titles become safe file names
+func TestPDFFileName(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Design Doc", want: "Design Doc.pdf"},
+		{title: "Q1/Q2: Plans?", want: "Q1-Q2- Plans.pdf"},
+		{title: "🚀 Launch ✨", want: "Launch.pdf"},
+		{title: "   ", want: "note.pdf"},
+	}
+
+	for _, tt := range tests {
+		if got := pdfFileName(tt.title); got != tt.want {
+			t.Errorf("pdfFileName(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
@@ -1,5 +1,5 @@
 // ABOUTME: Folder hierarchy command for displaying folder tree in Apple Notes
-// ABOUTME: Returns nested folder structure with note counts
+// ABOUTME: Returns nested folder structure with note counts, optionally sorted or flattened to paths
 
 package cmd
 
@@ -7,15 +7,27 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/harper/notes-mcp/services"
 	"github.com/spf13/cobra"
 )
 
+var (
+	folderHierarchySort string
+	folderHierarchyFlat bool
+)
+
 var folderHierarchyCmd = &cobra.Command{
 	Use:   "folder-hierarchy",
 	Short: "Display the folder hierarchy in Apple Notes",
-	Long:  `Displays the complete folder hierarchy in Apple Notes as a nested structure with note counts.`,
-	Args:  cobra.NoArgs,
+	Long: `Displays the complete folder hierarchy in Apple Notes as a nested structure with note counts.
+Each folder also has total_note_count, which includes its subfolders. --sort orders folders by name or by
+total note count; --flat prints a list of "Parent/Child" paths instead of a tree.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := services.ValidateFolderSort(folderHierarchySort); err != nil {
+			return err
+		}
+
 		// Create service with real executor
 		notesService := newNotesService()
 
@@ -30,7 +42,7 @@ var folderHierarchyCmd = &cobra.Command{
 		}
 
 		// Output as JSON
-		output, err := json.MarshalIndent(hierarchy, "", "  ")
+		output, err := formatFolderHierarchy(hierarchy, folderHierarchySort, folderHierarchyFlat)
 		if err != nil {
 			return fmt.Errorf("failed to format hierarchy: %w", err)
 		}
@@ -40,6 +52,19 @@ var folderHierarchyCmd = &cobra.Command{
 	},
 }
 
+// formatFolderHierarchy sorts the hierarchy and renders it as an indented JSON tree or path list
+func formatFolderHierarchy(hierarchy *services.FolderNode, sortBy string, flat bool) ([]byte, error) {
+	services.SortFolderTree(hierarchy, sortBy)
+	if flat {
+		return json.MarshalIndent(services.FlattenFolderTree(hierarchy), "", "  ")
+	}
+	return json.MarshalIndent(hierarchy, "", "  ")
+}
+
 func init() {
 	rootCmd.AddCommand(folderHierarchyCmd)
+
+	// Add flags
+	folderHierarchyCmd.Flags().StringVar(&folderHierarchySort, "sort", "", "Sort folders by name or notes (default: Notes.app order)")
+	folderHierarchyCmd.Flags().BoolVar(&folderHierarchyFlat, "flat", false, "Print a flat list of folder paths")
 }
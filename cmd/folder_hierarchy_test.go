@@ -0,0 +1,64 @@
+// ABOUTME: Unit tests for the folder-hierarchy command and get_folder_hierarchy tool
+// ABOUTME: Tests sort validation and sorted, flattened hierarchy output
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestFolderHierarchyCommandArgs tests that invalid sort orders are rejected before calling Notes
+func TestFolderHierarchyCommandArgs(t *testing.T) {
+	rootCmd.SetArgs([]string{"folder-hierarchy", "--sort", "size"})
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	rootCmd.SetArgs([]string{})
+	folderHierarchySort = ""
+}
+
+// TestGetFolderHierarchyToolFlat tests sorting by note count and flattening to paths
+func TestGetFolderHierarchyToolFlat(t *testing.T) {
+	mock := &mockNotesService{
+		getFolderHierarchy: func(ctx context.Context) (*services.FolderNode, error) {
+			return &services.FolderNode{Name: "iCloud", TotalNoteCount: 6, Children: []services.FolderNode{
+				{Name: "Archive", NoteCount: 1, TotalNoteCount: 1},
+				{Name: "Work", NoteCount: 2, TotalNoteCount: 5, Children: []services.FolderNode{
+					{Name: "Projects", NoteCount: 3, TotalNoteCount: 3},
+				}},
+			}}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetFolderHierarchyTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "get_folder_hierarchy", map[string]any{"sort": "notes", "flat": true})
+	var paths []services.FolderPath
+	if err := json.Unmarshal([]byte(firstText(result)), &paths); err != nil {
+		t.Fatalf("failed to decode paths: %v", err)
+	}
+
+	got := []string{}
+	for _, path := range paths {
+		got = append(got, path.Path)
+	}
+	if want := []string{"Work", "Work/Projects", "Archive"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+
+	if result := callToolResult(t, session, "get_folder_hierarchy", map[string]any{"sort": "size"}); !result.IsError {
+		t.Error("expected error for unknown sort")
+	}
+}
@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/harper/notes-mcp/validation"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +18,10 @@ var getCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		title := args[0]
 
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
 		// Create service with real executor
 		notesService := newNotesService()
 
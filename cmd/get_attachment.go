@@ -14,13 +14,15 @@ import (
 var (
 	attachmentOutput  string
 	attachmentMaxSize int64
+	attachmentCopyTo  string
 )
 
 var getAttachmentCmd = &cobra.Command{
 	Use:   "get-attachment <file-path>",
 	Short: "Get attachment content from Apple Notes",
-	Long:  `Retrieves the content of an attachment from Apple Notes. Output as base64 to stdout or save to a file using --output flag.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Retrieves the content of an attachment from Apple Notes. Output as base64 to stdout or save to a file using --output flag.
+Use --copy-to to stream a large attachment into a directory without the size limit.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath := args[0]
 
@@ -31,6 +33,16 @@ var getAttachmentCmd = &cobra.Command{
 		ctx, cancel := newCommandContext()
 		defer cancel()
 
+		// Copy without loading the file into memory
+		if attachmentCopyTo != "" {
+			copied, err := notesService.CopyAttachment(ctx, filePath, attachmentCopyTo)
+			if err != nil {
+				return fmt.Errorf("failed to copy attachment: %w", err)
+			}
+			printSuccess(cmd.OutOrStdout(), "Attachment copied to: %s (%d bytes)", copied.Path, copied.Size)
+			return nil
+		}
+
 		// Get attachment content
 		content, err := notesService.GetAttachmentContent(ctx, filePath, attachmentMaxSize)
 		if err != nil {
@@ -43,7 +55,7 @@ var getAttachmentCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("failed to write attachment to file: %w", err)
 			}
-			fmt.Printf("Attachment saved to: %s\n", attachmentOutput)
+			printSuccess(cmd.OutOrStdout(), "Attachment saved to: %s", attachmentOutput)
 		} else {
 			// Output as base64 to stdout
 			encoded := base64.StdEncoding.EncodeToString(content)
@@ -60,4 +72,5 @@ func init() {
 	// Add flags
 	getAttachmentCmd.Flags().StringVarP(&attachmentOutput, "output", "o", "", "Save attachment to file instead of outputting base64")
 	getAttachmentCmd.Flags().Int64Var(&attachmentMaxSize, "max-size", 10*1024*1024, "Maximum attachment size in bytes (default 10MB)")
+	getAttachmentCmd.Flags().StringVar(&attachmentCopyTo, "copy-to", "", "Copy the attachment into this directory instead of reading it (no size limit)")
 }
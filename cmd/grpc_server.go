@@ -0,0 +1,501 @@
+// ABOUTME: gRPC server implementation of the notes.v1.NotesService definition
+// ABOUTME: Adapts services.NotesService to gRPC messages behind API token auth and maps service errors to status codes
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	notesv1 "github.com/harper/notes-mcp/proto/notes/v1"
+	"github.com/harper/notes-mcp/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcNotesServer implements notesv1.NotesServiceServer on top of a NotesService
+type grpcNotesServer struct {
+	notesv1.UnimplementedNotesServiceServer
+	notesService services.NotesService
+	// attachmentRoot is the only directory GetAttachmentContent reads files from
+	attachmentRoot string
+}
+
+// newGRPCServer creates a gRPC server with the notes service registered behind API token authentication
+// GetAttachmentContent only reads files under attachmentRoot, normally the Notes group container.
+func newGRPCServer(notesService services.NotesService, tokens *services.TokenStore, attachmentRoot string) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(requireGRPCToken(tokens)))
+	notesv1.RegisterNotesServiceServer(server, &grpcNotesServer{notesService: notesService, attachmentRoot: attachmentRoot})
+	return server
+}
+
+// requireGRPCToken rejects calls without valid "authorization: Bearer <token>" metadata
+// Like the HTTP transport, tokens are looked up on every call so revocation takes effect immediately.
+func requireGRPCToken(tokens *services.TokenStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		token, ok := bearerToken(values[0])
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+
+		record, err := tokens.Validate(ctx, token)
+		if errors.Is(err, services.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if err != nil {
+			log.Printf("Token validation failed: %v", err)
+			return nil, status.Error(codes.Internal, "token validation unavailable")
+		}
+
+		log.Printf("Authenticated %s with token %s (%s)", info.FullMethod, record.ID, record.Name)
+		return handler(ctx, req)
+	}
+}
+
+func (s *grpcNotesServer) CreateNote(ctx context.Context, req *notesv1.CreateNoteRequest) (*notesv1.Note, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	note, err := s.notesService.CreateNote(opCtx, req.GetTitle(), req.GetContent(), req.GetTags())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return noteToProto(note), nil
+}
+
+func (s *grpcNotesServer) SearchNotes(ctx context.Context, req *notesv1.SearchNotesRequest) (*notesv1.NoteList, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	notes, err := s.notesService.SearchNotes(opCtx, req.GetQuery())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return notesToProto(notes), nil
+}
+
+func (s *grpcNotesServer) SearchNotesAdvanced(ctx context.Context, req *notesv1.SearchNotesAdvancedRequest) (*notesv1.NoteList, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	opts := services.SearchOptions{
+		Query:  req.GetQuery(),
+		Folder: req.GetFolder(),
+	}
+	switch req.GetSearchIn() {
+	case notesv1.SearchIn_SEARCH_IN_BODY:
+		opts.SearchIn = services.SearchInBody
+	case notesv1.SearchIn_SEARCH_IN_BOTH:
+		opts.SearchIn = services.SearchInBoth
+	default:
+		opts.SearchIn = services.SearchInTitle
+	}
+	if req.GetDateFrom() != nil {
+		dateFrom := req.GetDateFrom().AsTime()
+		opts.DateFrom = &dateFrom
+	}
+	if req.GetDateTo() != nil {
+		dateTo := req.GetDateTo().AsTime()
+		opts.DateTo = &dateTo
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	notes, err := s.notesService.SearchNotesAdvanced(opCtx, opts)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return notesToProto(notes), nil
+}
+
+func (s *grpcNotesServer) GetNote(ctx context.Context, req *notesv1.GetNoteRequest) (*notesv1.Note, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	note, err := s.notesService.GetNoteMetadata(opCtx, req.GetTitle())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	content, err := s.notesService.GetNoteContent(opCtx, req.GetTitle())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	result := noteToProto(note)
+	result.Content = content
+	result.ContentHash = services.ContentHash(content)
+	return result, nil
+}
+
+func (s *grpcNotesServer) HasNoteChanged(ctx context.Context, req *notesv1.HasNoteChangedRequest) (*notesv1.HasNoteChangedResponse, error) {
+	if req.GetTitle() == "" || req.GetHash() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title and hash are required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	changed, currentHash, err := s.notesService.HasNoteChanged(opCtx, req.GetTitle(), req.GetHash())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.HasNoteChangedResponse{Changed: changed, ContentHash: currentHash}, nil
+}
+
+func (s *grpcNotesServer) UpdateNote(ctx context.Context, req *notesv1.UpdateNoteRequest) (*notesv1.UpdateNoteResponse, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	precondition := services.UpdatePrecondition{ExpectedHash: req.GetExpectedHash()}
+	if req.GetExpectedModified() != nil {
+		expected := req.GetExpectedModified().AsTime()
+		precondition.ExpectedModified = &expected
+	}
+
+	var err error
+	if precondition.ExpectedModified != nil || precondition.ExpectedHash != "" {
+		err = s.notesService.UpdateNoteIfUnchanged(opCtx, req.GetTitle(), req.GetContent(), precondition)
+	} else {
+		err = s.notesService.UpdateNote(opCtx, req.GetTitle(), req.GetContent())
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.UpdateNoteResponse{}, nil
+}
+
+func (s *grpcNotesServer) DeleteNote(ctx context.Context, req *notesv1.DeleteNoteRequest) (*notesv1.DeleteNoteResponse, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	if err := s.notesService.DeleteNote(opCtx, req.GetTitle()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.DeleteNoteResponse{}, nil
+}
+
+func (s *grpcNotesServer) OpenNote(ctx context.Context, req *notesv1.OpenNoteRequest) (*notesv1.OpenNoteResponse, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	if err := s.notesService.OpenNote(opCtx, req.GetTitle()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.OpenNoteResponse{}, nil
+}
+
+func (s *grpcNotesServer) GetRecentNotes(ctx context.Context, req *notesv1.GetRecentNotesRequest) (*notesv1.NoteList, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = 10
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	notes, err := s.notesService.GetRecentNotes(opCtx, limit)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return notesToProto(notes), nil
+}
+
+func (s *grpcNotesServer) GetNotesInFolder(ctx context.Context, req *notesv1.GetNotesInFolderRequest) (*notesv1.NoteList, error) {
+	if req.GetFolder() == "" {
+		return nil, status.Error(codes.InvalidArgument, "folder is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	notes, err := s.notesService.GetNotesInFolder(opCtx, req.GetFolder())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return notesToProto(notes), nil
+}
+
+func (s *grpcNotesServer) MoveNote(ctx context.Context, req *notesv1.MoveNoteRequest) (*notesv1.MoveNoteResponse, error) {
+	if req.GetNoteTitle() == "" || req.GetTargetFolder() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_title and target_folder are required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	if err := s.notesService.MoveNote(opCtx, req.GetNoteTitle(), req.GetTargetFolder()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.MoveNoteResponse{}, nil
+}
+
+func (s *grpcNotesServer) ListFolders(ctx context.Context, req *notesv1.ListFoldersRequest) (*notesv1.ListFoldersResponse, error) {
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	folders, err := s.notesService.ListFolders(opCtx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.ListFoldersResponse{Folders: folders}, nil
+}
+
+func (s *grpcNotesServer) CreateFolder(ctx context.Context, req *notesv1.CreateFolderRequest) (*notesv1.CreateFolderResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	if err := s.notesService.CreateFolder(opCtx, req.GetName(), req.GetParentFolder()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.CreateFolderResponse{}, nil
+}
+
+func (s *grpcNotesServer) GetFolderHierarchy(ctx context.Context, req *notesv1.GetFolderHierarchyRequest) (*notesv1.FolderNode, error) {
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	root, err := s.notesService.GetFolderHierarchy(opCtx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return folderNodeToProto(*root), nil
+}
+
+func (s *grpcNotesServer) GetNoteAttachments(ctx context.Context, req *notesv1.GetNoteAttachmentsRequest) (*notesv1.GetNoteAttachmentsResponse, error) {
+	if req.GetNoteTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	attachments, err := s.notesService.GetNoteAttachments(opCtx, req.GetNoteTitle())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &notesv1.GetNoteAttachmentsResponse{}
+	for _, attachment := range attachments {
+		resp.Attachments = append(resp.Attachments, &notesv1.Attachment{
+			Id:                attachment.ID,
+			Name:              attachment.Name,
+			FilePath:          attachment.FilePath,
+			ContentIdentifier: attachment.ContentIdentifier,
+			Created:           timestampOrNil(attachment.CreationDate),
+			Modified:          timestampOrNil(attachment.ModificationDate),
+		})
+	}
+	return resp, nil
+}
+
+func (s *grpcNotesServer) GetAttachmentContent(ctx context.Context, req *notesv1.GetAttachmentContentRequest) (*notesv1.GetAttachmentContentResponse, error) {
+	if req.GetFilePath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_path is required")
+	}
+
+	maxSize := req.GetMaxSize()
+	if maxSize <= 0 {
+		maxSize = 10 * 1024 * 1024
+	}
+
+	filePath, ok := fileWithin(s.attachmentRoot, req.GetFilePath())
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "file_path must be an attachment file in the Notes container")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	content, err := s.notesService.GetAttachmentContent(opCtx, filePath, maxSize)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.GetAttachmentContentResponse{Content: content}, nil
+}
+
+func (s *grpcNotesServer) ExportNote(ctx context.Context, req *notesv1.ExportNoteRequest) (*notesv1.ExportNoteResponse, error) {
+	if req.GetNoteTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	var content string
+	var err error
+	switch req.GetFormat() {
+	case notesv1.ExportFormat_EXPORT_FORMAT_TEXT:
+		content, err = s.notesService.ExportNoteText(opCtx, req.GetNoteTitle())
+	default:
+		content, err = s.notesService.ExportNoteMarkdown(opCtx, req.GetNoteTitle())
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &notesv1.ExportNoteResponse{Content: content}, nil
+}
+
+func (s *grpcNotesServer) ExtractActionItems(ctx context.Context, req *notesv1.ExtractActionItemsRequest) (*notesv1.ExtractActionItemsResponse, error) {
+	if req.GetNoteTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_title is required")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+	defer cancel()
+
+	items, err := s.notesService.ExtractActionItems(opCtx, req.GetNoteTitle())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &notesv1.ExtractActionItemsResponse{}
+	for _, item := range items {
+		resp.Items = append(resp.Items, &notesv1.ActionItem{
+			Text:       item.Text,
+			Done:       item.Done,
+			SourceNote: item.SourceNote,
+		})
+	}
+	return resp, nil
+}
+
+// grpcError maps service errors to gRPC status codes
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrNoteNotFound), errors.Is(err, services.ErrFolderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, services.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, services.ErrReadOnly):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, services.ErrNotSupported):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, services.ErrScriptTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, services.ErrNotesAppNotRunning):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// fileWithin resolves symlinks in an absolute path and reports whether it names a file under root
+func fileWithin(root, path string) (string, bool) {
+	if root == "" || !filepath.IsAbs(path) {
+		return "", false
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", false
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return resolved, true
+}
+
+// noteToProto converts a service note to its protobuf message
+func noteToProto(note *services.Note) *notesv1.Note {
+	created := note.CreationDate
+	if created.IsZero() {
+		created = note.Created
+	}
+	modified := note.ModificationDate
+	if modified.IsZero() {
+		modified = note.Modified
+	}
+
+	return &notesv1.Note{
+		Id:                note.ID,
+		Title:             note.Title,
+		Content:           note.Content,
+		Tags:              note.Tags,
+		Created:           timestampOrNil(created),
+		Modified:          timestampOrNil(modified),
+		Folder:            note.Folder,
+		Shared:            note.Shared,
+		PasswordProtected: note.PasswordProtected,
+	}
+}
+
+// notesToProto converts a slice of service notes to a NoteList message
+func notesToProto(notes []services.Note) *notesv1.NoteList {
+	list := &notesv1.NoteList{}
+	for i := range notes {
+		list.Notes = append(list.Notes, noteToProto(&notes[i]))
+	}
+	return list
+}
+
+// folderNodeToProto recursively converts a folder tree to protobuf messages
+func folderNodeToProto(node services.FolderNode) *notesv1.FolderNode {
+	result := &notesv1.FolderNode{
+		Name:      node.Name,
+		Shared:    node.Shared,
+		NoteCount: int32(node.NoteCount),
+	}
+	for _, child := range node.Children {
+		result.Children = append(result.Children, folderNodeToProto(child))
+	}
+	return result
+}
+
+// timestampOrNil converts a time to a protobuf timestamp, leaving zero times unset
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,250 @@
+// ABOUTME: Unit tests for the gRPC notes server
+// ABOUTME: Exercises RPCs end-to-end over an in-memory connection, including token auth, read-only mode, and attachment paths
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	notesv1 "github.com/harper/notes-mcp/proto/notes/v1"
+	"github.com/harper/notes-mcp/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGRPCClient starts a gRPC server on an in-memory listener and returns a client that sends a valid token
+func newTestGRPCClient(t *testing.T, notesService services.NotesService) notesv1.NotesServiceClient {
+	t.Helper()
+
+	tokens := services.NewTokenStore(fakeSecretStore{})
+	token, _, err := tokens.Create(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dialTestGRPCServer(t, newGRPCServer(notesService, tokens, t.TempDir()), token)
+}
+
+// dialTestGRPCServer serves server on an in-memory listener and returns a client sending token, if any
+func dialTestGRPCServer(t *testing.T, server *grpc.Server, token string) notesv1.NotesServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return notesv1.NewNotesServiceClient(conn)
+}
+
+func TestGRPCGetNote(t *testing.T) {
+	modified := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	mock := &mockNotesService{
+		getNoteMetadata: func(ctx context.Context, title string) (*services.Note, error) {
+			return &services.Note{ID: "x-coredata://1", Title: title, Folder: "Work", ModificationDate: modified}, nil
+		},
+		getNoteContent: func(ctx context.Context, title string) (string, error) {
+			return "<div>Hello</div>", nil
+		},
+	}
+	client := newTestGRPCClient(t, mock)
+
+	note, err := client.GetNote(context.Background(), &notesv1.GetNoteRequest{Title: "Plan"})
+	if err != nil {
+		t.Fatalf("GetNote returned error: %v", err)
+	}
+	if note.GetTitle() != "Plan" || note.GetFolder() != "Work" || note.GetContent() != "<div>Hello</div>" {
+		t.Errorf("unexpected note: %+v", note)
+	}
+	if !note.GetModified().AsTime().Equal(modified) {
+		t.Errorf("expected modified %v, got %v", modified, note.GetModified().AsTime())
+	}
+	if note.GetContentHash() != services.ContentHash("<div>Hello</div>") {
+		t.Errorf("unexpected content hash %q", note.GetContentHash())
+	}
+	if note.GetCreated() != nil {
+		t.Errorf("expected unset created timestamp, got %v", note.GetCreated())
+	}
+}
+
+func TestGRPCSearchNotesAdvancedMapsOptions(t *testing.T) {
+	var got services.SearchOptions
+	mock := &mockNotesService{
+		searchNotesAdvanced: func(ctx context.Context, opts services.SearchOptions) ([]services.Note, error) {
+			got = opts
+			return []services.Note{{Title: "A"}, {Title: "B"}}, nil
+		},
+	}
+	client := newTestGRPCClient(t, mock)
+
+	resp, err := client.SearchNotesAdvanced(context.Background(), &notesv1.SearchNotesAdvancedRequest{
+		Query:    "launch",
+		SearchIn: notesv1.SearchIn_SEARCH_IN_BOTH,
+		Folder:   "Work",
+	})
+	if err != nil {
+		t.Fatalf("SearchNotesAdvanced returned error: %v", err)
+	}
+	if len(resp.GetNotes()) != 2 {
+		t.Errorf("expected 2 notes, got %d", len(resp.GetNotes()))
+	}
+	if got.SearchIn != services.SearchInBoth || got.Folder != "Work" || got.DateFrom != nil {
+		t.Errorf("unexpected search options: %+v", got)
+	}
+}
+
+func TestGRPCErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "note not found", err: fmt.Errorf("failed: %w", services.ErrNoteNotFound), want: codes.NotFound},
+		{name: "permission denied", err: services.ErrPermissionDenied, want: codes.PermissionDenied},
+		{name: "timeout", err: services.ErrScriptTimeout, want: codes.DeadlineExceeded},
+		{name: "app not running", err: services.ErrNotesAppNotRunning, want: codes.Unavailable},
+		{name: "unexpected", err: fmt.Errorf("boom"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockNotesService{
+				deleteNote: func(ctx context.Context, title string) error { return tt.err },
+			}
+			client := newTestGRPCClient(t, mock)
+
+			_, err := client.DeleteNote(context.Background(), &notesv1.DeleteNoteRequest{Title: "Plan"})
+			if status.Code(err) != tt.want {
+				t.Errorf("expected code %v, got %v (%v)", tt.want, status.Code(err), err)
+			}
+		})
+	}
+}
+
+func TestGRPCValidatesRequiredFields(t *testing.T) {
+	client := newTestGRPCClient(t, &mockNotesService{})
+
+	_, err := client.CreateNote(context.Background(), &notesv1.CreateNoteRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+// TestGRPCRequiresToken tests that calls without a valid bearer token never reach the service
+func TestGRPCRequiresToken(t *testing.T) {
+	tokens := services.NewTokenStore(fakeSecretStore{})
+	if _, _, err := tokens.Create(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	called := false
+	mock := &mockNotesService{
+		listFolders: func(ctx context.Context) ([]string, error) {
+			called = true
+			return []string{"Notes"}, nil
+		},
+	}
+
+	for _, token := range []string{"", "nmcp_nope"} {
+		client := dialTestGRPCServer(t, newGRPCServer(mock, tokens, t.TempDir()), token)
+		_, err := client.ListFolders(context.Background(), &notesv1.ListFoldersRequest{})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("token %q: expected Unauthenticated, got %v", token, err)
+		}
+	}
+	if called {
+		t.Error("expected unauthenticated calls not to reach the service")
+	}
+}
+
+// TestGRPCReadOnly tests that NOTES_MCP_READ_ONLY refuses write RPCs on the provider-built service
+func TestGRPCReadOnly(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(providerEnvVar, "memory")
+	t.Setenv(readOnlyEnvVar, "1")
+
+	notesService, err := newGRPCNotesService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newTestGRPCClient(t, notesService)
+
+	_, err = client.CreateNote(context.Background(), &notesv1.CreateNoteRequest{Title: "Plan", Content: "hello"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for a write, got %v", err)
+	}
+	if _, err := client.ListFolders(context.Background(), &notesv1.ListFoldersRequest{}); err != nil {
+		t.Errorf("expected reads to succeed, got %v", err)
+	}
+}
+
+// TestGRPCAttachmentContentConfined tests that GetAttachmentContent only reads files under the attachment root
+func TestGRPCAttachmentContentConfined(t *testing.T) {
+	root := t.TempDir()
+	inside := filepath.Join(root, "Accounts", "x", "Media", "photo.jpg")
+	if err := os.MkdirAll(filepath.Dir(inside), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(inside, []byte("jpeg"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(root, "link.txt")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := services.NewTokenStore(fakeSecretStore{})
+	token, _, err := tokens.Create(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockNotesService{
+		getAttachmentContent: func(ctx context.Context, filePath string, maxSize int64) ([]byte, error) {
+			return os.ReadFile(filePath)
+		},
+	}
+	client := dialTestGRPCServer(t, newGRPCServer(mock, tokens, root), token)
+
+	resp, err := client.GetAttachmentContent(context.Background(), &notesv1.GetAttachmentContentRequest{FilePath: inside})
+	if err != nil || string(resp.GetContent()) != "jpeg" {
+		t.Errorf("expected the attachment under the root, got %v (%v)", resp.GetContent(), err)
+	}
+
+	for _, path := range []string{outside, link, filepath.Join(root, "..", filepath.Base(outside)), "secret.txt", root} {
+		_, err := client.GetAttachmentContent(context.Background(), &notesv1.GetAttachmentContentRequest{FilePath: path})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: expected PermissionDenied, got %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// ABOUTME: Startup permission pre-flight and the health_check tool reporting server and permission status
+// ABOUTME: NOTES_MCP_STARTUP_CHECK runs a read-only script at startup so the Automation dialog appears early
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// startupCheckEnvVar enables the permission pre-flight when the MCP server starts
+const startupCheckEnvVar = "NOTES_MCP_STARTUP_CHECK"
+
+// permissionCheckTimeout leaves time to answer the consent dialog the pre-flight may trigger
+const permissionCheckTimeout = 60 * time.Second
+
+// permissionState holds the latest permission check for health reporting
+type permissionState struct {
+	mu      sync.Mutex
+	checker services.PermissionChecker // nil when the provider needs no permission
+	last    services.PermissionCheck
+}
+
+// newPermissionState creates the state for a notes service; providers without a
+// permission to check report not_required
+func newPermissionState(notesService services.NotesService) *permissionState {
+	state := &permissionState{last: services.PermissionCheck{Status: services.PermissionUnchecked}}
+	if checker, ok := services.UndecoratedNotesService(notesService).(services.PermissionChecker); ok {
+		state.checker = checker
+	} else {
+		state.last = services.PermissionCheck{Status: services.PermissionNotRequired, CheckedAt: time.Now()}
+	}
+	return state
+}
+
+// check runs the permission pre-flight and records the result
+func (p *permissionState) check(ctx context.Context) services.PermissionCheck {
+	if p.checker == nil {
+		return p.current()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, permissionCheckTimeout)
+	defer cancel()
+	result := p.checker.CheckPermission(ctx)
+
+	p.mu.Lock()
+	p.last = result
+	p.mu.Unlock()
+	return result
+}
+
+// current returns the latest recorded result without running a check
+func (p *permissionState) current() services.PermissionCheck {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.last
+}
+
+// startPermissionCheck runs the pre-flight in the background when NOTES_MCP_STARTUP_CHECK is set
+// It doesn't block startup, since a pending consent dialog can take as long as the user does.
+func startPermissionCheck(state *permissionState) {
+	if !envEnabled(startupCheckEnvVar) || state.checker == nil {
+		return
+	}
+
+	go func() {
+		result := state.check(context.Background())
+		if result.Status == services.PermissionGranted {
+			log.Printf("Permission check: Apple Notes automation access granted")
+			return
+		}
+		log.Printf("Permission check: %s (%s)", result.Status, result.Detail)
+	}()
+}
+
+// HealthCheckArgs are the arguments for the health_check tool
+type HealthCheckArgs struct {
+	Refresh bool `json:"refresh,omitempty" jsonschema:"Run the permission check again instead of reporting the last result"`
+}
+
+// healthReport is the health_check result
+type healthReport struct {
+	Status           string                    `json:"status"`
+	Provider         string                    `json:"provider"`
+	PermissionStatus string                    `json:"permission_status"`
+	PermissionDetail string                    `json:"permission_detail,omitempty"`
+	PermissionAt     *time.Time                `json:"permission_checked_at,omitempty"`
+	Operations       []services.OperationStats `json:"operations,omitempty"`
+}
+
+// registerHealthCheckTool registers the health_check tool
+// metrics, when set, adds per-operation call counts, errors, and average durations to the report.
+func registerHealthCheckTool(server *mcp.Server, provider string, permissions *permissionState, metrics *services.ServiceMetrics) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input HealthCheckArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		result := permissions.current()
+		if input.Refresh || result.Status == services.PermissionUnchecked {
+			result = permissions.check(ctx)
+		}
+
+		report := healthReport{
+			Status:           "ok",
+			Provider:         provider,
+			PermissionStatus: result.Status,
+			PermissionDetail: result.Detail,
+		}
+		if !result.CheckedAt.IsZero() {
+			report.PermissionAt = &result.CheckedAt
+		}
+		if metrics != nil {
+			report.Operations = metrics.Snapshot()
+		}
+		if result.Status != services.PermissionGranted && result.Status != services.PermissionNotRequired {
+			report.Status = "degraded"
+		}
+
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format health report: %w", err)), nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "health_check",
+		Description: "Reports whether the server can reach its notes: the provider in use and a permission_status (granted, denied, notes_not_running, timed_out, error, or not_required). Runs a read-only permission check the first time, or again with refresh. operations lists calls, errors, and average_ms per notes operation since the server started. Call it when tools fail unexpectedly.",
+	}, handler)
+}
@@ -0,0 +1,65 @@
+// ABOUTME: Tests for the health_check tool and startup permission state
+// ABOUTME: Uses a fake permission checker so no AppleScript runs
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// permissionCheckingService is a mock notes service that also reports a permission status
+type permissionCheckingService struct {
+	mockNotesService
+	statuses []string
+	calls    int
+}
+
+func (p *permissionCheckingService) CheckPermission(ctx context.Context) services.PermissionCheck {
+	status := p.statuses[min(p.calls, len(p.statuses)-1)]
+	p.calls++
+	return services.PermissionCheck{Status: status, CheckedAt: time.Now()}
+}
+
+func TestHealthCheckTool(t *testing.T) {
+	notes := &permissionCheckingService{statuses: []string{services.PermissionDenied, services.PermissionGranted}}
+	state := newPermissionState(notes)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerHealthCheckTool(server, "applescript", state, nil)
+	session := connectTestClient(t, server)
+
+	var report healthReport
+	result := callToolResult(t, session, "health_check", map[string]any{})
+	if err := json.Unmarshal([]byte(firstText(result)), &report); err != nil {
+		t.Fatalf("expected JSON, got %s", firstText(result))
+	}
+	if report.Status != "degraded" || report.PermissionStatus != services.PermissionDenied || report.Provider != "applescript" {
+		t.Errorf("expected an unchecked state to be checked and reported as denied, got %+v", report)
+	}
+
+	// Without refresh the recorded result is reused
+	result = callToolResult(t, session, "health_check", map[string]any{})
+	_ = json.Unmarshal([]byte(firstText(result)), &report)
+	if notes.calls != 1 || report.PermissionStatus != services.PermissionDenied {
+		t.Errorf("expected the cached result, got %+v after %d checks", report, notes.calls)
+	}
+
+	result = callToolResult(t, session, "health_check", map[string]any{"refresh": true})
+	_ = json.Unmarshal([]byte(firstText(result)), &report)
+	if report.Status != "ok" || report.PermissionStatus != services.PermissionGranted {
+		t.Errorf("expected a refreshed check to report granted, got %+v", report)
+	}
+}
+
+func TestPermissionStateWithoutChecker(t *testing.T) {
+	state := newPermissionState(&mockNotesService{})
+	if got := state.check(context.Background()); got.Status != services.PermissionNotRequired {
+		t.Errorf("expected not_required for a provider without permissions, got %+v", got)
+	}
+}
@@ -0,0 +1,122 @@
+// ABOUTME: History command listing earlier versions of notes recorded before updates and deletes
+// ABOUTME: History is kept in NOTES_MCP_HISTORY_FILE when set, encrypted when NOTES_MCP_ENCRYPT_STORES=true
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// historyFileEnvVar enables note history, naming the file versions are kept in
+const historyFileEnvVar = "NOTES_MCP_HISTORY_FILE"
+
+var (
+	historyShow int
+	historyJSON bool
+)
+
+// Note history is opened once per process, holding its store lock until the process exits
+var (
+	noteHistoryOnce sync.Once
+	noteHistory     *services.NoteHistory
+	noteHistoryErr  error
+)
+
+// openNoteHistory returns the process's note history, or nil when NOTES_MCP_HISTORY_FILE is unset
+func openNoteHistory() (*services.NoteHistory, error) {
+	noteHistoryOnce.Do(func() {
+		path := os.Getenv(historyFileEnvVar)
+		if path == "" {
+			return
+		}
+
+		// Hold the store lock before loading the key, and never fall back to plaintext when encryption was requested
+		if noteHistoryErr = lockStoreForWriting(path); noteHistoryErr != nil {
+			return
+		}
+		encryptor, err := newStoreEncryptor()
+		if err != nil {
+			noteHistoryErr = err
+			return
+		}
+		noteHistory = services.NewNoteHistory(path, encryptor, services.DefaultHistoryLimit)
+	})
+	return noteHistory, noteHistoryErr
+}
+
+// historyMiddleware records note versions before updates and deletes through backend, when history is enabled
+func historyMiddleware(backend services.NoteReader) []services.ServiceMiddleware {
+	history, err := openNoteHistory()
+	if err != nil {
+		log.Printf("Note history disabled: %v", err)
+		return nil
+	}
+	if history == nil {
+		return nil
+	}
+	return []services.ServiceMiddleware{services.HistoryMiddleware(history, backend, time.Now)}
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history [note title or ID]",
+	Short: "List earlier versions of notes recorded before updates and deletes",
+	Long: `Lists the versions recorded in NOTES_MCP_HISTORY_FILE, oldest first. With NOTES_MCP_HISTORY_FILE set,
+every update or delete made through notes-mcp first records the note's previous body there (the newest
+1000 versions are kept). Without a note, every recorded version is listed; --show N prints version N's body.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		history, err := openNoteHistory()
+		if err != nil {
+			return err
+		}
+		if history == nil {
+			return fmt.Errorf("%w: note history is off; set %s to record it", services.ErrInvalidInput, historyFileEnvVar)
+		}
+
+		note := ""
+		if len(args) == 1 {
+			note = args[0]
+		}
+		versions, err := history.Versions(note)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if historyShow != 0 {
+			if historyShow < 1 || historyShow > len(versions) {
+				return fmt.Errorf("%w: --show must be between 1 and %d", services.ErrInvalidInput, len(versions))
+			}
+			fmt.Fprintln(out, versions[historyShow-1].Body)
+			return nil
+		}
+		if historyJSON {
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(versions)
+		}
+		if len(versions) == 0 {
+			fmt.Fprintln(out, "No recorded versions found.")
+			return nil
+		}
+		for i, version := range versions {
+			fmt.Fprintf(out, "%d. %s  %-22s %s\n", i+1, version.Time.Format("2006-01-02 15:04:05"), version.Operation, version.Title)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().IntVar(&historyShow, "show", 0, "Print the body of version N from the list")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Print the versions as JSON")
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Tests for the history command and the note history wiring
+// ABOUTME: Records versions through an MCP session on the in-memory provider and lists them with the CLI
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// useTestNoteHistory points note history at a temporary file, reopened on next use
+func useTestNoteHistory(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	t.Setenv(historyFileEnvVar, path)
+	reset := func() {
+		noteHistoryOnce = sync.Once{}
+		noteHistory, noteHistoryErr = nil, nil
+		historyShow, historyJSON = 0, false
+	}
+	reset()
+	t.Cleanup(reset)
+	return path
+}
+
+func TestHistoryCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(providerEnvVar, "memory")
+	useTestNoteHistory(t)
+
+	session := connectTestClient(t, newMCPServer(""))
+	callTool(t, session, "create_note", map[string]any{"title": "Plan", "content": "<div>v1</div>"})
+	callTool(t, session, "update_note", map[string]any{"title": "Plan", "content": "<div>v2</div>"})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		rootCmd.SetArgs(args)
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(io.Discard)
+		defer func() {
+			rootCmd.SetArgs([]string{})
+			rootCmd.SetOut(nil)
+			rootCmd.SetErr(nil)
+		}()
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if out := run("history", "Plan"); !strings.Contains(out, "1. ") || !strings.Contains(out, "UpdateNote") {
+		t.Errorf("expected the update listed, got %q", out)
+	}
+	if out := run("history", "Plan", "--show", "1"); !strings.Contains(out, "<div>v1</div>") {
+		t.Errorf("expected the previous body, got %q", out)
+	}
+	historyShow = 0
+	if out := run("history", "Groceries"); !strings.Contains(out, "No recorded versions found.") {
+		t.Errorf("expected no versions for another note, got %q", out)
+	}
+}
+
+func TestHistoryCommandDisabled(t *testing.T) {
+	useTestNoteHistory(t)
+	t.Setenv(historyFileEnvVar, "")
+
+	rootCmd.SetArgs([]string{"history"})
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	defer func() {
+		rootCmd.SetArgs([]string{})
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	}()
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), historyFileEnvVar) {
+		t.Errorf("expected an error naming %s, got %v", historyFileEnvVar, err)
+	}
+}
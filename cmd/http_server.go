@@ -0,0 +1,61 @@
+// ABOUTME: Streamable HTTP transport for the MCP server
+// ABOUTME: Requires a bearer API token validated against the Keychain-backed token store
+
+package cmd
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newMCPHTTPHandler serves the MCP server over streamable HTTP behind API token authentication
+func newMCPHTTPHandler(server *mcp.Server, tokens *services.TokenStore) http.Handler {
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return server
+	}, nil)
+	return requireAPIToken(tokens, handler)
+}
+
+// requireAPIToken rejects requests without a valid "Authorization: Bearer <token>" header
+// Tokens are looked up on every request so revocation takes effect immediately.
+func requireAPIToken(tokens *services.TokenStore, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r.Header.Get("Authorization"))
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="notes-mcp"`)
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		record, err := tokens.Validate(r.Context(), token)
+		if errors.Is(err, services.ErrInvalidToken) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="notes-mcp", error="invalid_token"`)
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			log.Printf("Token validation failed: %v", err)
+			http.Error(w, "token validation unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		log.Printf("Authenticated %s %s with token %s (%s)", r.Method, r.URL.Path, record.ID, record.Name)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header value
+// It is shared by the HTTP and gRPC transports.
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
@@ -0,0 +1,105 @@
+// ABOUTME: Unit tests for the HTTP transport's API token authentication
+// ABOUTME: Tests the bearer token middleware and the token management commands
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// TestRequireAPIToken tests that only requests with a valid bearer token reach the handler
+func TestRequireAPIToken(t *testing.T) {
+	tokens := services.NewTokenStore(fakeSecretStore{})
+	token, _, err := tokens.Create(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := requireAPIToken(tokens, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "valid token", header: "Bearer " + token, want: http.StatusNoContent},
+		{name: "lowercase scheme", header: "bearer " + token, want: http.StatusNoContent},
+		{name: "missing header", header: "", want: http.StatusUnauthorized},
+		{name: "wrong scheme", header: "Basic " + token, want: http.StatusUnauthorized},
+		{name: "unknown token", header: "Bearer nmcp_nope", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+// runTokenCommand runs a token subcommand and returns its output
+func runTokenCommand(t *testing.T, args ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	rootCmd.SetArgs(append([]string{"token"}, args...))
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(io.Discard)
+	defer func() {
+		rootCmd.SetArgs([]string{})
+		rootCmd.SetOut(nil)
+	}()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("token %v failed: %v", args, err)
+	}
+	return out.String()
+}
+
+// TestTokenCommands tests create, list, and revoke end to end
+func TestTokenCommands(t *testing.T) {
+	secrets := useFakeSecretStore(t)
+
+	output := runTokenCommand(t, "create", "laptop")
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	token := lines[len(lines)-1]
+	if !strings.HasPrefix(token, "nmcp_") {
+		t.Fatalf("expected token on the last line, got %q", output)
+	}
+
+	records, _ := services.NewTokenStore(secrets).List(context.Background())
+	if len(records) != 1 {
+		t.Fatalf("expected 1 token, got %d", len(records))
+	}
+
+	if output := runTokenCommand(t, "list"); !strings.Contains(output, records[0].ID) || !strings.Contains(output, "laptop") {
+		t.Errorf("list output missing token: %q", output)
+	}
+
+	runTokenCommand(t, "revoke", records[0].ID)
+	if output := runTokenCommand(t, "list"); !strings.Contains(output, "No API tokens") {
+		t.Errorf("expected no tokens after revoke, got %q", output)
+	}
+}
@@ -0,0 +1,73 @@
+// ABOUTME: Import command for bringing Bear or Apple Notes exports into Apple Notes
+// ABOUTME: Accepts a Bear export zip or an exported folder and skips duplicate titles
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFolder          string
+	importAllowDuplicates bool
+	importDryRun          bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <zip-or-folder>",
+	Short: "Import notes from a Bear export zip or an exported folder",
+	Long: `Imports notes from a Bear export (markdown files or TextBundles in a zip) or from a folder of exported .md, .txt, or .html files.
+Notes whose titles already exist in Apple Notes are skipped unless --allow-duplicates is set. Use --dry-run to list what would be imported.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sourcePath := args[0]
+
+		// Read the export before touching Apple Notes
+		notes, err := services.ReadImportSource(sourcePath)
+		if err != nil {
+			return fmt.Errorf("failed to read import source: %w", err)
+		}
+
+		if importDryRun {
+			for _, note := range notes {
+				fmt.Printf("%s\t%s\n", note.Title, note.SourcePath)
+			}
+			return nil
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context for a batch operation
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		// Import the notes
+		result, err := notesService.ImportNotes(ctx, notes, importFolder, importAllowDuplicates)
+		if err != nil {
+			return fmt.Errorf("failed to import notes: %w", err)
+		}
+
+		// Output summary as JSON
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format import result: %w", err)
+		}
+
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	// Add flags
+	importCmd.Flags().StringVar(&importFolder, "folder", "", "Folder to move imported notes into")
+	importCmd.Flags().BoolVar(&importAllowDuplicates, "allow-duplicates", false, "Import notes even if a note with the same title exists")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "List notes that would be imported without creating them")
+}
@@ -0,0 +1,73 @@
+// ABOUTME: Import HTML command for saving web pages into Apple Notes to read later
+// ABOUTME: Sanitizes each HTML file and records its source URL at the top of the new note
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importHTMLURL             string
+	importHTMLFolder          string
+	importHTMLAllowDuplicates bool
+)
+
+var importHTMLCmd = &cobra.Command{
+	Use:   "import-html <file>...",
+	Short: "Import HTML files, such as saved web pages, as notes",
+	Long: `Creates a note from each HTML file, keeping text, headings, lists, tables, and links and removing
+scripts, styles, navigation, and images. The note title comes from the page's <title>. The source URL is
+taken from --url or detected from the saved page, and recorded at the top of the note.
+Notes whose titles already exist are skipped unless --allow-duplicates is set.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if importHTMLURL != "" && len(args) > 1 {
+			return fmt.Errorf("%w: --url can only be used when importing a single file", services.ErrInvalidInput)
+		}
+
+		// Read and sanitize every file before touching Apple Notes
+		notes := make([]services.ImportedNote, 0, len(args))
+		for _, path := range args {
+			note, err := services.ReadHTMLImport(path, importHTMLURL)
+			if err != nil {
+				return err
+			}
+			notes = append(notes, note)
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context for a batch operation
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		result, err := notesService.ImportNotes(ctx, notes, importHTMLFolder, importHTMLAllowDuplicates)
+		if err != nil {
+			return fmt.Errorf("failed to import notes: %w", err)
+		}
+
+		// Output summary as JSON
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format import result: %w", err)
+		}
+
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importHTMLCmd)
+
+	// Add flags
+	importHTMLCmd.Flags().StringVar(&importHTMLURL, "url", "", "Source URL to record (default: detected from the saved page)")
+	importHTMLCmd.Flags().StringVar(&importHTMLFolder, "folder", "", "Folder to move imported notes into")
+	importHTMLCmd.Flags().BoolVar(&importHTMLAllowDuplicates, "allow-duplicates", false, "Import notes even if a note with the same title exists")
+}
@@ -0,0 +1,59 @@
+// ABOUTME: Unit tests for the import command
+// ABOUTME: Tests CLI argument parsing and source validation
+
+package cmd
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+// TestImportCommandArgs tests that the import command validates its source argument
+func TestImportCommandArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			name:        "no arguments",
+			args:        []string{"import"},
+			expectError: true,
+		},
+		{
+			name:        "missing source",
+			args:        []string{"import", filepath.Join(t.TempDir(), "missing.zip")},
+			expectError: true,
+		},
+		{
+			name:        "dry run of empty folder",
+			args:        []string{"import", t.TempDir(), "--dry-run"},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up command
+			rootCmd.SetArgs(tt.args)
+
+			// Silence output
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			err := rootCmd.Execute()
+
+			if tt.expectError && err == nil {
+				t.Error("expected error but got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+			importDryRun = false
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// ABOUTME: Index command maintaining the local full-text index behind the "index" search backend
+// ABOUTME: Shows its status, updates, rebuilds, or prunes it through the configured provider, printing progress
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// searchIndexEnvVar overrides the file the full-text search index is kept in
+const searchIndexEnvVar = "NOTES_MCP_SEARCH_INDEX"
+
+var indexStatusJSON bool
+
+// searchIndexPath returns the search index file: NOTES_MCP_SEARCH_INDEX or ~/.config/notes-mcp/search-index.jsonl
+func searchIndexPath() string {
+	if path := os.Getenv(searchIndexEnvVar); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "search-index.jsonl")
+}
+
+// The search index is opened once per process, holding its store lock until the process exits
+var (
+	searchIndexOnce sync.Once
+	searchIndex     *services.SearchIndex
+	searchIndexErr  error
+)
+
+// openSearchIndex returns the process's search index, opening it on first use
+func openSearchIndex() (*services.SearchIndex, error) {
+	searchIndexOnce.Do(func() {
+		path := searchIndexPath()
+		if path == "" {
+			searchIndexErr = errors.New("search index unavailable: no home directory")
+			return
+		}
+
+		// Hold the store lock before loading the key, and never fall back to plaintext when encryption was requested
+		if searchIndexErr = lockStoreForWriting(path); searchIndexErr != nil {
+			return
+		}
+		encryptor, err := newStoreEncryptor()
+		if err != nil {
+			searchIndexErr = err
+			return
+		}
+		searchIndex = services.NewSearchIndex(path, encryptor)
+	})
+	return searchIndex, searchIndexErr
+}
+
+// searchIndexMiddleware answers searches asking for the index backend from the process's search index,
+// updating it from backend first
+func searchIndexMiddleware(backend services.NoteReader) services.ServiceMiddleware {
+	return services.SearchIndexMiddleware(openSearchIndex, backend)
+}
+
+// indexProgress prints each indexed note to w as "[done/total] title", unless --quiet is set
+func indexProgress(w io.Writer) services.IndexProgress {
+	return func(done, total int, title string) {
+		if !quietOutput {
+			fmt.Fprintf(w, "[%d/%d] %s\n", done, total, title) //nolint:errcheck // progress output is best-effort
+		}
+	}
+}
+
+// runIndexCommand opens the provider's service and the search index for an index subcommand
+func runIndexCommand(run func(notesService services.NotesService, index *services.SearchIndex) error) error {
+	_, notesService, err := newProviderNotesService()
+	if err != nil {
+		return err
+	}
+	index, err := openSearchIndex()
+	if err != nil {
+		return err
+	}
+	return run(notesService, index)
+}
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Maintain the local full-text search index",
+	Long: `Maintains the full-text index used by searches with --backend=index (or NOTES_MCP_SEARCH_BACKEND=index).
+The index keeps each note's plain-text body in ~/.config/notes-mcp/search-index.jsonl (or NOTES_MCP_SEARCH_INDEX),
+encrypted when NOTES_MCP_ENCRYPT_STORES=true. Notes are read through NOTES_MCP_PROVIDER, so the sqlite provider
+builds it straight from the Notes database. Index searches update it first, reading only notes modified since,
+and "notes-mcp watch --update-index" keeps it current as changes are detected.`,
+}
+
+var indexStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show how many notes are indexed and how many are stale or deleted",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIndexCommand(func(notesService services.NotesService, index *services.SearchIndex) error {
+			ctx, cancel := newBatchCommandContext()
+			defer cancel()
+
+			status, err := index.Status(ctx, notesService)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if indexStatusJSON {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(status)
+			}
+			fmt.Fprintf(out, "Index: %s\n", status.Path)
+			fmt.Fprintf(out, "Notes indexed: %d\n", status.Notes)
+			fmt.Fprintf(out, "Stale (new or modified since indexed): %d\n", status.Stale)
+			fmt.Fprintf(out, "Prunable (deleted since indexed): %d\n", status.Prunable)
+			if status.UpdatedAt.IsZero() {
+				fmt.Fprintln(out, "Last updated: never")
+			} else {
+				fmt.Fprintf(out, "Last updated: %s\n", status.UpdatedAt.Format("2006-01-02 15:04:05"))
+			}
+			return nil
+		})
+	},
+}
+
+var indexUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Index notes created or modified since the last update and drop deleted ones",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIndexCommand(func(notesService services.NotesService, index *services.SearchIndex) error {
+			ctx, cancel := newBatchCommandContext()
+			defer cancel()
+
+			update, err := index.Update(ctx, notesService, indexProgress(cmd.ErrOrStderr()))
+			if err != nil {
+				if update != nil && update.Indexed > 0 {
+					return fmt.Errorf("index update failed after %d notes; run it again to continue: %w", update.Indexed, err)
+				}
+				return err
+			}
+			printSuccess(cmd.OutOrStdout(), "Indexed %d notes, pruned %d (%d in the index)", update.Indexed, update.Pruned, update.Total)
+			return nil
+		})
+	},
+}
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Discard the index and index every note again",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIndexCommand(func(notesService services.NotesService, index *services.SearchIndex) error {
+			ctx, cancel := newBatchCommandContext()
+			defer cancel()
+
+			update, err := index.Rebuild(ctx, notesService, indexProgress(cmd.ErrOrStderr()))
+			if err != nil {
+				return fmt.Errorf("index rebuild failed; the previous index is unchanged: %w", err)
+			}
+			printSuccess(cmd.OutOrStdout(), "Rebuilt the index with %d notes", update.Total)
+			return nil
+		})
+	},
+}
+
+var indexPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Drop indexed notes that have been deleted",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIndexCommand(func(notesService services.NotesService, index *services.SearchIndex) error {
+			ctx, cancel := newBatchCommandContext()
+			defer cancel()
+
+			update, err := index.Prune(ctx, notesService)
+			if err != nil {
+				return err
+			}
+			printSuccess(cmd.OutOrStdout(), "Pruned %d deleted notes (%d in the index)", update.Pruned, update.Total)
+			return nil
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+	indexCmd.AddCommand(indexStatusCmd, indexUpdateCmd, indexRebuildCmd, indexPruneCmd)
+
+	indexStatusCmd.Flags().BoolVar(&indexStatusJSON, "json", false, "Print the status as JSON")
+}
@@ -0,0 +1,110 @@
+// ABOUTME: Tests for the index command and the index search backend
+// ABOUTME: Runs index subcommands and index-backed searches against the in-memory provider
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// useTestSearchIndex points the search index at a temporary file, reopened on next use
+func useTestSearchIndex(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "search-index.jsonl")
+	t.Setenv(searchIndexEnvVar, path)
+	reset := func() {
+		searchIndexOnce = sync.Once{}
+		searchIndex, searchIndexErr = nil, nil
+	}
+	reset()
+	t.Cleanup(reset)
+	return path
+}
+
+func TestSearchIndexPath(t *testing.T) {
+	t.Setenv("HOME", "/home/test")
+	t.Setenv(searchIndexEnvVar, "")
+	if got := searchIndexPath(); got != "/home/test/.config/notes-mcp/search-index.jsonl" {
+		t.Errorf("searchIndexPath = %q", got)
+	}
+	t.Setenv(searchIndexEnvVar, "/tmp/index.jsonl")
+	if got := searchIndexPath(); got != "/tmp/index.jsonl" {
+		t.Errorf("searchIndexPath = %q, want the override", got)
+	}
+}
+
+// TestIndexBackendSearch tests that an index search over MCP indexes a new note before answering
+func TestIndexBackendSearch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(providerEnvVar, "memory")
+	useTestSearchIndex(t)
+
+	session := connectTestClient(t, newMCPServer(""))
+	if created := callToolResult(t, session, "create_note", map[string]any{"title": "Trip", "content": "Pack the passport"}); created.IsError {
+		t.Fatalf("create_note failed: %s", firstText(created))
+	}
+
+	result := callToolResult(t, session, "search_notes_advanced", map[string]any{"query": "passport", "search_in": "body", "backend": "index"})
+	if result.IsError || !strings.Contains(firstText(result), "Trip") {
+		t.Errorf("expected the index search to find the new note, got %s", firstText(result))
+	}
+}
+
+func TestIndexCommands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(providerEnvVar, "memory")
+	useTestSearchIndex(t)
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		rootCmd.SetArgs(args)
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(io.Discard)
+		defer func() {
+			rootCmd.SetArgs([]string{})
+			rootCmd.SetOut(nil)
+			rootCmd.SetErr(nil)
+		}()
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out.String()
+	}
+
+	if out := run("index", "rebuild"); !strings.Contains(out, "Rebuilt the index with 0 notes") {
+		t.Errorf("unexpected rebuild output %q", out)
+	}
+	if out := run("index", "update"); !strings.Contains(out, "Indexed 0 notes, pruned 0") {
+		t.Errorf("unexpected update output %q", out)
+	}
+	if out := run("index", "prune"); !strings.Contains(out, "Pruned 0 deleted notes") {
+		t.Errorf("unexpected prune output %q", out)
+	}
+	out := run("index", "status")
+	if !strings.Contains(out, "Notes indexed: 0") || !strings.Contains(out, "Last updated:") {
+		t.Errorf("unexpected status output %q", out)
+	}
+}
+
+// TestIndexProgress tests that progress lines name each note and are silenced by --quiet
+func TestIndexProgress(t *testing.T) {
+	var out bytes.Buffer
+	progress := indexProgress(&out)
+	progress(1, 2, "Trip")
+	if out.String() != "[1/2] Trip\n" {
+		t.Errorf("unexpected progress %q", out.String())
+	}
+
+	quietOutput = true
+	defer func() { quietOutput = false }()
+	progress(2, 2, "Groceries")
+	if strings.Contains(out.String(), "Groceries") {
+		t.Errorf("expected --quiet to silence progress, got %q", out.String())
+	}
+}
@@ -0,0 +1,122 @@
+// ABOUTME: Keys command for managing the encryption key of local stores
+// ABOUTME: Rotates the Keychain-held AES key and re-encrypts every local store with it
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// encryptStoresEnvVar enables AES-GCM encryption of local stores with a key kept in the Keychain
+const encryptStoresEnvVar = "NOTES_MCP_ENCRYPT_STORES"
+
+// newSecretStore returns the secret store holding notes-mcp keys and tokens
+// It is a variable so tests can substitute an in-memory store for the Keychain.
+var newSecretStore = func() services.SecretStore {
+	return services.NewKeychainSecretStore(osascriptTimeout)
+}
+
+// newStoreEncryptor returns the encryptor for local stores, or nil when NOTES_MCP_ENCRYPT_STORES is off
+func newStoreEncryptor() (*services.StoreEncryptor, error) {
+	if !envEnabled(encryptStoresEnvVar) {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	encryptor, err := services.LoadStoreEncryptor(ctx, newSecretStore())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load store encryption key: %w", err)
+	}
+	return encryptor, nil
+}
+
+// heldStoreLocks keeps this process's shared store locks open until it exits
+var (
+	heldStoreLocksMu sync.Mutex
+	heldStoreLocks   []*services.StoreLock
+)
+
+// lockStoreForWriting takes a shared lock on the local store at path for the rest of the process
+// Call it before loading the store key, so "keys rotate" can't switch keys or rewrite the store under a writer.
+func lockStoreForWriting(path string) error {
+	lock, err := services.LockStoreShared(path)
+	if err != nil {
+		return err
+	}
+
+	heldStoreLocksMu.Lock()
+	defer heldStoreLocksMu.Unlock()
+	heldStoreLocks = append(heldStoreLocks, lock)
+	return nil
+}
+
+// localStorePaths lists the on-disk stores that hold note data or activity
+func localStorePaths() []string {
+	paths := []string{}
+	if path := os.Getenv(auditLogEnvVar); path != "" {
+		paths = append(paths, path)
+	}
+	if path := scriptLogPath(); path != "" {
+		paths = append(paths, path)
+	}
+	for _, envVar := range []string{historyFileEnvVar, cacheFileEnvVar} {
+		if path := os.Getenv(envVar); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if path := searchIndexPath(); path != "" {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the encryption key for local stores",
+	Long: `Manages the AES-256 key used to encrypt local stores when NOTES_MCP_ENCRYPT_STORES=true.
+The key is kept in the login Keychain under the "notes-mcp" service.`,
+}
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Generate a new store key and re-encrypt local stores with it",
+	Long: `Generates a new AES-256 key, re-encrypts every local store (plaintext records included) with it,
+and then makes it the active key. The old key is kept for reading until every store is confirmed re-encrypted.
+Rotation refuses to start while an MCP server or another command has a store open; stop it and retry.
+If rotation is interrupted, run it again to finish.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		paths := localStorePaths()
+		keyID, err := services.RotateStoreKey(ctx, newSecretStore(), paths)
+		if errors.Is(err, services.ErrStoreInUse) {
+			return fmt.Errorf("%w; stop the MCP server or other notes-mcp commands using it and retry", err)
+		}
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		printSuccess(out, "Rotated store key (new key ID %s)", keyID)
+		for _, path := range paths {
+			printSuccess(out, "  re-encrypted %s", path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keysCmd)
+	keysCmd.AddCommand(keysRotateCmd)
+}
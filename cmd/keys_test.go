@@ -0,0 +1,119 @@
+// ABOUTME: Unit tests for the keys command and encrypted audit logging
+// ABOUTME: Uses an in-memory secret store in place of the Keychain
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// fakeSecretStore implements services.SecretStore in memory
+type fakeSecretStore map[string]string
+
+func (f fakeSecretStore) Get(ctx context.Context, name string) (string, error) {
+	if value, ok := f[name]; ok {
+		return value, nil
+	}
+	return "", services.ErrSecretNotFound
+}
+
+func (f fakeSecretStore) Set(ctx context.Context, name, value string) error {
+	f[name] = value
+	return nil
+}
+
+func (f fakeSecretStore) Delete(ctx context.Context, name string) error {
+	delete(f, name)
+	return nil
+}
+
+// useFakeSecretStore swaps the Keychain for an in-memory store for the duration of a test
+func useFakeSecretStore(t *testing.T) fakeSecretStore {
+	t.Helper()
+	store := fakeSecretStore{}
+	original := newSecretStore
+	newSecretStore = func() services.SecretStore { return store }
+	t.Cleanup(func() { newSecretStore = original })
+	return store
+}
+
+// TestEncryptedAuditLog tests that audit entries are sealed when store encryption is enabled
+func TestEncryptedAuditLog(t *testing.T) {
+	useFakeSecretStore(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	t.Setenv(auditLogEnvVar, path)
+	t.Setenv(encryptStoresEnvVar, "true")
+
+	audit := newAuditLogger()
+	if audit == nil || audit.encryptor == nil {
+		t.Fatal("expected an encrypting audit logger")
+	}
+	audit.record(auditEntry{RequestID: "abc123", Tool: "get_note_content"})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("get_note_content")) {
+		t.Errorf("audit log contains plaintext: %q", data)
+	}
+
+	opened, err := audit.encryptor.Open(bytes.TrimSpace(data))
+	if err != nil || !strings.Contains(string(opened), `"request_id":"abc123"`) {
+		t.Errorf("unexpected decrypted entry %q (%v)", opened, err)
+	}
+}
+
+// TestKeysRotateCommand tests that rotation re-encrypts the audit log, note history, cache file, and search index
+func TestKeysRotateCommand(t *testing.T) {
+	secrets := useFakeSecretStore(t)
+	dir := t.TempDir()
+	stores := map[string]string{
+		auditLogEnvVar:    filepath.Join(dir, "audit.log"),
+		historyFileEnvVar: filepath.Join(dir, "history.jsonl"),
+		cacheFileEnvVar:   filepath.Join(dir, "cache.jsonl"),
+		searchIndexEnvVar: filepath.Join(dir, "search-index.jsonl"),
+	}
+	for envVar, path := range stores {
+		t.Setenv(envVar, path)
+		if err := os.WriteFile(path, []byte(`{"store":"`+envVar+`"}`+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	rootCmd.SetArgs([]string{"keys", "rotate"})
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(io.Discard)
+	defer func() {
+		rootCmd.SetArgs([]string{})
+		rootCmd.SetOut(nil)
+	}()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("keys rotate failed: %v", err)
+	}
+
+	encryptor, err := services.LoadStoreEncryptor(context.Background(), secrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for envVar, path := range stores {
+		if !strings.Contains(out.String(), "re-encrypted "+path) {
+			t.Errorf("expected re-encrypted %s in output, got %q", path, out.String())
+		}
+		data, _ := os.ReadFile(path)
+		opened, err := encryptor.Open(bytes.TrimSpace(data))
+		if err != nil || string(opened) != `{"store":"`+envVar+`"}` {
+			t.Errorf("unexpected %s contents %q (%v)", envVar, opened, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// ABOUTME: List-folder command printing the notes in a folder, optionally including subfolders
+// ABOUTME: Lets scripts read folder contents without going through the MCP server
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listFolderRecursive bool
+	listFolderJSON      bool
+	listFolderTemplate  string
+)
+
+// folderNote is a note listed by list-folder with its path relative to the listed folder
+type folderNote struct {
+	services.Note
+	Path string `json:"path"`
+}
+
+var listFolderCmd = &cobra.Command{
+	Use:   "list-folder <folder>",
+	Short: "List the notes in a folder",
+	Long: `Lists the notes in a folder, newest first, one title per line. With --recursive, notes in
+subfolders are included and printed as "<subfolder>/<title>" relative to the folder; the folder
+may then also be given as a path such as "Work/Projects". --json prints each note's id, title,
+folder, dates, and shared/locked flags, plus its relative path, and --template prints a Go template
+per note, e.g. '{{.Path}}\t{{.Modified}}'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		folder := args[0]
+		if strings.TrimSpace(folder) == "" {
+			return fmt.Errorf("%w: folder is required", services.ErrInvalidInput)
+		}
+		tmpl, err := parseListTemplate(listFolderTemplate)
+		if err != nil {
+			return err
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		notes, err := listFolderNotes(ctx, notesService, folder, listFolderRecursive)
+		if err != nil {
+			return fmt.Errorf("failed to list notes in folder: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		if listFolderJSON {
+			output, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format notes: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+		if tmpl != nil {
+			data := make([]listTemplateNote, 0, len(notes))
+			for _, note := range notes {
+				data = append(data, newListTemplateNote(note.Note, note.Path))
+			}
+			return printNoteTemplate(out, tmpl, data)
+		}
+
+		for _, note := range notes {
+			fmt.Fprintln(out, note.Path) //nolint:errcheck // stdout write failure is non-critical
+		}
+		return nil
+	},
+}
+
+// listFolderNotes lists a folder's notes, and with recursive those of every folder below it
+func listFolderNotes(ctx context.Context, notesService services.NotesService, folder string, recursive bool) ([]folderNote, error) {
+	if !recursive {
+		notes, err := notesService.ListNotesWithMetadata(ctx, folder)
+		if err != nil {
+			return nil, err
+		}
+		listed := make([]folderNote, 0, len(notes))
+		for _, note := range notes {
+			listed = append(listed, folderNote{Note: note, Path: note.Title})
+		}
+		return listed, nil
+	}
+
+	root, err := notesService.GetFolderHierarchy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subtree, ok := services.FolderSubtree(root, folder)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", services.ErrFolderNotFound, folder)
+	}
+
+	listed := []folderNote{}
+	base := subtree[0].Path
+	for _, sub := range subtree {
+		name := sub.Path[strings.LastIndex(sub.Path, "/")+1:]
+		notes, err := notesService.ListNotesWithMetadata(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		relative := strings.TrimPrefix(strings.TrimPrefix(sub.Path, base), "/")
+		for _, note := range notes {
+			path := note.Title
+			if relative != "" {
+				path = relative + "/" + note.Title
+			}
+			listed = append(listed, folderNote{Note: note, Path: path})
+		}
+	}
+	return listed, nil
+}
+
+func init() {
+	rootCmd.AddCommand(listFolderCmd)
+
+	listFolderCmd.Flags().BoolVar(&listFolderRecursive, "recursive", false, "Include notes in subfolders")
+	listFolderCmd.Flags().BoolVar(&listFolderJSON, "json", false, "Print the notes as JSON")
+	addListTemplateFlag(listFolderCmd, &listFolderTemplate)
+}
@@ -0,0 +1,49 @@
+// ABOUTME: Unit tests for the list-folder command
+// ABOUTME: Tests folder and recursive listings against the in-memory service
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// TestListFolderNotes tests listing a folder with and without its subfolders
+func TestListFolderNotes(t *testing.T) {
+	ctx := context.Background()
+	notes := services.NewMemoryNotesService()
+	_ = notes.CreateFolder(ctx, "Work", "")
+	_ = notes.CreateFolder(ctx, "Archive", "Work")
+	for title, folder := range map[string]string{"Roadmap": "Work", "Old plan": "Archive", "Inbox": "Notes"} {
+		_, _ = notes.CreateNote(ctx, title, "<div>"+title+"</div>", nil)
+		_ = notes.MoveNote(ctx, title, folder)
+	}
+
+	pathsOf := func(listed []folderNote) []string {
+		paths := []string{}
+		for _, note := range listed {
+			paths = append(paths, note.Path)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	listed, err := listFolderNotes(ctx, notes, "Work", false)
+	if err != nil || !reflect.DeepEqual(pathsOf(listed), []string{"Roadmap"}) {
+		t.Errorf("listFolderNotes(Work) = %v, %v", pathsOf(listed), err)
+	}
+
+	listed, err = listFolderNotes(ctx, notes, "Work", true)
+	if err != nil || !reflect.DeepEqual(pathsOf(listed), []string{"Archive/Old plan", "Roadmap"}) {
+		t.Errorf("listFolderNotes(Work, recursive) = %v, %v", pathsOf(listed), err)
+	}
+
+	if _, err := listFolderNotes(ctx, notes, "Missing", true); !errors.Is(err, services.ErrFolderNotFound) {
+		t.Errorf("expected ErrFolderNotFound, got %v", err)
+	}
+}
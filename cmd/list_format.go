@@ -0,0 +1,156 @@
+// ABOUTME: Output formats shared by CLI commands that list notes
+// ABOUTME: Prints note titles one per line, CSV with id, folder, dates, and shared/locked flags, or a --template per note
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// Note list output formats
+const (
+	listFormatText = "text"
+	listFormatCSV  = "csv"
+)
+
+// validateListFormat checks a --format value for note listing commands
+func validateListFormat(format string) error {
+	if format != listFormatText && format != listFormatCSV {
+		return fmt.Errorf("%w: unknown format %q (use text or csv)", services.ErrInvalidInput, format)
+	}
+	return nil
+}
+
+// printNoteList writes notes as newline-separated titles or as CSV
+func printNoteList(w io.Writer, notes []services.Note, format string) error {
+	if format == listFormatCSV {
+		return services.WriteNotesCSV(w, notes)
+	}
+
+	for _, note := range notes {
+		fmt.Fprintln(w, note.Title) //nolint:errcheck // stdout write failure is non-critical
+	}
+	return nil
+}
+
+// withNoteMetadata replaces title-only search results with full metadata for CSV output
+// Notes whose metadata can't be read are kept as they are rather than dropped.
+func withNoteMetadata(ctx context.Context, notesService services.NotesService, notes []services.Note) []services.Note {
+	detailed := make([]services.Note, 0, len(notes))
+	for _, note := range notes {
+		if full, err := notesService.GetNoteMetadata(ctx, note.Title); err == nil {
+			note = *full
+		}
+		detailed = append(detailed, note)
+	}
+	return detailed
+}
+
+// listTemplateEscapes turns the escapes people type in shell-quoted templates into the characters they mean
+var listTemplateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// listTemplateNote is the data a --template is executed with for each listed note
+type listTemplateNote struct {
+	ID       string
+	Title    string
+	Folder   string
+	Path     string // the note's path relative to the listed folder for list-folder, otherwise its title
+	Tags     []string
+	Created  listTime
+	Modified listTime
+	Shared   bool
+	Locked   bool
+}
+
+// listTime is a note date that prints as "2006-01-02 15:04" in local time, or nothing when unknown
+// Its time.Time methods stay available, e.g. {{.Modified.Format "Jan 2"}}.
+type listTime struct {
+	time.Time
+}
+
+// String formats the date in local time
+func (t listTime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// newListTemplateNote builds a note's template data, preferring the dates Notes reports
+func newListTemplateNote(note services.Note, path string) listTemplateNote {
+	created := note.CreationDate
+	if created.IsZero() {
+		created = note.Created
+	}
+	modified := note.ModificationDate
+	if modified.IsZero() {
+		modified = note.Modified
+	}
+	return listTemplateNote{
+		ID:       note.ID,
+		Title:    note.Title,
+		Folder:   note.Folder,
+		Path:     path,
+		Tags:     note.Tags,
+		Created:  listTime{created},
+		Modified: listTime{modified},
+		Shared:   note.Shared,
+		Locked:   note.PasswordProtected,
+	}
+}
+
+// addListTemplateFlag registers the --template flag of a note listing command
+func addListTemplateFlag(cmd *cobra.Command, value *string) {
+	cmd.Flags().StringVar(value, "template", "",
+		`Go template printed for each note, e.g. '{{.Title}}\t{{.Folder}}\t{{.Modified}}' (fields: ID, Title, Folder, Path, Tags, Created, Modified, Shared, Locked)`)
+}
+
+// parseListTemplate parses a --template value, or returns nil when it is empty
+// The template is tried on an empty note, so a misspelled field fails before any note is read.
+func parseListTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("template").Parse(listTemplateEscapes.Replace(text))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid --template: %v", services.ErrInvalidInput, err)
+	}
+	if err := tmpl.Execute(io.Discard, listTemplateNote{}); err != nil {
+		return nil, fmt.Errorf("%w: invalid --template: %v", services.ErrInvalidInput, err)
+	}
+	return tmpl, nil
+}
+
+// printNoteTemplate executes tmpl for each note, ending each note's output with a newline
+func printNoteTemplate(w io.Writer, tmpl *template.Template, notes []listTemplateNote) error {
+	for _, note := range notes {
+		var line strings.Builder
+		if err := tmpl.Execute(&line, note); err != nil {
+			return fmt.Errorf("failed to apply --template to %q: %w", note.Title, err)
+		}
+		if !strings.HasSuffix(line.String(), "\n") {
+			line.WriteString("\n")
+		}
+		if _, err := io.WriteString(w, line.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listTemplateNotes builds the template data of notes listed by title
+func listTemplateNotes(notes []services.Note) []listTemplateNote {
+	data := make([]listTemplateNote, 0, len(notes))
+	for _, note := range notes {
+		data = append(data, newListTemplateNote(note, note.Title))
+	}
+	return data
+}
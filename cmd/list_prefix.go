@@ -0,0 +1,65 @@
+// ABOUTME: List-prefix command for notes named with a shared title prefix
+// ABOUTME: Lists "Project X — ..." style notes, sorted by title, without a full search
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	listPrefixFolder   string
+	listPrefixFormat   string
+	listPrefixTemplate string
+)
+
+var listPrefixCmd = &cobra.Command{
+	Use:   "list-prefix <prefix>",
+	Short: "List notes whose titles begin with a prefix",
+	Long: `Lists notes whose titles begin with prefix (case-insensitive), sorted by title, for naming
+conventions like "Project X — ..." or "2024-01-05 ...". Notes.app filters titles itself, so this
+is much faster than a search on large libraries.
+--format=csv prints id, title, folder, created, modified, shared, and locked columns instead, and
+--template prints a Go template per note, e.g. '{{.Title}}\t{{.Modified}}'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateListFormat(listPrefixFormat); err != nil {
+			return err
+		}
+		tmpl, err := parseListTemplate(listPrefixTemplate)
+		if err != nil {
+			return err
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		notes, err := notesService.ListNotesByPrefix(ctx, args[0], listPrefixFolder)
+		if err != nil {
+			return fmt.Errorf("failed to list notes: %w", err)
+		}
+
+		if tmpl != nil {
+			err = printNoteTemplate(cmd.OutOrStdout(), tmpl, listTemplateNotes(notes))
+		} else {
+			err = printNoteList(cmd.OutOrStdout(), notes, listPrefixFormat)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write notes: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listPrefixCmd)
+
+	listPrefixCmd.Flags().StringVar(&listPrefixFolder, "folder", "", "Limit the listing to a folder")
+	listPrefixCmd.Flags().StringVar(&listPrefixFormat, "format", listFormatText, "Output format: text or csv")
+	addListTemplateFlag(listPrefixCmd, &listPrefixTemplate)
+}
@@ -10,10 +10,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/spf13/cobra"
 )
@@ -32,28 +35,46 @@ func init() {
 // Tool input argument structs with JSON schema annotations
 
 type CreateNoteArgs struct {
-	Title   string   `json:"title" jsonschema:"The title of the note"`
-	Content string   `json:"content" jsonschema:"The content of the note"`
-	Tags    []string `json:"tags,omitempty" jsonschema:"Optional tags for the note"`
+	Title       string   `json:"title" jsonschema:"The title of the note"`
+	Content     string   `json:"content" jsonschema:"The content of the note"`
+	ContentType string   `json:"content_type,omitempty" jsonschema:"How to read content: plain (default; newlines become line breaks), markdown (converted to formatted text), or html (sanitized and kept as markup)"`
+	Tags        []string `json:"tags,omitempty" jsonschema:"Optional tags for the note"`
+	Folder      string   `json:"folder,omitempty" jsonschema:"Optional folder to create the note in (default: the session root folder, if one is set)"`
+	Template    bool     `json:"template,omitempty" jsonschema:"Expand {{date}}, {{time}}, and {{week}} in the title, e.g. {{date:Jan 2}} (default: false, the title is kept as given)"`
 }
 
 type SearchNotesArgs struct {
-	Query string `json:"query" jsonschema:"The search query to find notes by title"`
+	Query          string `json:"query" jsonschema:"The search query to find notes by title"`
+	AllFolders     bool   `json:"all_folders,omitempty" jsonschema:"Search every folder, ignoring the session root folder"`
+	IncludeMetrics bool   `json:"include_metrics,omitempty" jsonschema:"Add word count, read time, and checklist/attachment flags to each result"`
 }
 
 type GetNoteContentArgs struct {
-	Title string `json:"title" jsonschema:"The title of the note to retrieve"`
+	Title     string `json:"title" jsonschema:"The title of the note to retrieve"`
+	Highlight string `json:"highlight,omitempty" jsonschema:"Optional query whose matches in the content are wrapped in <mark> tags"`
+}
+
+type HasNoteChangedArgs struct {
+	Title string `json:"title" jsonschema:"The title of the note to check"`
+	Hash  string `json:"hash" jsonschema:"The content_hash returned by an earlier get_note_content call"`
 }
 
 type UpdateNoteArgs struct {
-	Title   string `json:"title" jsonschema:"The title of the note to update"`
-	Content string `json:"content" jsonschema:"The new content for the note"`
+	Title            string `json:"title" jsonschema:"The title of the note to update"`
+	Content          string `json:"content" jsonschema:"The new content for the note"`
+	ContentType      string `json:"content_type,omitempty" jsonschema:"How to read content: plain (default; newlines become line breaks), markdown (converted to formatted text), or html (sanitized and kept as markup)"`
+	ExpectedModified string `json:"expected_modified,omitempty" jsonschema:"Optional modification_date (RFC3339) from when the note was read; the update fails with a conflict if the note has changed since"`
+	ExpectedHash     string `json:"expected_hash,omitempty" jsonschema:"Optional SHA-256 hex of the note body from when it was read; the update fails with a conflict if the body has changed since"`
 }
 
 type DeleteNoteArgs struct {
 	Title string `json:"title" jsonschema:"The title of the note to delete"`
 }
 
+type OpenNoteArgs struct {
+	Title string `json:"title" jsonschema:"The title of the note to open in Notes.app"`
+}
+
 type CreateFolderArgs struct {
 	Name         string `json:"name" jsonschema:"The name of the folder to create"`
 	ParentFolder string `json:"parent_folder,omitempty" jsonschema:"Optional parent folder name for nested folders"`
@@ -64,12 +85,34 @@ type MoveNoteArgs struct {
 	TargetFolder string `json:"target_folder" jsonschema:"The target folder to move the note to"`
 }
 
+type GetFolderHierarchyArgs struct {
+	Sort string `json:"sort,omitempty" jsonschema:"Order folders at each level by 'name' or by 'notes' (most notes first, counting subfolders); default is Notes.app order"`
+	Flat bool   `json:"flat,omitempty" jsonschema:"Return a flat list of 'Parent/Child' folder paths instead of a nested tree"`
+}
+
 type SearchNotesAdvancedArgs struct {
-	Query    string `json:"query" jsonschema:"The search query"`
-	SearchIn string `json:"search_in,omitempty" jsonschema:"Where to search: 'title', 'body', or 'both' (default: 'title')"`
-	Folder   string `json:"folder,omitempty" jsonschema:"Optional folder name to limit search scope"`
-	DateFrom string `json:"date_from,omitempty" jsonschema:"Optional start date filter (YYYY-MM-DD format)"`
-	DateTo   string `json:"date_to,omitempty" jsonschema:"Optional end date filter (YYYY-MM-DD format)"`
+	Query          string   `json:"query" jsonschema:"The search query"`
+	SearchIn       string   `json:"search_in,omitempty" jsonschema:"Where to search: 'title', 'body', or 'both' (default: 'title')"`
+	Folder         string   `json:"folder,omitempty" jsonschema:"Optional folder name to limit search scope"`
+	DateFrom       string   `json:"date_from,omitempty" jsonschema:"Optional start date filter (YYYY-MM-DD format), from the start of that day"`
+	DateTo         string   `json:"date_to,omitempty" jsonschema:"Optional end date filter (YYYY-MM-DD format), through the end of that day"`
+	Timezone       string   `json:"timezone,omitempty" jsonschema:"IANA time zone the dates are days in, e.g. 'America/New_York' (default: NOTES_MCP_TIMEZONE or the system zone)"`
+	Backend        string   `json:"backend,omitempty" jsonschema:"Search backend: 'applescript', 'spotlight' (Spotlight index first, falling back to AppleScript; ignored with folder/date filters), or 'index' (the local full-text index, updated with notes modified since before searching)"`
+	AllFolders     bool     `json:"all_folders,omitempty" jsonschema:"Search every folder, ignoring the session root folder (an explicit folder still applies)"`
+	MatchHTML      bool     `json:"match_html,omitempty" jsonschema:"Match body queries against the raw HTML body instead of its plain text (default: false)"`
+	IncludeMetrics bool     `json:"include_metrics,omitempty" jsonschema:"Add word count, read time, and checklist/attachment flags to each result"`
+	HasAttachments bool     `json:"has_attachments,omitempty" jsonschema:"Only match notes with at least one attachment"`
+	HasChecklist   bool     `json:"has_checklist,omitempty" jsonschema:"Only match notes containing a checklist"`
+	Shared         bool     `json:"shared,omitempty" jsonschema:"Only match shared notes"`
+	Locked         bool     `json:"locked,omitempty" jsonschema:"Only match password-protected notes"`
+	ExcludeFolders []string `json:"exclude_folders,omitempty" jsonschema:"Folder names to skip (e.g. ['Recently Deleted', 'Archive'])"`
+	Explain        bool     `json:"explain,omitempty" jsonschema:"Return how the search ran instead of the results: strategy, generated AppleScript or Spotlight query, candidate and result counts, matches, and timings"`
+}
+
+type ListNotesByPrefixArgs struct {
+	Prefix     string `json:"prefix" jsonschema:"Title prefix to match, e.g. 'Project X —' (case-insensitive)"`
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to list (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"List every folder, ignoring the session root folder"`
 }
 
 type GetNoteAttachmentsArgs struct {
@@ -79,21 +122,216 @@ type GetNoteAttachmentsArgs struct {
 type GetAttachmentContentArgs struct {
 	FilePath  string `json:"file_path" jsonschema:"The file path of the attachment to retrieve"`
 	MaxSizeMB int    `json:"max_size_mb,omitempty" jsonschema:"Maximum file size in MB (default: 10)"`
+	Offset    int64  `json:"offset,omitempty" jsonschema:"Byte offset to start reading from; setting offset or length returns one chunk as JSON"`
+	Length    int64  `json:"length,omitempty" jsonschema:"Number of bytes to read from offset (default and cap: max_size_mb)"`
+	CopyToDir string `json:"copy_to_dir,omitempty" jsonschema:"Copy the attachment into this existing directory under NOTES_MCP_EXPORT_DIR (relative to it, or absolute inside it) and return its path instead of the content"`
+}
+
+type GetAttachmentThumbnailArgs struct {
+	NoteTitle      string `json:"note_title" jsonschema:"The title of the note containing the image"`
+	AttachmentName string `json:"attachment_name" jsonschema:"The name of the image attachment, as returned by get_note_attachments"`
+	MaxPx          int    `json:"max_px,omitempty" jsonschema:"Longest edge of the thumbnail in pixels (default: 256, max: 1024)"`
 }
 
 type ExportNoteMarkdownArgs struct {
 	NoteTitle string `json:"note_title" jsonschema:"The title of the note to export as markdown"`
+	Format    string `json:"format,omitempty" jsonschema:"Markdown flavor: 'markdown' (default) or 'obsidian' (front matter, wikilinks, attachment embeds)"`
+	AssetsDir string `json:"assets_dir,omitempty" jsonschema:"Optional directory to copy attachments into for the 'obsidian' format, or images into with images 'files'"`
+	Images    string `json:"images,omitempty" jsonschema:"How the 'markdown' format shows images: 'placeholder' (default, attachment:<name> links), 'data' (inline data URIs), or 'files' (copied into assets_dir)"`
+	Highlight string `json:"highlight,omitempty" jsonschema:"Optional query whose matches are wrapped in **bold**"`
+	MaxChars  int    `json:"max_chars,omitempty" jsonschema:"Optional budget in characters; a longer note is trimmed at a paragraph break, or reduced to its outline when the budget is small"`
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"Optional budget in model tokens, estimated at 4 characters per token"`
 }
 
 type ExportNoteTextArgs struct {
 	NoteTitle string `json:"note_title" jsonschema:"The title of the note to export as plain text"`
+	Highlight string `json:"highlight,omitempty" jsonschema:"Optional query whose matches are wrapped in **bold**"`
+}
+
+type ExportNotesCSVArgs struct {
+	Folder         string `json:"folder,omitempty" jsonschema:"Optional folder to export (default: the session root folder, if one is set, otherwise every note)"`
+	Query          string `json:"query,omitempty" jsonschema:"Optional text the note titles must contain (case-insensitive)"`
+	AllFolders     bool   `json:"all_folders,omitempty" jsonschema:"Export every folder, ignoring the session root folder"`
+	IncludeMetrics bool   `json:"include_metrics,omitempty" jsonschema:"Add word_count, read_time_minutes, has_checklist, and has_attachments columns (reads every exported note's body)"`
+}
+
+type ImportHTMLArgs struct {
+	Path      string `json:"path" jsonschema:"Path of the HTML file to import, e.g. a saved web page"`
+	SourceURL string `json:"source_url,omitempty" jsonschema:"URL to record at the top of the note (default: detected from the saved page)"`
+	Folder    string `json:"folder,omitempty" jsonschema:"Folder to put the note in (default: the session root folder, if one is set)"`
+}
+
+type TranscribeAttachmentArgs struct {
+	NoteTitle      string `json:"note_title" jsonschema:"The title of the note containing the audio"`
+	AttachmentName string `json:"attachment_name" jsonschema:"The name of the audio attachment, as returned by get_note_attachments"`
+	AppendToNote   bool   `json:"append_to_note,omitempty" jsonschema:"Append the transcript to the end of the note as well as returning it"`
+}
+
+type ClipURLArgs struct {
+	URL    string `json:"url" jsonschema:"The http or https URL of the page to clip"`
+	Folder string `json:"folder,omitempty" jsonschema:"Folder to put the note in (default: the session root folder, if one is set)"`
+}
+
+type ExtractActionItemsArgs struct {
+	NoteTitle       string `json:"note_title" jsonschema:"The title of the note to extract action items from"`
+	PushToReminders bool   `json:"push_to_reminders,omitempty" jsonschema:"Create Apple Reminders for unchecked items (requires the Reminders integration)"`
+	List            string `json:"list,omitempty" jsonschema:"Optional Reminders list name (default: the default Reminders list)"`
+}
+
+type FindActionItemsArgs struct {
+	Query      string `json:"query" jsonschema:"Search term matched against note titles and bodies (e.g. 'TODO' or a project name)"`
+	Status     string `json:"status,omitempty" jsonschema:"Which items to return: 'open' (default), 'done', or 'all'"`
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to search (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Search every folder, ignoring the session root folder"`
+}
+
+type GetUpcomingDeadlinesArgs struct {
+	Days       int    `json:"days,omitempty" jsonschema:"How many days ahead to look (default: 7); overdue items are always included"`
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to limit the notes scanned (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Scan every folder, ignoring the session root folder"`
+}
+
+type PinNoteArgs struct {
+	Title string `json:"title" jsonschema:"The title of the note to pin"`
+}
+
+type AddNoteTagsArgs struct {
+	Title string   `json:"title" jsonschema:"The title of the note to tag"`
+	Tags  []string `json:"tags" jsonschema:"Tags to add, with or without a leading #"`
+}
+
+type CreateReminderFromNoteArgs struct {
+	NoteTitle string `json:"note_title" jsonschema:"The title of the note the reminder refers to"`
+	Text      string `json:"text,omitempty" jsonschema:"Optional reminder text (default: the note title)"`
+	List      string `json:"list,omitempty" jsonschema:"Optional Reminders list name (default: the default Reminders list)"`
+	DueDate   string `json:"due_date,omitempty" jsonschema:"Optional due date (YYYY-MM-DD format)"`
+}
+
+type ReadNotesBundleArgs struct {
+	Notes     []string `json:"notes" jsonschema:"Titles or IDs of the notes to read, in the order they should appear (up to 50)"`
+	MaxChars  int      `json:"max_chars,omitempty" jsonschema:"Optional budget for the whole bundle in characters; notes are trimmed, reduced to outlines, or omitted to fit"`
+	MaxTokens int      `json:"max_tokens,omitempty" jsonschema:"Optional budget in model tokens, estimated at 4 characters per token (the smaller of max_chars and max_tokens applies)"`
+	Priority  string   `json:"priority,omitempty" jsonschema:"Which notes keep their full text under a budget: 'order' (default, as listed) or 'recent' (most recently modified first, also ordering the bundle)"`
+}
+
+type FindStaleNotesArgs struct {
+	Days       int    `json:"days,omitempty" jsonschema:"Minimum days since a note was last modified (default: 90)"`
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to check (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Check every folder, ignoring the session root folder"`
+	MinWords   int    `json:"min_words,omitempty" jsonschema:"Only report notes with at least this many words"`
+}
+
+type FindEmptyNotesArgs struct {
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to check (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Check every folder, ignoring the session root folder"`
+	MaxChars   int    `json:"max_chars,omitempty" jsonschema:"Notes with fewer characters of text than this, not counting the title, are empty (default: 10)"`
+	Delete     bool   `json:"delete,omitempty" jsonschema:"Delete the empty notes found; the user may be asked to confirm first"`
+}
+
+type CheckLinksArgs struct {
+	Folder         string `json:"folder,omitempty" jsonschema:"Optional folder to check (default: the session root folder, if one is set)"`
+	AllFolders     bool   `json:"all_folders,omitempty" jsonschema:"Check every folder, ignoring the session root folder"`
+	Concurrency    int    `json:"concurrency,omitempty" jsonschema:"Links checked at once (default: 8, maximum: 32)"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" jsonschema:"Seconds to wait for each link before counting it dead (default: 10)"`
+}
+
+type FindTitleVariantsArgs struct {
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to check (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Check every folder, ignoring the session root folder"`
+	Merge      bool   `json:"merge,omitempty" jsonschema:"Merge each set into its target note with merge_notes; the user may be asked to confirm first"`
+}
+
+type MergeNotesArgs struct {
+	Target  string   `json:"target" jsonschema:"Title of the note to keep"`
+	Sources []string `json:"sources" jsonschema:"Titles of the notes to append to the target and then delete"`
+}
+
+type ResolveConflictsArgs struct {
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to check (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Check every folder, ignoring the session root folder"`
+	Title      string `json:"title,omitempty" jsonschema:"Only this conflicted title"`
+	Strategy   string `json:"strategy,omitempty" jsonschema:"How to resolve each conflict: 'newest' keeps the newest copy, 'longest' the copy with the most text, and 'merge' adds the lines only other copies have to the newest; omit to only report conflicts"`
+}
+
+type ExportGraphArgs struct {
+	Format     string `json:"format,omitempty" jsonschema:"Output format: 'json' (default) for nodes and edges, or 'dot' for Graphviz"`
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to graph (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Graph every folder, ignoring the session root folder"`
+}
+
+type GetNotePropertiesArgs struct {
+	Title string `json:"title" jsonschema:"Title of the note"`
+}
+
+type SetNotePropertyArgs struct {
+	Title string `json:"title" jsonschema:"Title of the note"`
+	Key   string `json:"key" jsonschema:"Property name: letters, digits, '_', '.', or '-', up to 64 characters"`
+	Value string `json:"value,omitempty" jsonschema:"Value to store; empty removes the property"`
+}
+
+type MarkNoteProcessedArgs struct {
+	Title string `json:"title" jsonschema:"Title of the note the job handled"`
+	Key   string `json:"key,omitempty" jsonschema:"The job's processed marker, e.g. 'summarized' (default: 'processed')"`
+}
+
+type ListUnprocessedNotesArgs struct {
+	Query      string `json:"query,omitempty" jsonschema:"Only notes whose title or body contains this text (default: every note)"`
+	Folder     string `json:"folder,omitempty" jsonschema:"Optional folder to check (default: the session root folder, if one is set)"`
+	AllFolders bool   `json:"all_folders,omitempty" jsonschema:"Check every folder, ignoring the session root folder"`
+	Key        string `json:"key,omitempty" jsonschema:"The job's processed marker, as passed to mark_note_processed (default: 'processed')"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Maximum notes to return (default: 50)"`
+}
+
+type ShareNoteToArgs struct {
+	Title    string `json:"title" jsonschema:"Title of the note to share"`
+	Target   string `json:"target" jsonschema:"Where to send it: 'messages' or 'slack' (the channel of the configured webhook)"`
+	To       string `json:"to,omitempty" jsonschema:"Messages recipient: phone number or email (default: the recipient in share.json)"`
+	MaxChars int    `json:"max_chars,omitempty" jsonschema:"Longest message to send; longer notes are cut at a paragraph or line break (default: 4000)"`
+}
+
+type GenerateWeeklyDigestArgs struct {
+	WeekStart string `json:"week_start,omitempty" jsonschema:"Optional first day of the week to digest (YYYY-MM-DD format, default: 6 days ago, so the digest ends today)"`
+	Folder    string `json:"folder,omitempty" jsonschema:"Optional folder to save the digest in (default: 'Digests', created if missing)"`
+	Timezone  string `json:"timezone,omitempty" jsonschema:"IANA time zone whose days the week covers, e.g. 'Europe/London' (default: NOTES_MCP_TIMEZONE or the system zone)"`
 }
 
 // runMCPServer starts the MCP server in stdio mode
 func runMCPServer(cmd *cobra.Command, args []string) {
-	// Create the notes service
-	executor := services.NewOSAScriptExecutor(10 * time.Second)
-	notesService := services.NewAppleNotesService(executor)
+	server := newMCPServer("")
+
+	// Run the server over stdio transport
+	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+		log.Fatalf("MCP server failed: %v", err)
+	}
+}
+
+// newMCPServer creates the MCP server with all tools, resources, and prompts registered
+// fileRoot, when set, confines the files tools read to those under it, for servers reachable remotely.
+func newMCPServer(fileRoot string) *mcp.Server {
+	// Create the notes service from the configured provider
+	provider, notesService, err := newProviderNotesService()
+	if err != nil {
+		log.Fatalf("Failed to create notes service: %v", err)
+	}
+
+	// NOTES_MCP_READ_ONLY hides writing tools as well as refusing writes in the service
+	if readOnlyEnabled() {
+		provider.Capabilities.ReadOnly = true
+	}
+
+	// Track each session's root folder from client roots and set_root_folder
+	roots := newSessionRoots()
+
+	// Track the notes each session changes and reads for get_session_changes and get_last_note
+	changes := newSessionChanges()
+
+	// Bookmarks and folder briefs persist across sessions in local files
+	bookmarks := services.NewBookmarkStore(bookmarksPath())
+	briefs := services.NewBriefStore(briefsPath())
+
+	// Optionally surface the Automation permission dialog now instead of mid-tool-call
+	permissions := newPermissionState(notesService)
+	startPermissionCheck(permissions)
 
 	// Create the MCP server
 	server := mcp.NewServer(
@@ -101,35 +339,45 @@ func runMCPServer(cmd *cobra.Command, args []string) {
 			Name:    "apple-notes-go",
 			Version: "1.0.0",
 		},
-		nil,
+		rootsServerOptions(roots),
 	)
 
-	// Register the tools
-	registerCreateNoteTool(server, notesService)
-	registerSearchNotesTool(server, notesService)
-	registerGetNoteContentTool(server, notesService)
-	registerUpdateNoteTool(server, notesService)
-	registerDeleteNoteTool(server, notesService)
-	registerListFoldersTool(server, notesService)
-	registerCreateFolderTool(server, notesService)
-	registerMoveNoteTool(server, notesService)
-	registerGetFolderHierarchyTool(server, notesService)
-	registerSearchNotesAdvancedTool(server, notesService)
-	registerGetNoteAttachmentsTool(server, notesService)
-	registerGetAttachmentContentTool(server, notesService)
-	registerExportNoteMarkdownTool(server, notesService)
-	registerExportNoteTextTool(server, notesService)
+	// Tag every request with an ID for log correlation and the optional audit log, resolve
+	// "@alias" note references, scope it to the session's root folder, record the notes it changes,
+	// and note when bookmarked notes are accessed
+	server.AddReceivingMiddleware(requestIDMiddleware(newAuditLogger()), aliasMiddleware(newAliasStore(), notesService),
+		rootFolderMiddleware(roots), sessionChangesMiddleware(changes), bookmarkAccessMiddleware(bookmarks))
+
+	// Enforce per-session quotas when any are configured
+	if limits := quotaLimitsFromEnv(); limits.enabled() {
+		server.AddReceivingMiddleware(quotaMiddleware(newSessionQuotas(limits)))
+	}
+
+	// Register the tools the provider and configuration support
+	deps := &toolDeps{
+		notes:       notesService,
+		provider:    provider.Name,
+		roots:       roots,
+		changes:     changes,
+		bookmarks:   bookmarks,
+		briefs:      briefs,
+		linkChecker: services.NewHTTPLinkChecker(0),
+		permissions: permissions,
+		metrics:     serviceMetrics,
+		fileRoot:    fileRoot,
+	}
+	registerTools(server, toolRegistry, deps, provider.Capabilities)
 
 	// Register resources
 	registerResources(server, notesService)
+	registerBookmarksResource(server, bookmarks)
+	registerSessionRecentResource(server, changes)
 
-	// Register prompts
+	// Register prompts, then user templates that add to or override them
 	registerPrompts(server, notesService)
+	registerUserPrompts(server, loadUserPrompts(userPromptsDir()))
 
-	// Run the server over stdio transport
-	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
-		log.Fatalf("MCP server failed: %v", err)
-	}
+	return server
 }
 
 // registerCreateNoteTool registers the create_note tool
@@ -138,23 +386,40 @@ func registerCreateNoteTool(server *mcp.Server, notesService services.NotesServi
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.Title == "" {
-			return nil, nil, fmt.Errorf("%w: title is required", services.ErrInvalidInput)
+		if err := validation.Check(
+			validation.Title("title", input.Title),
+			validation.Content("content", input.Content),
+			validation.Optional(validation.FolderName, "folder", input.Folder),
+		); err != nil {
+			return createErrorResult(err), nil, nil
 		}
-		if input.Content == "" {
-			return nil, nil, fmt.Errorf("%w: content is required", services.ErrInvalidInput)
+		body, err := services.FormatNoteBody(input.Content, input.ContentType)
+		if err != nil {
+			return nil, nil, err
 		}
 
 		// Create a context with timeout for the operation
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
 
-		// Call the service
-		note, err := notesService.CreateNote(opCtx, input.Title, input.Content, input.Tags)
+		// Call the service, expanding title placeholders only when asked
+		createCtx := opCtx
+		if input.Template {
+			createCtx = services.WithTitleTemplate(opCtx)
+		}
+		note, err := notesService.CreateNote(createCtx, input.Title, body, input.Tags)
 		if err != nil {
 			return createErrorResult(err), nil, nil
 		}
 
+		// Notes are created in the default folder; move into the requested or session root folder
+		if folder := scopedFolder(ctx, input.Folder, false); folder != "" {
+			if err := notesService.MoveNote(opCtx, note.Title, folder); err != nil {
+				return createErrorResult(err), nil, nil
+			}
+			note.Folder = folder
+		}
+
 		// Marshal note to JSON for structured output with full metadata
 		noteJSON, err := json.MarshalIndent(note, "", "  ")
 		if err != nil {
@@ -173,7 +438,7 @@ func registerCreateNoteTool(server *mcp.Server, notesService services.NotesServi
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "create_note",
-		Description: "Creates a new note in Apple Notes with the specified title, content, and optional tags. Returns the created note with full metadata including creation/modification dates, folder, and sharing status as JSON.",
+		Description: "Creates a new note in Apple Notes with the specified title, content, and optional tags. With template set, title placeholders {{date}}, {{time}}, and {{week}} (optionally with a format, e.g. {{date:Jan 2}}) are expanded on the server; otherwise the title is kept as given. Set content_type to markdown or html to write formatted content instead of plain text. Returns the created note with full metadata including creation/modification dates, folder, and sharing status as JSON.",
 	}, handler)
 }
 
@@ -191,8 +456,8 @@ func registerSearchNotesTool(server *mcp.Server, notesService services.NotesServ
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
 
-		// Call the service
-		notes, err := notesService.SearchNotes(opCtx, input.Query)
+		// Call the service, limited to the session root folder unless overridden
+		notes, err := searchTitlesInScope(opCtx, notesService, input.Query, scopedFolder(ctx, "", input.AllFolders))
 		if err != nil {
 			return createErrorResult(err), nil, nil
 		}
@@ -214,6 +479,13 @@ func registerSearchNotesTool(server *mcp.Server, notesService services.NotesServ
 			notes = notes[:maxSearchResults]
 		}
 
+		// Metrics read the bodies of the returned notes only, after truncation
+		if input.IncludeMetrics {
+			if notes, err = notesService.WithNoteMetrics(opCtx, notes); err != nil {
+				return createErrorResult(err), nil, nil
+			}
+		}
+
 		// Format results with metadata as JSON
 		result, err := formatSearchResults(notes, totalNotes)
 		if err != nil {
@@ -242,8 +514,8 @@ func registerGetNoteContentTool(server *mcp.Server, notesService services.NotesS
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.Title == "" {
-			return nil, nil, fmt.Errorf("%w: title is required", services.ErrInvalidInput)
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
 		}
 
 		// Create a context with timeout for the operation
@@ -256,14 +528,16 @@ func registerGetNoteContentTool(server *mcp.Server, notesService services.NotesS
 			return createErrorResult(err), nil, nil
 		}
 
-		// Get note content
-		content, err := notesService.GetNoteContent(opCtx, input.Title)
+		// Get note content by the title metadata found, which may differ from input.Title in punctuation
+		content, err := notesService.GetNoteContent(opCtx, note.Title)
 		if err != nil {
 			return createErrorResult(err), nil, nil
 		}
 
-		// Populate content field
-		note.Content = content
+		// Populate content field and its hash for has_note_changed and conditional updates
+		// The hash covers the stored content, not the highlighted copy returned here
+		note.Content = services.HighlightHTML(content, input.Highlight)
+		note.ContentHash = services.ContentHash(content)
 
 		// Marshal note to JSON for structured output with full metadata
 		noteJSON, err := json.MarshalIndent(note, "", "  ")
@@ -283,7 +557,57 @@ func registerGetNoteContentTool(server *mcp.Server, notesService services.NotesS
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_note_content",
-		Description: "Retrieves the full content and metadata of a note from Apple Notes by its title. Returns the note with all fields including creation/modification dates, folder, sharing status, and content as JSON.",
+		Description: "Retrieves the full content and metadata of a note from Apple Notes by its title. Returns the note with all fields including creation/modification dates, folder, sharing status, content, and content_hash (for has_note_changed and update_note's expected_hash) as JSON. Pass highlight to wrap matches of a search query in <mark> tags.",
+	}, handler)
+}
+
+// registerHasNoteChangedTool registers the has_note_changed tool
+func registerHasNoteChangedTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input HasNoteChangedArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		if input.Hash == "" {
+			return nil, nil, fmt.Errorf("%w: hash is required", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		changed, currentHash, err := notesService.HasNoteChanged(opCtx, input.Title, input.Hash)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		output := struct {
+			Changed     bool   `json:"changed"`
+			ContentHash string `json:"content_hash"`
+		}{Changed: changed, ContentHash: currentHash}
+
+		// Marshal to JSON for structured output
+		outputJSON, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format result: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(outputJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "has_note_changed",
+		Description: "Checks whether a note's content has changed since it was read, by comparing against the content_hash from get_note_content. Cheaper than re-reading large notes. Returns {changed, content_hash} as JSON.",
 	}, handler)
 }
 
@@ -293,19 +617,37 @@ func registerUpdateNoteTool(server *mcp.Server, notesService services.NotesServi
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.Title == "" {
-			return nil, nil, fmt.Errorf("%w: title is required", services.ErrInvalidInput)
+		if err := validation.Check(
+			validation.Title("title", input.Title),
+			validation.Content("content", input.Content),
+		); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		body, err := services.FormatNoteBody(input.Content, input.ContentType)
+		if err != nil {
+			return nil, nil, err
 		}
-		if input.Content == "" {
-			return nil, nil, fmt.Errorf("%w: content is required", services.ErrInvalidInput)
+
+		// Parse the optional concurrency precondition
+		precondition := services.UpdatePrecondition{ExpectedHash: input.ExpectedHash}
+		if input.ExpectedModified != "" {
+			expected, err := time.Parse(time.RFC3339, input.ExpectedModified)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: expected_modified must be RFC3339", services.ErrInvalidInput)
+			}
+			precondition.ExpectedModified = &expected
 		}
 
 		// Create a context with timeout for the operation
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
 
-		// Call the service
-		err := notesService.UpdateNote(opCtx, input.Title, input.Content)
+		// Call the service, guarding against concurrent edits when the caller supplied a precondition
+		if precondition.ExpectedModified != nil || precondition.ExpectedHash != "" {
+			err = notesService.UpdateNoteIfUnchanged(opCtx, input.Title, body, precondition)
+		} else {
+			err = notesService.UpdateNote(opCtx, input.Title, body)
+		}
 		if err != nil {
 			return createErrorResult(err), nil, nil
 		}
@@ -322,7 +664,7 @@ func registerUpdateNoteTool(server *mcp.Server, notesService services.NotesServi
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "update_note",
-		Description: "Updates the content of an existing note in Apple Notes by its title. Returns confirmation of note update.",
+		Description: "Updates the content of an existing note in Apple Notes by its title; content_type works as for create_note. Pass expected_modified or expected_hash from a previous read to fail with a conflict instead of overwriting concurrent edits. Returns confirmation of note update.",
 	}, handler)
 }
 
@@ -332,8 +674,13 @@ func registerDeleteNoteTool(server *mcp.Server, notesService services.NotesServi
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.Title == "" {
-			return nil, nil, fmt.Errorf("%w: title is required", services.ErrInvalidInput)
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Ask the user first when the client supports elicitation
+		if refused := confirmDestructive(ctx, req.Session, fmt.Sprintf("delete the note '%s'", input.Title)); refused != nil {
+			return refused, nil, nil
 		}
 
 		// Create a context with timeout for the operation
@@ -358,7 +705,44 @@ func registerDeleteNoteTool(server *mcp.Server, notesService services.NotesServi
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "delete_note",
-		Description: "Deletes a note from Apple Notes by its title. Returns confirmation of note deletion.",
+		Description: "Deletes a note from Apple Notes by its title. The user may be asked to confirm first. Returns confirmation of note deletion.",
+	}, handler)
+}
+
+// registerOpenNoteTool registers the open_note tool
+func registerOpenNoteTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input OpenNoteArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service, noting the title actually opened when it only nearly matches
+		opened := input.Title
+		opCtx = services.WithTitleMatchReport(opCtx, func(requested, actual string) { opened = actual })
+		if err := notesService.OpenNote(opCtx, input.Title); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Note opened in Notes: %s", opened),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "open_note",
+		Description: "Opens a note in the Notes app on the user's Mac, bringing Notes to the front with the note selected. Use after finding a note the user wants to look at.",
 	}, handler)
 }
 
@@ -413,8 +797,11 @@ func registerCreateFolderTool(server *mcp.Server, notesService services.NotesSer
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.Name == "" {
-			return nil, nil, fmt.Errorf("%w: name is required", services.ErrInvalidInput)
+		if err := validation.Check(
+			validation.FolderName("name", input.Name),
+			validation.Optional(validation.FolderName, "parent_folder", input.ParentFolder),
+		); err != nil {
+			return createErrorResult(err), nil, nil
 		}
 
 		// Create a context with timeout for the operation
@@ -457,11 +844,11 @@ func registerMoveNoteTool(server *mcp.Server, notesService services.NotesService
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.NoteTitle == "" {
-			return nil, nil, fmt.Errorf("%w: note_title is required", services.ErrInvalidInput)
-		}
-		if input.TargetFolder == "" {
-			return nil, nil, fmt.Errorf("%w: target_folder is required", services.ErrInvalidInput)
+		if err := validation.Check(
+			validation.Title("note_title", input.NoteTitle),
+			validation.FolderName("target_folder", input.TargetFolder),
+		); err != nil {
+			return createErrorResult(err), nil, nil
 		}
 
 		// Create a context with timeout for the operation
@@ -492,9 +879,14 @@ func registerMoveNoteTool(server *mcp.Server, notesService services.NotesService
 
 // registerGetFolderHierarchyTool registers the get_folder_hierarchy tool
 func registerGetFolderHierarchyTool(server *mcp.Server, notesService services.NotesService) {
-	handler := func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetFolderHierarchyArgs) (
 		*mcp.CallToolResult, any, error) {
 
+		// Validate options
+		if err := services.ValidateFolderSort(input.Sort); err != nil {
+			return nil, nil, err
+		}
+
 		// Create a context with timeout for the operation
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
@@ -506,7 +898,7 @@ func registerGetFolderHierarchyTool(server *mcp.Server, notesService services.No
 		}
 
 		// Marshal to JSON for structured output
-		hierarchyJSON, err := json.MarshalIndent(hierarchy, "", "  ")
+		hierarchyJSON, err := formatFolderHierarchy(hierarchy, input.Sort, input.Flat)
 		if err != nil {
 			return createErrorResult(fmt.Errorf("failed to format hierarchy: %w", err)), nil, nil
 		}
@@ -523,18 +915,18 @@ func registerGetFolderHierarchyTool(server *mcp.Server, notesService services.No
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_folder_hierarchy",
-		Description: "Retrieves the complete folder hierarchy from Apple Notes with note counts. Returns nested folder structure as JSON.",
+		Description: "Retrieves the complete folder hierarchy from Apple Notes with note counts. Returns nested folder structure as JSON; each folder's total_note_count includes its subfolders. Use sort ('name' or 'notes') for a stable order and flat for a list of folder paths.",
 	}, handler)
 }
 
-// parseDateFilter parses a date string in YYYY-MM-DD format
+// parseDateFilter parses a date string in YYYY-MM-DD format as midnight in loc
 // Returns nil pointer and nil error when dateStr is empty (valid case for optional dates)
-func parseDateFilter(dateStr string) (*time.Time, error) {
+func parseDateFilter(dateStr string, loc *time.Location) (*time.Time, error) {
 	if dateStr == "" {
 		var nilTime *time.Time
 		return nilTime, nil
 	}
-	t, err := time.Parse("2006-01-02", dateStr)
+	t, err := time.ParseInLocation("2006-01-02", dateStr, loc)
 	if err != nil {
 		return nil, fmt.Errorf("%w: invalid date format, use YYYY-MM-DD", services.ErrInvalidInput)
 	}
@@ -570,29 +962,59 @@ func registerSearchNotesAdvancedTool(server *mcp.Server, notesService services.N
 			input.SearchIn = "title"
 		}
 
-		// Parse date filters
-		dateFrom, err := parseDateFilter(input.DateFrom)
+		// Parse date filters as whole days in the caller's zone
+		loc, err := resolveTimezone(input.Timezone)
+		if err != nil {
+			return nil, nil, err
+		}
+		dateFrom, err := parseDateFilter(input.DateFrom, loc)
 		if err != nil {
 			return nil, nil, err
 		}
-		dateTo, err := parseDateFilter(input.DateTo)
+		dateTo, err := parseDateFilter(input.DateTo, loc)
 		if err != nil {
 			return nil, nil, err
 		}
+		if dateTo != nil {
+			end := endOfDay(*dateTo)
+			dateTo = &end
+		}
 
 		// Create search options
 		opts := services.SearchOptions{
-			Query:    input.Query,
-			SearchIn: input.SearchIn,
-			Folder:   input.Folder,
-			DateFrom: dateFrom,
-			DateTo:   dateTo,
+			Query:     input.Query,
+			SearchIn:  input.SearchIn,
+			Folder:    scopedFolder(ctx, input.Folder, input.AllFolders),
+			DateFrom:  dateFrom,
+			DateTo:    dateTo,
+			Backend:   defaultSearchBackend(input.Backend),
+			MatchHTML: input.MatchHTML,
+
+			HasAttachments: input.HasAttachments,
+			HasChecklist:   input.HasChecklist,
+			Shared:         input.Shared,
+			Locked:         input.Locked,
+			ExcludeFolders: input.ExcludeFolders,
 		}
 
 		// Create a context with timeout for the operation
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
 
+		if input.Explain {
+			explain, err := explainSearch(opCtx, notesService, opts)
+			if err != nil {
+				return createErrorResult(err), nil, nil
+			}
+			data, err := json.MarshalIndent(explain, "", "  ")
+			if err != nil {
+				return createErrorResult(err), nil, nil
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+			}, nil, nil
+		}
+
 		// Call the service
 		notes, err := notesService.SearchNotesAdvanced(opCtx, opts)
 		if err != nil {
@@ -616,6 +1038,13 @@ func registerSearchNotesAdvancedTool(server *mcp.Server, notesService services.N
 			notes = notes[:maxSearchResults]
 		}
 
+		// Metrics read the bodies of the returned notes only, after truncation
+		if input.IncludeMetrics {
+			if notes, err = notesService.WithNoteMetrics(opCtx, notes); err != nil {
+				return createErrorResult(err), nil, nil
+			}
+		}
+
 		// Format results with metadata
 		result, err := formatSearchResults(notes, totalNotes)
 		if err != nil {
@@ -634,7 +1063,64 @@ func registerSearchNotesAdvancedTool(server *mcp.Server, notesService services.N
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search_notes_advanced",
-		Description: "Searches for notes with advanced filters including body search, folder filtering, and date ranges. Returns notes with full metadata as JSON.",
+		Description: "Searches for notes with advanced filters including body search, folder filtering, and date ranges. Returns notes with full metadata as JSON, or with explain, how the search ran.",
+	}, handler)
+}
+
+// registerListNotesByPrefixTool registers the list_notes_by_prefix tool
+func registerListNotesByPrefixTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListNotesByPrefixArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if strings.TrimSpace(input.Prefix) == "" {
+			return nil, nil, fmt.Errorf("%w: prefix is required", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		notes, err := notesService.ListNotesByPrefix(opCtx, input.Prefix, scopedFolder(ctx, input.Folder, input.AllFolders))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		if len(notes) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("No notes found with titles starting with %q.", input.Prefix),
+					},
+				},
+			}, nil, nil
+		}
+
+		// Limit results like search, since a short prefix can match much of a library
+		totalNotes := len(notes)
+		if totalNotes > maxSearchResults {
+			notes = notes[:maxSearchResults]
+		}
+
+		result, err := formatSearchResults(notes, totalNotes)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: result,
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_notes_by_prefix",
+		Description: "Lists notes whose titles begin with a prefix, sorted by title, with id, folder, dates, and shared/locked flags. Suits naming conventions like 'Project X — ...' or bullet-journal dates ('2024-01-05 ...'). Faster than search_notes on large libraries because Notes.app filters the titles itself.",
 	}, handler)
 }
 
@@ -644,8 +1130,8 @@ func registerGetNoteAttachmentsTool(server *mcp.Server, notesService services.No
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.NoteTitle == "" {
-			return nil, nil, fmt.Errorf("%w: note_title is required", services.ErrInvalidInput)
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
 		}
 
 		// Create a context with timeout for the operation
@@ -687,12 +1173,13 @@ func registerGetNoteAttachmentsTool(server *mcp.Server, notesService services.No
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_note_attachments",
-		Description: "Retrieves all attachments for a note in Apple Notes. Returns attachment metadata including file paths as JSON.",
+		Description: "Retrieves all attachments for a note in Apple Notes. Returns attachment metadata including file paths (resolved from the Notes container when needed) as JSON.",
 	}, handler)
 }
 
 // registerGetAttachmentContentTool registers the get_attachment_content tool
-func registerGetAttachmentContentTool(server *mcp.Server, notesService services.NotesService) {
+// When fileRoot is set, only files under it are read, as the gRPC server does for remote callers.
+func registerGetAttachmentContentTool(server *mcp.Server, notesService services.NotesService, fileRoot string) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetAttachmentContentArgs) (
 		*mcp.CallToolResult, any, error) {
 
@@ -700,6 +1187,14 @@ func registerGetAttachmentContentTool(server *mcp.Server, notesService services.
 		if input.FilePath == "" {
 			return nil, nil, fmt.Errorf("%w: file_path is required", services.ErrInvalidInput)
 		}
+		if fileRoot != "" {
+			filePath, ok := fileWithin(fileRoot, input.FilePath)
+			if !ok {
+				return createErrorResult(fmt.Errorf("%w: file_path must be an attachment file in the Notes container",
+					services.ErrInvalidInput)), nil, nil
+			}
+			input.FilePath = filePath
+		}
 
 		// Set default max size to 10MB
 		maxSizeMB := input.MaxSizeMB
@@ -708,10 +1203,52 @@ func registerGetAttachmentContentTool(server *mcp.Server, notesService services.
 		}
 		maxSizeBytes := int64(maxSizeMB) * 1024 * 1024
 
+		chunked := input.Offset != 0 || input.Length != 0
+		if input.CopyToDir != "" && chunked {
+			return nil, nil, fmt.Errorf("%w: copy_to_dir cannot be combined with offset or length", services.ErrInvalidInput)
+		}
+		if input.Offset < 0 || input.Length < 0 {
+			return nil, nil, fmt.Errorf("%w: offset and length must not be negative", services.ErrInvalidInput)
+		}
+
 		// Create a context with timeout for the operation
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
 
+		// Large files: copy to disk, or return one base64 chunk, rather than the whole file
+		if input.CopyToDir != "" || chunked {
+			var result any
+			var err error
+			if input.CopyToDir != "" {
+				destDir, dirErr := resolveExportDir(input.CopyToDir)
+				if dirErr != nil {
+					return createErrorResult(dirErr), nil, nil
+				}
+				result, err = notesService.CopyAttachment(opCtx, input.FilePath, destDir)
+			} else {
+				length := input.Length
+				if length == 0 || length > maxSizeBytes {
+					length = maxSizeBytes
+				}
+				result, err = notesService.ReadAttachmentChunk(opCtx, input.FilePath, input.Offset, length)
+			}
+			if err != nil {
+				return createErrorResult(err), nil, nil
+			}
+
+			resultJSON, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal attachment result: %w", err)
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: string(resultJSON),
+					},
+				},
+			}, nil, nil
+		}
+
 		// Call the service
 		content, err := notesService.GetAttachmentContent(opCtx, input.FilePath, maxSizeBytes)
 		if err != nil {
@@ -733,18 +1270,1152 @@ func registerGetAttachmentContentTool(server *mcp.Server, notesService services.
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_attachment_content",
-		Description: "Retrieves the content of an attachment from Apple Notes. Returns base64-encoded content. Limited by max_size_mb parameter (default: 10MB).",
+		Description: "Retrieves the content of an attachment from Apple Notes. Returns base64-encoded content. Limited by max_size_mb parameter (default: 10MB). For larger files, read chunks with offset/length (returns JSON with base64 data, total_size, and eof) or set copy_to_dir to save the file under NOTES_MCP_EXPORT_DIR and get its path (refused when NOTES_MCP_READ_ONLY is set).",
 	}, handler)
 }
 
-// registerExportNoteMarkdownTool registers the export_note_markdown tool
-func registerExportNoteMarkdownTool(server *mcp.Server, notesService services.NotesService) {
-	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportNoteMarkdownArgs) (
+// registerGetAttachmentThumbnailTool registers the get_attachment_thumbnail tool
+func registerGetAttachmentThumbnailTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetAttachmentThumbnailArgs) (
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.NoteTitle == "" {
-			return nil, nil, fmt.Errorf("%w: note_title is required", services.ErrInvalidInput)
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		if input.AttachmentName == "" {
+			return nil, nil, fmt.Errorf("%w: attachment_name is required", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		thumb, err := notesService.GetAttachmentThumbnail(opCtx, input.NoteTitle, input.AttachmentName, input.MaxPx)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return the thumbnail as image content, which the protocol carries base64-encoded
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.ImageContent{
+					Data:     thumb.Data,
+					MIMEType: thumb.MIMEType,
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_attachment_thumbnail",
+		Description: "Returns a small JPEG thumbnail of an image attachment in a note, downsized with macOS sips so its longest edge is at most max_px (default: 256). Use it for visual context instead of get_attachment_content to keep payloads small.",
+	}, handler)
+}
+
+// registerExportNoteMarkdownTool registers the export_note_markdown tool
+func registerExportNoteMarkdownTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportNoteMarkdownArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service for the requested format
+		var markdown string
+		var err error
+		switch input.Format {
+		case "", markdownFormatStandard:
+			if input.Images == "" {
+				markdown, err = notesService.ExportNoteMarkdown(opCtx, input.NoteTitle)
+			} else {
+				markdown, err = notesService.ExportNoteMarkdownWithOptions(opCtx, input.NoteTitle,
+					services.MarkdownExportOptions{Images: input.Images, AssetsDir: input.AssetsDir})
+			}
+		case markdownFormatObsidian:
+			markdown, err = notesService.ExportNoteObsidian(opCtx, input.NoteTitle, input.AssetsDir)
+		default:
+			return nil, nil, fmt.Errorf("%w: format must be '%s' or '%s'", services.ErrInvalidInput, markdownFormatStandard, markdownFormatObsidian)
+		}
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Shrink the note to the caller's context budget, if any
+		markdown, _, err = services.FitNoteMarkdown(markdown, input.NoteTitle,
+			services.BundleBudget{MaxChars: input.MaxChars, MaxTokens: input.MaxTokens})
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: services.HighlightMarkdown(markdown, input.Highlight),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_note_markdown",
+		Description: "Exports a note from Apple Notes as markdown format. Returns the note content converted to markdown. Use format 'obsidian' for YAML front matter, [[wikilinks]], and ![[...]] attachment embeds (copied into assets_dir when given). Pass highlight to bold matches of a search query, and max_chars or max_tokens to trim a long note to a context budget.",
+	}, handler)
+}
+
+// registerExportNoteTextTool registers the export_note_text tool
+func registerExportNoteTextTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportNoteTextArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		plainText, err := notesService.ExportNoteText(opCtx, input.NoteTitle)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: services.HighlightMarkdown(plainText, input.Highlight),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_note_text",
+		Description: "Exports a note from Apple Notes as plain text. Returns the note content as plain text without formatting. Pass highlight to bold matches of a search query.",
+	}, handler)
+}
+
+// registerExportNotesCSVTool registers the export_notes_csv tool
+func registerExportNotesCSVTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportNotesCSVArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		notes, err := notesService.ListNotesWithMetadata(opCtx, scopedFolder(ctx, input.Folder, input.AllFolders))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Filter titles client-side; the listing already has every note's metadata
+		if query := strings.ToLower(strings.TrimSpace(input.Query)); query != "" {
+			matched := []services.Note{}
+			for _, note := range notes {
+				if strings.Contains(strings.ToLower(note.Title), query) {
+					matched = append(matched, note)
+				}
+			}
+			notes = matched
+		}
+
+		if input.IncludeMetrics {
+			if notes, err = notesService.WithNoteMetrics(opCtx, notes); err != nil {
+				return createErrorResult(err), nil, nil
+			}
+		}
+
+		var out strings.Builder
+		if err := services.WriteNotesCSV(&out, notes); err != nil {
+			return createErrorResult(fmt.Errorf("failed to format notes: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: out.String(),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_notes_csv",
+		Description: "Exports a list of notes as CSV with id, title, folder, created, modified, shared, and locked columns, newest first, for spreadsheet review of large libraries. Optionally limited to a folder and to titles containing query. Note bodies are not included.",
+	}, handler)
+}
+
+// registerImportHTMLTool registers the import_html tool
+func registerImportHTMLTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ImportHTMLArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if input.Path == "" {
+			return nil, nil, fmt.Errorf("%w: path is required", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		result, err := notesService.ImportHTMLFile(opCtx, input.Path, input.SourceURL, scopedFolder(ctx, input.Folder, false))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal import result: %w", err)
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(resultJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "import_html",
+		Description: "Imports an HTML file (e.g. a saved web page) as a new note for reading later. The page is reduced to text, headings, lists, tables, and links; scripts, styles, navigation, and images are removed. The source URL is recorded at the top of the note. Skipped, and reported under duplicates, if a note with the page's title already exists.",
+	}, handler)
+}
+
+// registerTranscribeAttachmentTool registers the transcribe_attachment tool
+func registerTranscribeAttachmentTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input TranscribeAttachmentArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		if input.AttachmentName == "" {
+			return nil, nil, fmt.Errorf("%w: attachment_name is required", services.ErrInvalidInput)
+		}
+
+		// Transcription runs far longer than AppleScript calls, so it gets its own deadline
+		opCtx, cancel := context.WithTimeout(ctx, transcriptionTimeout)
+		defer cancel()
+
+		// Call the service
+		transcript, err := notesService.TranscribeAttachment(opCtx, input.NoteTitle, input.AttachmentName, input.AppendToNote)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		transcriptJSON, err := json.Marshal(transcript)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal transcript: %w", err)
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(transcriptJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "transcribe_attachment",
+		Description: "Transcribes an audio attachment (e.g. a voice memo) in a note to text using the configured Whisper endpoint or macOS Speech helper. Returns the transcript as JSON; with append_to_note it is also added to the end of the note under a 'Transcript:' heading.",
+	}, handler)
+}
+
+// registerClipURLTool registers the clip_url tool
+func registerClipURLTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ClipURLArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if input.URL == "" {
+			return nil, nil, fmt.Errorf("%w: url is required", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		note, err := notesService.ClipURL(opCtx, input.URL, scopedFolder(ctx, input.Folder, false))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		noteJSON, err := json.Marshal(note)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal note: %w", err)
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(noteJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "clip_url",
+		Description: "Saves a web page to Apple Notes: fetches the URL, extracts the main article (dropping navigation, ads, comments, and sidebars), and creates a note with the article title, a link to the source, and the article text, headings, lists, and links. Returns the created note's metadata as JSON.",
+	}, handler)
+}
+
+// registerExtractActionItemsTool registers the extract_action_items tool
+func registerExtractActionItemsTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExtractActionItemsArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		if input.PushToReminders && !remindersEnabled() {
+			return nil, nil, fmt.Errorf("%w: Reminders integration is disabled (set %s=true to enable)",
+				services.ErrInvalidInput, remindersEnvVar)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		items, err := notesService.ExtractActionItems(opCtx, input.NoteTitle)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		output := struct {
+			Items     []services.ActionItem `json:"items"`
+			Reminders []services.Reminder   `json:"reminders,omitempty"`
+		}{Items: items}
+
+		// Push unchecked items to Reminders when requested
+		if input.PushToReminders {
+			output.Reminders, err = notesService.PushActionItemsToReminders(opCtx, input.NoteTitle, input.List)
+			if err != nil {
+				return createErrorResult(err), nil, nil
+			}
+		}
+
+		// Marshal to JSON for structured output
+		outputJSON, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format action items: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(outputJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "extract_action_items",
+		Description: "Extracts checklist items from a note in Apple Notes, including their checked state. Optionally pushes unchecked items to Apple Reminders. Returns items (and created reminders) as JSON.",
+	}, handler)
+}
+
+// registerFindActionItemsTool registers the find_action_items tool
+func registerFindActionItemsTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input FindActionItemsArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if input.Query == "" {
+			return nil, nil, fmt.Errorf("%w: query is required", services.ErrInvalidInput)
+		}
+
+		// "completed" matches the wording of the action-items prompt
+		status := strings.ToLower(input.Status)
+		switch status {
+		case "":
+			status = "open"
+		case "completed":
+			status = "done"
+		}
+		if status != "open" && status != "done" && status != "all" {
+			return nil, nil, fmt.Errorf("%w: status must be 'open', 'done', or 'all'", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		items, err := notesService.FindActionItems(opCtx, input.Query, scopedFolder(ctx, input.Folder, input.AllFolders))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Keep the items matching the requested status
+		filtered := []services.ActionItem{}
+		for _, item := range items {
+			if status == "all" || item.Done == (status == "done") {
+				filtered = append(filtered, item)
+			}
+		}
+
+		// Marshal to JSON for structured output
+		outputJSON, err := json.MarshalIndent(struct {
+			Items []services.ActionItem `json:"items"`
+		}{Items: filtered}, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format action items: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(outputJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_action_items",
+		Description: "Finds action items across every note whose title or body matches a search term: Apple Notes checklists, '- [ ]' checkboxes, and 'TODO:' lines. Returns items as JSON with text, source_note, done, and due_hint (a deadline phrase such as 'by Friday' or '2024-07-01' when the item mentions one). Returns open items unless status is 'done' or 'all'.",
+	}, handler)
+}
+
+// registerGetUpcomingDeadlinesTool registers the get_upcoming_deadlines tool
+func registerGetUpcomingDeadlinesTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetUpcomingDeadlinesArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		days := input.Days
+		if days == 0 {
+			days = defaultDeadlineDays
+		}
+		if days < 0 {
+			return nil, nil, fmt.Errorf("%w: days must be positive", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		items, err := notesService.GetUpcomingDeadlines(opCtx, days, scopedFolder(ctx, input.Folder, input.AllFolders))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Marshal to JSON for structured output
+		outputJSON, err := json.MarshalIndent(struct {
+			Days      int                   `json:"days"`
+			Deadlines []services.ActionItem `json:"deadlines"`
+		}{Days: days, Deadlines: items}, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format deadlines: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(outputJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_upcoming_deadlines",
+		Description: "Lists open action items with a due date in the next N days (default 7) across recently modified notes, soonest first, including overdue items. Dates come from phrases like 'by Friday', 'due tomorrow', 'before 7/1', or '2024-07-01', resolved relative to when the note was last modified. Returns items as JSON with text, source_note, due_hint, and due.",
+	}, handler)
+}
+
+// registerPinNoteTool registers the pin_note tool
+func registerPinNoteTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input PinNoteArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		if err := notesService.PinNote(opCtx, input.Title); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Note pinned: %s", input.Title),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "pin_note",
+		Description: "Pins a note in Apple Notes. Uses the 'notes-mcp Pin Note' Shortcut when enabled, otherwise the Notes menu (requires Accessibility permission).",
+	}, handler)
+}
+
+// registerAddNoteTagsTool registers the add_note_tags tool
+func registerAddNoteTagsTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input AddNoteTagsArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		if len(input.Tags) == 0 {
+			return nil, nil, fmt.Errorf("%w: tags is required", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		if err := notesService.AddNoteTags(opCtx, input.Title, input.Tags); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Tags added to note: %s", input.Title),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_note_tags",
+		Description: "Adds tags to a note in Apple Notes. Uses the 'notes-mcp Tag Note' Shortcut for native tags when enabled, otherwise appends #hashtags to the note body.",
+	}, handler)
+}
+
+// registerCreateReminderFromNoteTool registers the create_reminder_from_note tool
+func registerCreateReminderFromNoteTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateReminderFromNoteArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("note_title", input.NoteTitle)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Parse due date as a day in the configured zone
+		loc, err := resolveTimezone("")
+		if err != nil {
+			return nil, nil, err
+		}
+		dueDate, err := parseDateFilter(input.DueDate, loc)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		reminder, err := notesService.CreateReminder(opCtx, input.NoteTitle, input.Text, input.List, dueDate)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Marshal to JSON for structured output
+		reminderJSON, err := json.MarshalIndent(reminder, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format reminder: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(reminderJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_reminder_from_note",
+		Description: "Creates a reminder in Apple Reminders that references a note in Apple Notes. Optional text, list, and due date. Returns the created reminder as JSON.",
+	}, handler)
+}
+
+// registerGenerateWeeklyDigestTool registers the generate_weekly_digest tool
+func registerGenerateWeeklyDigestTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GenerateWeeklyDigestArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Parse the week start, defaulting to the seven days ending today in the caller's zone
+		loc, err := resolveTimezone(input.Timezone)
+		if err != nil {
+			return nil, nil, err
+		}
+		weekStart := time.Now().In(loc).AddDate(0, 0, -6)
+		if input.WeekStart != "" {
+			parsed, err := parseDateFilter(input.WeekStart, loc)
+			if err != nil {
+				return nil, nil, err
+			}
+			weekStart = *parsed
+		}
+		weekStart = startOfDay(weekStart)
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		note, err := notesService.GenerateWeeklyDigest(opCtx, weekStart, input.Folder)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Marshal note to JSON for structured output with full metadata
+		noteJSON, err := json.MarshalIndent(note, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format note: %w", err)), nil, nil
+		}
+
+		// Return the created digest note
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(noteJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "generate_weekly_digest",
+		Description: "Collects the notes modified during a week with their outlines (headings) and action items, writes a formatted digest as a new note in the 'Digests' folder (or the given folder), and returns the created note with full metadata as JSON.",
+	}, handler)
+}
+
+// staleNotesResult is the find_stale_notes response
+type staleNotesResult struct {
+	Days  int                  `json:"days"`
+	Total int                  `json:"total"`
+	Notes []services.StaleNote `json:"notes"`
+}
+
+// registerFindStaleNotesTool registers the find_stale_notes tool
+func registerFindStaleNotesTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input FindStaleNotesArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		opts := services.StaleNoteOptions{
+			Days:     input.Days,
+			Folder:   scopedFolder(ctx, input.Folder, input.AllFolders),
+			MinWords: input.MinWords,
+		}
+		notes, err := services.FindStaleNotes(opCtx, notesService, opts, time.Now())
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Limit results like search, keeping the oldest notes
+		result := staleNotesResult{Days: input.Days, Total: len(notes), Notes: notes}
+		if result.Days == 0 {
+			result.Days = services.DefaultStaleDays
+		}
+		if len(result.Notes) > maxSearchResults {
+			result.Notes = result.Notes[:maxSearchResults]
+		}
+
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format stale notes: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_stale_notes",
+		Description: "Lists notes not modified in the given number of days (default 90), oldest first, with their folder, dates, days_stale, and metrics (word count, checklist and attachment flags). min_words skips stubs. Returns {days, total, notes} as JSON, with at most 100 notes. Use it to find candidates for archiving or review.",
+	}, handler)
+}
+
+// emptyNotesResult is the find_empty_notes response
+type emptyNotesResult struct {
+	MaxChars int                         `json:"max_chars"`
+	Notes    []services.Note             `json:"notes"`
+	Deletion *services.EmptyNoteDeletion `json:"deletion,omitempty"`
+}
+
+// maxConfirmTitles caps the note titles named in a bulk delete confirmation
+const maxConfirmTitles = 10
+
+// registerFindEmptyNotesTool registers the find_empty_notes tool
+func registerFindEmptyNotesTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input FindEmptyNotesArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		opts := services.EmptyNoteOptions{Folder: scopedFolder(ctx, input.Folder, input.AllFolders), MaxChars: input.MaxChars}
+		notes, err := services.FindEmptyNotes(opCtx, notesService, opts)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		result := emptyNotesResult{MaxChars: input.MaxChars, Notes: notes}
+		if result.MaxChars == 0 {
+			result.MaxChars = services.DefaultEmptyNoteChars
+		}
+
+		if input.Delete && len(notes) > 0 {
+			// Ask the user first when the client supports elicitation, naming the notes
+			titles := []string{}
+			for _, note := range notes {
+				if len(titles) == maxConfirmTitles {
+					titles = append(titles, fmt.Sprintf("and %d more", len(notes)-maxConfirmTitles))
+					break
+				}
+				titles = append(titles, fmt.Sprintf("'%s'", note.Title))
+			}
+			action := fmt.Sprintf("delete %d empty notes (%s)", len(notes), strings.Join(titles, ", "))
+			if refused := confirmDestructive(ctx, req.Session, action); refused != nil {
+				return refused, nil, nil
+			}
+
+			// Confirmation may take a while, so deleting gets its own timeout
+			deleteCtx, cancelDelete := context.WithTimeout(ctx, getOperationTimeout())
+			defer cancelDelete()
+			if result.Deletion, err = services.DeleteEmptyNotes(deleteCtx, notesService, notes); err != nil {
+				return createErrorResult(err), nil, nil
+			}
+		}
+
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format empty notes: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_empty_notes",
+		Description: "Lists notes whose text, not counting the title line, is shorter than max_chars (default 10), such as blank notes left by sync glitches or interrupted agents. Notes with attachments are never listed. Set delete to remove them in bulk after the user confirms; notes sharing a title with another note are skipped. Returns {max_chars, notes, deletion} as JSON.",
+	}, handler)
+}
+
+// titleVariantsResult is the find_title_variants response
+type titleVariantsResult struct {
+	Sets   []services.TitleVariantSet   `json:"sets"`
+	Merges *services.TitleVariantMerges `json:"merges,omitempty"`
+}
+
+// registerFindTitleVariantsTool registers the find_title_variants tool
+func registerFindTitleVariantsTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input FindTitleVariantsArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		sets, err := services.FindTitleVariants(opCtx, notesService, scopedFolder(ctx, input.Folder, input.AllFolders))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		result := titleVariantsResult{Sets: sets}
+
+		if input.Merge && len(sets) > 0 {
+			// Ask the user first when the client supports elicitation, naming the notes kept
+			targets := []string{}
+			for _, set := range sets {
+				if len(targets) == maxConfirmTitles {
+					targets = append(targets, fmt.Sprintf("and %d more", len(sets)-maxConfirmTitles))
+					break
+				}
+				targets = append(targets, fmt.Sprintf("'%s'", set.Target))
+			}
+			action := fmt.Sprintf("merge %d sets of title variants into %s, deleting the other notes", len(sets), strings.Join(targets, ", "))
+			if refused := confirmDestructive(ctx, req.Session, action); refused != nil {
+				return refused, nil, nil
+			}
+
+			// Confirmation may take a while, so merging gets its own timeout
+			mergeCtx, cancelMerge := context.WithTimeout(ctx, getOperationTimeout())
+			defer cancelMerge()
+			if result.Merges, err = services.MergeTitleVariants(mergeCtx, notesService, sets); err != nil {
+				return createErrorResult(err), nil, nil
+			}
+		}
+
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format title variants: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_title_variants",
+		Description: "Groups notes whose titles differ only by case, punctuation, emoji, or 'copy' and '(2)' suffixes, a frequent artifact of iCloud sync conflicts. Each set names a suggested target (the plainest title) and lists its notes, newest first. Set merge to merge every set into its target after the user confirms, as merge_notes does; sets whose titles differ only in case are skipped. Returns {sets, merges} as JSON.",
+	}, handler)
+}
+
+// registerMergeNotesTool registers the merge_notes tool
+func registerMergeNotesTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input MergeNotesArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		checks := []*validation.FieldError{validation.Title("target", input.Target)}
+		for i, source := range input.Sources {
+			checks = append(checks, validation.Title(fmt.Sprintf("sources[%d]", i), source))
+		}
+		if err := validation.Check(checks...); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		quoted := make([]string, len(input.Sources))
+		for i, source := range input.Sources {
+			quoted[i] = fmt.Sprintf("'%s'", source)
+		}
+		action := fmt.Sprintf("merge %s into '%s', deleting them", strings.Join(quoted, ", "), input.Target)
+		if refused := confirmDestructive(ctx, req.Session, action); refused != nil {
+			return refused, nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		merge, err := services.MergeNotes(opCtx, notesService, input.Target, input.Sources)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		out, err := json.MarshalIndent(merge, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format merge: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "merge_notes",
+		Description: "Appends each source note's body to the target note, separated by an empty line, then deletes the sources; the user may be asked to confirm first. Every title must belong to exactly one note, and sources with attachments are refused since their files would be lost. The target is only written if it hasn't changed since it was read. Returns {target, merged, deleted} as JSON.",
+	}, handler)
+}
+
+// conflictsResult is the resolve_conflicts response
+type conflictsResult struct {
+	Conflicts   []services.NoteConflict       `json:"conflicts"`
+	Resolutions []services.ConflictResolution `json:"resolutions,omitempty"`
+	Skipped     []services.SkippedNote        `json:"skipped,omitempty"`
+}
+
+// registerResolveConflictsTool registers the resolve_conflicts tool
+func registerResolveConflictsTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ResolveConflictsArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		strategy := strings.ToLower(strings.TrimSpace(input.Strategy))
+		if strategy != "" && !services.ValidConflictStrategy(strategy) {
+			return createErrorResult(fmt.Errorf("%w: strategy must be %s, %s, or %s", services.ErrInvalidInput,
+				services.ConflictKeepNewest, services.ConflictKeepLongest, services.ConflictMerge)), nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		conflicts, err := services.FindNoteConflicts(opCtx, notesService, scopedFolder(ctx, input.Folder, input.AllFolders))
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		if input.Title != "" {
+			conflicts = slices.DeleteFunc(conflicts, func(conflict services.NoteConflict) bool {
+				return !strings.EqualFold(conflict.Title, input.Title)
+			})
+		}
+		result := conflictsResult{Conflicts: conflicts}
+
+		if strategy != "" && len(conflicts) > 0 {
+			// Ask the user first when the client supports elicitation, naming the titles
+			titles := []string{}
+			for _, conflict := range conflicts {
+				if len(titles) == maxConfirmTitles {
+					titles = append(titles, fmt.Sprintf("and %d more", len(conflicts)-maxConfirmTitles))
+					break
+				}
+				titles = append(titles, fmt.Sprintf("'%s'", conflict.Title))
+			}
+			action := fmt.Sprintf("resolve %d conflicts (%s) with the %s strategy, deleting the other copies", len(conflicts), strings.Join(titles, ", "), strategy)
+			if refused := confirmDestructive(ctx, req.Session, action); refused != nil {
+				return refused, nil, nil
+			}
+
+			// Confirmation may take a while, so resolving gets its own timeout
+			resolveCtx, cancelResolve := context.WithTimeout(ctx, getOperationTimeout())
+			defer cancelResolve()
+			result.Resolutions = []services.ConflictResolution{}
+			result.Skipped = []services.SkippedNote{}
+			for _, conflict := range conflicts {
+				resolution, err := services.ResolveNoteConflict(resolveCtx, notesService, conflict, strategy)
+				if resolution != nil {
+					result.Resolutions = append(result.Resolutions, *resolution)
+				}
+				if err != nil {
+					result.Skipped = append(result.Skipped, services.SkippedNote{Title: conflict.Title, Reason: err.Error()})
+				}
+			}
+		}
+
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format conflicts: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "resolve_conflicts",
+		Description: "Finds conflict copies: notes sharing a title whose bodies diverged, as iCloud sync conflicts leave. Each conflict lists its copies newest first with IDs, dates, text length, and a line diff from the newest copy. Set strategy to resolve them after the user confirms: 'newest' keeps the newest copy, 'longest' the one with the most text, and 'merge' appends the lines only other copies have to the newest; the other copies are deleted. A copy edited since it was compared stops its resolution. Returns {conflicts, resolutions, skipped} as JSON.",
+	}, handler)
+}
+
+// maxLinkCheckConcurrency caps the concurrency a check_links caller can ask for
+const maxLinkCheckConcurrency = 32
+
+// registerCheckLinksTool registers the check_links tool
+func registerCheckLinksTool(server *mcp.Server, notesService services.NotesService, checker services.LinkChecker) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CheckLinksArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		opts := services.LinkCheckOptions{
+			Folder:      scopedFolder(ctx, input.Folder, input.AllFolders),
+			Concurrency: min(input.Concurrency, maxLinkCheckConcurrency),
+			Timeout:     time.Duration(input.TimeoutSeconds) * time.Second,
+		}
+		report, err := services.CheckNoteLinks(opCtx, notesService, checker, opts)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format link report: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "check_links",
+		Description: "Finds the http(s) links in notes and checks each one with a HEAD request (GET when HEAD is refused), several at a time. Returns {notes_scanned, links_checked, dead_count, notes} as JSON, where notes lists each note with its dead links and the HTTP status or error they failed with. Reads at most 200 notes, newest first. Use it to maintain research notes.",
+	}, handler)
+}
+
+// registerExportGraphTool registers the export_graph tool
+func registerExportGraphTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportGraphArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		format := input.Format
+		if format == "" {
+			format = graphFormatJSON
+		}
+		if _, err := renderNoteGraph(&services.NoteGraph{}, format); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		opts := services.GraphOptions{Folder: scopedFolder(ctx, input.Folder, input.AllFolders)}
+		graph, err := services.BuildNoteGraph(opCtx, notesService, opts)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		out, err := renderNoteGraph(graph, format)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: out,
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_graph",
+		Description: "Builds a graph of how notes interconnect: a node per note and per folder, link edges from each note to the notes it links to (a note's incoming link edges are its backlinks), and folder edges joining notes to their folder. format 'json' (default) returns {nodes, edges, notes_scanned, unresolved_links} with each note's links and backlinks counts; 'dot' returns Graphviz DOT for rendering. Reads at most 500 notes; locked notes are left out.",
+	}, handler)
+}
+
+// notePropertiesResult is the get_note_properties and set_note_property response
+type notePropertiesResult struct {
+	Title      string            `json:"title"`
+	Properties map[string]string `json:"properties"`
+}
+
+// registerGetNotePropertiesTool registers the get_note_properties tool
+func registerGetNotePropertiesTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetNotePropertiesArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		properties, err := services.GetNoteProperties(opCtx, notesService, input.Title)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		out, err := json.MarshalIndent(notePropertiesResult{Title: input.Title, Properties: properties}, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format properties: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_note_properties",
+		Description: "Returns the custom key/value properties stored on a note by set_note_property, as {title, properties}. Properties live in a hidden comment at the top of the note body, so Notes doesn't display them; a note without any returns an empty object.",
+	}, handler)
+}
+
+// registerSetNotePropertyTool registers the set_note_property tool
+func registerSetNotePropertyTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SetNotePropertyArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
 		}
 
 		// Create a context with timeout for the operation
@@ -752,35 +2423,40 @@ func registerExportNoteMarkdownTool(server *mcp.Server, notesService services.No
 		defer cancel()
 
 		// Call the service
-		markdown, err := notesService.ExportNoteMarkdown(opCtx, input.NoteTitle)
+		properties, err := services.SetNoteProperty(opCtx, notesService, input.Title, input.Key, input.Value)
 		if err != nil {
 			return createErrorResult(err), nil, nil
 		}
 
+		out, err := json.MarshalIndent(notePropertiesResult{Title: input.Title, Properties: properties}, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format properties: %w", err)), nil, nil
+		}
+
 		// Return success result
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: markdown,
+					Text: string(out),
 				},
 			},
 		}, nil, nil
 	}
 
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "export_note_markdown",
-		Description: "Exports a note from Apple Notes as markdown format. Returns the note content converted to markdown.",
+		Name:        "set_note_property",
+		Description: "Stores a custom key/value property on a note, such as processed=true, giving agents a place to keep state on notes; an empty value removes the property. Properties live in a hidden comment at the top of the note body that Notes doesn't display, and the note is only written if it hasn't changed since it was read. Returns the note's properties as {title, properties}.",
 	}, handler)
 }
 
-// registerExportNoteTextTool registers the export_note_text tool
-func registerExportNoteTextTool(server *mcp.Server, notesService services.NotesService) {
-	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportNoteTextArgs) (
+// registerMarkNoteProcessedTool registers the mark_note_processed tool
+func registerMarkNoteProcessedTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input MarkNoteProcessedArgs) (
 		*mcp.CallToolResult, any, error) {
 
 		// Validate required fields
-		if input.NoteTitle == "" {
-			return nil, nil, fmt.Errorf("%w: note_title is required", services.ErrInvalidInput)
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
 		}
 
 		// Create a context with timeout for the operation
@@ -788,24 +2464,180 @@ func registerExportNoteTextTool(server *mcp.Server, notesService services.NotesS
 		defer cancel()
 
 		// Call the service
-		plainText, err := notesService.ExportNoteText(opCtx, input.NoteTitle)
+		properties, err := services.MarkNoteProcessed(opCtx, notesService, input.Title, input.Key, time.Now())
 		if err != nil {
 			return createErrorResult(err), nil, nil
 		}
 
+		out, err := json.MarshalIndent(notePropertiesResult{Title: input.Title, Properties: properties}, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format properties: %w", err)), nil, nil
+		}
+
 		// Return success result
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: plainText,
+					Text: string(out),
 				},
 			},
 		}, nil, nil
 	}
 
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "export_note_text",
-		Description: "Exports a note from Apple Notes as plain text. Returns the note content as plain text without formatting.",
+		Name:        "mark_note_processed",
+		Description: "Marks a note as handled by a recurring job by storing the current time (UTC, RFC 3339) in its key property (default 'processed'), so list_unprocessed_notes leaves it out next time. Give each job its own key, e.g. 'summarized', to track them separately. Returns the note's properties as {title, properties}.",
+	}, handler)
+}
+
+// unprocessedNotesResult is the list_unprocessed_notes response
+type unprocessedNotesResult struct {
+	Key   string          `json:"key"`
+	Notes []services.Note `json:"notes"`
+}
+
+// registerListUnprocessedNotesTool registers the list_unprocessed_notes tool
+func registerListUnprocessedNotesTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListUnprocessedNotesArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		opts := services.UnprocessedNoteOptions{
+			Query:  input.Query,
+			Folder: scopedFolder(ctx, input.Folder, input.AllFolders),
+			Key:    input.Key,
+			Limit:  input.Limit,
+		}
+		notes, err := services.FindUnprocessedNotes(opCtx, notesService, opts)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		result := unprocessedNotesResult{Key: input.Key, Notes: notes}
+		if result.Key == "" {
+			result.Key = services.DefaultProcessedKey
+		}
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format unprocessed notes: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_unprocessed_notes",
+		Description: "Lists the notes a recurring job hasn't handled yet: those matching query (title or body; default every note) without the key property mark_note_processed sets (default 'processed'). Returns {key, notes} as JSON with at most limit notes (default 50); locked notes are left out. Handle each note, then mark it with mark_note_processed using the same key.",
+	}, handler)
+}
+
+// registerShareNoteToTool registers the share_note_to tool
+func registerShareNoteToTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ShareNoteToArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Validate required fields
+		if err := validation.Check(validation.Title("title", input.Title)); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		config, err := loadShareConfig(shareConfigPath())
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+		target, err := newShareTarget(input.Target, input.To, config)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		action := fmt.Sprintf("send '%s' to %s", input.Title, target.Name())
+		if target.Name() == services.ShareTargetMessages {
+			recipient := input.To
+			if recipient == "" {
+				recipient = config.Messages.Recipient
+			}
+			action = fmt.Sprintf("send '%s' to %s in Messages", input.Title, recipient)
+		}
+		if refused := confirmDestructive(ctx, req.Session, action); refused != nil {
+			return refused, nil, nil
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		shared, err := services.ShareNote(opCtx, notesService, target, input.Title, input.MaxChars)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		out, err := json.MarshalIndent(shared, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format share report: %w", err)), nil, nil
+		}
+
+		// Return success result
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "share_note_to",
+		Description: "Sends a note as markdown to Messages (target 'messages', to a phone number or email) or to a Slack channel (target 'slack', through the incoming webhook in share.json); the user may be asked to confirm first, since a sent message can't be taken back. Notes longer than max_chars (default 4000) are cut at a paragraph or line break, ending with how many characters were left out. Returns {title, target, characters, truncated} as JSON.",
+	}, handler)
+}
+
+// registerReadNotesBundleTool registers the read_notes_bundle tool
+func registerReadNotesBundleTool(server *mcp.Server, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ReadNotesBundleArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// Call the service
+		bundle, err := services.BuildNoteBundle(opCtx, notesService, input.Notes)
+		if err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Shrink the bundle to the caller's context budget, if any
+		budget := services.BundleBudget{MaxChars: input.MaxChars, MaxTokens: input.MaxTokens, Priority: input.Priority}
+		if err := bundle.Fit(budget); err != nil {
+			return createErrorResult(err), nil, nil
+		}
+
+		// Return the combined markdown document
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: bundle.Markdown(),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "read_notes_bundle",
+		Description: "Reads several notes at once, by title or ID, and returns them as one markdown document: a '## <title>' section per note, opened by front matter with its id, folder, dates, and tags. Use it to load a set of related notes into context in one call. Notes that can't be read are listed with the reason instead of failing the whole bundle. With max_chars or max_tokens, notes past the budget are trimmed, reduced to their outline, or omitted, in priority order (as listed, or most recent first), and a closing section reports what was cut.",
 	}, handler)
 }
 
@@ -816,21 +2648,29 @@ func createErrorResult(err error) *mcp.CallToolResult {
 	// Map service errors to user-friendly messages
 	switch {
 	case errors.Is(err, services.ErrNoteNotFound):
-		message = "Note not found in Apple Notes. Please check the title and try again."
+		message = "Note not found in Apple Notes." + didYouMean(err) + " Please check the title and try again."
+	case errors.Is(err, services.ErrFolderNotFound):
+		message = "Folder not found in Apple Notes." + didYouMean(err) + " Use list_folders to see every folder."
 	case errors.Is(err, services.ErrNotesAppNotRunning):
 		message = "Apple Notes app is not running. Please open the Notes app and try again."
 	case errors.Is(err, services.ErrPermissionDenied):
 		message = "Permission denied to access Notes. Please grant access in System Preferences > Privacy & Security > Automation."
 	case errors.Is(err, services.ErrScriptTimeout):
-		message = "Apple Notes is not responding (timeout after 10 seconds). Please try again."
+		message = timeoutMessage(err)
+	case errors.Is(err, services.ErrConflict):
+		message = fmt.Sprintf("Update rejected: %v. Re-read the note and retry with the new modification date or hash.", err)
 	case errors.Is(err, services.ErrInvalidInput):
 		message = fmt.Sprintf("Invalid input: %v", err)
+	case errors.Is(err, services.ErrNotSupported):
+		message = fmt.Sprintf("Not available: %v.", err)
+	case errors.Is(err, services.ErrReadOnly):
+		message = fmt.Sprintf("Not allowed: %v. Unset NOTES_MCP_READ_ONLY to make changes.", err)
 	default:
 		// Include the error message for unexpected errors
 		message = fmt.Sprintf("An error occurred: %v", err)
 	}
 
-	return &mcp.CallToolResult{
+	result := &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{
 				Text: message,
@@ -838,6 +2678,47 @@ func createErrorResult(err error) *mcp.CallToolResult {
 		},
 		IsError: true,
 	}
+
+	// Validation failures also carry the field, rule, and limit of each problem for clients to act on
+	var invalid validation.Errors
+	if errors.As(err, &invalid) {
+		result.StructuredContent = map[string]any{"validation_errors": invalid}
+	}
+	return result
+}
+
+// didYouMean lists the close matches carried by a not-found error, or returns "" when there are none
+func didYouMean(err error) string {
+	var suggestions []string
+	var noteErr *services.NoteNotFoundError
+	var folderErr *services.FolderNotFoundError
+	switch {
+	case errors.Is(err, services.ErrNoteNotFound) && errors.As(err, &noteErr):
+		suggestions = noteErr.Suggestions
+	case errors.Is(err, services.ErrFolderNotFound) && errors.As(err, &folderErr):
+		suggestions = folderErr.Suggestions
+	}
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(suggestions))
+	for i, name := range suggestions {
+		quoted[i] = strconv.Quote(name)
+	}
+	return " Did you mean " + strings.Join(quoted, ", ") + "?"
+}
+
+// timeoutMessage describes a timeout with the failed operation and how long it ran, and how to allow more time
+func timeoutMessage(err error) string {
+	detail := err.Error()
+	var timeout *services.TimeoutError
+	if !errors.As(err, &timeout) {
+		// The operation as a whole ran out of time rather than a single script
+		detail += fmt.Sprintf(" after the %s operation limit", getOperationTimeout())
+	}
+	return fmt.Sprintf("Apple Notes is not responding: %s. Please try again, or set %s to a larger number of seconds to allow more time.",
+		detail, timeoutEnvVar)
 }
 
 // registerResources registers MCP resources for direct note access
@@ -889,6 +2770,30 @@ func registerResources(server *mcp.Server, notesService services.NotesService) {
 		},
 		createFolderNotesResourceHandler(notesService),
 	)
+
+	// Register resource template for a folder's recent notes: notes:///folder/{folder}/recent
+	server.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			URITemplate: "notes:///folder/{folder}/recent",
+			Name:        "folder-recent-notes",
+			Title:       "Recent Notes in Folder",
+			Description: "Access the 20 most recently modified notes in a folder, newest first, with their modification dates.",
+			MIMEType:    "text/plain",
+		},
+		createFolderRecentNotesResourceHandler(notesService),
+	)
+
+	// Register resource template for a date range: notes:///modified/{from}/{to}
+	server.AddResourceTemplate(
+		&mcp.ResourceTemplate{
+			URITemplate: "notes:///modified/{from}/{to}",
+			Name:        "modified-notes",
+			Title:       "Notes Modified in Date Range",
+			Description: "Access metadata (id, title, folder, dates) for notes modified between two ISO dates (YYYY-MM-DD, both inclusive) as JSON.",
+			MIMEType:    "application/json",
+		},
+		createModifiedNotesResourceHandler(notesService),
+	)
 }
 
 // createNoteResourceHandler creates a handler for note:///{title} resources
@@ -940,8 +2845,15 @@ func createRecentNotesResourceHandler(notesService services.NotesService) mcp.Re
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
 
-		// Get recent notes by searching for all notes (AppleScript returns them sorted)
-		notes, err := notesService.GetRecentNotes(opCtx, 20)
+		// Get recent notes by searching for all notes (AppleScript returns them sorted),
+		// or the session root folder's most recently modified notes when one is set
+		var notes []services.Note
+		var err error
+		if folder := rootFolderFromContext(ctx); folder != "" {
+			notes, err = notesService.GetRecentNotesInFolder(opCtx, folder, 20)
+		} else {
+			notes, err = notesService.GetRecentNotes(opCtx, 20)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to get recent notes: %w", err)
 		}
@@ -990,8 +2902,8 @@ func createSearchNotesResourceHandler(notesService services.NotesService) mcp.Re
 		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
 		defer cancel()
 
-		// Search for notes
-		notes, err := notesService.SearchNotes(opCtx, query)
+		// Search for notes within the session root folder, if any
+		notes, err := searchTitlesInScope(opCtx, notesService, query, rootFolderFromContext(ctx))
 		if err != nil {
 			return nil, fmt.Errorf("failed to search notes: %w", err)
 		}
@@ -1077,6 +2989,113 @@ func createFolderNotesResourceHandler(notesService services.NotesService) mcp.Re
 	}
 }
 
+// createFolderRecentNotesResourceHandler creates a handler for notes:///folder/{folder}/recent resources
+func createFolderRecentNotesResourceHandler(notesService services.NotesService) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		// Extract folder from URI (format: notes:///folder/{folder}/recent)
+		uri := req.Params.URI
+		if !strings.HasPrefix(uri, "notes:///folder/") || !strings.HasSuffix(uri, "/recent") {
+			return nil, fmt.Errorf("invalid folder recent URI: %s", uri)
+		}
+
+		folder := strings.TrimSuffix(strings.TrimPrefix(uri, "notes:///folder/"), "/recent")
+		if folder == "" {
+			return nil, fmt.Errorf("folder name is required")
+		}
+
+		// URL decode the folder name
+		folder = strings.ReplaceAll(folder, "%20", " ")
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		notes, err := notesService.GetRecentNotesInFolder(opCtx, folder, 20)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recent notes in folder: %w", err)
+		}
+
+		// Format the results as one "title (modified date)" line per note
+		var lines []string
+		for _, note := range notes {
+			lines = append(lines, fmt.Sprintf("%s (modified %s)", note.Title, note.Modified.Format("2006-01-02 15:04")))
+		}
+		result := strings.Join(lines, "\n")
+
+		if result == "" {
+			result = fmt.Sprintf("No notes found in folder '%s'.", folder)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      uri,
+					MIMEType: "text/plain",
+					Text:     result,
+				},
+			},
+		}, nil
+	}
+}
+
+// createModifiedNotesResourceHandler creates a handler for notes:///modified/{from}/{to} resources
+func createModifiedNotesResourceHandler(notesService services.NotesService) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		// Extract the range from URI (format: notes:///modified/{from}/{to})
+		uri := req.Params.URI
+		if !strings.HasPrefix(uri, "notes:///modified/") {
+			return nil, fmt.Errorf("invalid modified URI: %s", uri)
+		}
+
+		parts := strings.Split(strings.TrimPrefix(uri, "notes:///modified/"), "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: expected notes:///modified/{from}/{to}", services.ErrInvalidInput)
+		}
+
+		// Dates are calendar days in the configured zone, by default the local time Notes.app shows
+		loc, err := resolveTimezone("")
+		if err != nil {
+			return nil, err
+		}
+		from, err := time.ParseInLocation("2006-01-02", parts[0], loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid from date %q, use YYYY-MM-DD", services.ErrInvalidInput, parts[0])
+		}
+		to, err := time.ParseInLocation("2006-01-02", parts[1], loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid to date %q, use YYYY-MM-DD", services.ErrInvalidInput, parts[1])
+		}
+		if to.Before(from) {
+			return nil, fmt.Errorf("%w: to date must not be before from date", services.ErrInvalidInput)
+		}
+
+		// Create a context with timeout for the operation
+		opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+		defer cancel()
+
+		// The to date is inclusive, so the range ends at the start of the following day
+		notes, err := notesService.GetNotesModifiedBetween(opCtx, from, to.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notes modified in range: %w", err)
+		}
+
+		notesJSON, err := json.MarshalIndent(notes, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to format notes: %w", err)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      uri,
+					MIMEType: "application/json",
+					Text:     string(notesJSON),
+				},
+			},
+		}, nil
+	}
+}
+
 // registerPrompts registers all prompt templates for workflow assistance
 func registerPrompts(server *mcp.Server, notesService services.NotesService) {
 	registerDailyReviewPrompt(server, notesService)
@@ -1092,12 +3111,22 @@ func registerDailyReviewPrompt(server *mcp.Server, notesService services.NotesSe
 	prompt := &mcp.Prompt{
 		Name:        "daily-review",
 		Description: "Review notes from today with summary and action items. Analyzes recent notes to provide a daily overview.",
-		Arguments:   []*mcp.PromptArgument{},
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        "timezone",
+				Description: "Optional IANA time zone whose day counts as today, e.g. 'Asia/Tokyo' (default: NOTES_MCP_TIMEZONE or the system zone)",
+				Required:    false,
+			},
+		},
 	}
 
 	handler := func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-		// Get today's date
-		today := time.Now().Format("2006-01-02")
+		// Get today's date in the user's zone
+		loc, err := resolveTimezone(req.Params.Arguments["timezone"])
+		if err != nil {
+			return nil, err
+		}
+		today := time.Now().In(loc).Format("2006-01-02")
 
 		instructions := fmt.Sprintf(`Review my notes from today (%s) and provide:
 
@@ -1135,6 +3164,11 @@ func registerWeeklySummaryPrompt(server *mcp.Server, notesService services.Notes
 				Description: "Optional comma-separated list of categories to focus on (e.g., 'meetings,ideas,todos')",
 				Required:    false,
 			},
+			{
+				Name:        "timezone",
+				Description: "Optional IANA time zone whose days the week covers (default: NOTES_MCP_TIMEZONE or the system zone)",
+				Required:    false,
+			},
 		},
 	}
 
@@ -1146,6 +3180,13 @@ func registerWeeklySummaryPrompt(server *mcp.Server, notesService services.Notes
 			categoryInstructions = fmt.Sprintf("\nFocus on these categories: %s", categories)
 		}
 
+		loc, err := resolveTimezone(req.Params.Arguments["timezone"])
+		if err != nil {
+			return nil, err
+		}
+		today := time.Now().In(loc)
+		weekStart := today.AddDate(0, 0, -6)
+
 		instructions := fmt.Sprintf(`Review my notes from the past week and provide a comprehensive summary:
 
 1. Group notes by category or theme
@@ -1154,7 +3195,8 @@ func registerWeeklySummaryPrompt(server *mcp.Server, notesService services.Notes
 4. Identify any recurring themes or patterns
 5. Note any areas that need more attention%s
 
-Use the notes:///recent resource to access recent notes from the past week. Organize the summary by categories to make it easy to review.`, categoryInstructions)
+Use the notes:///modified/%s/%s resource to access notes modified in the past week. Organize the summary by categories to make it easy to review.`,
+			categoryInstructions, weekStart.Format("2006-01-02"), today.Format("2006-01-02"))
 
 		return &mcp.GetPromptResult{
 			Description: "Weekly summary prompt with instructions for reviewing the past week's notes",
@@ -1200,6 +3242,19 @@ func registerMeetingPrepPrompt(server *mcp.Server, notesService services.NotesSe
 			attendeeContext = fmt.Sprintf("\nAttendees: %s", attendees)
 		}
 
+		// Add today's matching calendar events when the Calendar integration is enabled
+		// Calendar failures are logged and the prompt is returned without the extra context
+		if calendarEnabled() {
+			opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+			events, err := notesService.GetTodaysEvents(opCtx, topic)
+			cancel()
+			if err != nil {
+				log.Printf("meeting-prep: failed to get calendar events: %v", err)
+			} else {
+				attendeeContext += formatCalendarContext(events)
+			}
+		}
+
 		instructions := fmt.Sprintf(`Prepare me for a meeting about: %s%s
 
 Please provide:
@@ -1228,6 +3283,29 @@ Use the notes:///search/%s resource to find relevant notes. Also check notes:///
 	server.AddPrompt(prompt, handler)
 }
 
+// formatCalendarContext formats calendar events as a block of meeting context for prompts
+// Returns an empty string when there are no events
+func formatCalendarContext(events []services.CalendarEvent) string {
+	if len(events) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\nToday's matching calendar events:")
+	for _, event := range events {
+		fmt.Fprintf(&b, "\n- %s-%s %s (%s calendar)",
+			event.Start.Format("15:04"), event.End.Format("15:04"), event.Title, event.Calendar)
+		if event.Location != "" {
+			fmt.Fprintf(&b, "\n  Location: %s", event.Location)
+		}
+		if len(event.Attendees) > 0 {
+			fmt.Fprintf(&b, "\n  Attendees: %s", strings.Join(event.Attendees, ", "))
+		}
+	}
+
+	return b.String()
+}
+
 // registerActionItemsPrompt registers the action-items prompt
 func registerActionItemsPrompt(server *mcp.Server, notesService services.NotesService) {
 	prompt := &mcp.Prompt{
@@ -1267,7 +3345,7 @@ Please provide:
 3. Group items by urgency (urgent, soon, later)
 4. Flag any overdue or blocked items
 
-Use the notes:///search/%s resource to find relevant notes. Look for action items, todos, tasks, or similar indicators in the note content.`, searchTerm, status, searchTerm)
+Call the find_action_items tool with query "%s" and status "%s" to collect the items; each one includes its source note and any due_hint. Read the source notes for context on items whose urgency is unclear.`, searchTerm, status, searchTerm, status)
 
 		return &mcp.GetPromptResult{
 			Description: "Action items extraction prompt with instructions for finding and organizing tasks",
@@ -1309,7 +3387,7 @@ func registerNoteCleanupPrompt(server *mcp.Server, notesService services.NotesSe
 
 Please analyze notes and suggest:
 
-1. Notes older than %s days that may be outdated
+1. Notes older than %[1]s days that may be outdated
 2. Duplicate or redundant notes
 3. Notes with completed action items that can be archived
 4. Empty or placeholder notes
@@ -1320,7 +3398,7 @@ For each suggestion, provide:
 - Reason for archival/deletion
 - Any important content that should be preserved elsewhere
 
-Use the notes:///recent resource to get an overview of notes. Be conservative - only suggest cleanup for notes that are clearly outdated or redundant.`, ageThreshold)
+Call find_stale_notes with days set to %[1]s for the notes that haven't changed in that time, with their folders and word counts, and use the notes:///recent resource for an overview of current work. Be conservative - only suggest cleanup for notes that are clearly outdated or redundant.`, ageThreshold)
 
 		return &mcp.GetPromptResult{
 			Description: "Note cleanup prompt with instructions for identifying notes to archive or delete",
@@ -5,7 +5,12 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,23 +20,53 @@ import (
 
 // mockNotesService is a simple mock for testing tool handlers
 type mockNotesService struct {
-	createNote           func(ctx context.Context, title, content string, tags []string) (*services.Note, error)
-	searchNotes          func(ctx context.Context, query string) ([]services.Note, error)
-	searchNotesAdvanced  func(ctx context.Context, opts services.SearchOptions) ([]services.Note, error)
-	getNoteContent       func(ctx context.Context, title string) (string, error)
-	getNoteMetadata      func(ctx context.Context, title string) (*services.Note, error)
-	updateNote           func(ctx context.Context, title, content string) error
-	deleteNote           func(ctx context.Context, title string) error
-	listFolders          func(ctx context.Context) ([]string, error)
-	getRecentNotes       func(ctx context.Context, limit int) ([]services.Note, error)
-	getNotesInFolder     func(ctx context.Context, folder string) ([]services.Note, error)
-	createFolder         func(ctx context.Context, name string, parentFolder string) error
-	moveNote             func(ctx context.Context, noteTitle string, targetFolder string) error
-	getFolderHierarchy   func(ctx context.Context) (*services.FolderNode, error)
-	getNoteAttachments   func(ctx context.Context, noteTitle string) ([]services.Attachment, error)
-	getAttachmentContent func(ctx context.Context, filePath string, maxSize int64) ([]byte, error)
-	exportNoteMarkdown   func(ctx context.Context, noteTitle string) (string, error)
-	exportNoteText       func(ctx context.Context, noteTitle string) (string, error)
+	createNote                    func(ctx context.Context, title, content string, tags []string) (*services.Note, error)
+	searchNotes                   func(ctx context.Context, query string) ([]services.Note, error)
+	searchNotesAdvanced           func(ctx context.Context, opts services.SearchOptions) ([]services.Note, error)
+	getNoteContent                func(ctx context.Context, title string) (string, error)
+	getNoteMetadata               func(ctx context.Context, title string) (*services.Note, error)
+	getNoteTitleByID              func(ctx context.Context, noteID string) (string, error)
+	getNoteContentByID            func(ctx context.Context, noteID string) (string, error)
+	updateNoteByID                func(ctx context.Context, noteID, content string) error
+	deleteNoteByID                func(ctx context.Context, noteID string) error
+	updateNote                    func(ctx context.Context, title, content string) error
+	hasNoteChanged                func(ctx context.Context, title, hash string) (bool, string, error)
+	updateNoteIfUnchanged         func(ctx context.Context, title, content string, precondition services.UpdatePrecondition) error
+	deleteNote                    func(ctx context.Context, title string) error
+	openNote                      func(ctx context.Context, title string) error
+	listFolders                   func(ctx context.Context) ([]string, error)
+	getRecentNotes                func(ctx context.Context, limit int) ([]services.Note, error)
+	getNotesInFolder              func(ctx context.Context, folder string) ([]services.Note, error)
+	getRecentInFolder             func(ctx context.Context, folder string, limit int) ([]services.Note, error)
+	getModifiedBetween            func(ctx context.Context, from, to time.Time) ([]services.Note, error)
+	listNotesWithMetadata         func(ctx context.Context, folder string) ([]services.Note, error)
+	listNotesByPrefix             func(ctx context.Context, prefix, folder string) ([]services.Note, error)
+	transcribeAttachment          func(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*services.Transcript, error)
+	withNoteMetrics               func(ctx context.Context, notes []services.Note) ([]services.Note, error)
+	clipURL                       func(ctx context.Context, pageURL, folder string) (*services.Note, error)
+	importHTMLFile                func(ctx context.Context, filePath, sourceURL, folder string) (*services.ImportResult, error)
+	createFolder                  func(ctx context.Context, name string, parentFolder string) error
+	moveNote                      func(ctx context.Context, noteTitle string, targetFolder string) error
+	moveNoteByID                  func(ctx context.Context, noteID string, targetFolder string) error
+	getFolderHierarchy            func(ctx context.Context) (*services.FolderNode, error)
+	getNoteAttachments            func(ctx context.Context, noteTitle string) ([]services.Attachment, error)
+	getAttachmentContent          func(ctx context.Context, filePath string, maxSize int64) ([]byte, error)
+	readAttachmentChunk           func(ctx context.Context, filePath string, offset, length int64) (*services.AttachmentChunk, error)
+	copyAttachment                func(ctx context.Context, filePath string, destDir string) (*services.AttachmentCopy, error)
+	getAttachmentThumb            func(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*services.Thumbnail, error)
+	exportNoteMarkdown            func(ctx context.Context, noteTitle string) (string, error)
+	exportNoteMarkdownWithOptions func(ctx context.Context, noteTitle string, opts services.MarkdownExportOptions) (string, error)
+	exportNoteText                func(ctx context.Context, noteTitle string) (string, error)
+	extractActionItems            func(ctx context.Context, noteTitle string) ([]services.ActionItem, error)
+	findActionItems               func(ctx context.Context, query, folder string) ([]services.ActionItem, error)
+	getUpcomingDeadlines          func(ctx context.Context, days int, folder string) ([]services.ActionItem, error)
+	createReminder                func(ctx context.Context, noteTitle, text, list string, dueDate *time.Time) (*services.Reminder, error)
+	pushActionItems               func(ctx context.Context, noteTitle, list string) ([]services.Reminder, error)
+	getTodaysEvents               func(ctx context.Context, query string) ([]services.CalendarEvent, error)
+	exportNoteObsidian            func(ctx context.Context, noteTitle string, assetsDir string) (string, error)
+	pinNote                       func(ctx context.Context, title string) error
+	addNoteTags                   func(ctx context.Context, title string, tags []string) error
+	generateWeeklyDigest          func(ctx context.Context, weekStart time.Time, digestFolder string) (*services.Note, error)
 }
 
 func (m *mockNotesService) CreateNote(ctx context.Context, title, content string, tags []string) (*services.Note, error) {
@@ -69,6 +104,34 @@ func (m *mockNotesService) GetNoteMetadata(ctx context.Context, title string) (*
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockNotesService) GetNoteTitleByID(ctx context.Context, noteID string) (string, error) {
+	if m.getNoteTitleByID != nil {
+		return m.getNoteTitleByID(ctx, noteID)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (m *mockNotesService) GetNoteContentByID(ctx context.Context, noteID string) (string, error) {
+	if m.getNoteContentByID != nil {
+		return m.getNoteContentByID(ctx, noteID)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (m *mockNotesService) UpdateNoteByID(ctx context.Context, noteID, content string) error {
+	if m.updateNoteByID != nil {
+		return m.updateNoteByID(ctx, noteID, content)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockNotesService) DeleteNoteByID(ctx context.Context, noteID string) error {
+	if m.deleteNoteByID != nil {
+		return m.deleteNoteByID(ctx, noteID)
+	}
+	return errors.New("not implemented")
+}
+
 func (m *mockNotesService) UpdateNote(ctx context.Context, title, content string) error {
 	if m.updateNote != nil {
 		return m.updateNote(ctx, title, content)
@@ -76,6 +139,27 @@ func (m *mockNotesService) UpdateNote(ctx context.Context, title, content string
 	return errors.New("not implemented")
 }
 
+func (m *mockNotesService) HasNoteChanged(ctx context.Context, title, hash string) (bool, string, error) {
+	if m.hasNoteChanged != nil {
+		return m.hasNoteChanged(ctx, title, hash)
+	}
+	return false, "", errors.New("not implemented")
+}
+
+func (m *mockNotesService) UpdateNoteIfUnchanged(ctx context.Context, title, content string, precondition services.UpdatePrecondition) error {
+	if m.updateNoteIfUnchanged != nil {
+		return m.updateNoteIfUnchanged(ctx, title, content, precondition)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockNotesService) OpenNote(ctx context.Context, title string) error {
+	if m.openNote != nil {
+		return m.openNote(ctx, title)
+	}
+	return errors.New("not implemented")
+}
+
 func (m *mockNotesService) DeleteNote(ctx context.Context, title string) error {
 	if m.deleteNote != nil {
 		return m.deleteNote(ctx, title)
@@ -104,6 +188,62 @@ func (m *mockNotesService) GetNotesInFolder(ctx context.Context, folder string)
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockNotesService) GetRecentNotesInFolder(ctx context.Context, folder string, limit int) ([]services.Note, error) {
+	if m.getRecentInFolder != nil {
+		return m.getRecentInFolder(ctx, folder, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) GetNotesModifiedBetween(ctx context.Context, from, to time.Time) ([]services.Note, error) {
+	if m.getModifiedBetween != nil {
+		return m.getModifiedBetween(ctx, from, to)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) ListNotesWithMetadata(ctx context.Context, folder string) ([]services.Note, error) {
+	if m.listNotesWithMetadata != nil {
+		return m.listNotesWithMetadata(ctx, folder)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) ListNotesByPrefix(ctx context.Context, prefix, folder string) ([]services.Note, error) {
+	if m.listNotesByPrefix != nil {
+		return m.listNotesByPrefix(ctx, prefix, folder)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) ImportHTMLFile(ctx context.Context, filePath, sourceURL, folder string) (*services.ImportResult, error) {
+	if m.importHTMLFile != nil {
+		return m.importHTMLFile(ctx, filePath, sourceURL, folder)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) ClipURL(ctx context.Context, pageURL, folder string) (*services.Note, error) {
+	if m.clipURL != nil {
+		return m.clipURL(ctx, pageURL, folder)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) WithNoteMetrics(ctx context.Context, notes []services.Note) ([]services.Note, error) {
+	if m.withNoteMetrics != nil {
+		return m.withNoteMetrics(ctx, notes)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) TranscribeAttachment(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*services.Transcript, error) {
+	if m.transcribeAttachment != nil {
+		return m.transcribeAttachment(ctx, noteTitle, attachmentName, appendToNote)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockNotesService) CreateFolder(ctx context.Context, name string, parentFolder string) error {
 	if m.createFolder != nil {
 		return m.createFolder(ctx, name, parentFolder)
@@ -118,6 +258,13 @@ func (m *mockNotesService) MoveNote(ctx context.Context, noteTitle string, targe
 	return errors.New("not implemented")
 }
 
+func (m *mockNotesService) MoveNoteByID(ctx context.Context, noteID string, targetFolder string) error {
+	if m.moveNoteByID != nil {
+		return m.moveNoteByID(ctx, noteID, targetFolder)
+	}
+	return errors.New("not implemented")
+}
+
 func (m *mockNotesService) GetFolderHierarchy(ctx context.Context) (*services.FolderNode, error) {
 	if m.getFolderHierarchy != nil {
 		return m.getFolderHierarchy(ctx)
@@ -139,6 +286,27 @@ func (m *mockNotesService) GetAttachmentContent(ctx context.Context, filePath st
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockNotesService) ReadAttachmentChunk(ctx context.Context, filePath string, offset, length int64) (*services.AttachmentChunk, error) {
+	if m.readAttachmentChunk != nil {
+		return m.readAttachmentChunk(ctx, filePath, offset, length)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) CopyAttachment(ctx context.Context, filePath string, destDir string) (*services.AttachmentCopy, error) {
+	if m.copyAttachment != nil {
+		return m.copyAttachment(ctx, filePath, destDir)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) GetAttachmentThumbnail(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*services.Thumbnail, error) {
+	if m.getAttachmentThumb != nil {
+		return m.getAttachmentThumb(ctx, noteTitle, attachmentName, maxPx)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockNotesService) ExportNoteMarkdown(ctx context.Context, noteTitle string) (string, error) {
 	if m.exportNoteMarkdown != nil {
 		return m.exportNoteMarkdown(ctx, noteTitle)
@@ -146,6 +314,13 @@ func (m *mockNotesService) ExportNoteMarkdown(ctx context.Context, noteTitle str
 	return "", errors.New("not implemented")
 }
 
+func (m *mockNotesService) ExportNoteMarkdownWithOptions(ctx context.Context, noteTitle string, opts services.MarkdownExportOptions) (string, error) {
+	if m.exportNoteMarkdownWithOptions != nil {
+		return m.exportNoteMarkdownWithOptions(ctx, noteTitle, opts)
+	}
+	return "", errors.New("not implemented")
+}
+
 func (m *mockNotesService) ExportNoteText(ctx context.Context, noteTitle string) (string, error) {
 	if m.exportNoteText != nil {
 		return m.exportNoteText(ctx, noteTitle)
@@ -153,8 +328,80 @@ func (m *mockNotesService) ExportNoteText(ctx context.Context, noteTitle string)
 	return "", errors.New("not implemented")
 }
 
+func (m *mockNotesService) ExtractActionItems(ctx context.Context, noteTitle string) ([]services.ActionItem, error) {
+	if m.extractActionItems != nil {
+		return m.extractActionItems(ctx, noteTitle)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) FindActionItems(ctx context.Context, query, folder string) ([]services.ActionItem, error) {
+	if m.findActionItems != nil {
+		return m.findActionItems(ctx, query, folder)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) GetUpcomingDeadlines(ctx context.Context, days int, folder string) ([]services.ActionItem, error) {
+	if m.getUpcomingDeadlines != nil {
+		return m.getUpcomingDeadlines(ctx, days, folder)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) CreateReminder(ctx context.Context, noteTitle, text, list string, dueDate *time.Time) (*services.Reminder, error) {
+	if m.createReminder != nil {
+		return m.createReminder(ctx, noteTitle, text, list, dueDate)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) PushActionItemsToReminders(ctx context.Context, noteTitle, list string) ([]services.Reminder, error) {
+	if m.pushActionItems != nil {
+		return m.pushActionItems(ctx, noteTitle, list)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) GetTodaysEvents(ctx context.Context, query string) ([]services.CalendarEvent, error) {
+	if m.getTodaysEvents != nil {
+		return m.getTodaysEvents(ctx, query)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockNotesService) ExportNoteObsidian(ctx context.Context, noteTitle string, assetsDir string) (string, error) {
+	if m.exportNoteObsidian != nil {
+		return m.exportNoteObsidian(ctx, noteTitle, assetsDir)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (m *mockNotesService) PinNote(ctx context.Context, title string) error {
+	if m.pinNote != nil {
+		return m.pinNote(ctx, title)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockNotesService) AddNoteTags(ctx context.Context, title string, tags []string) error {
+	if m.addNoteTags != nil {
+		return m.addNoteTags(ctx, title, tags)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockNotesService) GenerateWeeklyDigest(ctx context.Context, weekStart time.Time, digestFolder string) (*services.Note, error) {
+	if m.generateWeeklyDigest != nil {
+		return m.generateWeeklyDigest(ctx, weekStart, digestFolder)
+	}
+	return nil, errors.New("not implemented")
+}
+
 // Test that createErrorResult properly converts service errors to user-friendly messages
 func TestCreateErrorResult(t *testing.T) {
+	t.Setenv("NOTES_MCP_TIMEOUT", "")
+
 	tests := []struct {
 		name            string
 		err             error
@@ -179,10 +426,36 @@ func TestCreateErrorResult(t *testing.T) {
 			expectedText:    "Permission denied to access Notes. Please grant access in System Preferences > Privacy & Security > Automation.",
 			expectedIsError: true,
 		},
+		{
+			name: "note not found with suggestions",
+			err: &services.NoteNotFoundError{Title: "Meeting Note", Suggestions: []string{"Meeting Notes"},
+				Err: fmt.Errorf("failed to get note content: %w", services.ErrNoteNotFound)},
+			expectedText:    `Note not found in Apple Notes. Did you mean "Meeting Notes"? Please check the title and try again.`,
+			expectedIsError: true,
+		},
+		{
+			name: "folder not found with suggestions",
+			err: &services.FolderNotFoundError{Folder: "Wrok", Suggestions: []string{"Work", "Work Projects"},
+				Err: fmt.Errorf("failed to move note: %w", services.ErrFolderNotFound)},
+			expectedText:    `Folder not found in Apple Notes. Did you mean "Work", "Work Projects"? Use list_folders to see every folder.`,
+			expectedIsError: true,
+		},
+		{
+			name:            "folder not found",
+			err:             services.ErrFolderNotFound,
+			expectedText:    "Folder not found in Apple Notes. Use list_folders to see every folder.",
+			expectedIsError: true,
+		},
+		{
+			name:            "operation timeout",
+			err:             fmt.Errorf("failed to search notes: %w", services.ErrScriptTimeout),
+			expectedText:    "Apple Notes is not responding: failed to search notes: AppleScript execution timeout after the 30s operation limit. Please try again, or set NOTES_MCP_TIMEOUT to a larger number of seconds to allow more time.",
+			expectedIsError: true,
+		},
 		{
 			name:            "script timeout",
-			err:             services.ErrScriptTimeout,
-			expectedText:    "Apple Notes is not responding (timeout after 10 seconds). Please try again.",
+			err:             fmt.Errorf("failed to list folders: %w", &services.TimeoutError{Elapsed: 10 * time.Second, Limit: 10 * time.Second}),
+			expectedText:    "Apple Notes is not responding: failed to list folders: AppleScript execution timeout after 10s (limit 10s). Please try again, or set NOTES_MCP_TIMEOUT to a larger number of seconds to allow more time.",
 			expectedIsError: true,
 		},
 		{
@@ -191,6 +464,12 @@ func TestCreateErrorResult(t *testing.T) {
 			expectedText:    "Invalid input: invalid input parameters",
 			expectedIsError: true,
 		},
+		{
+			name:            "conflict",
+			err:             services.ErrConflict,
+			expectedText:    "Update rejected: note was modified since it was read. Re-read the note and retry with the new modification date or hash.",
+			expectedIsError: true,
+		},
 		{
 			name:            "unknown error",
 			err:             errors.New("something went wrong"),
@@ -224,6 +503,77 @@ func TestCreateErrorResult(t *testing.T) {
 	}
 }
 
+// TestCreateNoteValidation verifies bad input is rejected with details before reaching the service
+func TestCreateNoteValidation(t *testing.T) {
+	created := false
+	mock := &mockNotesService{
+		createNote: func(ctx context.Context, title, content string, tags []string) (*services.Note, error) {
+			created = true
+			return &services.Note{Title: title}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerCreateNoteTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "create_note", map[string]any{"title": "  ", "content": "bad\x00byte"})
+	if !result.IsError || created {
+		t.Fatalf("expected a validation error before creating, got %s", firstText(result))
+	}
+	want := "Invalid input: invalid input parameters: title is required; content contains the control character U+0000"
+	if firstText(result) != want {
+		t.Errorf("text = %q, want %q", firstText(result), want)
+	}
+
+	details, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatalf("failed to marshal structured content: %v", err)
+	}
+	if !strings.Contains(string(details), `{"field":"title","message":"is required","rule":"required"}`) {
+		t.Errorf("structured content lacks the title error: %s", details)
+	}
+}
+
+// TestCreateNoteContentType verifies markdown and html content are converted before the service sees them
+func TestCreateNoteContentType(t *testing.T) {
+	var gotContent string
+	mock := &mockNotesService{
+		createNote: func(ctx context.Context, title, content string, tags []string) (*services.Note, error) {
+			gotContent = content
+			return &services.Note{Title: title}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerCreateNoteTool(server, mock)
+	session := connectTestClient(t, server)
+
+	tests := []struct {
+		contentType string
+		content     string
+		want        string
+	}{
+		{"", "one\ntwo", "one\ntwo"},
+		{"markdown", "## Agenda\n- [ ] demo", "<h2>Agenda</h2><ul><li>☐ demo</li></ul>"},
+		{"html", "<div>one</div>\n<div onclick=\"x()\">two</div>", "<div>one</div> <div>two</div>"},
+	}
+	for _, tt := range tests {
+		result := callToolResult(t, session, "create_note", map[string]any{
+			"title": "Meeting", "content": tt.content, "content_type": tt.contentType,
+		})
+		if result.IsError {
+			t.Fatalf("content_type %q: unexpected error: %s", tt.contentType, firstText(result))
+		}
+		if gotContent != tt.want {
+			t.Errorf("content_type %q: service got %q, want %q", tt.contentType, gotContent, tt.want)
+		}
+	}
+
+	result := callToolResult(t, session, "create_note", map[string]any{"title": "Meeting", "content": "x", "content_type": "rtf"})
+	if !result.IsError {
+		t.Error("expected an error for an unknown content_type")
+	}
+}
+
 // TestMockServiceIntegration verifies the service interface works correctly with handlers
 func TestMockServiceIntegration(t *testing.T) {
 	// This test verifies that our mock service implementation is compatible
@@ -537,6 +887,137 @@ func TestFolderNotesResourceHandler(t *testing.T) {
 	}
 }
 
+// TestFolderRecentNotesResourceHandler tests the notes:///folder/{folder}/recent resource handler
+func TestFolderRecentNotesResourceHandler(t *testing.T) {
+	modified := time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name         string
+		uri          string
+		mockNotes    []services.Note
+		expectError  bool
+		expectText   string
+		expectFolder string
+	}{
+		{
+			name:         "recent notes with dates",
+			uri:          "notes:///folder/Project%20X/recent",
+			mockNotes:    []services.Note{{Title: "Standup", Modified: modified}},
+			expectText:   "Standup (modified 2024-03-05 09:30)",
+			expectFolder: "Project X",
+		},
+		{
+			name:         "empty folder",
+			uri:          "notes:///folder/Empty/recent",
+			mockNotes:    []services.Note{},
+			expectText:   "No notes found in folder 'Empty'.",
+			expectFolder: "Empty",
+		},
+		{
+			name:        "empty folder name",
+			uri:         "notes:///folder//recent",
+			expectError: true,
+		},
+		{
+			name:        "missing recent suffix",
+			uri:         "notes:///folder/Work",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFolder string
+			var gotLimit int
+			mock := &mockNotesService{
+				getRecentInFolder: func(ctx context.Context, folder string, limit int) ([]services.Note, error) {
+					gotFolder, gotLimit = folder, limit
+					return tt.mockNotes, nil
+				},
+			}
+
+			handler := createFolderRecentNotesResourceHandler(mock)
+			result, err := handler(context.Background(), &mcp.ReadResourceRequest{
+				Params: &mcp.ReadResourceParams{URI: tt.uri},
+			})
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gotFolder != tt.expectFolder || gotLimit != 20 {
+				t.Errorf("called with folder %q limit %d, want %q limit 20", gotFolder, gotLimit, tt.expectFolder)
+			}
+
+			if result.Contents[0].Text != tt.expectText {
+				t.Errorf("expected text %q, got %q", tt.expectText, result.Contents[0].Text)
+			}
+		})
+	}
+}
+
+// TestModifiedNotesResourceHandler tests the notes:///modified/{from}/{to} resource handler
+func TestModifiedNotesResourceHandler(t *testing.T) {
+	t.Run("inclusive date range", func(t *testing.T) {
+		var gotFrom, gotTo time.Time
+		mock := &mockNotesService{
+			getModifiedBetween: func(ctx context.Context, from, to time.Time) ([]services.Note, error) {
+				gotFrom, gotTo = from, to
+				return []services.Note{{ID: "id-1", Title: "Plan", Folder: "Work"}}, nil
+			},
+		}
+
+		handler := createModifiedNotesResourceHandler(mock)
+		result, err := handler(context.Background(), &mcp.ReadResourceRequest{
+			Params: &mcp.ReadResourceParams{URI: "notes:///modified/2024-01-01/2024-01-07"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		wantFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+		wantTo := time.Date(2024, 1, 8, 0, 0, 0, 0, time.Local)
+		if !gotFrom.Equal(wantFrom) || !gotTo.Equal(wantTo) {
+			t.Errorf("range = [%v, %v), want [%v, %v)", gotFrom, gotTo, wantFrom, wantTo)
+		}
+
+		var notes []services.Note
+		if err := json.Unmarshal([]byte(result.Contents[0].Text), &notes); err != nil {
+			t.Fatalf("result is not JSON: %v", err)
+		}
+		if len(notes) != 1 || notes[0].Folder != "Work" {
+			t.Errorf("unexpected notes: %+v", notes)
+		}
+		if result.Contents[0].MIMEType != "application/json" {
+			t.Errorf("MIMEType = %q, want application/json", result.Contents[0].MIMEType)
+		}
+	})
+
+	invalid := []string{
+		"notes:///modified/2024-01-01",
+		"notes:///modified/yesterday/2024-01-07",
+		"notes:///modified/2024-01-07/2024-01-01",
+	}
+	for _, uri := range invalid {
+		t.Run(uri, func(t *testing.T) {
+			handler := createModifiedNotesResourceHandler(&mockNotesService{})
+			_, err := handler(context.Background(), &mcp.ReadResourceRequest{
+				Params: &mcp.ReadResourceParams{URI: uri},
+			})
+			if !errors.Is(err, services.ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
 // TestDailyReviewPrompt tests the daily-review prompt handler
 func TestDailyReviewPrompt(t *testing.T) {
 	mock := &mockNotesService{}
@@ -611,6 +1092,30 @@ func TestMeetingPrepPrompt(t *testing.T) {
 	}
 }
 
+// TestFormatCalendarContext tests formatting of calendar events for the meeting-prep prompt
+func TestFormatCalendarContext(t *testing.T) {
+	if got := formatCalendarContext(nil); got != "" {
+		t.Errorf("expected empty context for no events, got %q", got)
+	}
+
+	events := []services.CalendarEvent{
+		{
+			Title:     "Sprint Review",
+			Start:     time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC),
+			End:       time.Date(2024, 7, 1, 15, 0, 0, 0, time.UTC),
+			Location:  "Room 4",
+			Calendar:  "Work",
+			Attendees: []string{"Alice", "Bob"},
+		},
+	}
+
+	got := formatCalendarContext(events)
+	expected := "\n\nToday's matching calendar events:\n- 14:00-15:00 Sprint Review (Work calendar)\n  Location: Room 4\n  Attendees: Alice, Bob"
+	if got != expected {
+		t.Errorf("formatCalendarContext() = %q, want %q", got, expected)
+	}
+}
+
 // TestActionItemsPrompt tests the action-items prompt handler
 func TestActionItemsPrompt(t *testing.T) {
 	tests := []struct {
@@ -797,6 +1302,66 @@ func TestRegisterSearchNotesAdvancedTool(t *testing.T) {
 	// If we get here without panic, registration succeeded
 }
 
+// TestSearchNotesAdvancedPropertyFilters tests that property and folder-exclusion arguments reach the search options
+func TestSearchNotesAdvancedPropertyFilters(t *testing.T) {
+	var got services.SearchOptions
+	mock := &mockNotesService{
+		searchNotesAdvanced: func(ctx context.Context, opts services.SearchOptions) ([]services.Note, error) {
+			got = opts
+			return []services.Note{{Title: "Shopping"}}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerSearchNotesAdvancedTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "search_notes_advanced", map[string]any{
+		"query": "shop", "has_checklist": true, "shared": true, "exclude_folders": []string{"Archive"},
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if !got.HasChecklist || !got.Shared || got.HasAttachments || got.Locked {
+		t.Errorf("search options = %+v", got)
+	}
+	if len(got.ExcludeFolders) != 1 || got.ExcludeFolders[0] != "Archive" {
+		t.Errorf("exclude folders = %v, want [Archive]", got.ExcludeFolders)
+	}
+}
+
+// TestListNotesByPrefixTool tests the prefix and scoped folder passed to the service
+func TestListNotesByPrefixTool(t *testing.T) {
+	var gotPrefix, gotFolder string
+	mock := &mockNotesService{
+		listNotesByPrefix: func(ctx context.Context, prefix, folder string) ([]services.Note, error) {
+			gotPrefix, gotFolder = prefix, folder
+			if prefix == "Nothing" {
+				return []services.Note{}, nil
+			}
+			return []services.Note{{ID: "id1", Title: "Project X — Kickoff"}}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerListNotesByPrefixTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "list_notes_by_prefix", map[string]any{"prefix": "Project X —", "folder": "Work"})
+	if result.IsError || !strings.Contains(firstText(result), "Project X — Kickoff") {
+		t.Errorf("unexpected result: %s", firstText(result))
+	}
+	if gotPrefix != "Project X —" || gotFolder != "Work" {
+		t.Errorf("prefix, folder = %q, %q", gotPrefix, gotFolder)
+	}
+
+	if text := firstText(callToolResult(t, session, "list_notes_by_prefix", map[string]any{"prefix": "Nothing"})); !strings.Contains(text, "No notes found") {
+		t.Errorf("expected empty message, got %s", text)
+	}
+
+	if result := callToolResult(t, session, "list_notes_by_prefix", map[string]any{"prefix": " "}); !result.IsError {
+		t.Error("expected error for blank prefix")
+	}
+}
+
 // TestRegisterGetNoteAttachmentsTool tests the get_note_attachments tool registration
 func TestRegisterGetNoteAttachmentsTool(t *testing.T) {
 	mock := &mockNotesService{
@@ -825,10 +1390,108 @@ func TestRegisterGetAttachmentContentTool(t *testing.T) {
 	}
 	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
 
-	registerGetAttachmentContentTool(server, mock)
+	registerGetAttachmentContentTool(server, mock, "")
 	// If we get here without panic, registration succeeded
 }
 
+// TestGetAttachmentContentChunks tests offset/length reads and copy_to_dir
+func TestGetAttachmentContentChunks(t *testing.T) {
+	var gotOffset, gotLength int64
+	mock := &mockNotesService{
+		readAttachmentChunk: func(ctx context.Context, filePath string, offset, length int64) (*services.AttachmentChunk, error) {
+			gotOffset, gotLength = offset, length
+			return &services.AttachmentChunk{Offset: offset, Length: 3, TotalSize: 20, Data: []byte("abc")}, nil
+		},
+		copyAttachment: func(ctx context.Context, filePath string, destDir string) (*services.AttachmentCopy, error) {
+			return &services.AttachmentCopy{Path: destDir + "/big.mov", Size: 1 << 30}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetAttachmentContentTool(server, mock, "")
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "get_attachment_content", map[string]any{"file_path": "/tmp/big.mov", "offset": 5, "max_size_mb": 1})
+	var chunk services.AttachmentChunk
+	if err := json.Unmarshal([]byte(firstText(result)), &chunk); err != nil {
+		t.Fatalf("failed to decode chunk: %v", err)
+	}
+	if string(chunk.Data) != "abc" || gotOffset != 5 || gotLength != 1024*1024 {
+		t.Errorf("chunk %+v read at %d/%d", chunk, gotOffset, gotLength)
+	}
+
+	if result := callToolResult(t, session, "get_attachment_content", map[string]any{"file_path": "/tmp/big.mov", "copy_to_dir": "out"}); !result.IsError {
+		t.Errorf("expected copy_to_dir to be refused without an export root, got %s", firstText(result))
+	}
+
+	root := t.TempDir()
+	t.Setenv(exportDirEnvVar, root)
+	if err := os.Mkdir(filepath.Join(root, "out"), 0o700); err != nil {
+		t.Fatalf("failed to create export directory: %v", err)
+	}
+	resolvedRoot, _ := filepath.EvalSymlinks(root)
+	result = callToolResult(t, session, "get_attachment_content", map[string]any{"file_path": "/tmp/big.mov", "copy_to_dir": "out"})
+	if !strings.Contains(firstText(result), `"path":"`+filepath.Join(resolvedRoot, "out", "big.mov")+`"`) {
+		t.Errorf("unexpected copy result %s", firstText(result))
+	}
+
+	result = callToolResult(t, session, "get_attachment_content", map[string]any{"file_path": "/tmp/big.mov", "copy_to_dir": "out", "length": 10})
+	if !result.IsError {
+		t.Error("expected error combining copy_to_dir with length")
+	}
+}
+
+// TestGetAttachmentContentConfinedToFileRoot tests that every read mode refuses files outside the root
+func TestGetAttachmentContentConfinedToFileRoot(t *testing.T) {
+	root, outside := t.TempDir(), t.TempDir()
+	inside := filepath.Join(root, "Media", "photo.jpg")
+	secret := filepath.Join(outside, "secret.txt")
+	for _, path := range []string{inside, secret} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(secret, filepath.Join(root, "Media", "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	var read []string
+	mock := &mockNotesService{
+		getAttachmentContent: func(ctx context.Context, filePath string, maxSize int64) ([]byte, error) {
+			read = append(read, filePath)
+			return []byte("data"), nil
+		},
+		readAttachmentChunk: func(ctx context.Context, filePath string, offset, length int64) (*services.AttachmentChunk, error) {
+			read = append(read, filePath)
+			return &services.AttachmentChunk{Offset: offset, Length: 4, TotalSize: 4, Data: []byte("data")}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetAttachmentContentTool(server, mock, root)
+	session := connectTestClient(t, server)
+
+	for _, args := range []map[string]any{
+		{"file_path": secret},
+		{"file_path": secret, "offset": 1},
+		{"file_path": filepath.Join(root, "..", filepath.Base(outside), "secret.txt")},
+		{"file_path": filepath.Join(root, "Media", "link.txt"), "length": 2},
+		{"file_path": "Media/photo.jpg"},
+	} {
+		if result := callToolResult(t, session, "get_attachment_content", args); !result.IsError {
+			t.Errorf("expected %v to be refused, got %s", args, firstText(result))
+		}
+	}
+	if len(read) != 0 {
+		t.Errorf("expected no reads outside the root, got %v", read)
+	}
+
+	if result := callToolResult(t, session, "get_attachment_content", map[string]any{"file_path": inside, "offset": 1}); result.IsError {
+		t.Errorf("expected a file under the root to be read, got %s", firstText(result))
+	}
+}
+
 // TestRegisterExportNoteMarkdownTool tests the export_note_markdown tool registration
 func TestRegisterExportNoteMarkdownTool(t *testing.T) {
 	mock := &mockNotesService{
@@ -855,26 +1518,458 @@ func TestRegisterExportNoteTextTool(t *testing.T) {
 	// If we get here without panic, registration succeeded
 }
 
-// TestAllToolsRegistrationIntegration tests that all tools can be registered together
-func TestAllToolsRegistrationIntegration(t *testing.T) {
-	mock := &mockNotesService{}
+// TestGetAttachmentThumbnailTool tests that thumbnails are returned as image content
+func TestGetAttachmentThumbnailTool(t *testing.T) {
+	mock := &mockNotesService{
+		getAttachmentThumb: func(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*services.Thumbnail, error) {
+			return &services.Thumbnail{Name: attachmentName, MIMEType: "image/jpeg", MaxPx: maxPx, Data: []byte("jpeg")}, nil
+		},
+	}
 	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetAttachmentThumbnailTool(server, mock)
+	session := connectTestClient(t, server)
 
-	// Register all tools (original 6 + new 8 = 14 total)
-	registerCreateNoteTool(server, mock)
+	result := callToolResult(t, session, "get_attachment_thumbnail", map[string]any{"note_title": "Meeting", "attachment_name": "board.png", "max_px": 128})
+	if result.IsError || len(result.Content) != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	image, ok := result.Content[0].(*mcp.ImageContent)
+	if !ok {
+		t.Fatalf("expected image content, got %T", result.Content[0])
+	}
+	if image.MIMEType != "image/jpeg" || string(image.Data) != "jpeg" {
+		t.Errorf("unexpected image %s %q", image.MIMEType, image.Data)
+	}
+
+	if _, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "get_attachment_thumbnail", Arguments: map[string]any{"note_title": "Meeting"}}); err == nil {
+		t.Error("expected error without attachment_name")
+	}
+}
+
+// TestImportHTMLTool tests that the session root folder is the default destination
+func TestImportHTMLTool(t *testing.T) {
+	var gotPath, gotURL, gotFolder string
+	mock := &mockNotesService{
+		importHTMLFile: func(ctx context.Context, filePath, sourceURL, folder string) (*services.ImportResult, error) {
+			gotPath, gotURL, gotFolder = filePath, sourceURL, folder
+			return &services.ImportResult{Imported: []string{"Ten Go Tips"}, Duplicates: []string{}, Failed: []services.ImportFailure{}}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerImportHTMLTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "import_html", map[string]any{"path": "/tmp/page.html", "source_url": "https://example.com", "folder": "Reading"})
+	if !strings.Contains(firstText(result), `"imported":["Ten Go Tips"]`) {
+		t.Errorf("unexpected result %s", firstText(result))
+	}
+	if gotPath != "/tmp/page.html" || gotURL != "https://example.com" || gotFolder != "Reading" {
+		t.Errorf("service called with %q, %q, %q", gotPath, gotURL, gotFolder)
+	}
+
+	if result := callToolResult(t, session, "import_html", map[string]any{"path": ""}); !result.IsError {
+		t.Error("expected error without path")
+	}
+}
+
+// TestTranscribeAttachmentTool tests the transcript JSON and required arguments
+func TestTranscribeAttachmentTool(t *testing.T) {
+	var gotAppend bool
+	mock := &mockNotesService{
+		transcribeAttachment: func(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*services.Transcript, error) {
+			gotAppend = appendToNote
+			return &services.Transcript{NoteTitle: noteTitle, Attachment: attachmentName, Text: "Hello", Appended: appendToNote}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerTranscribeAttachmentTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "transcribe_attachment", map[string]any{"note_title": "Errands", "attachment_name": "memo.m4a", "append_to_note": true})
+	if !strings.Contains(firstText(result), `"text":"Hello"`) || !gotAppend {
+		t.Errorf("unexpected result %s", firstText(result))
+	}
+
+	if result := callToolResult(t, session, "transcribe_attachment", map[string]any{"note_title": "Errands", "attachment_name": ""}); !result.IsError {
+		t.Error("expected error without attachment_name")
+	}
+}
+
+// TestClipURLTool tests the clipped note JSON and the session root folder default
+func TestClipURLTool(t *testing.T) {
+	var gotFolder string
+	mock := &mockNotesService{
+		clipURL: func(ctx context.Context, pageURL, folder string) (*services.Note, error) {
+			gotFolder = folder
+			return &services.Note{Title: "Why Plain Text Lasts", Folder: folder}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerClipURLTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "clip_url", map[string]any{"url": "https://example.com/post", "folder": "Research"})
+	if !strings.Contains(firstText(result), `"title":"Why Plain Text Lasts"`) || gotFolder != "Research" {
+		t.Errorf("unexpected result %s (folder %q)", firstText(result), gotFolder)
+	}
+
+	if result := callToolResult(t, session, "clip_url", map[string]any{"url": ""}); !result.IsError {
+		t.Error("expected error without url")
+	}
+}
+
+// TestExportNotesCSVTool tests the CSV columns and the title filter
+func TestExportNotesCSVTool(t *testing.T) {
+	var gotFolder string
+	mock := &mockNotesService{
+		listNotesWithMetadata: func(ctx context.Context, folder string) ([]services.Note, error) {
+			gotFolder = folder
+			return []services.Note{
+				{ID: "id1", Title: "Q1 Budget", Folder: "Work", PasswordProtected: true},
+				{ID: "id2", Title: "Groceries", Folder: "Work"},
+			}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerExportNotesCSVTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "export_notes_csv", map[string]any{"folder": "Work", "query": "budget"})
+	want := "id,title,folder,created,modified,shared,locked\nid1,Q1 Budget,Work,,,false,true\n"
+	if text := firstText(result); text != want {
+		t.Errorf("CSV = %q, want %q", text, want)
+	}
+	if gotFolder != "Work" {
+		t.Errorf("folder = %q, want %q", gotFolder, "Work")
+	}
+}
+
+// TestIncludeMetricsOption tests that include_metrics adds metrics to search results and CSV columns
+func TestIncludeMetricsOption(t *testing.T) {
+	var enriched int
+	mock := &mockNotesService{
+		searchNotes: func(ctx context.Context, query string) ([]services.Note, error) {
+			return []services.Note{{ID: "id1", Title: "Plan"}}, nil
+		},
+		listNotesWithMetadata: func(ctx context.Context, folder string) ([]services.Note, error) {
+			return []services.Note{{ID: "id1", Title: "Plan"}}, nil
+		},
+		withNoteMetrics: func(ctx context.Context, notes []services.Note) ([]services.Note, error) {
+			enriched++
+			for i := range notes {
+				notes[i].Metrics = &services.NoteMetrics{WordCount: 450, ReadTimeMinutes: 3, HasChecklist: true}
+			}
+			return notes, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
 	registerSearchNotesTool(server, mock)
+	registerExportNotesCSVTool(server, mock)
+	session := connectTestClient(t, server)
+
+	text := firstText(callToolResult(t, session, "search_notes", map[string]any{"query": "plan"}))
+	if strings.Contains(text, "metrics") || enriched != 0 {
+		t.Errorf("metrics computed without include_metrics: %s", text)
+	}
+
+	text = firstText(callToolResult(t, session, "search_notes", map[string]any{"query": "plan", "include_metrics": true}))
+	if !strings.Contains(text, `"read_time_minutes": 3`) {
+		t.Errorf("expected metrics in results, got %s", text)
+	}
+
+	text = firstText(callToolResult(t, session, "export_notes_csv", map[string]any{"include_metrics": true}))
+	if !strings.HasPrefix(text, "id,title,folder,created,modified,shared,locked,word_count,read_time_minutes,has_checklist,has_attachments\n") ||
+		!strings.Contains(text, ",450,3,true,false") {
+		t.Errorf("CSV = %q", text)
+	}
+}
+
+// TestHighlightOption tests that highlight marks matches in returned content but not in the hash
+func TestHighlightOption(t *testing.T) {
+	body := `<div class="plan">Plan the launch</div>`
+	mock := &mockNotesService{
+		getNoteMetadata: func(ctx context.Context, title string) (*services.Note, error) {
+			return &services.Note{Title: title}, nil
+		},
+		getNoteContent: func(ctx context.Context, title string) (string, error) {
+			return body, nil
+		},
+		exportNoteText: func(ctx context.Context, noteTitle string) (string, error) {
+			return "Plan the launch", nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
 	registerGetNoteContentTool(server, mock)
-	registerUpdateNoteTool(server, mock)
-	registerDeleteNoteTool(server, mock)
-	registerListFoldersTool(server, mock)
-	registerCreateFolderTool(server, mock)
-	registerMoveNoteTool(server, mock)
-	registerGetFolderHierarchyTool(server, mock)
-	registerSearchNotesAdvancedTool(server, mock)
-	registerGetNoteAttachmentsTool(server, mock)
-	registerGetAttachmentContentTool(server, mock)
-	registerExportNoteMarkdownTool(server, mock)
 	registerExportNoteTextTool(server, mock)
+	session := connectTestClient(t, server)
+
+	var note services.Note
+	result := callToolResult(t, session, "get_note_content", map[string]any{"title": "Launch", "highlight": "plan"})
+	if err := json.Unmarshal([]byte(firstText(result)), &note); err != nil {
+		t.Fatalf("failed to decode note: %v", err)
+	}
+	if note.Content != `<div class="plan"><mark>Plan</mark> the launch</div>` {
+		t.Errorf("content = %q", note.Content)
+	}
+	if note.ContentHash != services.ContentHash(body) {
+		t.Error("content hash should be computed from the unhighlighted body")
+	}
+
+	result = callToolResult(t, session, "export_note_text", map[string]any{"note_title": "Launch", "highlight": "LAUNCH"})
+	if text := firstText(result); text != "Plan the **launch**" {
+		t.Errorf("text = %q", text)
+	}
+}
+
+// TestAllToolsRegistrationIntegration tests that all tools can be registered together
+func TestAllToolsRegistrationIntegration(t *testing.T) {
+	mock := &mockNotesService{}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+
+	// Register every tool in the registry, opt-in ones included
+	deps := &toolDeps{
+		notes:       mock,
+		provider:    "applescript",
+		roots:       newSessionRoots(),
+		changes:     newSessionChanges(),
+		bookmarks:   services.NewBookmarkStore(filepath.Join(t.TempDir(), "bookmarks.json")),
+		briefs:      services.NewBriefStore(filepath.Join(t.TempDir(), "briefs.json")),
+		linkChecker: services.NewHTTPLinkChecker(0),
+		permissions: newPermissionState(mock),
+	}
+	for _, spec := range toolRegistry {
+		spec.register(server, deps)
+	}
 
 	// If we get here without panic, all registrations succeeded
 }
+
+// TestGenerateWeeklyDigestTool tests week start parsing and the folder passed to the service
+func TestGenerateWeeklyDigestTool(t *testing.T) {
+	var gotStart time.Time
+	var gotFolder string
+	mock := &mockNotesService{
+		generateWeeklyDigest: func(ctx context.Context, weekStart time.Time, digestFolder string) (*services.Note, error) {
+			gotStart, gotFolder = weekStart, digestFolder
+			return &services.Note{Title: "Weekly Digest 2024-01-01 to 2024-01-07", Folder: "Reviews"}, nil
+		},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGenerateWeeklyDigestTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "generate_weekly_digest", map[string]any{"week_start": "2024-01-01", "folder": "Reviews"})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local); !gotStart.Equal(want) {
+		t.Errorf("week start = %v, want local midnight %v", gotStart, want)
+	}
+	if gotFolder != "Reviews" {
+		t.Errorf("folder = %q, want %q", gotFolder, "Reviews")
+	}
+	if !strings.Contains(firstText(result), "Weekly Digest 2024-01-01") {
+		t.Errorf("expected the created note in the result, got %s", firstText(result))
+	}
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "generate_weekly_digest",
+		Arguments: map[string]any{"week_start": "last week"},
+	})
+	if err == nil && !result.IsError {
+		t.Error("expected an invalid date to be rejected")
+	}
+}
+
+// TestReadNotesBundleTool tests combining notes into one markdown document
+func TestReadNotesBundleTool(t *testing.T) {
+	notesService := services.NewMemoryNotesService()
+	for _, title := range []string{"Brief", "Milestones"} {
+		if _, err := notesService.CreateNote(context.Background(), title, "<div>"+title+" body</div>", nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerReadNotesBundleTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "read_notes_bundle", map[string]any{"notes": []string{"Milestones", "Brief"}})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	text := firstText(result)
+	if !strings.HasPrefix(text, "# Note bundle (2 of 2 notes)") || !strings.Contains(text, "Brief body") {
+		t.Errorf("unexpected bundle:\n%s", text)
+	}
+	if strings.Index(text, "## Milestones") > strings.Index(text, "## Brief") {
+		t.Error("expected notes in the requested order")
+	}
+
+	if result := callToolResult(t, session, "read_notes_bundle", map[string]any{"notes": []string{}}); !result.IsError {
+		t.Error("expected an empty note list to be rejected")
+	}
+
+	// A budget too small for both notes omits the one listed last and reports it
+	result = callToolResult(t, session, "read_notes_bundle", map[string]any{"notes": []string{"Brief", "Milestones"}, "max_chars": 150})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if text := firstText(result); len(text) > 150 || !strings.Contains(text, "- Milestones: omitted") {
+		t.Errorf("expected Milestones omitted within 150 characters, got %d:\n%s", len(text), text)
+	}
+
+	if result := callToolResult(t, session, "read_notes_bundle", map[string]any{"notes": []string{"Brief"}, "priority": "size"}); !result.IsError {
+		t.Error("expected an unknown priority to be rejected")
+	}
+}
+
+// TestFindActionItemsTool tests status filtering and the folder passed to the service
+func TestFindActionItemsTool(t *testing.T) {
+	var gotFolder string
+	mock := &mockNotesService{
+		findActionItems: func(ctx context.Context, query, folder string) ([]services.ActionItem, error) {
+			gotFolder = folder
+			return []services.ActionItem{
+				{Text: "Open task", SourceNote: "Plan", DueHint: "by Friday"},
+				{Text: "Finished task", Done: true, SourceNote: "Plan"},
+			}, nil
+		},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerFindActionItemsTool(server, mock)
+	session := connectTestClient(t, server)
+
+	tests := []struct {
+		status string
+		want   []string
+	}{
+		{status: "", want: []string{"Open task"}},
+		{status: "completed", want: []string{"Finished task"}},
+		{status: "all", want: []string{"Open task", "Finished task"}},
+	}
+
+	for _, tt := range tests {
+		result := callToolResult(t, session, "find_action_items", map[string]any{"query": "plan", "status": tt.status, "folder": "Work"})
+
+		var output struct {
+			Items []services.ActionItem `json:"items"`
+		}
+		if err := json.Unmarshal([]byte(firstText(result)), &output); err != nil {
+			t.Fatalf("status %q: failed to decode result: %v", tt.status, err)
+		}
+		if len(output.Items) != len(tt.want) {
+			t.Fatalf("status %q: got %+v, want %v", tt.status, output.Items, tt.want)
+		}
+		for i, item := range output.Items {
+			if item.Text != tt.want[i] {
+				t.Errorf("status %q: item %d = %q, want %q", tt.status, i, item.Text, tt.want[i])
+			}
+		}
+	}
+
+	if gotFolder != "Work" {
+		t.Errorf("folder = %q, want %q", gotFolder, "Work")
+	}
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "find_action_items",
+		Arguments: map[string]any{"query": "plan", "status": "someday"},
+	})
+	if err == nil && !result.IsError {
+		t.Error("expected an invalid status to be rejected")
+	}
+}
+
+// TestGetUpcomingDeadlinesTool tests the default window and argument validation
+func TestGetUpcomingDeadlinesTool(t *testing.T) {
+	var gotDays int
+	mock := &mockNotesService{
+		getUpcomingDeadlines: func(ctx context.Context, days int, folder string) ([]services.ActionItem, error) {
+			gotDays = days
+			due := time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local)
+			return []services.ActionItem{{Text: "Ship", SourceNote: "Launch", DueHint: "2024-07-01", Due: &due}}, nil
+		},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetUpcomingDeadlinesTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "get_upcoming_deadlines", map[string]any{})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if gotDays != defaultDeadlineDays {
+		t.Errorf("days = %d, want default %d", gotDays, defaultDeadlineDays)
+	}
+	if !strings.Contains(firstText(result), `"due_hint": "2024-07-01"`) {
+		t.Errorf("expected the deadline in the result, got %s", firstText(result))
+	}
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "get_upcoming_deadlines",
+		Arguments: map[string]any{"days": -3},
+	})
+	if err == nil && !result.IsError {
+		t.Error("expected negative days to be rejected")
+	}
+}
+
+// TestGetNoteContentToolReportsTolerantMatch tests that a punctuation-tolerant read returns the stored title
+func TestGetNoteContentToolReportsTolerantMatch(t *testing.T) {
+	notesService := services.NewMemoryNotesService()
+	if _, err := notesService.CreateNote(context.Background(), "Harper’s “Plan”", "<div>steps</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetNoteContentTool(server, notesService)
+	registerDeleteNoteTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "get_note_content", map[string]any{"title": `Harper's "Plan"`})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	var note services.Note
+	if err := json.Unmarshal([]byte(firstText(result)), &note); err != nil {
+		t.Fatalf("failed to parse note: %v", err)
+	}
+	if note.Title != "Harper’s “Plan”" || note.Content != "<div>steps</div>" {
+		t.Errorf("expected the stored note, got %+v", note)
+	}
+
+	result = callToolResult(t, session, "delete_note", map[string]any{"title": `Harper's "Plan"`})
+	if !result.IsError || !strings.Contains(firstText(result), "Harper’s “Plan”") {
+		t.Errorf("expected delete to fail suggesting the stored title, got %s", firstText(result))
+	}
+}
+
+// TestGetAttachmentContentCopyReadOnly tests that NOTES_MCP_READ_ONLY refuses copy_to_dir but not reads
+func TestGetAttachmentContentCopyReadOnly(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(exportDirEnvVar, root)
+	mock := &mockNotesService{
+		getAttachmentContent: func(ctx context.Context, filePath string, maxSize int64) ([]byte, error) {
+			return []byte("abc"), nil
+		},
+		copyAttachment: func(ctx context.Context, filePath string, destDir string) (*services.AttachmentCopy, error) {
+			t.Error("a read-only server must not copy attachments")
+			return &services.AttachmentCopy{}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetAttachmentContentTool(server, services.DecorateNotesService(mock, services.ReadOnlyMiddleware()), "")
+	session := connectTestClient(t, server)
+
+	if result := callToolResult(t, session, "get_attachment_content", map[string]any{"file_path": "/tmp/a.png"}); result.IsError {
+		t.Errorf("expected reads to work read-only, got %s", firstText(result))
+	}
+	result := callToolResult(t, session, "get_attachment_content", map[string]any{"file_path": "/tmp/a.png", "copy_to_dir": root})
+	if !result.IsError || !strings.Contains(firstText(result), "read-only") {
+		t.Errorf("expected copy_to_dir to be refused read-only, got %s", firstText(result))
+	}
+}
@@ -0,0 +1,53 @@
+// ABOUTME: Meta command for printing a note's metadata without its body
+// ABOUTME: Outputs id, folder, dates, and shared/locked flags as JSON for scripting and sanity checks
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/harper/notes-mcp/validation"
+	"github.com/spf13/cobra"
+)
+
+var metaCmd = &cobra.Command{
+	Use:   "meta <title>",
+	Short: "Print a note's metadata as JSON",
+	Long: `Prints a note's metadata (id, title, folder, creation and modification dates, and whether it is
+shared or locked) as JSON. The note body is never read, so this stays fast for large notes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		// Get the note metadata
+		note, err := notesService.GetNoteMetadata(ctx, title)
+		if err != nil {
+			return fmt.Errorf("failed to get note metadata: %w", err)
+		}
+
+		// Output as JSON
+		output, err := json.MarshalIndent(note, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format note metadata: %w", err)
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(metaCmd)
+}
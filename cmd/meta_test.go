@@ -0,0 +1,36 @@
+// ABOUTME: Unit tests for the meta command
+// ABOUTME: Tests CLI argument validation
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestMetaCommandArgs tests that the meta command requires exactly one non-empty title
+func TestMetaCommandArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no arguments", args: []string{"meta"}},
+		{name: "two arguments", args: []string{"meta", "title", "extra"}},
+		{name: "empty title", args: []string{"meta", "  "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd.SetArgs(tt.args)
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+		})
+	}
+}
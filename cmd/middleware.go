@@ -0,0 +1,153 @@
+// ABOUTME: MCP server middleware for request IDs and the audit log
+// ABOUTME: Tags each request with an ID that flows into executor logs, audit entries, and error messages
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// auditLogEnvVar names a file that receives one JSON line per MCP request
+const auditLogEnvVar = "NOTES_MCP_AUDIT_LOG"
+
+// auditEntry is a single line in the audit log
+type auditEntry struct {
+	Time       time.Time `json:"time"`
+	RequestID  string    `json:"request_id"`
+	Method     string    `json:"method"`
+	Tool       string    `json:"tool,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	IsError    bool      `json:"is_error"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// auditLogger appends audit entries as JSON lines; a nil logger discards entries
+// When encryptor is set, each line is sealed before it is written.
+type auditLogger struct {
+	mu        sync.Mutex
+	w         io.Writer
+	encryptor *services.StoreEncryptor
+}
+
+// newAuditLogger opens the audit log named by NOTES_MCP_AUDIT_LOG, returning nil when unset or unusable
+func newAuditLogger() *auditLogger {
+	path := os.Getenv(auditLogEnvVar)
+	if path == "" {
+		return nil
+	}
+
+	// Hold the store lock before loading the key, and never fall back to plaintext when encryption was requested
+	if err := lockStoreForWriting(path); err != nil {
+		log.Printf("Audit log disabled: %v", err)
+		return nil
+	}
+	encryptor, err := newStoreEncryptor()
+	if err != nil {
+		log.Printf("Audit log disabled: %v", err)
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		log.Printf("Audit log disabled: %v", err)
+		return nil
+	}
+	return &auditLogger{w: file, encryptor: encryptor}
+}
+
+// record writes an entry to the audit log
+func (a *auditLogger) record(entry auditEntry) {
+	if a == nil {
+		return
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if a.encryptor != nil {
+		if line, err = a.encryptor.Seal(line); err != nil {
+			log.Printf("Failed to encrypt audit entry: %v", err)
+			return
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		log.Printf("Failed to write audit log: %v", err)
+	}
+}
+
+// requestIDMiddleware assigns a request ID to every incoming request, stores it in the context
+// for the executor, records the request in the audit log, and appends the ID to tool errors
+func requestIDMiddleware(audit *auditLogger) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			requestID := services.NewRequestID()
+			ctx = services.WithRequestID(ctx, requestID)
+
+			var tool string
+			if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+				tool = params.Name
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+			duration := time.Since(start)
+
+			entry := auditEntry{
+				Time:       start.UTC(),
+				RequestID:  requestID,
+				Method:     method,
+				Tool:       tool,
+				DurationMS: duration.Milliseconds(),
+			}
+
+			switch {
+			case err != nil:
+				entry.IsError = true
+				entry.Error = err.Error()
+				err = fmt.Errorf("%w (request ID: %s)", err, requestID)
+			default:
+				if toolResult, ok := result.(*mcp.CallToolResult); ok && toolResult.IsError {
+					entry.IsError = true
+					entry.Error = annotateToolError(toolResult, requestID)
+				}
+			}
+
+			audit.record(entry)
+			if tool != "" {
+				log.Printf("[%s] %s %s completed in %s (error: %t)", requestID, method, tool,
+					duration.Round(time.Millisecond), entry.IsError)
+			}
+
+			return result, err
+		}
+	}
+}
+
+// annotateToolError appends the request ID to a tool error's text and returns the original message
+func annotateToolError(result *mcp.CallToolResult, requestID string) string {
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			message := text.Text
+			text.Text = fmt.Sprintf("%s (request ID: %s)", message, requestID)
+			return message
+		}
+	}
+
+	result.Content = append(result.Content, &mcp.TextContent{Text: fmt.Sprintf("Request ID: %s", requestID)})
+	return ""
+}
@@ -0,0 +1,87 @@
+// ABOUTME: Unit tests for the request ID middleware and audit log
+// ABOUTME: Tests context propagation, error annotation, and audit entries
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRequestIDMiddlewareAnnotatesToolErrors(t *testing.T) {
+	var buf bytes.Buffer
+	audit := &auditLogger{w: &buf}
+
+	var seenID string
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		seenID = services.RequestIDFromContext(ctx)
+		return createErrorResult(services.ErrNoteNotFound), nil
+	}
+
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_note_content"}}
+	result, err := requestIDMiddleware(audit)(next)(context.Background(), "tools/call", req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if seenID == "" {
+		t.Fatal("expected a request ID in the handler context")
+	}
+
+	text := result.(*mcp.CallToolResult).Content[0].(*mcp.TextContent).Text
+	if !strings.HasSuffix(text, "(request ID: "+seenID+")") {
+		t.Errorf("expected request ID in error text, got %q", text)
+	}
+
+	var entry auditEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid audit entry %q: %v", buf.String(), err)
+	}
+	if entry.RequestID != seenID || entry.Tool != "get_note_content" || !entry.IsError {
+		t.Errorf("unexpected audit entry: %+v", entry)
+	}
+	if strings.Contains(entry.Error, "request ID") {
+		t.Errorf("audit error should hold the original message, got %q", entry.Error)
+	}
+}
+
+func TestRequestIDMiddlewareWrapsHandlerErrors(t *testing.T) {
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return nil, services.ErrInvalidInput
+	}
+
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "create_note"}}
+	_, err := requestIDMiddleware(nil)(next)(context.Background(), "tools/call", req)
+	if !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected wrapped ErrInvalidInput, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "request ID:") {
+		t.Errorf("expected request ID in error, got %v", err)
+	}
+}
+
+func TestRequestIDMiddlewareLeavesSuccessUntouched(t *testing.T) {
+	var buf bytes.Buffer
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+	}
+
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "list_folders"}}
+	result, err := requestIDMiddleware(&auditLogger{w: &buf})(next)(context.Background(), "tools/call", req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text := result.(*mcp.CallToolResult).Content[0].(*mcp.TextContent).Text; text != "ok" {
+		t.Errorf("expected untouched result, got %q", text)
+	}
+	if !strings.Contains(buf.String(), `"is_error":false`) {
+		t.Errorf("expected a success audit entry, got %q", buf.String())
+	}
+}
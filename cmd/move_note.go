@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/harper/notes-mcp/validation"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +19,10 @@ var moveNoteCmd = &cobra.Command{
 		noteTitle := args[0]
 		targetFolder := args[1]
 
+		if err := validation.Check(validation.Title("title", noteTitle), validation.FolderName("folder", targetFolder)); err != nil {
+			return err
+		}
+
 		// Create service with real executor
 		notesService := newNotesService()
 
@@ -32,7 +37,7 @@ var moveNoteCmd = &cobra.Command{
 		}
 
 		// Output success message
-		fmt.Printf("Note '%s' moved to folder '%s'\n", noteTitle, targetFolder)
+		printSuccess(cmd.OutOrStdout(), "Note '%s' moved to folder '%s'", noteTitle, targetFolder)
 		return nil
 	},
 }
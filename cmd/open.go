@@ -0,0 +1,43 @@
+// ABOUTME: Open command for showing a note in Notes.app
+// ABOUTME: Activates Apple Notes with the note identified by its title selected
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/harper/notes-mcp/validation"
+	"github.com/spf13/cobra"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <title>",
+	Short: "Open a note in Notes.app",
+	Long:  `Activates Apple Notes and shows the note identified by its title.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		// Open the note
+		if err := notesService.OpenNote(ctx, title); err != nil {
+			return fmt.Errorf("failed to open note: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+}
@@ -0,0 +1,38 @@
+// ABOUTME: Unit tests for the open command
+// ABOUTME: Tests CLI argument parsing and command structure
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestOpenCommandArgs tests that the open command requires exactly 1 argument
+func TestOpenCommandArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no arguments", args: []string{"open"}},
+		{name: "two arguments", args: []string{"open", "title", "extra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up command
+			rootCmd.SetArgs(tt.args)
+
+			// Silence output
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+		})
+	}
+}
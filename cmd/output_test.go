@@ -0,0 +1,72 @@
+// ABOUTME: Tests for the --quiet and --verbose output modes
+// ABOUTME: Covers suppressed confirmations, verbose diagnostics, and rejecting both flags together
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// setOutputModes sets --quiet and --verbose for one test and restores them afterwards
+func setOutputModes(t *testing.T, quiet, verbose bool) *bytes.Buffer {
+	t.Helper()
+	var diagnostics bytes.Buffer
+	oldQuiet, oldVerbose, oldWriter := quietOutput, verboseOutput, verboseWriter
+	quietOutput, verboseOutput, verboseWriter = quiet, verbose, &diagnostics
+	t.Cleanup(func() {
+		quietOutput, verboseOutput, verboseWriter = oldQuiet, oldVerbose, oldWriter
+	})
+	return &diagnostics
+}
+
+func TestPrintSuccess(t *testing.T) {
+	var out bytes.Buffer
+	setOutputModes(t, false, false)
+	printSuccess(&out, "Note created: %s", "Plan")
+	if out.String() != "Note created: Plan\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+
+	out.Reset()
+	setOutputModes(t, true, false)
+	printSuccess(&out, "Note created: %s", "Plan")
+	if out.Len() != 0 {
+		t.Errorf("expected --quiet to suppress confirmations, got %q", out.String())
+	}
+}
+
+func TestPrintVerbose(t *testing.T) {
+	diagnostics := setOutputModes(t, false, false)
+	printVerbose("hidden")
+	if diagnostics.Len() != 0 {
+		t.Errorf("expected no diagnostics without --verbose, got %q", diagnostics.String())
+	}
+
+	diagnostics = setOutputModes(t, false, true)
+	printVerbose("script timeout %s", "10s")
+	if !strings.Contains(diagnostics.String(), "[verbose] script timeout 10s") {
+		t.Errorf("expected verbose diagnostics, got %q", diagnostics.String())
+	}
+}
+
+func TestQuietVerboseExclusive(t *testing.T) {
+	setOutputModes(t, false, false)
+	rootCmd.SetArgs([]string{"--quiet", "--verbose", "folders"})
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	defer func() {
+		rootCmd.SetArgs([]string{})
+		for _, name := range []string{"quiet", "verbose"} {
+			flag := rootCmd.PersistentFlags().Lookup(name)
+			_ = flag.Value.Set("false")
+			flag.Changed = false
+		}
+	}()
+
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "quiet") {
+		t.Errorf("expected --quiet and --verbose to be rejected together, got %v", err)
+	}
+}
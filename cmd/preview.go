@@ -0,0 +1,94 @@
+// ABOUTME: Preview command showing a note as formatted markdown in the terminal, without opening Notes.app
+// ABOUTME: Colors are used only when stdout is a terminal and NO_COLOR is unset; text wraps to the terminal width
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
+	"github.com/spf13/cobra"
+)
+
+// defaultPreviewWidth is the wrap width when --width is not given and COLUMNS is unset
+const defaultPreviewWidth = 80
+
+var (
+	previewWidth   int
+	previewNoColor bool
+)
+
+var previewCmd = &cobra.Command{
+	Use:   "preview <title>",
+	Short: "Show a note formatted for the terminal",
+	Long: `Converts a note to markdown and shows it formatted for the terminal: bold headings, bulleted and
+numbered lists, ☐/☑ checkboxes, quotes, and code. Text wraps to --width columns (default: $COLUMNS or 80;
+0 turns wrapping off). Colors are used when stdout is a terminal, unless NO_COLOR is set or --no-color
+is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+		if previewWidth < 0 {
+			return fmt.Errorf("%w: --width cannot be negative", services.ErrInvalidInput)
+		}
+
+		width := previewWidth
+		if !cmd.Flags().Changed("width") {
+			width = terminalColumns()
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		markdown, err := notesService.ExportNoteMarkdown(ctx, title)
+		if err != nil {
+			return fmt.Errorf("failed to preview note: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		opts := services.TerminalRenderOptions{Color: !previewNoColor && colorEnabled(out), Width: width}
+		fmt.Fprint(out, services.RenderTerminalMarkdown(markdown, opts)) //nolint:errcheck // stdout write failure is non-critical
+		return nil
+	},
+}
+
+// terminalColumns returns the terminal width from COLUMNS, or defaultPreviewWidth when it is unset or invalid
+func terminalColumns() int {
+	columns, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || columns <= 0 {
+		return defaultPreviewWidth
+	}
+	return columns
+}
+
+// colorEnabled reports whether w is a terminal that should get ANSI colors (see https://no-color.org)
+func colorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+
+	previewCmd.Flags().IntVar(&previewWidth, "width", defaultPreviewWidth, "Wrap text to this many columns (0: no wrapping)")
+	previewCmd.Flags().BoolVar(&previewNoColor, "no-color", false, "Don't use colors, even on a terminal")
+}
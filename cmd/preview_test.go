@@ -0,0 +1,43 @@
+// ABOUTME: Unit tests for the preview command's terminal detection
+// ABOUTME: Tests the COLUMNS wrap width and that colors are off for pipes, buffers, and NO_COLOR
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestTerminalColumns tests reading the wrap width from COLUMNS
+func TestTerminalColumns(t *testing.T) {
+	for value, want := range map[string]int{"": defaultPreviewWidth, "120": 120, "wide": defaultPreviewWidth, "-3": defaultPreviewWidth} {
+		t.Setenv("COLUMNS", value)
+		if got := terminalColumns(); got != want {
+			t.Errorf("COLUMNS=%q: terminalColumns() = %d, want %d", value, got, want)
+		}
+	}
+}
+
+// TestColorEnabled tests that only terminals get colors
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if colorEnabled(&bytes.Buffer{}) {
+		t.Error("expected no colors for a buffer")
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe failed: %v", err)
+	}
+	defer reader.Close() //nolint:errcheck // test cleanup
+	defer writer.Close() //nolint:errcheck // test cleanup
+	if colorEnabled(writer) {
+		t.Error("expected no colors for a pipe")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if colorEnabled(os.Stdout) {
+		t.Error("expected NO_COLOR to turn colors off")
+	}
+}
@@ -0,0 +1,111 @@
+// ABOUTME: Processed commands for recurring jobs: list the notes a job hasn't handled and mark them handled
+// ABOUTME: CLI counterparts of the list_unprocessed_notes and mark_note_processed tools
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
+	"github.com/spf13/cobra"
+)
+
+var (
+	processedKey    string
+	processedFolder string
+	processedLimit  int
+	processedJSON   bool
+)
+
+var processedCmd = &cobra.Command{
+	Use:   "processed",
+	Short: "Track which notes a recurring job has handled",
+	Long: `Tracks the notes a recurring job, such as summarizing new meeting notes, has already handled.
+"processed mark" stores the current time in a note's --key property (default "processed"), and
+"processed list" shows the notes without it. Give each job its own --key to track them separately.`,
+}
+
+var processedListCmd = &cobra.Command{
+	Use:   "list [query]",
+	Short: "List notes not yet marked processed",
+	Long: `Lists the notes whose title or body contains query (every note when it's omitted) that
+don't have the --key property, up to --limit (default 50), as "<folder>/<title>" lines.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := services.UnprocessedNoteOptions{Folder: processedFolder, Key: processedKey, Limit: processedLimit}
+		if len(args) == 1 {
+			opts.Query = args[0]
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		notes, err := services.FindUnprocessedNotes(ctx, notesService, opts)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if processedJSON {
+			output, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format notes: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+		if len(notes) == 0 {
+			fmt.Fprintln(out, "No unprocessed notes found.")
+			return nil
+		}
+		for _, note := range notes {
+			fmt.Fprintln(out, joinFolderPath(note.Folder, note.Title))
+		}
+		return nil
+	},
+}
+
+var processedMarkCmd = &cobra.Command{
+	Use:   "mark <title>",
+	Short: "Mark a note as processed",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		properties, err := services.MarkNoteProcessed(ctx, notesService, title, processedKey, time.Now())
+		if err != nil {
+			return err
+		}
+
+		key := processedKey
+		if key == "" {
+			key = services.DefaultProcessedKey
+		}
+		printSuccess(cmd.OutOrStdout(), "Marked %s: %s=%s", title, key, properties[key])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(processedCmd)
+	processedCmd.AddCommand(processedListCmd, processedMarkCmd)
+
+	processedCmd.PersistentFlags().StringVar(&processedKey, "key", services.DefaultProcessedKey, "The job's processed marker property")
+	processedListCmd.Flags().StringVar(&processedFolder, "folder", "", "Only check notes in this folder")
+	processedListCmd.Flags().IntVar(&processedLimit, "limit", services.DefaultUnprocessedLimit, "Maximum notes to list")
+	processedListCmd.Flags().BoolVar(&processedJSON, "json", false, "Print the notes as JSON")
+}
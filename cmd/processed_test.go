@@ -0,0 +1,51 @@
+// ABOUTME: Unit tests for the processed commands and the processed marker tools
+// ABOUTME: Tests marking a note and listing the rest under default and custom keys against the memory service
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestProcessedMarkerTools tests that marked notes drop out of list_unprocessed_notes for their key only
+func TestProcessedMarkerTools(t *testing.T) {
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	for _, title := range []string{"Meeting Monday", "Meeting Tuesday", "Groceries"} {
+		if _, err := notesService.CreateNote(ctx, title, title, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerMarkNoteProcessedTool(server, notesService)
+	registerListUnprocessedNotesTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "mark_note_processed", map[string]any{"title": "Meeting Monday", "key": "summarized"})
+	if result.IsError {
+		t.Fatalf("mark_note_processed failed: %s", firstText(result))
+	}
+
+	var report unprocessedNotesResult
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "list_unprocessed_notes",
+		map[string]any{"query": "meeting", "key": "summarized"}))), &report); err != nil {
+		t.Fatalf("list_unprocessed_notes did not return JSON: %v", err)
+	}
+	if report.Key != "summarized" || len(report.Notes) != 1 || report.Notes[0].Title != "Meeting Tuesday" {
+		t.Errorf("expected only Meeting Tuesday unsummarized, got %+v", report)
+	}
+
+	report = unprocessedNotesResult{}
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "list_unprocessed_notes", map[string]any{}))), &report); err != nil {
+		t.Fatalf("list_unprocessed_notes did not return JSON: %v", err)
+	}
+	if report.Key != services.DefaultProcessedKey || len(report.Notes) != 3 {
+		t.Errorf("expected every note unprocessed under the default key, got %+v", report)
+	}
+}
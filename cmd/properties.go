@@ -0,0 +1,93 @@
+// ABOUTME: Properties command for reading and setting a note's custom key/value properties
+// ABOUTME: CLI counterpart of the get_note_properties and set_note_property tools
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
+	"github.com/spf13/cobra"
+)
+
+var propertiesJSON bool
+
+// propertyAssignment is one key=value argument to the properties command
+type propertyAssignment struct {
+	key   string
+	value string
+}
+
+var propertiesCmd = &cobra.Command{
+	Use:   "properties <title> [key=value...]",
+	Short: "Show or set a note's custom properties",
+	Long: `Prints the custom key/value properties stored on a note, one "key=value" per line. Each
+key=value argument sets a property first; "key=" removes it. Properties live in a hidden comment
+at the top of the note body, so Notes doesn't display them.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+		assignments, err := parsePropertyAssignments(args[1:])
+		if err != nil {
+			return err
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		properties, err := services.GetNoteProperties(ctx, notesService, title)
+		if err != nil {
+			return err
+		}
+		for _, assignment := range assignments {
+			properties, err = services.SetNoteProperty(ctx, notesService, title, assignment.key, assignment.value)
+			if err != nil {
+				return err
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		if propertiesJSON {
+			output, err := json.MarshalIndent(properties, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format properties: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+		for _, key := range services.SortedPropertyKeys(properties) {
+			fmt.Fprintf(out, "%s=%s\n", key, properties[key])
+		}
+		return nil
+	},
+}
+
+// parsePropertyAssignments splits key=value arguments, rejecting any without "="
+func parsePropertyAssignments(args []string) ([]propertyAssignment, error) {
+	assignments := make([]propertyAssignment, 0, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not key=value", services.ErrInvalidInput, arg)
+		}
+		assignments = append(assignments, propertyAssignment{key: key, value: value})
+	}
+	return assignments, nil
+}
+
+func init() {
+	rootCmd.AddCommand(propertiesCmd)
+
+	propertiesCmd.Flags().BoolVar(&propertiesJSON, "json", false, "Print the properties as a JSON object")
+}
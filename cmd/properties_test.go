@@ -0,0 +1,66 @@
+// ABOUTME: Unit tests for the properties command and the note property tools
+// ABOUTME: Tests key=value parsing and setting, reading, and rejecting properties against the memory service
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestParsePropertyAssignments tests splitting key=value arguments
+func TestParsePropertyAssignments(t *testing.T) {
+	assignments, err := parsePropertyAssignments([]string{"processed=true", "url=https://x.test/?a=b", "done="})
+	if err != nil {
+		t.Fatalf("parsePropertyAssignments failed: %v", err)
+	}
+	want := []propertyAssignment{{"processed", "true"}, {"url", "https://x.test/?a=b"}, {"done", ""}}
+	if len(assignments) != len(want) {
+		t.Fatalf("got %v, want %v", assignments, want)
+	}
+	for i := range want {
+		if assignments[i] != want[i] {
+			t.Errorf("assignment %d = %v, want %v", i, assignments[i], want[i])
+		}
+	}
+
+	if _, err := parsePropertyAssignments([]string{"processed"}); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+// TestNotePropertyTools tests setting a property, reading it back, and rejecting a bad key
+func TestNotePropertyTools(t *testing.T) {
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	if _, err := notesService.CreateNote(ctx, "Plan", "Ship it", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerGetNotePropertiesTool(server, notesService)
+	registerSetNotePropertyTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "set_note_property", map[string]any{"title": "Plan", "key": "processed", "value": "true"})
+	if result.IsError {
+		t.Fatalf("set_note_property failed: %s", firstText(result))
+	}
+
+	var report notePropertiesResult
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "get_note_properties", map[string]any{"title": "Plan"}))), &report); err != nil {
+		t.Fatalf("get_note_properties did not return JSON: %v", err)
+	}
+	if report.Title != "Plan" || len(report.Properties) != 1 || report.Properties["processed"] != "true" {
+		t.Errorf("unexpected properties %+v", report)
+	}
+
+	if result := callToolResult(t, session, "set_note_property", map[string]any{"title": "Plan", "key": "two words", "value": "x"}); !result.IsError {
+		t.Error("expected a key with a space to be rejected")
+	}
+}
@@ -0,0 +1,103 @@
+// ABOUTME: Selects the notes provider for the MCP server from NOTES_MCP_PROVIDER
+// ABOUTME: Drops tools the provider's capabilities can't support and lists providers with "notes-mcp providers"
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// providerEnvVar names the notes provider the MCP server uses (default "applescript")
+const providerEnvVar = "NOTES_MCP_PROVIDER"
+
+// notesDatabaseEnvVar overrides the Notes database the sqlite provider reads
+const notesDatabaseEnvVar = "NOTES_MCP_NOTES_DB"
+
+// newProviderNotesService creates the notes service for the provider named by NOTES_MCP_PROVIDER
+// The AppleScript provider gets every setting the CLI applies, through configureAppleNotesService. Operations
+// a partial backend doesn't implement fail with services.ErrNotSupported, and every operation runs
+// through the configured service middleware, with searches on the index backend answered from the search index
+// and, when note history is on, previous versions recorded before updates and deletes.
+func newProviderNotesService() (services.Provider, services.NotesService, error) {
+	provider, err := services.LookupProvider(os.Getenv(providerEnvVar))
+	if err != nil {
+		return services.Provider{}, nil, err
+	}
+
+	backend, err := provider.New(services.ProviderConfig{
+		ScriptTimeout: getScriptTimeout(),
+		Executor:      newScriptExecutor(getScriptTimeout()),
+		Database:      os.Getenv(notesDatabaseEnvVar),
+	})
+	if err != nil {
+		return services.Provider{}, nil, fmt.Errorf("failed to start notes provider %s: %w", provider.Name, err)
+	}
+	notesService := services.ComposeNotesService(backend)
+	printVerbose("using notes provider %s (script timeout %s, implements %s)", provider.Name, getScriptTimeout(),
+		services.InterfacesOf(backend))
+
+	if apple, ok := notesService.(*services.AppleNotesService); ok {
+		configureAppleNotesService(apple)
+	}
+	middleware := append(serviceMiddleware(), searchIndexMiddleware(notesService))
+	middleware = append(middleware, historyMiddleware(notesService)...)
+	return provider, services.DecorateNotesService(notesService, middleware...), nil
+}
+
+// unsupportedTools returns the registered tools a backend with the given capabilities and interfaces can't serve
+func unsupportedTools(capabilities services.ProviderCapabilities, interfaces services.BackendInterfaces) []string {
+	var tools []string
+	for _, spec := range toolRegistry {
+		if !spec.supportedBy(capabilities, interfaces) {
+			tools = append(tools, spec.name)
+		}
+	}
+	return tools
+}
+
+var providersCmd = &cobra.Command{
+	Use:   "providers",
+	Short: "List the notes providers the MCP server can use",
+	Long: `Lists the registered notes providers and their capabilities.
+Select one for the MCP server with NOTES_MCP_PROVIDER (default "applescript").`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tCAPABILITIES\tDESCRIPTION")
+		for _, name := range services.ProviderNames() {
+			provider, err := services.LookupProvider(name)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", provider.Name, describeCapabilities(provider.Capabilities), provider.Description)
+		}
+		return w.Flush()
+	},
+}
+
+// describeCapabilities summarizes capabilities as a comma-separated list
+func describeCapabilities(capabilities services.ProviderCapabilities) string {
+	var parts []string
+	if capabilities.SupportsFolders {
+		parts = append(parts, "folders")
+	}
+	if capabilities.SupportsTags {
+		parts = append(parts, "tags")
+	}
+	if capabilities.ReadOnly {
+		parts = append(parts, "read-only")
+	} else {
+		parts = append(parts, "read-write")
+	}
+	return strings.Join(parts, ",")
+}
+
+func init() {
+	rootCmd.AddCommand(providersCmd)
+}
@@ -0,0 +1,177 @@
+// ABOUTME: Tests for notes provider selection in the MCP server
+// ABOUTME: Runs the full server on the in-memory and scripted providers and checks capability-based tool filtering
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// scriptedProviderName is a test provider serving the AppleScript service, its scripts answered by scriptedExecutor
+const scriptedProviderName = "scripted-test"
+
+// scriptedExecutor answers the scripts of the scripted test provider's service
+var scriptedExecutor scriptFunc
+
+// scriptFunc answers each script with a function
+type scriptFunc func(script string) (string, string, error)
+
+func (f scriptFunc) Execute(ctx context.Context, script string) (string, string, error) {
+	return f(script)
+}
+
+func init() {
+	services.RegisterProvider(services.Provider{
+		Name:         scriptedProviderName,
+		Description:  "AppleScript service with canned script output, for tests",
+		Capabilities: services.ProviderCapabilities{SupportsFolders: true, SupportsTags: true},
+		New: func(services.ProviderConfig) (services.NoteReader, error) {
+			return services.NewAppleNotesService(scriptedExecutor), nil
+		},
+	})
+}
+
+// connectScriptedServer runs the full MCP server on the AppleScript service, answering its scripts with execute
+// Environment settings go through the same configuration as the applescript provider.
+func connectScriptedServer(t *testing.T, execute scriptFunc) *mcp.ClientSession {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(providerEnvVar, scriptedProviderName)
+	scriptedExecutor = execute
+	return connectTestClient(t, newMCPServer(""))
+}
+
+// TestMemoryProviderServer tests that NOTES_MCP_PROVIDER=memory serves every tool from memory
+func TestMemoryProviderServer(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(providerEnvVar, "memory")
+
+	session := connectTestClient(t, newMCPServer(""))
+
+	created := callToolResult(t, session, "create_note", map[string]any{"title": "Scratch", "content": "hello"})
+	if created.IsError {
+		t.Fatalf("create_note failed: %s", firstText(created))
+	}
+	content := callToolResult(t, session, "get_note_content", map[string]any{"title": "Scratch"})
+	if !strings.Contains(firstText(content), "hello") {
+		t.Errorf("expected the note body from memory, got %q", firstText(content))
+	}
+
+	opened := callToolResult(t, session, "open_note", map[string]any{"title": "Scratch"})
+	if !opened.IsError || !strings.Contains(firstText(opened), "not supported") {
+		t.Errorf("expected open_note to report it isn't supported, got %q", firstText(opened))
+	}
+
+	tools, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	names := []string{}
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	if !slices.Contains(names, "add_note_tags") || !slices.Contains(names, "get_folder_hierarchy") {
+		t.Errorf("expected tag and folder tools for the memory provider, got %v", names)
+	}
+}
+
+// TestReadOnlyProviderServers tests that NOTES_MCP_PROVIDER=jxa or sqlite starts a server offering only reading tools
+func TestReadOnlyProviderServers(t *testing.T) {
+	for _, name := range []string{"jxa", "sqlite"} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv(providerEnvVar, name)
+			t.Setenv(notesDatabaseEnvVar, filepath.Join(t.TempDir(), "NoteStore.sqlite"))
+
+			tools, err := connectTestClient(t, newMCPServer("")).ListTools(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("ListTools failed: %v", err)
+			}
+			names := []string{}
+			for _, tool := range tools.Tools {
+				names = append(names, tool.Name)
+			}
+			if !slices.Contains(names, "get_note_content") || !slices.Contains(names, "search_notes") {
+				t.Errorf("expected reading tools, got %v", names)
+			}
+			if slices.Contains(names, "create_note") || slices.Contains(names, "add_note_tags") {
+				t.Errorf("expected no writing or tag tools, got %v", names)
+			}
+		})
+	}
+}
+
+func TestUnsupportedTools(t *testing.T) {
+	if tools := unsupportedTools(services.ProviderCapabilities{SupportsTags: true, SupportsFolders: true}, services.AllBackendInterfaces); len(tools) != 0 {
+		t.Errorf("expected every tool for a full provider, got %v", tools)
+	}
+
+	readOnly := unsupportedTools(services.ProviderCapabilities{SupportsTags: true, SupportsFolders: true, ReadOnly: true}, services.AllBackendInterfaces)
+	if !slices.Contains(readOnly, "create_note") || slices.Contains(readOnly, "get_note_content") {
+		t.Errorf("expected only writing tools dropped for a read-only provider, got %v", readOnly)
+	}
+
+	flat := unsupportedTools(services.ProviderCapabilities{}, services.AllBackendInterfaces)
+	if !slices.Contains(flat, "get_folder_hierarchy") || !slices.Contains(flat, "add_note_tags") {
+		t.Errorf("expected folder and tag tools dropped, got %v", flat)
+	}
+}
+
+// TestMCPServerConfiguresTranscriber tests that NOTES_MCP_SPEECH_HELPER reaches the MCP server's service
+func TestMCPServerConfiguresTranscriber(t *testing.T) {
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "transcribe")
+	if err := os.WriteFile(helper, []byte("#!/bin/sh\necho Buy milk.\n"), 0o755); err != nil {
+		t.Fatalf("failed to write helper: %v", err)
+	}
+	memo := filepath.Join(dir, "memo.m4a")
+	if err := os.WriteFile(memo, []byte("audio"), 0o600); err != nil {
+		t.Fatalf("failed to write attachment: %v", err)
+	}
+	t.Setenv(speechHelperEnvVar, helper)
+
+	session := connectScriptedServer(t, func(script string) (string, string, error) {
+		if strings.Contains(script, "attachments of theNote") {
+			return strings.Join([]string{"att-1", "memo.m4a", "", memo, "", ""}, "\x1f") + "\x1e", "", nil
+		}
+		return "", "", nil
+	})
+
+	result := callToolResult(t, session, "transcribe_attachment", map[string]any{"note_title": "Errands", "attachment_name": "memo.m4a"})
+	if result.IsError {
+		t.Fatalf("transcribe_attachment failed: %s", firstText(result))
+	}
+	if !strings.Contains(firstText(result), "Buy milk.") {
+		t.Errorf("expected the helper's transcript, got %s", firstText(result))
+	}
+}
+
+// TestMCPServerGuardsBodySearches tests that NOTES_MCP_MAX_BODY_SEARCH_NOTES limits the MCP server's body searches
+func TestMCPServerGuardsBodySearches(t *testing.T) {
+	t.Setenv(maxBodySearchNotesEnvVar, "10")
+
+	scripts := 0
+	session := connectScriptedServer(t, func(script string) (string, string, error) {
+		scripts++
+		if strings.Contains(script, "return count of") {
+			return "50\n", "", nil
+		}
+		return "", "", nil
+	})
+
+	result := callToolResult(t, session, "search_notes_advanced", map[string]any{"query": "roadmap", "search_in": "body", "folder": "Work"})
+	if !result.IsError || !strings.Contains(firstText(result), "NOTES_MCP_MAX_BODY_SEARCH_NOTES") {
+		t.Errorf("expected the body search to be refused over the limit, got %s", firstText(result))
+	}
+	if scripts != 1 {
+		t.Errorf("expected only the count script to run, got %d scripts", scripts)
+	}
+}
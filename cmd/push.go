@@ -0,0 +1,165 @@
+// ABOUTME: Push command copying notes one way to Notion or Google Keep
+// ABOUTME: Field mappings come from ~/.config/notes-mcp/push.json (or NOTES_MCP_PUSH_CONFIG); API tokens from the environment
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// Environment variables configuring push targets
+const (
+	// pushConfigEnvVar overrides the push configuration file
+	pushConfigEnvVar = "NOTES_MCP_PUSH_CONFIG"
+	// notionTokenEnvVar is the Notion internal integration token
+	notionTokenEnvVar = "NOTES_MCP_NOTION_TOKEN"
+	// keepTokenEnvVar is a Google OAuth access token with the Keep API scope
+	keepTokenEnvVar = "NOTES_MCP_KEEP_TOKEN"
+)
+
+var (
+	pushTarget   string
+	pushFolder   string
+	pushTitles   []string
+	pushDatabase string
+	pushDryRun   bool
+)
+
+// pushConfig is the push configuration file
+type pushConfig struct {
+	Notion services.NotionPushConfig `json:"notion"`
+	Keep   services.KeepPushConfig   `json:"keep"`
+}
+
+// pushConfigPath returns the push configuration file: NOTES_MCP_PUSH_CONFIG or ~/.config/notes-mcp/push.json
+func pushConfigPath() string {
+	if path := os.Getenv(pushConfigEnvVar); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "push.json")
+}
+
+// loadPushConfig reads the push configuration; a missing file means no field mappings
+func loadPushConfig(path string) (pushConfig, error) {
+	var config pushConfig
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("failed to load push config: %w", err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to load push config from %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// newPushTarget creates the named push target from the config file and token environment variables
+func newPushTarget(name string, config pushConfig) (services.PushTarget, error) {
+	switch name {
+	case services.PushTargetNotion:
+		notion := config.Notion
+		notion.Token = os.Getenv(notionTokenEnvVar)
+		if pushDatabase != "" {
+			notion.DatabaseID = pushDatabase
+		}
+		return services.NewNotionTarget(notion)
+	case services.PushTargetKeep:
+		keep := config.Keep
+		keep.Token = os.Getenv(keepTokenEnvVar)
+		return services.NewKeepTarget(keep)
+	default:
+		return nil, fmt.Errorf("invalid target %q (must be '%s' or '%s')", name, services.PushTargetNotion, services.PushTargetKeep)
+	}
+}
+
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Copy notes to Notion or Google Keep",
+	Long: `Pushes notes one way to Notion (as pages of a database) or Google Keep, as a migration path.
+Select notes with --folder, --title (repeatable), or both. Each note is sent as markdown.
+
+Tokens are read from NOTES_MCP_NOTION_TOKEN or NOTES_MCP_KEEP_TOKEN. The database and the destination
+fields for each note's title, folder, tags, dates, and ID are set in ~/.config/notes-mcp/push.json
+(or NOTES_MCP_PUSH_CONFIG), for example:
+
+  {"notion": {"database_id": "...", "fields": {"title": "Name", "folder": "Folder", "tags": "Tags"}}}
+
+Notes are read through the configured provider (NOTES_MCP_PROVIDER). Pushing again creates new copies.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := loadPushConfig(pushConfigPath())
+		if err != nil {
+			return err
+		}
+
+		_, notesService, err := newProviderNotesService()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		selection := services.PushSelection{Folder: pushFolder, Titles: pushTitles}
+		out := cmd.OutOrStdout()
+
+		if pushDryRun {
+			notes, err := services.SelectPushNotes(ctx, notesService, selection)
+			if err != nil {
+				return err
+			}
+			for _, note := range notes {
+				fmt.Fprintf(out, "would push: %s\n", note.Title)
+			}
+			fmt.Fprintf(out, "%d notes would be pushed to %s\n", len(notes), pushTarget)
+			return nil
+		}
+
+		target, err := newPushTarget(pushTarget, config)
+		if err != nil {
+			return err
+		}
+
+		report, err := services.PushNotes(ctx, notesService, target, selection)
+		if err != nil {
+			return err
+		}
+
+		for _, pushed := range report.Pushed {
+			printSuccess(out, "pushed: %s -> %s", pushed.Title, pushed.Destination)
+		}
+		for _, failed := range report.Failed {
+			fmt.Fprintf(out, "failed: %s: %s\n", failed.Title, failed.Error)
+		}
+		if len(report.Failed) > 0 {
+			return fmt.Errorf("%d of %d notes failed to push", len(report.Failed), len(report.Failed)+len(report.Pushed))
+		}
+		printSuccess(out, "Pushed %d notes to %s", len(report.Pushed), report.Target)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pushCmd)
+
+	pushCmd.Flags().StringVar(&pushTarget, "target", "", "Destination: notion or keep")
+	pushCmd.Flags().StringVar(&pushFolder, "folder", "", "Push every note in this folder")
+	pushCmd.Flags().StringArrayVar(&pushTitles, "title", nil, "Push the note with this title (repeatable)")
+	pushCmd.Flags().StringVar(&pushDatabase, "database", "", "Notion database ID (overrides push.json)")
+	pushCmd.Flags().BoolVar(&pushDryRun, "dry-run", false, "List the notes that would be pushed without sending them")
+	_ = pushCmd.MarkFlagRequired("target") //nolint:errcheck // the flag is defined just above
+}
@@ -0,0 +1,53 @@
+// ABOUTME: Tests for the push command's configuration
+// ABOUTME: Covers loading push.json field mappings and creating targets from tokens in the environment
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPushConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := loadPushConfig(filepath.Join(dir, "missing.json"))
+	if err != nil || config.Notion.DatabaseID != "" {
+		t.Fatalf("expected an empty config for a missing file, got %+v, %v", config, err)
+	}
+
+	path := filepath.Join(dir, "push.json")
+	data := `{"notion": {"database_id": "db1", "fields": {"title": "Title", "folder": "Area"}}, "keep": {"fields": {"tags": "Labels"}}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config, err = loadPushConfig(path)
+	if err != nil {
+		t.Fatalf("loadPushConfig failed: %v", err)
+	}
+	if config.Notion.DatabaseID != "db1" || config.Notion.Fields.Folder != "Area" || config.Keep.Fields.Tags != "Labels" {
+		t.Errorf("unexpected config: %+v", config)
+	}
+}
+
+func TestNewPushTarget(t *testing.T) {
+	config := pushConfig{}
+	config.Notion.DatabaseID = "db1"
+
+	t.Setenv(notionTokenEnvVar, "")
+	if _, err := newPushTarget("notion", config); err == nil || !strings.Contains(err.Error(), "token") {
+		t.Errorf("expected a missing token error, got %v", err)
+	}
+
+	t.Setenv(notionTokenEnvVar, "secret")
+	target, err := newPushTarget("notion", config)
+	if err != nil || target.Name() != "notion" {
+		t.Errorf("expected a Notion target, got %v, %v", target, err)
+	}
+
+	if _, err := newPushTarget("evernote", config); err == nil {
+		t.Error("expected an unknown target to be rejected")
+	}
+}
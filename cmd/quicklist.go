@@ -0,0 +1,172 @@
+// ABOUTME: Quicklist command emitting launcher-friendly JSON for Alfred and Raycast
+// ABOUTME: Lists recent notes or search results with the note ID as the item argument
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// Quicklist output formats
+const (
+	quicklistFormatAlfred  = "alfred"
+	quicklistFormatRaycast = "raycast"
+)
+
+var (
+	quicklistFormat string
+	quicklistLimit  int
+)
+
+// alfredItem is a single entry in Alfred's Script Filter JSON format
+type alfredItem struct {
+	UID          string `json:"uid,omitempty"`
+	Title        string `json:"title"`
+	Subtitle     string `json:"subtitle"`
+	Arg          string `json:"arg,omitempty"`
+	Autocomplete string `json:"autocomplete,omitempty"`
+	Valid        *bool  `json:"valid,omitempty"`
+}
+
+// raycastItem is a single entry in the list consumed by Raycast script commands and extensions
+type raycastItem struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Arg      string `json:"arg"`
+	Folder   string `json:"folder"`
+	Modified string `json:"modified,omitempty"`
+}
+
+var quicklistCmd = &cobra.Command{
+	Use:   "quicklist [query]",
+	Short: "List notes as Alfred or Raycast JSON",
+	Long: `Emits recent notes (or notes whose titles match the query) as launcher JSON.
+--format=alfred (default) prints Alfred Script Filter JSON; --format=raycast prints {"items": [...]} with id, title, subtitle, arg, folder, and modified.
+Each item's arg is the note ID. Errors are reported as a single non-actionable item so the launcher shows them.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if quicklistFormat != quicklistFormatAlfred && quicklistFormat != quicklistFormatRaycast {
+			return fmt.Errorf("%w: unknown format %q (use alfred or raycast)", services.ErrInvalidInput, quicklistFormat)
+		}
+		if quicklistLimit <= 0 {
+			return fmt.Errorf("%w: limit must be positive", services.ErrInvalidInput)
+		}
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		var notes []services.Note
+		var err error
+		if len(args) == 1 && strings.TrimSpace(args[0]) != "" {
+			notes, err = notesService.SearchNotes(ctx, args[0])
+		} else {
+			notes, err = notesService.GetRecentNotes(ctx, quicklistLimit)
+		}
+
+		var output []byte
+		if err != nil {
+			output, err = formatQuicklistError(err, quicklistFormat)
+		} else {
+			if len(notes) > quicklistLimit {
+				notes = notes[:quicklistLimit]
+			}
+			output, err = formatQuicklist(notes, quicklistFormat)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to format quicklist: %w", err)
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), string(output)) //nolint:errcheck // stdout write failure is non-critical
+		return nil
+	},
+}
+
+// formatQuicklist renders notes in the requested launcher format
+func formatQuicklist(notes []services.Note, format string) ([]byte, error) {
+	if format == quicklistFormatRaycast {
+		items := make([]raycastItem, 0, len(notes))
+		for _, note := range notes {
+			item := raycastItem{
+				ID:       note.ID,
+				Title:    note.Title,
+				Subtitle: quicklistSubtitle(note),
+				Arg:      note.ID,
+				Folder:   note.Folder,
+			}
+			if modified := noteModified(note); !modified.IsZero() {
+				item.Modified = modified.Format(time.RFC3339)
+			}
+			items = append(items, item)
+		}
+		return json.Marshal(map[string][]raycastItem{"items": items})
+	}
+
+	items := make([]alfredItem, 0, len(notes))
+	for _, note := range notes {
+		items = append(items, alfredItem{
+			UID:          note.ID,
+			Title:        note.Title,
+			Subtitle:     quicklistSubtitle(note),
+			Arg:          note.ID,
+			Autocomplete: note.Title,
+		})
+	}
+	if len(items) == 0 {
+		invalid := false
+		items = append(items, alfredItem{Title: "No matching notes", Subtitle: "Try a different search", Valid: &invalid})
+	}
+	return json.Marshal(map[string][]alfredItem{"items": items})
+}
+
+// formatQuicklistError renders an error as a single non-actionable launcher item
+func formatQuicklistError(err error, format string) ([]byte, error) {
+	if format == quicklistFormatRaycast {
+		return json.Marshal(map[string]any{"items": []raycastItem{}, "error": err.Error()})
+	}
+
+	invalid := false
+	return json.Marshal(map[string][]alfredItem{"items": {{
+		Title:    "Apple Notes error",
+		Subtitle: err.Error(),
+		Valid:    &invalid,
+	}}})
+}
+
+// quicklistSubtitle builds a "Folder · Jan 2, 2006 15:04" subtitle for a note
+func quicklistSubtitle(note services.Note) string {
+	parts := []string{}
+	if note.Folder != "" {
+		parts = append(parts, note.Folder)
+	}
+	if modified := noteModified(note); !modified.IsZero() {
+		parts = append(parts, modified.Format("Jan 2, 2006 15:04"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// noteModified returns the note's modification date, preferring the full metadata field
+func noteModified(note services.Note) time.Time {
+	if !note.ModificationDate.IsZero() {
+		return note.ModificationDate
+	}
+	return note.Modified
+}
+
+func init() {
+	rootCmd.AddCommand(quicklistCmd)
+
+	// Add flags
+	quicklistCmd.Flags().StringVar(&quicklistFormat, "format", quicklistFormatAlfred, "Output format: alfred or raycast")
+	quicklistCmd.Flags().IntVar(&quicklistLimit, "limit", 20, "Maximum number of notes to list")
+}
@@ -0,0 +1,120 @@
+// ABOUTME: Unit tests for the quicklist command
+// ABOUTME: Tests flag validation and Alfred/Raycast JSON formatting
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// TestQuicklistCommandArgs tests that the quicklist command validates its arguments and flags
+func TestQuicklistCommandArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "two arguments", args: []string{"quicklist", "one", "two"}},
+		{name: "unknown format", args: []string{"quicklist", "--format", "spotlight"}},
+		{name: "zero limit", args: []string{"quicklist", "--limit", "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up command
+			rootCmd.SetArgs(tt.args)
+
+			// Silence output
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+			quicklistFormat = quicklistFormatAlfred
+			quicklistLimit = 20
+		})
+	}
+}
+
+func TestFormatQuicklistAlfred(t *testing.T) {
+	notes := []services.Note{{
+		ID:               "x-coredata://1",
+		Title:            "Standup",
+		Folder:           "Work",
+		ModificationDate: time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC),
+	}}
+
+	output, err := formatQuicklist(notes, quicklistFormatAlfred)
+	if err != nil {
+		t.Fatalf("formatQuicklist returned error: %v", err)
+	}
+
+	var parsed struct {
+		Items []alfredItem `json:"items"`
+	}
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(parsed.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(parsed.Items))
+	}
+	item := parsed.Items[0]
+	if item.Arg != "x-coredata://1" || item.UID != "x-coredata://1" || item.Title != "Standup" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+	if item.Subtitle != "Work · Mar 2, 2025 10:00" {
+		t.Errorf("unexpected subtitle: %q", item.Subtitle)
+	}
+}
+
+func TestFormatQuicklistAlfredEmpty(t *testing.T) {
+	output, err := formatQuicklist(nil, quicklistFormatAlfred)
+	if err != nil {
+		t.Fatalf("formatQuicklist returned error: %v", err)
+	}
+	if !strings.Contains(string(output), `"valid":false`) {
+		t.Errorf("expected a non-actionable placeholder item, got %s", output)
+	}
+}
+
+func TestFormatQuicklistRaycast(t *testing.T) {
+	notes := []services.Note{{ID: "x-coredata://2", Title: "Ideas", Folder: "Notes"}}
+
+	output, err := formatQuicklist(notes, quicklistFormatRaycast)
+	if err != nil {
+		t.Fatalf("formatQuicklist returned error: %v", err)
+	}
+
+	var parsed struct {
+		Items []raycastItem `json:"items"`
+	}
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(parsed.Items) != 1 || parsed.Items[0].Arg != "x-coredata://2" || parsed.Items[0].Subtitle != "Notes" {
+		t.Errorf("unexpected items: %+v", parsed.Items)
+	}
+	if parsed.Items[0].Modified != "" {
+		t.Errorf("expected empty modified for zero date, got %q", parsed.Items[0].Modified)
+	}
+}
+
+func TestFormatQuicklistError(t *testing.T) {
+	output, err := formatQuicklistError(errors.New("Notes is not running"), quicklistFormatAlfred)
+	if err != nil {
+		t.Fatalf("formatQuicklistError returned error: %v", err)
+	}
+	if !strings.Contains(string(output), "Notes is not running") || !strings.Contains(string(output), `"valid":false`) {
+		t.Errorf("unexpected error output: %s", output)
+	}
+}
@@ -0,0 +1,301 @@
+// ABOUTME: Per-session quotas for the MCP server
+// ABOUTME: Limits tool calls per minute, notes created per hour, and bytes read to stop runaway agent loops
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Environment variables setting per-session quotas; unset or 0 means unlimited
+const (
+	// quotaCallsPerMinuteEnvVar caps tool calls per session in any one-minute window
+	quotaCallsPerMinuteEnvVar = "NOTES_MCP_QUOTA_CALLS_PER_MINUTE"
+	// quotaNotesPerHourEnvVar caps notes created per session in any one-hour window
+	quotaNotesPerHourEnvVar = "NOTES_MCP_QUOTA_NOTES_PER_HOUR"
+	// quotaBytesReadEnvVar caps the total bytes of note content returned to a session
+	quotaBytesReadEnvVar = "NOTES_MCP_QUOTA_BYTES_READ"
+)
+
+// Quota names reported in quota-exceeded errors
+const (
+	quotaToolCallsPerMinute = "tool_calls_per_minute"
+	quotaNotesPerHour       = "notes_created_per_hour"
+	quotaBytesRead          = "bytes_read"
+)
+
+// noteCreatingTools are the tools counted against the notes-per-hour quota
+var noteCreatingTools = map[string]bool{
+	"create_note":            true,
+	"import_html":            true,
+	"clip_url":               true,
+	"generate_weekly_digest": true,
+}
+
+// quotaLimits holds the configured per-session limits; zero values are unlimited
+type quotaLimits struct {
+	CallsPerMinute int
+	NotesPerHour   int
+	BytesRead      int64
+}
+
+// enabled reports whether any quota is set
+func (l quotaLimits) enabled() bool {
+	return l.CallsPerMinute > 0 || l.NotesPerHour > 0 || l.BytesRead > 0
+}
+
+// quotaLimitsFromEnv reads the NOTES_MCP_QUOTA_* variables
+// Invalid values are logged and ignored so a typo never prevents startup
+func quotaLimitsFromEnv() quotaLimits {
+	return quotaLimits{
+		CallsPerMinute: int(envQuota(quotaCallsPerMinuteEnvVar)),
+		NotesPerHour:   int(envQuota(quotaNotesPerHourEnvVar)),
+		BytesRead:      envQuota(quotaBytesReadEnvVar),
+	}
+}
+
+// envQuota parses a non-negative quota from an environment variable
+func envQuota(name string) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit < 0 {
+		log.Printf("Ignoring %s=%q: expected a non-negative integer", name, value)
+		return 0
+	}
+	return limit
+}
+
+// quotaExceeded is the structured error payload returned when a session hits a quota
+type quotaExceeded struct {
+	Error             string `json:"error"`
+	Quota             string `json:"quota"`
+	Limit             int64  `json:"limit"`
+	RetryAfterSeconds int    `json:"retry_after_seconds,omitempty"`
+}
+
+// message returns a human-readable description of the exceeded quota
+func (e *quotaExceeded) message() string {
+	if e.RetryAfterSeconds > 0 {
+		return fmt.Sprintf("Quota exceeded: %s limit of %d reached for this session. Retry in %d seconds.",
+			e.Quota, e.Limit, e.RetryAfterSeconds)
+	}
+	return fmt.Sprintf("Quota exceeded: %s limit of %d reached for this session. Start a new session to continue.",
+		e.Quota, e.Limit)
+}
+
+// toolResult converts the error into a tool error carrying the structured details
+func (e *quotaExceeded) toolResult() *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: e.message(),
+			},
+		},
+		StructuredContent: e,
+		IsError:           true,
+	}
+}
+
+// sessionUsage is one session's recent activity
+type sessionUsage struct {
+	calls     []time.Time
+	creates   []time.Time
+	bytesRead int64
+}
+
+// sessionQuotas tracks usage of each MCP session against the configured limits
+type sessionQuotas struct {
+	mu     sync.Mutex
+	limits quotaLimits
+	now    func() time.Time
+	usage  map[*mcp.ServerSession]*sessionUsage
+}
+
+// newSessionQuotas creates a quota tracker with the given limits
+func newSessionQuotas(limits quotaLimits) *sessionQuotas {
+	return &sessionQuotas{
+		limits: limits,
+		now:    time.Now,
+		usage:  make(map[*mcp.ServerSession]*sessionUsage),
+	}
+}
+
+// sessionUsageLocked returns the usage record for a session, creating it if needed
+func (q *sessionQuotas) sessionUsageLocked(session *mcp.ServerSession) *sessionUsage {
+	usage, ok := q.usage[session]
+	if !ok {
+		usage = &sessionUsage{}
+		q.usage[session] = usage
+		forgetOnClose(session, q.forget)
+	}
+	return usage
+}
+
+// forget drops a closed session's usage record
+func (q *sessionQuotas) forget(session *mcp.ServerSession) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.usage, session)
+}
+
+// checkToolCall records a tool call, returning an error instead when it would exceed a quota
+func (q *sessionQuotas) checkToolCall(session *mcp.ServerSession, tool string) *quotaExceeded {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	usage := q.sessionUsageLocked(session)
+
+	if err := q.checkBytesLocked(usage); err != nil {
+		return err
+	}
+
+	if q.limits.CallsPerMinute > 0 {
+		usage.calls = pruneBefore(usage.calls, now.Add(-time.Minute))
+		if len(usage.calls) >= q.limits.CallsPerMinute {
+			return windowExceeded(quotaToolCallsPerMinute, q.limits.CallsPerMinute, usage.calls[0].Add(time.Minute).Sub(now))
+		}
+	}
+
+	if q.limits.NotesPerHour > 0 && noteCreatingTools[tool] {
+		usage.creates = pruneBefore(usage.creates, now.Add(-time.Hour))
+		if len(usage.creates) >= q.limits.NotesPerHour {
+			return windowExceeded(quotaNotesPerHour, q.limits.NotesPerHour, usage.creates[0].Add(time.Hour).Sub(now))
+		}
+	}
+
+	if q.limits.CallsPerMinute > 0 {
+		usage.calls = append(usage.calls, now)
+	}
+	return nil
+}
+
+// checkRead returns an error when the session has used up its byte budget
+func (q *sessionQuotas) checkRead(session *mcp.ServerSession) *quotaExceeded {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.checkBytesLocked(q.sessionUsageLocked(session))
+}
+
+// checkBytesLocked reports an exhausted byte budget
+func (q *sessionQuotas) checkBytesLocked(usage *sessionUsage) *quotaExceeded {
+	if q.limits.BytesRead > 0 && usage.bytesRead >= q.limits.BytesRead {
+		return &quotaExceeded{Error: "quota_exceeded", Quota: quotaBytesRead, Limit: q.limits.BytesRead}
+	}
+	return nil
+}
+
+// recordNoteCreated counts a successful note creation against the session
+func (q *sessionQuotas) recordNoteCreated(session *mcp.ServerSession) {
+	if q.limits.NotesPerHour == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	usage := q.sessionUsageLocked(session)
+	usage.creates = append(usage.creates, q.now())
+}
+
+// recordBytesRead adds returned content to the session's byte count
+func (q *sessionQuotas) recordBytesRead(session *mcp.ServerSession, n int64) {
+	if q.limits.BytesRead == 0 || n == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.sessionUsageLocked(session).bytesRead += n
+}
+
+// windowExceeded builds the error for a sliding-window quota
+func windowExceeded(quota string, limit int, retryAfter time.Duration) *quotaExceeded {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &quotaExceeded{Error: "quota_exceeded", Quota: quota, Limit: int64(limit), RetryAfterSeconds: seconds}
+}
+
+// pruneBefore drops timestamps older than cutoff from a sorted slice
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
+
+// resultBytes returns the size of the content a result hands back to the client
+func resultBytes(result mcp.Result) int64 {
+	var n int64
+	switch r := result.(type) {
+	case *mcp.CallToolResult:
+		for _, content := range r.Content {
+			if text, ok := content.(*mcp.TextContent); ok {
+				n += int64(len(text.Text))
+			}
+		}
+	case *mcp.ReadResourceResult:
+		for _, contents := range r.Contents {
+			if contents != nil {
+				n += int64(len(contents.Text) + len(contents.Blob))
+			}
+		}
+	}
+	return n
+}
+
+// quotaMiddleware enforces the session quotas on tool calls and resource reads
+func quotaMiddleware(quotas *sessionQuotas) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			session, ok := req.GetSession().(*mcp.ServerSession)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			var tool string
+			switch method {
+			case "tools/call":
+				if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+					tool = params.Name
+				}
+				if exceeded := quotas.checkToolCall(session, tool); exceeded != nil {
+					log.Printf("Session quota exceeded: %s", exceeded.Quota)
+					return exceeded.toolResult(), nil
+				}
+			case "resources/read":
+				if exceeded := quotas.checkRead(session); exceeded != nil {
+					log.Printf("Session quota exceeded: %s", exceeded.Quota)
+					return nil, fmt.Errorf("%s", exceeded.message())
+				}
+			default:
+				return next(ctx, method, req)
+			}
+
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+
+			quotas.recordBytesRead(session, resultBytes(result))
+			if toolResult, ok := result.(*mcp.CallToolResult); ok && !toolResult.IsError && noteCreatingTools[tool] {
+				quotas.recordNoteCreated(session)
+			}
+			return result, err
+		}
+	}
+}
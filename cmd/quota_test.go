@@ -0,0 +1,204 @@
+// ABOUTME: Tests for per-session quotas
+// ABOUTME: Drives tool calls over in-memory transports and checks each quota and its structured error
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// fakeClock is a manually advanced clock for quota windows
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newQuotaTestSession connects a client to a server enforcing the given limits
+func newQuotaTestSession(t *testing.T, limits quotaLimits) (*mcp.ClientSession, *fakeClock) {
+	t.Helper()
+
+	mock := &mockNotesService{
+		createNote: func(ctx context.Context, title, content string, tags []string) (*services.Note, error) {
+			return &services.Note{Title: title, Folder: "Notes"}, nil
+		},
+		getNoteMetadata: func(ctx context.Context, title string) (*services.Note, error) {
+			return &services.Note{Title: title, Folder: "Notes"}, nil
+		},
+		getNoteContent: func(ctx context.Context, title string) (string, error) {
+			return strings.Repeat("x", 100), nil
+		},
+	}
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)}
+	quotas := newSessionQuotas(limits)
+	quotas.now = clock.Now
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(quotaMiddleware(quotas))
+	registerCreateNoteTool(server, mock)
+	registerGetNoteContentTool(server, mock)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = clientSession.Close() })
+
+	return clientSession, clock
+}
+
+// callToolResult calls a tool and returns its result, failing on protocol errors
+func callToolResult(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("%s failed: %v", name, err)
+	}
+	return result
+}
+
+// assertQuotaExceeded checks a result is a quota error for the given quota
+func assertQuotaExceeded(t *testing.T, result *mcp.CallToolResult, quota string) {
+	t.Helper()
+
+	if !result.IsError {
+		t.Fatalf("expected a quota error for %s, got success", quota)
+	}
+	structured, ok := result.StructuredContent.(map[string]any)
+	if !ok {
+		t.Fatalf("expected structured content, got %T", result.StructuredContent)
+	}
+	if structured["error"] != "quota_exceeded" || structured["quota"] != quota {
+		t.Errorf("structured error = %v, want quota %s", structured, quota)
+	}
+}
+
+// TestQuotaCallsPerMinute tests that calls beyond the limit are refused until the window slides
+func TestQuotaCallsPerMinute(t *testing.T) {
+	session, clock := newQuotaTestSession(t, quotaLimits{CallsPerMinute: 2})
+	args := map[string]any{"title": "Note"}
+
+	for i := 0; i < 2; i++ {
+		if result := callToolResult(t, session, "get_note_content", args); result.IsError {
+			t.Fatalf("call %d should be allowed", i+1)
+		}
+	}
+
+	result := callToolResult(t, session, "get_note_content", args)
+	assertQuotaExceeded(t, result, quotaToolCallsPerMinute)
+	if retry := result.StructuredContent.(map[string]any)["retry_after_seconds"]; retry != float64(60) {
+		t.Errorf("retry_after_seconds = %v, want 60", retry)
+	}
+
+	clock.Advance(61 * time.Second)
+	if result := callToolResult(t, session, "get_note_content", args); result.IsError {
+		t.Error("expected calls to be allowed once the window has passed")
+	}
+}
+
+// TestQuotaNotesPerHour tests that only note creations count against the hourly limit
+func TestQuotaNotesPerHour(t *testing.T) {
+	session, clock := newQuotaTestSession(t, quotaLimits{NotesPerHour: 1})
+	create := map[string]any{"title": "T", "content": "C"}
+
+	if result := callToolResult(t, session, "create_note", create); result.IsError {
+		t.Fatal("first create should be allowed")
+	}
+	assertQuotaExceeded(t, callToolResult(t, session, "create_note", create), quotaNotesPerHour)
+
+	if result := callToolResult(t, session, "get_note_content", map[string]any{"title": "T"}); result.IsError {
+		t.Error("reads should not count against the notes quota")
+	}
+
+	clock.Advance(time.Hour)
+	if result := callToolResult(t, session, "create_note", create); result.IsError {
+		t.Error("expected creates to be allowed after an hour")
+	}
+}
+
+// TestQuotaBytesRead tests that the session is cut off once its byte budget is spent
+func TestQuotaBytesRead(t *testing.T) {
+	session, _ := newQuotaTestSession(t, quotaLimits{BytesRead: 50})
+	args := map[string]any{"title": "Note"}
+
+	// The read that crosses the budget still completes; the next one is refused
+	if result := callToolResult(t, session, "get_note_content", args); result.IsError {
+		t.Fatal("first read should be allowed")
+	}
+
+	result := callToolResult(t, session, "get_note_content", args)
+	assertQuotaExceeded(t, result, quotaBytesRead)
+	if _, ok := result.StructuredContent.(map[string]any)["retry_after_seconds"]; ok {
+		t.Error("the byte budget never refills, so no retry time should be given")
+	}
+}
+
+// TestQuotaForgetsClosedSessions tests that a session's usage is dropped once it disconnects
+func TestQuotaForgetsClosedSessions(t *testing.T) {
+	mock := &mockNotesService{
+		getNoteContent: func(ctx context.Context, title string) (string, error) {
+			return "body", nil
+		},
+	}
+	quotas := newSessionQuotas(quotaLimits{CallsPerMinute: 10})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(quotaMiddleware(quotas))
+	registerGetNoteContentTool(server, mock)
+
+	session := connectTestClient(t, server)
+	callToolResult(t, session, "get_note_content", map[string]any{"title": "Note"})
+	tracked := func() int {
+		quotas.mu.Lock()
+		defer quotas.mu.Unlock()
+		return len(quotas.usage)
+	}
+	if tracked() != 1 {
+		t.Fatalf("expected the session's usage to be tracked, got %d", tracked())
+	}
+
+	_ = session.Close()
+	waitUntilForgotten(t, tracked)
+}
+
+// TestQuotaLimitsFromEnv tests parsing and ignoring invalid quota values
+func TestQuotaLimitsFromEnv(t *testing.T) {
+	t.Setenv(quotaCallsPerMinuteEnvVar, "30")
+	t.Setenv(quotaNotesPerHourEnvVar, "-1")
+	t.Setenv(quotaBytesReadEnvVar, "lots")
+
+	limits := quotaLimitsFromEnv()
+	if limits != (quotaLimits{CallsPerMinute: 30}) {
+		t.Errorf("limits = %+v, want only CallsPerMinute=30", limits)
+	}
+	if !limits.enabled() || (quotaLimits{}).enabled() {
+		t.Error("enabled() should report whether any quota is set")
+	}
+}
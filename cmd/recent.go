@@ -0,0 +1,96 @@
+// ABOUTME: Recent command listing the most recently modified notes with their dates
+// ABOUTME: CLI counterpart of the notes:///recent resource, optionally limited to a folder
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// defaultRecentLimit matches the number of notes the notes:///recent resource lists
+const defaultRecentLimit = 20
+
+var (
+	recentLimit    int
+	recentFolder   string
+	recentJSON     bool
+	recentTemplate string
+)
+
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List recently modified notes",
+	Long: `Lists the most recently modified notes, newest first, with their modification dates, like the
+notes:///recent resource. --folder limits the listing to one folder, --json prints each note's
+id, title, folder, and dates as JSON, and --template prints a Go template per note, e.g.
+'{{.Modified.Format "Jan 2"}}\t{{.Title}}'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if recentLimit <= 0 {
+			return fmt.Errorf("%w: --limit must be a positive number", services.ErrInvalidInput)
+		}
+		tmpl, err := parseListTemplate(recentTemplate)
+		if err != nil {
+			return err
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		// Both calls return real dates, newest first
+		var notes []services.Note
+		if recentFolder != "" {
+			notes, err = notesService.GetRecentNotesInFolder(ctx, recentFolder, recentLimit)
+		} else {
+			notes, err = notesService.ListNotesWithMetadata(ctx, "")
+			if len(notes) > recentLimit {
+				notes = notes[:recentLimit]
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get recent notes: %w", err)
+		}
+
+		if recentJSON {
+			output, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format notes: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(output))
+			return nil
+		}
+		if tmpl != nil {
+			return printNoteTemplate(cmd.OutOrStdout(), tmpl, listTemplateNotes(notes))
+		}
+
+		printRecentNotes(cmd.OutOrStdout(), notes)
+		return nil
+	},
+}
+
+// printRecentNotes writes one "<modified>  <title>" line per note, in local time
+func printRecentNotes(w io.Writer, notes []services.Note) {
+	if len(notes) == 0 {
+		fmt.Fprintln(w, "No notes found.") //nolint:errcheck // stdout write failure is non-critical
+		return
+	}
+	for _, note := range notes {
+		fmt.Fprintf(w, "%s  %s\n", note.ModificationDate.Local().Format("2006-01-02 15:04"), note.Title) //nolint:errcheck // stdout write failure is non-critical
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(recentCmd)
+
+	recentCmd.Flags().IntVar(&recentLimit, "limit", defaultRecentLimit, "Maximum number of notes to list")
+	recentCmd.Flags().StringVar(&recentFolder, "folder", "", "Only list notes in this folder")
+	recentCmd.Flags().BoolVar(&recentJSON, "json", false, "Print the notes as JSON")
+	addListTemplateFlag(recentCmd, &recentTemplate)
+}
@@ -0,0 +1,56 @@
+// ABOUTME: Unit tests for the recent command
+// ABOUTME: Tests flag validation and the text listing
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// TestRecentCommandArgs tests argument and flag validation
+func TestRecentCommandArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "positional argument", args: []string{"recent", "extra"}},
+		{name: "zero limit", args: []string{"recent", "--limit", "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd.SetArgs(tt.args)
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+			recentLimit = defaultRecentLimit
+		})
+	}
+}
+
+// TestPrintRecentNotes tests the dated text listing
+func TestPrintRecentNotes(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local)
+	var out bytes.Buffer
+	printRecentNotes(&out, []services.Note{{Title: "Plan", ModificationDate: modified}})
+	if out.String() != "2024-03-01 09:30  Plan\n" {
+		t.Errorf("unexpected listing %q", out.String())
+	}
+
+	out.Reset()
+	printRecentNotes(&out, nil)
+	if out.String() != "No notes found.\n" {
+		t.Errorf("unexpected empty listing %q", out.String())
+	}
+}
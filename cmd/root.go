@@ -13,6 +13,15 @@ var rootCmd = &cobra.Command{
 	Long:  `A Model Context Protocol server that provides intelligent notes management capabilities.`,
 }
 
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Only print results and errors, not confirmation messages")
+	rootCmd.PersistentFlags().BoolVarP(&verboseOutput, "verbose", "v", false, "Print AppleScript timings, retries, and fallbacks to stderr")
+	rootCmd.PersistentFlags().BoolVar(&strictTitles, "strict", false, "Fail instead of picking one note when several share the title given")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+
+	rootCmd.PersistentPreRunE = resolveTitleArgs
+}
+
 // Execute runs the root command
 func Execute() error {
 	return rootCmd.Execute()
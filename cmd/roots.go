@@ -0,0 +1,207 @@
+// ABOUTME: Session root folder scoping for the MCP server
+// ABOUTME: Maps MCP roots (notes:///folder/{folder}) and set_root_folder calls to an implicit folder scope
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// rootFolderURIPrefix is the root URI form clients use to scope a session to a folder
+const rootFolderURIPrefix = "notes:///folder/"
+
+// rootsListTimeout bounds how long the server waits for the client to answer roots/list
+const rootsListTimeout = 5 * time.Second
+
+// rootFolderKey is the context key for the session's root folder
+type rootFolderKey struct{}
+
+// withRootFolder returns a context carrying the session's root folder
+func withRootFolder(ctx context.Context, folder string) context.Context {
+	return context.WithValue(ctx, rootFolderKey{}, folder)
+}
+
+// rootFolderFromContext returns the session's root folder, or "" when the session is unscoped
+func rootFolderFromContext(ctx context.Context) string {
+	folder, _ := ctx.Value(rootFolderKey{}).(string)
+	return folder
+}
+
+// scopedFolder returns the folder an operation should be limited to
+// An explicit folder wins; otherwise the session root applies unless allFolders is set
+func scopedFolder(ctx context.Context, explicit string, allFolders bool) string {
+	if explicit != "" || allFolders {
+		return explicit
+	}
+	return rootFolderFromContext(ctx)
+}
+
+// searchTitlesInScope runs a title search, limited to folder when one is given
+func searchTitlesInScope(ctx context.Context, notesService services.NotesService, query, folder string) ([]services.Note, error) {
+	if folder == "" {
+		return notesService.SearchNotes(ctx, query)
+	}
+	return notesService.SearchNotesAdvanced(ctx, services.SearchOptions{
+		Query:    query,
+		SearchIn: services.SearchInTitle,
+		Folder:   folder,
+	})
+}
+
+// sessionRoots tracks the root folder of each MCP session
+// A folder set with set_root_folder takes precedence over one derived from client roots
+type sessionRoots struct {
+	mu        sync.Mutex
+	fromRoots map[*mcp.ServerSession]string
+	overrides map[*mcp.ServerSession]string
+}
+
+// newSessionRoots creates an empty root folder store
+func newSessionRoots() *sessionRoots {
+	return &sessionRoots{
+		fromRoots: make(map[*mcp.ServerSession]string),
+		overrides: make(map[*mcp.ServerSession]string),
+	}
+}
+
+// folder returns the effective root folder for a session
+func (r *sessionRoots) folder(session *mcp.ServerSession) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if folder, ok := r.overrides[session]; ok {
+		return folder
+	}
+	return r.fromRoots[session]
+}
+
+// setOverride sets (or, with an empty folder, clears) the root folder chosen by set_root_folder
+func (r *sessionRoots) setOverride(session *mcp.ServerSession, folder string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if folder == "" {
+		delete(r.overrides, session)
+		return
+	}
+	r.trackLocked(session)
+	r.overrides[session] = folder
+}
+
+// refresh asks the client for its roots and records the first notes folder root
+// Clients without roots support answer with an error and the session stays unscoped
+func (r *sessionRoots) refresh(ctx context.Context, session *mcp.ServerSession) {
+	ctx, cancel := context.WithTimeout(ctx, rootsListTimeout)
+	defer cancel()
+
+	result, err := session.ListRoots(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	folder := rootFolderFromRoots(result.Roots)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trackLocked(session)
+	r.fromRoots[session] = folder
+}
+
+// trackLocked arranges for a session's folders to be forgotten when it closes, if none are recorded yet
+func (r *sessionRoots) trackLocked(session *mcp.ServerSession) {
+	_, fromRoots := r.fromRoots[session]
+	_, override := r.overrides[session]
+	if !fromRoots && !override {
+		forgetOnClose(session, r.forget)
+	}
+}
+
+// forget drops a closed session's folders
+func (r *sessionRoots) forget(session *mcp.ServerSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.fromRoots, session)
+	delete(r.overrides, session)
+}
+
+// rootFolderFromRoots returns the folder named by the first notes:///folder/{folder} root
+// Other roots (e.g. file:// workspace roots) are ignored
+func rootFolderFromRoots(roots []*mcp.Root) string {
+	for _, root := range roots {
+		if root == nil || !strings.HasPrefix(root.URI, rootFolderURIPrefix) {
+			continue
+		}
+
+		folder, err := url.PathUnescape(strings.TrimPrefix(root.URI, rootFolderURIPrefix))
+		if err != nil || folder == "" {
+			continue
+		}
+		return folder
+	}
+	return ""
+}
+
+// rootsServerOptions wires root list notifications from the client into the store
+func rootsServerOptions(roots *sessionRoots) *mcp.ServerOptions {
+	return &mcp.ServerOptions{
+		InitializedHandler: func(ctx context.Context, req *mcp.InitializedRequest) {
+			roots.refresh(ctx, req.Session)
+		},
+		RootsListChangedHandler: func(ctx context.Context, req *mcp.RootsListChangedRequest) {
+			roots.refresh(ctx, req.Session)
+		},
+	}
+}
+
+// rootFolderMiddleware puts the session's root folder into the context of every request
+func rootFolderMiddleware(roots *sessionRoots) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if session, ok := req.GetSession().(*mcp.ServerSession); ok {
+				if folder := roots.folder(session); folder != "" {
+					ctx = withRootFolder(ctx, folder)
+				}
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// SetRootFolderArgs are the arguments for the set_root_folder tool
+type SetRootFolderArgs struct {
+	Folder string `json:"folder" jsonschema:"Folder to scope this session to; empty clears the scope"`
+}
+
+// registerSetRootFolderTool registers the set_root_folder tool
+func registerSetRootFolderTool(server *mcp.Server, roots *sessionRoots) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SetRootFolderArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		folder := strings.TrimSpace(input.Folder)
+		roots.setOverride(req.Session, folder)
+
+		message := "Root folder cleared. Searches, listings, and new notes now cover all folders."
+		if folder != "" {
+			message = fmt.Sprintf("Root folder set to '%s'. Searches, listings, and new notes are scoped to it unless a tool call names another folder or sets all_folders.", folder)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: message,
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_root_folder",
+		Description: "Scopes this session to a folder (e.g. 'Work'): search_notes, search_notes_advanced, create_note, and the recent/search resources then use it implicitly. Overrides any notes:///folder/{folder} root sent by the client. Pass an empty folder to clear.",
+	}, handler)
+}
@@ -0,0 +1,207 @@
+// ABOUTME: Tests for session root folder scoping
+// ABOUTME: Drives a client with roots over in-memory transports and checks tools are scoped
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// scopeRecorder captures the folders that scoped tools passed to the service
+type scopeRecorder struct {
+	mu          sync.Mutex
+	searchOpts  []services.SearchOptions
+	plainSearch int
+	moves       []string
+}
+
+// newScopedTestSession connects a client advertising roots to a server registered like runMCPServer
+func newScopedTestSession(t *testing.T, roots ...*mcp.Root) (*mcp.ClientSession, *scopeRecorder) {
+	t.Helper()
+
+	recorder := &scopeRecorder{}
+	mock := &mockNotesService{
+		searchNotes: func(ctx context.Context, query string) ([]services.Note, error) {
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			recorder.plainSearch++
+			return []services.Note{}, nil
+		},
+		searchNotesAdvanced: func(ctx context.Context, opts services.SearchOptions) ([]services.Note, error) {
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			recorder.searchOpts = append(recorder.searchOpts, opts)
+			return []services.Note{}, nil
+		},
+		createNote: func(ctx context.Context, title, content string, tags []string) (*services.Note, error) {
+			return &services.Note{Title: title, Folder: "Notes"}, nil
+		},
+		moveNote: func(ctx context.Context, noteTitle, targetFolder string) error {
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			recorder.moves = append(recorder.moves, targetFolder)
+			return nil
+		},
+	}
+
+	sessionRoots := newSessionRoots()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, rootsServerOptions(sessionRoots))
+	server.AddReceivingMiddleware(rootFolderMiddleware(sessionRoots))
+	registerCreateNoteTool(server, mock)
+	registerSearchNotesTool(server, mock)
+	registerSearchNotesAdvancedTool(server, mock)
+	registerSetRootFolderTool(server, sessionRoots)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil)
+	client.AddRoots(roots...)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = clientSession.Close() })
+
+	return clientSession, recorder
+}
+
+// callTool calls a tool and fails the test on protocol errors
+func callTool(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) {
+	t.Helper()
+	if _, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args}); err != nil {
+		t.Fatalf("%s failed: %v", name, err)
+	}
+}
+
+// TestRootFolderFromClientRoots tests that a notes folder root scopes searches and creations
+func TestRootFolderFromClientRoots(t *testing.T) {
+	session, recorder := newScopedTestSession(t,
+		&mcp.Root{URI: "file:///Users/me/project"},
+		&mcp.Root{URI: "notes:///folder/Project%20X"},
+	)
+
+	callTool(t, session, "search_notes", map[string]any{"query": "plan"})
+	callTool(t, session, "search_notes_advanced", map[string]any{"query": "plan"})
+	callTool(t, session, "create_note", map[string]any{"title": "Kickoff", "content": "Agenda"})
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	if recorder.plainSearch != 0 {
+		t.Errorf("expected scoped searches only, got %d unscoped", recorder.plainSearch)
+	}
+	if len(recorder.searchOpts) != 2 {
+		t.Fatalf("expected 2 scoped searches, got %d", len(recorder.searchOpts))
+	}
+	for _, opts := range recorder.searchOpts {
+		if opts.Folder != "Project X" {
+			t.Errorf("search folder = %q, want %q", opts.Folder, "Project X")
+		}
+	}
+	if len(recorder.moves) != 1 || recorder.moves[0] != "Project X" {
+		t.Errorf("expected the new note to move to Project X, got %v", recorder.moves)
+	}
+}
+
+// TestRootFolderOverrides tests set_root_folder, explicit folders, and all_folders
+func TestRootFolderOverrides(t *testing.T) {
+	session, recorder := newScopedTestSession(t, &mcp.Root{URI: "notes:///folder/Work"})
+
+	callTool(t, session, "set_root_folder", map[string]any{"folder": "Personal"})
+	callTool(t, session, "search_notes_advanced", map[string]any{"query": "a"})
+	callTool(t, session, "search_notes_advanced", map[string]any{"query": "b", "folder": "Archive"})
+	callTool(t, session, "search_notes", map[string]any{"query": "c", "all_folders": true})
+	callTool(t, session, "create_note", map[string]any{"title": "T", "content": "C", "folder": "Inbox"})
+
+	callTool(t, session, "set_root_folder", map[string]any{"folder": ""})
+	callTool(t, session, "search_notes_advanced", map[string]any{"query": "d"})
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	wantFolders := []string{"Personal", "Archive", "Work"}
+	if len(recorder.searchOpts) != len(wantFolders) {
+		t.Fatalf("expected %d advanced searches, got %d", len(wantFolders), len(recorder.searchOpts))
+	}
+	for i, want := range wantFolders {
+		if recorder.searchOpts[i].Folder != want {
+			t.Errorf("search %d folder = %q, want %q", i, recorder.searchOpts[i].Folder, want)
+		}
+	}
+	if recorder.plainSearch != 1 {
+		t.Errorf("expected all_folders to bypass the scope, got %d unscoped searches", recorder.plainSearch)
+	}
+	if len(recorder.moves) != 1 || recorder.moves[0] != "Inbox" {
+		t.Errorf("expected explicit folder to win, got %v", recorder.moves)
+	}
+}
+
+// TestRootFoldersForgottenOnClose tests that a session's root folders are dropped once it disconnects
+func TestRootFoldersForgottenOnClose(t *testing.T) {
+	roots := newSessionRoots()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, rootsServerOptions(roots))
+	registerSetRootFolderTool(server, roots)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil)
+	client.AddRoots(&mcp.Root{URI: "notes:///folder/Work"})
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+	callTool(t, session, "set_root_folder", map[string]any{"folder": "Personal"})
+
+	tracked := func() int {
+		roots.mu.Lock()
+		defer roots.mu.Unlock()
+		return len(roots.fromRoots) + len(roots.overrides)
+	}
+	deadline := time.Now().Add(time.Second)
+	for tracked() != 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond) // roots are fetched once the client has initialized
+	}
+	if tracked() != 2 {
+		t.Fatalf("expected the client root and the override to be tracked, got %d", tracked())
+	}
+
+	_ = session.Close()
+	waitUntilForgotten(t, tracked)
+}
+
+// TestRootFolderFromRoots tests picking the folder out of a client's roots
+func TestRootFolderFromRoots(t *testing.T) {
+	tests := []struct {
+		name  string
+		roots []*mcp.Root
+		want  string
+	}{
+		{name: "no roots", roots: nil, want: ""},
+		{name: "file roots only", roots: []*mcp.Root{{URI: "file:///tmp"}}, want: ""},
+		{name: "encoded folder", roots: []*mcp.Root{{URI: "notes:///folder/My%20Work"}}, want: "My Work"},
+		{name: "first folder wins", roots: []*mcp.Root{{URI: "notes:///folder/A"}, {URI: "notes:///folder/B"}}, want: "A"},
+		{name: "empty folder skipped", roots: []*mcp.Root{{URI: "notes:///folder/"}, {URI: "notes:///folder/B"}}, want: "B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rootFolderFromRoots(tt.roots); got != tt.want {
+				t.Errorf("rootFolderFromRoots() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
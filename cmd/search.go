@@ -9,13 +9,27 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	searchFormat   string
+	searchTemplate string
+)
+
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search for notes in Apple Notes",
-	Long:  `Searches for notes in Apple Notes by title. Returns a newline-separated list of matching note titles.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Searches for notes in Apple Notes by title. Returns a newline-separated list of matching note titles.
+--format=csv prints id, title, folder, created, modified, shared, and locked columns instead, and
+--template prints a Go template per note, e.g. '{{.Title}}\t{{.Folder}}\t{{.Modified}}'.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
+		if err := validateListFormat(searchFormat); err != nil {
+			return err
+		}
+		tmpl, err := parseListTemplate(searchTemplate)
+		if err != nil {
+			return err
+		}
 
 		// Create service with real executor
 		notesService := newNotesService()
@@ -36,9 +50,14 @@ var searchCmd = &cobra.Command{
 			notes = notes[:maxSearchResults]
 		}
 
-		// Output newline-separated list of titles
-		for _, note := range notes {
-			fmt.Println(note.Title)
+		// Output newline-separated list of titles, CSV, or the template with each note's metadata
+		if tmpl != nil {
+			err = printNoteTemplate(cmd.OutOrStdout(), tmpl, listTemplateNotes(withNoteMetadata(ctx, notesService, notes)))
+		} else {
+			err = printNoteList(cmd.OutOrStdout(), notes, searchFormat)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write notes: %w", err)
 		}
 
 		// Add indicator if results were limited
@@ -53,4 +72,7 @@ var searchCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringVar(&searchFormat, "format", listFormatText, "Output format: text or csv")
+	addListTemplateFlag(searchCmd, &searchTemplate)
 }
@@ -1,5 +1,5 @@
 // ABOUTME: Advanced search command for finding notes with filters in Apple Notes
-// ABOUTME: Supports searching in title/body/both, folder filtering, and date range filtering
+// ABOUTME: Supports searching in title/body/both, folder, date range, and note property filters
 
 package cmd
 
@@ -12,53 +12,104 @@ import (
 )
 
 var (
-	searchIn     string
-	searchFolder string
-	dateFrom     string
-	dateTo       string
+	searchIn               string
+	searchFolder           string
+	dateFrom               string
+	dateTo                 string
+	searchTimezone         string
+	searchBackend          string
+	matchHTML              bool
+	searchAdvancedFormat   string
+	searchAdvancedTemplate string
+	searchExplain          bool
+
+	filterHasAttachments bool
+	filterHasChecklist   bool
+	filterShared         bool
+	filterLocked         bool
+	excludeFolders       []string
 )
 
 var searchAdvancedCmd = &cobra.Command{
 	Use:   "search-advanced <query>",
 	Short: "Advanced search for notes with filters",
-	Long:  `Searches for notes in Apple Notes with advanced filtering options including search location (title/body/both), folder, and date range.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Searches for notes in Apple Notes with advanced filtering options including search location (title/body/both), folder, and date range.
+--format=csv prints each note's metadata as CSV, and --template prints a Go template per note.
+--explain reports how the search ran instead: the strategy, the generated AppleScript or Spotlight query,
+how many notes were candidates, the matches, and how long each step took.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
+		if err := validateListFormat(searchAdvancedFormat); err != nil {
+			return err
+		}
+		tmpl, err := parseListTemplate(searchAdvancedTemplate)
+		if err != nil {
+			return err
+		}
 
-		// Parse date flags if provided
+		// Parse date flags if provided, as whole days in the chosen zone
+		loc, err := resolveTimezone(searchTimezone)
+		if err != nil {
+			return err
+		}
 		var dateFromPtr, dateToPtr *time.Time
 		if dateFrom != "" {
-			t, err := time.Parse("2006-01-02", dateFrom)
+			t, err := time.ParseInLocation("2006-01-02", dateFrom, loc)
 			if err != nil {
 				return fmt.Errorf("invalid date-from format (use YYYY-MM-DD): %w", err)
 			}
 			dateFromPtr = &t
 		}
 		if dateTo != "" {
-			t, err := time.Parse("2006-01-02", dateTo)
+			t, err := time.ParseInLocation("2006-01-02", dateTo, loc)
 			if err != nil {
 				return fmt.Errorf("invalid date-to format (use YYYY-MM-DD): %w", err)
 			}
+			t = endOfDay(t)
 			dateToPtr = &t
 		}
 
 		// Build search options
 		opts := services.SearchOptions{
-			Query:    query,
-			SearchIn: searchIn,
-			Folder:   searchFolder,
-			DateFrom: dateFromPtr,
-			DateTo:   dateToPtr,
+			Query:     query,
+			SearchIn:  searchIn,
+			Folder:    searchFolder,
+			DateFrom:  dateFromPtr,
+			DateTo:    dateToPtr,
+			Backend:   defaultSearchBackend(searchBackend),
+			MatchHTML: matchHTML,
+
+			HasAttachments: filterHasAttachments,
+			HasChecklist:   filterHasChecklist,
+			Shared:         filterShared,
+			Locked:         filterLocked,
+			ExcludeFolders: excludeFolders,
 		}
 
-		// Create service with real executor
-		notesService := newNotesService()
+		// Create service with real executor; the index backend reads through the configured provider
+		var notesService services.NotesService = newNotesService()
+		if opts.Backend == services.SearchBackendIndex {
+			if searchExplain {
+				return fmt.Errorf("%w: --explain doesn't apply to the %s backend", services.ErrInvalidInput, services.SearchBackendIndex)
+			}
+			if _, notesService, err = newProviderNotesService(); err != nil {
+				return err
+			}
+		}
 
 		// Create context with timeout
 		ctx, cancel := newCommandContext()
 		defer cancel()
 
+		if searchExplain {
+			explain, err := explainSearch(ctx, notesService, opts)
+			if err != nil {
+				return err
+			}
+			return printSearchExplanation(cmd.OutOrStdout(), explain)
+		}
+
 		// Search for notes
 		notes, err := notesService.SearchNotesAdvanced(ctx, opts)
 		if err != nil {
@@ -71,9 +122,17 @@ var searchAdvancedCmd = &cobra.Command{
 			notes = notes[:maxSearchResults]
 		}
 
-		// Output newline-separated list of titles
-		for _, note := range notes {
-			fmt.Println(note.Title)
+		// Output newline-separated list of titles, or CSV or the template with each note's metadata
+		if searchAdvancedFormat == listFormatCSV || tmpl != nil {
+			notes = withNoteMetadata(ctx, notesService, notes)
+		}
+		if tmpl != nil {
+			err = printNoteTemplate(cmd.OutOrStdout(), tmpl, listTemplateNotes(notes))
+		} else {
+			err = printNoteList(cmd.OutOrStdout(), notes, searchAdvancedFormat)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write notes: %w", err)
 		}
 
 		// Add indicator if results were limited
@@ -92,6 +151,18 @@ func init() {
 	// Add flags
 	searchAdvancedCmd.Flags().StringVar(&searchIn, "search-in", "title", "Where to search: title, body, or both")
 	searchAdvancedCmd.Flags().StringVar(&searchFolder, "folder", "", "Limit search to specific folder")
-	searchAdvancedCmd.Flags().StringVar(&dateFrom, "date-from", "", "Filter by creation date from (YYYY-MM-DD)")
-	searchAdvancedCmd.Flags().StringVar(&dateTo, "date-to", "", "Filter by creation date to (YYYY-MM-DD)")
+	searchAdvancedCmd.Flags().StringVar(&dateFrom, "date-from", "", "Filter by modification date from the start of this day (YYYY-MM-DD)")
+	searchAdvancedCmd.Flags().StringVar(&dateTo, "date-to", "", "Filter by modification date through the end of this day (YYYY-MM-DD)")
+	searchAdvancedCmd.Flags().StringVar(&searchTimezone, "timezone", "", "IANA time zone the dates are days in (default: $NOTES_MCP_TIMEZONE or the system zone)")
+	searchAdvancedCmd.Flags().StringVar(&searchBackend, "backend", "", "Search backend: applescript, spotlight, or index (default: $NOTES_MCP_SEARCH_BACKEND or applescript)")
+	searchAdvancedCmd.Flags().StringVar(&searchAdvancedFormat, "format", listFormatText, "Output format: text or csv")
+	addListTemplateFlag(searchAdvancedCmd, &searchAdvancedTemplate)
+	searchAdvancedCmd.Flags().BoolVar(&searchExplain, "explain", false, "Report the strategy, script, candidate count, and timings instead of the results")
+	searchAdvancedCmd.Flags().BoolVar(&matchHTML, "match-html", false, "Match body queries against the raw HTML instead of the plain text")
+	searchAdvancedCmd.Flags().BoolVar(&filterHasAttachments, "has-attachments", false, "Only match notes with attachments")
+	searchAdvancedCmd.Flags().BoolVar(&filterHasChecklist, "has-checklist", false, "Only match notes containing a checklist")
+	searchAdvancedCmd.Flags().BoolVar(&filterShared, "shared", false, "Only match shared notes")
+	searchAdvancedCmd.Flags().BoolVar(&filterLocked, "locked", false, "Only match password-protected notes")
+	searchAdvancedCmd.Flags().StringArrayVar(&excludeFolders, "exclude-folder", nil, "Skip notes in this folder (repeatable)")
+	searchAdvancedCmd.MarkFlagsMutuallyExclusive("explain", "template")
 }
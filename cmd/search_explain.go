@@ -0,0 +1,63 @@
+// ABOUTME: Explain output for advanced searches, shared by search-advanced --explain and the search_notes_advanced tool
+// ABOUTME: Prints the strategy, generated script or Spotlight query, candidate and result counts, and timings
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// explainSearch runs an advanced search through the provider's explain mode
+func explainSearch(ctx context.Context, notesService services.NotesService, opts services.SearchOptions) (*services.SearchExplanation, error) {
+	explainer, ok := services.UndecoratedNotesService(notesService).(services.SearchExplainer)
+	if !ok {
+		return nil, fmt.Errorf("%w: this notes provider can't explain searches", services.ErrNotSupported)
+	}
+
+	explain, _, err := explainer.ExplainSearch(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain search: %w", err)
+	}
+	return explain, nil
+}
+
+// printSearchExplanation writes an explanation for reading in a terminal
+func printSearchExplanation(w io.Writer, explain *services.SearchExplanation) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Strategy:   %s\n", explain.Strategy)
+	fmt.Fprintf(&b, "Backend:    %s\n", explain.Backend)
+	if explain.Fallback != "" {
+		fmt.Fprintf(&b, "Fallback:   %s\n", explain.Fallback)
+	}
+	fmt.Fprintf(&b, "Search in:  %s\n", explain.SearchIn)
+	fmt.Fprintf(&b, "Candidates: %d notes in the folder and date range\n", explain.Candidates)
+	fmt.Fprintf(&b, "Results:    %d\n", explain.Results)
+
+	timings := []string{fmt.Sprintf("count %.1fms", explain.Timings.CountMS)}
+	if explain.SpotlightQuery != "" {
+		timings = append(timings, fmt.Sprintf("spotlight %.1fms", explain.Timings.SpotlightMS))
+	}
+	if explain.Script != "" {
+		timings = append(timings, fmt.Sprintf("script %.1fms", explain.Timings.ScriptMS))
+	}
+	timings = append(timings, fmt.Sprintf("total %.1fms", explain.Timings.TotalMS))
+	fmt.Fprintf(&b, "Timings:    %s\n", strings.Join(timings, ", "))
+
+	if explain.SpotlightQuery != "" {
+		fmt.Fprintf(&b, "\nSpotlight query:\n  %s\n", explain.SpotlightQuery)
+	}
+	if explain.Script != "" {
+		fmt.Fprintf(&b, "\nAppleScript:\n%s\n", strings.Trim(explain.Script, "\n"))
+	}
+	if len(explain.Matches) > 0 {
+		fmt.Fprintf(&b, "\nMatches:\n  %s\n", strings.Join(explain.Matches, "\n  "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,84 @@
+// ABOUTME: Unit tests for explaining advanced searches from the CLI and the search_notes_advanced tool
+// ABOUTME: Uses a memory service with a canned explanation, and checks providers without explain support
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// explainingNotesService is a memory service whose searches explain themselves with a fixed explanation
+type explainingNotesService struct {
+	*services.MemoryNotesService
+	opts services.SearchOptions
+}
+
+func (e *explainingNotesService) ExplainSearch(ctx context.Context, opts services.SearchOptions) (*services.SearchExplanation, []services.Note, error) {
+	e.opts = opts
+	return &services.SearchExplanation{
+		Strategy:   services.SearchStrategyBodyScan,
+		Backend:    services.SearchBackendAppleScript,
+		SearchIn:   opts.SearchIn,
+		Script:     "\ntell application \"Notes\"\nend tell\n",
+		Candidates: 120,
+		Results:    1,
+		Matches:    []string{"Roadmap"},
+		Timings:    services.SearchTimings{CountMS: 4.2, ScriptMS: 812.5, TotalMS: 816.7},
+	}, []services.Note{{Title: "Roadmap"}}, nil
+}
+
+// TestPrintSearchExplanation tests the terminal layout of an explanation
+func TestPrintSearchExplanation(t *testing.T) {
+	explain, _, _ := (&explainingNotesService{}).ExplainSearch(context.Background(), services.SearchOptions{SearchIn: "body"})
+
+	var out bytes.Buffer
+	if err := printSearchExplanation(&out, explain); err != nil {
+		t.Fatalf("printSearchExplanation failed: %v", err)
+	}
+	for _, want := range []string{
+		"Strategy:   body-scan\n",
+		"Candidates: 120 notes",
+		"Timings:    count 4.2ms, script 812.5ms, total 816.7ms\n",
+		"AppleScript:\ntell application \"Notes\"\nend tell\n",
+		"Matches:\n  Roadmap\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Spotlight") {
+		t.Errorf("expected no Spotlight section without a Spotlight query:\n%s", out.String())
+	}
+}
+
+// TestSearchNotesAdvancedExplain tests the tool's explain option and providers that can't explain
+func TestSearchNotesAdvancedExplain(t *testing.T) {
+	notesService := &explainingNotesService{MemoryNotesService: services.NewMemoryNotesService()}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerSearchNotesAdvancedTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "search_notes_advanced", map[string]any{"query": "roadmap", "search_in": "body", "explain": true})
+	if result.IsError {
+		t.Fatalf("search_notes_advanced failed: %s", firstText(result))
+	}
+	var explain services.SearchExplanation
+	if err := json.Unmarshal([]byte(firstText(result)), &explain); err != nil {
+		t.Fatalf("expected an explanation as JSON: %v", err)
+	}
+	if explain.Candidates != 120 || explain.Strategy != services.SearchStrategyBodyScan || notesService.opts.Query != "roadmap" {
+		t.Errorf("unexpected explanation %+v for options %+v", explain, notesService.opts)
+	}
+
+	if _, err := explainSearch(context.Background(), services.NewMemoryNotesService(), services.SearchOptions{Query: "x"}); !errors.Is(err, services.ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported from a provider without explain, got %v", err)
+	}
+}
@@ -4,8 +4,13 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"io"
 	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
 )
 
 // TestSearchCommandArgs tests that the search command requires exactly 1 argument
@@ -25,6 +30,11 @@ func TestSearchCommandArgs(t *testing.T) {
 			args:        []string{"search", "query", "extra"},
 			expectError: true,
 		},
+		{
+			name:        "unknown format",
+			args:        []string{"search", "query", "--format", "xlsx"},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -47,6 +57,70 @@ func TestSearchCommandArgs(t *testing.T) {
 
 			// Reset for next test
 			rootCmd.SetArgs([]string{})
+			searchFormat = listFormatText
 		})
 	}
 }
+
+// TestPrintNoteList tests text and CSV note list output
+func TestPrintNoteList(t *testing.T) {
+	notes := []services.Note{{ID: "id1", Title: "Budget", Folder: "Work", Shared: true}}
+
+	var text bytes.Buffer
+	if err := printNoteList(&text, notes, listFormatText); err != nil {
+		t.Fatalf("printNoteList failed: %v", err)
+	}
+	if text.String() != "Budget\n" {
+		t.Errorf("text output = %q", text.String())
+	}
+
+	var csv bytes.Buffer
+	if err := printNoteList(&csv, notes, listFormatCSV); err != nil {
+		t.Fatalf("printNoteList failed: %v", err)
+	}
+	if want := "id,title,folder,created,modified,shared,locked\nid1,Budget,Work,,,true,false\n"; csv.String() != want {
+		t.Errorf("csv output = %q, want %q", csv.String(), want)
+	}
+}
+
+// TestPrintNoteTemplate tests --template output, shell escapes, date formatting, and list-folder paths
+func TestPrintNoteTemplate(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 12, 30, 0, 0, time.Local)
+	notes := []services.Note{
+		{ID: "id1", Title: "Budget", Folder: "Work", ModificationDate: modified},
+		{ID: "id2", Title: "Ideas", Folder: "Notes"},
+	}
+
+	tmpl, err := parseListTemplate(`{{.Title}}\t{{.Folder}}\t{{.Modified}}`)
+	if err != nil {
+		t.Fatalf("parseListTemplate failed: %v", err)
+	}
+	var out bytes.Buffer
+	if err := printNoteTemplate(&out, tmpl, listTemplateNotes(notes)); err != nil {
+		t.Fatalf("printNoteTemplate failed: %v", err)
+	}
+	if want := "Budget\tWork\t2024-05-01 12:30\nIdeas\tNotes\t\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+
+	tmpl, err = parseListTemplate(`{{.Path}} {{.Modified.Format "Jan 2"}}\n`)
+	if err != nil {
+		t.Fatalf("parseListTemplate failed: %v", err)
+	}
+	out.Reset()
+	if err := printNoteTemplate(&out, tmpl, []listTemplateNote{newListTemplateNote(notes[0], "Q2/Budget")}); err != nil {
+		t.Fatalf("printNoteTemplate failed: %v", err)
+	}
+	if out.String() != "Q2/Budget May 1\n" {
+		t.Errorf("output = %q", out.String())
+	}
+
+	if tmpl, err := parseListTemplate(""); tmpl != nil || err != nil {
+		t.Errorf("an empty template should mean none, got %v, %v", tmpl, err)
+	}
+	for _, text := range []string{"{{.Title", "{{.Modifed}}"} {
+		if _, err := parseListTemplate(text); !errors.Is(err, services.ErrInvalidInput) {
+			t.Errorf("%q: expected ErrInvalidInput, got %v", text, err)
+		}
+	}
+}
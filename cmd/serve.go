@@ -0,0 +1,202 @@
+// ABOUTME: Serve command that runs notes-mcp as a long-lived server
+// ABOUTME: Serves MCP over stdio by default, MCP over HTTP with --http, or the notes.v1 gRPC API with --grpc
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveGRPC bool
+	serveHTTP bool
+	serveAddr string
+)
+
+// Default listen addresses; both only accept local connections
+const (
+	defaultGRPCAddr = "127.0.0.1:50051"
+	defaultHTTPAddr = "127.0.0.1:8765"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the MCP server, or the gRPC server with --grpc",
+	Long: `Without flags, serve behaves like "notes-mcp mcp" and speaks MCP over stdio.
+With --http, it serves MCP over streamable HTTP on --addr (default ` + defaultHTTPAddr + `). Every request must
+carry "Authorization: Bearer <token>" with a token from "notes-mcp token create".
+With --grpc, it serves the notes.v1.NotesService API defined in proto/notes/v1/notes.proto on --addr
+(default ` + defaultGRPCAddr + `), so editors and launchers can integrate without MCP. Calls need the same bearer
+token as "authorization" metadata, and NOTES_MCP_PROVIDER and NOTES_MCP_READ_ONLY apply. The default addresses only
+accept local connections; use unix:///path/to/socket to listen on a Unix domain socket instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if serveGRPC && serveHTTP {
+			return fmt.Errorf("--grpc and --http cannot be used together")
+		}
+		if serveHTTP {
+			return runHTTPServer(cmd)
+		}
+		if !serveGRPC {
+			runMCPServer(cmd, args)
+			return nil
+		}
+
+		return runGRPCServer(cmd)
+	},
+}
+
+// runGRPCServer serves the notes.v1 gRPC API until interrupted
+// Like the HTTP transport it requires API tokens, and it builds the notes service the way the MCP server does.
+func runGRPCServer(cmd *cobra.Command) error {
+	tokens, err := loadAPITokens()
+	if err != nil {
+		return err
+	}
+	notesService, err := newGRPCNotesService()
+	if err != nil {
+		return err
+	}
+	attachmentRoot, err := services.NotesContainerDir()
+	if err != nil {
+		return err
+	}
+
+	addr := serveAddr
+	if addr == "" {
+		addr = defaultGRPCAddr
+	}
+	listener, err := listenGRPC(addr)
+	if err != nil {
+		return err
+	}
+
+	server := newGRPCServer(notesService, tokens, attachmentRoot)
+
+	// Stop gracefully on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	log.Printf("Serving notes.v1.NotesService over gRPC on %s", listener.Addr())
+	if err := server.Serve(listener); err != nil {
+		return fmt.Errorf("gRPC server failed: %w", err)
+	}
+	return nil
+}
+
+// newGRPCNotesService creates the gRPC server's notes service from the configured provider and middleware
+// Write RPCs are refused when NOTES_MCP_READ_ONLY is set or the provider is read-only.
+func newGRPCNotesService() (services.NotesService, error) {
+	provider, notesService, err := newProviderNotesService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notes service: %w", err)
+	}
+	if provider.Capabilities.ReadOnly && !readOnlyEnabled() {
+		notesService = services.DecorateNotesService(notesService, services.ReadOnlyMiddleware())
+	}
+	return notesService, nil
+}
+
+// loadAPITokens returns the Keychain token store for the network transports
+// It refuses to start a server without any API tokens, since every request would be rejected.
+func loadAPITokens() (*services.TokenStore, error) {
+	tokens := services.NewTokenStore(newSecretStore())
+
+	ctx, cancel := newCommandContext()
+	existing, err := tokens.List(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return nil, fmt.Errorf("no API tokens exist; create one with: notes-mcp token create <name>")
+	}
+	return tokens, nil
+}
+
+// runHTTPServer serves MCP over streamable HTTP until interrupted
+func runHTTPServer(cmd *cobra.Command) error {
+	tokens, err := loadAPITokens()
+	if err != nil {
+		return err
+	}
+	// Remote clients may only read attachment files, as over gRPC
+	fileRoot, err := services.NotesContainerDir()
+	if err != nil {
+		return err
+	}
+
+	addr := serveAddr
+	if addr == "" {
+		addr = defaultHTTPAddr
+	}
+	listener, err := listenGRPC(addr)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           newMCPHTTPHandler(newMCPServer(fileRoot), tokens),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Stop gracefully on interrupt
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-sigCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx) //nolint:errcheck,gosec // best-effort shutdown
+	}()
+
+	log.Printf("Serving MCP over HTTP on %s", listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("HTTP server failed: %w", err)
+	}
+	return nil
+}
+
+// listenGRPC opens a TCP listener, or a Unix socket listener for "unix://" addresses
+// It is shared by the gRPC and HTTP servers.
+func listenGRPC(addr string) (net.Listener, error) {
+	network, address := "tcp", addr
+	if path, ok := strings.CutPrefix(addr, "unix://"); ok {
+		network, address = "unix", path
+		// Remove a stale socket left behind by a previous run
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return listener, nil
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	// Add flags
+	serveCmd.Flags().BoolVar(&serveGRPC, "grpc", false, "Serve the gRPC API instead of MCP over stdio")
+	serveCmd.Flags().BoolVar(&serveHTTP, "http", false, "Serve MCP over streamable HTTP with API token auth")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "", "Listen address (host:port or unix:///path; default "+defaultGRPCAddr+" for gRPC, "+defaultHTTPAddr+" for HTTP)")
+}
@@ -0,0 +1,114 @@
+// ABOUTME: Configures the middleware chain around the notes service from environment variables
+// ABOUTME: Every provider's service gets metrics and tracing, plus read-only policy, caching (optionally to a file), and retries when enabled
+
+package cmd
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// Environment variables configuring the notes service middleware
+const (
+	// readOnlyEnvVar refuses every operation that would change notes or folders
+	readOnlyEnvVar = "NOTES_MCP_READ_ONLY"
+	// retriesEnvVar sets how many times reads failing with a timeout or a closed Notes app are retried
+	retriesEnvVar = "NOTES_MCP_RETRIES"
+	// cacheTTLEnvVar sets how long note bodies, exports, and folder lists are cached (e.g. "30s")
+	cacheTTLEnvVar = "NOTES_MCP_CACHE_TTL"
+	// cacheFileEnvVar keeps cached results in a file, shared by every notes-mcp process using it
+	cacheFileEnvVar = "NOTES_MCP_CACHE_FILE"
+)
+
+// retryBackoff is the wait before the first retry, growing by the same amount per retry
+const retryBackoff = 500 * time.Millisecond
+
+// serviceMetrics collects per-operation call counts and timings for health_check
+var serviceMetrics = services.NewServiceMetrics()
+
+// readOnlyEnabled reports whether NOTES_MCP_READ_ONLY is set
+func readOnlyEnabled() bool {
+	return envEnabled(readOnlyEnvVar)
+}
+
+// serviceMiddleware returns the configured notes service middleware, outermost first
+// Metrics see every call as the caller does, including retries and cache hits.
+func serviceMiddleware() []services.ServiceMiddleware {
+	middleware := []services.ServiceMiddleware{
+		services.MetricsMiddleware(serviceMetrics),
+		services.TraceMiddleware(),
+	}
+	if readOnlyEnabled() {
+		middleware = append(middleware, services.ReadOnlyMiddleware())
+	}
+	if ttl := cacheTTL(); ttl > 0 {
+		middleware = append(middleware, services.CacheMiddleware(ttl, time.Now, openCacheFile()))
+	}
+	if retries := readRetries(); retries > 0 {
+		middleware = append(middleware, services.RetryMiddleware(retries, retryBackoff))
+	}
+	return middleware
+}
+
+// cacheTTL parses NOTES_MCP_CACHE_TTL; unset or invalid disables the cache
+func cacheTTL() time.Duration {
+	value := os.Getenv(cacheTTLEnvVar)
+	if value == "" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		log.Printf("Ignoring %s=%q: expected a duration such as 30s", cacheTTLEnvVar, value)
+		return 0
+	}
+	return ttl
+}
+
+// The cache file is opened once per process, holding its store lock until the process exits
+var (
+	cacheFileOnce sync.Once
+	cacheFile     *services.CacheFile
+)
+
+// openCacheFile returns the process's cache file, or nil to cache in memory only
+// When NOTES_MCP_CACHE_FILE is unset or can't be opened, the cache stays in memory.
+func openCacheFile() *services.CacheFile {
+	cacheFileOnce.Do(func() {
+		path := os.Getenv(cacheFileEnvVar)
+		if path == "" {
+			return
+		}
+
+		// Hold the store lock before loading the key, and never fall back to plaintext when encryption was requested
+		if err := lockStoreForWriting(path); err != nil {
+			log.Printf("Cache file disabled: %v", err)
+			return
+		}
+		encryptor, err := newStoreEncryptor()
+		if err != nil {
+			log.Printf("Cache file disabled: %v", err)
+			return
+		}
+		cacheFile = services.NewCacheFile(path, encryptor, services.DefaultCacheFileLimit)
+	})
+	return cacheFile
+}
+
+// readRetries parses NOTES_MCP_RETRIES; unset or invalid means no retries
+func readRetries() int {
+	value := os.Getenv(retriesEnvVar)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("Ignoring %s=%q: expected a non-negative number", retriesEnvVar, value)
+		return 0
+	}
+	return n
+}
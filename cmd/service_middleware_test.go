@@ -0,0 +1,45 @@
+// ABOUTME: Tests for configuring the notes service middleware from the environment
+// ABOUTME: Verifies which middleware each setting enables and how invalid values are ignored
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestServiceMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		readOnly string
+		retries  string
+		cacheTTL string
+		want     int
+	}{
+		{"defaults", "", "", "", 2},
+		{"read only", "true", "", "", 3},
+		{"cache and retries", "", "2", "30s", 4},
+		{"everything", "true", "1", "1m", 5},
+		{"invalid values ignored", "", "-1", "soon", 2},
+		{"zero disables", "", "0", "0", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(readOnlyEnvVar, tt.readOnly)
+			t.Setenv(retriesEnvVar, tt.retries)
+			t.Setenv(cacheTTLEnvVar, tt.cacheTTL)
+			if got := len(serviceMiddleware()); got != tt.want {
+				t.Errorf("expected %d middleware, got %d", tt.want, got)
+			}
+		})
+	}
+
+	t.Setenv(cacheTTLEnvVar, "90s")
+	if got := cacheTTL(); got != 90*time.Second {
+		t.Errorf("cacheTTL() = %v, want 90s", got)
+	}
+	t.Setenv(retriesEnvVar, "3")
+	if got := readRetries(); got != 3 {
+		t.Errorf("readRetries() = %d, want 3", got)
+	}
+}
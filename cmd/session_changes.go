@@ -0,0 +1,270 @@
+// ABOUTME: Tracks the notes each MCP session has changed and accessed
+// ABOUTME: Records successful create/update/delete/move tool calls and reports them via get_session_changes
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Kinds of change recorded for a session
+const (
+	changeCreated = "created"
+	changeUpdated = "updated"
+	changeDeleted = "deleted"
+	changeMoved   = "moved"
+)
+
+// noteChange is a single change a session made to a note
+type noteChange struct {
+	Action string    `json:"action"`
+	Title  string    `json:"title"`
+	Folder string    `json:"folder,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// sessionChangeSummary is the result of get_session_changes
+type sessionChangeSummary struct {
+	SessionStarted time.Time      `json:"session_started"`
+	Counts         map[string]int `json:"counts"`
+	Changes        []noteChange   `json:"changes"`
+}
+
+// sessionLog is one session's start time, changes in the order they happened, and the notes
+// it read or wrote, most recent last
+type sessionLog struct {
+	started  time.Time
+	changes  []noteChange
+	accessed []noteAccess
+}
+
+// sessionChanges records the note changes made by each MCP session
+type sessionChanges struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	sessions map[*mcp.ServerSession]*sessionLog
+}
+
+// newSessionChanges creates an empty change tracker
+func newSessionChanges() *sessionChanges {
+	return &sessionChanges{
+		now:      time.Now,
+		sessions: make(map[*mcp.ServerSession]*sessionLog),
+	}
+}
+
+// sessionLogLocked returns the log for a session, starting it now if it is new
+func (c *sessionChanges) sessionLogLocked(session *mcp.ServerSession) *sessionLog {
+	entry, ok := c.sessions[session]
+	if !ok {
+		entry = &sessionLog{started: c.now()}
+		c.sessions[session] = entry
+		forgetOnClose(session, c.forget)
+	}
+	return entry
+}
+
+// forget drops a closed session's changes and the notes it accessed
+func (c *sessionChanges) forget(session *mcp.ServerSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, session)
+}
+
+// touch starts tracking a session if it isn't tracked yet
+func (c *sessionChanges) touch(session *mcp.ServerSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessionLogLocked(session)
+}
+
+// record appends a change to a session's log
+func (c *sessionChanges) record(session *mcp.ServerSession, change noteChange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.sessionLogLocked(session)
+	change.Time = c.now()
+	entry.changes = append(entry.changes, change)
+}
+
+// summary returns a copy of a session's changes with per-action counts
+func (c *sessionChanges) summary(session *mcp.ServerSession) sessionChangeSummary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.sessionLogLocked(session)
+
+	summary := sessionChangeSummary{
+		SessionStarted: entry.started,
+		Counts: map[string]int{
+			changeCreated: 0,
+			changeUpdated: 0,
+			changeDeleted: 0,
+			changeMoved:   0,
+		},
+		Changes: append([]noteChange{}, entry.changes...),
+	}
+	for _, change := range entry.changes {
+		summary.Counts[change.Action]++
+	}
+	return summary
+}
+
+// changeFromToolCall describes the note change made by a successful tool call
+// Returns false for tools that don't change notes
+func changeFromToolCall(tool string, arguments json.RawMessage, result *mcp.CallToolResult) (noteChange, bool) {
+	var args struct {
+		Title        string   `json:"title"`
+		NoteTitle    string   `json:"note_title"`
+		TargetFolder string   `json:"target_folder"`
+		Tags         []string `json:"tags"`
+		Folder       string   `json:"folder"`
+		AppendToNote bool     `json:"append_to_note"`
+		Target       string   `json:"target"`
+		Sources      []string `json:"sources"`
+		Key          string   `json:"key"`
+		Value        string   `json:"value"`
+	}
+	if len(arguments) > 0 {
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return noteChange{}, false
+		}
+	}
+
+	switch tool {
+	case "create_note", "generate_weekly_digest", "clip_url":
+		// The created note's title may differ from the argument once placeholders are expanded
+		change := noteChange{Action: changeCreated, Title: args.Title}
+		var note struct {
+			Title  string `json:"title"`
+			Folder string `json:"folder"`
+		}
+		if text := firstText(result); text != "" && json.Unmarshal([]byte(text), &note) == nil && note.Title != "" {
+			change.Title = note.Title
+			change.Folder = note.Folder
+		}
+		return change, true
+	case "import_html":
+		var imported struct {
+			Imported []string `json:"imported"`
+		}
+		if json.Unmarshal([]byte(firstText(result)), &imported) != nil || len(imported.Imported) == 0 {
+			return noteChange{}, false
+		}
+		return noteChange{Action: changeCreated, Title: imported.Imported[0], Folder: args.Folder}, true
+	case "update_note":
+		return noteChange{Action: changeUpdated, Title: args.Title, Detail: "content replaced"}, true
+	case "transcribe_attachment":
+		if !args.AppendToNote {
+			return noteChange{}, false
+		}
+		return noteChange{Action: changeUpdated, Title: args.NoteTitle, Detail: "transcript appended"}, true
+	case "pin_note":
+		return noteChange{Action: changeUpdated, Title: args.Title, Detail: "pinned"}, true
+	case "add_note_tags":
+		return noteChange{Action: changeUpdated, Title: args.Title, Detail: "tags added: " + strings.Join(args.Tags, ", ")}, true
+	case "set_note_property":
+		if args.Value == "" {
+			return noteChange{Action: changeUpdated, Title: args.Title, Detail: "property removed: " + args.Key}, true
+		}
+		return noteChange{Action: changeUpdated, Title: args.Title, Detail: "property set: " + args.Key + "=" + args.Value}, true
+	case "mark_note_processed":
+		if args.Key == "" {
+			args.Key = services.DefaultProcessedKey
+		}
+		return noteChange{Action: changeUpdated, Title: args.Title, Detail: "marked processed: " + args.Key}, true
+	case "delete_note":
+		return noteChange{Action: changeDeleted, Title: args.Title}, true
+	case "merge_notes":
+		return noteChange{Action: changeUpdated, Title: args.Target, Detail: "merged and deleted: " + strings.Join(args.Sources, ", ")}, true
+	case "move_note":
+		return noteChange{Action: changeMoved, Title: args.NoteTitle, Folder: args.TargetFolder}, true
+	}
+	return noteChange{}, false
+}
+
+// firstText returns the text of a result's first text content
+func firstText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
+
+// sessionChangesMiddleware records the notes changed by successful tool calls in each session,
+// and the notes read by tool calls and note:/// resource reads
+func sessionChangesMiddleware(changes *sessionChanges) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			session, ok := req.GetSession().(*mcp.ServerSession)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			changes.touch(session)
+
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+
+			if resource, isRead := req.GetParams().(*mcp.ReadResourceParams); isRead {
+				if title, read := readFromResourceURI(resource.URI); read {
+					changes.recordAccess(session, noteAccess{Title: title, Action: accessRead})
+				}
+				return result, err
+			}
+
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			toolResult, isToolResult := result.(*mcp.CallToolResult)
+			if !ok || !isToolResult || toolResult.IsError {
+				return result, err
+			}
+
+			if change, tracked := changeFromToolCall(params.Name, params.Arguments, toolResult); tracked {
+				changes.record(session, change)
+				changes.recordChangeAccess(session, change)
+			} else if title, read := readFromToolCall(params.Name, params.Arguments); read {
+				changes.recordAccess(session, noteAccess{Title: title, Action: accessRead})
+			}
+			return result, err
+		}
+	}
+}
+
+// GetSessionChangesArgs are the arguments for the get_session_changes tool
+type GetSessionChangesArgs struct{}
+
+// registerGetSessionChangesTool registers the get_session_changes tool
+func registerGetSessionChangesTool(server *mcp.Server, changes *sessionChanges) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetSessionChangesArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		summaryJSON, err := json.MarshalIndent(changes.summary(req.Session), "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format session changes: %w", err)), nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(summaryJSON),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_session_changes",
+		Description: "Summarizes the notes this session has created, updated, deleted, or moved, with titles and timestamps in the order they happened, plus per-action counts. Use it at the end of a task to report what changed.",
+	}, handler)
+}
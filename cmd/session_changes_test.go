@@ -0,0 +1,172 @@
+// ABOUTME: Tests for per-session change tracking
+// ABOUTME: Drives mutating tools over in-memory transports and checks the get_session_changes report
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connectTestClient connects a client to the server over in-memory transports
+func connectTestClient(t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = clientSession.Close() })
+
+	return clientSession
+}
+
+// getSessionChanges calls get_session_changes and decodes the summary
+func getSessionChanges(t *testing.T, session *mcp.ClientSession) sessionChangeSummary {
+	t.Helper()
+
+	result := callToolResult(t, session, "get_session_changes", map[string]any{})
+	var summary sessionChangeSummary
+	if err := json.Unmarshal([]byte(firstText(result)), &summary); err != nil {
+		t.Fatalf("failed to decode summary: %v", err)
+	}
+	return summary
+}
+
+// TestSessionChanges tests that successful changes are reported per session and failures are not
+func TestSessionChanges(t *testing.T) {
+	mock := &mockNotesService{
+		createNote: func(ctx context.Context, title, content string, tags []string) (*services.Note, error) {
+			// Simulate placeholder expansion changing the title
+			return &services.Note{Title: "Standup 2025-01-06", Folder: "Notes"}, nil
+		},
+		updateNote: func(ctx context.Context, title, content string) error {
+			return nil
+		},
+		moveNote: func(ctx context.Context, noteTitle, targetFolder string) error {
+			return nil
+		},
+		deleteNote: func(ctx context.Context, title string) error {
+			if title == "Locked" {
+				return services.ErrNoteNotFound
+			}
+			return nil
+		},
+	}
+
+	changes := newSessionChanges()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(sessionChangesMiddleware(changes))
+	registerCreateNoteTool(server, mock)
+	registerUpdateNoteTool(server, mock)
+	registerMoveNoteTool(server, mock)
+	registerDeleteNoteTool(server, mock)
+	registerGetSessionChangesTool(server, changes)
+
+	t.Setenv(confirmDestructiveEnvVar, confirmNever)
+	session := connectTestClient(t, server)
+	other := connectTestClient(t, server)
+
+	callTool(t, session, "create_note", map[string]any{"title": "Standup {{date}}", "content": "Notes", "template": true})
+	callTool(t, session, "update_note", map[string]any{"title": "Plan", "content": "v2"})
+	callTool(t, session, "move_note", map[string]any{"note_title": "Plan", "target_folder": "Archive"})
+	callTool(t, session, "delete_note", map[string]any{"title": "Old"})
+	callTool(t, session, "delete_note", map[string]any{"title": "Locked"})
+
+	summary := getSessionChanges(t, session)
+
+	want := []noteChange{
+		{Action: changeCreated, Title: "Standup 2025-01-06", Folder: "Notes"},
+		{Action: changeUpdated, Title: "Plan", Detail: "content replaced"},
+		{Action: changeMoved, Title: "Plan", Folder: "Archive"},
+		{Action: changeDeleted, Title: "Old"},
+	}
+	if len(summary.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), summary.Changes)
+	}
+	for i, change := range summary.Changes {
+		if change.Time.IsZero() || change.Time.Before(summary.SessionStarted) {
+			t.Errorf("change %d has time %v before session start %v", i, change.Time, summary.SessionStarted)
+		}
+		change.Time = want[i].Time
+		if change != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, change, want[i])
+		}
+	}
+	for action, count := range map[string]int{changeCreated: 1, changeUpdated: 1, changeMoved: 1, changeDeleted: 1} {
+		if summary.Counts[action] != count {
+			t.Errorf("counts[%s] = %d, want %d", action, summary.Counts[action], count)
+		}
+	}
+
+	if otherSummary := getSessionChanges(t, other); len(otherSummary.Changes) != 0 {
+		t.Errorf("expected no changes in another session, got %+v", otherSummary.Changes)
+	}
+}
+
+// TestSessionChangesForgottenOnClose tests that a session's changes and reads are dropped once it disconnects
+func TestSessionChangesForgottenOnClose(t *testing.T) {
+	mock := &mockNotesService{
+		updateNote: func(ctx context.Context, title, content string) error {
+			return nil
+		},
+		getNoteContent: func(ctx context.Context, title string) (string, error) {
+			return "body", nil
+		},
+	}
+	changes := newSessionChanges()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(sessionChangesMiddleware(changes))
+	registerUpdateNoteTool(server, mock)
+	registerGetNoteContentTool(server, mock)
+
+	session := connectTestClient(t, server)
+	callToolResult(t, session, "update_note", map[string]any{"title": "Plan", "content": "v2"})
+	callToolResult(t, session, "get_note_content", map[string]any{"title": "Notes"})
+	tracked := func() int {
+		changes.mu.Lock()
+		defer changes.mu.Unlock()
+		return len(changes.sessions)
+	}
+	if tracked() != 1 {
+		t.Fatalf("expected the session to be tracked, got %d", tracked())
+	}
+
+	_ = session.Close()
+	waitUntilForgotten(t, tracked)
+}
+
+// TestChangeFromToolCall tests which tool calls count as changes
+func TestChangeFromToolCall(t *testing.T) {
+	success := &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}
+
+	if _, tracked := changeFromToolCall("get_note_content", json.RawMessage(`{"title":"A"}`), success); tracked {
+		t.Error("reads should not be tracked")
+	}
+	if _, tracked := changeFromToolCall("update_note", json.RawMessage(`not json`), success); tracked {
+		t.Error("unparseable arguments should not be tracked")
+	}
+
+	change, tracked := changeFromToolCall("add_note_tags", json.RawMessage(`{"title":"A","tags":["x","y"]}`), success)
+	if !tracked || change.Action != changeUpdated || change.Detail != "tags added: x, y" {
+		t.Errorf("add_note_tags change = %+v, %t", change, tracked)
+	}
+
+	change, _ = changeFromToolCall("create_note", json.RawMessage(`{"title":"Draft"}`), success)
+	if change.Title != "Draft" {
+		t.Errorf("expected the argument title when the result isn't a note, got %q", change.Title)
+	}
+}
@@ -0,0 +1,19 @@
+// ABOUTME: Cleanup of per-session state once an MCP session ends
+// ABOUTME: Waits on the session's connection in the background and calls back when it closes
+
+package cmd
+
+import "github.com/modelcontextprotocol/go-sdk/mcp"
+
+// forgetOnClose calls forget with session once its connection closes, so state kept per session
+// doesn't outlive it. Call it when the state for a session is first created; a nil session, as
+// when handlers are called directly, is ignored.
+func forgetOnClose(session *mcp.ServerSession, forget func(*mcp.ServerSession)) {
+	if session == nil {
+		return
+	}
+	go func() {
+		_ = session.Wait() // the close matters, not why it happened
+		forget(session)
+	}()
+}
@@ -0,0 +1,59 @@
+// ABOUTME: Tests for cleaning up per-session state once an MCP session ends
+// ABOUTME: Closes in-memory client sessions and waits for the server side to forget them
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// waitUntilForgotten fails the test unless tracked reports no sessions within a second
+func waitUntilForgotten(t *testing.T, tracked func() int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for tracked() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected closed sessions to be forgotten, %d still tracked", tracked())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestForgetOnClose tests that forget runs with the session once its client disconnects
+func TestForgetOnClose(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+
+	forgotten := make(chan *mcp.ServerSession, 1)
+	forgetOnClose(serverSession, func(session *mcp.ServerSession) { forgotten <- session })
+	forgetOnClose(nil, func(*mcp.ServerSession) { t.Error("expected a nil session to be ignored") })
+
+	select {
+	case <-forgotten:
+		t.Fatal("expected the session to be kept while it is open")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	_ = clientSession.Close()
+	select {
+	case session := <-forgotten:
+		if session != serverSession {
+			t.Errorf("forgot %p, want %p", session, serverSession)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the session to be forgotten once closed")
+	}
+}
@@ -0,0 +1,236 @@
+// ABOUTME: Session memory of the notes read and written, for follow-ups like "add that to the note from before"
+// ABOUTME: Exposes the notes:///session/recent resource and the get_last_note tool
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// sessionRecentURI is the resource listing the notes this session touched
+const sessionRecentURI = "notes:///session/recent"
+
+// noteResourcePrefix begins the URI of the note:///{title} resource
+const noteResourcePrefix = "note:///"
+
+// Kinds of note access remembered for a session; changes use their change action
+const (
+	accessRead = "read"
+)
+
+// maxSessionAccesses bounds the notes remembered per session
+const maxSessionAccesses = 50
+
+// noteAccess is the latest time a session read or wrote a note
+type noteAccess struct {
+	Title  string    `json:"title"`
+	Folder string    `json:"folder,omitempty"`
+	Action string    `json:"action"`
+	Time   time.Time `json:"time"`
+}
+
+// noteReadingTools maps tools that read a single note to the argument naming it
+var noteReadingTools = map[string]string{
+	"get_note_content":         "title",
+	"has_note_changed":         "title",
+	"open_note":                "title",
+	"bookmark_note":            "title",
+	"get_note_attachments":     "note_title",
+	"get_attachment_thumbnail": "note_title",
+	"export_note_markdown":     "note_title",
+	"export_note_text":         "note_title",
+	"extract_action_items":     "note_title",
+}
+
+// readFromToolCall returns the note read by a successful tool call
+// Returns false for tools that don't read a single note
+func readFromToolCall(tool string, arguments json.RawMessage) (string, bool) {
+	key, ok := noteReadingTools[tool]
+	if !ok || len(arguments) == 0 {
+		return "", false
+	}
+
+	var args map[string]any
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return "", false
+	}
+	title, _ := args[key].(string)
+	return title, title != ""
+}
+
+// readFromResourceURI returns the note named by a note:///{title} resource URI
+func readFromResourceURI(uri string) (string, bool) {
+	if !strings.HasPrefix(uri, noteResourcePrefix) {
+		return "", false
+	}
+	title, err := url.PathUnescape(strings.TrimPrefix(uri, noteResourcePrefix))
+	if err != nil || title == "" {
+		return "", false
+	}
+	return title, true
+}
+
+// recordAccess remembers that a session read or wrote a note, keeping one entry per title
+func (c *sessionChanges) recordAccess(session *mcp.ServerSession, access noteAccess) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.sessionLogLocked(session)
+
+	access.Time = c.now()
+	kept := make([]noteAccess, 0, len(entry.accessed)+1)
+	for _, existing := range entry.accessed {
+		if !strings.EqualFold(existing.Title, access.Title) {
+			kept = append(kept, existing)
+		} else if access.Folder == "" {
+			access.Folder = existing.Folder
+		}
+	}
+	kept = append(kept, access)
+	if len(kept) > maxSessionAccesses {
+		kept = kept[len(kept)-maxSessionAccesses:]
+	}
+	entry.accessed = kept
+}
+
+// recordChangeAccess remembers the note a change wrote; deleted notes are forgotten
+// so "the note from before" never resolves to one that no longer exists
+func (c *sessionChanges) recordChangeAccess(session *mcp.ServerSession, change noteChange) {
+	if change.Title == "" {
+		return
+	}
+	if change.Action != changeDeleted {
+		c.recordAccess(session, noteAccess{Title: change.Title, Folder: change.Folder, Action: change.Action})
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.sessionLogLocked(session)
+	kept := entry.accessed[:0]
+	for _, existing := range entry.accessed {
+		if !strings.EqualFold(existing.Title, change.Title) {
+			kept = append(kept, existing)
+		}
+	}
+	entry.accessed = kept
+}
+
+// recent returns a session's accessed notes, most recent first
+func (c *sessionChanges) recent(session *mcp.ServerSession) []noteAccess {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.sessionLogLocked(session)
+
+	recent := make([]noteAccess, 0, len(entry.accessed))
+	for i := len(entry.accessed) - 1; i >= 0; i-- {
+		recent = append(recent, entry.accessed[i])
+	}
+	return recent
+}
+
+// GetLastNoteArgs are the arguments for the get_last_note tool
+type GetLastNoteArgs struct {
+	Action         string `json:"action,omitempty" jsonschema:"Optional filter: 'read' or 'written' (created, updated, or moved); default: any"`
+	IncludeContent bool   `json:"include_content,omitempty" jsonschema:"Also return the note's HTML content"`
+}
+
+// lastNoteResult is the result of get_last_note
+type lastNoteResult struct {
+	noteAccess
+	Content string `json:"content,omitempty"`
+}
+
+// registerGetLastNoteTool registers the get_last_note tool
+func registerGetLastNoteTool(server *mcp.Server, changes *sessionChanges, notesService services.NotesService) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetLastNoteArgs) (
+		*mcp.CallToolResult, any, error) {
+
+		if input.Action != "" && input.Action != accessRead && input.Action != "written" {
+			return nil, nil, fmt.Errorf("%w: action must be 'read' or 'written'", services.ErrInvalidInput)
+		}
+
+		var last *noteAccess
+		for _, access := range changes.recent(req.Session) {
+			if input.Action == "" || (input.Action == accessRead) == (access.Action == accessRead) {
+				last = &access
+				break
+			}
+		}
+		if last == nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: "No notes have been read or written in this session yet.",
+					},
+				},
+			}, nil, nil
+		}
+
+		result := lastNoteResult{noteAccess: *last}
+		if input.IncludeContent {
+			opCtx, cancel := context.WithTimeout(ctx, getOperationTimeout())
+			defer cancel()
+
+			content, err := notesService.GetNoteContent(opCtx, last.Title)
+			if err != nil {
+				return createErrorResult(err), nil, nil
+			}
+			result.Content = content
+		}
+
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return createErrorResult(fmt.Errorf("failed to format note: %w", err)), nil, nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: string(out),
+				},
+			},
+		}, nil, nil
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_last_note",
+		Description: "Returns the note this session most recently read or wrote (title, folder, what was done, and when), so follow-ups like 'add that to the note from before' need no title. Filter with action 'read' or 'written'; set include_content to also fetch its HTML content.",
+	}, handler)
+}
+
+// registerSessionRecentResource registers the notes:///session/recent resource
+func registerSessionRecentResource(server *mcp.Server, changes *sessionChanges) {
+	server.AddResource(
+		&mcp.Resource{
+			URI:         sessionRecentURI,
+			Name:        "session-recent-notes",
+			Title:       "Notes Used in This Session",
+			Description: "Notes this session has read or written, most recent first, with the last action and time, as JSON.",
+			MIMEType:    "application/json",
+		},
+		func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			out, err := json.MarshalIndent(changes.recent(req.Session), "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to format session notes: %w", err)
+			}
+
+			return &mcp.ReadResourceResult{
+				Contents: []*mcp.ResourceContents{
+					{
+						URI:      req.Params.URI,
+						MIMEType: "application/json",
+						Text:     string(out),
+					},
+				},
+			}, nil
+		},
+	)
+}
@@ -0,0 +1,123 @@
+// ABOUTME: Tests for session memory of accessed notes
+// ABOUTME: Covers get_last_note and the notes:///session/recent resource over in-memory transports
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// getLastNote calls get_last_note and decodes the result
+func getLastNote(t *testing.T, session *mcp.ClientSession, args map[string]any) lastNoteResult {
+	t.Helper()
+
+	result := callToolResult(t, session, "get_last_note", args)
+	var last lastNoteResult
+	if err := json.Unmarshal([]byte(firstText(result)), &last); err != nil {
+		t.Fatalf("failed to decode last note %q: %v", firstText(result), err)
+	}
+	return last
+}
+
+// TestGetLastNote tests that reads and writes are remembered, most recent first, per session
+func TestGetLastNote(t *testing.T) {
+	mock := &mockNotesService{
+		getNoteMetadata: func(ctx context.Context, title string) (*services.Note, error) {
+			return &services.Note{Title: title}, nil
+		},
+		getNoteContent: func(ctx context.Context, title string) (string, error) {
+			return "<div>" + title + " body</div>", nil
+		},
+		updateNote: func(ctx context.Context, title, content string) error {
+			return nil
+		},
+		deleteNote: func(ctx context.Context, title string) error {
+			return nil
+		},
+	}
+
+	changes := newSessionChanges()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(sessionChangesMiddleware(changes))
+	registerGetNoteContentTool(server, mock)
+	registerUpdateNoteTool(server, mock)
+	registerDeleteNoteTool(server, mock)
+	registerGetLastNoteTool(server, changes, mock)
+	registerSessionRecentResource(server, changes)
+
+	t.Setenv(confirmDestructiveEnvVar, confirmNever)
+	session := connectTestClient(t, server)
+
+	empty := callToolResult(t, session, "get_last_note", map[string]any{})
+	if !strings.Contains(firstText(empty), "No notes") {
+		t.Errorf("expected an empty session message, got %q", firstText(empty))
+	}
+
+	callToolResult(t, session, "get_note_content", map[string]any{"title": "Meeting Notes"})
+	callToolResult(t, session, "update_note", map[string]any{"title": "Plan", "content": "<div>v2</div>"})
+	callToolResult(t, session, "get_note_content", map[string]any{"title": "Scratch"})
+	callToolResult(t, session, "delete_note", map[string]any{"title": "Scratch"})
+
+	if last := getLastNote(t, session, map[string]any{}); last.Title != "Plan" || last.Action != changeUpdated {
+		t.Errorf("expected updated Plan as the last note, got %+v", last)
+	}
+
+	last := getLastNote(t, session, map[string]any{"action": "read", "include_content": true})
+	if last.Title != "Meeting Notes" || last.Content != "<div>Meeting Notes body</div>" {
+		t.Errorf("expected Meeting Notes with content as the last read note, got %+v", last)
+	}
+
+	resource, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: sessionRecentURI})
+	if err != nil {
+		t.Fatalf("failed to read session resource: %v", err)
+	}
+	var recent []noteAccess
+	if err := json.Unmarshal([]byte(resource.Contents[0].Text), &recent); err != nil {
+		t.Fatalf("failed to decode session resource: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Title != "Plan" || recent[1].Title != "Meeting Notes" {
+		t.Errorf("expected Plan then Meeting Notes (Scratch was deleted), got %+v", recent)
+	}
+
+	// Each session has its own memory
+	other := connectTestClient(t, server)
+	if text := firstText(callToolResult(t, other, "get_last_note", map[string]any{})); !strings.Contains(text, "No notes") {
+		t.Errorf("expected a new session to start empty, got %q", text)
+	}
+}
+
+// TestRecordAccess tests de-duplication and the cap on remembered notes
+func TestRecordAccess(t *testing.T) {
+	changes := newSessionChanges()
+	changes.recordAccess(nil, noteAccess{Title: "Plan", Folder: "Work", Action: changeCreated})
+	changes.recordAccess(nil, noteAccess{Title: "Other", Action: accessRead})
+	changes.recordAccess(nil, noteAccess{Title: "plan", Action: accessRead})
+
+	recent := changes.recent(nil)
+	if len(recent) != 2 || recent[0].Title != "plan" || recent[0].Folder != "Work" {
+		t.Errorf("expected one Plan entry first keeping its folder, got %+v", recent)
+	}
+
+	for i := 0; i < maxSessionAccesses+5; i++ {
+		changes.recordAccess(nil, noteAccess{Title: strings.Repeat("n", i+1), Action: accessRead})
+	}
+	if got := len(changes.recent(nil)); got != maxSessionAccesses {
+		t.Errorf("expected %d remembered notes, got %d", maxSessionAccesses, got)
+	}
+}
+
+// TestReadFromResourceURI tests parsing note titles from note:/// URIs
+func TestReadFromResourceURI(t *testing.T) {
+	if title, ok := readFromResourceURI("note:///Meeting%20Notes"); !ok || title != "Meeting Notes" {
+		t.Errorf("expected Meeting Notes, got %q, %v", title, ok)
+	}
+	if _, ok := readFromResourceURI(sessionRecentURI); ok {
+		t.Error("expected non-note URIs to be ignored")
+	}
+}
@@ -0,0 +1,134 @@
+// ABOUTME: Share command sending a note as markdown to Messages or a Slack channel
+// ABOUTME: The Slack webhook and a default Messages recipient come from ~/.config/notes-mcp/share.json (or NOTES_MCP_SHARE_CONFIG)
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
+	"github.com/spf13/cobra"
+)
+
+// shareConfigEnvVar overrides the share configuration file
+const shareConfigEnvVar = "NOTES_MCP_SHARE_CONFIG"
+
+var (
+	shareTarget   string
+	shareTo       string
+	shareMaxChars int
+)
+
+// shareConfig is the share configuration file
+type shareConfig struct {
+	Slack    services.SlackShareConfig `json:"slack"`
+	Messages struct {
+		Recipient string `json:"recipient"` // used when no recipient is given
+	} `json:"messages"`
+}
+
+// shareConfigPath returns the share configuration file: NOTES_MCP_SHARE_CONFIG or ~/.config/notes-mcp/share.json
+func shareConfigPath() string {
+	if path := os.Getenv(shareConfigEnvVar); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "share.json")
+}
+
+// loadShareConfig reads the share configuration; a missing file means no Slack webhook or default recipient
+func loadShareConfig(path string) (shareConfig, error) {
+	var config shareConfig
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("failed to load share config: %w", err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to load share config from %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// newShareTarget creates the named share target; to is the Messages recipient, defaulting to the configured one
+func newShareTarget(name, to string, config shareConfig) (services.ShareTarget, error) {
+	switch name {
+	case services.ShareTargetMessages:
+		if to == "" {
+			to = config.Messages.Recipient
+		}
+		return services.NewMessagesTarget(newScriptExecutor(getScriptTimeout()), to)
+	case services.ShareTargetSlack:
+		return services.NewSlackTarget(config.Slack)
+	default:
+		return nil, fmt.Errorf("%w: invalid target %q (must be '%s' or '%s')",
+			services.ErrInvalidInput, name, services.ShareTargetMessages, services.ShareTargetSlack)
+	}
+}
+
+var shareCmd = &cobra.Command{
+	Use:   "share <title>",
+	Short: "Send a note to Messages or Slack",
+	Long: `Sends a note as markdown to Messages (--target messages --to <phone or email>) or to the
+Slack channel of an incoming webhook (--target slack). Notes longer than --max-chars (default 4000)
+are cut at a paragraph or line break, ending with how many characters were left out.
+
+The Slack webhook URL and a default Messages recipient are read from ~/.config/notes-mcp/share.json
+(or NOTES_MCP_SHARE_CONFIG), for example:
+
+  {"slack": {"webhook_url": "https://hooks.slack.com/services/..."}, "messages": {"recipient": "+15555550100"}}`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		if err := validation.Check(validation.Title("title", title)); err != nil {
+			return err
+		}
+
+		config, err := loadShareConfig(shareConfigPath())
+		if err != nil {
+			return err
+		}
+		target, err := newShareTarget(shareTarget, shareTo, config)
+		if err != nil {
+			return err
+		}
+
+		notesService := newNotesService()
+
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		shared, err := services.ShareNote(ctx, notesService, target, title, shareMaxChars)
+		if err != nil {
+			return err
+		}
+
+		if shared.Truncated {
+			printSuccess(cmd.OutOrStdout(), "Shared %s to %s (truncated to %d characters)", shared.Title, shared.Target, shared.Characters)
+			return nil
+		}
+		printSuccess(cmd.OutOrStdout(), "Shared %s to %s", shared.Title, shared.Target)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shareCmd)
+
+	shareCmd.Flags().StringVar(&shareTarget, "target", "", "Destination: messages or slack")
+	shareCmd.Flags().StringVar(&shareTo, "to", "", "Messages recipient: phone number or email (default: share.json's recipient)")
+	shareCmd.Flags().IntVar(&shareMaxChars, "max-chars", services.DefaultShareMaxChars, "Longest message to send; longer notes are truncated")
+	_ = shareCmd.MarkFlagRequired("target") //nolint:errcheck // the flag is defined just above
+}
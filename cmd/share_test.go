@@ -0,0 +1,83 @@
+// ABOUTME: Unit tests for the share command and the share_note_to tool
+// ABOUTME: Tests target selection from the config file and sharing to a test Slack webhook
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestNewShareTarget tests the targets built from the config and their missing settings
+func TestNewShareTarget(t *testing.T) {
+	var config shareConfig
+	if _, err := newShareTarget("email", "", config); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown target, got %v", err)
+	}
+	if _, err := newShareTarget(services.ShareTargetSlack, "", config); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without a webhook, got %v", err)
+	}
+	if _, err := newShareTarget(services.ShareTargetMessages, "", config); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without a recipient, got %v", err)
+	}
+
+	config.Messages.Recipient = "+15555550100"
+	if target, err := newShareTarget(services.ShareTargetMessages, "", config); err != nil || target.Name() != services.ShareTargetMessages {
+		t.Errorf("expected the configured recipient to be used, got %v, %v", target, err)
+	}
+}
+
+// TestShareNoteToTool tests posting a note to the configured Slack webhook
+func TestShareNoteToTool(t *testing.T) {
+	t.Setenv(confirmDestructiveEnvVar, confirmNever)
+
+	var payload map[string]string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer webhook.Close()
+
+	path := filepath.Join(t.TempDir(), "share.json")
+	if err := os.WriteFile(path, []byte(`{"slack": {"webhook_url": "`+webhook.URL+`"}}`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv(shareConfigEnvVar, path)
+
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	if _, err := notesService.CreateNote(ctx, "Plan", "Plan\nShip it", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerShareNoteToTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "share_note_to", map[string]any{"title": "Plan", "target": "slack"})
+	if result.IsError {
+		t.Fatalf("share_note_to failed: %s", firstText(result))
+	}
+	var shared services.SharedNote
+	if err := json.Unmarshal([]byte(firstText(result)), &shared); err != nil {
+		t.Fatalf("share_note_to did not return JSON: %v", err)
+	}
+	if shared.Target != services.ShareTargetSlack || shared.Truncated || payload["text"] == "" {
+		t.Errorf("unexpected report %+v with payload %v", shared, payload)
+	}
+
+	if result := callToolResult(t, session, "share_note_to", map[string]any{"title": "Plan", "target": "email"}); !result.IsError {
+		t.Error("expected an unknown target to be rejected")
+	}
+}
@@ -0,0 +1,109 @@
+// ABOUTME: Commands for the macOS Shortcuts integration: pin, tag, and shortcuts
+// ABOUTME: Pins and tags notes, and lists the bundled Shortcut definitions with install status
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <note-title>",
+	Short: "Pin a note in Apple Notes",
+	Long: `Pins a note. With NOTES_MCP_SHORTCUTS including "pin", the "notes-mcp Pin Note" Shortcut is used;
+otherwise (or if the Shortcut fails) notes-mcp clicks File > Pin Note, which requires Accessibility permission.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		if err := notesService.PinNote(ctx, title); err != nil {
+			return err
+		}
+
+		printSuccess(cmd.OutOrStdout(), "Note pinned: %s", title)
+		return nil
+	},
+}
+
+var tagCmd = &cobra.Command{
+	Use:   "tag <note-title> <tag> [tag...]",
+	Short: "Add tags to a note in Apple Notes",
+	Long: `Adds tags to a note. With NOTES_MCP_SHORTCUTS including "tags", the "notes-mcp Tag Note" Shortcut adds native tags;
+otherwise (or if the Shortcut fails) the tags are appended to the note body as #hashtags.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title := args[0]
+
+		// Create service with real executor
+		notesService := newNotesService()
+
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		if err := notesService.AddNoteTags(ctx, title, args[1:]); err != nil {
+			return err
+		}
+
+		printSuccess(cmd.OutOrStdout(), "Tags added to note: %s", title)
+		return nil
+	},
+}
+
+var shortcutsCmd = &cobra.Command{
+	Use:   "shortcuts",
+	Short: "List the Shortcuts notes-mcp can use and whether they are installed",
+	Long: `Prints each bundled Shortcut definition with the steps needed to build it in the Shortcuts app.
+Create each Shortcut with exactly the listed name, then enable it with NOTES_MCP_SHORTCUTS=pin,tags (or "all").`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create context with timeout
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		// Installed status is best effort; the shortcuts CLI only exists on macOS 12+
+		installed := map[string]bool{}
+		names, err := services.NewShortcutsCLIRunner(osascriptTimeout).ListInstalled(ctx)
+		for _, name := range names {
+			installed[name] = true
+		}
+
+		out := cmd.OutOrStdout()
+		for _, def := range services.BundledShortcuts {
+			status := "not installed"
+			switch {
+			case err != nil:
+				status = "unknown"
+			case installed[def.Name]:
+				status = "installed"
+			}
+
+			//nolint:errcheck // stdout write failure is non-critical
+			fmt.Fprintf(out, "%s (%s) [%s]\n  %s\n  Input: %s\n  Steps:\n    - %s\n\n",
+				def.Name, def.Operation, status, def.Description, def.Input, strings.Join(def.Steps, "\n    - "))
+		}
+
+		if err != nil {
+			//nolint:errcheck // stderr write failure is non-critical
+			fmt.Fprintf(cmd.ErrOrStderr(), "Could not check installed Shortcuts: %v\n", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(shortcutsCmd)
+}
@@ -0,0 +1,40 @@
+// ABOUTME: Unit tests for the pin, tag, and shortcuts commands
+// ABOUTME: Tests CLI argument validation
+
+package cmd
+
+import (
+	"io"
+	"testing"
+)
+
+// TestShortcutCommandsArgs tests that pin and tag validate their argument counts
+func TestShortcutCommandsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "pin without title", args: []string{"pin"}},
+		{name: "pin with extra argument", args: []string{"pin", "Note", "extra"}},
+		{name: "tag without tags", args: []string{"tag", "Note"}},
+		{name: "shortcuts with argument", args: []string{"shortcuts", "extra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up command
+			rootCmd.SetArgs(tt.args)
+
+			// Silence output
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+		})
+	}
+}
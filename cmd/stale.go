@@ -0,0 +1,80 @@
+// ABOUTME: Stale command listing notes not modified in a given number of days, oldest first
+// ABOUTME: CLI counterpart of the find_stale_notes tool, for cleanup reviews from the terminal
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	staleDays     int
+	staleFolder   string
+	staleMinWords int
+	staleJSON     bool
+)
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List notes not modified in a number of days",
+	Long: `Lists notes that haven't been modified in --days days (default 90), oldest first, with their
+last modification date, word count, and folder. --min-words skips stubs shorter than the given
+number of words, and --folder limits the report to one folder. --json prints each note with its
+dates, days_stale, and metrics.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		notesService := newNotesService()
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		opts := services.StaleNoteOptions{Days: staleDays, Folder: staleFolder, MinWords: staleMinWords}
+		notes, err := services.FindStaleNotes(ctx, notesService, opts, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if staleJSON {
+			output, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format notes: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(output))
+			return nil
+		}
+
+		printStaleNotes(cmd.OutOrStdout(), notes)
+		return nil
+	},
+}
+
+// printStaleNotes writes one "<modified>  <words>  <folder>/<title>" line per note, in local time
+func printStaleNotes(w io.Writer, notes []services.StaleNote) {
+	if len(notes) == 0 {
+		fmt.Fprintln(w, "No stale notes found.") //nolint:errcheck // stdout write failure is non-critical
+		return
+	}
+	for _, note := range notes {
+		words := "-"
+		if note.Metrics != nil {
+			words = fmt.Sprintf("%d", note.Metrics.WordCount)
+		}
+		fmt.Fprintf(w, "%s  %6s words  %s\n", note.ModificationDate.Local().Format("2006-01-02"), words, //nolint:errcheck // stdout write failure is non-critical
+			joinFolderPath(note.Folder, note.Title))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(staleCmd)
+
+	staleCmd.Flags().IntVar(&staleDays, "days", services.DefaultStaleDays, "Minimum days since a note was last modified")
+	staleCmd.Flags().StringVar(&staleFolder, "folder", "", "Only check notes in this folder")
+	staleCmd.Flags().IntVar(&staleMinWords, "min-words", 0, "Only list notes with at least this many words")
+	staleCmd.Flags().BoolVar(&staleJSON, "json", false, "Print the notes as JSON")
+}
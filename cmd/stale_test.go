@@ -0,0 +1,60 @@
+// ABOUTME: Unit tests for the stale command and the find_stale_notes tool
+// ABOUTME: Tests the text listing and the tool's JSON report against the memory service
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestPrintStaleNotes tests the text listing, including notes without metrics
+func TestPrintStaleNotes(t *testing.T) {
+	modified := time.Date(2023, 11, 2, 9, 30, 0, 0, time.Local)
+	var out bytes.Buffer
+	printStaleNotes(&out, []services.StaleNote{
+		{Note: services.Note{Title: "Plan", Folder: "Work", ModificationDate: modified, Metrics: &services.NoteMetrics{WordCount: 120}}},
+		{Note: services.Note{Title: "Locked", ModificationDate: modified}},
+	})
+	want := "2023-11-02     120 words  Work/Plan\n2023-11-02       - words  Locked\n"
+	if out.String() != want {
+		t.Errorf("listing = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	printStaleNotes(&out, nil)
+	if out.String() != "No stale notes found.\n" {
+		t.Errorf("unexpected empty listing %q", out.String())
+	}
+}
+
+// TestFindStaleNotesTool tests the tool's default age and JSON report
+func TestFindStaleNotesTool(t *testing.T) {
+	notesService := services.NewMemoryNotesService()
+	if _, err := notesService.CreateNote(context.Background(), "Fresh", "<div>New</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerFindStaleNotesTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	var report staleNotesResult
+	result := callToolResult(t, session, "find_stale_notes", map[string]any{})
+	if err := json.Unmarshal([]byte(firstText(result)), &report); err != nil {
+		t.Fatalf("find_stale_notes did not return JSON: %v: %s", err, firstText(result))
+	}
+	if report.Days != services.DefaultStaleDays || report.Total != 0 || len(report.Notes) != 0 {
+		t.Errorf("expected no stale notes at the default age, got %+v", report)
+	}
+
+	if result := callToolResult(t, session, "find_stale_notes", map[string]any{"min_words": -1}); !result.IsError {
+		t.Error("expected a negative min_words to be rejected")
+	}
+}
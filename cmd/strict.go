@@ -0,0 +1,48 @@
+// ABOUTME: The --strict flag, making note commands fail when their title matches more than one note
+// ABOUTME: The matching notes are listed on stderr so a script's author can pick one by ID or rename them
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// strictTitles is set by the persistent --strict flag
+var strictTitles bool
+
+// resolveTitleArgs prepares the title argument of note commands: it resolves "@name" aliases and,
+// with --strict, refuses titles that more than one note answers to
+func resolveTitleArgs(cmd *cobra.Command, args []string) error {
+	if err := resolveAliasArgs(cmd, args); err != nil {
+		return err
+	}
+	if !strictTitles || !noteTitleCommands[cmd.Name()] || len(args) == 0 {
+		return nil
+	}
+
+	ctx, cancel := newCommandContext()
+	defer cancel()
+
+	return checkStrictTitle(ctx, cmd.ErrOrStderr(), newNotesService(), args[0])
+}
+
+// checkStrictTitle returns an error if title matches more than one note, listing the matches on w
+func checkStrictTitle(ctx context.Context, w io.Writer, notes services.NoteReader, title string) error {
+	err := services.RequireUniqueTitle(ctx, notes, title)
+	var ambiguous *services.AmbiguousTitleError
+	if !errors.As(err, &ambiguous) {
+		return err
+	}
+
+	for _, note := range ambiguous.Candidates {
+		//nolint:errcheck // stderr write failure is non-critical
+		fmt.Fprintf(w, "candidate: %s\t%s\t%s\t%s\n", note.Title, note.Folder, note.ID, listTime{note.ModificationDate})
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+// ABOUTME: Unit tests for the --strict title check
+// ABOUTME: Tests that an ambiguous title fails with its candidates listed and a unique one passes
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// TestCheckStrictTitle tests refusing a title two notes share and accepting unique or unknown ones
+func TestCheckStrictTitle(t *testing.T) {
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	for _, title := range []string{"Plan", "plan", "Other"} {
+		if _, err := notesService.CreateNote(ctx, title, title, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	err := checkStrictTitle(ctx, &stderr, notesService, "Plan")
+	if !errors.Is(err, services.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for an ambiguous title, got %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(stderr.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], "candidate: ") {
+		t.Errorf("expected both candidates on stderr, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	for _, title := range []string{"Other", "Missing"} {
+		if err := checkStrictTitle(ctx, &stderr, notesService, title); err != nil {
+			t.Errorf("%q: expected no error, got %v", title, err)
+		}
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("expected nothing on stderr, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,46 @@
+// ABOUTME: Time zone handling for date arguments, so YYYY-MM-DD means a day in the user's zone
+// ABOUTME: Tools take an optional IANA timezone; the default is NOTES_MCP_TIMEZONE, then the system zone
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// timezoneEnvVar sets the default IANA time zone date arguments are read in (default: the system zone)
+const timezoneEnvVar = "NOTES_MCP_TIMEZONE"
+
+// resolveTimezone returns the named IANA zone, or NOTES_MCP_TIMEZONE when name is empty, or the system zone
+func resolveTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		name = os.Getenv(timezoneEnvVar)
+		if name == "" {
+			return time.Local, nil
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s=%q is not an IANA time zone such as Europe/Berlin", services.ErrInvalidInput, timezoneEnvVar, name)
+		}
+		return loc, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q, use an IANA name such as America/New_York", services.ErrInvalidInput, name)
+	}
+	return loc, nil
+}
+
+// startOfDay returns midnight at the start of t's day in its zone
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// endOfDay returns the last second of t's day in its zone, for inclusive date ranges
+func endOfDay(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, 1).Add(-time.Second)
+}
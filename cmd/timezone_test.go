@@ -0,0 +1,82 @@
+// ABOUTME: Tests for reading date arguments as days in the caller's time zone
+// ABOUTME: Verifies zone resolution and that search and digest tools align their ranges with local day boundaries
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestResolveTimezone(t *testing.T) {
+	t.Setenv(timezoneEnvVar, "")
+	if loc, err := resolveTimezone(""); err != nil || loc != time.Local {
+		t.Errorf("expected the system zone by default, got %v, %v", loc, err)
+	}
+	if loc, err := resolveTimezone("Asia/Tokyo"); err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("expected Asia/Tokyo, got %v, %v", loc, err)
+	}
+	if _, err := resolveTimezone("Mars/Olympus"); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown zone, got %v", err)
+	}
+
+	t.Setenv(timezoneEnvVar, "Europe/Berlin")
+	if loc, err := resolveTimezone(""); err != nil || loc.String() != "Europe/Berlin" {
+		t.Errorf("expected the configured zone, got %v, %v", loc, err)
+	}
+	t.Setenv(timezoneEnvVar, "nowhere")
+	if _, err := resolveTimezone(""); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a bad %s, got %v", timezoneEnvVar, err)
+	}
+}
+
+func TestDateToolsUseTimezone(t *testing.T) {
+	t.Setenv(timezoneEnvVar, "")
+	var gotOpts services.SearchOptions
+	var gotWeekStart time.Time
+	mock := &mockNotesService{
+		searchNotesAdvanced: func(ctx context.Context, opts services.SearchOptions) ([]services.Note, error) {
+			gotOpts = opts
+			return []services.Note{}, nil
+		},
+		generateWeeklyDigest: func(ctx context.Context, weekStart time.Time, digestFolder string) (*services.Note, error) {
+			gotWeekStart = weekStart
+			return &services.Note{Title: "Weekly Digest"}, nil
+		},
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerSearchNotesAdvancedTool(server, mock)
+	registerGenerateWeeklyDigestTool(server, mock)
+	session := connectTestClient(t, server)
+
+	result := callToolResult(t, session, "search_notes_advanced", map[string]any{
+		"query": "plan", "date_from": "2024-03-10", "date_to": "2024-03-10", "timezone": "America/New_York",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	// March 10 in New York runs from 05:00 UTC to 03:59:59 UTC the next day (DST starts that morning)
+	if want := time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC); !gotOpts.DateFrom.Equal(want) {
+		t.Errorf("DateFrom = %v, want %v", gotOpts.DateFrom.UTC(), want)
+	}
+	if want := time.Date(2024, 3, 11, 3, 59, 59, 0, time.UTC); !gotOpts.DateTo.Equal(want) {
+		t.Errorf("DateTo = %v, want %v", gotOpts.DateTo.UTC(), want)
+	}
+
+	if result := callToolResult(t, session, "search_notes_advanced", map[string]any{"query": "plan", "timezone": "Nowhere/Else"}); !result.IsError {
+		t.Error("expected an unknown timezone to be rejected")
+	}
+
+	result = callToolResult(t, session, "generate_weekly_digest", map[string]any{"week_start": "2024-06-03", "timezone": "Asia/Tokyo"})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", firstText(result))
+	}
+	if want := time.Date(2024, 6, 2, 15, 0, 0, 0, time.UTC); !gotWeekStart.Equal(want) || gotWeekStart.Location().String() != "Asia/Tokyo" {
+		t.Errorf("weekStart = %v, want midnight in Tokyo (%v)", gotWeekStart, want)
+	}
+}
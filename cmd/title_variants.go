@@ -0,0 +1,106 @@
+// ABOUTME: Title-variants command listing notes whose titles differ only in case, punctuation, emoji, or copy suffixes
+// ABOUTME: CLI counterpart of the find_title_variants tool; --merge walks through each set, asking before merging it
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	titleVariantsFolder string
+	titleVariantsMerge  bool
+	titleVariantsYes    bool
+	titleVariantsJSON   bool
+)
+
+var titleVariantsCmd = &cobra.Command{
+	Use:   "title-variants",
+	Short: "Find notes whose titles are variants of each other",
+	Long: `Groups notes whose titles differ only by case, punctuation, emoji, or "copy" and "(2)"
+suffixes, a frequent artifact of iCloud sync conflicts. Each set is listed under the note
+suggested as its target, the one with the plainest title, marked with "*".
+
+--merge walks through the sets, asking for each whether to append the other notes to the target
+and delete them; --yes merges every set without asking. Sets whose titles differ only in case,
+and notes with attachments, are never merged.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		notesService := newNotesService()
+
+		ctx, cancel := newBatchCommandContext()
+		defer cancel()
+
+		sets, err := services.FindTitleVariants(ctx, notesService, titleVariantsFolder)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if titleVariantsJSON && !titleVariantsMerge {
+			output, err := json.MarshalIndent(sets, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format title variants: %w", err)
+			}
+			fmt.Fprintln(out, string(output))
+			return nil
+		}
+
+		if len(sets) == 0 {
+			fmt.Fprintln(out, "No title variants found.")
+			return nil
+		}
+		if !titleVariantsMerge {
+			for _, set := range sets {
+				printTitleVariantSet(out, set)
+			}
+			fmt.Fprintf(out, "%d sets of title variants\n", len(sets))
+			return nil
+		}
+
+		// One reader for every answer, so buffered input isn't lost between questions
+		in := bufio.NewReader(cmd.InOrStdin())
+		merged := 0
+		for _, set := range sets {
+			printTitleVariantSet(out, set)
+			if !titleVariantsYes && !confirmPrompt(in, out, fmt.Sprintf("Merge into %q?", set.Target)) {
+				continue
+			}
+			if _, err := services.MergeNotes(ctx, notesService, set.Target, set.Sources()); err != nil {
+				fmt.Fprintf(out, "skipped: %s (%v)\n", set.Target, err)
+				continue
+			}
+			merged++
+		}
+		printSuccess(out, "Merged %d of %d sets", merged, len(sets))
+		return nil
+	},
+}
+
+// printTitleVariantSet writes a set's notes, one "<modified>  <folder>/<title>" line each, marking the target
+func printTitleVariantSet(w io.Writer, set services.TitleVariantSet) {
+	for _, note := range set.Notes {
+		marker := " "
+		if note.Title == set.Target {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s  %s\n", marker, note.ModificationDate.Local().Format("2006-01-02"), //nolint:errcheck // stdout write failure is non-critical
+			joinFolderPath(note.Folder, note.Title))
+	}
+	fmt.Fprintln(w) //nolint:errcheck // stdout write failure is non-critical
+}
+
+func init() {
+	rootCmd.AddCommand(titleVariantsCmd)
+
+	titleVariantsCmd.Flags().StringVar(&titleVariantsFolder, "folder", "", "Only check notes in this folder")
+	titleVariantsCmd.Flags().BoolVar(&titleVariantsMerge, "merge", false, "Ask about merging each set into its target")
+	titleVariantsCmd.Flags().BoolVar(&titleVariantsYes, "yes", false, "Merge every set without asking")
+	titleVariantsCmd.Flags().BoolVar(&titleVariantsJSON, "json", false, "Print the sets as JSON")
+}
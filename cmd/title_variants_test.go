@@ -0,0 +1,76 @@
+// ABOUTME: Unit tests for the title-variants command and the find_title_variants and merge_notes tools
+// ABOUTME: Tests the set listing and the tools' find, merge, and refusal behavior against the memory service
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestPrintTitleVariantSet tests that the target is marked
+func TestPrintTitleVariantSet(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	var out bytes.Buffer
+	printTitleVariantSet(&out, services.TitleVariantSet{Target: "Plan", Notes: []services.Note{
+		{Title: "Plan (2)", Folder: "Work", ModificationDate: modified},
+		{Title: "Plan", Folder: "Work", ModificationDate: modified},
+	}})
+	want := "  2024-05-01  Work/Plan (2)\n* 2024-05-01  Work/Plan\n\n"
+	if out.String() != want {
+		t.Errorf("listing = %q, want %q", out.String(), want)
+	}
+}
+
+// TestFindTitleVariantsTool tests finding sets, merging them, and merge_notes refusing ambiguous titles
+func TestFindTitleVariantsTool(t *testing.T) {
+	t.Setenv(confirmDestructiveEnvVar, confirmNever)
+	ctx := context.Background()
+	notesService := services.NewMemoryNotesService()
+	for _, title := range []string{"Plan", "Plan copy", "Other", "OTHER"} {
+		if _, err := notesService.CreateNote(ctx, title, "<div>"+title+"</div>", nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerFindTitleVariantsTool(server, notesService)
+	registerMergeNotesTool(server, notesService)
+	session := connectTestClient(t, server)
+
+	var report titleVariantsResult
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "find_title_variants", map[string]any{}))), &report); err != nil {
+		t.Fatalf("find_title_variants did not return JSON: %v", err)
+	}
+	if len(report.Sets) != 2 || report.Merges != nil {
+		t.Fatalf("expected the Other and Plan sets, got %+v", report)
+	}
+
+	if result := callToolResult(t, session, "merge_notes", map[string]any{"target": "Other", "sources": []string{"OTHER"}}); !result.IsError {
+		t.Error("expected a case-only merge to be refused")
+	}
+
+	report = titleVariantsResult{}
+	if err := json.Unmarshal([]byte(firstText(callToolResult(t, session, "find_title_variants", map[string]any{"merge": true}))), &report); err != nil {
+		t.Fatalf("find_title_variants did not return JSON: %v", err)
+	}
+	if report.Merges == nil || len(report.Merges.Merged) != 1 || len(report.Merges.Skipped) != 1 {
+		t.Fatalf("expected Plan merged and Other skipped, got %+v", report.Merges)
+	}
+	body, err := notesService.GetNoteContent(ctx, "Plan")
+	if err != nil || !strings.Contains(body, "Plan copy") {
+		t.Errorf("expected Plan copy merged into Plan, got %q, %v", body, err)
+	}
+
+	t.Setenv(confirmDestructiveEnvVar, confirmAlwaysDeny)
+	if result := callToolResult(t, session, "merge_notes", map[string]any{"target": "Plan", "sources": []string{"Other"}}); !result.IsError {
+		t.Error("expected the merge refused when destructive actions are denied")
+	}
+}
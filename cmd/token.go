@@ -0,0 +1,90 @@
+// ABOUTME: Token commands for managing HTTP transport API tokens
+// ABOUTME: Creates, lists, and revokes tokens whose hashes live in the macOS Keychain
+
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage API tokens for the HTTP transport",
+	Long: `Manages the bearer tokens accepted by "notes-mcp serve --http".
+Only SHA-256 hashes of tokens are kept, in the login Keychain under the "notes-mcp" service.`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an API token",
+	Long:  `Creates an API token labelled <name> and prints it. The token is shown only once.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		token, record, err := services.NewTokenStore(newSecretStore()).Create(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Created token %s (%s). Store it now; it will not be shown again:\n\n", record.ID, record.Name)
+		fmt.Fprintln(out, token)
+		return nil
+	},
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API tokens",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		tokens, err := services.NewTokenStore(newSecretStore()).List(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(tokens) == 0 {
+			fmt.Fprintln(out, "No API tokens. Create one with: notes-mcp token create <name>")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tCREATED")
+		for _, token := range tokens {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", token.ID, token.Name, token.Created.Local().Format("2006-01-02 15:04"))
+		}
+		return w.Flush()
+	},
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API token",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		if err := services.NewTokenStore(newSecretStore()).Revoke(ctx, args[0]); err != nil {
+			return err
+		}
+
+		printSuccess(cmd.OutOrStdout(), "Revoked token %s", args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenCreateCmd, tokenListCmd, tokenRevokeCmd)
+}
@@ -0,0 +1,146 @@
+// ABOUTME: Declarative table of the MCP server's tools, with what each needs to be offered
+// ABOUTME: Registers tools by provider capability, backend interfaces, opt-in setting, and NOTES_MCP_DISABLED_TOOLS
+
+package cmd
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolDeps are the services and session state tool handlers are built from
+type toolDeps struct {
+	notes       services.NotesService
+	provider    string
+	roots       *sessionRoots
+	changes     *sessionChanges
+	bookmarks   *services.BookmarkStore
+	briefs      *services.BriefStore
+	linkChecker services.LinkChecker
+	permissions *permissionState
+	metrics     *services.ServiceMetrics
+	fileRoot    string // when set, tools read only files under it; see newMCPServer
+}
+
+// disabledToolsEnvVar lists tools, comma-separated, that the MCP server should not offer
+const disabledToolsEnvVar = "NOTES_MCP_DISABLED_TOOLS"
+
+// toolSpec declares one MCP tool: how to register it and what the server needs to offer it
+type toolSpec struct {
+	name     string
+	register func(server *mcp.Server, deps *toolDeps)
+	needs    services.BackendInterfaces // interfaces beyond NoteReader the backend must implement
+	writes   bool                       // changes notes or folders, so read-only providers don't offer it
+	folders  bool                       // needs a provider that organizes notes into folders
+	tags     bool                       // needs a provider that can tag notes
+	enabled  func() bool                // opt-in tools are offered only when this reports true; nil means always
+	anyFile  bool                       // reads any local file named in its arguments, so it isn't offered when files are confined
+}
+
+// supportedBy reports whether a backend with the given capabilities and interfaces can serve the tool
+func (spec toolSpec) supportedBy(capabilities services.ProviderCapabilities, interfaces services.BackendInterfaces) bool {
+	return interfaces.Has(spec.needs) &&
+		!(spec.writes && capabilities.ReadOnly) &&
+		!(spec.folders && !capabilities.SupportsFolders) &&
+		!(spec.tags && !capabilities.SupportsTags)
+}
+
+// toolRegistry lists every tool the MCP server can offer, in registration order
+var toolRegistry = []toolSpec{
+	{name: "create_note", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerCreateNoteTool(s, d.notes) }},
+	{name: "search_notes", register: func(s *mcp.Server, d *toolDeps) { registerSearchNotesTool(s, d.notes) }},
+	{name: "get_note_content", register: func(s *mcp.Server, d *toolDeps) { registerGetNoteContentTool(s, d.notes) }},
+	{name: "update_note", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerUpdateNoteTool(s, d.notes) }},
+	{name: "has_note_changed", register: func(s *mcp.Server, d *toolDeps) { registerHasNoteChangedTool(s, d.notes) }},
+	{name: "delete_note", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerDeleteNoteTool(s, d.notes) }},
+	{name: "open_note", needs: services.HasAppIntegrations, register: func(s *mcp.Server, d *toolDeps) { registerOpenNoteTool(s, d.notes) }},
+	{name: "list_folders", needs: services.HasFolderManager, folders: true, register: func(s *mcp.Server, d *toolDeps) { registerListFoldersTool(s, d.notes) }},
+	{name: "create_folder", needs: services.HasFolderManager, writes: true, folders: true, register: func(s *mcp.Server, d *toolDeps) { registerCreateFolderTool(s, d.notes) }},
+	{name: "move_note", needs: services.HasFolderManager, writes: true, folders: true, register: func(s *mcp.Server, d *toolDeps) { registerMoveNoteTool(s, d.notes) }},
+	{name: "get_folder_hierarchy", needs: services.HasFolderManager, folders: true, register: func(s *mcp.Server, d *toolDeps) { registerGetFolderHierarchyTool(s, d.notes) }},
+	{name: "search_notes_advanced", register: func(s *mcp.Server, d *toolDeps) { registerSearchNotesAdvancedTool(s, d.notes) }},
+	{name: "list_notes_by_prefix", register: func(s *mcp.Server, d *toolDeps) { registerListNotesByPrefixTool(s, d.notes) }},
+	{name: "get_note_attachments", needs: services.HasAttachmentReader, register: func(s *mcp.Server, d *toolDeps) { registerGetNoteAttachmentsTool(s, d.notes) }},
+	{name: "get_attachment_content", needs: services.HasAttachmentReader, register: func(s *mcp.Server, d *toolDeps) { registerGetAttachmentContentTool(s, d.notes, d.fileRoot) }},
+	{name: "get_attachment_thumbnail", needs: services.HasAttachmentReader, register: func(s *mcp.Server, d *toolDeps) { registerGetAttachmentThumbnailTool(s, d.notes) }},
+	{name: "export_note_markdown", needs: services.HasExporter, register: func(s *mcp.Server, d *toolDeps) { registerExportNoteMarkdownTool(s, d.notes) }},
+	{name: "export_note_text", needs: services.HasExporter, register: func(s *mcp.Server, d *toolDeps) { registerExportNoteTextTool(s, d.notes) }},
+	{name: "export_notes_csv", register: func(s *mcp.Server, d *toolDeps) { registerExportNotesCSVTool(s, d.notes) }},
+	{name: "import_html", needs: services.HasNoteWriter, writes: true, anyFile: true, register: func(s *mcp.Server, d *toolDeps) { registerImportHTMLTool(s, d.notes) }},
+	{name: "clip_url", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerClipURLTool(s, d.notes) }},
+	{name: "extract_action_items", register: func(s *mcp.Server, d *toolDeps) { registerExtractActionItemsTool(s, d.notes) }},
+	{name: "find_action_items", register: func(s *mcp.Server, d *toolDeps) { registerFindActionItemsTool(s, d.notes) }},
+	{name: "get_upcoming_deadlines", register: func(s *mcp.Server, d *toolDeps) { registerGetUpcomingDeadlinesTool(s, d.notes) }},
+	{name: "pin_note", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerPinNoteTool(s, d.notes) }},
+	{name: "add_note_tags", needs: services.HasNoteWriter, writes: true, tags: true, register: func(s *mcp.Server, d *toolDeps) { registerAddNoteTagsTool(s, d.notes) }},
+	{name: "generate_weekly_digest", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerGenerateWeeklyDigestTool(s, d.notes) }},
+	{name: "read_notes_bundle", needs: services.HasExporter, register: func(s *mcp.Server, d *toolDeps) { registerReadNotesBundleTool(s, d.notes) }},
+	{name: "find_stale_notes", register: func(s *mcp.Server, d *toolDeps) { registerFindStaleNotesTool(s, d.notes) }},
+	{name: "find_empty_notes", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerFindEmptyNotesTool(s, d.notes) }},
+	{name: "find_title_variants", needs: services.HasNoteWriter | services.HasAttachmentReader, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerFindTitleVariantsTool(s, d.notes) }},
+	{name: "merge_notes", needs: services.HasNoteWriter | services.HasAttachmentReader, writes: true,
+		register: func(s *mcp.Server, d *toolDeps) { registerMergeNotesTool(s, d.notes) }},
+	{name: "resolve_conflicts", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerResolveConflictsTool(s, d.notes) }},
+	{name: "check_links", register: func(s *mcp.Server, d *toolDeps) { registerCheckLinksTool(s, d.notes, d.linkChecker) }},
+	{name: "export_graph", register: func(s *mcp.Server, d *toolDeps) { registerExportGraphTool(s, d.notes) }},
+	{name: "get_note_properties", register: func(s *mcp.Server, d *toolDeps) { registerGetNotePropertiesTool(s, d.notes) }},
+	{name: "set_note_property", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerSetNotePropertyTool(s, d.notes) }},
+	{name: "mark_note_processed", needs: services.HasNoteWriter, writes: true, register: func(s *mcp.Server, d *toolDeps) { registerMarkNoteProcessedTool(s, d.notes) }},
+	{name: "list_unprocessed_notes", register: func(s *mcp.Server, d *toolDeps) { registerListUnprocessedNotesTool(s, d.notes) }},
+	{name: "share_note_to", needs: services.HasExporter, register: func(s *mcp.Server, d *toolDeps) { registerShareNoteToTool(s, d.notes) }},
+	{name: "set_root_folder", register: func(s *mcp.Server, d *toolDeps) { registerSetRootFolderTool(s, d.roots) }},
+	{name: "get_session_changes", register: func(s *mcp.Server, d *toolDeps) { registerGetSessionChangesTool(s, d.changes) }},
+	{name: "get_last_note", register: func(s *mcp.Server, d *toolDeps) { registerGetLastNoteTool(s, d.changes, d.notes) }},
+	{name: "bookmark_note", register: func(s *mcp.Server, d *toolDeps) { registerBookmarkNoteTool(s, d.notes, d.bookmarks) }},
+	{name: "list_bookmarks", register: func(s *mcp.Server, d *toolDeps) { registerListBookmarksTool(s, d.bookmarks) }},
+	{name: "set_folder_brief", needs: services.HasFolderManager, folders: true, register: func(s *mcp.Server, d *toolDeps) { registerSetFolderBriefTool(s, d.notes, d.briefs) }},
+	{name: "get_folder_brief", needs: services.HasFolderManager, folders: true, register: func(s *mcp.Server, d *toolDeps) { registerGetFolderBriefTool(s, d.notes, d.briefs) }},
+	{name: "health_check", register: func(s *mcp.Server, d *toolDeps) { registerHealthCheckTool(s, d.provider, d.permissions, d.metrics) }},
+
+	// Reminders integration is opt-in since it requires a separate Automation permission
+	{name: "create_reminder_from_note", needs: services.HasAppIntegrations, enabled: remindersEnabled,
+		register: func(s *mcp.Server, d *toolDeps) { registerCreateReminderFromNoteTool(s, d.notes) }},
+
+	// Transcription needs a Whisper endpoint or Speech helper to be configured
+	{name: "transcribe_attachment", needs: services.HasAttachmentReader | services.HasNoteWriter, writes: true, enabled: transcriptionEnabled,
+		register: func(s *mcp.Server, d *toolDeps) { registerTranscribeAttachmentTool(s, d.notes) }},
+}
+
+// registerTools registers the enabled tools in specs that the backend behind deps.notes can serve
+// Capabilities come from the provider; interfaces from what its backend implements. Tools named in
+// NOTES_MCP_DISABLED_TOOLS are left out whatever else allows them.
+func registerTools(server *mcp.Server, specs []toolSpec, deps *toolDeps, capabilities services.ProviderCapabilities) {
+	interfaces := services.InterfacesOf(deps.notes)
+	disabled := disabledTools(specs)
+	for _, spec := range specs {
+		if (spec.enabled == nil || spec.enabled()) && !disabled[spec.name] && !(spec.anyFile && deps.fileRoot != "") &&
+			spec.supportedBy(capabilities, interfaces) {
+			spec.register(server, deps)
+		}
+	}
+}
+
+// disabledTools parses NOTES_MCP_DISABLED_TOOLS; names of no tool in specs are logged and ignored
+func disabledTools(specs []toolSpec) map[string]bool {
+	known := map[string]bool{}
+	for _, spec := range specs {
+		known[spec.name] = true
+	}
+
+	disabled := map[string]bool{}
+	for _, name := range strings.Split(os.Getenv(disabledToolsEnvVar), ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case known[name]:
+			disabled[name] = true
+		default:
+			log.Printf("Ignoring unknown tool %q in %s", name, disabledToolsEnvVar)
+		}
+	}
+	return disabled
+}
@@ -0,0 +1,150 @@
+// ABOUTME: Unit tests for the declarative tool registry
+// ABOUTME: Tests spec names against registered tools, and capability, opt-in, and NOTES_MCP_DISABLED_TOOLS filtering
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newTestToolDeps returns tool dependencies backed by an in-memory notes service
+func newTestToolDeps(t *testing.T) *toolDeps {
+	t.Helper()
+	notes := services.NewMemoryNotesService()
+	return &toolDeps{
+		notes:       notes,
+		provider:    "memory",
+		roots:       newSessionRoots(),
+		changes:     newSessionChanges(),
+		bookmarks:   services.NewBookmarkStore(filepath.Join(t.TempDir(), "bookmarks.json")),
+		briefs:      services.NewBriefStore(filepath.Join(t.TempDir(), "briefs.json")),
+		linkChecker: services.NewHTTPLinkChecker(0),
+		permissions: newPermissionState(notes),
+	}
+}
+
+// listedToolNames returns the names of the tools a server offers
+func listedToolNames(t *testing.T, server *mcp.Server) []string {
+	t.Helper()
+	tools, err := connectTestClient(t, server).ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	names := []string{}
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// TestToolRegistryNames tests that each spec registers exactly the tool it is named for
+func TestToolRegistryNames(t *testing.T) {
+	deps := newTestToolDeps(t)
+	for _, spec := range toolRegistry {
+		server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+		spec.register(server, deps)
+		if names := listedToolNames(t, server); len(names) != 1 || names[0] != spec.name {
+			t.Errorf("spec %q registered %v", spec.name, names)
+		}
+	}
+}
+
+// TestRegisterTools tests that unsupported and disabled tools are left out
+func TestRegisterTools(t *testing.T) {
+	specs := []toolSpec{
+		{name: "get_note_content", register: func(s *mcp.Server, d *toolDeps) { registerGetNoteContentTool(s, d.notes) }},
+		{name: "delete_note", writes: true, register: func(s *mcp.Server, d *toolDeps) { registerDeleteNoteTool(s, d.notes) }},
+		{name: "list_folders", folders: true, register: func(s *mcp.Server, d *toolDeps) { registerListFoldersTool(s, d.notes) }},
+		{name: "open_note", enabled: func() bool { return false }, register: func(s *mcp.Server, d *toolDeps) { registerOpenNoteTool(s, d.notes) }},
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerTools(server, specs, newTestToolDeps(t), services.ProviderCapabilities{ReadOnly: true, SupportsFolders: true})
+	names := listedToolNames(t, server)
+	slices.Sort(names)
+	if strings.Join(names, ",") != "get_note_content,list_folders" {
+		t.Errorf("expected the write and disabled tools left out, got %v", names)
+	}
+}
+
+// TestRegisterToolsDisabled tests that NOTES_MCP_DISABLED_TOOLS leaves the named tools out
+func TestRegisterToolsDisabled(t *testing.T) {
+	t.Setenv(disabledToolsEnvVar, " delete_note, no_such_tool ,move_note")
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerTools(server, toolRegistry, newTestToolDeps(t), services.ProviderCapabilities{SupportsFolders: true, SupportsTags: true})
+	names := listedToolNames(t, server)
+
+	if slices.Contains(names, "delete_note") || slices.Contains(names, "move_note") {
+		t.Errorf("expected disabled tools left out, got %v", names)
+	}
+	if !slices.Contains(names, "create_note") {
+		t.Errorf("expected other tools still offered, got %v", names)
+	}
+}
+
+// TestRegisterToolsReadOnlyHidesDeletingTools tests that tools able to delete notes aren't offered read-only
+func TestRegisterToolsReadOnlyHidesDeletingTools(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerTools(server, toolRegistry, newTestToolDeps(t), services.ProviderCapabilities{ReadOnly: true, SupportsFolders: true, SupportsTags: true})
+	names := listedToolNames(t, server)
+
+	for _, unwanted := range []string{"delete_note", "find_empty_notes", "find_title_variants", "resolve_conflicts"} {
+		if slices.Contains(names, unwanted) {
+			t.Errorf("expected %s left out in read-only mode", unwanted)
+		}
+	}
+	if !slices.Contains(names, "find_stale_notes") {
+		t.Errorf("expected reading tools still offered, got %v", names)
+	}
+}
+
+// TestRegisterToolsConfinedFiles tests that tools reading any local file are left out when files are confined
+func TestRegisterToolsConfinedFiles(t *testing.T) {
+	deps := newTestToolDeps(t)
+	deps.fileRoot = t.TempDir()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerTools(server, toolRegistry, deps, services.ProviderCapabilities{SupportsFolders: true, SupportsTags: true})
+	names := listedToolNames(t, server)
+
+	if slices.Contains(names, "import_html") {
+		t.Errorf("expected import_html left out, got %v", names)
+	}
+	if !slices.Contains(names, "get_attachment_content") {
+		t.Errorf("expected get_attachment_content still offered, got %v", names)
+	}
+}
+
+// readOnlyBackend is a notes backend implementing only services.NoteReader
+type readOnlyBackend struct {
+	services.NoteReader
+}
+
+// TestRegisterToolsByBackendInterfaces tests that tools needing interfaces a backend lacks are left out
+func TestRegisterToolsByBackendInterfaces(t *testing.T) {
+	deps := newTestToolDeps(t)
+	deps.notes = services.ComposeNotesService(readOnlyBackend{services.NewMemoryNotesService()})
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerTools(server, toolRegistry, deps, services.ProviderCapabilities{SupportsFolders: true, SupportsTags: true})
+	names := listedToolNames(t, server)
+
+	for _, want := range []string{"search_notes", "get_note_content", "find_stale_notes", "health_check"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("expected reader tool %s, got %v", want, names)
+		}
+	}
+	for _, unwanted := range []string{"create_note", "list_folders", "get_note_attachments", "export_note_markdown", "open_note"} {
+		if slices.Contains(names, unwanted) {
+			t.Errorf("expected %s left out for a reader-only backend", unwanted)
+		}
+	}
+}
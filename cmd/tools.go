@@ -0,0 +1,201 @@
+// ABOUTME: Tools command describing the MCP tools the server registers, with their input schemas
+// ABOUTME: Lists tools from an in-process server so the output matches what an agent sees, as JSON or markdown
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+// Tool description output formats
+const (
+	toolsFormatJSON     = "json"
+	toolsFormatMarkdown = "markdown"
+)
+
+var toolsDescribeFormat string
+
+// toolDescription is one tool in the tools describe output
+type toolDescription struct {
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	InputSchema  map[string]any `json:"input_schema"`
+	OutputSchema map[string]any `json:"output_schema,omitempty"`
+}
+
+var toolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "Inspect the MCP tools the server offers",
+}
+
+var toolsDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Print every MCP tool with its description and input schema",
+	Long: `Prints the name, description, and input schema of every tool the MCP server registers, read from
+a server built in-process with the current configuration. Tools that depend on settings, such as
+create_reminder_from_note (NOTES_MCP_REMINDERS) or those a read-only provider drops, appear only
+when the MCP server would offer them.
+
+--format=json (default) prints an array suitable for generating client configs; --format=markdown
+prints a reference with one section and argument table per tool.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if toolsDescribeFormat != toolsFormatJSON && toolsDescribeFormat != toolsFormatMarkdown {
+			return fmt.Errorf("%w: unknown format %q (use json or markdown)", services.ErrInvalidInput, toolsDescribeFormat)
+		}
+
+		ctx, cancel := newCommandContext()
+		defer cancel()
+
+		tools, err := describeTools(ctx, newMCPServer(""))
+		if err != nil {
+			return err
+		}
+
+		if toolsDescribeFormat == toolsFormatMarkdown {
+			printToolsMarkdown(cmd.OutOrStdout(), tools)
+			return nil
+		}
+		output, err := json.MarshalIndent(tools, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format tools: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+		return nil
+	},
+}
+
+// describeTools lists a server's tools through an in-memory client session, as an agent would see them
+func describeTools(ctx context.Context, server *mcp.Server) ([]toolDescription, error) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start server: %w", err)
+	}
+	defer serverSession.Close() //nolint:errcheck // in-memory session close failure is non-critical
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "notes-mcp tools", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer session.Close() //nolint:errcheck // in-memory session close failure is non-critical
+
+	tools := []toolDescription{}
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		description := toolDescription{Name: tool.Name, Description: tool.Description}
+		if description.InputSchema, err = schemaMap(tool.InputSchema); err != nil {
+			return nil, err
+		}
+		if tool.OutputSchema != nil {
+			if description.OutputSchema, err = schemaMap(tool.OutputSchema); err != nil {
+				return nil, err
+			}
+		}
+		tools = append(tools, description)
+	}
+	return tools, nil
+}
+
+// schemaMap converts a tool schema, whatever its Go type, into plain JSON values
+func schemaMap(schema any) (map[string]any, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool schema: %w", err)
+	}
+	result := map[string]any{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to read tool schema: %w", err)
+	}
+	return result, nil
+}
+
+// printToolsMarkdown writes a reference section per tool with a table of its arguments
+func printToolsMarkdown(w io.Writer, tools []toolDescription) {
+	fmt.Fprintf(w, "# notes-mcp tools\n\n%d tools.\n", len(tools)) //nolint:errcheck // stdout write failure is non-critical
+	for _, tool := range tools {
+		fmt.Fprintf(w, "\n## %s\n\n%s\n\n", tool.Name, tool.Description) //nolint:errcheck // stdout write failure is non-critical
+
+		properties, _ := tool.InputSchema["properties"].(map[string]any)
+		if len(properties) == 0 {
+			fmt.Fprintln(w, "No arguments.") //nolint:errcheck // stdout write failure is non-critical
+			continue
+		}
+		required := map[string]bool{}
+		if names, ok := tool.InputSchema["required"].([]any); ok {
+			for _, name := range names {
+				if s, ok := name.(string); ok {
+					required[s] = true
+				}
+			}
+		}
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		// Required arguments first, each group alphabetical
+		sort.Slice(names, func(i, j int) bool {
+			if required[names[i]] != required[names[j]] {
+				return required[names[i]]
+			}
+			return names[i] < names[j]
+		})
+
+		fmt.Fprintln(w, "| Argument | Type | Required | Description |") //nolint:errcheck // stdout write failure is non-critical
+		fmt.Fprintln(w, "|---|---|---|---|")                            //nolint:errcheck // stdout write failure is non-critical
+		for _, name := range names {
+			property, _ := properties[name].(map[string]any)
+			description, _ := property["description"].(string)
+			requiredCell := "no"
+			if required[name] {
+				requiredCell = "yes"
+			}
+			fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n", name, schemaType(property), requiredCell, //nolint:errcheck // stdout write failure is non-critical
+				strings.ReplaceAll(description, "|", `\|`))
+		}
+	}
+}
+
+// schemaType names a property's JSON type, such as "string", "array of string", or "integer or null"
+func schemaType(property map[string]any) string {
+	var types []string
+	switch t := property["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return "any"
+	}
+
+	for i, t := range types {
+		if items, ok := property["items"].(map[string]any); ok && t == "array" {
+			types[i] = "array of " + schemaType(items)
+		}
+	}
+	return strings.Join(types, " or ")
+}
+
+func init() {
+	rootCmd.AddCommand(toolsCmd)
+	toolsCmd.AddCommand(toolsDescribeCmd)
+
+	toolsDescribeCmd.Flags().StringVar(&toolsDescribeFormat, "format", toolsFormatJSON, "Output format: json or markdown")
+}
@@ -0,0 +1,74 @@
+// ABOUTME: Unit tests for the tools describe command
+// ABOUTME: Tests listing the server's tools with schemas and the markdown argument tables
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestDescribeTools tests that every registered tool is described with its input schema
+func TestDescribeTools(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(providerEnvVar, "memory")
+
+	tools, err := describeTools(context.Background(), newMCPServer(""))
+	if err != nil {
+		t.Fatalf("describeTools failed: %v", err)
+	}
+	if len(tools) < 30 {
+		t.Fatalf("expected every tool, got %d", len(tools))
+	}
+
+	var createNote *toolDescription
+	for i := range tools {
+		if tools[i].Name == "create_note" {
+			createNote = &tools[i]
+		}
+		if tools[i].Description == "" || tools[i].InputSchema["type"] != "object" {
+			t.Errorf("expected a description and object schema for %s, got %+v", tools[i].Name, tools[i])
+		}
+	}
+	if createNote == nil {
+		t.Fatal("expected create_note to be described")
+	}
+	if properties, _ := createNote.InputSchema["properties"].(map[string]any); properties["title"] == nil {
+		t.Errorf("expected create_note's title argument, got %v", createNote.InputSchema)
+	}
+}
+
+// TestPrintToolsMarkdown tests the argument table, required arguments first
+func TestPrintToolsMarkdown(t *testing.T) {
+	tools := []toolDescription{
+		{
+			Name:        "tag_note",
+			Description: "Adds tags.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title": map[string]any{"type": "string", "description": "Note title"},
+					"tags":  map[string]any{"type": []any{"null", "array"}, "items": map[string]any{"type": "string"}, "description": "Tags | labels"},
+				},
+				"required": []any{"title"},
+			},
+		},
+		{Name: "ping", Description: "Checks health.", InputSchema: map[string]any{"type": "object"}},
+	}
+
+	var out bytes.Buffer
+	printToolsMarkdown(&out, tools)
+	text := out.String()
+	for _, want := range []string{
+		"# notes-mcp tools\n\n2 tools.\n",
+		"## tag_note\n\nAdds tags.\n",
+		"| `title` | string | yes | Note title |\n| `tags` | null or array of string | no | Tags \\| labels |\n",
+		"## ping\n\nChecks health.\n\nNo arguments.\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in:\n%s", want, text)
+		}
+	}
+}
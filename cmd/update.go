@@ -5,19 +5,48 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/harper/notes-mcp/services"
+	"github.com/harper/notes-mcp/validation"
 	"github.com/spf13/cobra"
 )
 
+var (
+	updateExpectedModified string
+	updateExpectedHash     string
+	updateContentType      string
+)
+
 var updateCmd = &cobra.Command{
 	Use:   "update <title> <content>",
 	Short: "Update an existing note in Apple Notes",
-	Long:  `Updates the content of an existing note in Apple Notes identified by its title.`,
-	Args:  cobra.ExactArgs(2),
+	Long: `Updates the content of an existing note in Apple Notes identified by its title.
+Use --expected-modified (RFC3339) or --expected-hash (SHA-256 of the note body) to refuse the update if the note changed since it was read.
+Use --content-type markdown or html to write formatted content instead of plain text.`,
+	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		title := args[0]
 		content := args[1]
 
+		if err := validation.Check(validation.Title("title", title), validation.Content("content", content)); err != nil {
+			return err
+		}
+		content, err := services.FormatNoteBody(content, updateContentType)
+		if err != nil {
+			return err
+		}
+
+		// Parse the optional concurrency precondition
+		precondition := services.UpdatePrecondition{ExpectedHash: updateExpectedHash}
+		if updateExpectedModified != "" {
+			expected, err := time.Parse(time.RFC3339, updateExpectedModified)
+			if err != nil {
+				return fmt.Errorf("invalid expected-modified format (use RFC3339): %w", err)
+			}
+			precondition.ExpectedModified = &expected
+		}
+
 		// Create service with real executor
 		notesService := newNotesService()
 
@@ -25,18 +54,27 @@ var updateCmd = &cobra.Command{
 		ctx, cancel := newCommandContext()
 		defer cancel()
 
-		// Update the note
-		err := notesService.UpdateNote(ctx, title, content)
+		// Update the note, checking the precondition first if one was given
+		if precondition.ExpectedModified != nil || precondition.ExpectedHash != "" {
+			err = notesService.UpdateNoteIfUnchanged(ctx, title, content, precondition)
+		} else {
+			err = notesService.UpdateNote(ctx, title, content)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to update note: %w", err)
 		}
 
 		// Output success message
-		fmt.Printf("Note updated: %s\n", title)
+		printSuccess(cmd.OutOrStdout(), "Note updated: %s", title)
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(updateCmd)
+
+	// Add flags
+	updateCmd.Flags().StringVar(&updateExpectedModified, "expected-modified", "", "Only update if the note's modification date matches (RFC3339)")
+	updateCmd.Flags().StringVar(&updateExpectedHash, "expected-hash", "", "Only update if the SHA-256 of the note body matches")
+	updateCmd.Flags().StringVar(&updateContentType, "content-type", services.ContentTypePlain, "How to read the content: plain, markdown, or html")
 }
@@ -30,6 +30,11 @@ func TestUpdateCommandArgs(t *testing.T) {
 			args:        []string{"update", "title", "content", "extra"},
 			expectError: true,
 		},
+		{
+			name:        "invalid expected-modified",
+			args:        []string{"update", "title", "content", "--expected-modified", "yesterday"},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -52,6 +57,7 @@ func TestUpdateCommandArgs(t *testing.T) {
 
 			// Reset for next test
 			rootCmd.SetArgs([]string{})
+			updateExpectedModified = ""
 		})
 	}
 }
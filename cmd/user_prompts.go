@@ -0,0 +1,226 @@
+// ABOUTME: User-defined MCP prompts loaded from template files at server start
+// ABOUTME: Parses ~/.config/notes-mcp/prompts/*.tmpl headers and renders bodies with text/template
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// promptsDirEnvVar overrides the directory user prompt templates are loaded from
+const promptsDirEnvVar = "NOTES_MCP_PROMPTS_DIR"
+
+// promptHeaderSeparator ends the metadata header of a prompt template
+const promptHeaderSeparator = "---"
+
+// userPrompt is a prompt defined by a template file
+type userPrompt struct {
+	prompt   *mcp.Prompt
+	template *template.Template
+}
+
+// promptTemplateData is the data available to prompt templates
+type promptTemplateData struct {
+	Today     string
+	WeekStart string
+	Now       time.Time
+	Args      map[string]string
+}
+
+// userPromptsDir returns the prompt template directory: NOTES_MCP_PROMPTS_DIR or ~/.config/notes-mcp/prompts
+func userPromptsDir() string {
+	if dir := os.Getenv(promptsDirEnvVar); dir != "" {
+		return dir
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "notes-mcp", "prompts")
+}
+
+// loadUserPrompts parses every *.tmpl file in dir, in name order
+// A missing directory yields no prompts; invalid files are logged and skipped so one typo
+// never prevents the server from starting.
+func loadUserPrompts(dir string) []*userPrompt {
+	if dir == "" {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		log.Printf("Ignoring prompt templates in %s: %v", dir, err)
+		return nil
+	}
+	sort.Strings(paths)
+
+	prompts := []*userPrompt{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Ignoring prompt template %s: %v", path, err)
+			continue
+		}
+
+		prompt, err := parsePromptTemplate(strings.TrimSuffix(filepath.Base(path), ".tmpl"), string(data))
+		if err != nil {
+			log.Printf("Ignoring prompt template %s: %v", path, err)
+			continue
+		}
+		prompts = append(prompts, prompt)
+	}
+
+	return prompts
+}
+
+// parsePromptTemplate parses a prompt template file
+// The optional header holds "name:", "description:", and "argument: name | description | required"
+// lines and ends at a "---" line; without a header the whole file is the body. The name defaults
+// to the file name.
+func parsePromptTemplate(defaultName, content string) (*userPrompt, error) {
+	prompt := &mcp.Prompt{Name: defaultName, Arguments: []*mcp.PromptArgument{}}
+	body := content
+
+	if header, rest, found := splitPromptHeader(content); found {
+		body = rest
+		for i, line := range strings.Split(header, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				return nil, fmt.Errorf("header line %d: expected 'key: value'", i+1)
+			}
+			value = strings.TrimSpace(value)
+
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "name":
+				prompt.Name = value
+			case "description":
+				prompt.Description = value
+			case "argument":
+				argument, err := parsePromptArgument(value)
+				if err != nil {
+					return nil, fmt.Errorf("header line %d: %w", i+1, err)
+				}
+				prompt.Arguments = append(prompt.Arguments, argument)
+			default:
+				return nil, fmt.Errorf("header line %d: unknown key %q", i+1, strings.TrimSpace(key))
+			}
+		}
+	}
+
+	if prompt.Name == "" {
+		return nil, fmt.Errorf("prompt name is empty")
+	}
+
+	tmpl, err := template.New(prompt.Name).Option("missingkey=zero").Parse(strings.TrimSpace(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return &userPrompt{prompt: prompt, template: tmpl}, nil
+}
+
+// splitPromptHeader splits content at the first "---" line
+func splitPromptHeader(content string) (header, body string, found bool) {
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == promptHeaderSeparator {
+			return strings.Join(lines[:i], ""), strings.Join(lines[i+1:], ""), true
+		}
+	}
+	return "", content, false
+}
+
+// parsePromptArgument parses "name | description | required"; the last two parts are optional
+func parsePromptArgument(value string) (*mcp.PromptArgument, error) {
+	parts := strings.Split(value, "|")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	argument := &mcp.PromptArgument{Name: parts[0]}
+	if argument.Name == "" {
+		return nil, fmt.Errorf("argument name is empty")
+	}
+	if len(parts) > 1 {
+		argument.Description = parts[1]
+	}
+	if len(parts) > 2 {
+		if !strings.EqualFold(parts[2], "required") && parts[2] != "" {
+			return nil, fmt.Errorf("argument %q: expected 'required', got %q", argument.Name, parts[2])
+		}
+		argument.Required = strings.EqualFold(parts[2], "required")
+	}
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("argument %q: too many '|' separated parts", argument.Name)
+	}
+	return argument, nil
+}
+
+// render executes the prompt template with the request's arguments
+func (p *userPrompt) render(args map[string]string, now time.Time) (string, error) {
+	for _, argument := range p.prompt.Arguments {
+		if argument.Required && args[argument.Name] == "" {
+			return "", fmt.Errorf("%w: argument %q is required", services.ErrInvalidInput, argument.Name)
+		}
+	}
+	if args == nil {
+		args = map[string]string{}
+	}
+
+	var out bytes.Buffer
+	err := p.template.Execute(&out, promptTemplateData{
+		Today:     now.Format("2006-01-02"),
+		WeekStart: now.AddDate(0, 0, -6).Format("2006-01-02"),
+		Now:       now,
+		Args:      args,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt %s: %w", p.prompt.Name, err)
+	}
+	return out.String(), nil
+}
+
+// registerUserPrompts adds user prompts to the server, replacing built-in prompts with the same name
+func registerUserPrompts(server *mcp.Server, prompts []*userPrompt) {
+	for _, p := range prompts {
+		handler := func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			text, err := p.render(req.Params.Arguments, time.Now())
+			if err != nil {
+				return nil, err
+			}
+
+			return &mcp.GetPromptResult{
+				Description: p.prompt.Description,
+				Messages: []*mcp.PromptMessage{
+					{
+						Role: "user",
+						Content: &mcp.TextContent{
+							Text: text,
+						},
+					},
+				},
+			}, nil
+		}
+
+		server.AddPrompt(p.prompt, handler)
+		log.Printf("Loaded prompt template %s", p.prompt.Name)
+	}
+}
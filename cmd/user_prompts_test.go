@@ -0,0 +1,176 @@
+// ABOUTME: Tests for user prompt templates
+// ABOUTME: Covers header parsing, rendering, directory loading, and overriding built-in prompts
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestParsePromptTemplate tests reading the metadata header and defaulting the name
+func TestParsePromptTemplate(t *testing.T) {
+	content := `# Standup prep
+description: Prepare for standup
+argument: team | Team to focus on | required
+argument: days
+---
+Review {{.Args.team}} notes since {{.WeekStart}}.
+`
+
+	p, err := parsePromptTemplate("standup", content)
+	if err != nil {
+		t.Fatalf("parsePromptTemplate failed: %v", err)
+	}
+	if p.prompt.Name != "standup" {
+		t.Errorf("Name = %q, want file name %q", p.prompt.Name, "standup")
+	}
+	if p.prompt.Description != "Prepare for standup" {
+		t.Errorf("Description = %q", p.prompt.Description)
+	}
+	if len(p.prompt.Arguments) != 2 {
+		t.Fatalf("got %d arguments, want 2", len(p.prompt.Arguments))
+	}
+	if team := p.prompt.Arguments[0]; team.Name != "team" || team.Description != "Team to focus on" || !team.Required {
+		t.Errorf("team argument = %+v", team)
+	}
+	if days := p.prompt.Arguments[1]; days.Name != "days" || days.Required {
+		t.Errorf("days argument = %+v", days)
+	}
+
+	named, err := parsePromptTemplate("file", "name: renamed\n---\nBody")
+	if err != nil {
+		t.Fatalf("parsePromptTemplate failed: %v", err)
+	}
+	if named.prompt.Name != "renamed" {
+		t.Errorf("Name = %q, want %q", named.prompt.Name, "renamed")
+	}
+
+	plain, err := parsePromptTemplate("plain", "No header here")
+	if err != nil {
+		t.Fatalf("parsePromptTemplate failed: %v", err)
+	}
+	if text, _ := plain.render(nil, time.Now()); text != "No header here" {
+		t.Errorf("render = %q", text)
+	}
+}
+
+// TestParsePromptTemplateInvalid tests that malformed headers and templates are rejected
+func TestParsePromptTemplateInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing colon":    "just words\n---\nBody",
+		"unknown key":      "title: Standup\n---\nBody",
+		"empty argument":   "argument: | desc\n---\nBody",
+		"bad required":     "argument: team | desc | maybe\n---\nBody",
+		"too many parts":   "argument: a | b | required | c\n---\nBody",
+		"empty name":       "name:\n---\nBody",
+		"template syntax":  "---\n{{.Args.team",
+		"unknown function": "---\n{{shout .Today}}",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parsePromptTemplate("prompt", content); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// TestUserPromptRender tests template data and required argument checks
+func TestUserPromptRender(t *testing.T) {
+	p, err := parsePromptTemplate("review", "argument: topic | | required\n---\n{{.Today}} {{.WeekStart}} {{.Args.topic}}{{.Args.missing}}")
+	if err != nil {
+		t.Fatalf("parsePromptTemplate failed: %v", err)
+	}
+
+	now := time.Date(2024, 6, 26, 9, 0, 0, 0, time.UTC)
+	text, err := p.render(map[string]string{"topic": "launch"}, now)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if text != "2024-06-26 2024-06-20 launch" {
+		t.Errorf("render = %q", text)
+	}
+
+	if _, err := p.render(map[string]string{}, now); !errors.Is(err, services.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for missing argument, got %v", err)
+	}
+}
+
+// TestLoadUserPrompts tests loading templates in name order and skipping invalid files
+func TestLoadUserPrompts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b-standup.tmpl": "description: Standup\n---\nStandup",
+		"a-retro.tmpl":   "Retro",
+		"broken.tmpl":    "oops: true\n---\nBody",
+		"notes.txt":      "not a template",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prompts := loadUserPrompts(dir)
+	names := []string{}
+	for _, p := range prompts {
+		names = append(names, p.prompt.Name)
+	}
+	if strings.Join(names, ",") != "a-retro,b-standup" {
+		t.Errorf("loaded %v, want [a-retro b-standup]", names)
+	}
+
+	if got := loadUserPrompts(filepath.Join(dir, "missing")); len(got) != 0 {
+		t.Errorf("expected no prompts from a missing directory, got %d", len(got))
+	}
+}
+
+// TestUserPromptsDir tests the environment override
+func TestUserPromptsDir(t *testing.T) {
+	t.Setenv(promptsDirEnvVar, "/tmp/my-prompts")
+	if dir := userPromptsDir(); dir != "/tmp/my-prompts" {
+		t.Errorf("userPromptsDir = %q", dir)
+	}
+
+	t.Setenv(promptsDirEnvVar, "")
+	if dir := userPromptsDir(); !strings.HasSuffix(dir, filepath.Join(".config", "notes-mcp", "prompts")) {
+		t.Errorf("userPromptsDir = %q", dir)
+	}
+}
+
+// TestRegisterUserPromptsOverridesBuiltin tests that a template replaces the built-in prompt of the same name
+func TestRegisterUserPromptsOverridesBuiltin(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	registerPrompts(server, &mockNotesService{})
+
+	custom, err := parsePromptTemplate("daily-review", "description: My review\n---\nWhat did I write on {{.Today}}?")
+	if err != nil {
+		t.Fatalf("parsePromptTemplate failed: %v", err)
+	}
+	registerUserPrompts(server, []*userPrompt{custom})
+
+	session := connectTestClient(t, server)
+	result, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{Name: "daily-review"})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	if result.Description != "My review" {
+		t.Errorf("Description = %q", result.Description)
+	}
+
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	want := "What did I write on " + time.Now().Format("2006-01-02") + "?"
+	if text != want {
+		t.Errorf("prompt text = %q, want %q", text, want)
+	}
+}
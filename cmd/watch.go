@@ -0,0 +1,144 @@
+// ABOUTME: Watch command that polls Apple Notes and reports note changes
+// ABOUTME: Prints changes as JSON lines, optionally sending signed webhook notifications and updating the search index
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/harper/notes-mcp/services"
+	"github.com/spf13/cobra"
+)
+
+// Environment variables providing webhook defaults so the secret stays out of the process list
+const (
+	webhookURLEnvVar    = "NOTES_MCP_WEBHOOK_URL"
+	webhookSecretEnvVar = "NOTES_MCP_WEBHOOK_SECRET"
+)
+
+var (
+	watchInterval      time.Duration
+	watchWebhookURL    string
+	watchFolder        string
+	watchTitleContains string
+	watchEvents        string
+	watchUpdateIndex   bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch Apple Notes for changes and send webhook notifications",
+	Long: `Polls Apple Notes at a fixed interval and prints each created, modified, or deleted note as a JSON line.
+With --webhook-url (or NOTES_MCP_WEBHOOK_URL), matching changes are POSTed as JSON. If NOTES_MCP_WEBHOOK_SECRET is set,
+each request carries an X-Notes-MCP-Signature header containing "sha256=" and the hex HMAC-SHA256 of the body.
+With --update-index, each detected change is applied to the full-text search index ("notes-mcp index"),
+reading only the notes that changed. Use NOTES_MCP_TIMEOUT to raise the per-poll timeout for large libraries.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if watchInterval < time.Second {
+			return fmt.Errorf("%w: interval must be at least 1s", services.ErrInvalidInput)
+		}
+
+		events, err := parseWatchEvents(watchEvents)
+		if err != nil {
+			return err
+		}
+
+		webhookURL := watchWebhookURL
+		if webhookURL == "" {
+			webhookURL = os.Getenv(webhookURLEnvVar)
+		}
+
+		var notifier *services.WebhookNotifier
+		if webhookURL != "" {
+			notifier = services.NewWebhookNotifier(services.WebhookConfig{
+				URL:           webhookURL,
+				Secret:        os.Getenv(webhookSecretEnvVar),
+				Folder:        watchFolder,
+				TitleContains: watchTitleContains,
+				Events:        events,
+			})
+		}
+
+		var index *services.SearchIndex
+		if watchUpdateIndex {
+			if index, err = openSearchIndex(); err != nil {
+				return err
+			}
+		}
+
+		// Listing every note can take a while, so each poll uses the operation timeout
+		executor := newScriptExecutor(getOperationTimeout())
+		notesService := services.NewAppleNotesService(executor)
+
+		// Run until interrupted
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		watcher := services.NewWatcher(notesService, watchInterval,
+			func(ctx context.Context, changes []services.NoteChange) {
+				for _, change := range changes {
+					if err := encoder.Encode(change); err != nil {
+						log.Printf("Failed to write change: %v", err)
+					}
+				}
+				if notifier != nil {
+					if err := notifier.Notify(ctx, changes); err != nil {
+						log.Printf("Webhook notification failed: %v", err)
+					}
+				}
+				if index != nil {
+					if _, err := index.ApplyChanges(ctx, notesService, changes); err != nil {
+						log.Printf("Search index update failed: %v", err)
+					}
+				}
+			},
+			func(err error) {
+				log.Printf("Failed to poll notes: %v", err)
+			})
+
+		if err := watcher.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("watcher stopped: %w", err)
+		}
+		return nil
+	},
+}
+
+// parseWatchEvents parses a comma-separated list of change types
+func parseWatchEvents(value string) ([]string, error) {
+	events := []string{}
+	for _, event := range strings.Split(value, ",") {
+		event = strings.TrimSpace(strings.ToLower(event))
+		if event == "" {
+			continue
+		}
+		switch event {
+		case services.ChangeCreated, services.ChangeModified, services.ChangeDeleted:
+			events = append(events, event)
+		default:
+			return nil, fmt.Errorf("%w: unknown event %q (use created, modified, deleted)", services.ErrInvalidInput, event)
+		}
+	}
+	return events, nil
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	// Add flags
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Minute, "How often to poll Apple Notes")
+	watchCmd.Flags().StringVar(&watchWebhookURL, "webhook-url", "", "URL to POST change notifications to")
+	watchCmd.Flags().StringVar(&watchFolder, "folder", "", "Only notify for notes in this folder")
+	watchCmd.Flags().StringVar(&watchTitleContains, "title-contains", "", "Only notify for notes whose title contains this text")
+	watchCmd.Flags().BoolVar(&watchUpdateIndex, "update-index", false, "Apply detected changes to the full-text search index")
+	watchCmd.Flags().StringVar(&watchEvents, "events", "created,modified", "Comma-separated change types to notify: created, modified, deleted")
+}
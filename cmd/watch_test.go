@@ -0,0 +1,52 @@
+// ABOUTME: Unit tests for the watch command
+// ABOUTME: Tests flag validation and event list parsing
+
+package cmd
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// TestWatchCommandArgs tests that the watch command rejects invalid flags before polling
+func TestWatchCommandArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unexpected argument", args: []string{"watch", "extra"}},
+		{name: "interval too short", args: []string{"watch", "--interval", "10ms"}},
+		{name: "unknown event", args: []string{"watch", "--events", "created,renamed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up command
+			rootCmd.SetArgs(tt.args)
+
+			// Silence output
+			rootCmd.SetOut(io.Discard)
+			rootCmd.SetErr(io.Discard)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("expected error but got nil")
+			}
+
+			// Reset for next test
+			rootCmd.SetArgs([]string{})
+			watchInterval = time.Minute
+			watchEvents = "created,modified"
+		})
+	}
+}
+
+func TestParseWatchEvents(t *testing.T) {
+	events, err := parseWatchEvents(" Created, deleted ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0] != "created" || events[1] != "deleted" {
+		t.Errorf("unexpected events: %v", events)
+	}
+}
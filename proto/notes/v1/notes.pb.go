@@ -0,0 +1,2119 @@
+// ABOUTME: gRPC service definition for Apple Notes operations
+// ABOUTME: Mirrors services.NotesService so local tools can integrate without MCP or the CLI
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/notes/v1/notes.proto
+
+package notesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchIn int32
+
+const (
+	SearchIn_SEARCH_IN_UNSPECIFIED SearchIn = 0 // defaults to title
+	SearchIn_SEARCH_IN_TITLE       SearchIn = 1
+	SearchIn_SEARCH_IN_BODY        SearchIn = 2
+	SearchIn_SEARCH_IN_BOTH        SearchIn = 3
+)
+
+// Enum value maps for SearchIn.
+var (
+	SearchIn_name = map[int32]string{
+		0: "SEARCH_IN_UNSPECIFIED",
+		1: "SEARCH_IN_TITLE",
+		2: "SEARCH_IN_BODY",
+		3: "SEARCH_IN_BOTH",
+	}
+	SearchIn_value = map[string]int32{
+		"SEARCH_IN_UNSPECIFIED": 0,
+		"SEARCH_IN_TITLE":       1,
+		"SEARCH_IN_BODY":        2,
+		"SEARCH_IN_BOTH":        3,
+	}
+)
+
+func (x SearchIn) Enum() *SearchIn {
+	p := new(SearchIn)
+	*p = x
+	return p
+}
+
+func (x SearchIn) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchIn) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_notes_v1_notes_proto_enumTypes[0].Descriptor()
+}
+
+func (SearchIn) Type() protoreflect.EnumType {
+	return &file_proto_notes_v1_notes_proto_enumTypes[0]
+}
+
+func (x SearchIn) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchIn.Descriptor instead.
+func (SearchIn) EnumDescriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{0}
+}
+
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0 // defaults to markdown
+	ExportFormat_EXPORT_FORMAT_MARKDOWN    ExportFormat = 1
+	ExportFormat_EXPORT_FORMAT_TEXT        ExportFormat = 2
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_MARKDOWN",
+		2: "EXPORT_FORMAT_TEXT",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_MARKDOWN":    1,
+		"EXPORT_FORMAT_TEXT":        2,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_notes_v1_notes_proto_enumTypes[1].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_proto_notes_v1_notes_proto_enumTypes[1]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{1}
+}
+
+type Note struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title             string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content           string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Tags              []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Created           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	Modified          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=modified,proto3" json:"modified,omitempty"`
+	Folder            string                 `protobuf:"bytes,7,opt,name=folder,proto3" json:"folder,omitempty"`
+	Shared            bool                   `protobuf:"varint,8,opt,name=shared,proto3" json:"shared,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,9,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	ContentHash       string                 `protobuf:"bytes,10,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"` // hex SHA-256 of content; set by GetNote
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Note) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Note) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Note) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Note) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Note) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Note) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+func (x *Note) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *Note) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Note) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *Note) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type NoteList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteList) Reset() {
+	*x = NoteList{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteList) ProtoMessage() {}
+
+func (x *NoteList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteList.ProtoReflect.Descriptor instead.
+func (*NoteList) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NoteList) GetNotes() []*Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+type Attachment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	FilePath          string                 `protobuf:"bytes,3,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	ContentIdentifier string                 `protobuf:"bytes,4,opt,name=content_identifier,json=contentIdentifier,proto3" json:"content_identifier,omitempty"`
+	Created           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	Modified          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentIdentifier() string {
+	if x != nil {
+		return x.ContentIdentifier
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Attachment) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+type FolderNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Shared        bool                   `protobuf:"varint,2,opt,name=shared,proto3" json:"shared,omitempty"`
+	NoteCount     int32                  `protobuf:"varint,3,opt,name=note_count,json=noteCount,proto3" json:"note_count,omitempty"`
+	Children      []*FolderNode          `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FolderNode) Reset() {
+	*x = FolderNode{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FolderNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FolderNode) ProtoMessage() {}
+
+func (x *FolderNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FolderNode.ProtoReflect.Descriptor instead.
+func (*FolderNode) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FolderNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FolderNode) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *FolderNode) GetNoteCount() int32 {
+	if x != nil {
+		return x.NoteCount
+	}
+	return 0
+}
+
+func (x *FolderNode) GetChildren() []*FolderNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type ActionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Done          bool                   `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	SourceNote    string                 `protobuf:"bytes,3,opt,name=source_note,json=sourceNote,proto3" json:"source_note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionItem) Reset() {
+	*x = ActionItem{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionItem) ProtoMessage() {}
+
+func (x *ActionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionItem.ProtoReflect.Descriptor instead.
+func (*ActionItem) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ActionItem) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ActionItem) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ActionItem) GetSourceNote() string {
+	if x != nil {
+		return x.SourceNote
+	}
+	return ""
+}
+
+type CreateNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNoteRequest) Reset() {
+	*x = CreateNoteRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNoteRequest) ProtoMessage() {}
+
+func (x *CreateNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNoteRequest.ProtoReflect.Descriptor instead.
+func (*CreateNoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateNoteRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreateNoteRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type SearchNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchNotesRequest) Reset() {
+	*x = SearchNotesRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchNotesRequest) ProtoMessage() {}
+
+func (x *SearchNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchNotesRequest.ProtoReflect.Descriptor instead.
+func (*SearchNotesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchNotesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type SearchNotesAdvancedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	SearchIn      SearchIn               `protobuf:"varint,2,opt,name=search_in,json=searchIn,proto3,enum=notes.v1.SearchIn" json:"search_in,omitempty"`
+	Folder        string                 `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"`
+	DateFrom      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date_from,json=dateFrom,proto3" json:"date_from,omitempty"`
+	DateTo        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=date_to,json=dateTo,proto3" json:"date_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchNotesAdvancedRequest) Reset() {
+	*x = SearchNotesAdvancedRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchNotesAdvancedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchNotesAdvancedRequest) ProtoMessage() {}
+
+func (x *SearchNotesAdvancedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchNotesAdvancedRequest.ProtoReflect.Descriptor instead.
+func (*SearchNotesAdvancedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchNotesAdvancedRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchNotesAdvancedRequest) GetSearchIn() SearchIn {
+	if x != nil {
+		return x.SearchIn
+	}
+	return SearchIn_SEARCH_IN_UNSPECIFIED
+}
+
+func (x *SearchNotesAdvancedRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *SearchNotesAdvancedRequest) GetDateFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateFrom
+	}
+	return nil
+}
+
+func (x *SearchNotesAdvancedRequest) GetDateTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTo
+	}
+	return nil
+}
+
+type GetNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteRequest) Reset() {
+	*x = GetNoteRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteRequest) ProtoMessage() {}
+
+func (x *GetNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type HasNoteChangedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasNoteChangedRequest) Reset() {
+	*x = HasNoteChangedRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasNoteChangedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasNoteChangedRequest) ProtoMessage() {}
+
+func (x *HasNoteChangedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasNoteChangedRequest.ProtoReflect.Descriptor instead.
+func (*HasNoteChangedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HasNoteChangedRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *HasNoteChangedRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type HasNoteChangedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
+	ContentHash   string                 `protobuf:"bytes,2,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasNoteChangedResponse) Reset() {
+	*x = HasNoteChangedResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasNoteChangedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasNoteChangedResponse) ProtoMessage() {}
+
+func (x *HasNoteChangedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasNoteChangedResponse.ProtoReflect.Descriptor instead.
+func (*HasNoteChangedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HasNoteChangedResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *HasNoteChangedResponse) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type UpdateNoteRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Title   string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Content string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// Optional optimistic concurrency checks; the update fails with ABORTED if the note changed.
+	ExpectedModified *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expected_modified,json=expectedModified,proto3" json:"expected_modified,omitempty"`
+	ExpectedHash     string                 `protobuf:"bytes,4,opt,name=expected_hash,json=expectedHash,proto3" json:"expected_hash,omitempty"` // hex SHA-256 of the note's HTML body
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateNoteRequest) Reset() {
+	*x = UpdateNoteRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNoteRequest) ProtoMessage() {}
+
+func (x *UpdateNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNoteRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateNoteRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateNoteRequest) GetExpectedModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpectedModified
+	}
+	return nil
+}
+
+func (x *UpdateNoteRequest) GetExpectedHash() string {
+	if x != nil {
+		return x.ExpectedHash
+	}
+	return ""
+}
+
+type UpdateNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNoteResponse) Reset() {
+	*x = UpdateNoteResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNoteResponse) ProtoMessage() {}
+
+func (x *UpdateNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNoteResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{12}
+}
+
+type DeleteNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNoteRequest) Reset() {
+	*x = DeleteNoteRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNoteRequest) ProtoMessage() {}
+
+func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNoteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type DeleteNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNoteResponse) Reset() {
+	*x = DeleteNoteResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNoteResponse) ProtoMessage() {}
+
+func (x *DeleteNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNoteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{14}
+}
+
+type OpenNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenNoteRequest) Reset() {
+	*x = OpenNoteRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenNoteRequest) ProtoMessage() {}
+
+func (x *OpenNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenNoteRequest.ProtoReflect.Descriptor instead.
+func (*OpenNoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OpenNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type OpenNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenNoteResponse) Reset() {
+	*x = OpenNoteResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenNoteResponse) ProtoMessage() {}
+
+func (x *OpenNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenNoteResponse.ProtoReflect.Descriptor instead.
+func (*OpenNoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{16}
+}
+
+type GetRecentNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // defaults to 10
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentNotesRequest) Reset() {
+	*x = GetRecentNotesRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentNotesRequest) ProtoMessage() {}
+
+func (x *GetRecentNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentNotesRequest.ProtoReflect.Descriptor instead.
+func (*GetRecentNotesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetRecentNotesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetNotesInFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        string                 `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotesInFolderRequest) Reset() {
+	*x = GetNotesInFolderRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotesInFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotesInFolderRequest) ProtoMessage() {}
+
+func (x *GetNotesInFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotesInFolderRequest.ProtoReflect.Descriptor instead.
+func (*GetNotesInFolderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetNotesInFolderRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+type MoveNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteTitle     string                 `protobuf:"bytes,1,opt,name=note_title,json=noteTitle,proto3" json:"note_title,omitempty"`
+	TargetFolder  string                 `protobuf:"bytes,2,opt,name=target_folder,json=targetFolder,proto3" json:"target_folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveNoteRequest) Reset() {
+	*x = MoveNoteRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveNoteRequest) ProtoMessage() {}
+
+func (x *MoveNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveNoteRequest.ProtoReflect.Descriptor instead.
+func (*MoveNoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MoveNoteRequest) GetNoteTitle() string {
+	if x != nil {
+		return x.NoteTitle
+	}
+	return ""
+}
+
+func (x *MoveNoteRequest) GetTargetFolder() string {
+	if x != nil {
+		return x.TargetFolder
+	}
+	return ""
+}
+
+type MoveNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveNoteResponse) Reset() {
+	*x = MoveNoteResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveNoteResponse) ProtoMessage() {}
+
+func (x *MoveNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveNoteResponse.ProtoReflect.Descriptor instead.
+func (*MoveNoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{20}
+}
+
+type ListFoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFoldersRequest) Reset() {
+	*x = ListFoldersRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFoldersRequest) ProtoMessage() {}
+
+func (x *ListFoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFoldersRequest.ProtoReflect.Descriptor instead.
+func (*ListFoldersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{21}
+}
+
+type ListFoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []string               `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFoldersResponse) Reset() {
+	*x = ListFoldersResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFoldersResponse) ProtoMessage() {}
+
+func (x *ListFoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFoldersResponse.ProtoReflect.Descriptor instead.
+func (*ListFoldersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListFoldersResponse) GetFolders() []string {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+type CreateFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentFolder  string                 `protobuf:"bytes,2,opt,name=parent_folder,json=parentFolder,proto3" json:"parent_folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFolderRequest) Reset() {
+	*x = CreateFolderRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderRequest) ProtoMessage() {}
+
+func (x *CreateFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderRequest.ProtoReflect.Descriptor instead.
+func (*CreateFolderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFolderRequest) GetParentFolder() string {
+	if x != nil {
+		return x.ParentFolder
+	}
+	return ""
+}
+
+type CreateFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFolderResponse) Reset() {
+	*x = CreateFolderResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderResponse) ProtoMessage() {}
+
+func (x *CreateFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderResponse.ProtoReflect.Descriptor instead.
+func (*CreateFolderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{24}
+}
+
+type GetFolderHierarchyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFolderHierarchyRequest) Reset() {
+	*x = GetFolderHierarchyRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFolderHierarchyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFolderHierarchyRequest) ProtoMessage() {}
+
+func (x *GetFolderHierarchyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFolderHierarchyRequest.ProtoReflect.Descriptor instead.
+func (*GetFolderHierarchyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{25}
+}
+
+type GetNoteAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteTitle     string                 `protobuf:"bytes,1,opt,name=note_title,json=noteTitle,proto3" json:"note_title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteAttachmentsRequest) Reset() {
+	*x = GetNoteAttachmentsRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteAttachmentsRequest) ProtoMessage() {}
+
+func (x *GetNoteAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetNoteAttachmentsRequest) GetNoteTitle() string {
+	if x != nil {
+		return x.NoteTitle
+	}
+	return ""
+}
+
+type GetNoteAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteAttachmentsResponse) Reset() {
+	*x = GetNoteAttachmentsResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteAttachmentsResponse) ProtoMessage() {}
+
+func (x *GetNoteAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*GetNoteAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetNoteAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type GetAttachmentContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FilePath      string                 `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	MaxSize       int64                  `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"` // bytes; defaults to 10MB
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentContentRequest) Reset() {
+	*x = GetAttachmentContentRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentContentRequest) ProtoMessage() {}
+
+func (x *GetAttachmentContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentContentRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentContentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetAttachmentContentRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *GetAttachmentContentRequest) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+type GetAttachmentContentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentContentResponse) Reset() {
+	*x = GetAttachmentContentResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentContentResponse) ProtoMessage() {}
+
+func (x *GetAttachmentContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentContentResponse.ProtoReflect.Descriptor instead.
+func (*GetAttachmentContentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetAttachmentContentResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ExportNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteTitle     string                 `protobuf:"bytes,1,opt,name=note_title,json=noteTitle,proto3" json:"note_title,omitempty"`
+	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=notes.v1.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportNoteRequest) Reset() {
+	*x = ExportNoteRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportNoteRequest) ProtoMessage() {}
+
+func (x *ExportNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportNoteRequest.ProtoReflect.Descriptor instead.
+func (*ExportNoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExportNoteRequest) GetNoteTitle() string {
+	if x != nil {
+		return x.NoteTitle
+	}
+	return ""
+}
+
+func (x *ExportNoteRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type ExportNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportNoteResponse) Reset() {
+	*x = ExportNoteResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportNoteResponse) ProtoMessage() {}
+
+func (x *ExportNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportNoteResponse.ProtoReflect.Descriptor instead.
+func (*ExportNoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportNoteResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ExtractActionItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteTitle     string                 `protobuf:"bytes,1,opt,name=note_title,json=noteTitle,proto3" json:"note_title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractActionItemsRequest) Reset() {
+	*x = ExtractActionItemsRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractActionItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractActionItemsRequest) ProtoMessage() {}
+
+func (x *ExtractActionItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractActionItemsRequest.ProtoReflect.Descriptor instead.
+func (*ExtractActionItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExtractActionItemsRequest) GetNoteTitle() string {
+	if x != nil {
+		return x.NoteTitle
+	}
+	return ""
+}
+
+type ExtractActionItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ActionItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractActionItemsResponse) Reset() {
+	*x = ExtractActionItemsResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractActionItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractActionItemsResponse) ProtoMessage() {}
+
+func (x *ExtractActionItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractActionItemsResponse.ProtoReflect.Descriptor instead.
+func (*ExtractActionItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExtractActionItemsResponse) GetItems() []*ActionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_proto_notes_v1_notes_proto protoreflect.FileDescriptor
+
+const file_proto_notes_v1_notes_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/notes/v1/notes.proto\x12\bnotes.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x02\n" +
+	"\x04Note\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x124\n" +
+	"\acreated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bmodified\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\x12\x16\n" +
+	"\x06folder\x18\a \x01(\tR\x06folder\x12\x16\n" +
+	"\x06shared\x18\b \x01(\bR\x06shared\x12-\n" +
+	"\x12password_protected\x18\t \x01(\bR\x11passwordProtected\x12!\n" +
+	"\fcontent_hash\x18\n" +
+	" \x01(\tR\vcontentHash\"0\n" +
+	"\bNoteList\x12$\n" +
+	"\x05notes\x18\x01 \x03(\v2\x0e.notes.v1.NoteR\x05notes\"\xea\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tfile_path\x18\x03 \x01(\tR\bfilePath\x12-\n" +
+	"\x12content_identifier\x18\x04 \x01(\tR\x11contentIdentifier\x124\n" +
+	"\acreated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bmodified\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\"\x89\x01\n" +
+	"\n" +
+	"FolderNode\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06shared\x18\x02 \x01(\bR\x06shared\x12\x1d\n" +
+	"\n" +
+	"note_count\x18\x03 \x01(\x05R\tnoteCount\x120\n" +
+	"\bchildren\x18\x04 \x03(\v2\x14.notes.v1.FolderNodeR\bchildren\"U\n" +
+	"\n" +
+	"ActionItem\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\bR\x04done\x12\x1f\n" +
+	"\vsource_note\x18\x03 \x01(\tR\n" +
+	"sourceNote\"W\n" +
+	"\x11CreateNoteRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"*\n" +
+	"\x12SearchNotesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\"\xe9\x01\n" +
+	"\x1aSearchNotesAdvancedRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12/\n" +
+	"\tsearch_in\x18\x02 \x01(\x0e2\x12.notes.v1.SearchInR\bsearchIn\x12\x16\n" +
+	"\x06folder\x18\x03 \x01(\tR\x06folder\x127\n" +
+	"\tdate_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdateFrom\x123\n" +
+	"\adate_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06dateTo\"&\n" +
+	"\x0eGetNoteRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\"A\n" +
+	"\x15HasNoteChangedRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\"U\n" +
+	"\x16HasNoteChangedResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\x12!\n" +
+	"\fcontent_hash\x18\x02 \x01(\tR\vcontentHash\"\xb1\x01\n" +
+	"\x11UpdateNoteRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12G\n" +
+	"\x11expected_modified\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x10expectedModified\x12#\n" +
+	"\rexpected_hash\x18\x04 \x01(\tR\fexpectedHash\"\x14\n" +
+	"\x12UpdateNoteResponse\")\n" +
+	"\x11DeleteNoteRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\"\x14\n" +
+	"\x12DeleteNoteResponse\"'\n" +
+	"\x0fOpenNoteRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\"\x12\n" +
+	"\x10OpenNoteResponse\"-\n" +
+	"\x15GetRecentNotesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"1\n" +
+	"\x17GetNotesInFolderRequest\x12\x16\n" +
+	"\x06folder\x18\x01 \x01(\tR\x06folder\"U\n" +
+	"\x0fMoveNoteRequest\x12\x1d\n" +
+	"\n" +
+	"note_title\x18\x01 \x01(\tR\tnoteTitle\x12#\n" +
+	"\rtarget_folder\x18\x02 \x01(\tR\ftargetFolder\"\x12\n" +
+	"\x10MoveNoteResponse\"\x14\n" +
+	"\x12ListFoldersRequest\"/\n" +
+	"\x13ListFoldersResponse\x12\x18\n" +
+	"\afolders\x18\x01 \x03(\tR\afolders\"N\n" +
+	"\x13CreateFolderRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rparent_folder\x18\x02 \x01(\tR\fparentFolder\"\x16\n" +
+	"\x14CreateFolderResponse\"\x1b\n" +
+	"\x19GetFolderHierarchyRequest\":\n" +
+	"\x19GetNoteAttachmentsRequest\x12\x1d\n" +
+	"\n" +
+	"note_title\x18\x01 \x01(\tR\tnoteTitle\"T\n" +
+	"\x1aGetNoteAttachmentsResponse\x126\n" +
+	"\vattachments\x18\x01 \x03(\v2\x14.notes.v1.AttachmentR\vattachments\"U\n" +
+	"\x1bGetAttachmentContentRequest\x12\x1b\n" +
+	"\tfile_path\x18\x01 \x01(\tR\bfilePath\x12\x19\n" +
+	"\bmax_size\x18\x02 \x01(\x03R\amaxSize\"8\n" +
+	"\x1cGetAttachmentContentResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\"b\n" +
+	"\x11ExportNoteRequest\x12\x1d\n" +
+	"\n" +
+	"note_title\x18\x01 \x01(\tR\tnoteTitle\x12.\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x16.notes.v1.ExportFormatR\x06format\".\n" +
+	"\x12ExportNoteResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\":\n" +
+	"\x19ExtractActionItemsRequest\x12\x1d\n" +
+	"\n" +
+	"note_title\x18\x01 \x01(\tR\tnoteTitle\"H\n" +
+	"\x1aExtractActionItemsResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.notes.v1.ActionItemR\x05items*b\n" +
+	"\bSearchIn\x12\x19\n" +
+	"\x15SEARCH_IN_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSEARCH_IN_TITLE\x10\x01\x12\x12\n" +
+	"\x0eSEARCH_IN_BODY\x10\x02\x12\x12\n" +
+	"\x0eSEARCH_IN_BOTH\x10\x03*a\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16EXPORT_FORMAT_MARKDOWN\x10\x01\x12\x16\n" +
+	"\x12EXPORT_FORMAT_TEXT\x10\x022\xed\n" +
+	"\n" +
+	"\fNotesService\x129\n" +
+	"\n" +
+	"CreateNote\x12\x1b.notes.v1.CreateNoteRequest\x1a\x0e.notes.v1.Note\x12?\n" +
+	"\vSearchNotes\x12\x1c.notes.v1.SearchNotesRequest\x1a\x12.notes.v1.NoteList\x12O\n" +
+	"\x13SearchNotesAdvanced\x12$.notes.v1.SearchNotesAdvancedRequest\x1a\x12.notes.v1.NoteList\x123\n" +
+	"\aGetNote\x12\x18.notes.v1.GetNoteRequest\x1a\x0e.notes.v1.Note\x12S\n" +
+	"\x0eHasNoteChanged\x12\x1f.notes.v1.HasNoteChangedRequest\x1a .notes.v1.HasNoteChangedResponse\x12G\n" +
+	"\n" +
+	"UpdateNote\x12\x1b.notes.v1.UpdateNoteRequest\x1a\x1c.notes.v1.UpdateNoteResponse\x12G\n" +
+	"\n" +
+	"DeleteNote\x12\x1b.notes.v1.DeleteNoteRequest\x1a\x1c.notes.v1.DeleteNoteResponse\x12A\n" +
+	"\bOpenNote\x12\x19.notes.v1.OpenNoteRequest\x1a\x1a.notes.v1.OpenNoteResponse\x12E\n" +
+	"\x0eGetRecentNotes\x12\x1f.notes.v1.GetRecentNotesRequest\x1a\x12.notes.v1.NoteList\x12I\n" +
+	"\x10GetNotesInFolder\x12!.notes.v1.GetNotesInFolderRequest\x1a\x12.notes.v1.NoteList\x12A\n" +
+	"\bMoveNote\x12\x19.notes.v1.MoveNoteRequest\x1a\x1a.notes.v1.MoveNoteResponse\x12J\n" +
+	"\vListFolders\x12\x1c.notes.v1.ListFoldersRequest\x1a\x1d.notes.v1.ListFoldersResponse\x12M\n" +
+	"\fCreateFolder\x12\x1d.notes.v1.CreateFolderRequest\x1a\x1e.notes.v1.CreateFolderResponse\x12O\n" +
+	"\x12GetFolderHierarchy\x12#.notes.v1.GetFolderHierarchyRequest\x1a\x14.notes.v1.FolderNode\x12_\n" +
+	"\x12GetNoteAttachments\x12#.notes.v1.GetNoteAttachmentsRequest\x1a$.notes.v1.GetNoteAttachmentsResponse\x12e\n" +
+	"\x14GetAttachmentContent\x12%.notes.v1.GetAttachmentContentRequest\x1a&.notes.v1.GetAttachmentContentResponse\x12G\n" +
+	"\n" +
+	"ExportNote\x12\x1b.notes.v1.ExportNoteRequest\x1a\x1c.notes.v1.ExportNoteResponse\x12_\n" +
+	"\x12ExtractActionItems\x12#.notes.v1.ExtractActionItemsRequest\x1a$.notes.v1.ExtractActionItemsResponseB4Z2github.com/harper/notes-mcp/proto/notes/v1;notesv1b\x06proto3"
+
+var (
+	file_proto_notes_v1_notes_proto_rawDescOnce sync.Once
+	file_proto_notes_v1_notes_proto_rawDescData []byte
+)
+
+func file_proto_notes_v1_notes_proto_rawDescGZIP() []byte {
+	file_proto_notes_v1_notes_proto_rawDescOnce.Do(func() {
+		file_proto_notes_v1_notes_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_notes_v1_notes_proto_rawDesc), len(file_proto_notes_v1_notes_proto_rawDesc)))
+	})
+	return file_proto_notes_v1_notes_proto_rawDescData
+}
+
+var file_proto_notes_v1_notes_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_notes_v1_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_proto_notes_v1_notes_proto_goTypes = []any{
+	(SearchIn)(0),                        // 0: notes.v1.SearchIn
+	(ExportFormat)(0),                    // 1: notes.v1.ExportFormat
+	(*Note)(nil),                         // 2: notes.v1.Note
+	(*NoteList)(nil),                     // 3: notes.v1.NoteList
+	(*Attachment)(nil),                   // 4: notes.v1.Attachment
+	(*FolderNode)(nil),                   // 5: notes.v1.FolderNode
+	(*ActionItem)(nil),                   // 6: notes.v1.ActionItem
+	(*CreateNoteRequest)(nil),            // 7: notes.v1.CreateNoteRequest
+	(*SearchNotesRequest)(nil),           // 8: notes.v1.SearchNotesRequest
+	(*SearchNotesAdvancedRequest)(nil),   // 9: notes.v1.SearchNotesAdvancedRequest
+	(*GetNoteRequest)(nil),               // 10: notes.v1.GetNoteRequest
+	(*HasNoteChangedRequest)(nil),        // 11: notes.v1.HasNoteChangedRequest
+	(*HasNoteChangedResponse)(nil),       // 12: notes.v1.HasNoteChangedResponse
+	(*UpdateNoteRequest)(nil),            // 13: notes.v1.UpdateNoteRequest
+	(*UpdateNoteResponse)(nil),           // 14: notes.v1.UpdateNoteResponse
+	(*DeleteNoteRequest)(nil),            // 15: notes.v1.DeleteNoteRequest
+	(*DeleteNoteResponse)(nil),           // 16: notes.v1.DeleteNoteResponse
+	(*OpenNoteRequest)(nil),              // 17: notes.v1.OpenNoteRequest
+	(*OpenNoteResponse)(nil),             // 18: notes.v1.OpenNoteResponse
+	(*GetRecentNotesRequest)(nil),        // 19: notes.v1.GetRecentNotesRequest
+	(*GetNotesInFolderRequest)(nil),      // 20: notes.v1.GetNotesInFolderRequest
+	(*MoveNoteRequest)(nil),              // 21: notes.v1.MoveNoteRequest
+	(*MoveNoteResponse)(nil),             // 22: notes.v1.MoveNoteResponse
+	(*ListFoldersRequest)(nil),           // 23: notes.v1.ListFoldersRequest
+	(*ListFoldersResponse)(nil),          // 24: notes.v1.ListFoldersResponse
+	(*CreateFolderRequest)(nil),          // 25: notes.v1.CreateFolderRequest
+	(*CreateFolderResponse)(nil),         // 26: notes.v1.CreateFolderResponse
+	(*GetFolderHierarchyRequest)(nil),    // 27: notes.v1.GetFolderHierarchyRequest
+	(*GetNoteAttachmentsRequest)(nil),    // 28: notes.v1.GetNoteAttachmentsRequest
+	(*GetNoteAttachmentsResponse)(nil),   // 29: notes.v1.GetNoteAttachmentsResponse
+	(*GetAttachmentContentRequest)(nil),  // 30: notes.v1.GetAttachmentContentRequest
+	(*GetAttachmentContentResponse)(nil), // 31: notes.v1.GetAttachmentContentResponse
+	(*ExportNoteRequest)(nil),            // 32: notes.v1.ExportNoteRequest
+	(*ExportNoteResponse)(nil),           // 33: notes.v1.ExportNoteResponse
+	(*ExtractActionItemsRequest)(nil),    // 34: notes.v1.ExtractActionItemsRequest
+	(*ExtractActionItemsResponse)(nil),   // 35: notes.v1.ExtractActionItemsResponse
+	(*timestamppb.Timestamp)(nil),        // 36: google.protobuf.Timestamp
+}
+var file_proto_notes_v1_notes_proto_depIdxs = []int32{
+	36, // 0: notes.v1.Note.created:type_name -> google.protobuf.Timestamp
+	36, // 1: notes.v1.Note.modified:type_name -> google.protobuf.Timestamp
+	2,  // 2: notes.v1.NoteList.notes:type_name -> notes.v1.Note
+	36, // 3: notes.v1.Attachment.created:type_name -> google.protobuf.Timestamp
+	36, // 4: notes.v1.Attachment.modified:type_name -> google.protobuf.Timestamp
+	5,  // 5: notes.v1.FolderNode.children:type_name -> notes.v1.FolderNode
+	0,  // 6: notes.v1.SearchNotesAdvancedRequest.search_in:type_name -> notes.v1.SearchIn
+	36, // 7: notes.v1.SearchNotesAdvancedRequest.date_from:type_name -> google.protobuf.Timestamp
+	36, // 8: notes.v1.SearchNotesAdvancedRequest.date_to:type_name -> google.protobuf.Timestamp
+	36, // 9: notes.v1.UpdateNoteRequest.expected_modified:type_name -> google.protobuf.Timestamp
+	4,  // 10: notes.v1.GetNoteAttachmentsResponse.attachments:type_name -> notes.v1.Attachment
+	1,  // 11: notes.v1.ExportNoteRequest.format:type_name -> notes.v1.ExportFormat
+	6,  // 12: notes.v1.ExtractActionItemsResponse.items:type_name -> notes.v1.ActionItem
+	7,  // 13: notes.v1.NotesService.CreateNote:input_type -> notes.v1.CreateNoteRequest
+	8,  // 14: notes.v1.NotesService.SearchNotes:input_type -> notes.v1.SearchNotesRequest
+	9,  // 15: notes.v1.NotesService.SearchNotesAdvanced:input_type -> notes.v1.SearchNotesAdvancedRequest
+	10, // 16: notes.v1.NotesService.GetNote:input_type -> notes.v1.GetNoteRequest
+	11, // 17: notes.v1.NotesService.HasNoteChanged:input_type -> notes.v1.HasNoteChangedRequest
+	13, // 18: notes.v1.NotesService.UpdateNote:input_type -> notes.v1.UpdateNoteRequest
+	15, // 19: notes.v1.NotesService.DeleteNote:input_type -> notes.v1.DeleteNoteRequest
+	17, // 20: notes.v1.NotesService.OpenNote:input_type -> notes.v1.OpenNoteRequest
+	19, // 21: notes.v1.NotesService.GetRecentNotes:input_type -> notes.v1.GetRecentNotesRequest
+	20, // 22: notes.v1.NotesService.GetNotesInFolder:input_type -> notes.v1.GetNotesInFolderRequest
+	21, // 23: notes.v1.NotesService.MoveNote:input_type -> notes.v1.MoveNoteRequest
+	23, // 24: notes.v1.NotesService.ListFolders:input_type -> notes.v1.ListFoldersRequest
+	25, // 25: notes.v1.NotesService.CreateFolder:input_type -> notes.v1.CreateFolderRequest
+	27, // 26: notes.v1.NotesService.GetFolderHierarchy:input_type -> notes.v1.GetFolderHierarchyRequest
+	28, // 27: notes.v1.NotesService.GetNoteAttachments:input_type -> notes.v1.GetNoteAttachmentsRequest
+	30, // 28: notes.v1.NotesService.GetAttachmentContent:input_type -> notes.v1.GetAttachmentContentRequest
+	32, // 29: notes.v1.NotesService.ExportNote:input_type -> notes.v1.ExportNoteRequest
+	34, // 30: notes.v1.NotesService.ExtractActionItems:input_type -> notes.v1.ExtractActionItemsRequest
+	2,  // 31: notes.v1.NotesService.CreateNote:output_type -> notes.v1.Note
+	3,  // 32: notes.v1.NotesService.SearchNotes:output_type -> notes.v1.NoteList
+	3,  // 33: notes.v1.NotesService.SearchNotesAdvanced:output_type -> notes.v1.NoteList
+	2,  // 34: notes.v1.NotesService.GetNote:output_type -> notes.v1.Note
+	12, // 35: notes.v1.NotesService.HasNoteChanged:output_type -> notes.v1.HasNoteChangedResponse
+	14, // 36: notes.v1.NotesService.UpdateNote:output_type -> notes.v1.UpdateNoteResponse
+	16, // 37: notes.v1.NotesService.DeleteNote:output_type -> notes.v1.DeleteNoteResponse
+	18, // 38: notes.v1.NotesService.OpenNote:output_type -> notes.v1.OpenNoteResponse
+	3,  // 39: notes.v1.NotesService.GetRecentNotes:output_type -> notes.v1.NoteList
+	3,  // 40: notes.v1.NotesService.GetNotesInFolder:output_type -> notes.v1.NoteList
+	22, // 41: notes.v1.NotesService.MoveNote:output_type -> notes.v1.MoveNoteResponse
+	24, // 42: notes.v1.NotesService.ListFolders:output_type -> notes.v1.ListFoldersResponse
+	26, // 43: notes.v1.NotesService.CreateFolder:output_type -> notes.v1.CreateFolderResponse
+	5,  // 44: notes.v1.NotesService.GetFolderHierarchy:output_type -> notes.v1.FolderNode
+	29, // 45: notes.v1.NotesService.GetNoteAttachments:output_type -> notes.v1.GetNoteAttachmentsResponse
+	31, // 46: notes.v1.NotesService.GetAttachmentContent:output_type -> notes.v1.GetAttachmentContentResponse
+	33, // 47: notes.v1.NotesService.ExportNote:output_type -> notes.v1.ExportNoteResponse
+	35, // 48: notes.v1.NotesService.ExtractActionItems:output_type -> notes.v1.ExtractActionItemsResponse
+	31, // [31:49] is the sub-list for method output_type
+	13, // [13:31] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_proto_notes_v1_notes_proto_init() }
+func file_proto_notes_v1_notes_proto_init() {
+	if File_proto_notes_v1_notes_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_notes_v1_notes_proto_rawDesc), len(file_proto_notes_v1_notes_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   34,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_notes_v1_notes_proto_goTypes,
+		DependencyIndexes: file_proto_notes_v1_notes_proto_depIdxs,
+		EnumInfos:         file_proto_notes_v1_notes_proto_enumTypes,
+		MessageInfos:      file_proto_notes_v1_notes_proto_msgTypes,
+	}.Build()
+	File_proto_notes_v1_notes_proto = out.File
+	file_proto_notes_v1_notes_proto_goTypes = nil
+	file_proto_notes_v1_notes_proto_depIdxs = nil
+}
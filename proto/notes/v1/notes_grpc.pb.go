@@ -0,0 +1,812 @@
+// ABOUTME: gRPC service definition for Apple Notes operations
+// ABOUTME: Mirrors services.NotesService so local tools can integrate without MCP or the CLI
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/notes/v1/notes.proto
+
+package notesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NotesService_CreateNote_FullMethodName           = "/notes.v1.NotesService/CreateNote"
+	NotesService_SearchNotes_FullMethodName          = "/notes.v1.NotesService/SearchNotes"
+	NotesService_SearchNotesAdvanced_FullMethodName  = "/notes.v1.NotesService/SearchNotesAdvanced"
+	NotesService_GetNote_FullMethodName              = "/notes.v1.NotesService/GetNote"
+	NotesService_HasNoteChanged_FullMethodName       = "/notes.v1.NotesService/HasNoteChanged"
+	NotesService_UpdateNote_FullMethodName           = "/notes.v1.NotesService/UpdateNote"
+	NotesService_DeleteNote_FullMethodName           = "/notes.v1.NotesService/DeleteNote"
+	NotesService_OpenNote_FullMethodName             = "/notes.v1.NotesService/OpenNote"
+	NotesService_GetRecentNotes_FullMethodName       = "/notes.v1.NotesService/GetRecentNotes"
+	NotesService_GetNotesInFolder_FullMethodName     = "/notes.v1.NotesService/GetNotesInFolder"
+	NotesService_MoveNote_FullMethodName             = "/notes.v1.NotesService/MoveNote"
+	NotesService_ListFolders_FullMethodName          = "/notes.v1.NotesService/ListFolders"
+	NotesService_CreateFolder_FullMethodName         = "/notes.v1.NotesService/CreateFolder"
+	NotesService_GetFolderHierarchy_FullMethodName   = "/notes.v1.NotesService/GetFolderHierarchy"
+	NotesService_GetNoteAttachments_FullMethodName   = "/notes.v1.NotesService/GetNoteAttachments"
+	NotesService_GetAttachmentContent_FullMethodName = "/notes.v1.NotesService/GetAttachmentContent"
+	NotesService_ExportNote_FullMethodName           = "/notes.v1.NotesService/ExportNote"
+	NotesService_ExtractActionItems_FullMethodName   = "/notes.v1.NotesService/ExtractActionItems"
+)
+
+// NotesServiceClient is the client API for NotesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// NotesService exposes Apple Notes operations over gRPC.
+// Notes are addressed by title, matching the MCP tools and CLI commands.
+type NotesServiceClient interface {
+	// CreateNote creates a new note.
+	CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	// SearchNotes searches note titles.
+	SearchNotes(ctx context.Context, in *SearchNotesRequest, opts ...grpc.CallOption) (*NoteList, error)
+	// SearchNotesAdvanced searches with location, folder, and date filters.
+	SearchNotesAdvanced(ctx context.Context, in *SearchNotesAdvancedRequest, opts ...grpc.CallOption) (*NoteList, error)
+	// GetNote returns a note's metadata and HTML content.
+	GetNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	// HasNoteChanged compares a note's body against a content hash from an earlier GetNote.
+	HasNoteChanged(ctx context.Context, in *HasNoteChangedRequest, opts ...grpc.CallOption) (*HasNoteChangedResponse, error)
+	// UpdateNote replaces a note's content, optionally only if it is unchanged since it was read.
+	UpdateNote(ctx context.Context, in *UpdateNoteRequest, opts ...grpc.CallOption) (*UpdateNoteResponse, error)
+	// DeleteNote deletes a note.
+	DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error)
+	// OpenNote shows a note in Notes.app and brings it to the front.
+	OpenNote(ctx context.Context, in *OpenNoteRequest, opts ...grpc.CallOption) (*OpenNoteResponse, error)
+	// GetRecentNotes returns the most recently modified notes.
+	GetRecentNotes(ctx context.Context, in *GetRecentNotesRequest, opts ...grpc.CallOption) (*NoteList, error)
+	// GetNotesInFolder returns all notes in a folder.
+	GetNotesInFolder(ctx context.Context, in *GetNotesInFolderRequest, opts ...grpc.CallOption) (*NoteList, error)
+	// MoveNote moves a note to another folder.
+	MoveNote(ctx context.Context, in *MoveNoteRequest, opts ...grpc.CallOption) (*MoveNoteResponse, error)
+	// ListFolders lists folder names.
+	ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error)
+	// CreateFolder creates a folder, optionally nested under a parent.
+	CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error)
+	// GetFolderHierarchy returns the folder tree with note counts.
+	GetFolderHierarchy(ctx context.Context, in *GetFolderHierarchyRequest, opts ...grpc.CallOption) (*FolderNode, error)
+	// GetNoteAttachments lists a note's attachments.
+	GetNoteAttachments(ctx context.Context, in *GetNoteAttachmentsRequest, opts ...grpc.CallOption) (*GetNoteAttachmentsResponse, error)
+	// GetAttachmentContent reads an attachment file.
+	GetAttachmentContent(ctx context.Context, in *GetAttachmentContentRequest, opts ...grpc.CallOption) (*GetAttachmentContentResponse, error)
+	// ExportNote exports a note as markdown or plain text.
+	ExportNote(ctx context.Context, in *ExportNoteRequest, opts ...grpc.CallOption) (*ExportNoteResponse, error)
+	// ExtractActionItems returns the checklist items in a note.
+	ExtractActionItems(ctx context.Context, in *ExtractActionItemsRequest, opts ...grpc.CallOption) (*ExtractActionItemsResponse, error)
+}
+
+type notesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNotesServiceClient(cc grpc.ClientConnInterface) NotesServiceClient {
+	return &notesServiceClient{cc}
+}
+
+func (c *notesServiceClient) CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, NotesService_CreateNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) SearchNotes(ctx context.Context, in *SearchNotesRequest, opts ...grpc.CallOption) (*NoteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteList)
+	err := c.cc.Invoke(ctx, NotesService_SearchNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) SearchNotesAdvanced(ctx context.Context, in *SearchNotesAdvancedRequest, opts ...grpc.CallOption) (*NoteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteList)
+	err := c.cc.Invoke(ctx, NotesService_SearchNotesAdvanced_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) GetNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, NotesService_GetNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) HasNoteChanged(ctx context.Context, in *HasNoteChangedRequest, opts ...grpc.CallOption) (*HasNoteChangedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HasNoteChangedResponse)
+	err := c.cc.Invoke(ctx, NotesService_HasNoteChanged_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) UpdateNote(ctx context.Context, in *UpdateNoteRequest, opts ...grpc.CallOption) (*UpdateNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateNoteResponse)
+	err := c.cc.Invoke(ctx, NotesService_UpdateNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNoteResponse)
+	err := c.cc.Invoke(ctx, NotesService_DeleteNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) OpenNote(ctx context.Context, in *OpenNoteRequest, opts ...grpc.CallOption) (*OpenNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenNoteResponse)
+	err := c.cc.Invoke(ctx, NotesService_OpenNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) GetRecentNotes(ctx context.Context, in *GetRecentNotesRequest, opts ...grpc.CallOption) (*NoteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteList)
+	err := c.cc.Invoke(ctx, NotesService_GetRecentNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) GetNotesInFolder(ctx context.Context, in *GetNotesInFolderRequest, opts ...grpc.CallOption) (*NoteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteList)
+	err := c.cc.Invoke(ctx, NotesService_GetNotesInFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) MoveNote(ctx context.Context, in *MoveNoteRequest, opts ...grpc.CallOption) (*MoveNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveNoteResponse)
+	err := c.cc.Invoke(ctx, NotesService_MoveNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFoldersResponse)
+	err := c.cc.Invoke(ctx, NotesService_ListFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFolderResponse)
+	err := c.cc.Invoke(ctx, NotesService_CreateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) GetFolderHierarchy(ctx context.Context, in *GetFolderHierarchyRequest, opts ...grpc.CallOption) (*FolderNode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FolderNode)
+	err := c.cc.Invoke(ctx, NotesService_GetFolderHierarchy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) GetNoteAttachments(ctx context.Context, in *GetNoteAttachmentsRequest, opts ...grpc.CallOption) (*GetNoteAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNoteAttachmentsResponse)
+	err := c.cc.Invoke(ctx, NotesService_GetNoteAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) GetAttachmentContent(ctx context.Context, in *GetAttachmentContentRequest, opts ...grpc.CallOption) (*GetAttachmentContentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAttachmentContentResponse)
+	err := c.cc.Invoke(ctx, NotesService_GetAttachmentContent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) ExportNote(ctx context.Context, in *ExportNoteRequest, opts ...grpc.CallOption) (*ExportNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportNoteResponse)
+	err := c.cc.Invoke(ctx, NotesService_ExportNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesServiceClient) ExtractActionItems(ctx context.Context, in *ExtractActionItemsRequest, opts ...grpc.CallOption) (*ExtractActionItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtractActionItemsResponse)
+	err := c.cc.Invoke(ctx, NotesService_ExtractActionItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotesServiceServer is the server API for NotesService service.
+// All implementations must embed UnimplementedNotesServiceServer
+// for forward compatibility.
+//
+// NotesService exposes Apple Notes operations over gRPC.
+// Notes are addressed by title, matching the MCP tools and CLI commands.
+type NotesServiceServer interface {
+	// CreateNote creates a new note.
+	CreateNote(context.Context, *CreateNoteRequest) (*Note, error)
+	// SearchNotes searches note titles.
+	SearchNotes(context.Context, *SearchNotesRequest) (*NoteList, error)
+	// SearchNotesAdvanced searches with location, folder, and date filters.
+	SearchNotesAdvanced(context.Context, *SearchNotesAdvancedRequest) (*NoteList, error)
+	// GetNote returns a note's metadata and HTML content.
+	GetNote(context.Context, *GetNoteRequest) (*Note, error)
+	// HasNoteChanged compares a note's body against a content hash from an earlier GetNote.
+	HasNoteChanged(context.Context, *HasNoteChangedRequest) (*HasNoteChangedResponse, error)
+	// UpdateNote replaces a note's content, optionally only if it is unchanged since it was read.
+	UpdateNote(context.Context, *UpdateNoteRequest) (*UpdateNoteResponse, error)
+	// DeleteNote deletes a note.
+	DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error)
+	// OpenNote shows a note in Notes.app and brings it to the front.
+	OpenNote(context.Context, *OpenNoteRequest) (*OpenNoteResponse, error)
+	// GetRecentNotes returns the most recently modified notes.
+	GetRecentNotes(context.Context, *GetRecentNotesRequest) (*NoteList, error)
+	// GetNotesInFolder returns all notes in a folder.
+	GetNotesInFolder(context.Context, *GetNotesInFolderRequest) (*NoteList, error)
+	// MoveNote moves a note to another folder.
+	MoveNote(context.Context, *MoveNoteRequest) (*MoveNoteResponse, error)
+	// ListFolders lists folder names.
+	ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error)
+	// CreateFolder creates a folder, optionally nested under a parent.
+	CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error)
+	// GetFolderHierarchy returns the folder tree with note counts.
+	GetFolderHierarchy(context.Context, *GetFolderHierarchyRequest) (*FolderNode, error)
+	// GetNoteAttachments lists a note's attachments.
+	GetNoteAttachments(context.Context, *GetNoteAttachmentsRequest) (*GetNoteAttachmentsResponse, error)
+	// GetAttachmentContent reads an attachment file.
+	GetAttachmentContent(context.Context, *GetAttachmentContentRequest) (*GetAttachmentContentResponse, error)
+	// ExportNote exports a note as markdown or plain text.
+	ExportNote(context.Context, *ExportNoteRequest) (*ExportNoteResponse, error)
+	// ExtractActionItems returns the checklist items in a note.
+	ExtractActionItems(context.Context, *ExtractActionItemsRequest) (*ExtractActionItemsResponse, error)
+	mustEmbedUnimplementedNotesServiceServer()
+}
+
+// UnimplementedNotesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNotesServiceServer struct{}
+
+func (UnimplementedNotesServiceServer) CreateNote(context.Context, *CreateNoteRequest) (*Note, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNote not implemented")
+}
+func (UnimplementedNotesServiceServer) SearchNotes(context.Context, *SearchNotesRequest) (*NoteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchNotes not implemented")
+}
+func (UnimplementedNotesServiceServer) SearchNotesAdvanced(context.Context, *SearchNotesAdvancedRequest) (*NoteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchNotesAdvanced not implemented")
+}
+func (UnimplementedNotesServiceServer) GetNote(context.Context, *GetNoteRequest) (*Note, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNote not implemented")
+}
+func (UnimplementedNotesServiceServer) HasNoteChanged(context.Context, *HasNoteChangedRequest) (*HasNoteChangedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasNoteChanged not implemented")
+}
+func (UnimplementedNotesServiceServer) UpdateNote(context.Context, *UpdateNoteRequest) (*UpdateNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNote not implemented")
+}
+func (UnimplementedNotesServiceServer) DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNote not implemented")
+}
+func (UnimplementedNotesServiceServer) OpenNote(context.Context, *OpenNoteRequest) (*OpenNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenNote not implemented")
+}
+func (UnimplementedNotesServiceServer) GetRecentNotes(context.Context, *GetRecentNotesRequest) (*NoteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentNotes not implemented")
+}
+func (UnimplementedNotesServiceServer) GetNotesInFolder(context.Context, *GetNotesInFolderRequest) (*NoteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotesInFolder not implemented")
+}
+func (UnimplementedNotesServiceServer) MoveNote(context.Context, *MoveNoteRequest) (*MoveNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveNote not implemented")
+}
+func (UnimplementedNotesServiceServer) ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFolders not implemented")
+}
+func (UnimplementedNotesServiceServer) CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedNotesServiceServer) GetFolderHierarchy(context.Context, *GetFolderHierarchyRequest) (*FolderNode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFolderHierarchy not implemented")
+}
+func (UnimplementedNotesServiceServer) GetNoteAttachments(context.Context, *GetNoteAttachmentsRequest) (*GetNoteAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNoteAttachments not implemented")
+}
+func (UnimplementedNotesServiceServer) GetAttachmentContent(context.Context, *GetAttachmentContentRequest) (*GetAttachmentContentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachmentContent not implemented")
+}
+func (UnimplementedNotesServiceServer) ExportNote(context.Context, *ExportNoteRequest) (*ExportNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportNote not implemented")
+}
+func (UnimplementedNotesServiceServer) ExtractActionItems(context.Context, *ExtractActionItemsRequest) (*ExtractActionItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtractActionItems not implemented")
+}
+func (UnimplementedNotesServiceServer) mustEmbedUnimplementedNotesServiceServer() {}
+func (UnimplementedNotesServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeNotesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NotesServiceServer will
+// result in compilation errors.
+type UnsafeNotesServiceServer interface {
+	mustEmbedUnimplementedNotesServiceServer()
+}
+
+func RegisterNotesServiceServer(s grpc.ServiceRegistrar, srv NotesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedNotesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NotesService_ServiceDesc, srv)
+}
+
+func _NotesService_CreateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).CreateNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_CreateNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).CreateNote(ctx, req.(*CreateNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_SearchNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).SearchNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_SearchNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).SearchNotes(ctx, req.(*SearchNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_SearchNotesAdvanced_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchNotesAdvancedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).SearchNotesAdvanced(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_SearchNotesAdvanced_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).SearchNotesAdvanced(ctx, req.(*SearchNotesAdvancedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_GetNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).GetNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_GetNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).GetNote(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_HasNoteChanged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasNoteChangedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).HasNoteChanged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_HasNoteChanged_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).HasNoteChanged(ctx, req.(*HasNoteChangedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_UpdateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).UpdateNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_UpdateNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).UpdateNote(ctx, req.(*UpdateNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_DeleteNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).DeleteNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_DeleteNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).DeleteNote(ctx, req.(*DeleteNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_OpenNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).OpenNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_OpenNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).OpenNote(ctx, req.(*OpenNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_GetRecentNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).GetRecentNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_GetRecentNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).GetRecentNotes(ctx, req.(*GetRecentNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_GetNotesInFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotesInFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).GetNotesInFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_GetNotesInFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).GetNotesInFolder(ctx, req.(*GetNotesInFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_MoveNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).MoveNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_MoveNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).MoveNote(ctx, req.(*MoveNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_ListFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).ListFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_ListFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).ListFolders(ctx, req.(*ListFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_GetFolderHierarchy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFolderHierarchyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).GetFolderHierarchy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_GetFolderHierarchy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).GetFolderHierarchy(ctx, req.(*GetFolderHierarchyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_GetNoteAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).GetNoteAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_GetNoteAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).GetNoteAttachments(ctx, req.(*GetNoteAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_GetAttachmentContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).GetAttachmentContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_GetAttachmentContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).GetAttachmentContent(ctx, req.(*GetAttachmentContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_ExportNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).ExportNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_ExportNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).ExportNote(ctx, req.(*ExportNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotesService_ExtractActionItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtractActionItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServiceServer).ExtractActionItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotesService_ExtractActionItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServiceServer).ExtractActionItems(ctx, req.(*ExtractActionItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NotesService_ServiceDesc is the grpc.ServiceDesc for NotesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NotesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "notes.v1.NotesService",
+	HandlerType: (*NotesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateNote",
+			Handler:    _NotesService_CreateNote_Handler,
+		},
+		{
+			MethodName: "SearchNotes",
+			Handler:    _NotesService_SearchNotes_Handler,
+		},
+		{
+			MethodName: "SearchNotesAdvanced",
+			Handler:    _NotesService_SearchNotesAdvanced_Handler,
+		},
+		{
+			MethodName: "GetNote",
+			Handler:    _NotesService_GetNote_Handler,
+		},
+		{
+			MethodName: "HasNoteChanged",
+			Handler:    _NotesService_HasNoteChanged_Handler,
+		},
+		{
+			MethodName: "UpdateNote",
+			Handler:    _NotesService_UpdateNote_Handler,
+		},
+		{
+			MethodName: "DeleteNote",
+			Handler:    _NotesService_DeleteNote_Handler,
+		},
+		{
+			MethodName: "OpenNote",
+			Handler:    _NotesService_OpenNote_Handler,
+		},
+		{
+			MethodName: "GetRecentNotes",
+			Handler:    _NotesService_GetRecentNotes_Handler,
+		},
+		{
+			MethodName: "GetNotesInFolder",
+			Handler:    _NotesService_GetNotesInFolder_Handler,
+		},
+		{
+			MethodName: "MoveNote",
+			Handler:    _NotesService_MoveNote_Handler,
+		},
+		{
+			MethodName: "ListFolders",
+			Handler:    _NotesService_ListFolders_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _NotesService_CreateFolder_Handler,
+		},
+		{
+			MethodName: "GetFolderHierarchy",
+			Handler:    _NotesService_GetFolderHierarchy_Handler,
+		},
+		{
+			MethodName: "GetNoteAttachments",
+			Handler:    _NotesService_GetNoteAttachments_Handler,
+		},
+		{
+			MethodName: "GetAttachmentContent",
+			Handler:    _NotesService_GetAttachmentContent_Handler,
+		},
+		{
+			MethodName: "ExportNote",
+			Handler:    _NotesService_ExportNote_Handler,
+		},
+		{
+			MethodName: "ExtractActionItems",
+			Handler:    _NotesService_ExtractActionItems_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/notes/v1/notes.proto",
+}
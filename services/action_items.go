@@ -0,0 +1,182 @@
+// ABOUTME: Action item extraction from note bodies
+// ABOUTME: Parses checklist items, "- [ ]" lines, and "TODO:" markers from note HTML, with due date hints
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxActionItemNotes caps how many matching notes FindActionItems reads
+const maxActionItemNotes = 50
+
+// ActionItem represents a single checklist entry found in a note
+// DueHint holds a deadline phrase found in the text ("by Friday", "2024-07-01"), if any;
+// Due is the date it resolves to, when it can be resolved
+type ActionItem struct {
+	Text       string     `json:"text"`
+	Done       bool       `json:"done"`
+	SourceNote string     `json:"source_note"`
+	DueHint    string     `json:"due_hint,omitempty"`
+	Due        *time.Time `json:"due,omitempty"`
+}
+
+// checklistItemPattern matches <li> elements, capturing attributes and inner HTML
+var checklistItemPattern = regexp.MustCompile(`(?is)<li([^>]*)>(.*?)</li>`)
+
+// checkboxLinePattern matches plain text checkbox lines like "- [ ] task" or "[x] task"
+var checkboxLinePattern = regexp.MustCompile(`^\s*(?:[-*]\s*)?\[([ xX])\]\s+(.+)$`)
+
+// checkboxSymbolPattern matches lines prefixed with checkbox symbols like "☐ task" or "☑ task"
+var checkboxSymbolPattern = regexp.MustCompile(`^\s*([☐☑✅✓✔])\s*(.+)$`)
+
+// todoLinePattern matches "TODO:"/"DONE:" marker lines like "TODO: send recap" or "- done: book room"
+var todoLinePattern = regexp.MustCompile(`^\s*(?:[-*•]\s*)?(?i:(todo|done))\s*[:\-]\s*(.+)$`)
+
+// dueHintPattern matches deadline phrases: "by Friday", "due tomorrow", "before 7/1", or a bare ISO date
+var dueHintPattern = regexp.MustCompile(`(?i)\b(?:(?:due(?:\s+(?:on|by))?|by|before|until)\s+(?:today|tonight|tomorrow|eod|eow|end of (?:the\s+)?(?:day|week|month)|(?:next\s+)?week|(?:next\s+|this\s+)?(?:mon|tue|tues|wed|wednes|thu|thur|thurs|fri|sat|satur|sun)(?:day)?|\d{4}-\d{2}-\d{2}|\d{1,2}/\d{1,2}(?:/\d{2,4})?|(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?\s+\d{1,2}(?:st|nd|rd|th)?)|\d{4}-\d{2}-\d{2})\b`)
+
+// lineBreakPattern matches HTML elements that end a visual line
+var lineBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>|</h[1-6]>`)
+
+// htmlTagPattern matches any HTML tag
+var htmlTagPattern = regexp.MustCompile(`<[^>]+>`)
+
+// ExtractActionItems retrieves a note and returns the checklist items found in its body
+func (s *AppleNotesService) ExtractActionItems(ctx context.Context, noteTitle string) ([]ActionItem, error) {
+	body, err := s.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return []ActionItem{}, fmt.Errorf("failed to extract action items: %w", err)
+	}
+
+	return withDueDates(parseActionItems(body, noteTitle), time.Now()), nil
+}
+
+// FindActionItems collects action items from the notes whose title or body matches query
+// An optional folder limits the search; at most maxActionItemNotes notes are read
+func (s *AppleNotesService) FindActionItems(ctx context.Context, query, folder string) ([]ActionItem, error) {
+	if strings.TrimSpace(query) == "" {
+		return []ActionItem{}, fmt.Errorf("%w: query is required", ErrInvalidInput)
+	}
+
+	notes, err := s.SearchNotesAdvanced(ctx, SearchOptions{
+		Query:    query,
+		SearchIn: SearchInBoth,
+		Folder:   folder,
+	})
+	if err != nil {
+		return []ActionItem{}, fmt.Errorf("failed to find action items: %w", err)
+	}
+	if len(notes) > maxActionItemNotes {
+		notes = notes[:maxActionItemNotes]
+	}
+
+	bodies := make([]string, len(notes))
+	err = forEachBounded(ctx, len(notes), s.workerLimit(), func(ctx context.Context, i int) error {
+		body, err := s.GetNoteContent(ctx, notes[i].Title)
+		bodies[i] = body
+		return err
+	})
+	if err != nil {
+		return []ActionItem{}, fmt.Errorf("failed to find action items: %w", err)
+	}
+
+	items := []ActionItem{}
+	for i, note := range notes {
+		items = append(items, withDueDates(parseActionItems(bodies[i], note.Title), time.Now())...)
+	}
+
+	return items, nil
+}
+
+// parseActionItems parses checklist items out of a note's HTML body
+// Apple Notes checklists are <li> elements marked with a "checked"/"unchecked" class;
+// text checkboxes ("- [ ] task", "[x] task", "☐ task") and "TODO: task" lines are also recognized
+func parseActionItems(body, sourceNote string) []ActionItem {
+	items := []ActionItem{}
+
+	// First pass: checklist list items, removed from the body once consumed
+	remaining := checklistItemPattern.ReplaceAllStringFunc(body, func(match string) string {
+		parts := checklistItemPattern.FindStringSubmatch(match)
+		attrs := strings.ToLower(parts[1])
+		text := stripHTML(parts[2])
+
+		if item, ok := parseCheckboxLine(text, sourceNote); ok {
+			items = append(items, item)
+			return ""
+		}
+
+		if strings.Contains(attrs, "checked") || strings.Contains(attrs, "checklist") {
+			if text != "" {
+				items = append(items, ActionItem{
+					Text:       text,
+					Done:       strings.Contains(attrs, "checked") && !strings.Contains(attrs, "unchecked"),
+					SourceNote: sourceNote,
+				})
+			}
+			return ""
+		}
+
+		// Not a checklist item; keep it as its own line for the second pass
+		return "<div>" + parts[2] + "</div>"
+	})
+
+	// Second pass: plain text lines containing checkbox markers
+	remaining = lineBreakPattern.ReplaceAllString(remaining, "\n")
+	for _, line := range strings.Split(remaining, "\n") {
+		if item, ok := parseCheckboxLine(stripHTML(line), sourceNote); ok {
+			items = append(items, item)
+		}
+	}
+
+	for i := range items {
+		items[i].DueHint = dueHint(items[i].Text)
+	}
+
+	return items
+}
+
+// dueHint returns the first deadline phrase in an action item's text, or ""
+func dueHint(text string) string {
+	return dueHintPattern.FindString(text)
+}
+
+// parseCheckboxLine parses a single line of text with a checkbox or TODO marker
+func parseCheckboxLine(line, sourceNote string) (ActionItem, bool) {
+	if matches := checkboxLinePattern.FindStringSubmatch(line); matches != nil {
+		return ActionItem{
+			Text:       strings.TrimSpace(matches[2]),
+			Done:       matches[1] != " ",
+			SourceNote: sourceNote,
+		}, true
+	}
+
+	if matches := checkboxSymbolPattern.FindStringSubmatch(line); matches != nil {
+		return ActionItem{
+			Text:       strings.TrimSpace(matches[2]),
+			Done:       matches[1] != "☐",
+			SourceNote: sourceNote,
+		}, true
+	}
+
+	if matches := todoLinePattern.FindStringSubmatch(line); matches != nil {
+		return ActionItem{
+			Text:       strings.TrimSpace(matches[2]),
+			Done:       strings.EqualFold(matches[1], "done"),
+			SourceNote: sourceNote,
+		}, true
+	}
+
+	return ActionItem{}, false
+}
+
+// stripHTML removes HTML tags and decodes entities, returning trimmed text
+func stripHTML(input string) string {
+	text := htmlTagPattern.ReplaceAllString(input, "")
+	return strings.TrimSpace(html.UnescapeString(text))
+}
@@ -0,0 +1,199 @@
+// ABOUTME: Unit tests for action item extraction
+// ABOUTME: Verifies checklist parsing from Apple Notes HTML and text checkboxes
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseActionItems tests checklist parsing across supported formats
+func TestParseActionItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []ActionItem
+	}{
+		{
+			name: "apple notes checklist classes",
+			body: `<ul class="checklist"><li class="checked">Send invoice</li><li class="unchecked">Call Bob</li></ul>`,
+			expected: []ActionItem{
+				{Text: "Send invoice", Done: true, SourceNote: "Note"},
+				{Text: "Call Bob", Done: false, SourceNote: "Note"},
+			},
+		},
+		{
+			name: "markdown style checkboxes in divs",
+			body: `<div>Intro</div><div>- [ ] Draft proposal</div><div>- [x] Book room</div>`,
+			expected: []ActionItem{
+				{Text: "Draft proposal", Done: false, SourceNote: "Note"},
+				{Text: "Book room", Done: true, SourceNote: "Note"},
+			},
+		},
+		{
+			name: "checkboxes inside plain list items",
+			body: `<ul><li>[ ] Review PR</li><li>Not a task</li></ul>`,
+			expected: []ActionItem{
+				{Text: "Review PR", Done: false, SourceNote: "Note"},
+			},
+		},
+		{
+			name: "checkbox symbols separated by breaks",
+			body: `☐ Buy milk<br>☑ Pay rent`,
+			expected: []ActionItem{
+				{Text: "Buy milk", Done: false, SourceNote: "Note"},
+				{Text: "Pay rent", Done: true, SourceNote: "Note"},
+			},
+		},
+		{
+			name: "html entities are decoded",
+			body: `<div>- [ ] Ask &quot;legal&quot; &amp; finance</div>`,
+			expected: []ActionItem{
+				{Text: `Ask "legal" & finance`, Done: false, SourceNote: "Note"},
+			},
+		},
+		{
+			name: "todo and done markers",
+			body: `<div>TODO: Email the vendor</div><div>- done: Book flights</div><div>Todo list for today</div>`,
+			expected: []ActionItem{
+				{Text: "Email the vendor", Done: false, SourceNote: "Note"},
+				{Text: "Book flights", Done: true, SourceNote: "Note"},
+			},
+		},
+		{
+			name: "due hints",
+			body: `<div>- [ ] Send recap by Friday</div><div>TODO: renew passport before 2024-07-01</div><div>[ ] File taxes due Apr 15</div>`,
+			expected: []ActionItem{
+				{Text: "Send recap by Friday", SourceNote: "Note", DueHint: "by Friday"},
+				{Text: "renew passport before 2024-07-01", SourceNote: "Note", DueHint: "before 2024-07-01"},
+				{Text: "File taxes due Apr 15", SourceNote: "Note", DueHint: "due Apr 15"},
+			},
+		},
+		{
+			name:     "no action items",
+			body:     `<div>Just some text</div><ul><li>bullet</li></ul>`,
+			expected: []ActionItem{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := parseActionItems(tt.body, "Note")
+
+			if len(items) != len(tt.expected) {
+				t.Fatalf("got %d items, want %d: %+v", len(items), len(tt.expected), items)
+			}
+			for i, item := range items {
+				if item != tt.expected[i] {
+					t.Errorf("item %d = %+v, want %+v", i, item, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestExtractActionItems tests extraction through the service with a mock executor
+func TestExtractActionItems(t *testing.T) {
+	executor := &MockExecutor{
+		stdout: `<div>- [ ] Follow up</div><div>- [x] Done already</div>`,
+	}
+
+	service := NewAppleNotesService(executor)
+
+	items, err := service.ExtractActionItems(context.Background(), "Meeting")
+	if err != nil {
+		t.Fatalf("ExtractActionItems failed: %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if items[0].SourceNote != "Meeting" {
+		t.Errorf("SourceNote = %q, want %q", items[0].SourceNote, "Meeting")
+	}
+}
+
+// TestExtractActionItemsNotFound tests error propagation for missing notes
+func TestExtractActionItemsNotFound(t *testing.T) {
+	executor := &MockExecutor{
+		stderr: "note 'Missing' not found",
+		err:    ErrNoteNotFound,
+	}
+
+	service := NewAppleNotesService(executor)
+
+	_, err := service.ExtractActionItems(context.Background(), "Missing")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "note not found") {
+		t.Errorf("Expected error containing 'note not found', got %v", err)
+	}
+}
+
+// TestDueHint tests deadline phrase detection in action item text
+func TestDueHint(t *testing.T) {
+	tests := map[string]string{
+		"Ship release 2024-07-01":        "2024-07-01",
+		"Call Sam by tomorrow":           "by tomorrow",
+		"Review deck due by next Monday": "due by next Monday",
+		"Pay invoice until 7/15":         "until 7/15",
+		"Draft plan by end of week":      "by end of week",
+		"Stand by the door":              "",
+		"Buy milk":                       "",
+	}
+
+	for text, want := range tests {
+		if got := dueHint(text); got != want {
+			t.Errorf("dueHint(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+// TestFindActionItems tests collecting items from every matching note
+func TestFindActionItems(t *testing.T) {
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: "Launch|||Retro"},                                // SearchNotesAdvanced
+			{stdout: "<div>TODO: Write announcement by Friday</div>"}, // GetNoteContent "Launch"
+			{stdout: "<div>- [x] Share notes</div>"},                  // GetNoteContent "Retro"
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+	service.SetConcurrency(1) // bodies are consumed in call order
+
+	items, err := service.FindActionItems(context.Background(), "launch", "")
+	if err != nil {
+		t.Fatalf("FindActionItems failed: %v", err)
+	}
+
+	want := []ActionItem{
+		{Text: "Write announcement by Friday", SourceNote: "Launch", DueHint: "by Friday"},
+		{Text: "Share notes", Done: true, SourceNote: "Retro"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	if items[0].Due == nil || items[0].Due.Weekday() != time.Friday {
+		t.Errorf("expected the due hint to resolve to a Friday, got %v", items[0].Due)
+	}
+	items[0].Due = nil
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, items[i], want[i])
+		}
+	}
+
+	if _, err := service.FindActionItems(context.Background(), " ", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an empty query, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+// ABOUTME: Local alias registry mapping short names like "inbox" to note IDs
+// ABOUTME: Aliases are written as "@name" wherever a note title is expected and resolve to the note's current title
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AliasPrefix marks a note reference as an alias rather than a title
+const AliasPrefix = "@"
+
+// aliasNamePattern limits alias names to characters that are easy to type in a shell
+var aliasNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// NoteAlias maps a short name to a note
+// Title is the note's title when the alias was added; the ID is what resolution follows,
+// so aliases keep working after a note is renamed.
+type NoteAlias struct {
+	Name    string    `json:"name"`
+	NoteID  string    `json:"note_id"`
+	Title   string    `json:"title"`
+	Created time.Time `json:"created"`
+}
+
+// NoteTitleLookup finds a note's current title from its ID
+type NoteTitleLookup interface {
+	GetNoteTitleByID(ctx context.Context, noteID string) (string, error)
+}
+
+// AliasStore keeps aliases in a JSON file
+type AliasStore struct {
+	path string
+}
+
+// NewAliasStore creates an AliasStore backed by the file at path, which need not exist yet
+func NewAliasStore(path string) *AliasStore {
+	return &AliasStore{path: path}
+}
+
+// AliasName returns the alias name in ref and whether ref is an alias reference ("@name")
+func AliasName(ref string) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if !strings.HasPrefix(ref, AliasPrefix) || len(ref) == len(AliasPrefix) {
+		return "", false
+	}
+	return strings.ToLower(strings.TrimPrefix(ref, AliasPrefix)), true
+}
+
+// List returns every alias sorted by name; a missing file means no aliases
+func (a *AliasStore) List() ([]NoteAlias, error) {
+	data, err := os.ReadFile(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []NoteAlias{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aliases: %w", err)
+	}
+
+	aliases := []NoteAlias{}
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to load aliases from %s: %w", a.path, err)
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Name < aliases[j].Name
+	})
+	return aliases, nil
+}
+
+// Get returns the alias with the given name (with or without the leading "@"), or nil if there is none
+func (a *AliasStore) Get(name string) (*NoteAlias, error) {
+	name = normalizeAliasName(name)
+
+	aliases, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range aliases {
+		if aliases[i].Name == name {
+			return &aliases[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Add saves an alias, replacing any existing alias with the same name, and returns the saved record
+func (a *AliasStore) Add(alias NoteAlias) (*NoteAlias, error) {
+	alias.Name = normalizeAliasName(alias.Name)
+	if !aliasNamePattern.MatchString(alias.Name) {
+		return nil, fmt.Errorf("%w: alias names use lowercase letters, digits, '-' and '_' (got %q)", ErrInvalidInput, alias.Name)
+	}
+	if alias.NoteID == "" {
+		return nil, fmt.Errorf("%w: alias %q needs a note ID", ErrInvalidInput, alias.Name)
+	}
+	if alias.Created.IsZero() {
+		alias.Created = time.Now().UTC().Truncate(time.Second)
+	}
+
+	aliases, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]NoteAlias, 0, len(aliases)+1)
+	for _, existing := range aliases {
+		if existing.Name != alias.Name {
+			kept = append(kept, existing)
+		}
+	}
+	if err := a.save(append(kept, alias)); err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// Remove deletes the alias with the given name
+func (a *AliasStore) Remove(name string) error {
+	name = normalizeAliasName(name)
+
+	aliases, err := a.List()
+	if err != nil {
+		return err
+	}
+
+	kept := make([]NoteAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		if alias.Name != name {
+			kept = append(kept, alias)
+		}
+	}
+	if len(kept) == len(aliases) {
+		return fmt.Errorf("%w: no alias named %q", ErrInvalidInput, name)
+	}
+
+	return a.save(kept)
+}
+
+// Resolve turns an "@name" reference into the aliased note's current title
+// References that are not aliases, or name no registered alias, are returned unchanged so
+// titles that happen to start with "@" still work.
+func (a *AliasStore) Resolve(ctx context.Context, notes NoteTitleLookup, ref string) (string, error) {
+	name, ok := AliasName(ref)
+	if !ok {
+		return ref, nil
+	}
+
+	alias, err := a.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if alias == nil {
+		return ref, nil
+	}
+
+	title, err := notes.GetNoteTitleByID(ctx, alias.NoteID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve alias @%s (note %q): %w", alias.Name, alias.Title, err)
+	}
+	return title, nil
+}
+
+// save writes aliases to the store file, creating its directory if needed
+func (a *AliasStore) save(aliases []NoteAlias) error {
+	data, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to save aliases: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save aliases: %w", err)
+	}
+	if err := os.WriteFile(a.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to save aliases: %w", err)
+	}
+	return nil
+}
+
+// normalizeAliasName drops a leading "@" and lowercases the name
+func normalizeAliasName(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), AliasPrefix))
+}
+
+// GetNoteTitleByID returns the current title of the note with the given ID
+func (s *AppleNotesService) GetNoteTitleByID(ctx context.Context, noteID string) (string, error) {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				return name of note id "%s"
+			end tell
+		end tell
+	`, s.iCloudAccount, s.escapeForAppleScript(noteID))
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return "", fmt.Errorf("failed to look up note by ID: %w", detectedErr)
+	}
+
+	title := strings.TrimRight(stdout, "\r\n")
+	if title == "" {
+		return "", fmt.Errorf("failed to look up note by ID: %w", ErrNoteNotFound)
+	}
+	return title, nil
+}
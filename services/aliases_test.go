@@ -0,0 +1,125 @@
+// ABOUTME: Unit tests for the alias registry
+// ABOUTME: Tests adding, replacing, removing, and resolving aliases through note IDs
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// titleLookup is a NoteTitleLookup backed by a map of note IDs to titles
+type titleLookup map[string]string
+
+func (l titleLookup) GetNoteTitleByID(ctx context.Context, noteID string) (string, error) {
+	if title, ok := l[noteID]; ok {
+		return title, nil
+	}
+	return "", ErrNoteNotFound
+}
+
+func TestAliasStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "aliases.json")
+	store := NewAliasStore(path)
+
+	aliases, err := store.List()
+	if err != nil || len(aliases) != 0 {
+		t.Fatalf("missing file should list no aliases, got %v, %v", aliases, err)
+	}
+
+	if _, err := store.Add(NoteAlias{Name: "@Inbox", NoteID: "id1", Title: "Inbox"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := store.Add(NoteAlias{Name: "standup", NoteID: "id2", Title: "Standup"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := store.Add(NoteAlias{Name: "inbox", NoteID: "id3", Title: "New Inbox"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	aliases, err = store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(aliases) != 2 || aliases[0].Name != "inbox" || aliases[0].NoteID != "id3" || aliases[1].Name != "standup" {
+		t.Errorf("expected replaced inbox and standup sorted by name, got %+v", aliases)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("alias file not written: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("alias file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := store.Remove("@standup"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := store.Remove("standup"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("removing a missing alias should fail with ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestAliasStoreAddValidation(t *testing.T) {
+	store := NewAliasStore(filepath.Join(t.TempDir(), "aliases.json"))
+
+	for _, alias := range []NoteAlias{
+		{Name: "", NoteID: "id1"},
+		{Name: "my inbox", NoteID: "id1"},
+		{Name: "inbox", NoteID: ""},
+	} {
+		if _, err := store.Add(alias); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Add(%+v) error = %v, want ErrInvalidInput", alias, err)
+		}
+	}
+}
+
+func TestAliasStoreResolve(t *testing.T) {
+	store := NewAliasStore(filepath.Join(t.TempDir(), "aliases.json"))
+	if _, err := store.Add(NoteAlias{Name: "inbox", NoteID: "id1", Title: "Inbox"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Add(NoteAlias{Name: "gone", NoteID: "deleted", Title: "Old"}); err != nil {
+		t.Fatal(err)
+	}
+	notes := titleLookup{"id1": "Inbox (renamed)"}
+	ctx := context.Background()
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"@inbox", "Inbox (renamed)"},
+		{"@INBOX", "Inbox (renamed)"},
+		{"Inbox", "Inbox"},
+		{"@unknown", "@unknown"},
+		{"@", "@"},
+	}
+	for _, tt := range tests {
+		got, err := store.Resolve(ctx, notes, tt.ref)
+		if err != nil || got != tt.want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", tt.ref, got, err, tt.want)
+		}
+	}
+
+	if _, err := store.Resolve(ctx, notes, "@gone"); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("alias to a deleted note should fail with ErrNoteNotFound, got %v", err)
+	}
+}
+
+func TestGetNoteTitleByID(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{stdout: "Inbox\n"})
+	title, err := service.GetNoteTitleByID(context.Background(), "x-coredata://STORE/ICNote/p1")
+	if err != nil || title != "Inbox" {
+		t.Errorf("GetNoteTitleByID = %q, %v; want Inbox", title, err)
+	}
+
+	service = NewAppleNotesService(&MockExecutor{stderr: "Can't get note id", err: errors.New("exit status 1")})
+	if _, err := service.GetNoteTitleByID(context.Background(), "missing"); err == nil {
+		t.Error("expected error")
+	}
+}
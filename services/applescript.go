@@ -5,6 +5,8 @@ package services
 import (
 	"bytes"
 	"context"
+	"errors"
+	"log"
 	"os/exec"
 	"time"
 )
@@ -16,7 +18,8 @@ type ScriptExecutor interface {
 
 // OSAScriptExecutor implements ScriptExecutor using the osascript command
 type OSAScriptExecutor struct {
-	timeout time.Duration
+	timeout  time.Duration
+	language string // osascript -l language; empty means AppleScript
 }
 
 // NewOSAScriptExecutor creates a new OSAScriptExecutor with the specified timeout.
@@ -32,14 +35,19 @@ func NewOSAScriptExecutor(timeout time.Duration) *OSAScriptExecutor {
 }
 
 // Execute runs the provided AppleScript using osascript and returns stdout, stderr, and any error.
-// The execution is subject to the configured timeout and respects context cancellation.
+// The execution is subject to the configured timeout and respects context cancellation;
+// running out of time returns a *TimeoutError.
 func (e *OSAScriptExecutor) Execute(ctx context.Context, script string) (string, string, error) {
 	// Create a context with timeout
 	ctx, cancel := context.WithTimeout(ctx, e.timeout)
 	defer cancel()
 
 	// Create command with context for cancellation support
-	cmd := exec.CommandContext(ctx, "osascript", "-e", script)
+	args := []string{"-e", script}
+	if e.language != "" {
+		args = append([]string{"-l", e.language}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "osascript", args...) // #nosec G204 - language is fixed by the constructor
 
 	// Buffers to capture stdout and stderr separately
 	var stdout, stderr bytes.Buffer
@@ -47,7 +55,35 @@ func (e *OSAScriptExecutor) Execute(ctx context.Context, script string) (string,
 	cmd.Stderr = &stderr
 
 	// Execute the command
+	start := time.Now()
+	limit := e.timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		limit = deadline.Sub(start)
+	}
 	err := cmd.Run()
 
+	// Report a timeout with how long the script ran and which limit stopped it
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = &TimeoutError{
+			Elapsed: time.Since(start).Round(100 * time.Millisecond),
+			Limit:   limit.Round(100 * time.Millisecond),
+		}
+	}
+
+	if err != nil {
+		tracef(ctx, "osascript failed after %s: %v", time.Since(start).Round(time.Millisecond), err)
+	} else {
+		tracef(ctx, "osascript completed in %s", time.Since(start).Round(time.Millisecond))
+	}
+
+	// Log executions that belong to a server request so they can be correlated with the tool call
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		if err != nil {
+			log.Printf("[%s] osascript failed after %s: %v", requestID, time.Since(start).Round(time.Millisecond), err)
+		} else {
+			log.Printf("[%s] osascript completed in %s", requestID, time.Since(start).Round(time.Millisecond))
+		}
+	}
+
 	return stdout.String(), stderr.String(), err
 }
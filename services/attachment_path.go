@@ -0,0 +1,112 @@
+// ABOUTME: Resolves attachment file paths from the Apple Notes group container
+// ABOUTME: Maps attachment identifiers and names to files under Accounts/*/Media
+
+package services
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// uuidPattern matches the UUIDs Notes uses in attachment ids and Media directory names
+var uuidPattern = regexp.MustCompile(`[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}`)
+
+// mediaFile is a file under a Media directory with the directory names between Media and the file
+type mediaFile struct {
+	path string
+	dirs []string
+}
+
+// resolveAttachmentPaths fills in FilePath for attachments whose path AppleScript left empty
+// or pointed somewhere that doesn't exist. The Media directory is scanned at most once per call.
+// Attachments that can't be resolved keep whatever FilePath they had.
+func resolveAttachmentPaths(containerDir string, attachments []Attachment) {
+	var files []mediaFile
+	scanned := false
+
+	for i := range attachments {
+		if attachmentFileExists(attachments[i].FilePath) {
+			continue
+		}
+		if !scanned {
+			files = listMediaFiles(containerDir)
+			scanned = true
+		}
+		if path := matchMediaFile(files, attachments[i]); path != "" {
+			attachments[i].FilePath = path
+		}
+	}
+}
+
+// attachmentFileExists reports whether path names a regular file
+func attachmentFileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// listMediaFiles walks <containerDir>/Accounts/*/Media, skipping hidden files and unreadable directories
+func listMediaFiles(containerDir string) []mediaFile {
+	if containerDir == "" {
+		return nil
+	}
+	mediaDirs, err := filepath.Glob(filepath.Join(containerDir, "Accounts", "*", "Media"))
+	if err != nil {
+		return nil
+	}
+
+	files := []mediaFile{}
+	for _, mediaDir := range mediaDirs {
+		_ = filepath.WalkDir(mediaDir, func(path string, entry fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				if entry != nil && entry.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				return nil
+			}
+
+			rel, err := filepath.Rel(mediaDir, filepath.Dir(path))
+			if err != nil {
+				return nil
+			}
+			files = append(files, mediaFile{path: path, dirs: strings.Split(rel, string(filepath.Separator))})
+			return nil
+		})
+	}
+	return files
+}
+
+// matchMediaFile finds the file for an attachment
+// A Media subdirectory named by a UUID from the attachment's id or content identifier wins;
+// otherwise a file with the attachment's name is used, but only if exactly one exists.
+func matchMediaFile(files []mediaFile, attachment Attachment) string {
+	uuids := map[string]bool{}
+	for _, id := range uuidPattern.FindAllString(attachment.ID+" "+attachment.ContentIdentifier, -1) {
+		uuids[strings.ToUpper(id)] = true
+	}
+
+	byName := []string{}
+	for _, file := range files {
+		for _, dir := range file.dirs {
+			if uuids[strings.ToUpper(dir)] {
+				return file.path
+			}
+		}
+		if attachment.Name != "" && filepath.Base(file.path) == attachment.Name {
+			byName = append(byName, file.path)
+		}
+	}
+
+	if len(byName) == 1 {
+		return byName[0]
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+// ABOUTME: Unit tests for resolving attachment files in the Notes container
+// ABOUTME: Tests UUID directory matching, unique-name fallback, and existing paths
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetNoteAttachmentsResolvesContainerPaths(t *testing.T) {
+	container := t.TempDir()
+	writeFile := func(rel string) string {
+		path := filepath.Join(container, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("data"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	byUUID := writeFile("Accounts/ACC/Media/5F3C9A1E-2B4D-4E6F-8A9B-0C1D2E3F4A5B/1_ABCD/scan.pdf")
+	byName := writeFile("Accounts/ACC/Media/11111111-2222-3333-4444-555555555555/photo.jpg")
+	writeFile("Accounts/ACC/Media/AAAAAAAA-2222-3333-4444-555555555555/dup.png")
+	writeFile("Accounts/ACC/Media/BBBBBBBB-2222-3333-4444-555555555555/dup.png")
+	existing := writeFile("elsewhere/kept.txt")
+
+	output := attachmentRecord("x-coredata://STORE/ICAttachment/p1", "Scan.pdf", "5f3c9a1e-2b4d-4e6f-8a9b-0c1d2e3f4a5b", "", "", "") +
+		attachmentRecord("x-coredata://STORE/ICAttachment/p2", "photo.jpg", "", "", "", "") +
+		attachmentRecord("x-coredata://STORE/ICAttachment/p3", "dup.png", "", "", "", "") +
+		attachmentRecord("x-coredata://STORE/ICAttachment/p4", "kept.txt", "", existing, "", "")
+
+	service := NewAppleNotesService(&MockExecutor{stdout: output})
+	service.containerDir = container
+
+	attachments, err := service.GetNoteAttachments(context.Background(), "Test Note")
+	if err != nil {
+		t.Fatalf("GetNoteAttachments failed: %v", err)
+	}
+	if len(attachments) != 4 {
+		t.Fatalf("expected 4 attachments, got %d", len(attachments))
+	}
+
+	want := []string{byUUID, byName, "", existing}
+	for i, path := range want {
+		if attachments[i].FilePath != path {
+			t.Errorf("attachment %d (%s) path = %q, want %q", i, attachments[i].Name, attachments[i].FilePath, path)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// ABOUTME: Unit tests for chunked attachment reads and attachment copies
+// ABOUTME: Tests byte ranges, end-of-file handling, and non-overwriting copies
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadAttachmentChunk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, []byte("0123456789"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	service := NewAppleNotesService(&MockExecutor{})
+	ctx := context.Background()
+
+	chunk, err := service.ReadAttachmentChunk(ctx, path, 2, 4)
+	if err != nil {
+		t.Fatalf("ReadAttachmentChunk failed: %v", err)
+	}
+	if string(chunk.Data) != "2345" || chunk.Length != 4 || chunk.TotalSize != 10 || chunk.EOF {
+		t.Errorf("unexpected chunk %+v", chunk)
+	}
+
+	last, err := service.ReadAttachmentChunk(ctx, path, 8, 100)
+	if err != nil {
+		t.Fatalf("ReadAttachmentChunk failed: %v", err)
+	}
+	if string(last.Data) != "89" || last.Length != 2 || !last.EOF {
+		t.Errorf("unexpected last chunk %+v", last)
+	}
+
+	end, err := service.ReadAttachmentChunk(ctx, path, 10, 4)
+	if err != nil || end.Length != 0 || !end.EOF {
+		t.Errorf("expected empty EOF chunk, got %+v, %v", end, err)
+	}
+
+	for _, tc := range []struct{ offset, length int64 }{{11, 4}, {-1, 4}, {0, 0}} {
+		if _, err := service.ReadAttachmentChunk(ctx, path, tc.offset, tc.length); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("offset %d length %d: expected ErrInvalidInput, got %v", tc.offset, tc.length, err)
+		}
+	}
+}
+
+func TestCopyAttachment(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "photo.jpg")
+	if err := os.WriteFile(src, []byte("jpeg bytes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dest := t.TempDir()
+	service := NewAppleNotesService(&MockExecutor{})
+	ctx := context.Background()
+
+	first, err := service.CopyAttachment(ctx, src, dest)
+	if err != nil {
+		t.Fatalf("CopyAttachment failed: %v", err)
+	}
+	if first.Path != filepath.Join(dest, "photo.jpg") || first.Size != 10 {
+		t.Errorf("unexpected copy %+v", first)
+	}
+
+	second, err := service.CopyAttachment(ctx, src, dest)
+	if err != nil {
+		t.Fatalf("CopyAttachment failed: %v", err)
+	}
+	if second.Path != filepath.Join(dest, "photo-1.jpg") {
+		t.Errorf("expected a new name for the second copy, got %s", second.Path)
+	}
+	if data, _ := os.ReadFile(second.Path); string(data) != "jpeg bytes" {
+		t.Errorf("copied content = %q", data)
+	}
+
+	if _, err := service.CopyAttachment(ctx, src, filepath.Join(dest, "missing")); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for missing directory, got %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+// ABOUTME: Composes a full NotesService from a backend implementing only some of the focused interfaces
+// ABOUTME: Reports which interfaces a backend implements and answers the missing operations with ErrNotSupported
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BackendInterfaces is a set of the focused interfaces a backend implements beyond NoteReader
+type BackendInterfaces uint
+
+// Focused interfaces a backend can implement; every backend is a NoteReader
+const (
+	HasNoteWriter BackendInterfaces = 1 << iota
+	HasFolderManager
+	HasAttachmentReader
+	HasExporter
+	HasAppIntegrations
+
+	// AllBackendInterfaces is the set a complete NotesService implements
+	AllBackendInterfaces = HasNoteWriter | HasFolderManager | HasAttachmentReader | HasExporter | HasAppIntegrations
+)
+
+// backendInterfaceNames names each interface flag, in flag order
+var backendInterfaceNames = []string{"NoteWriter", "FolderManager", "AttachmentReader", "Exporter", "AppIntegrations"}
+
+// Has reports whether the set includes every interface in needed
+func (b BackendInterfaces) Has(needed BackendInterfaces) bool {
+	return b&needed == needed
+}
+
+// String lists the interfaces in the set, such as "NoteReader, NoteWriter, Exporter"
+func (b BackendInterfaces) String() string {
+	names := []string{"NoteReader"}
+	for i, name := range backendInterfaceNames {
+		if b.Has(1 << i) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// interfaceReporter is a wrapper that knows the interfaces of the backend it wraps
+type interfaceReporter interface {
+	backendInterfaces() BackendInterfaces
+}
+
+// InterfacesOf reports which focused interfaces a backend implements
+// Services built by ComposeNotesService or DecorateNotesService report their backend's interfaces,
+// not those of the stand-ins and adapters around it.
+func InterfacesOf(backend NoteReader) BackendInterfaces {
+	if wrapper, ok := backend.(interfaceReporter); ok {
+		return wrapper.backendInterfaces()
+	}
+
+	var interfaces BackendInterfaces
+	if _, ok := backend.(NoteWriter); ok {
+		interfaces |= HasNoteWriter
+	}
+	if _, ok := backend.(FolderManager); ok {
+		interfaces |= HasFolderManager
+	}
+	if _, ok := backend.(AttachmentReader); ok {
+		interfaces |= HasAttachmentReader
+	}
+	if _, ok := backend.(Exporter); ok {
+		interfaces |= HasExporter
+	}
+	if _, ok := backend.(AppIntegrations); ok {
+		interfaces |= HasAppIntegrations
+	}
+	return interfaces
+}
+
+// ComposeNotesService returns backend as a full NotesService
+// A backend that already is one is returned as is; otherwise each interface it lacks is
+// answered by a stand-in whose operations fail with ErrNotSupported.
+func ComposeNotesService(backend NoteReader) NotesService {
+	if full, ok := backend.(NotesService); ok {
+		return full
+	}
+
+	composed := &composedNotesService{
+		NoteReader:       backend,
+		NoteWriter:       unsupportedOperations{},
+		FolderManager:    unsupportedOperations{},
+		AttachmentReader: unsupportedOperations{},
+		Exporter:         unsupportedOperations{},
+		AppIntegrations:  unsupportedOperations{},
+		interfaces:       InterfacesOf(backend),
+	}
+	if writer, ok := backend.(NoteWriter); ok {
+		composed.NoteWriter = writer
+	}
+	if folders, ok := backend.(FolderManager); ok {
+		composed.FolderManager = folders
+	}
+	if attachments, ok := backend.(AttachmentReader); ok {
+		composed.AttachmentReader = attachments
+	}
+	if exporter, ok := backend.(Exporter); ok {
+		composed.Exporter = exporter
+	}
+	if apps, ok := backend.(AppIntegrations); ok {
+		composed.AppIntegrations = apps
+	}
+	return composed
+}
+
+// composedNotesService is a NotesService assembled from a partial backend and stand-ins
+type composedNotesService struct {
+	NoteReader
+	NoteWriter
+	FolderManager
+	AttachmentReader
+	Exporter
+	AppIntegrations
+	interfaces BackendInterfaces
+}
+
+// backendInterfaces reports the interfaces of the partial backend, not the stand-ins
+func (s *composedNotesService) backendInterfaces() BackendInterfaces {
+	return s.interfaces
+}
+
+// unsupportedOperations stands in for the interfaces a backend doesn't implement
+type unsupportedOperations struct{}
+
+// notSupported wraps ErrNotSupported with the failed operation, matching the built-in providers
+func notSupported(operation string) error {
+	return fmt.Errorf("failed to %s: %w", operation, ErrNotSupported)
+}
+
+func (unsupportedOperations) CreateNote(ctx context.Context, title, content string, tags []string) (*Note, error) {
+	return nil, notSupported("create note")
+}
+
+func (unsupportedOperations) UpdateNote(ctx context.Context, title, content string) error {
+	return notSupported("update note")
+}
+
+func (unsupportedOperations) UpdateNoteIfUnchanged(ctx context.Context, title, content string, precondition UpdatePrecondition) error {
+	return notSupported("update note")
+}
+
+func (unsupportedOperations) DeleteNote(ctx context.Context, title string) error {
+	return notSupported("delete note")
+}
+
+func (unsupportedOperations) UpdateNoteByID(ctx context.Context, noteID, content string) error {
+	return notSupported("update note by ID")
+}
+
+func (unsupportedOperations) DeleteNoteByID(ctx context.Context, noteID string) error {
+	return notSupported("delete note by ID")
+}
+
+func (unsupportedOperations) PinNote(ctx context.Context, title string) error {
+	return notSupported("pin note")
+}
+
+func (unsupportedOperations) AddNoteTags(ctx context.Context, title string, tags []string) error {
+	return notSupported("add tags")
+}
+
+func (unsupportedOperations) ImportHTMLFile(ctx context.Context, filePath, sourceURL, folder string) (*ImportResult, error) {
+	return nil, notSupported("import HTML")
+}
+
+func (unsupportedOperations) ClipURL(ctx context.Context, pageURL, folder string) (*Note, error) {
+	return nil, notSupported("clip URL")
+}
+
+func (unsupportedOperations) GenerateWeeklyDigest(ctx context.Context, weekStart time.Time, digestFolder string) (*Note, error) {
+	return nil, notSupported("generate digest")
+}
+
+func (unsupportedOperations) ListFolders(ctx context.Context) ([]string, error) {
+	return []string{}, notSupported("list folders")
+}
+
+func (unsupportedOperations) CreateFolder(ctx context.Context, name string, parentFolder string) error {
+	return notSupported("create folder")
+}
+
+func (unsupportedOperations) MoveNote(ctx context.Context, noteTitle string, targetFolder string) error {
+	return notSupported("move note")
+}
+
+func (unsupportedOperations) MoveNoteByID(ctx context.Context, noteID string, targetFolder string) error {
+	return notSupported("move note")
+}
+
+func (unsupportedOperations) GetFolderHierarchy(ctx context.Context) (*FolderNode, error) {
+	return nil, notSupported("get folder hierarchy")
+}
+
+func (unsupportedOperations) GetNoteAttachments(ctx context.Context, noteTitle string) ([]Attachment, error) {
+	return []Attachment{}, notSupported("list attachments")
+}
+
+func (unsupportedOperations) GetAttachmentContent(ctx context.Context, filePath string, maxSize int64) ([]byte, error) {
+	return nil, notSupported("read attachment")
+}
+
+func (unsupportedOperations) ReadAttachmentChunk(ctx context.Context, filePath string, offset, length int64) (*AttachmentChunk, error) {
+	return nil, notSupported("read attachment")
+}
+
+func (unsupportedOperations) CopyAttachment(ctx context.Context, filePath string, destDir string) (*AttachmentCopy, error) {
+	return nil, notSupported("copy attachment")
+}
+
+func (unsupportedOperations) GetAttachmentThumbnail(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*Thumbnail, error) {
+	return nil, notSupported("get thumbnail")
+}
+
+func (unsupportedOperations) TranscribeAttachment(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*Transcript, error) {
+	return nil, notSupported("transcribe attachment")
+}
+
+func (unsupportedOperations) ExportNoteMarkdown(ctx context.Context, noteTitle string) (string, error) {
+	return "", notSupported("export note")
+}
+
+func (unsupportedOperations) ExportNoteMarkdownWithOptions(ctx context.Context, noteTitle string, opts MarkdownExportOptions) (string, error) {
+	return "", notSupported("export note")
+}
+
+func (unsupportedOperations) ExportNoteText(ctx context.Context, noteTitle string) (string, error) {
+	return "", notSupported("export note")
+}
+
+func (unsupportedOperations) ExportNoteObsidian(ctx context.Context, noteTitle string, assetsDir string) (string, error) {
+	return "", notSupported("export note for Obsidian")
+}
+
+func (unsupportedOperations) OpenNote(ctx context.Context, title string) error {
+	return notSupported("open note")
+}
+
+func (unsupportedOperations) CreateReminder(ctx context.Context, noteTitle, text, list string, dueDate *time.Time) (*Reminder, error) {
+	return nil, notSupported("create reminder")
+}
+
+func (unsupportedOperations) PushActionItemsToReminders(ctx context.Context, noteTitle, list string) ([]Reminder, error) {
+	return []Reminder{}, notSupported("create reminders")
+}
+
+func (unsupportedOperations) GetTodaysEvents(ctx context.Context, query string) ([]CalendarEvent, error) {
+	return []CalendarEvent{}, notSupported("get calendar events")
+}
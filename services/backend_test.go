@@ -0,0 +1,80 @@
+// ABOUTME: Unit tests for composing a NotesService from a partial backend
+// ABOUTME: Tests interface detection, pass-through of implemented operations, and ErrNotSupported stand-ins
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// readOnlyBackend implements only NoteReader, over an in-memory store
+type readOnlyBackend struct {
+	NoteReader
+}
+
+// exportingBackend implements NoteReader and Exporter
+type exportingBackend struct {
+	NoteReader
+	Exporter
+}
+
+func TestInterfacesOf(t *testing.T) {
+	memory := NewMemoryNotesService()
+	tests := []struct {
+		name    string
+		backend NoteReader
+		want    BackendInterfaces
+	}{
+		{"full service", memory, AllBackendInterfaces},
+		{"reader only", readOnlyBackend{memory}, 0},
+		{"reader and exporter", exportingBackend{memory, memory}, HasExporter},
+		{"composed", ComposeNotesService(exportingBackend{memory, memory}), HasExporter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InterfacesOf(tt.backend); got != tt.want {
+				t.Errorf("InterfacesOf() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got := (HasNoteWriter | HasExporter).String(); got != "NoteReader, NoteWriter, Exporter" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestComposeNotesService(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryNotesService()
+	if _, err := memory.CreateNote(ctx, "Plan", "<div>Ship it</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	if ComposeNotesService(memory) != NotesService(memory) {
+		t.Error("expected a full service to be returned as is")
+	}
+
+	service := ComposeNotesService(exportingBackend{memory, memory})
+	if content, err := service.GetNoteContent(ctx, "Plan"); err != nil || content != "<div>Ship it</div>" {
+		t.Errorf("expected reads to reach the backend, got %q, %v", content, err)
+	}
+	if markdown, err := service.ExportNoteMarkdown(ctx, "Plan"); err != nil || markdown == "" {
+		t.Errorf("expected exports to reach the backend, got %q, %v", markdown, err)
+	}
+
+	if _, err := service.CreateNote(ctx, "New", "body", nil); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported creating a note, got %v", err)
+	}
+	if _, err := service.ListFolders(ctx); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported listing folders, got %v", err)
+	}
+	if _, err := service.GetNoteAttachments(ctx, "Plan"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported listing attachments, got %v", err)
+	}
+	if err := service.OpenNote(ctx, "Plan"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported opening a note, got %v", err)
+	}
+}
@@ -0,0 +1,553 @@
+// ABOUTME: Encrypted, incremental backups of the whole notes library to a local directory or S3
+// ABOUTME: Each run writes a manifest of every note plus an archive of only the bodies that changed
+
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Backup file naming: manifests and archives share the run's UTC timestamp
+const (
+	backupManifestPrefix = "manifest-"
+	backupManifestSuffix = ".json.enc"
+	backupArchivePrefix  = "notes-"
+	backupArchiveSuffix  = ".tar.gz.enc"
+	backupTimeLayout     = "20060102T150405Z"
+)
+
+// Backup encryption: "NMBACKUP1" + salt + nonce + AES-256-GCM ciphertext, keyed by PBKDF2-SHA256 of the passphrase
+const (
+	backupMagic       = "NMBACKUP1"
+	backupSaltSize    = 16
+	backupKDFRounds   = 210000
+	backupManifestVer = 1
+)
+
+// ErrBackupPassphrase indicates a backup file could not be decrypted with the given passphrase
+var ErrBackupPassphrase = errors.New("wrong backup passphrase or corrupted backup file")
+
+// BackupStore holds backup files by name
+type BackupStore interface {
+	Put(ctx context.Context, name string, data []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+	List(ctx context.Context) ([]string, error)
+}
+
+// BackupEntry is one note in a backup manifest
+// Archive and Path locate the note's body, which may live in an earlier run's archive.
+type BackupEntry struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Folder   string    `json:"folder"`
+	Modified time.Time `json:"modified"`
+	Hash     string    `json:"hash"`
+	Archive  string    `json:"archive"`
+	Path     string    `json:"path"`
+}
+
+// BackupSkip records a note left out of a backup
+type BackupSkip struct {
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// BackupManifest lists every note in the library at the time of a backup run
+type BackupManifest struct {
+	Version  int           `json:"version"`
+	Created  time.Time     `json:"created"`
+	Archive  string        `json:"archive,omitempty"` // archive written by this run; empty when nothing changed
+	Previous string        `json:"previous,omitempty"`
+	Notes    []BackupEntry `json:"notes"`
+	Skipped  []BackupSkip  `json:"skipped"`
+}
+
+// Changed returns how many notes this run stored in its own archive
+func (m *BackupManifest) Changed() int {
+	changed := 0
+	for _, entry := range m.Notes {
+		if m.Archive != "" && entry.Archive == m.Archive {
+			changed++
+		}
+	}
+	return changed
+}
+
+// OpenBackupStore returns the store for a destination: "s3://bucket/prefix" or a local directory
+func OpenBackupStore(dest string) (BackupStore, error) {
+	if strings.TrimSpace(dest) == "" {
+		return nil, fmt.Errorf("%w: a backup destination is required", ErrInvalidInput)
+	}
+	if strings.HasPrefix(dest, "s3://") {
+		return NewS3BackupStore(dest)
+	}
+	return NewLocalBackupStore(dest), nil
+}
+
+// LocalBackupStore keeps backup files in a directory
+type LocalBackupStore struct {
+	dir string
+}
+
+// NewLocalBackupStore creates a store in dir, which is created on the first write
+func NewLocalBackupStore(dir string) *LocalBackupStore {
+	return &LocalBackupStore{dir: dir}
+}
+
+// Put writes a backup file readable only by the user
+func (l *LocalBackupStore) Put(ctx context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.WriteFile(filepath.Join(l.dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get reads a backup file
+func (l *LocalBackupStore) Get(ctx context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir, filepath.Base(name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// List returns the names of the files in the directory; a missing directory holds no backups
+func (l *LocalBackupStore) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// ListBackups returns the manifest names in a store, oldest first
+func ListBackups(ctx context.Context, store BackupStore) ([]string, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, backupManifestPrefix) && strings.HasSuffix(name, backupManifestSuffix) {
+			manifests = append(manifests, name)
+		}
+	}
+	// The timestamp format sorts chronologically as text
+	sort.Strings(manifests)
+	return manifests, nil
+}
+
+// LoadBackupManifest decrypts a manifest; name may be a manifest file name, its timestamp, or "" for the latest
+func LoadBackupManifest(ctx context.Context, store BackupStore, passphrase, name string) (*BackupManifest, string, error) {
+	manifests, err := ListBackups(ctx, store)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch {
+	case name == "" && len(manifests) == 0:
+		return nil, "", fmt.Errorf("%w: no backups found", ErrInvalidInput)
+	case name == "":
+		name = manifests[len(manifests)-1]
+	case !strings.HasPrefix(name, backupManifestPrefix):
+		name = backupManifestPrefix + name + backupManifestSuffix
+	}
+
+	sealed, err := store.Get(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := openBackup(passphrase, sealed)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open %s: %w", name, err)
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return &manifest, name, nil
+}
+
+// CreateBackup backs up every note, storing only bodies that changed since the latest backup
+// Notes whose modification date matches the previous manifest are not read again. Locked notes,
+// and notes that fail to read, are listed as skipped rather than failing the run.
+func CreateBackup(ctx context.Context, notes NotesService, store BackupStore, passphrase string, now time.Time) (*BackupManifest, string, error) {
+	if passphrase == "" {
+		return nil, "", fmt.Errorf("%w: a backup passphrase is required", ErrInvalidInput)
+	}
+
+	previous := map[string]BackupEntry{}
+	var previousName string
+	if existing, err := ListBackups(ctx, store); err != nil {
+		return nil, "", err
+	} else if len(existing) > 0 {
+		manifest, name, err := LoadBackupManifest(ctx, store, passphrase, "")
+		if err != nil {
+			return nil, "", err
+		}
+		previousName = name
+		for _, entry := range manifest.Notes {
+			previous[entry.ID] = entry
+		}
+	}
+
+	library, err := notes.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list notes to back up: %w", err)
+	}
+
+	stamp := now.UTC().Format(backupTimeLayout)
+	archiveName := backupArchivePrefix + stamp + backupArchiveSuffix
+	manifest := &BackupManifest{
+		Version:  backupManifestVer,
+		Created:  now.UTC(),
+		Previous: previousName,
+		Notes:    []BackupEntry{},
+		Skipped:  []BackupSkip{},
+	}
+	bodies := map[string]string{}
+
+	for i, note := range library {
+		if note.PasswordProtected {
+			manifest.Skipped = append(manifest.Skipped, BackupSkip{Title: note.Title, Reason: "locked"})
+			continue
+		}
+
+		entry := BackupEntry{ID: note.ID, Title: note.Title, Folder: note.Folder, Modified: note.ModificationDate}
+		prior, known := previous[note.ID]
+		if known && note.ID != "" && prior.Modified.Truncate(time.Second).Equal(note.ModificationDate.Truncate(time.Second)) {
+			entry.Hash, entry.Archive, entry.Path = prior.Hash, prior.Archive, prior.Path
+			manifest.Notes = append(manifest.Notes, entry)
+			continue
+		}
+
+		body, err := notes.GetNoteContentByID(ctx, note.ID)
+		if err != nil {
+			manifest.Skipped = append(manifest.Skipped, BackupSkip{Title: note.Title, Reason: err.Error()})
+			continue
+		}
+
+		entry.Hash = ContentHash(body)
+		if known && prior.Hash == entry.Hash {
+			entry.Archive, entry.Path = prior.Archive, prior.Path
+		} else {
+			entry.Archive, entry.Path = archiveName, fmt.Sprintf("notes/%05d.html", i)
+			bodies[entry.Path] = body
+		}
+		manifest.Notes = append(manifest.Notes, entry)
+	}
+
+	if len(bodies) > 0 {
+		archive, err := writeBackupArchive(bodies)
+		if err != nil {
+			return nil, "", err
+		}
+		sealed, err := sealBackup(passphrase, archive)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := store.Put(ctx, archiveName, sealed); err != nil {
+			return nil, "", err
+		}
+		manifest.Archive = archiveName
+	}
+
+	// The manifest goes last, so an interrupted run never leaves a manifest pointing at a missing archive
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode backup manifest: %w", err)
+	}
+	sealed, err := sealBackup(passphrase, data)
+	if err != nil {
+		return nil, "", err
+	}
+	manifestName := backupManifestPrefix + stamp + backupManifestSuffix
+	if err := store.Put(ctx, manifestName, sealed); err != nil {
+		return nil, "", err
+	}
+
+	return manifest, manifestName, nil
+}
+
+// RestoreOptions controls how a backup is re-imported
+type RestoreOptions struct {
+	Folder          string // optional: restore every note into this folder instead of its original one
+	AllowDuplicates bool   // create notes even when a note with the same title exists
+}
+
+// RestoreBackup re-creates the notes in a manifest, creating missing folders
+// Notes whose titles already exist (case-insensitive) are reported as duplicates and skipped
+// unless AllowDuplicates is set.
+func RestoreBackup(ctx context.Context, notes NotesService, store BackupStore, passphrase string,
+	manifest *BackupManifest, opts RestoreOptions) (*ImportResult, error) {
+
+	bodies, err := readBackupBodies(ctx, store, passphrase, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := notes.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore backup: %w", err)
+	}
+	seen := map[string]bool{}
+	for _, note := range existing {
+		seen[strings.ToLower(note.Title)] = true
+	}
+
+	folderList, err := notes.ListFolders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore backup: %w", err)
+	}
+	folders := map[string]bool{}
+	for _, folder := range folderList {
+		folders[folder] = true
+	}
+
+	result := &ImportResult{Imported: []string{}, Duplicates: []string{}, Failed: []ImportFailure{}}
+	for _, entry := range manifest.Notes {
+		key := strings.ToLower(entry.Title)
+		if seen[key] && !opts.AllowDuplicates {
+			result.Duplicates = append(result.Duplicates, entry.Title)
+			continue
+		}
+
+		folder := entry.Folder
+		if opts.Folder != "" {
+			folder = opts.Folder
+		}
+		if err := restoreNote(ctx, notes, entry.Title, bodies[entry.Archive+"/"+entry.Path], folder, folders); err != nil {
+			result.Failed = append(result.Failed, ImportFailure{Title: entry.Title, Error: err.Error()})
+			continue
+		}
+
+		seen[key] = true
+		result.Imported = append(result.Imported, entry.Title)
+	}
+
+	return result, nil
+}
+
+// restoreNote creates one note from its backed-up HTML and moves it into folder, creating the folder if needed
+// The note is moved by the ID CreateNote returned, since a note with the same title may already exist.
+func restoreNote(ctx context.Context, notes NotesService, title, body, folder string, folders map[string]bool) error {
+	created, err := notes.CreateNote(WithHTMLBody(ctx), title, body, nil)
+	if err != nil {
+		return err
+	}
+	if folder == "" {
+		return nil
+	}
+
+	if !folders[folder] {
+		if err := notes.CreateFolder(ctx, folder, ""); err != nil {
+			return err
+		}
+		folders[folder] = true
+	}
+	return notes.MoveNoteByID(ctx, created.ID, folder)
+}
+
+// readBackupBodies decrypts every archive a manifest refers to, keyed by "archive/path"
+func readBackupBodies(ctx context.Context, store BackupStore, passphrase string, manifest *BackupManifest) (map[string]string, error) {
+	bodies := map[string]string{}
+	opened := map[string]bool{}
+
+	for _, entry := range manifest.Notes {
+		if opened[entry.Archive] {
+			continue
+		}
+		opened[entry.Archive] = true
+
+		sealed, err := store.Get(ctx, entry.Archive)
+		if err != nil {
+			return nil, err
+		}
+		archive, err := openBackup(passphrase, sealed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", entry.Archive, err)
+		}
+		files, err := readBackupArchive(archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Archive, err)
+		}
+		for path, body := range files {
+			bodies[entry.Archive+"/"+path] = body
+		}
+	}
+
+	return bodies, nil
+}
+
+// writeBackupArchive packs note bodies into a gzipped tarball, in path order for stable output
+func writeBackupArchive(files map[string]string) ([]byte, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, path := range paths {
+		body := []byte(files[path])
+		if err := tw.WriteHeader(&tar.Header{Name: path, Mode: 0o600, Size: int64(len(body))}); err != nil {
+			return nil, fmt.Errorf("failed to write backup archive: %w", err)
+		}
+		if _, err := tw.Write(body); err != nil {
+			return nil, fmt.Errorf("failed to write backup archive: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readBackupArchive unpacks a gzipped tarball of note bodies
+func readBackupArchive(data []byte) (map[string]string, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close() //nolint:errcheck // reader close failure is non-critical
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(io.LimitReader(tr, maxImportFileSize))
+		if err != nil {
+			return nil, err
+		}
+		files[header.Name] = string(body)
+	}
+}
+
+// sealBackup encrypts data with a key derived from the passphrase and a fresh salt
+func sealBackup(passphrase string, data []byte) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	aead, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := append([]byte(backupMagic), salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, []byte(backupMagic)), nil
+}
+
+// openBackup decrypts data produced by sealBackup
+func openBackup(passphrase string, sealed []byte) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, []byte(backupMagic)) || len(sealed) < len(backupMagic)+backupSaltSize {
+		return nil, fmt.Errorf("not a notes-mcp backup file")
+	}
+	rest := sealed[len(backupMagic):]
+
+	aead, err := backupCipher(passphrase, rest[:backupSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	rest = rest[backupSaltSize:]
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("not a notes-mcp backup file")
+	}
+
+	data, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], []byte(backupMagic))
+	if err != nil {
+		return nil, ErrBackupPassphrase
+	}
+	return data, nil
+}
+
+// backupCipher derives the AES-256-GCM cipher for a passphrase and salt
+func backupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2SHA256([]byte(passphrase), salt, backupKDFRounds, storeKeySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return aead, nil
+}
+
+// pbkdf2SHA256 implements PBKDF2 (RFC 8018) with HMAC-SHA256
+func pbkdf2SHA256(password, salt []byte, rounds, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	blocks := (keyLen + prf.Size() - 1) / prf.Size()
+
+	key := make([]byte, 0, blocks*prf.Size())
+	counter := make([]byte, 4)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter, uint32(block))
+		prf.Write(counter)
+		u := prf.Sum(nil)
+
+		t := append([]byte{}, u...)
+		for i := 1; i < rounds; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
@@ -0,0 +1,246 @@
+// ABOUTME: Compares two backup manifests, reporting notes created, modified, and deleted between them
+// ABOUTME: Optionally includes a line diff of each modified note's text
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// backupDiffContext is how many unchanged lines surround each change in a content diff
+const backupDiffContext = 2
+
+// backupDiffMaxCells bounds the line-comparison table; larger notes get a plain before/after diff
+const backupDiffMaxCells = 4_000_000
+
+// BackupNoteChange is one note that differs between two backups
+type BackupNoteChange struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Folder      string `json:"folder"`
+	RenamedFrom string `json:"renamed_from,omitempty"`
+	MovedFrom   string `json:"moved_from,omitempty"`
+	Diff        string `json:"diff,omitempty"`
+}
+
+// BackupDiff lists the changes from one backup to a later one
+type BackupDiff struct {
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	Changes []BackupNoteChange `json:"changes"`
+}
+
+// Count returns how many changes have the given type
+func (d *BackupDiff) Count(changeType string) int {
+	count := 0
+	for _, change := range d.Changes {
+		if change.Type == changeType {
+			count++
+		}
+	}
+	return count
+}
+
+// DiffBackups compares backup "from" with backup "to"; names are resolved as in LoadBackupManifest
+// Notes are matched by ID, falling back to title so notes restored under new IDs still line up.
+// With content set, each modified note whose body changed carries a line diff of its text.
+func DiffBackups(ctx context.Context, store BackupStore, passphrase, from, to string, content bool) (*BackupDiff, error) {
+	if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+		return nil, fmt.Errorf("%w: two backup versions are required", ErrInvalidInput)
+	}
+
+	before, fromName, err := LoadBackupManifest(ctx, store, passphrase, from)
+	if err != nil {
+		return nil, err
+	}
+	after, toName, err := LoadBackupManifest(ctx, store, passphrase, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &BackupDiff{From: fromName, To: toName, Changes: []BackupNoteChange{}}
+	pairs := matchBackupEntries(before.Notes, after.Notes)
+
+	var beforeBodies, afterBodies map[string]string
+	if content {
+		if beforeBodies, err = readBackupBodies(ctx, store, passphrase, before); err != nil {
+			return nil, err
+		}
+		if afterBodies, err = readBackupBodies(ctx, store, passphrase, after); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pair := range pairs {
+		old, current := pair[0], pair[1]
+		switch {
+		case old == nil:
+			diff.Changes = append(diff.Changes, BackupNoteChange{
+				Type: ChangeCreated, ID: current.ID, Title: current.Title, Folder: current.Folder,
+			})
+		case current == nil:
+			diff.Changes = append(diff.Changes, BackupNoteChange{
+				Type: ChangeDeleted, ID: old.ID, Title: old.Title, Folder: old.Folder,
+			})
+		case old.Hash != current.Hash || old.Title != current.Title || old.Folder != current.Folder:
+			change := BackupNoteChange{
+				Type: ChangeModified, ID: current.ID, Title: current.Title, Folder: current.Folder,
+			}
+			if old.Title != current.Title {
+				change.RenamedFrom = old.Title
+			}
+			if old.Folder != current.Folder {
+				change.MovedFrom = old.Folder
+			}
+			if content && old.Hash != current.Hash {
+				change.Diff = diffLines(
+					backupNoteLines(beforeBodies[old.Archive+"/"+old.Path]),
+					backupNoteLines(afterBodies[current.Archive+"/"+current.Path]),
+				)
+			}
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+
+	order := map[string]int{ChangeCreated: 0, ChangeModified: 1, ChangeDeleted: 2}
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		if order[diff.Changes[i].Type] != order[diff.Changes[j].Type] {
+			return order[diff.Changes[i].Type] < order[diff.Changes[j].Type]
+		}
+		return diff.Changes[i].Title < diff.Changes[j].Title
+	})
+
+	return diff, nil
+}
+
+// matchBackupEntries pairs the entries of two manifests as [before, after]; nil marks a missing side
+func matchBackupEntries(before, after []BackupEntry) [][2]*BackupEntry {
+	byID := map[string]*BackupEntry{}
+	byTitle := map[string]*BackupEntry{}
+	for i := range before {
+		byID[before[i].ID] = &before[i]
+	}
+
+	pairs := [][2]*BackupEntry{}
+	unmatched := []*BackupEntry{}
+	for i := range after {
+		entry := &after[i]
+		if old, ok := byID[entry.ID]; ok && entry.ID != "" {
+			pairs = append(pairs, [2]*BackupEntry{old, entry})
+			delete(byID, entry.ID)
+			continue
+		}
+		unmatched = append(unmatched, entry)
+	}
+
+	for _, old := range byID {
+		if _, taken := byTitle[old.Title]; !taken {
+			byTitle[old.Title] = old
+		}
+	}
+	for _, entry := range unmatched {
+		old, ok := byTitle[entry.Title]
+		if ok && byID[old.ID] == old {
+			pairs = append(pairs, [2]*BackupEntry{old, entry})
+			delete(byID, old.ID)
+			delete(byTitle, entry.Title)
+			continue
+		}
+		pairs = append(pairs, [2]*BackupEntry{nil, entry})
+	}
+
+	for _, old := range byID {
+		pairs = append(pairs, [2]*BackupEntry{old, nil})
+	}
+	return pairs
+}
+
+// backupNoteLines converts a note's HTML body to lines of plain text
+func backupNoteLines(body string) []string {
+	text := stripHTML(lineBreakPattern.ReplaceAllString(body, "\n"))
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines returns a unified-style line diff: "-" removed, "+" added, " " context, "…" skipped lines
+func diffLines(before, after []string) string {
+	type op struct {
+		kind byte
+		line string
+	}
+
+	ops := []op{}
+	if len(before)*len(after) > backupDiffMaxCells {
+		for _, line := range before {
+			ops = append(ops, op{'-', line})
+		}
+		for _, line := range after {
+			ops = append(ops, op{'+', line})
+		}
+	} else {
+		// lcs[i][j] is the longest common subsequence of before[i:] and after[j:]
+		lcs := make([][]int, len(before)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(after)+1)
+		}
+		for i := len(before) - 1; i >= 0; i-- {
+			for j := len(after) - 1; j >= 0; j-- {
+				if before[i] == after[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < len(before) || j < len(after) {
+			switch {
+			case i < len(before) && j < len(after) && before[i] == after[j]:
+				ops = append(ops, op{' ', before[i]})
+				i++
+				j++
+			case i < len(before) && (j == len(after) || lcs[i+1][j] >= lcs[i][j+1]):
+				ops = append(ops, op{'-', before[i]})
+				i++
+			default:
+				ops = append(ops, op{'+', after[j]})
+				j++
+			}
+		}
+	}
+
+	// Keep only the context lines near a change
+	keep := make([]bool, len(ops))
+	for k, o := range ops {
+		if o.kind == ' ' {
+			continue
+		}
+		for c := max(0, k-backupDiffContext); c <= min(len(ops)-1, k+backupDiffContext); c++ {
+			keep[c] = true
+		}
+	}
+
+	var b strings.Builder
+	skipped := false
+	for k, o := range ops {
+		if !keep[k] {
+			skipped = true
+			continue
+		}
+		if skipped && b.Len() > 0 {
+			b.WriteString("…\n")
+		}
+		skipped = false
+		b.WriteByte(o.kind)
+		b.WriteString(o.line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+// ABOUTME: Size guard for body searches, which read every candidate note's body through AppleScript
+// ABOUTME: Over the limit, a search switches to the Spotlight index or fails, asking for a folder or date filter
+
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultMaxBodySearchNotes is the body search limit the CLI and server use when none is configured
+// Reading a few thousand bodies already takes most of the default operation timeout.
+const DefaultMaxBodySearchNotes = 2000
+
+// SetMaxBodySearchNotes sets the most notes a body search may read through AppleScript; n <= 0 means no limit
+// Larger body searches are answered from the Spotlight index when it can, and refused otherwise.
+func (s *AppleNotesService) SetMaxBodySearchNotes(n int) {
+	s.maxBodySearchNotes = n
+}
+
+// guardBodySearch counts the notes a body search would read and, over the limit, answers it from
+// Spotlight (reporting true) or returns an error saying how to narrow it. fallback is why an
+// earlier Spotlight attempt failed, if one was made; explain, when not nil, already holds the count.
+func (s *AppleNotesService) guardBodySearch(ctx context.Context, searchIn string, opts SearchOptions,
+	fallback string, explain *SearchExplanation) ([]Note, bool, error) {
+
+	var candidates int
+	if explain != nil {
+		candidates = explain.Candidates
+	} else {
+		var err error
+		if candidates, err = s.countSearchCandidates(ctx, opts); err != nil {
+			return nil, false, err
+		}
+	}
+	if candidates <= s.maxBodySearchNotes {
+		return nil, false, nil
+	}
+
+	if opts.Backend != SearchBackendSpotlight {
+		var notes []Note
+		if notes, fallback = s.trySpotlight(ctx, searchIn, opts, explain); fallback == "" {
+			tracef(ctx, "body search of %d notes is over the limit of %d; answered from Spotlight", candidates, s.maxBodySearchNotes)
+			return notes, true, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("%w: a body search would read %d notes, more than the limit of %d, and the Spotlight index "+
+		"couldn't answer it (%s); narrow it with a folder or date filter, or raise NOTES_MCP_MAX_BODY_SEARCH_NOTES",
+		ErrInvalidInput, candidates, s.maxBodySearchNotes, fallback)
+}
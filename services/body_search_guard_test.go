@@ -0,0 +1,58 @@
+// ABOUTME: Unit tests for the body search size guard
+// ABOUTME: Tests searches under the limit, switching to Spotlight over it, and the error when Spotlight can't answer
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBodySearchGuard(t *testing.T) {
+	ctx := context.Background()
+	newService := func(count string, spotlight *mockSpotlightSearcher) (*AppleNotesService, *countingSearchExecutor) {
+		executor := &countingSearchExecutor{count: count, results: "Roadmap"}
+		service := NewAppleNotesService(executor)
+		service.spotlight = spotlight
+		service.SetMaxBodySearchNotes(100)
+		service.SetConcurrency(1) // one script per search, without folder sharding
+		return service, executor
+	}
+
+	// Under the limit the AppleScript body search runs as usual
+	service, executor := newService("100", &mockSpotlightSearcher{titles: []string{"Ignored"}})
+	notes, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBody})
+	if err != nil || len(notes) != 1 || notes[0].Title != "Roadmap" || len(executor.scripts) != 2 {
+		t.Errorf("expected the body search to run, got %+v, %v after %d scripts", notes, err, len(executor.scripts))
+	}
+
+	// Over the limit the Spotlight index answers instead
+	spotlight := &mockSpotlightSearcher{titles: []string{"Road trip"}}
+	service, executor = newService("5000", spotlight)
+	notes, err = service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBoth})
+	if err != nil || len(notes) != 1 || notes[0].Title != "Road trip" || !spotlight.called || len(executor.scripts) != 1 {
+		t.Errorf("expected Spotlight to answer, got %+v, %v after %d scripts", notes, err, len(executor.scripts))
+	}
+
+	// Spotlight can't apply note filters, so the search is refused with advice
+	service, executor = newService("5000", &mockSpotlightSearcher{titles: []string{"Ignored"}})
+	_, err = service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBody, Shared: true})
+	if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "5000 notes") || !strings.Contains(err.Error(), "folder or date filter") {
+		t.Errorf("expected the search to be refused, got %v", err)
+	}
+	if len(executor.scripts) != 1 {
+		t.Errorf("expected only the count script to run, got %d scripts", len(executor.scripts))
+	}
+
+	// Title searches and an unlimited service don't count candidates
+	service, executor = newService("5000", &mockSpotlightSearcher{})
+	if _, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road"}); err != nil || len(executor.scripts) != 1 {
+		t.Errorf("expected a title search without a count, got %v after %d scripts", err, len(executor.scripts))
+	}
+	service.SetMaxBodySearchNotes(0)
+	if _, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBody}); err != nil || len(executor.scripts) != 2 {
+		t.Errorf("expected an unlimited body search without a count, got %v after %d scripts", err, len(executor.scripts))
+	}
+}
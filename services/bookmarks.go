@@ -0,0 +1,167 @@
+// ABOUTME: Local bookmark list of frequently accessed notes, kept across MCP sessions
+// ABOUTME: Records each note's ID, title, folder, and when it was last accessed
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBookmarks bounds the list; adding past it drops the least recently accessed bookmark
+const maxBookmarks = 50
+
+// Bookmark is a saved reference to a note
+type Bookmark struct {
+	NoteID       string    `json:"note_id"`
+	Title        string    `json:"title"`
+	Folder       string    `json:"folder,omitempty"`
+	Added        time.Time `json:"added"`
+	LastAccessed time.Time `json:"last_accessed"`
+}
+
+// BookmarkStore keeps bookmarks in a JSON file
+// A mutex serializes read-modify-write cycles from concurrent tool calls in one process.
+type BookmarkStore struct {
+	mu   sync.Mutex
+	path string
+	now  func() time.Time
+}
+
+// NewBookmarkStore creates a BookmarkStore backed by the file at path, which need not exist yet
+func NewBookmarkStore(path string) *BookmarkStore {
+	return &BookmarkStore{path: path, now: time.Now}
+}
+
+// List returns bookmarks, most recently accessed first
+func (b *BookmarkStore) List() ([]Bookmark, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.load()
+}
+
+// Add bookmarks a note, or refreshes the title, folder, and access time of an existing bookmark
+func (b *BookmarkStore) Add(note Note) (*Bookmark, error) {
+	if note.ID == "" {
+		return nil, fmt.Errorf("%w: note %q has no ID to bookmark", ErrInvalidInput, note.Title)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bookmarks, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := b.now().UTC().Truncate(time.Second)
+	bookmark := Bookmark{NoteID: note.ID, Title: note.Title, Folder: note.Folder, Added: now, LastAccessed: now}
+	kept := []Bookmark{bookmark}
+	for _, existing := range bookmarks {
+		if existing.NoteID == note.ID {
+			kept[0].Added = existing.Added
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	if len(kept) > maxBookmarks {
+		kept = kept[:maxBookmarks]
+	}
+
+	if err := b.save(kept); err != nil {
+		return nil, err
+	}
+	return &kept[0], nil
+}
+
+// Remove deletes the bookmark whose note ID or title (case-insensitive) matches ref
+func (b *BookmarkStore) Remove(ref string) (*Bookmark, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bookmarks, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, bookmark := range bookmarks {
+		if bookmark.NoteID == ref || strings.EqualFold(bookmark.Title, ref) {
+			kept := append(bookmarks[:i:i], bookmarks[i+1:]...)
+			if err := b.save(kept); err != nil {
+				return nil, err
+			}
+			return &bookmark, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no bookmark for %q", ErrInvalidInput, ref)
+}
+
+// Touch marks the bookmark with the given title as accessed now, reporting whether one matched
+// Titles that are not bookmarked are ignored without writing the file.
+func (b *BookmarkStore) Touch(title string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bookmarks, err := b.load()
+	if err != nil {
+		return false, err
+	}
+
+	for i := range bookmarks {
+		if strings.EqualFold(bookmarks[i].Title, title) {
+			bookmarks[i].LastAccessed = b.now().UTC().Truncate(time.Second)
+			sortBookmarks(bookmarks)
+			return true, b.save(bookmarks)
+		}
+	}
+	return false, nil
+}
+
+// load reads the bookmark file; a missing file means no bookmarks
+func (b *BookmarkStore) load() ([]Bookmark, error) {
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Bookmark{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bookmarks: %w", err)
+	}
+
+	bookmarks := []Bookmark{}
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return nil, fmt.Errorf("failed to load bookmarks from %s: %w", b.path, err)
+	}
+
+	sortBookmarks(bookmarks)
+	return bookmarks, nil
+}
+
+// save writes bookmarks to the store file, creating its directory if needed
+func (b *BookmarkStore) save(bookmarks []Bookmark) error {
+	data, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to save bookmarks: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save bookmarks: %w", err)
+	}
+	if err := os.WriteFile(b.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to save bookmarks: %w", err)
+	}
+	return nil
+}
+
+// sortBookmarks orders bookmarks most recently accessed first
+func sortBookmarks(bookmarks []Bookmark) {
+	sort.SliceStable(bookmarks, func(i, j int) bool {
+		return bookmarks[i].LastAccessed.After(bookmarks[j].LastAccessed)
+	})
+}
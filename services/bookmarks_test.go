@@ -0,0 +1,103 @@
+// ABOUTME: Unit tests for the bookmark store
+// ABOUTME: Tests ordering by last access, refreshing, removal, touching, and the size cap
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestBookmarkStore returns a store in a temp dir whose clock advances a minute per call
+func newTestBookmarkStore(t *testing.T) *BookmarkStore {
+	t.Helper()
+	store := NewBookmarkStore(filepath.Join(t.TempDir(), "bookmarks.json"))
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	store.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	return store
+}
+
+func TestBookmarkStore(t *testing.T) {
+	store := newTestBookmarkStore(t)
+
+	first, err := store.Add(Note{ID: "id1", Title: "Roadmap", Folder: "Work"})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := store.Add(Note{ID: "id2", Title: "Standup"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	list, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 2 || list[0].Title != "Standup" || list[1].Title != "Roadmap" {
+		t.Fatalf("expected most recently accessed first, got %+v", list)
+	}
+
+	// Re-bookmarking refreshes the title and access time but keeps the added time
+	refreshed, err := store.Add(Note{ID: "id1", Title: "Roadmap 2024", Folder: "Work"})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if !refreshed.Added.Equal(first.Added) || !refreshed.LastAccessed.After(first.LastAccessed) {
+		t.Errorf("refresh should keep added and bump last accessed: %+v vs %+v", refreshed, first)
+	}
+
+	touched, err := store.Touch("standup")
+	if err != nil || !touched {
+		t.Fatalf("Touch = %v, %v; want true", touched, err)
+	}
+	if touched, _ := store.Touch("Not Bookmarked"); touched {
+		t.Error("Touch should ignore notes that are not bookmarked")
+	}
+	list, _ = store.List()
+	if list[0].Title != "Standup" {
+		t.Errorf("touched bookmark should sort first, got %+v", list)
+	}
+
+	removed, err := store.Remove("id1")
+	if err != nil || removed.Title != "Roadmap 2024" {
+		t.Fatalf("Remove by ID = %+v, %v", removed, err)
+	}
+	if _, err := store.Remove("STANDUP"); err != nil {
+		t.Fatalf("Remove by title failed: %v", err)
+	}
+	if _, err := store.Remove("Standup"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("removing a missing bookmark should fail with ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestBookmarkStoreLimits(t *testing.T) {
+	store := newTestBookmarkStore(t)
+
+	if _, err := store.Add(Note{Title: "No ID"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a note without an ID, got %v", err)
+	}
+
+	for i := 0; i <= maxBookmarks; i++ {
+		if _, err := store.Add(Note{ID: fmt.Sprintf("id%d", i), Title: fmt.Sprintf("Note %d", i)}); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	list, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != maxBookmarks {
+		t.Fatalf("got %d bookmarks, want %d", len(list), maxBookmarks)
+	}
+	for _, bookmark := range list {
+		if bookmark.NoteID == "id0" {
+			t.Error("the least recently accessed bookmark should have been dropped")
+		}
+	}
+}
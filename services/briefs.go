@@ -0,0 +1,198 @@
+// ABOUTME: Local record of each folder's canonical "project brief" note, kept across MCP sessions
+// ABOUTME: Briefs point at note IDs so they survive renames, and are looked up by folder name or path
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FolderBrief names the note to start from when working in a folder
+type FolderBrief struct {
+	Folder string    `json:"folder"` // the folder's full path, such as "Work/Apollo"
+	NoteID string    `json:"note_id"`
+	Title  string    `json:"title"` // the note's title when the brief was set or last resolved
+	Set    time.Time `json:"set"`
+}
+
+// BriefStore keeps folder briefs in a JSON file
+// A mutex serializes read-modify-write cycles from concurrent tool calls in one process.
+type BriefStore struct {
+	mu   sync.Mutex
+	path string
+	now  func() time.Time
+}
+
+// NewBriefStore creates a BriefStore backed by the file at path, which need not exist yet
+func NewBriefStore(path string) *BriefStore {
+	return &BriefStore{path: path, now: time.Now}
+}
+
+// List returns every brief, ordered by folder path
+func (b *BriefStore) List() ([]FolderBrief, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.load()
+}
+
+// Set makes note the brief of the folder at folderPath, replacing any earlier brief
+func (b *BriefStore) Set(folderPath string, note Note) (*FolderBrief, error) {
+	if note.ID == "" {
+		return nil, fmt.Errorf("%w: note %q has no ID to record as a brief", ErrInvalidInput, note.Title)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	briefs, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+
+	brief := FolderBrief{Folder: folderPath, NoteID: note.ID, Title: note.Title, Set: b.now().UTC().Truncate(time.Second)}
+	kept := []FolderBrief{brief}
+	for _, existing := range briefs {
+		if !strings.EqualFold(existing.Folder, folderPath) {
+			kept = append(kept, existing)
+		}
+	}
+
+	if err := b.save(kept); err != nil {
+		return nil, err
+	}
+	return &brief, nil
+}
+
+// Get returns the brief of the folder named by folder, a full path or a plain folder name
+// (case-insensitive), or nil when none is set. A path match wins over a name match.
+func (b *BriefStore) Get(folder string) (*FolderBrief, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	briefs, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	return findBrief(briefs, folder), nil
+}
+
+// Remove deletes the brief of the folder named by folder
+func (b *BriefStore) Remove(folder string) (*FolderBrief, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	briefs, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+
+	brief := findBrief(briefs, folder)
+	if brief == nil {
+		return nil, fmt.Errorf("%w: no brief is set for folder %q", ErrInvalidInput, folder)
+	}
+	kept := []FolderBrief{}
+	for _, existing := range briefs {
+		if existing.Folder != brief.Folder {
+			kept = append(kept, existing)
+		}
+	}
+	if err := b.save(kept); err != nil {
+		return nil, err
+	}
+	return brief, nil
+}
+
+// Resolve returns the folder's brief with its title refreshed from the note ID, or nil when
+// none is set. A renamed note's new title is saved back to the store.
+func (b *BriefStore) Resolve(ctx context.Context, notes NoteTitleLookup, folder string) (*FolderBrief, error) {
+	brief, err := b.Get(folder)
+	if err != nil || brief == nil {
+		return brief, err
+	}
+
+	title, err := notes.GetNoteTitleByID(ctx, brief.NoteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the brief of %s (it may have been deleted): %w", brief.Folder, err)
+	}
+	if title == brief.Title {
+		return brief, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	briefs, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range briefs {
+		if briefs[i].Folder == brief.Folder && briefs[i].NoteID == brief.NoteID {
+			briefs[i].Title = title
+		}
+	}
+	brief.Title = title
+	return brief, b.save(briefs)
+}
+
+// findBrief matches folder against brief paths, then against their last segment
+func findBrief(briefs []FolderBrief, folder string) *FolderBrief {
+	folder = strings.Trim(strings.TrimSpace(folder), "/")
+	for i := range briefs {
+		if strings.EqualFold(briefs[i].Folder, folder) {
+			return &briefs[i]
+		}
+	}
+	for i := range briefs {
+		name := briefs[i].Folder[strings.LastIndex(briefs[i].Folder, "/")+1:]
+		if strings.EqualFold(name, folder) {
+			return &briefs[i]
+		}
+	}
+	return nil
+}
+
+// load reads the brief file; a missing file means no briefs
+func (b *BriefStore) load() ([]FolderBrief, error) {
+	data, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []FolderBrief{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load folder briefs: %w", err)
+	}
+
+	briefs := []FolderBrief{}
+	if err := json.Unmarshal(data, &briefs); err != nil {
+		return nil, fmt.Errorf("failed to load folder briefs from %s: %w", b.path, err)
+	}
+
+	sort.SliceStable(briefs, func(i, j int) bool {
+		return strings.ToLower(briefs[i].Folder) < strings.ToLower(briefs[j].Folder)
+	})
+	return briefs, nil
+}
+
+// save writes briefs to the store file, creating its directory if needed
+func (b *BriefStore) save(briefs []FolderBrief) error {
+	data, err := json.MarshalIndent(briefs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to save folder briefs: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save folder briefs: %w", err)
+	}
+	if err := os.WriteFile(b.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to save folder briefs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Unit tests for the folder brief store
+// ABOUTME: Tests setting, replacing, lookup by path or name, removal, and following renamed notes
+
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBriefStore(t *testing.T) {
+	store := NewBriefStore(filepath.Join(t.TempDir(), "briefs.json"))
+	store.now = func() time.Time { return time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC) }
+
+	if brief, err := store.Get("Work"); err != nil || brief != nil {
+		t.Fatalf("Get on an empty store = %+v, %v; want nil", brief, err)
+	}
+
+	if _, err := store.Set("Work/Apollo", Note{ID: "id1", Title: "Apollo Overview"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := store.Set("Personal", Note{ID: "id2", Title: "Life Admin"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	replaced, err := store.Set("work/apollo", Note{ID: "id3", Title: "Apollo Brief"})
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if replaced.Set.IsZero() {
+		t.Error("expected the set time to be recorded")
+	}
+
+	list, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 2 || list[0].Folder != "Personal" || list[1].NoteID != "id3" {
+		t.Fatalf("expected one brief per folder ordered by path, got %+v", list)
+	}
+
+	for _, folder := range []string{"Work/Apollo", "APOLLO", "/work/apollo/"} {
+		brief, err := store.Get(folder)
+		if err != nil || brief == nil || brief.Title != "Apollo Brief" {
+			t.Errorf("Get(%q) = %+v, %v; want the Apollo brief", folder, brief, err)
+		}
+	}
+
+	if _, err := store.Set("Work", Note{Title: "No ID"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a note without an ID, got %v", err)
+	}
+
+	if _, err := store.Remove("personal"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := store.Remove("personal"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput removing a missing brief, got %v", err)
+	}
+}
+
+// TestBriefStoreResolve tests that a brief follows its note through a rename
+func TestBriefStoreResolve(t *testing.T) {
+	ctx := context.Background()
+	store := NewBriefStore(filepath.Join(t.TempDir(), "briefs.json"))
+	if _, err := store.Set("Apollo", Note{ID: "id1", Title: "Apollo Overview"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	notes := titleLookup{"id1": "Apollo Brief"}
+
+	brief, err := store.Resolve(ctx, notes, "Apollo")
+	if err != nil || brief.Title != "Apollo Brief" {
+		t.Fatalf("Resolve = %+v, %v; want the new title", brief, err)
+	}
+	if saved, _ := store.Get("Apollo"); saved.Title != "Apollo Brief" {
+		t.Errorf("expected the new title saved, got %q", saved.Title)
+	}
+
+	if _, err := store.Resolve(ctx, titleLookup{}, "Apollo"); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("expected ErrNoteNotFound for a deleted brief, got %v", err)
+	}
+}
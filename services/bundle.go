@@ -0,0 +1,388 @@
+// ABOUTME: Note bundles combining several notes into one markdown document
+// ABOUTME: Each note gets a heading and metadata front matter; a character or token budget trims notes to fit
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxBundleNotes caps the notes one bundle can hold
+const MaxBundleNotes = 50
+
+// Bundle priorities, deciding which notes keep their full text when a budget runs short
+const (
+	BundlePriorityOrder  = "order"  // the order the notes were requested in
+	BundlePriorityRecent = "recent" // most recently modified first
+)
+
+// How a note was fit into a bundle's budget
+const (
+	BundleFitFull    = "full"
+	BundleFitTrimmed = "trimmed"
+	BundleFitOutline = "outline"
+	BundleFitOmitted = "omitted"
+)
+
+// charsPerToken approximates the characters of markdown per model token, for token budgets
+const charsPerToken = 4
+
+// minTrimmedBodyChars is the least body worth keeping when trimming a note; with less room, the
+// note's outline is used instead
+const minTrimmedBodyChars = 200
+
+// BundleBudget limits the size of a bundle's markdown
+// MaxTokens is converted at charsPerToken characters per token; when both limits are set the
+// smaller wins, and zero means unlimited.
+type BundleBudget struct {
+	MaxChars  int
+	MaxTokens int
+	Priority  string // BundlePriorityOrder (default) or BundlePriorityRecent
+}
+
+// limit checks the budget and returns it in characters, or 0 when unlimited
+func (b BundleBudget) limit() (int, error) {
+	if b.MaxChars < 0 || b.MaxTokens < 0 {
+		return 0, fmt.Errorf("%w: budgets cannot be negative", ErrInvalidInput)
+	}
+	switch b.Priority {
+	case "", BundlePriorityOrder, BundlePriorityRecent:
+	default:
+		return 0, fmt.Errorf("%w: priority must be '%s' or '%s'", ErrInvalidInput, BundlePriorityOrder, BundlePriorityRecent)
+	}
+
+	limit := b.MaxChars
+	if tokens := b.MaxTokens * charsPerToken; tokens > 0 && (limit == 0 || tokens < limit) {
+		limit = tokens
+	}
+	return limit, nil
+}
+
+// NoteBundleEntry is one note of a bundle, or the reason it couldn't be read
+type NoteBundleEntry struct {
+	Ref      string `json:"ref"` // the title or ID the note was requested by
+	Note     *Note  `json:"note,omitempty"`
+	Markdown string `json:"markdown,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Fit      string `json:"fit,omitempty"`   // how Fit shrank the note; empty when no budget applied
+	Chars    int    `json:"chars,omitempty"` // the note's full markdown length, once Fit has run
+}
+
+// NoteBundle is a set of notes read together, in the order they were requested
+type NoteBundle struct {
+	Entries []NoteBundleEntry `json:"entries"`
+	Budget  int               `json:"budget,omitempty"` // the character budget Fit applied
+}
+
+// BuildNoteBundle reads each referenced note's metadata and markdown body
+// A reference is a note title or, when no note has that title, a note ID. Repeated references are
+// read once. A note that can't be read is kept as an entry with its error, so one bad reference
+// doesn't lose the rest of the bundle.
+func BuildNoteBundle(ctx context.Context, notes NotesService, refs []string) (*NoteBundle, error) {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref != "" && !seen[ref] {
+			seen[ref] = true
+			unique = append(unique, ref)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("%w: at least one note title or ID is required", ErrInvalidInput)
+	}
+	if len(unique) > MaxBundleNotes {
+		return nil, fmt.Errorf("%w: a bundle holds at most %d notes, got %d", ErrInvalidInput, MaxBundleNotes, len(unique))
+	}
+
+	bundle := &NoteBundle{Entries: []NoteBundleEntry{}}
+	for _, ref := range unique {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("failed to build note bundle: %w", err)
+		}
+
+		entry := NoteBundleEntry{Ref: ref}
+		note, err := resolveBundleNote(ctx, notes, ref)
+		if err == nil {
+			entry.Note = note
+			entry.Markdown, err = notes.ExportNoteMarkdown(ctx, note.Title)
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		bundle.Entries = append(bundle.Entries, entry)
+	}
+	return bundle, nil
+}
+
+// resolveBundleNote looks a reference up as a title, then as a note ID
+func resolveBundleNote(ctx context.Context, notes NotesService, ref string) (*Note, error) {
+	note, err := notes.GetNoteMetadata(ctx, ref)
+	if err == nil || !errors.Is(err, ErrNoteNotFound) {
+		return note, err
+	}
+
+	title, idErr := notes.GetNoteTitleByID(ctx, ref)
+	if idErr != nil {
+		// Report the title lookup, which carries any "did you mean" suggestions
+		return nil, err
+	}
+	return notes.GetNoteMetadata(ctx, title)
+}
+
+// Fit shrinks the bundle to a budget, reordering it first when budget.Priority is recent
+// Notes are taken in priority order: each is kept whole if it fits after leaving room for the outlines
+// of the notes after it, otherwise trimmed at a paragraph break, cut to its outline (headings only),
+// or omitted. The truncation report Markdown appends is counted too, so the document stays within
+// budget unless it is too small for even the report. Notes that couldn't be read keep their error sections.
+func (b *NoteBundle) Fit(budget BundleBudget) error {
+	limit, err := budget.limit()
+	if err != nil {
+		return err
+	}
+	if budget.Priority == BundlePriorityRecent {
+		sort.SliceStable(b.Entries, func(i, j int) bool {
+			a, c := b.Entries[i].Note, b.Entries[j].Note
+			if a == nil || c == nil {
+				return c == nil && a != nil
+			}
+			return a.ModificationDate.After(c.ModificationDate)
+		})
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	b.Budget = limit
+	// Error sections are always shown, so they come off the top
+	remaining := limit - len(b.heading(len(b.Entries)))
+	for _, entry := range b.Entries {
+		if entry.Note == nil || entry.Error != "" {
+			remaining -= len(entry.section())
+		}
+	}
+
+	// Each note is owed room for at least its outline, or failing that its report line, before
+	// earlier notes are trimmed to fill the rest
+	outlines := make([]string, len(b.Entries))
+	laterOutlines := make([]int, len(b.Entries)+1)
+	laterOmits := make([]int, len(b.Entries)+1)
+	for i := len(b.Entries) - 1; i >= 0; i-- {
+		laterOutlines[i], laterOmits[i] = laterOutlines[i+1], laterOmits[i+1]
+		entry := &b.Entries[i]
+		if entry.Note == nil || entry.Error != "" {
+			continue
+		}
+		entry.Chars = len(strings.TrimSpace(entry.Markdown))
+		outlines[i] = markdownOutline(entry.Markdown, entry.Note.Title)
+		outline := NoteBundleEntry{Note: entry.Note, Markdown: outlines[i], Fit: BundleFitOutline, Chars: entry.Chars}
+		omitted := NoteBundleEntry{Note: entry.Note, Fit: BundleFitOmitted, Chars: entry.Chars}
+		laterOutlines[i] += len(outline.section()) + len(outline.reportLine())
+		laterOmits[i] += len(omitted.reportLine())
+	}
+
+	reported := false
+	for i := range b.Entries {
+		entry := &b.Entries[i]
+		if entry.Note == nil || entry.Error != "" {
+			continue
+		}
+
+		// Anything but the full note adds a line to the truncation report, and the first its heading
+		reserve, later := 0, laterOutlines[i+1]
+		if !reported {
+			reserve = len(b.reportHeading())
+			if later > 0 {
+				later += reserve
+			}
+		}
+
+		entry.Fit = BundleFitFull
+		if size := len(entry.section()); size <= remaining-later {
+			remaining -= size
+			continue
+		}
+
+		markdown := entry.Markdown
+		entry.Fit, entry.Markdown = BundleFitTrimmed, ""
+		// The notice and report line each print the kept length, at most as wide as the full one
+		overhead := len(entry.section()) + len(entry.reportLine()) + reserve + 2*len(strconv.Itoa(entry.Chars))
+		if body := remaining - later - overhead; body >= minTrimmedBodyChars {
+			entry.Markdown = trimMarkdown(markdown, body)
+		} else {
+			entry.Fit, entry.Markdown = BundleFitOutline, outlines[i]
+			if len(entry.section())+len(entry.reportLine())+reserve > remaining-laterOmits[i+1] {
+				entry.Fit, entry.Markdown = BundleFitOmitted, ""
+			}
+		}
+		remaining -= len(entry.section()) + len(entry.reportLine()) + reserve
+		reported = true
+	}
+	return nil
+}
+
+// heading is the bundle's first line
+func (b *NoteBundle) heading(read int) string {
+	return fmt.Sprintf("# Note bundle (%d of %d notes)\n", read, len(b.Entries))
+}
+
+// reportHeading opens the list of notes Fit shrank
+func (b *NoteBundle) reportHeading() string {
+	return fmt.Sprintf("\n## Truncated to fit the %d character budget\n\n", b.Budget)
+}
+
+// Markdown renders the bundle as one document: a "## <title>" section per note, each opened by
+// YAML front matter with the note's metadata, then a list of any notes Fit shrank
+func (b *NoteBundle) Markdown() string {
+	var out strings.Builder
+	read := 0
+	for _, entry := range b.Entries {
+		if entry.Note != nil && entry.Error == "" {
+			read++
+		}
+	}
+	out.WriteString(b.heading(read))
+
+	var report strings.Builder
+	for _, entry := range b.Entries {
+		out.WriteString(entry.section())
+		if entry.Fit != "" && entry.Fit != BundleFitFull {
+			report.WriteString(entry.reportLine())
+		}
+	}
+	if report.Len() > 0 {
+		out.WriteString(b.reportHeading())
+		out.WriteString(report.String())
+	}
+	return out.String()
+}
+
+// section renders an entry's part of the bundle, as shrunk by Fit
+func (e *NoteBundleEntry) section() string {
+	if e.Note == nil || e.Error != "" {
+		return fmt.Sprintf("\n## %s\n\n> Could not read this note: %s\n", e.Ref, e.Error)
+	}
+	if e.Fit == BundleFitOmitted {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", e.Note.Title)
+	b.WriteString(formatBundleFrontMatter(e.Note))
+	switch e.Fit {
+	case BundleFitTrimmed:
+		fmt.Fprintf(&b, "%s\n\n> Trimmed to fit the budget: %d of %d characters shown.\n", e.Markdown, len(e.Markdown), e.Chars)
+	case BundleFitOutline:
+		fmt.Fprintf(&b, "> Outline only; the full note is %d characters.\n\n%s\n", e.Chars, e.Markdown)
+	default:
+		b.WriteString(strings.TrimSpace(e.Markdown))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// reportLine describes how Fit shrank an entry
+func (e *NoteBundleEntry) reportLine() string {
+	switch e.Fit {
+	case BundleFitTrimmed:
+		return fmt.Sprintf("- %s: trimmed to %d of %d characters\n", e.Note.Title, len(e.Markdown), e.Chars)
+	case BundleFitOutline:
+		return fmt.Sprintf("- %s: outline only (%d characters)\n", e.Note.Title, e.Chars)
+	default:
+		return fmt.Sprintf("- %s: omitted (%d characters)\n", e.Note.Title, e.Chars)
+	}
+}
+
+// trimMarkdown cuts markdown to at most limit bytes, preferring a paragraph or line break in
+// the second half of the kept text, then a space, over cutting mid-word
+func trimMarkdown(markdown string, limit int) string {
+	markdown = strings.TrimSpace(markdown)
+	if len(markdown) <= limit {
+		return markdown
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(markdown[cut]) {
+		cut--
+	}
+	kept := markdown[:cut]
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(kept, sep); i > cut/2 {
+			kept = kept[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(kept)
+}
+
+// markdownOutline lists a markdown document's headings as a nested list, skipping one that repeats the title
+func markdownOutline(markdown, title string) string {
+	lines := []string{}
+	for _, line := range strings.Split(markdown, "\n") {
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level == 0 || level > 6 || !strings.HasPrefix(line[level:], " ") {
+			continue
+		}
+		heading := strings.TrimSpace(line[level:])
+		if heading == "" || strings.EqualFold(heading, title) {
+			continue
+		}
+		lines = append(lines, strings.Repeat("  ", level-1)+"- "+heading)
+	}
+	if len(lines) == 0 {
+		return "_No headings._"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FitNoteMarkdown shrinks one note's markdown to a budget, trimming it or, with too little room
+// to keep a useful excerpt, reducing it to its outline
+// It returns the markdown unchanged, with fit BundleFitFull, when it already fits.
+func FitNoteMarkdown(markdown, title string, budget BundleBudget) (fitted, fit string, err error) {
+	limit, err := budget.limit()
+	if err != nil {
+		return "", "", err
+	}
+	if limit == 0 || len(markdown) <= limit {
+		return markdown, BundleFitFull, nil
+	}
+
+	// Size the notice with the full length, the widest the kept length can print
+	notice := "\n\n> Trimmed to fit the budget: %d characters of the note's %d.\n"
+	if room := limit - len(fmt.Sprintf(notice, len(markdown), len(markdown))); room >= minTrimmedBodyChars {
+		trimmed := trimMarkdown(markdown, room)
+		return trimmed + fmt.Sprintf(notice, len(trimmed), len(markdown)), BundleFitTrimmed, nil
+	}
+	return trimMarkdown(markdownOutline(markdown, title), limit-1) + "\n", BundleFitOutline, nil
+}
+
+// formatBundleFrontMatter renders a note's metadata as the front matter of its bundle section
+func formatBundleFrontMatter(note *Note) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %q\n", note.ID)
+	if note.Folder != "" {
+		fmt.Fprintf(&b, "folder: %q\n", note.Folder)
+	}
+	if !note.CreationDate.IsZero() {
+		fmt.Fprintf(&b, "created: %s\n", note.CreationDate.Format(time.RFC3339))
+	}
+	if !note.ModificationDate.IsZero() {
+		fmt.Fprintf(&b, "modified: %s\n", note.ModificationDate.Format(time.RFC3339))
+	}
+	if len(note.Tags) > 0 {
+		b.WriteString("tags:\n")
+		for _, tag := range note.Tags {
+			fmt.Fprintf(&b, "  - %q\n", tag)
+		}
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
@@ -0,0 +1,179 @@
+// ABOUTME: Unit tests for note bundles
+// ABOUTME: Tests title and ID references, deduplication, inline errors, and the combined markdown layout
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBuildNoteBundle tests reading notes by title and ID into one document
+func TestBuildNoteBundle(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+
+	plan, err := service.CreateNote(ctx, "Project Plan", "<div>Ship in May</div>", []string{"work"})
+	if err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if _, err := service.CreateNote(ctx, "Risks", "<div>Vendor delay</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	bundle, err := BuildNoteBundle(ctx, service, []string{"Risks", plan.ID, "Risks", "Missing"})
+	if err != nil {
+		t.Fatalf("BuildNoteBundle failed: %v", err)
+	}
+	if len(bundle.Entries) != 3 {
+		t.Fatalf("expected 3 entries after deduplication, got %d", len(bundle.Entries))
+	}
+	if bundle.Entries[1].Note == nil || bundle.Entries[1].Note.Title != "Project Plan" {
+		t.Errorf("expected the ID reference to resolve to Project Plan, got %+v", bundle.Entries[1])
+	}
+	if bundle.Entries[2].Error == "" {
+		t.Error("expected the missing note to be kept with its error")
+	}
+
+	markdown := bundle.Markdown()
+	for _, want := range []string{
+		"# Note bundle (2 of 3 notes)",
+		"## Risks\n\n---\n",
+		"## Project Plan\n\n---\nid: \"" + plan.ID + "\"\n",
+		"tags:\n  - \"work\"\n---\n\nShip in May\n",
+		"## Missing\n\n> Could not read this note:",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("bundle markdown missing %q:\n%s", want, markdown)
+		}
+	}
+	if strings.Index(markdown, "## Risks") > strings.Index(markdown, "## Project Plan") {
+		t.Error("expected notes in the order they were requested")
+	}
+}
+
+// TestBuildNoteBundleLimits tests the empty and oversized reference lists
+func TestBuildNoteBundleLimits(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+
+	if _, err := BuildNoteBundle(ctx, service, []string{" ", ""}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for no references, got %v", err)
+	}
+
+	refs := make([]string, MaxBundleNotes+1)
+	for i := range refs {
+		refs[i] = strings.Repeat("n", i+1)
+	}
+	if _, err := BuildNoteBundle(ctx, service, refs); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for too many references, got %v", err)
+	}
+}
+
+// TestNoteBundleFit tests trimming, outlines, omission, and the truncation report under a budget
+func TestNoteBundleFit(t *testing.T) {
+	long := "# Plan\n\n## Goals\n\n" + strings.Repeat("Ship the thing on time. ", 60) + "\n\n## Risks\n\n" + strings.Repeat("Vendors slip. ", 60)
+	newBundle := func() *NoteBundle {
+		older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		return &NoteBundle{Entries: []NoteBundleEntry{
+			{Ref: "Plan", Note: &Note{Title: "Plan", ID: "p", ModificationDate: older}, Markdown: long},
+			{Ref: "Status", Note: &Note{Title: "Status", ID: "s", ModificationDate: older.Add(time.Hour)}, Markdown: long},
+			{Ref: "Gone", Error: "note not found"},
+		}}
+	}
+
+	unlimited := newBundle()
+	if err := unlimited.Fit(BundleBudget{}); err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if strings.Contains(unlimited.Markdown(), "Truncated") {
+		t.Error("expected no truncation without a budget")
+	}
+
+	for _, budget := range []BundleBudget{{MaxChars: 2400}, {MaxTokens: 300}, {MaxChars: 900}, {MaxChars: 200}} {
+		bundle := newBundle()
+		if err := bundle.Fit(budget); err != nil {
+			t.Fatalf("Fit(%+v) failed: %v", budget, err)
+		}
+		markdown := bundle.Markdown()
+		if len(markdown) > bundle.Budget {
+			t.Errorf("Fit(%+v): %d characters exceeds the %d budget:\n%s", budget, len(markdown), bundle.Budget, markdown)
+		}
+		if !strings.Contains(markdown, "## Truncated to fit the") {
+			t.Errorf("Fit(%+v): expected a truncation report:\n%s", budget, markdown)
+		}
+		if !strings.Contains(markdown, "> Could not read this note: note not found") {
+			t.Errorf("Fit(%+v): expected the unreadable note to stay listed", budget)
+		}
+	}
+
+	bundle := newBundle()
+	if err := bundle.Fit(BundleBudget{MaxChars: 3000}); err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if got := []string{bundle.Entries[0].Fit, bundle.Entries[1].Fit}; got[0] != BundleFitFull || got[1] == BundleFitFull {
+		t.Errorf("expected the first note whole and the second shrunk, got %v", got)
+	}
+
+	recent := newBundle()
+	if err := recent.Fit(BundleBudget{MaxChars: 3000, Priority: BundlePriorityRecent}); err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if recent.Entries[0].Ref != "Status" || recent.Entries[0].Fit != BundleFitFull || recent.Entries[2].Ref != "Gone" {
+		t.Errorf("expected the most recent note first and kept whole, got %+v", recent.Entries)
+	}
+
+	outline := newBundle()
+	if err := outline.Fit(BundleBudget{MaxChars: 1000}); err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+	if outline.Entries[0].Fit != BundleFitTrimmed || outline.Entries[1].Fit != BundleFitOutline {
+		t.Errorf("expected the first note trimmed and the second outlined, got %q and %q", outline.Entries[0].Fit, outline.Entries[1].Fit)
+	}
+	text := outline.Markdown()
+	for _, want := range []string{"  - Goals\n  - Risks\n", "- Plan: trimmed to ", "- Status: outline only (2309 characters)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("bundle missing %q:\n%s", want, text)
+		}
+	}
+
+	for _, budget := range []BundleBudget{{MaxChars: -1}, {Priority: "alphabetical"}} {
+		if err := newBundle().Fit(budget); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Fit(%+v): expected ErrInvalidInput, got %v", budget, err)
+		}
+	}
+}
+
+// TestMarkdownOutline tests heading extraction from markdown
+func TestMarkdownOutline(t *testing.T) {
+	markdown := "# Plan\n\nIntro #not-a-heading\n\n## Goals\n\n### Q1\n\n#hashtag\n\n## Risks"
+	want := "  - Goals\n    - Q1\n  - Risks"
+	if got := markdownOutline(markdown, "Plan"); got != want {
+		t.Errorf("markdownOutline = %q, want %q", got, want)
+	}
+	if got := markdownOutline("just text", "Plan"); got != "_No headings._" {
+		t.Errorf("markdownOutline without headings = %q", got)
+	}
+}
+
+// TestFitNoteMarkdown tests shrinking a single note to a budget
+func TestFitNoteMarkdown(t *testing.T) {
+	markdown := "## Goals\n\n" + strings.Repeat("word ", 200)
+
+	if got, fit, err := FitNoteMarkdown(markdown, "Plan", BundleBudget{}); err != nil || fit != BundleFitFull || got != markdown {
+		t.Errorf("expected an unlimited budget to keep the note, got fit %q, err %v", fit, err)
+	}
+
+	got, fit, err := FitNoteMarkdown(markdown, "Plan", BundleBudget{MaxChars: 500})
+	if err != nil || fit != BundleFitTrimmed || len(got) > 500 || !strings.Contains(got, "Trimmed to fit the budget") {
+		t.Errorf("expected a trimmed note within 500 characters, got fit %q (%d chars), err %v", fit, len(got), err)
+	}
+
+	got, fit, err = FitNoteMarkdown(markdown, "Plan", BundleBudget{MaxTokens: 25})
+	if err != nil || fit != BundleFitOutline || got != "- Goals\n" {
+		t.Errorf("expected the outline for a tiny budget, got fit %q %q, err %v", fit, got, err)
+	}
+}
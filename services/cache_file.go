@@ -0,0 +1,139 @@
+// ABOUTME: On-disk store for CacheMiddleware so cached reads are shared between notes-mcp processes
+// ABOUTME: Keeps one JSON record per cached result, optionally encrypted, dropping expired and oldest entries
+
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultCacheFileLimit is how many results a CacheFile keeps when no limit is given
+const DefaultCacheFileLimit = 256
+
+// cacheRecord is one cached result: a string, or a string list when IsList is set
+type cacheRecord struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+	Text    string    `json:"text,omitempty"`
+	List    []string  `json:"list,omitempty"`
+	IsList  bool      `json:"is_list,omitempty"`
+}
+
+// CacheFile keeps cached results in a line-oriented file, one JSON record per line
+// When encryptor is set, each line is sealed before it is written.
+type CacheFile struct {
+	mu        sync.Mutex
+	path      string
+	encryptor *StoreEncryptor
+	limit     int
+}
+
+// NewCacheFile creates a CacheFile at path keeping at most limit results.
+// If limit is 0 or negative, defaults to DefaultCacheFileLimit.
+func NewCacheFile(path string, encryptor *StoreEncryptor, limit int) *CacheFile {
+	if limit <= 0 {
+		limit = DefaultCacheFileLimit
+	}
+	return &CacheFile{path: path, encryptor: encryptor, limit: limit}
+}
+
+// get returns the cached result for key and when it expires, if one is stored and still fresh at now
+func (c *CacheFile) get(key string, now time.Time) (any, time.Time, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.load()
+	if err != nil {
+		return nil, time.Time{}, false, err
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.Key != key {
+			continue
+		}
+		if !now.Before(record.Expires) {
+			return nil, time.Time{}, false, nil
+		}
+		if record.IsList {
+			return slices.Clone(record.List), record.Expires, true, nil
+		}
+		return record.Text, record.Expires, true, nil
+	}
+	return nil, time.Time{}, false, nil
+}
+
+// put stores a string or string list result for key until expires, dropping expired results,
+// the key's previous result, and the oldest results beyond the limit
+func (c *CacheFile) put(key string, value any, expires, now time.Time) error {
+	record := cacheRecord{Key: key, Expires: expires}
+	switch value := value.(type) {
+	case string:
+		record.Text = value
+	case []string:
+		record.List, record.IsList = value, true
+	default:
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records, err := c.load()
+	if err != nil {
+		return err
+	}
+	kept := []cacheRecord{}
+	for _, existing := range records {
+		if existing.Key != key && now.Before(existing.Expires) {
+			kept = append(kept, existing)
+		}
+	}
+	kept = append(kept, record)
+	if len(kept) > c.limit {
+		kept = kept[len(kept)-c.limit:]
+	}
+	return c.save(kept)
+}
+
+// clear empties the cache file
+func (c *CacheFile) clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save(nil)
+}
+
+// load reads every record, oldest first; callers hold c.mu
+func (c *CacheFile) load() ([]cacheRecord, error) {
+	lines, err := readStoreLines(c.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+	records := make([]cacheRecord, 0, len(lines))
+	for _, line := range lines {
+		var record cacheRecord
+		if err := decodeStoreLine(c.path, line, c.encryptor, &record); err != nil {
+			return nil, fmt.Errorf("failed to read cache file: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// save replaces the file with records; callers hold c.mu
+func (c *CacheFile) save(records []cacheRecord) error {
+	lines := make([][]byte, 0, len(records))
+	for _, record := range records {
+		line, err := encodeStoreLine(record, c.encryptor)
+		if err != nil {
+			return fmt.Errorf("failed to write cache file: %w", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := replaceStoreLines(c.path, lines); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
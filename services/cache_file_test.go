@@ -0,0 +1,89 @@
+// ABOUTME: Unit tests for the on-disk cache behind CacheMiddleware
+// ABOUTME: Shares a cache file between two middleware instances and checks expiry, clearing, and encryption
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCacheFileSharedBetweenProcesses tests that a second cache sharing the file is served from it
+func TestCacheFileSharedBetweenProcesses(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+	base := newMiddlewareTestService(t)
+
+	var firstCalls, secondCalls []string
+	first := DecorateNotesService(base, CacheMiddleware(time.Minute, clock, NewCacheFile(path, nil, 0)),
+		recordingMiddleware("backend", &firstCalls))
+	second := DecorateNotesService(base, CacheMiddleware(time.Minute, clock, NewCacheFile(path, nil, 0)),
+		recordingMiddleware("backend", &secondCalls))
+
+	if _, err := first.GetNoteContent(ctx, "Plan"); err != nil {
+		t.Fatalf("GetNoteContent failed: %v", err)
+	}
+	if _, err := first.ListFolders(ctx); err != nil {
+		t.Fatalf("ListFolders failed: %v", err)
+	}
+	content, err := second.GetNoteContent(ctx, "Plan")
+	if err != nil || content != "<div>Ship it</div>" || len(secondCalls) != 0 {
+		t.Errorf("expected the second cache served from the file, got %q, %v, calls %v", content, err, secondCalls)
+	}
+	if folders, err := second.ListFolders(ctx); err != nil || len(folders) == 0 || len(secondCalls) != 0 {
+		t.Errorf("expected the folder list served from the file, got %v, %v, calls %v", folders, err, secondCalls)
+	}
+
+	if err := first.UpdateNote(ctx, "Plan", "<div>Ship it Friday</div>"); err != nil {
+		t.Fatalf("UpdateNote failed: %v", err)
+	}
+	third := DecorateNotesService(base, CacheMiddleware(time.Minute, clock, NewCacheFile(path, nil, 0)))
+	if content, _ := third.GetNoteContent(ctx, "Plan"); content != "<div>Ship it Friday</div>" {
+		t.Errorf("expected a write to clear the file, got %q", content)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, _, ok, err := NewCacheFile(path, nil, 0).get(`GetNoteContent["Plan"]`, now); ok || err != nil {
+		t.Errorf("expected an expired entry to miss, got %v, %v", ok, err)
+	}
+}
+
+func TestCacheFileLimitAndEncryption(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	encryptor, err := NewStoreEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+	cache := NewCacheFile(path, encryptor, 2)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if err := cache.put(key, "secret "+key, now.Add(time.Minute), now); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+	if _, _, ok, _ := cache.get("a", now); ok {
+		t.Error("expected the oldest entry dropped beyond the limit")
+	}
+	if value, _, ok, err := cache.get("c", now); !ok || value != "secret c" || err != nil {
+		t.Errorf("expected the newest entry, got %v, %v, %v", value, ok, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("secret")) {
+		t.Errorf("cache file contains plaintext: %q", data)
+	}
+	if _, _, _, err := NewCacheFile(path, nil, 0).get("c", now); err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Errorf("expected reading without the key to fail, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+// ABOUTME: Apple Calendar integration for meeting context
+// ABOUTME: Queries Calendar.app via AppleScript for today's events matching a topic
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CalendarEvent represents an event from Apple Calendar
+type CalendarEvent struct {
+	Title     string    `json:"title"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Location  string    `json:"location,omitempty"`
+	Calendar  string    `json:"calendar"`
+	Attendees []string  `json:"attendees"`
+}
+
+// GetTodaysEvents retrieves today's calendar events whose summary contains the query
+// An empty query returns all of today's events
+func (s *AppleNotesService) GetTodaysEvents(ctx context.Context, query string) ([]CalendarEvent, error) {
+	safeQuery := s.escapeForAppleScript(query)
+
+	// Dates are emitted in ISO 8601 («class isot») so parsing does not depend on the system locale
+	// Fields are separated by "|||", attendees by ";;", and events by linefeed
+	script := fmt.Sprintf(`
+		tell application "Calendar"
+			set dayStart to current date
+			set time of dayStart to 0
+			set dayEnd to dayStart + (1 * days)
+			set output to ""
+			repeat with cal in calendars
+				set calName to name of cal
+				set matchingEvents to (every event of cal whose start date ≥ dayStart and start date < dayEnd and summary contains "%s")
+				repeat with e in matchingEvents
+					set attendeeNames to {}
+					try
+						repeat with a in attendees of e
+							set end of attendeeNames to display name of a
+						end repeat
+					end try
+					set oldDelimiters to AppleScript's text item delimiters
+					set AppleScript's text item delimiters to ";;"
+					set attendeeText to attendeeNames as string
+					set AppleScript's text item delimiters to oldDelimiters
+					set eventLocation to ""
+					try
+						set eventLocation to location of e
+						if eventLocation is missing value then set eventLocation to ""
+					end try
+					set startText to (start date of e as «class isot» as string)
+					set endText to (end date of e as «class isot» as string)
+					set output to output & (summary of e) & "|||" & startText & "|||" & endText & "|||" & eventLocation & "|||" & calName & "|||" & attendeeText & linefeed
+				end repeat
+			end repeat
+			return output
+		end tell
+	`, safeQuery)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return []CalendarEvent{}, fmt.Errorf("failed to get calendar events: %w", detectedErr)
+	}
+
+	return parseCalendarEvents(stdout), nil
+}
+
+// parseCalendarEvents parses the linefeed/"|||" delimited event output from Calendar.app
+// Events are returned sorted by start time; malformed lines are skipped
+func parseCalendarEvents(output string) []CalendarEvent {
+	events := []CalendarEvent{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "|||")
+		if len(fields) != 6 {
+			continue
+		}
+
+		start, err := time.ParseInLocation("2006-01-02T15:04:05", fields[1], time.Local)
+		if err != nil {
+			continue
+		}
+		end, err := time.ParseInLocation("2006-01-02T15:04:05", fields[2], time.Local)
+		if err != nil {
+			end = start
+		}
+
+		attendees := []string{}
+		for _, name := range strings.Split(fields[5], ";;") {
+			if name = strings.TrimSpace(name); name != "" {
+				attendees = append(attendees, name)
+			}
+		}
+
+		events = append(events, CalendarEvent{
+			Title:     fields[0],
+			Start:     start,
+			End:       end,
+			Location:  fields[3],
+			Calendar:  fields[4],
+			Attendees: attendees,
+		})
+	}
+
+	// Calendars are iterated one at a time, so merge their events chronologically
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	return events
+}
@@ -0,0 +1,72 @@
+// ABOUTME: Unit tests for the Apple Calendar integration
+// ABOUTME: Verifies event output parsing and error handling
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestParseCalendarEvents tests parsing of Calendar.app event output
+func TestParseCalendarEvents(t *testing.T) {
+	output := "Sprint Review|||2024-07-01T14:00:00|||2024-07-01T15:00:00|||Room 4|||Work|||Alice;;Bob\n" +
+		"Sprint Planning|||2024-07-01T09:00:00|||2024-07-01T10:00:00||||||Home|||\n" +
+		"malformed line\n"
+
+	events := parseCalendarEvents(output)
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	// Events should be sorted by start time across calendars
+	if events[0].Title != "Sprint Planning" {
+		t.Errorf("first event = %q, want %q", events[0].Title, "Sprint Planning")
+	}
+	if len(events[0].Attendees) != 0 {
+		t.Errorf("expected no attendees, got %v", events[0].Attendees)
+	}
+
+	review := events[1]
+	if review.Location != "Room 4" || review.Calendar != "Work" {
+		t.Errorf("unexpected location/calendar: %q/%q", review.Location, review.Calendar)
+	}
+	if len(review.Attendees) != 2 || review.Attendees[0] != "Alice" || review.Attendees[1] != "Bob" {
+		t.Errorf("unexpected attendees: %v", review.Attendees)
+	}
+	if review.Start.Hour() != 14 || review.End.Hour() != 15 {
+		t.Errorf("unexpected times: %v - %v", review.Start, review.End)
+	}
+}
+
+// TestGetTodaysEventsEmpty tests that no events yields an empty slice
+func TestGetTodaysEventsEmpty(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{stdout: ""})
+
+	events, err := service.GetTodaysEvents(context.Background(), "Sprint")
+	if err != nil {
+		t.Fatalf("GetTodaysEvents failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected 0 events, got %d", len(events))
+	}
+}
+
+// TestGetTodaysEventsPermissionDenied tests error detection for Calendar access
+func TestGetTodaysEventsPermissionDenied(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{
+		stderr: "Not allowed to send Apple events to Calendar. (-1743)",
+		err:    errors.New("exit status 1"),
+	})
+
+	_, err := service.GetTodaysEvents(context.Background(), "Sprint")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Expected permission denied error, got %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+// ABOUTME: Storage cleanup for Apple Notes: Recently Deleted and orphaned attachment files
+// ABOUTME: Lists and empties the trash folder and finds media files no note references
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// recentlyDeletedFolder is the name Apple Notes gives its trash folder (English locale)
+const recentlyDeletedFolder = "Recently Deleted"
+
+// OrphanedFile is a media file in the Notes container that no note's attachments refer to
+type OrphanedFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// NotesContainerDir returns the Apple Notes group container directory for the current user
+func NotesContainerDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, "Library", "Group Containers", "group.com.apple.notes"), nil
+}
+
+// ListRecentlyDeleted returns the titles of notes in the Recently Deleted folder
+func (s *AppleNotesService) ListRecentlyDeleted(ctx context.Context) ([]string, error) {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				try
+					set trashFolder to folder "%s"
+				on error
+					return ""
+				end try
+				set output to {}
+				repeat with n in notes of trashFolder
+					set end of output to name of n
+				end repeat
+				set oldDelimiters to AppleScript's text item delimiters
+				set AppleScript's text item delimiters to "|||"
+				set result to output as string
+				set AppleScript's text item delimiters to oldDelimiters
+				return result
+			end tell
+		end tell
+	`, s.iCloudAccount, recentlyDeletedFolder)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return []string{}, fmt.Errorf("failed to list recently deleted notes: %w", detectedErr)
+	}
+
+	return splitDelimited(stdout), nil
+}
+
+// EmptyRecentlyDeleted permanently deletes every note in Recently Deleted and returns how many were removed
+func (s *AppleNotesService) EmptyRecentlyDeleted(ctx context.Context) (int, error) {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				try
+					set trashFolder to folder "%s"
+				on error
+					return 0
+				end try
+				set trashedNotes to (get notes of trashFolder)
+				set deletedCount to count of trashedNotes
+				repeat with n in trashedNotes
+					delete n
+				end repeat
+				return deletedCount
+			end tell
+		end tell
+	`, s.iCloudAccount, recentlyDeletedFolder)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return 0, fmt.Errorf("failed to empty recently deleted: %w", detectedErr)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse deleted note count %q: %w", strings.TrimSpace(stdout), err)
+	}
+	return count, nil
+}
+
+// FindOrphanedAttachments walks the Media directories under containerDir and returns files whose
+// names match no attachment of any note in any account (including notes in Recently Deleted).
+// Matching is by file name, so results are candidates to review rather than safe-to-delete files.
+func (s *AppleNotesService) FindOrphanedAttachments(ctx context.Context, containerDir string) ([]OrphanedFile, error) {
+	referenced, err := s.listAttachmentNames(ctx)
+	if err != nil {
+		return []OrphanedFile{}, err
+	}
+
+	return findOrphanedFiles(containerDir, referenced)
+}
+
+// listAttachmentNames returns the set of attachment names across all accounts
+func (s *AppleNotesService) listAttachmentNames(ctx context.Context) (map[string]bool, error) {
+	script := `
+		tell application "Notes"
+			set output to {}
+			repeat with acc in accounts
+				repeat with n in notes of acc
+					repeat with att in attachments of n
+						try
+							set end of output to name of att
+						end try
+					end repeat
+				end repeat
+			end repeat
+			set oldDelimiters to AppleScript's text item delimiters
+			set AppleScript's text item delimiters to "|||"
+			set result to output as string
+			set AppleScript's text item delimiters to oldDelimiters
+			return result
+		end tell
+	`
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return nil, fmt.Errorf("failed to list attachments: %w", detectedErr)
+	}
+
+	names := map[string]bool{}
+	for _, name := range splitDelimited(stdout) {
+		names[name] = true
+	}
+	return names, nil
+}
+
+// findOrphanedFiles walks <containerDir>/Accounts/*/Media and returns unreferenced files sorted by size
+func findOrphanedFiles(containerDir string, referenced map[string]bool) ([]OrphanedFile, error) {
+	mediaDirs, err := filepath.Glob(filepath.Join(containerDir, "Accounts", "*", "Media"))
+	if err != nil {
+		return []OrphanedFile{}, fmt.Errorf("failed to find media directories: %w", err)
+	}
+
+	orphans := []OrphanedFile{}
+	for _, mediaDir := range mediaDirs {
+		err := filepath.WalkDir(mediaDir, func(path string, entry fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || referenced[entry.Name()] {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			orphans = append(orphans, OrphanedFile{Path: path, Size: info.Size()})
+			return nil
+		})
+		if err != nil {
+			return []OrphanedFile{}, fmt.Errorf("failed to scan %s: %w", mediaDir, err)
+		}
+	}
+
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return orphans[i].Size > orphans[j].Size
+	})
+	return orphans, nil
+}
+
+// DirSize returns the total size in bytes of the regular files under dir
+func DirSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
+
+// splitDelimited splits "|||" delimited AppleScript output, dropping empty entries
+func splitDelimited(output string) []string {
+	items := []string{}
+	for _, item := range strings.Split(strings.TrimSpace(output), "|||") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
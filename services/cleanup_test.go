@@ -0,0 +1,73 @@
+// ABOUTME: Unit tests for Apple Notes storage cleanup
+// ABOUTME: Tests Recently Deleted listing/emptying and orphaned media detection
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestListRecentlyDeleted(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{stdout: "Old draft|||Scratch\n"})
+
+	titles, err := service.ListRecentlyDeleted(context.Background())
+	if err != nil {
+		t.Fatalf("ListRecentlyDeleted failed: %v", err)
+	}
+	if !reflect.DeepEqual(titles, []string{"Old draft", "Scratch"}) {
+		t.Errorf("unexpected titles: %v", titles)
+	}
+}
+
+func TestEmptyRecentlyDeleted(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{stdout: "3\n"})
+
+	count, err := service.EmptyRecentlyDeleted(context.Background())
+	if err != nil {
+		t.Fatalf("EmptyRecentlyDeleted failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+}
+
+func TestFindOrphanedAttachments(t *testing.T) {
+	container := t.TempDir()
+	writeFile := func(rel string, size int) {
+		path := filepath.Join(container, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("Accounts/A/Media/1111/photo.jpg", 10)
+	writeFile("Accounts/A/Media/2222/old-scan.pdf", 30)
+	writeFile("Accounts/B/Media/3333/.DS_Store", 5)
+	writeFile("Accounts/B/Media/4444/diagram.png", 20)
+	writeFile("Accounts/B/Previews/5555/ignored.png", 99)
+
+	service := NewAppleNotesService(&MockExecutor{stdout: "photo.jpg|||diagram.png"})
+
+	orphans, err := service.FindOrphanedAttachments(context.Background(), container)
+	if err != nil {
+		t.Fatalf("FindOrphanedAttachments failed: %v", err)
+	}
+	if len(orphans) != 1 || filepath.Base(orphans[0].Path) != "old-scan.pdf" || orphans[0].Size != 30 {
+		t.Errorf("unexpected orphans: %+v", orphans)
+	}
+
+	size, err := DirSize(container)
+	if err != nil {
+		t.Fatalf("DirSize failed: %v", err)
+	}
+	if size != 164 {
+		t.Errorf("DirSize = %d, want 164", size)
+	}
+}
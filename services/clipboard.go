@@ -0,0 +1,137 @@
+// ABOUTME: macOS clipboard access through pbcopy and pbpaste, for moving note text in and out by hand
+// ABOUTME: Copies a note's plain text or markdown to the clipboard and appends the clipboard's text to a note
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Clipboard formats a note can be copied in
+const (
+	ClipboardFormatText     = "text"
+	ClipboardFormatMarkdown = "markdown"
+)
+
+// Clipboard reads and writes the system clipboard's text
+type Clipboard interface {
+	Read(ctx context.Context) (string, error)
+	Write(ctx context.Context, text string) error
+}
+
+// PasteboardClipboard implements Clipboard using the macOS pbcopy and pbpaste commands
+type PasteboardClipboard struct {
+	timeout time.Duration
+}
+
+// NewPasteboardClipboard creates a PasteboardClipboard with the specified timeout.
+// If timeout is 0 or negative, defaults to 5 seconds.
+func NewPasteboardClipboard(timeout time.Duration) *PasteboardClipboard {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &PasteboardClipboard{
+		timeout: timeout,
+	}
+}
+
+// Read returns the clipboard's text with pbpaste
+func (p *PasteboardClipboard) Read(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "pbpaste")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("pbpaste failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Write replaces the clipboard's contents with text using pbcopy
+func (p *PasteboardClipboard) Write(ctx context.Context, text string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "pbcopy")
+	cmd.Stdin = strings.NewReader(text)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pbcopy failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// CopyNoteToClipboard puts a note's plain text or markdown on the clipboard, returning what was copied
+func CopyNoteToClipboard(ctx context.Context, notes NotesService, clipboard Clipboard, title, format string) (string, error) {
+	var text string
+	var err error
+	switch format {
+	case "", ClipboardFormatText:
+		text, err = notes.ExportNoteText(ctx, title)
+	case ClipboardFormatMarkdown:
+		text, err = notes.ExportNoteMarkdown(ctx, title)
+	default:
+		return "", fmt.Errorf("%w: unknown format %q (use %s or %s)", ErrInvalidInput, format, ClipboardFormatText, ClipboardFormatMarkdown)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to copy note: %w", err)
+	}
+
+	if err := clipboard.Write(ctx, text); err != nil {
+		return "", fmt.Errorf("failed to copy note: %w", err)
+	}
+	return text, nil
+}
+
+// PasteClipboardToNote appends the clipboard's text to a note, one line per clipboard line,
+// returning the text appended. The note is written only if it hasn't changed since it was read.
+func PasteClipboardToNote(ctx context.Context, notes NotesService, clipboard Clipboard, title string) (string, error) {
+	text, err := clipboard.Read(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to paste into note: %w", err)
+	}
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("%w: the clipboard has no text to paste", ErrInvalidInput)
+	}
+
+	var fragment strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			fragment.WriteString("<div><br></div>")
+			continue
+		}
+		fragment.WriteString("<div>" + html.EscapeString(line) + "</div>")
+	}
+
+	body, err := notes.GetNoteContent(ctx, title)
+	if err != nil {
+		return "", fmt.Errorf("failed to paste into note: %w", err)
+	}
+	updated, err := AppendHTML(body, fragment.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to paste into note: %w", err)
+	}
+
+	// UpdateNote turns newlines into line breaks, so drop the ones that only separate elements
+	updated = interElementNewlines.ReplaceAllString(updated, "><")
+	if err := notes.UpdateNoteIfUnchanged(ctx, title, updated, UpdatePrecondition{ExpectedHash: ContentHash(body)}); err != nil {
+		return "", fmt.Errorf("failed to paste into note: %w", err)
+	}
+	return text, nil
+}
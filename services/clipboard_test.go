@@ -0,0 +1,85 @@
+// ABOUTME: Unit tests for copying notes to and pasting into notes from the clipboard
+// ABOUTME: Uses an in-memory clipboard so the tests don't touch the system pasteboard
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// memoryClipboard is a Clipboard holding its text in memory
+type memoryClipboard struct {
+	text string
+}
+
+func (c *memoryClipboard) Read(ctx context.Context) (string, error) { return c.text, nil }
+
+func (c *memoryClipboard) Write(ctx context.Context, text string) error {
+	c.text = text
+	return nil
+}
+
+func TestCopyNoteToClipboard(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	if _, err := service.CreateNote(ctx, "Plan", "Plan", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if err := service.UpdateNote(ctx, "Plan", "<div>Plan</div><div><b>Ship</b> it</div>"); err != nil {
+		t.Fatalf("UpdateNote failed: %v", err)
+	}
+
+	clipboard := &memoryClipboard{}
+	if _, err := CopyNoteToClipboard(ctx, service, clipboard, "Plan", ""); err != nil {
+		t.Fatalf("CopyNoteToClipboard failed: %v", err)
+	}
+	if strings.Contains(clipboard.text, "<") || !strings.Contains(clipboard.text, "Ship it") {
+		t.Errorf("expected plain text on the clipboard, got %q", clipboard.text)
+	}
+
+	if _, err := CopyNoteToClipboard(ctx, service, clipboard, "Plan", ClipboardFormatMarkdown); err != nil {
+		t.Fatalf("CopyNoteToClipboard failed: %v", err)
+	}
+	if !strings.Contains(clipboard.text, "**Ship** it") {
+		t.Errorf("expected markdown on the clipboard, got %q", clipboard.text)
+	}
+
+	if _, err := CopyNoteToClipboard(ctx, service, clipboard, "Plan", "rtf"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown format, got %v", err)
+	}
+}
+
+func TestPasteClipboardToNote(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	if _, err := service.CreateNote(ctx, "Plan", "Plan", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if err := service.UpdateNote(ctx, "Plan", "<div>Plan</div>\n<div>First</div>"); err != nil {
+		t.Fatalf("UpdateNote failed: %v", err)
+	}
+
+	clipboard := &memoryClipboard{text: "a <b> & c\r\n\r\nlast\n"}
+	pasted, err := PasteClipboardToNote(ctx, service, clipboard, "Plan")
+	if err != nil {
+		t.Fatalf("PasteClipboardToNote failed: %v", err)
+	}
+	if pasted != "a <b> & c\n\nlast" {
+		t.Errorf("pasted = %q", pasted)
+	}
+	body, err := service.GetNoteContent(ctx, "Plan")
+	if err != nil {
+		t.Fatalf("GetNoteContent failed: %v", err)
+	}
+	if want := "<div>Plan</div><div>First</div><div>a &lt;b&gt; &amp; c</div><div><br/></div><div>last</div>"; body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+
+	clipboard.text = " \n"
+	if _, err := PasteClipboardToNote(ctx, service, clipboard, "Plan"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an empty clipboard, got %v", err)
+	}
+}
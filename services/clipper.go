@@ -0,0 +1,330 @@
+// ABOUTME: Web clipper that saves the readable part of a web page as a note
+// ABOUTME: Fetches a URL, picks the main article with a readability-style score, and creates a note from it
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// PageFetcher downloads a web page
+type PageFetcher interface {
+	// Fetch returns the page's HTML and the URL it was served from after redirects
+	Fetch(ctx context.Context, pageURL string) (body string, finalURL string, err error)
+}
+
+// HTTPPageFetcher implements PageFetcher with net/http
+type HTTPPageFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPPageFetcher creates an HTTPPageFetcher with the specified timeout.
+// If timeout is 0 or negative, defaults to 20 seconds.
+func NewHTTPPageFetcher(timeout time.Duration) *HTTPPageFetcher {
+	if timeout <= 0 {
+		timeout = 20 * time.Second
+	}
+
+	return &HTTPPageFetcher{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Fetch GETs pageURL and returns its body, refusing non-HTML responses and pages over maxImportFileSize
+func (f *HTTPPageFetcher) Fetch(ctx context.Context, pageURL string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "notes-mcp web clipper")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // response body close failure is non-critical
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", "", fmt.Errorf("failed to fetch page: HTTP %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "html") {
+		return "", "", fmt.Errorf("%w: %s is not an HTML page (%s)", ErrInvalidInput, pageURL, contentType)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize+1))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read page: %w", err)
+	}
+	if len(data) > maxImportFileSize {
+		return "", "", fmt.Errorf("%w: page exceeds maximum size (%d bytes)", ErrInvalidInput, maxImportFileSize)
+	}
+
+	return string(data), resp.Request.URL.String(), nil
+}
+
+// ClipURL fetches a web page and creates a note from its main article in folder (optional)
+// The note starts with the article title and a link to the page.
+func (s *AppleNotesService) ClipURL(ctx context.Context, pageURL, folder string) (*Note, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidInput)
+	}
+
+	page, finalURL, err := s.fetcher.Fetch(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clip %s: %w", pageURL, err)
+	}
+
+	title, article := ExtractArticle(page)
+	if title == "" {
+		title = parsed.Host
+	}
+
+	escapedURL := html.EscapeString(finalURL)
+	content := `<div><h1>` + html.EscapeString(title) + `</h1></div>` +
+		`<div>Source: <a href="` + escapedURL + `">` + escapedURL + `</a></div><br>` + article
+
+	note, err := s.CreateNote(ctx, title, content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clip %s: %w", pageURL, err)
+	}
+	if folder != "" {
+		if err := s.MoveNote(ctx, note.Title, folder); err != nil {
+			return nil, fmt.Errorf("failed to clip %s: %w", pageURL, err)
+		}
+		note.Folder = folder
+	}
+
+	return note, nil
+}
+
+// unlikelyCandidatePattern and likelyCandidatePattern weigh elements by class and id, as in Readability
+var (
+	unlikelyCandidatePattern = regexp.MustCompile(`(?i)comment|sidebar|footer|menu|share|social|promo|related|advert|sponsor|banner|cookie|popup|modal|subscribe|newsletter|breadcrumb|nav`)
+	likelyCandidatePattern   = regexp.MustCompile(`(?i)article|content|main|body|post|entry|story|text`)
+)
+
+// ExtractArticle returns a page's title and its main content as sanitized Notes HTML
+// Paragraphs score their parent and grandparent by length and commas; the best-scoring container,
+// discounted by how much of its text is links, is taken as the article. Pages without enough
+// paragraph text fall back to <article>, <main>, or the whole body.
+func ExtractArticle(page string) (string, string) {
+	root, err := nethtml.Parse(strings.NewReader(page))
+	if err != nil {
+		return "", SanitizeHTML(page)
+	}
+
+	title := articleTitle(root)
+	removeUnlikelyNodes(root)
+
+	scores := map[*nethtml.Node]float64{}
+	var candidates []*nethtml.Node
+	addScore := func(node *nethtml.Node, score float64) {
+		if node == nil || node.Type != nethtml.ElementNode {
+			return
+		}
+		if _, ok := scores[node]; !ok {
+			scores[node] = initialScore(node)
+			candidates = append(candidates, node)
+		}
+		scores[node] += score
+	}
+
+	walkElements(root, func(node *nethtml.Node) {
+		if node.Data != "p" && node.Data != "pre" && node.Data != "td" {
+			return
+		}
+		text := nodeText(node)
+		if len(text) < 25 {
+			return
+		}
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+		addScore(node.Parent, score)
+		if node.Parent != nil {
+			addScore(node.Parent.Parent, score/2)
+		}
+	})
+
+	var best *nethtml.Node
+	bestScore := 0.0
+	for _, candidate := range candidates {
+		score := scores[candidate] * (1 - linkDensity(candidate))
+		if best == nil || score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	if best == nil {
+		best = firstElement(root, "article", "main", "body")
+	}
+	if best == nil {
+		return title, ""
+	}
+
+	var out bytes.Buffer
+	if err := nethtml.Render(&out, best); err != nil {
+		return title, ""
+	}
+	return title, SanitizeHTML(out.String())
+}
+
+// articleTitle prefers og:title, then <title>, then the first <h1>
+func articleTitle(root *nethtml.Node) string {
+	var ogTitle, docTitle, heading string
+	walkElements(root, func(node *nethtml.Node) {
+		switch node.Data {
+		case "meta":
+			if attr(node, "property") == "og:title" && ogTitle == "" {
+				ogTitle = strings.TrimSpace(attr(node, "content"))
+			}
+		case "title":
+			if docTitle == "" {
+				docTitle = nodeText(node)
+			}
+		case "h1":
+			if heading == "" {
+				heading = nodeText(node)
+			}
+		}
+	})
+
+	for _, title := range []string{ogTitle, docTitle, heading} {
+		if title != "" {
+			return title
+		}
+	}
+	return ""
+}
+
+// removeUnlikelyNodes drops page chrome and elements whose class or id marks them as non-content
+func removeUnlikelyNodes(root *nethtml.Node) {
+	var remove []*nethtml.Node
+	walkElements(root, func(node *nethtml.Node) {
+		if droppedHTMLElements[node.Data] {
+			remove = append(remove, node)
+			return
+		}
+		if node.Data == "body" || node.Data == "html" || node.Data == "article" || node.Data == "main" {
+			return
+		}
+		classAndID := attr(node, "class") + " " + attr(node, "id")
+		if unlikelyCandidatePattern.MatchString(classAndID) && !likelyCandidatePattern.MatchString(classAndID) {
+			remove = append(remove, node)
+		}
+	})
+
+	for _, node := range remove {
+		if node.Parent != nil {
+			node.Parent.RemoveChild(node)
+		}
+	}
+}
+
+// initialScore seeds a candidate by tag and by its class and id names
+func initialScore(node *nethtml.Node) float64 {
+	score := 0.0
+	switch node.Data {
+	case "article":
+		score = 10
+	case "div", "section", "main":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "ol", "ul", "dl", "form", "li":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+
+	classAndID := attr(node, "class") + " " + attr(node, "id")
+	if likelyCandidatePattern.MatchString(classAndID) {
+		score += 25
+	}
+	if unlikelyCandidatePattern.MatchString(classAndID) {
+		score -= 25
+	}
+	return score
+}
+
+// linkDensity is the share of a node's text that sits inside links
+func linkDensity(node *nethtml.Node) float64 {
+	total := len(nodeText(node))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	walkElements(node, func(n *nethtml.Node) {
+		if n.Data == "a" {
+			linked += len(nodeText(n))
+		}
+	})
+	return float64(linked) / float64(total)
+}
+
+// walkElements calls fn for node and every element below it, parents first
+// Children are captured before fn runs so it may detach them.
+func walkElements(node *nethtml.Node, fn func(*nethtml.Node)) {
+	if node.Type == nethtml.ElementNode {
+		fn(node)
+	}
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		walkElements(child, fn)
+		child = next
+	}
+}
+
+// firstElement returns the first element with one of the given tags, trying tags in order
+func firstElement(root *nethtml.Node, tags ...string) *nethtml.Node {
+	for _, tag := range tags {
+		var found *nethtml.Node
+		walkElements(root, func(node *nethtml.Node) {
+			if found == nil && node.Data == tag {
+				found = node
+			}
+		})
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// nodeText returns the whitespace-collapsed text below node
+func nodeText(node *nethtml.Node) string {
+	var text strings.Builder
+	var collect func(*nethtml.Node)
+	collect = func(n *nethtml.Node) {
+		if n.Type == nethtml.TextNode {
+			text.WriteString(n.Data)
+			text.WriteString(" ")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(node)
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// attr returns the value of a node's attribute, or ""
+func attr(node *nethtml.Node, key string) string {
+	for _, a := range node.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
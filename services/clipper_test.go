@@ -0,0 +1,156 @@
+// ABOUTME: Unit tests for the web clipper
+// ABOUTME: Tests article extraction, the fetch-and-create flow, and the HTTP fetcher
+
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakePageFetcher returns a canned page
+type fakePageFetcher struct {
+	page string
+	err  error
+	url  string
+}
+
+func (f *fakePageFetcher) Fetch(ctx context.Context, pageURL string) (string, string, error) {
+	f.url = pageURL
+	return f.page, pageURL + "#final", f.err
+}
+
+// clipperPage has an article surrounded by navigation, a link-heavy sidebar, and comments
+const clipperPage = `<html><head>
+<meta property="og:title" content="Why Plain Text Lasts">
+<title>Why Plain Text Lasts | Example Blog</title>
+</head><body>
+<div class="site-nav"><a href="/">Home</a> <a href="/archive">Archive</a></div>
+<div class="layout">
+  <div id="sidebar"><p><a href="/a">Popular post one, with a long title here</a> <a href="/b">Another popular post, also long</a></p></div>
+  <div class="post-content">
+    <h2>The short version</h2>
+    <p>Plain text files outlive the applications that made them, because every editor, on every platform, can read them.</p>
+    <p>Formats come and go, vendors disappear, and proprietary databases rot, but a text file from 1985 still opens today.</p>
+    <p>That durability is worth more than any feature, and it is why notes belong in open formats <a href="https://example.com/formats">like these</a>.</p>
+  </div>
+  <div class="comments"><p>Great post, totally agree with everything you said here, thanks!</p></div>
+</div>
+<footer><p>Copyright Example Blog, all rights reserved, since forever and ever.</p></footer>
+</body></html>`
+
+// TestExtractArticle tests that the main content wins over navigation, sidebars, and comments
+func TestExtractArticle(t *testing.T) {
+	title, article := ExtractArticle(clipperPage)
+
+	if title != "Why Plain Text Lasts" {
+		t.Errorf("title = %q", title)
+	}
+	for _, want := range []string{"<h2>The short version</h2>", "a text file from 1985", `<a href="https://example.com/formats">like these</a>`} {
+		if !strings.Contains(article, want) {
+			t.Errorf("article missing %q:\n%s", want, article)
+		}
+	}
+	for _, unwanted := range []string{"Home", "Popular post", "Great post", "Copyright"} {
+		if strings.Contains(article, unwanted) {
+			t.Errorf("article contains %q:\n%s", unwanted, article)
+		}
+	}
+}
+
+// TestExtractArticleFallback tests pages without scoreable paragraphs
+func TestExtractArticleFallback(t *testing.T) {
+	title, article := ExtractArticle(`<html><head><title>Tiny</title></head><body><article><h1>Tiny</h1><div>Short.</div></article></body></html>`)
+	if title != "Tiny" || article != "<div><h1>Tiny</h1><div>Short.</div></div>" {
+		t.Errorf("unexpected extraction %q, %q", title, article)
+	}
+}
+
+// TestClipURL tests that the clipped note gets the title, source link, and folder
+func TestClipURL(t *testing.T) {
+	metadata := `{id:"x-coredata://clip", name:"Why Plain Text Lasts", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 1, 2024 at 10:00:00 AM", container:"Notes", shared:false, password protected:false}`
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: ""},       // CreateNote
+			{stdout: metadata}, // GetNoteMetadata
+			{stdout: ""},       // MoveNote
+		},
+	}
+	service := NewAppleNotesService(executor)
+	fetcher := &fakePageFetcher{page: clipperPage}
+	service.fetcher = fetcher
+
+	note, err := service.ClipURL(context.Background(), "https://example.com/plain-text", "Research")
+	if err != nil {
+		t.Fatalf("ClipURL failed: %v", err)
+	}
+	if note.Title != "Why Plain Text Lasts" || note.Folder != "Research" {
+		t.Errorf("unexpected note %+v", note)
+	}
+	if !strings.HasPrefix(note.Content, `<div><h1>Why Plain Text Lasts</h1></div><div>Source: <a href="https://example.com/plain-text#final">`) {
+		t.Errorf("unexpected content start: %s", note.Content)
+	}
+	if fetcher.url != "https://example.com/plain-text" {
+		t.Errorf("fetched %q", fetcher.url)
+	}
+}
+
+// TestClipURLErrors tests URL validation and fetch failures
+func TestClipURLErrors(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+	service.fetcher = &fakePageFetcher{err: errors.New("connection refused")}
+
+	for _, bad := range []string{"", "example.com", "ftp://example.com/file", "file:///etc/passwd"} {
+		if _, err := service.ClipURL(context.Background(), bad, ""); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ClipURL(%q): expected ErrInvalidInput, got %v", bad, err)
+		}
+	}
+
+	if _, err := service.ClipURL(context.Background(), "https://example.com", ""); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected fetch error, got %v", err)
+	}
+}
+
+// TestHTTPPageFetcher tests content type checks and redirects
+func TestHTTPPageFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<p>hello</p>")) //nolint:errcheck // test server
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPPageFetcher(0)
+	ctx := context.Background()
+
+	body, finalURL, err := fetcher.Fetch(ctx, server.URL+"/old")
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if body != "<p>hello</p>" || finalURL != server.URL+"/page" {
+		t.Errorf("got %q from %q", body, finalURL)
+	}
+
+	if _, _, err := fetcher.Fetch(ctx, server.URL+"/image"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an image, got %v", err)
+	}
+	if _, _, err := fetcher.Fetch(ctx, server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "HTTP 404") {
+		t.Errorf("expected HTTP 404 error, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+// ABOUTME: Content hashing and optimistic concurrency for note updates
+// ABOUTME: Detects changed notes and rejects updates when the modification date or hash no longer matches
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// UpdatePrecondition describes the note state a caller read before deciding to update it
+// Either field may be set; an empty precondition updates unconditionally
+type UpdatePrecondition struct {
+	ExpectedModified *time.Time // modification date returned when the note was read
+	ExpectedHash     string     // ContentHash of the note body when it was read
+}
+
+// ContentHash returns the hex SHA-256 of a note's HTML body
+func ContentHash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// HasNoteChanged compares a note's current body against a hash from an earlier read
+// Returns whether it changed and the current hash; only the hash leaves the server, not the body
+func (s *AppleNotesService) HasNoteChanged(ctx context.Context, title, hash string) (bool, string, error) {
+	body, err := s.GetNoteContent(ctx, title)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to check note: %w", err)
+	}
+
+	current := ContentHash(body)
+	return current != hash, current, nil
+}
+
+// UpdateNoteIfUnchanged updates a note only if it still matches the precondition, returning ErrConflict otherwise
+// The check and the write are separate scripts, so this narrows the race between concurrent agents rather than closing it
+func (s *AppleNotesService) UpdateNoteIfUnchanged(ctx context.Context, title, content string, precondition UpdatePrecondition) error {
+	if precondition.ExpectedModified != nil {
+		note, err := s.GetNoteMetadata(ctx, title)
+		if err != nil {
+			return fmt.Errorf("failed to update note: %w", err)
+		}
+
+		// AppleScript dates only carry whole seconds
+		expected := precondition.ExpectedModified.Truncate(time.Second)
+		actual := note.ModificationDate.Truncate(time.Second)
+		if !actual.Equal(expected) {
+			return fmt.Errorf("failed to update note: %w: modified at %s, expected %s",
+				ErrConflict, actual.Format(time.RFC3339), expected.Format(time.RFC3339))
+		}
+	}
+
+	if precondition.ExpectedHash != "" {
+		body, err := s.GetNoteContent(ctx, title)
+		if err != nil {
+			return fmt.Errorf("failed to update note: %w", err)
+		}
+
+		if actual := ContentHash(body); actual != precondition.ExpectedHash {
+			return fmt.Errorf("failed to update note: %w: content hash is %s", ErrConflict, actual)
+		}
+	}
+
+	return s.UpdateNote(ctx, title, content)
+}
@@ -0,0 +1,118 @@
+// ABOUTME: Unit tests for optimistic concurrency on note updates
+// ABOUTME: Tests content hashing and conflict detection by modification date and hash
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContentHash(t *testing.T) {
+	// SHA-256 of the empty string
+	if got := ContentHash(""); got != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("unexpected hash of empty body: %s", got)
+	}
+	if ContentHash("<div>a</div>") == ContentHash("<div>b</div>") {
+		t.Error("expected different bodies to hash differently")
+	}
+}
+
+func TestUpdateNoteIfUnchanged(t *testing.T) {
+	// reminderTestMetadata has modification date 2024-01-01 11:00:00 local time
+	modified := time.Date(2024, 1, 1, 11, 0, 0, 0, time.Local)
+	stale := modified.Add(-time.Minute)
+	body := "<div>Plan</div>"
+
+	type response = struct {
+		stdout string
+		stderr string
+		err    error
+	}
+
+	tests := []struct {
+		name         string
+		precondition UpdatePrecondition
+		responses    []response
+		wantConflict bool
+		wantCalls    int
+	}{
+		{
+			name:         "matching modification date",
+			precondition: UpdatePrecondition{ExpectedModified: &modified},
+			responses:    []response{{stdout: reminderTestMetadata}, {}},
+			wantCalls:    2,
+		},
+		{
+			name:         "stale modification date",
+			precondition: UpdatePrecondition{ExpectedModified: &stale},
+			responses:    []response{{stdout: reminderTestMetadata}},
+			wantConflict: true,
+			wantCalls:    1,
+		},
+		{
+			name:         "matching hash",
+			precondition: UpdatePrecondition{ExpectedHash: ContentHash(body)},
+			responses:    []response{{stdout: body}, {}},
+			wantCalls:    2,
+		},
+		{
+			name:         "stale hash",
+			precondition: UpdatePrecondition{ExpectedHash: ContentHash("<div>old</div>")},
+			responses:    []response{{stdout: body}},
+			wantConflict: true,
+			wantCalls:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &SequentialMockExecutor{responses: tt.responses}
+			service := NewAppleNotesService(executor)
+
+			err := service.UpdateNoteIfUnchanged(context.Background(), "Planning", "new content", tt.precondition)
+			if tt.wantConflict {
+				if !errors.Is(err, ErrConflict) {
+					t.Errorf("expected ErrConflict, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if executor.callIndex != tt.wantCalls {
+				t.Errorf("expected %d scripts, got %d", tt.wantCalls, executor.callIndex)
+			}
+		})
+	}
+}
+
+func TestHasNoteChanged(t *testing.T) {
+	body := "<div>Plan</div>"
+
+	tests := []struct {
+		name        string
+		hash        string
+		wantChanged bool
+	}{
+		{name: "unchanged", hash: ContentHash(body), wantChanged: false},
+		{name: "changed", hash: ContentHash("<div>old</div>"), wantChanged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAppleNotesService(&MockExecutor{stdout: body})
+
+			changed, current, err := service.HasNoteChanged(context.Background(), "Planning", tt.hash)
+			if err != nil {
+				t.Fatalf("HasNoteChanged failed: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if current != ContentHash(body) {
+				t.Errorf("current hash = %s, want %s", current, ContentHash(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// ABOUTME: Conflict copy detection for notes that share a title but whose bodies diverged, as iCloud sync conflicts leave
+// ABOUTME: Shows how each copy differs from the newest and resolves a conflict by keeping the newest or longest, or merging
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// Conflict resolution strategies
+const (
+	ConflictKeepNewest  = "newest"  // keep the most recently modified copy
+	ConflictKeepLongest = "longest" // keep the copy with the most text
+	ConflictMerge       = "merge"   // keep the newest copy, adding the lines only other copies have
+)
+
+// ConflictCopy is one of the notes sharing a conflicted title
+// Diff is a line diff from the newest copy's text to this copy's; the newest copy has none.
+type ConflictCopy struct {
+	Note
+	TextLength int    `json:"text_length"`
+	Diff       string `json:"diff,omitempty"`
+	body       string
+}
+
+// NoteConflict is a title shared by notes whose bodies differ, with its copies newest first
+type NoteConflict struct {
+	Title  string         `json:"title"`
+	Copies []ConflictCopy `json:"copies"`
+}
+
+// ConflictResolution reports how a conflict was resolved: the copy kept and the copies deleted, by ID
+type ConflictResolution struct {
+	Title       string   `json:"title"`
+	Strategy    string   `json:"strategy"`
+	Kept        string   `json:"kept"`
+	Deleted     []string `json:"deleted"`
+	MergedLines int      `json:"merged_lines,omitempty"`
+}
+
+// ValidConflictStrategy reports whether strategy is one ResolveNoteConflict accepts
+func ValidConflictStrategy(strategy string) bool {
+	switch strategy {
+	case ConflictKeepNewest, ConflictKeepLongest, ConflictMerge:
+		return true
+	}
+	return false
+}
+
+// FindNoteConflicts lists titles shared by notes in folder (or every folder) whose bodies differ
+// Copies are read by ID, since their title can't tell them apart; locked notes and notes without an ID
+// are left out. Titles whose copies all have the same body are plain duplicates and are not listed.
+func FindNoteConflicts(ctx context.Context, notes NoteReader, folder string) ([]NoteConflict, error) {
+	library, err := notes.ListNotesWithMetadata(ctx, folder)
+	if err != nil {
+		return []NoteConflict{}, fmt.Errorf("failed to find conflicts: %w", err)
+	}
+
+	groups := map[string][]ConflictCopy{}
+	for _, note := range library {
+		if note.ID == "" || note.PasswordProtected {
+			continue
+		}
+		key := strings.ToLower(normalizeTitle(note.Title))
+		groups[key] = append(groups[key], ConflictCopy{Note: note})
+	}
+	copies := []*ConflictCopy{}
+	for key, group := range groups {
+		if len(group) < 2 {
+			delete(groups, key)
+			continue
+		}
+		for i := range group {
+			copies = append(copies, &group[i])
+		}
+	}
+
+	err = forEachBounded(ctx, len(copies), DefaultConcurrency, func(ctx context.Context, i int) error {
+		body, err := notes.GetNoteContentByID(ctx, copies[i].ID)
+		copies[i].body = body
+		copies[i].ContentHash = ContentHash(body)
+		copies[i].TextLength = len([]rune(stripHTML(body)))
+		return err
+	})
+	if err != nil {
+		return []NoteConflict{}, fmt.Errorf("failed to find conflicts: %w", err)
+	}
+
+	conflicts := []NoteConflict{}
+	for _, group := range groups {
+		hashes := map[string]bool{}
+		for _, dup := range group {
+			hashes[dup.ContentHash] = true
+		}
+		if len(hashes) < 2 {
+			continue
+		}
+
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].ModificationDate.After(group[j].ModificationDate)
+		})
+		newest := backupNoteLines(group[0].body)
+		for i := 1; i < len(group); i++ {
+			if group[i].ContentHash != group[0].ContentHash {
+				group[i].Diff = diffLines(newest, backupNoteLines(group[i].body))
+			}
+		}
+		conflicts = append(conflicts, NoteConflict{Title: group[0].Title, Copies: group})
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return strings.ToLower(conflicts[i].Title) < strings.ToLower(conflicts[j].Title)
+	})
+	return conflicts, nil
+}
+
+// ResolveNoteConflict keeps one copy of a conflict found by FindNoteConflicts and deletes the others
+// With ConflictMerge, lines of the other copies missing from the newest are appended to it first. Copies
+// are re-read before anything changes, and a copy edited since it was found stops the resolution.
+func ResolveNoteConflict(ctx context.Context, notes NotesService, conflict NoteConflict, strategy string) (*ConflictResolution, error) {
+	if !ValidConflictStrategy(strategy) {
+		return nil, fmt.Errorf("%w: unknown strategy %q, use %s, %s, or %s",
+			ErrInvalidInput, strategy, ConflictKeepNewest, ConflictKeepLongest, ConflictMerge)
+	}
+	if len(conflict.Copies) < 2 {
+		return nil, fmt.Errorf("%w: a conflict needs at least two copies", ErrInvalidInput)
+	}
+
+	for _, dup := range conflict.Copies {
+		body, err := notes.GetNoteContentByID(ctx, dup.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve conflict: %w", err)
+		}
+		if ContentHash(body) != dup.ContentHash {
+			return nil, fmt.Errorf("failed to resolve conflict: %w: a copy of %q changed since it was compared", ErrConflict, conflict.Title)
+		}
+	}
+
+	kept := conflict.Copies[0]
+	if strategy == ConflictKeepLongest {
+		for _, dup := range conflict.Copies[1:] {
+			if dup.TextLength > kept.TextLength {
+				kept = dup
+			}
+		}
+	}
+	resolution := &ConflictResolution{Title: conflict.Title, Strategy: strategy, Kept: kept.ID, Deleted: []string{}}
+
+	if strategy == ConflictMerge {
+		merged, added, err := mergeConflictLines(kept, conflict.Copies[1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve conflict: %w", err)
+		}
+		if added > 0 {
+			// UpdateNoteByID turns newlines into line breaks, so drop the ones that only separate elements
+			merged = interElementNewlines.ReplaceAllString(merged, "><")
+			if err := notes.UpdateNoteByID(ctx, kept.ID, merged); err != nil {
+				return nil, fmt.Errorf("failed to resolve conflict: %w", err)
+			}
+		}
+		resolution.MergedLines = added
+	}
+
+	for _, dup := range conflict.Copies {
+		if dup.ID == kept.ID {
+			continue
+		}
+		if err := notes.DeleteNoteByID(ctx, dup.ID); err != nil {
+			return resolution, fmt.Errorf("kept %s of %q but failed to delete %s: %w", kept.ID, conflict.Title, dup.ID, err)
+		}
+		resolution.Deleted = append(resolution.Deleted, dup.ID)
+	}
+	return resolution, nil
+}
+
+// mergeConflictLines appends the lines of others that kept lacks to kept's body, one section per copy,
+// returning the body and the number of lines added
+func mergeConflictLines(kept ConflictCopy, others []ConflictCopy) (string, int, error) {
+	have := map[string]bool{}
+	for _, line := range backupNoteLines(kept.body) {
+		have[strings.TrimSpace(line)] = true
+	}
+
+	var b strings.Builder
+	added := 0
+	for _, dup := range others {
+		lines := []string{}
+		for _, line := range backupNoteLines(dup.body) {
+			if key := strings.TrimSpace(line); key != "" && !have[key] {
+				have[key] = true
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "<div><br></div><div><b>From the copy modified %s</b></div>", dup.ModificationDate.Local().Format("2006-01-02 15:04"))
+		for _, line := range lines {
+			b.WriteString("<div>" + html.EscapeString(line) + "</div>")
+		}
+		added += len(lines)
+	}
+	if added == 0 {
+		return kept.body, 0, nil
+	}
+	merged, err := AppendHTML(kept.body, b.String())
+	return merged, added, err
+}
@@ -0,0 +1,110 @@
+// ABOUTME: Unit tests for conflict copy detection and resolution
+// ABOUTME: Tests finding divergent same-titled notes with diffs and each resolution strategy
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newConflictService creates two diverged copies of "Plan" (the second newer), a plain duplicate, and an unrelated note
+func newConflictService(t *testing.T) *MemoryNotesService {
+	t.Helper()
+	ctx := context.Background()
+	service := newTestMemoryService()
+	for _, note := range []struct{ title, body string }{
+		{"Plan", "<div>Plan</div><div>Book flights</div><div>Pack snacks for the long drive</div>"},
+		{"plan", "<div>Plan</div><div>Book flights</div><div>Rent a car</div>"},
+		{"Copy", "<div>Same</div>"},
+		{"Copy", "<div>Same</div>"},
+		{"Solo", "<div>Alone</div>"},
+	} {
+		if _, err := service.CreateNote(ctx, note.title, note.body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+	return service
+}
+
+func TestFindNoteConflicts(t *testing.T) {
+	conflicts, err := FindNoteConflicts(context.Background(), newConflictService(t), "")
+	if err != nil {
+		t.Fatalf("FindNoteConflicts failed: %v", err)
+	}
+	if len(conflicts) != 1 || len(conflicts[0].Copies) != 2 {
+		t.Fatalf("expected only the Plan conflict, got %+v", conflicts)
+	}
+
+	newest, older := conflicts[0].Copies[0], conflicts[0].Copies[1]
+	if newest.Title != "plan" || newest.Diff != "" || older.Diff == "" {
+		t.Errorf("expected the newest copy first with the older one diffed against it, got %+v", conflicts[0].Copies)
+	}
+	for _, want := range []string{"-Rent a car", "+Pack snacks for the long drive", " Book flights"} {
+		if !strings.Contains(older.Diff, want) {
+			t.Errorf("expected the diff to contain %q, got:\n%s", want, older.Diff)
+		}
+	}
+}
+
+func TestResolveNoteConflict(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		strategy string
+		keep     string
+		wants    []string
+	}{
+		{ConflictKeepNewest, "Rent a car", nil},
+		{ConflictKeepLongest, "Pack snacks", nil},
+		{ConflictMerge, "Rent a car", []string{"From the copy modified", "Pack snacks for the long drive"}},
+	}
+	for _, tt := range tests {
+		service := newConflictService(t)
+		conflicts, err := FindNoteConflicts(ctx, service, "")
+		if err != nil {
+			t.Fatalf("FindNoteConflicts failed: %v", err)
+		}
+
+		resolution, err := ResolveNoteConflict(ctx, service, conflicts[0], tt.strategy)
+		if err != nil {
+			t.Fatalf("%s: ResolveNoteConflict failed: %v", tt.strategy, err)
+		}
+		if len(resolution.Deleted) != 1 || (tt.strategy == ConflictMerge) != (resolution.MergedLines == 1) {
+			t.Errorf("%s: unexpected resolution %+v", tt.strategy, resolution)
+		}
+		body, err := service.GetNoteContentByID(ctx, resolution.Kept)
+		if err != nil {
+			t.Fatalf("%s: GetNoteContentByID failed: %v", tt.strategy, err)
+		}
+		for _, want := range append(tt.wants, tt.keep) {
+			if !strings.Contains(body, want) {
+				t.Errorf("%s: expected the kept note to contain %q, got %s", tt.strategy, want, body)
+			}
+		}
+		if remaining, _ := FindNoteConflicts(ctx, service, ""); len(remaining) != 0 {
+			t.Errorf("%s: expected the conflict resolved, got %+v", tt.strategy, remaining)
+		}
+	}
+}
+
+func TestResolveNoteConflictRefusals(t *testing.T) {
+	ctx := context.Background()
+	service := newConflictService(t)
+	conflicts, err := FindNoteConflicts(ctx, service, "")
+	if err != nil {
+		t.Fatalf("FindNoteConflicts failed: %v", err)
+	}
+
+	if _, err := ResolveNoteConflict(ctx, service, conflicts[0], "oldest"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown strategy, got %v", err)
+	}
+
+	if err := service.UpdateNoteByID(ctx, conflicts[0].Copies[1].ID, "<div>Edited meanwhile</div>"); err != nil {
+		t.Fatalf("UpdateNoteByID failed: %v", err)
+	}
+	if _, err := ResolveNoteConflict(ctx, service, conflicts[0], ConflictKeepNewest); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict after a copy changed, got %v", err)
+	}
+}
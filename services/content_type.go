@@ -0,0 +1,50 @@
+// ABOUTME: Content types accepted when writing a note body: plain text, markdown, or HTML
+// ABOUTME: Converts markdown and sanitizes HTML so neither gains stray line breaks on the way in
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Content types for note bodies passed to CreateNote and UpdateNote
+const (
+	ContentTypePlain    = "plain"
+	ContentTypeMarkdown = "markdown"
+	ContentTypeHTML     = "html"
+)
+
+// FormatNoteBody prepares content of the given type for CreateNote or UpdateNote
+// Plain text is returned as is, so its newlines become line breaks as always; markdown is
+// converted with MarkdownToHTML and HTML is passed through SanitizeHTML, both of which return
+// markup without newlines outside <pre>. An empty contentType means plain.
+func FormatNoteBody(content, contentType string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case "", ContentTypePlain:
+		return content, nil
+	case ContentTypeMarkdown:
+		return MarkdownToHTML(content), nil
+	case ContentTypeHTML:
+		return SanitizeHTML(content), nil
+	default:
+		return "", fmt.Errorf("%w: content_type must be %q, %q, or %q",
+			ErrInvalidInput, ContentTypePlain, ContentTypeMarkdown, ContentTypeHTML)
+	}
+}
+
+// htmlBodyKey marks a context whose CreateNote content is HTML to be stored as is
+type htmlBodyKey struct{}
+
+// WithHTMLBody returns a context in which CreateNote stores content as is instead of turning its
+// newlines into line breaks, for HTML taken from a note body, such as a backup being restored
+func WithHTMLBody(ctx context.Context) context.Context {
+	return context.WithValue(ctx, htmlBodyKey{}, true)
+}
+
+// isHTMLBody reports whether ctx asks for CreateNote content to be stored as is
+func isHTMLBody(ctx context.Context) bool {
+	asIs, _ := ctx.Value(htmlBodyKey{}).(bool)
+	return asIs
+}
@@ -0,0 +1,148 @@
+// ABOUTME: Library statistics for the dashboard command, computed from backup manifests
+// ABOUTME: Reports growth over time, notes per folder, most edited notes, and attachment storage
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// dashboardTopEdited is how many notes the most-edited list keeps
+const dashboardTopEdited = 10
+
+// DashboardPoint is the size of the library at one backup
+type DashboardPoint struct {
+	Time  time.Time `json:"time"`
+	Notes int       `json:"notes"`
+}
+
+// DashboardFolder counts the notes in one folder
+type DashboardFolder struct {
+	Folder string `json:"folder"`
+	Notes  int    `json:"notes"`
+}
+
+// DashboardEdit counts how many backups saw a note's content change
+type DashboardEdit struct {
+	Title  string `json:"title"`
+	Folder string `json:"folder"`
+	Edits  int    `json:"edits"`
+}
+
+// AttachmentStorage sums the attachment files in the Notes container
+type AttachmentStorage struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
+
+// Dashboard summarizes the library across its backup history
+type Dashboard struct {
+	Backups     int               `json:"backups"`
+	TotalNotes  int               `json:"total_notes"`
+	Growth      []DashboardPoint  `json:"growth"`
+	Folders     []DashboardFolder `json:"folders"`
+	MostEdited  []DashboardEdit   `json:"most_edited"`
+	Attachments AttachmentStorage `json:"attachments"`
+}
+
+// BuildDashboard reads every backup manifest in a store and summarizes the library's history
+// Folder counts come from the latest backup. A note's edits are the backups in which its content
+// hash differed from the one before, so edits between two backups count once. Attachment storage
+// is measured from the Media directories under containerDir; an empty containerDir skips it.
+func BuildDashboard(ctx context.Context, store BackupStore, passphrase, containerDir string) (*Dashboard, error) {
+	names, err := ListBackups(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: no backups found; run notes-mcp backup first", ErrInvalidInput)
+	}
+
+	dashboard := &Dashboard{
+		Backups:    len(names),
+		Growth:     []DashboardPoint{},
+		Folders:    []DashboardFolder{},
+		MostEdited: []DashboardEdit{},
+	}
+
+	hashes := map[string]string{}
+	edits := map[string]*DashboardEdit{}
+	var latest *BackupManifest
+	for _, name := range names {
+		manifest, _, err := LoadBackupManifest(ctx, store, passphrase, name)
+		if err != nil {
+			return nil, err
+		}
+		dashboard.Growth = append(dashboard.Growth, DashboardPoint{Time: manifest.Created, Notes: len(manifest.Notes)})
+
+		for _, entry := range manifest.Notes {
+			previous, seen := hashes[entry.ID]
+			hashes[entry.ID] = entry.Hash
+
+			edit, ok := edits[entry.ID]
+			if !ok {
+				edit = &DashboardEdit{}
+				edits[entry.ID] = edit
+			}
+			edit.Title, edit.Folder = entry.Title, entry.Folder
+			if seen && previous != entry.Hash {
+				edit.Edits++
+			}
+		}
+		latest = manifest
+	}
+
+	dashboard.TotalNotes = len(latest.Notes)
+	folders := map[string]int{}
+	live := map[string]bool{}
+	for _, entry := range latest.Notes {
+		folders[entry.Folder]++
+		live[entry.ID] = true
+	}
+	for folder, count := range folders {
+		dashboard.Folders = append(dashboard.Folders, DashboardFolder{Folder: folder, Notes: count})
+	}
+	sort.Slice(dashboard.Folders, func(i, j int) bool {
+		if dashboard.Folders[i].Notes != dashboard.Folders[j].Notes {
+			return dashboard.Folders[i].Notes > dashboard.Folders[j].Notes
+		}
+		return dashboard.Folders[i].Folder < dashboard.Folders[j].Folder
+	})
+
+	// Deleted notes drop out of the most-edited list
+	for id, edit := range edits {
+		if live[id] && edit.Edits > 0 {
+			dashboard.MostEdited = append(dashboard.MostEdited, *edit)
+		}
+	}
+	sort.Slice(dashboard.MostEdited, func(i, j int) bool {
+		if dashboard.MostEdited[i].Edits != dashboard.MostEdited[j].Edits {
+			return dashboard.MostEdited[i].Edits > dashboard.MostEdited[j].Edits
+		}
+		return dashboard.MostEdited[i].Title < dashboard.MostEdited[j].Title
+	})
+	if len(dashboard.MostEdited) > dashboardTopEdited {
+		dashboard.MostEdited = dashboard.MostEdited[:dashboardTopEdited]
+	}
+
+	dashboard.Attachments = measureAttachmentStorage(containerDir)
+	return dashboard, nil
+}
+
+// measureAttachmentStorage counts the files under the container's Media directories and their total size
+func measureAttachmentStorage(containerDir string) AttachmentStorage {
+	storage := AttachmentStorage{}
+	for _, file := range listMediaFiles(containerDir) {
+		info, err := os.Stat(file.path)
+		if err != nil {
+			continue
+		}
+		storage.Files++
+		storage.Bytes += info.Size()
+	}
+	return storage
+}
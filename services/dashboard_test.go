@@ -0,0 +1,64 @@
+// ABOUTME: Unit tests for dashboard statistics built from backup history
+// ABOUTME: Backs up the in-memory service several times and checks growth, folders, edits, and storage
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuildDashboard(t *testing.T) {
+	ctx := context.Background()
+	notes := newTestMemoryService()
+	store := NewLocalBackupStore(t.TempDir())
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	if _, err := BuildDashboard(ctx, store, "pass", ""); err == nil {
+		t.Error("expected an error without backups")
+	}
+
+	_, _ = notes.CreateNote(ctx, "Plan", "<div>v1</div>", nil)
+	_, _ = notes.CreateNote(ctx, "Scratch", "<div>temp</div>", nil)
+	_, _, _ = CreateBackup(ctx, notes, store, "pass", now)
+
+	_ = notes.UpdateNote(ctx, "Plan", "<div>v2</div>")
+	_ = notes.UpdateNote(ctx, "Scratch", "<div>temp 2</div>")
+	_ = notes.CreateFolder(ctx, "Home", "")
+	_, _ = notes.CreateNote(ctx, "Recipes", "<div>soup</div>", nil)
+	_ = notes.MoveNote(ctx, "Recipes", "Home")
+	_, _, _ = CreateBackup(ctx, notes, store, "pass", now.Add(24*time.Hour))
+
+	_ = notes.UpdateNote(ctx, "Plan", "<div>v3</div>")
+	_ = notes.DeleteNote(ctx, "Scratch")
+	_, _, _ = CreateBackup(ctx, notes, store, "pass", now.Add(48*time.Hour))
+
+	container := t.TempDir()
+	media := filepath.Join(container, "Accounts", "A1", "Media", "X")
+	_ = os.MkdirAll(media, 0o755)
+	_ = os.WriteFile(filepath.Join(media, "photo.jpg"), make([]byte, 1500), 0o600)
+
+	dashboard, err := BuildDashboard(ctx, store, "pass", container)
+	if err != nil {
+		t.Fatalf("BuildDashboard failed: %v", err)
+	}
+
+	if dashboard.Backups != 3 || dashboard.TotalNotes != 2 {
+		t.Errorf("expected 3 backups and 2 notes, got %+v", dashboard)
+	}
+	if len(dashboard.Growth) != 3 || dashboard.Growth[0].Notes != 2 || dashboard.Growth[1].Notes != 3 {
+		t.Errorf("unexpected growth %+v", dashboard.Growth)
+	}
+	if len(dashboard.Folders) != 2 || dashboard.Folders[0].Folder != "Home" || dashboard.Folders[1].Folder != "Notes" {
+		t.Errorf("unexpected folders %+v", dashboard.Folders)
+	}
+	if len(dashboard.MostEdited) != 1 || dashboard.MostEdited[0].Title != "Plan" || dashboard.MostEdited[0].Edits != 2 {
+		t.Errorf("expected only Plan with two edits (Scratch was deleted), got %+v", dashboard.MostEdited)
+	}
+	if dashboard.Attachments.Files != 1 || dashboard.Attachments.Bytes != 1500 {
+		t.Errorf("unexpected attachment storage %+v", dashboard.Attachments)
+	}
+}
@@ -0,0 +1,185 @@
+// ABOUTME: Weekly digest generation from recently modified notes
+// ABOUTME: Collects each note's outline and action items into a digest note saved in a Digests folder
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultDigestFolder is the folder weekly digests are saved in when none is given
+const DefaultDigestFolder = "Digests"
+
+// maxOutlineEntries caps the headings listed for each note in a digest
+const maxOutlineEntries = 5
+
+// headingPattern matches HTML headings, capturing their inner HTML
+var headingPattern = regexp.MustCompile(`(?is)<h[1-6][^>]*>(.*?)</h[1-6]>`)
+
+// DigestEntry is one note's section of a weekly digest
+type DigestEntry struct {
+	Note        Note         `json:"note"`
+	Outline     []string     `json:"outline"`
+	ActionItems []ActionItem `json:"action_items"`
+}
+
+// WeeklyDigest is the collected content of a week's notes
+type WeeklyDigest struct {
+	From    time.Time     `json:"from"`
+	To      time.Time     `json:"to"`
+	Entries []DigestEntry `json:"entries"`
+}
+
+// BuildWeeklyDigest collects the notes modified in the seven days starting at weekStart
+// Notes already in the digest folder are skipped so earlier digests aren't summarized again
+func (s *AppleNotesService) BuildWeeklyDigest(ctx context.Context, weekStart time.Time, digestFolder string) (*WeeklyDigest, error) {
+	from := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
+	to := from.AddDate(0, 0, 7)
+
+	notes, err := s.GetNotesModifiedBetween(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build weekly digest: %w", err)
+	}
+
+	selected := []Note{}
+	for _, note := range notes {
+		if note.Folder != digestFolder {
+			selected = append(selected, note)
+		}
+	}
+
+	bodies := make([]string, len(selected))
+	err = forEachBounded(ctx, len(selected), s.workerLimit(), func(ctx context.Context, i int) error {
+		body, err := s.GetNoteContent(ctx, selected[i].Title)
+		bodies[i] = body
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build weekly digest: %w", err)
+	}
+
+	digest := &WeeklyDigest{From: from, To: to, Entries: []DigestEntry{}}
+	for i, note := range selected {
+		digest.Entries = append(digest.Entries, DigestEntry{
+			Note:        note,
+			Outline:     parseOutline(bodies[i], note.Title),
+			ActionItems: parseActionItems(bodies[i], note.Title),
+		})
+	}
+
+	return digest, nil
+}
+
+// GenerateWeeklyDigest builds the digest for the week starting at weekStart and saves it as a new note
+// The digest folder (DefaultDigestFolder when empty) is created if it doesn't exist
+func (s *AppleNotesService) GenerateWeeklyDigest(ctx context.Context, weekStart time.Time, digestFolder string) (*Note, error) {
+	if digestFolder == "" {
+		digestFolder = DefaultDigestFolder
+	}
+
+	digest, err := s.BuildWeeklyDigest(ctx, weekStart, digestFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureFolder(ctx, digestFolder); err != nil {
+		return nil, fmt.Errorf("failed to generate weekly digest: %w", err)
+	}
+
+	note, err := s.CreateNote(ctx, digest.Title(), digest.Render(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate weekly digest: %w", err)
+	}
+
+	if err := s.MoveNote(ctx, note.Title, digestFolder); err != nil {
+		return nil, fmt.Errorf("failed to generate weekly digest: %w", err)
+	}
+	note.Folder = digestFolder
+
+	return note, nil
+}
+
+// ensureFolder creates a top-level folder unless one with the same name already exists
+func (s *AppleNotesService) ensureFolder(ctx context.Context, name string) error {
+	folders, err := s.ListFolders(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, folder := range folders {
+		if folder == name {
+			return nil
+		}
+	}
+
+	return s.CreateFolder(ctx, name, "")
+}
+
+// Title returns the digest note's title, naming the first and last day covered
+func (d *WeeklyDigest) Title() string {
+	return fmt.Sprintf("Weekly Digest %s to %s",
+		d.From.Format("2006-01-02"), d.To.AddDate(0, 0, -1).Format("2006-01-02"))
+}
+
+// Render formats the digest as plain text, one section per note followed by open action items
+func (d *WeeklyDigest) Render() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d notes modified from %s to %s\n",
+		len(d.Entries), d.From.Format("Mon Jan 2"), d.To.AddDate(0, 0, -1).Format("Mon Jan 2"))
+
+	open := []ActionItem{}
+	for _, entry := range d.Entries {
+		fmt.Fprintf(&b, "\n%s", entry.Note.Title)
+		if entry.Note.Folder != "" {
+			fmt.Fprintf(&b, " (%s)", entry.Note.Folder)
+		}
+		b.WriteString("\n")
+
+		for _, heading := range entry.Outline {
+			fmt.Fprintf(&b, "  • %s\n", heading)
+		}
+
+		done := 0
+		for _, item := range entry.ActionItems {
+			if item.Done {
+				done++
+				continue
+			}
+			open = append(open, item)
+		}
+		if len(entry.ActionItems) > 0 {
+			fmt.Fprintf(&b, "  %d of %d action items done\n", done, len(entry.ActionItems))
+		}
+	}
+
+	if len(open) > 0 {
+		b.WriteString("\nOpen action items\n")
+		for _, item := range open {
+			fmt.Fprintf(&b, "☐ %s (%s)\n", item.Text, item.SourceNote)
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// parseOutline returns the headings in a note body, skipping the heading that repeats the title
+func parseOutline(body, title string) []string {
+	outline := []string{}
+	for _, match := range headingPattern.FindAllStringSubmatch(body, -1) {
+		heading := stripHTML(match[1])
+		if heading == "" || strings.EqualFold(heading, title) {
+			continue
+		}
+
+		outline = append(outline, heading)
+		if len(outline) == maxOutlineEntries {
+			break
+		}
+	}
+	return outline
+}
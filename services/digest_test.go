@@ -0,0 +1,149 @@
+// ABOUTME: Unit tests for weekly digest generation
+// ABOUTME: Tests outline parsing, digest rendering, and the create/move sequence for the digest note
+
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseOutline tests heading extraction from note bodies
+func TestParseOutline(t *testing.T) {
+	body := `<div><h1>Planning</h1></div>
+<div><h2>Goals</h2></div><div>Ship it</div>
+<div><h3><b>Risks &amp; Issues</b></h3></div>
+<div><h2> </h2></div>`
+
+	got := parseOutline(body, "Planning")
+	want := []string{"Goals", "Risks & Issues"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseOutline = %v, want %v", got, want)
+	}
+
+	var many strings.Builder
+	for i := 0; i < maxOutlineEntries+3; i++ {
+		many.WriteString("<h2>Section</h2>")
+	}
+	if got := parseOutline(many.String(), "Title"); len(got) != maxOutlineEntries {
+		t.Errorf("expected outline capped at %d entries, got %d", maxOutlineEntries, len(got))
+	}
+}
+
+// TestWeeklyDigestRender tests the digest title and plain text layout
+func TestWeeklyDigestRender(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	digest := &WeeklyDigest{
+		From: from,
+		To:   from.AddDate(0, 0, 7),
+		Entries: []DigestEntry{
+			{
+				Note:    Note{Title: "Planning", Folder: "Work"},
+				Outline: []string{"Goals"},
+				ActionItems: []ActionItem{
+					{Text: "Draft roadmap", Done: true, SourceNote: "Planning"},
+					{Text: "Book room", SourceNote: "Planning"},
+				},
+			},
+			{Note: Note{Title: "Ideas"}, Outline: []string{}, ActionItems: []ActionItem{}},
+		},
+	}
+
+	if title := digest.Title(); title != "Weekly Digest 2024-01-01 to 2024-01-07" {
+		t.Errorf("Title = %q", title)
+	}
+
+	want := `2 notes modified from Mon Jan 1 to Sun Jan 7
+
+Planning (Work)
+  • Goals
+  1 of 2 action items done
+
+Ideas
+
+Open action items
+☐ Book room (Planning)`
+	if got := digest.Render(); got != want {
+		t.Errorf("Render =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestGenerateWeeklyDigest tests collecting notes, creating the digest folder, and saving the digest
+func TestGenerateWeeklyDigest(t *testing.T) {
+	metadata := `{id:"x-coredata://digest", name:"Weekly Digest 2024-01-01 to 2024-01-07", creation date:date "Monday, January 8, 2024 at 9:00:00 AM", modification date:date "Monday, January 8, 2024 at 9:00:00 AM", container:"Notes", shared:false, password protected:false}`
+
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			// GetNotesModifiedBetween; the earlier digest is skipped without reading its body
+			{stdout: "id1|||Planning|||Work|||2024-01-01T09:00:00|||2024-01-03T10:00:00\n" +
+				"id0|||Weekly Digest 2023-12-25 to 2023-12-31|||Digests|||2024-01-01T08:00:00|||2024-01-01T08:00:00"},
+			{stdout: "<div><h1>Planning</h1></div><div><h2>Goals</h2></div><ul><li class=\"unchecked\">Book room</li></ul>"}, // GetNoteContent
+			{stdout: "Notes|||Work"}, // ListFolders
+			{stdout: ""},             // CreateFolder "Digests"
+			{stdout: ""},             // CreateNote
+			{stdout: metadata},       // GetNoteMetadata
+			{stdout: ""},             // MoveNote
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+	weekStart := time.Date(2024, 1, 1, 15, 30, 0, 0, time.Local)
+
+	note, err := service.GenerateWeeklyDigest(context.Background(), weekStart, "")
+	if err != nil {
+		t.Fatalf("GenerateWeeklyDigest failed: %v", err)
+	}
+	if note.Folder != DefaultDigestFolder {
+		t.Errorf("note folder = %q, want %q", note.Folder, DefaultDigestFolder)
+	}
+	if executor.callIndex != len(executor.responses) {
+		t.Errorf("expected %d scripts, got %d", len(executor.responses), executor.callIndex)
+	}
+}
+
+// TestGenerateWeeklyDigestExistingFolder tests that an existing digest folder is reused
+func TestGenerateWeeklyDigestExistingFolder(t *testing.T) {
+	metadata := `{id:"x-coredata://digest", name:"Weekly Digest", creation date:date "Monday, January 8, 2024 at 9:00:00 AM", modification date:date "Monday, January 8, 2024 at 9:00:00 AM", container:"Notes", shared:false, password protected:false}`
+
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: ""},                       // GetNotesModifiedBetween: quiet week
+			{stdout: "Notes|||Weekly Reviews"}, // ListFolders
+			{stdout: ""},                       // CreateNote
+			{stdout: metadata},                 // GetNoteMetadata
+			{stdout: ""},                       // MoveNote
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+	note, err := service.GenerateWeeklyDigest(context.Background(), time.Now(), "Weekly Reviews")
+	if err != nil {
+		t.Fatalf("GenerateWeeklyDigest failed: %v", err)
+	}
+	if note.Folder != "Weekly Reviews" {
+		t.Errorf("note folder = %q, want %q", note.Folder, "Weekly Reviews")
+	}
+}
+
+// TestGenerateWeeklyDigestError tests that failures collecting notes are wrapped
+func TestGenerateWeeklyDigestError(t *testing.T) {
+	executor := &MockExecutor{stderr: "execution error: Not allowed (-1743)", err: errors.New("exit status 1")}
+	service := NewAppleNotesService(executor)
+
+	_, err := service.GenerateWeeklyDigest(context.Background(), time.Now(), "")
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
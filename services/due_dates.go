@@ -0,0 +1,196 @@
+// ABOUTME: Due date resolution for action items and upcoming deadline aggregation
+// ABOUTME: Turns hints like "by Friday" or "2024-07-01" into dates and collects dated items across notes
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// deadlineLookbackDays is how far back GetUpcomingDeadlines looks for modified notes
+const deadlineLookbackDays = 90
+
+// maxDeadlineNotes caps how many notes GetUpcomingDeadlines reads, newest first
+const maxDeadlineNotes = 100
+
+// dueHintPrefixPattern matches the leading "due by"/"by"/"before"/"until" of a due hint
+var dueHintPrefixPattern = regexp.MustCompile(`(?i)^(?:due(?:\s+(?:on|by))?|by|before|until)\s+`)
+
+// numericDatePattern matches M/D and M/D/YY(YY) dates
+var numericDatePattern = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})(?:/(\d{2,4}))?$`)
+
+// monthDayPattern matches "Apr 15", "April 15th", and "Sept. 3"
+var monthDayPattern = regexp.MustCompile(`^([a-z]+)\.?\s+(\d{1,2})(?:st|nd|rd|th)?$`)
+
+// weekdayPattern matches "friday", "next fri", and "this tuesday"
+var weekdayPattern = regexp.MustCompile(`^(?:(next|this)\s+)?([a-z]+)$`)
+
+// monthPrefixes maps three-letter month prefixes to months
+var monthPrefixes = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// weekdayPrefixes maps three-letter weekday prefixes to weekdays
+var weekdayPrefixes = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseDueHint resolves a due hint to a calendar date relative to ref, the time the hint was written
+// Weekdays mean the next such day on or after ref ("next" skips ref's own day), "end of week" is
+// Friday, and dates without a year that fall before ref roll over to the following year.
+func ParseDueHint(hint string, ref time.Time) (time.Time, bool) {
+	phrase := strings.ToLower(strings.TrimSpace(dueHintPrefixPattern.ReplaceAllString(strings.TrimSpace(hint), "")))
+	phrase = strings.Join(strings.Fields(phrase), " ")
+	today := startOfDay(ref)
+
+	switch phrase {
+	case "":
+		return time.Time{}, false
+	case "today", "tonight", "eod", "end of day", "end of the day":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "eow", "end of week", "end of the week":
+		return nextWeekday(today, time.Friday, false), true
+	case "next week":
+		return nextWeekday(today, time.Monday, true), true
+	case "end of month", "end of the month":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), true
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", phrase, ref.Location()); err == nil {
+		return date, true
+	}
+
+	if m := numericDatePattern.FindStringSubmatch(phrase); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[2])
+		if m[3] == "" {
+			return dateWithoutYear(today, time.Month(month), day)
+		}
+		year, _ := strconv.Atoi(m[3])
+		if year < 100 {
+			year += 2000
+		}
+		return validDate(year, time.Month(month), day, today.Location())
+	}
+
+	if m := monthDayPattern.FindStringSubmatch(phrase); m != nil && len(m[1]) >= 3 {
+		if month, ok := monthPrefixes[m[1][:3]]; ok {
+			day, _ := strconv.Atoi(m[2])
+			return dateWithoutYear(today, month, day)
+		}
+	}
+
+	if m := weekdayPattern.FindStringSubmatch(phrase); m != nil && len(m[2]) >= 3 {
+		if weekday, ok := weekdayPrefixes[m[2][:3]]; ok {
+			return nextWeekday(today, weekday, m[1] == "next"), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// withDueDates resolves each item's due hint relative to ref
+func withDueDates(items []ActionItem, ref time.Time) []ActionItem {
+	for i := range items {
+		if items[i].DueHint == "" {
+			continue
+		}
+		if due, ok := ParseDueHint(items[i].DueHint, ref); ok {
+			items[i].Due = &due
+		}
+	}
+	return items
+}
+
+// GetUpcomingDeadlines collects open action items due within the next days days, soonest first
+// Overdue items are included. Notes modified in the last deadlineLookbackDays days are read
+// (at most maxDeadlineNotes), optionally limited to a folder, and each hint is resolved
+// relative to its note's modification date so "by Friday" means the Friday after it was written.
+func (s *AppleNotesService) GetUpcomingDeadlines(ctx context.Context, days int, folder string) ([]ActionItem, error) {
+	if days < 1 {
+		return []ActionItem{}, fmt.Errorf("%w: days must be at least 1", ErrInvalidInput)
+	}
+
+	today := startOfDay(time.Now())
+	until := today.AddDate(0, 0, days+1)
+
+	notes, err := s.GetNotesModifiedBetween(ctx, today.AddDate(0, 0, -deadlineLookbackDays), today.AddDate(0, 0, 1))
+	if err != nil {
+		return []ActionItem{}, fmt.Errorf("failed to get upcoming deadlines: %w", err)
+	}
+
+	deadlines := []ActionItem{}
+	read := 0
+	for _, note := range notes {
+		if folder != "" && note.Folder != folder {
+			continue
+		}
+		if read == maxDeadlineNotes {
+			break
+		}
+		read++
+
+		body, err := s.GetNoteContent(ctx, note.Title)
+		if err != nil {
+			return []ActionItem{}, fmt.Errorf("failed to get upcoming deadlines: %w", err)
+		}
+
+		for _, item := range withDueDates(parseActionItems(body, note.Title), note.Modified) {
+			if !item.Done && item.Due != nil && item.Due.Before(until) {
+				deadlines = append(deadlines, item)
+			}
+		}
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].Due.Before(*deadlines[j].Due)
+	})
+
+	return deadlines, nil
+}
+
+// startOfDay returns midnight at the start of t's day in t's location
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// nextWeekday returns the next weekday on or after day, or strictly after it when skipToday is set
+func nextWeekday(day time.Time, weekday time.Weekday, skipToday bool) time.Time {
+	offset := (int(weekday) - int(day.Weekday()) + 7) % 7
+	if offset == 0 && skipToday {
+		offset = 7
+	}
+	return day.AddDate(0, 0, offset)
+}
+
+// dateWithoutYear returns month/day in today's year, or next year if that has already passed
+func dateWithoutYear(today time.Time, month time.Month, day int) (time.Time, bool) {
+	date, ok := validDate(today.Year(), month, day, today.Location())
+	if !ok {
+		return time.Time{}, false
+	}
+	if date.Before(today) {
+		return validDate(today.Year()+1, month, day, today.Location())
+	}
+	return date, true
+}
+
+// validDate builds a date, rejecting values time.Date would normalize (e.g. 2/30)
+func validDate(year int, month time.Month, day int, loc *time.Location) (time.Time, bool) {
+	date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if date.Month() != month || date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,112 @@
+// ABOUTME: Unit tests for due date resolution and upcoming deadlines
+// ABOUTME: Tests relative and absolute hint parsing and aggregation of dated items across notes
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestParseDueHint tests resolving hints relative to the time they were written
+func TestParseDueHint(t *testing.T) {
+	// Wednesday
+	ref := time.Date(2024, 6, 26, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		hint string
+		want string
+	}{
+		{hint: "2024-07-01", want: "2024-07-01"},
+		{hint: "before 2024-07-01", want: "2024-07-01"},
+		{hint: "by today", want: "2024-06-26"},
+		{hint: "due tomorrow", want: "2024-06-27"},
+		{hint: "by Friday", want: "2024-06-28"},
+		{hint: "by Wednesday", want: "2024-06-26"},
+		{hint: "by next Wednesday", want: "2024-07-03"},
+		{hint: "by this tues", want: "2024-07-02"},
+		{hint: "by end of week", want: "2024-06-28"},
+		{hint: "by EOW", want: "2024-06-28"},
+		{hint: "by next week", want: "2024-07-01"},
+		{hint: "by end of month", want: "2024-06-30"},
+		{hint: "until 7/15", want: "2024-07-15"},
+		{hint: "by 1/5", want: "2025-01-05"},
+		{hint: "by 3/1/25", want: "2025-03-01"},
+		{hint: "due Apr 15", want: "2025-04-15"},
+		{hint: "due by September 3rd", want: "2024-09-03"},
+		{hint: "by 2/30", want: ""},
+		{hint: "by someday", want: ""},
+		{hint: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hint, func(t *testing.T) {
+			got, ok := ParseDueHint(tt.hint, ref)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("expected no date, got %v", got)
+				}
+				return
+			}
+			if !ok || got.Format("2006-01-02") != tt.want {
+				t.Errorf("ParseDueHint(%q) = %v, %t; want %s", tt.hint, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetUpcomingDeadlines tests filtering to open, dated items in the window, soonest first
+func TestGetUpcomingDeadlines(t *testing.T) {
+	now := time.Now()
+	modified := now.Format("2006-01-02T15:04:05")
+	overdue := now.AddDate(0, 0, -3).Format("2006-01-02")
+	farOff := now.AddDate(0, 1, 0).Format("2006-01-02")
+
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			// GetNotesModifiedBetween; the Personal note is outside the folder and never read
+			{stdout: fmt.Sprintf("id1|||Launch|||Work|||%[1]s|||%[1]s\nid2|||Groceries|||Personal|||%[1]s|||%[1]s\nid3|||Retro|||Work|||%[1]s|||%[1]s", modified)},
+			{stdout: fmt.Sprintf("<div>- [ ] Send recap by tomorrow</div><div>- [ ] Plan offsite by %s</div><div>- [x] Book room by today</div>", farOff)},
+			{stdout: fmt.Sprintf("<div>TODO: file expenses before %s</div><div>TODO: no deadline</div>", overdue)},
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+
+	items, err := service.GetUpcomingDeadlines(context.Background(), 7, "Work")
+	if err != nil {
+		t.Fatalf("GetUpcomingDeadlines failed: %v", err)
+	}
+
+	wantTexts := []string{"file expenses before " + overdue, "Send recap by tomorrow"}
+	if len(items) != len(wantTexts) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(wantTexts), items)
+	}
+	for i, item := range items {
+		if item.Text != wantTexts[i] {
+			t.Errorf("item %d = %q, want %q", i, item.Text, wantTexts[i])
+		}
+		if item.Due == nil {
+			t.Errorf("item %d has no due date", i)
+		}
+	}
+	if items[1].SourceNote != "Launch" {
+		t.Errorf("SourceNote = %q, want %q", items[1].SourceNote, "Launch")
+	}
+}
+
+// TestGetUpcomingDeadlinesInvalidDays tests that the window must be at least a day
+func TestGetUpcomingDeadlinesInvalidDays(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+
+	if _, err := service.GetUpcomingDeadlines(context.Background(), 0, ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
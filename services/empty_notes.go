@@ -0,0 +1,101 @@
+// ABOUTME: Finder for empty and near-empty notes, such as blank notes left by sync glitches or agents
+// ABOUTME: Lists notes whose text is under a threshold and deletes them on request, skipping ambiguous titles
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DefaultEmptyNoteChars is the text length under which a note counts as empty when no threshold is given
+const DefaultEmptyNoteChars = 10
+
+// EmptyNoteOptions selects the notes FindEmptyNotes reports
+type EmptyNoteOptions struct {
+	Folder   string // only notes in this folder; empty means every folder
+	MaxChars int    // notes with fewer characters of text count as empty (default: DefaultEmptyNoteChars)
+}
+
+// SkippedNote is a note a bulk operation left alone, with the reason
+type SkippedNote struct {
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// EmptyNoteDeletion reports a bulk delete of empty notes
+type EmptyNoteDeletion struct {
+	Deleted []string      `json:"deleted"`
+	Skipped []SkippedNote `json:"skipped"`
+}
+
+// FindEmptyNotes lists notes whose text, not counting the title line, is shorter than opts.MaxChars
+// Notes with attachments are never empty, since a scan or photo may be their whole content. Locked
+// notes can't be read and are left out. Results keep the newest-first order of the note listing.
+func FindEmptyNotes(ctx context.Context, notes NoteReader, opts EmptyNoteOptions) ([]Note, error) {
+	if opts.MaxChars < 0 {
+		return []Note{}, fmt.Errorf("%w: the empty note threshold cannot be negative", ErrInvalidInput)
+	}
+	if opts.MaxChars == 0 {
+		opts.MaxChars = DefaultEmptyNoteChars
+	}
+
+	library, err := notes.ListNotesWithMetadata(ctx, opts.Folder)
+	if err != nil {
+		return []Note{}, fmt.Errorf("failed to find empty notes: %w", err)
+	}
+
+	readable := []Note{}
+	for _, note := range library {
+		if !note.PasswordProtected {
+			readable = append(readable, note)
+		}
+	}
+	if readable, err = notes.WithNoteMetrics(ctx, readable); err != nil {
+		return []Note{}, fmt.Errorf("failed to find empty notes: %w", err)
+	}
+
+	empty := []Note{}
+	for _, note := range readable {
+		if note.Metrics != nil && !note.Metrics.HasAttachments && note.Metrics.TextLength < opts.MaxChars {
+			empty = append(empty, note)
+		}
+	}
+	return empty, nil
+}
+
+// DeleteEmptyNotes deletes notes found by FindEmptyNotes
+// Notes are deleted by title, so a note whose title any other note shares is skipped rather than
+// risk deleting the other one. A failed delete is reported and the rest continue.
+func DeleteEmptyNotes(ctx context.Context, notes NotesService, empty []Note) (*EmptyNoteDeletion, error) {
+	report := &EmptyNoteDeletion{Deleted: []string{}, Skipped: []SkippedNote{}}
+	if len(empty) == 0 {
+		return report, nil
+	}
+
+	library, err := notes.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return report, fmt.Errorf("failed to delete empty notes: %w", err)
+	}
+	titles := map[string]int{}
+	for _, note := range library {
+		titles[strings.ToLower(note.Title)]++
+	}
+
+	for _, note := range empty {
+		if err := ctx.Err(); err != nil {
+			return report, fmt.Errorf("failed to delete empty notes: %w", err)
+		}
+		if titles[strings.ToLower(note.Title)] > 1 {
+			report.Skipped = append(report.Skipped, SkippedNote{Title: note.Title, Reason: "another note has the same title"})
+			continue
+		}
+		if err := notes.DeleteNote(ctx, note.Title); err != nil {
+			report.Skipped = append(report.Skipped, SkippedNote{Title: note.Title, Reason: err.Error()})
+			continue
+		}
+		report.Deleted = append(report.Deleted, note.Title)
+	}
+	return report, nil
+}
@@ -0,0 +1,85 @@
+// ABOUTME: Unit tests for the empty note finder
+// ABOUTME: Tests the text threshold, title lines, attachment and locked exclusions, and guarded bulk deletes
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFindEmptyNotes(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+	for title, body := range map[string]string{
+		"Blank":      "<div><br></div>",
+		"Title only": "<div>Title only</div>",
+		"Nearly":     "<div>todo</div>",
+		"Real note":  "<div>Call the plumber about the leak</div>",
+	} {
+		if _, err := service.CreateNote(ctx, title, body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	empty, err := FindEmptyNotes(ctx, service, EmptyNoteOptions{})
+	if err != nil {
+		t.Fatalf("FindEmptyNotes failed: %v", err)
+	}
+	found := map[string]bool{}
+	for _, note := range empty {
+		found[note.Title] = true
+	}
+	if len(empty) != 3 || !found["Blank"] || !found["Title only"] || !found["Nearly"] {
+		t.Errorf("expected the three notes under 10 characters, got %v", found)
+	}
+
+	empty, err = FindEmptyNotes(ctx, service, EmptyNoteOptions{MaxChars: 1})
+	if err != nil || len(empty) != 2 {
+		t.Errorf("expected only the notes with no text under a threshold of 1, got %d, %v", len(empty), err)
+	}
+
+	if _, err := FindEmptyNotes(ctx, service, EmptyNoteOptions{MaxChars: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a negative threshold, got %v", err)
+	}
+}
+
+func TestDeleteEmptyNotes(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+	if err := service.CreateFolder(ctx, "Archive", ""); err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+	for _, title := range []string{"Blank", "Untitled", "Keep"} {
+		if _, err := service.CreateNote(ctx, title, "<div></div>", nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+	if err := service.MoveNote(ctx, "Untitled", "Archive"); err != nil {
+		t.Fatalf("MoveNote failed: %v", err)
+	}
+	if _, err := service.CreateNote(ctx, "Untitled", "<div>A real note that shares the title</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	empty, err := FindEmptyNotes(ctx, service, EmptyNoteOptions{Folder: "Archive"})
+	if err != nil || len(empty) != 1 {
+		t.Fatalf("expected the Archive note, got %+v, %v", empty, err)
+	}
+	empty = append(empty, Note{Title: "Blank"})
+
+	report, err := DeleteEmptyNotes(ctx, service, empty)
+	if err != nil {
+		t.Fatalf("DeleteEmptyNotes failed: %v", err)
+	}
+	if len(report.Deleted) != 1 || report.Deleted[0] != "Blank" {
+		t.Errorf("expected only Blank deleted, got %+v", report.Deleted)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Title != "Untitled" {
+		t.Errorf("expected the duplicated title skipped, got %+v", report.Skipped)
+	}
+	if _, err := service.GetNoteMetadata(ctx, "Keep"); err != nil {
+		t.Errorf("expected notes not listed to survive, got %v", err)
+	}
+}
@@ -0,0 +1,343 @@
+// ABOUTME: AES-GCM encryption at rest for local stores (audit log, history, cache, index)
+// ABOUTME: Keys live in a SecretStore; each record names its key so rotation can re-encrypt safely
+
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Secret names for local store encryption keys
+const (
+	// StoreKeyName holds the active key
+	StoreKeyName = "store-key"
+	// storeNextKeyName holds a key being rotated in, so an interrupted rotation can be resumed
+	storeNextKeyName = "store-key.next"
+	// storePreviousKeyName holds the key rotated out until every store is confirmed re-encrypted
+	storePreviousKeyName = "store-key.previous"
+)
+
+// encryptedRecordPrefix marks a sealed record: "nmenc1:<key id>:<base64 nonce+ciphertext>"
+const encryptedRecordPrefix = "nmenc1:"
+
+// storeKeySize is the AES-256 key size in bytes
+const storeKeySize = 32
+
+// ErrUnknownKey indicates a record was sealed with a key that is no longer available
+var ErrUnknownKey = errors.New("record encrypted with an unknown key")
+
+// StoreEncryptor seals and opens local store records with AES-256-GCM
+// It seals with its primary key and can open records sealed with any of its keys.
+type StoreEncryptor struct {
+	primaryID string
+	aeads     map[string]cipher.AEAD
+}
+
+// NewStoreEncryptor creates an encryptor that seals with primary and also opens records sealed with others
+func NewStoreEncryptor(primary []byte, others ...[]byte) (*StoreEncryptor, error) {
+	e := &StoreEncryptor{aeads: make(map[string]cipher.AEAD)}
+
+	for i, key := range append([][]byte{primary}, others...) {
+		if len(key) != storeKeySize {
+			return nil, fmt.Errorf("%w: store key must be %d bytes", ErrInvalidInput, storeKeySize)
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+
+		id := storeKeyID(key)
+		if i == 0 {
+			e.primaryID = id
+		}
+		e.aeads[id] = aead
+	}
+
+	return e, nil
+}
+
+// KeyID returns the identifier of the key used for sealing
+func (e *StoreEncryptor) KeyID() string {
+	return e.primaryID
+}
+
+// Seal encrypts a record; the output is a single line of text safe for line-oriented stores
+func (e *StoreEncryptor) Seal(plaintext []byte) ([]byte, error) {
+	aead := e.aeads[e.primaryID]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// The key ID is authenticated as additional data so records can't be relabeled
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(e.primaryID))
+	record := encryptedRecordPrefix + e.primaryID + ":" + base64.StdEncoding.EncodeToString(sealed)
+	return []byte(record), nil
+}
+
+// Open decrypts a sealed record; records without the encryption prefix are returned unchanged
+// so stores written before encryption was enabled stay readable
+func (e *StoreEncryptor) Open(record []byte) ([]byte, error) {
+	if !IsEncryptedRecord(record) {
+		return record, nil
+	}
+
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(string(record), encryptedRecordPrefix), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed encrypted record")
+	}
+
+	aead, ok := e.aeads[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("malformed encrypted record")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt record: %w", err)
+	}
+	return plaintext, nil
+}
+
+// IsEncryptedRecord reports whether a record was produced by Seal
+func IsEncryptedRecord(record []byte) bool {
+	return bytes.HasPrefix(record, []byte(encryptedRecordPrefix))
+}
+
+// storeKeyID derives a short, non-secret identifier for a key
+func storeKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+// generateStoreKey returns a new random AES-256 key
+func generateStoreKey() ([]byte, error) {
+	key := make([]byte, storeKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// loadStoreKey reads a hex-encoded key from the secret store
+func loadStoreKey(ctx context.Context, secrets SecretStore, name string) ([]byte, error) {
+	value, err := secrets.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := hex.DecodeString(value)
+	if err != nil || len(key) != storeKeySize {
+		return nil, fmt.Errorf("stored key %s is not a %d-byte hex key", name, storeKeySize)
+	}
+	return key, nil
+}
+
+// LoadStoreEncryptor returns an encryptor for the active store key, creating the key on first use
+// Keys left behind by an unfinished rotation, the one rotating in and the one rotating out, are
+// included so records sealed with either still open.
+func LoadStoreEncryptor(ctx context.Context, secrets SecretStore) (*StoreEncryptor, error) {
+	key, err := loadStoreKey(ctx, secrets, StoreKeyName)
+	if errors.Is(err, ErrSecretNotFound) {
+		if key, err = generateStoreKey(); err != nil {
+			return nil, err
+		}
+		if err := secrets.Set(ctx, StoreKeyName, hex.EncodeToString(key)); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	others := [][]byte{}
+	for _, name := range []string{storeNextKeyName, storePreviousKeyName} {
+		if other, err := loadStoreKey(ctx, secrets, name); err == nil {
+			others = append(others, other)
+		}
+	}
+
+	return NewStoreEncryptor(key, others...)
+}
+
+// RotateStoreKey generates a new store key and re-encrypts every record in paths with it.
+// Each store is locked first, and rotation refuses to start while another process holds one
+// (ErrStoreInUse), so no record is appended between reading a store and replacing it.
+// The new key is saved before any file is rewritten and only becomes active once every file
+// has been rewritten; the old key stays available for decryption until every store is read back
+// and confirmed sealed with the new key. An interrupted rotation can simply be run again.
+// Missing files are skipped. Returns the new key's ID.
+func RotateStoreKey(ctx context.Context, secrets SecretStore, paths []string) (string, error) {
+	for _, path := range paths {
+		lock, err := lockStoreExclusive(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to rotate store key: %w", err)
+		}
+		defer lock.Release() //nolint:errcheck // releasing a lock file only closes it
+	}
+
+	// Make sure there is an active key to rotate away from
+	if _, err := LoadStoreEncryptor(ctx, secrets); err != nil {
+		return "", fmt.Errorf("failed to rotate store key: %w", err)
+	}
+
+	// Resume an interrupted rotation rather than orphaning files already sealed with its key
+	next, err := loadStoreKey(ctx, secrets, storeNextKeyName)
+	if errors.Is(err, ErrSecretNotFound) {
+		if next, err = generateStoreKey(); err != nil {
+			return "", fmt.Errorf("failed to rotate store key: %w", err)
+		}
+		if err := secrets.Set(ctx, storeNextKeyName, hex.EncodeToString(next)); err != nil {
+			return "", fmt.Errorf("failed to rotate store key: %w", err)
+		}
+	} else if err != nil {
+		return "", fmt.Errorf("failed to rotate store key: %w", err)
+	}
+
+	oldKey, err := loadStoreKey(ctx, secrets, StoreKeyName)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate store key: %w", err)
+	}
+	openers := [][]byte{oldKey}
+	if previous, err := loadStoreKey(ctx, secrets, storePreviousKeyName); err == nil {
+		openers = append(openers, previous)
+	}
+	rotated, err := NewStoreEncryptor(next, openers...)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate store key: %w", err)
+	}
+
+	for _, path := range paths {
+		if err := ReencryptStoreFile(path, rotated); err != nil {
+			return "", fmt.Errorf("failed to rotate store key: %w", err)
+		}
+	}
+
+	// Activate the new key, keeping the old one for decryption until the stores are confirmed
+	if !bytes.Equal(oldKey, next) {
+		if err := secrets.Set(ctx, storePreviousKeyName, hex.EncodeToString(oldKey)); err != nil {
+			return "", fmt.Errorf("failed to rotate store key: %w", err)
+		}
+	}
+	if err := secrets.Set(ctx, StoreKeyName, hex.EncodeToString(next)); err != nil {
+		return "", fmt.Errorf("failed to rotate store key: %w", err)
+	}
+	if err := secrets.Delete(ctx, storeNextKeyName); err != nil {
+		return "", fmt.Errorf("failed to rotate store key: %w", err)
+	}
+
+	for _, path := range paths {
+		if err := verifyStoreFile(path, rotated); err != nil {
+			return "", fmt.Errorf("failed to rotate store key: %w", err)
+		}
+	}
+	if err := secrets.Delete(ctx, storePreviousKeyName); err != nil {
+		return "", fmt.Errorf("failed to rotate store key: %w", err)
+	}
+
+	return rotated.KeyID(), nil
+}
+
+// verifyStoreFile checks that every line of a store is sealed with encryptor's primary key and opens
+// A missing file passes.
+func verifyStoreFile(path string, encryptor *StoreEncryptor) error {
+	data, err := os.ReadFile(path) // #nosec G304 - path is a configured local store
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	prefix := []byte(encryptedRecordPrefix + encryptor.KeyID() + ":")
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if !bytes.HasPrefix(line, prefix) {
+			return fmt.Errorf("%s: line %d is not sealed with the new key", path, i+1)
+		}
+		if _, err := encryptor.Open(line); err != nil {
+			return fmt.Errorf("%s: line %d: %w", path, i+1, err)
+		}
+	}
+	return nil
+}
+
+// ReencryptStoreFile rewrites a line-oriented store so every line is sealed with encryptor's primary key
+// Plaintext lines are encrypted as well. The file is replaced atomically; a missing file is skipped.
+func ReencryptStoreFile(path string, encryptor *StoreEncryptor) error {
+	data, err := os.ReadFile(path) // #nosec G304 - path is a configured local store
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		plaintext, err := encryptor.Open(line)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		sealed, err := encryptor.Seal(plaintext)
+		if err != nil {
+			return err
+		}
+		out.Write(sealed)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".rotate-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // removal after a successful rename is a no-op
+
+	if _, err := tmp.Write(out.Bytes()); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // already failing
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
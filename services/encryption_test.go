@@ -0,0 +1,288 @@
+// ABOUTME: Unit tests for local store encryption
+// ABOUTME: Tests sealing, opening, key creation, and key rotation with an in-memory secret store
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// memorySecretStore implements SecretStore in memory
+type memorySecretStore struct {
+	secrets map[string]string
+	failSet string
+}
+
+func newMemorySecretStore() *memorySecretStore {
+	return &memorySecretStore{secrets: map[string]string{}}
+}
+
+func (m *memorySecretStore) Get(ctx context.Context, name string) (string, error) {
+	value, ok := m.secrets[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+func (m *memorySecretStore) Set(ctx context.Context, name, value string) error {
+	if name == m.failSet {
+		return errors.New("keychain locked")
+	}
+	m.secrets[name] = value
+	return nil
+}
+
+func (m *memorySecretStore) Delete(ctx context.Context, name string) error {
+	delete(m.secrets, name)
+	return nil
+}
+
+// TestStoreEncryptorRoundTrip tests that sealed records open to the original plaintext
+func TestStoreEncryptorRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	encryptor, err := NewStoreEncryptor(key)
+	if err != nil {
+		t.Fatalf("NewStoreEncryptor failed: %v", err)
+	}
+
+	plaintext := []byte(`{"title":"Secret plans"}`)
+	sealed, err := encryptor.Seal(plaintext)
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	if bytes.Contains(sealed, []byte("Secret")) || bytes.Contains(sealed, []byte("\n")) {
+		t.Errorf("sealed record leaks plaintext or spans lines: %q", sealed)
+	}
+	if !IsEncryptedRecord(sealed) {
+		t.Errorf("sealed record not recognized: %q", sealed)
+	}
+
+	opened, err := encryptor.Open(sealed)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("Open = %q, want %q", opened, plaintext)
+	}
+
+	// Plaintext records written before encryption was enabled pass through
+	if opened, _ := encryptor.Open([]byte("legacy")); string(opened) != "legacy" {
+		t.Errorf("plaintext record changed: %q", opened)
+	}
+}
+
+// TestStoreEncryptorRejectsTamperingAndUnknownKeys tests authentication failures
+func TestStoreEncryptorRejectsTamperingAndUnknownKeys(t *testing.T) {
+	first, _ := NewStoreEncryptor(bytes.Repeat([]byte{1}, 32))
+	second, _ := NewStoreEncryptor(bytes.Repeat([]byte{2}, 32))
+
+	sealed, _ := first.Seal([]byte("body"))
+
+	if _, err := second.Open(sealed); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+
+	tampered := []byte(strings.Replace(string(sealed), first.KeyID(), second.KeyID(), 1))
+	both, _ := NewStoreEncryptor(bytes.Repeat([]byte{2}, 32), bytes.Repeat([]byte{1}, 32))
+	if _, err := both.Open(tampered); err == nil {
+		t.Error("expected relabeled record to fail authentication")
+	}
+
+	if _, err := NewStoreEncryptor([]byte("short")); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a short key, got %v", err)
+	}
+}
+
+// TestLoadStoreEncryptorCreatesKey tests that the first load creates and persists a key
+func TestLoadStoreEncryptorCreatesKey(t *testing.T) {
+	secrets := newMemorySecretStore()
+
+	first, err := LoadStoreEncryptor(context.Background(), secrets)
+	if err != nil {
+		t.Fatalf("LoadStoreEncryptor failed: %v", err)
+	}
+	if len(secrets.secrets[StoreKeyName]) != 64 {
+		t.Fatalf("expected a hex key to be stored, got %q", secrets.secrets[StoreKeyName])
+	}
+
+	second, err := LoadStoreEncryptor(context.Background(), secrets)
+	if err != nil {
+		t.Fatalf("LoadStoreEncryptor failed: %v", err)
+	}
+	if first.KeyID() != second.KeyID() {
+		t.Errorf("key changed between loads: %s != %s", first.KeyID(), second.KeyID())
+	}
+}
+
+// TestRotateStoreKey tests that rotation re-encrypts stores and activates the new key
+func TestRotateStoreKey(t *testing.T) {
+	ctx := context.Background()
+	secrets := newMemorySecretStore()
+	old, _ := LoadStoreEncryptor(ctx, secrets)
+
+	dir := t.TempDir()
+	store := filepath.Join(dir, "audit.log")
+	sealed, _ := old.Seal([]byte("sealed entry"))
+	content := string(sealed) + "\nplain entry\n"
+	if err := os.WriteFile(store, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keyID, err := RotateStoreKey(ctx, secrets, []string{store, filepath.Join(dir, "missing.log")})
+	if err != nil {
+		t.Fatalf("RotateStoreKey failed: %v", err)
+	}
+
+	if keyID == old.KeyID() {
+		t.Error("expected a new key ID")
+	}
+	if _, ok := secrets.secrets[storeNextKeyName]; ok {
+		t.Error("pending key should be removed after rotation")
+	}
+	if _, ok := secrets.secrets[storePreviousKeyName]; ok {
+		t.Error("old key should be removed once the stores are confirmed")
+	}
+
+	current, _ := LoadStoreEncryptor(ctx, secrets)
+	if current.KeyID() != keyID {
+		t.Errorf("active key = %s, want %s", current.KeyID(), keyID)
+	}
+
+	data, _ := os.ReadFile(store)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{"sealed entry", "plain entry"}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d", len(want), len(lines))
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, encryptedRecordPrefix+keyID+":") {
+			t.Errorf("line %d not sealed with the new key: %q", i, line)
+		}
+		opened, err := current.Open([]byte(line))
+		if err != nil || string(opened) != want[i] {
+			t.Errorf("line %d = %q (%v), want %q", i, opened, err, want[i])
+		}
+	}
+}
+
+// TestRotateStoreKeyResumes tests that an interrupted rotation can be completed by running it again
+func TestRotateStoreKeyResumes(t *testing.T) {
+	ctx := context.Background()
+	secrets := newMemorySecretStore()
+	old, _ := LoadStoreEncryptor(ctx, secrets)
+
+	store := filepath.Join(t.TempDir(), "audit.log")
+	sealed, _ := old.Seal([]byte("entry"))
+	if err := os.WriteFile(store, append(sealed, '\n'), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fail when activating the new key, after the store has been rewritten
+	secrets.failSet = StoreKeyName
+	if _, err := RotateStoreKey(ctx, secrets, []string{store}); err == nil {
+		t.Fatal("expected rotation to fail")
+	}
+
+	// Records already rewritten with the pending key must still open
+	pending, err := LoadStoreEncryptor(ctx, secrets)
+	if err != nil {
+		t.Fatalf("LoadStoreEncryptor failed: %v", err)
+	}
+	data, _ := os.ReadFile(store)
+	if _, err := pending.Open(bytes.TrimSpace(data)); err != nil {
+		t.Fatalf("record unreadable after interrupted rotation: %v", err)
+	}
+
+	secrets.failSet = ""
+	keyID, err := RotateStoreKey(ctx, secrets, []string{store})
+	if err != nil {
+		t.Fatalf("resumed rotation failed: %v", err)
+	}
+
+	current, _ := LoadStoreEncryptor(ctx, secrets)
+	data, _ = os.ReadFile(store)
+	opened, err := current.Open(bytes.TrimSpace(data))
+	if err != nil || string(opened) != "entry" || current.KeyID() != keyID {
+		t.Errorf("unexpected state after resume: %q, %v", opened, err)
+	}
+}
+
+// TestRotateStoreKeyKeepsOldKeyUntilConfirmed tests that records sealed with the old key still open
+// when rotation stops after saving the old key but before the stores are confirmed
+func TestRotateStoreKeyKeepsOldKeyUntilConfirmed(t *testing.T) {
+	ctx := context.Background()
+	secrets := newMemorySecretStore()
+	old, _ := LoadStoreEncryptor(ctx, secrets)
+
+	store := filepath.Join(t.TempDir(), "audit.log")
+	if err := os.WriteFile(store, []byte("plain entry\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	secrets.failSet = StoreKeyName
+	if _, err := RotateStoreKey(ctx, secrets, []string{store}); err == nil {
+		t.Fatal("expected rotation to fail")
+	}
+
+	late, _ := old.Seal([]byte("late entry"))
+	current, err := LoadStoreEncryptor(ctx, secrets)
+	if err != nil {
+		t.Fatalf("LoadStoreEncryptor failed: %v", err)
+	}
+	if opened, err := current.Open(late); err != nil || string(opened) != "late entry" {
+		t.Errorf("record sealed with the old key unreadable mid-rotation: %q, %v", opened, err)
+	}
+	if _, ok := secrets.secrets[storePreviousKeyName]; !ok {
+		t.Error("expected the old key to be kept until the stores are confirmed")
+	}
+
+	secrets.failSet = ""
+	if _, err := RotateStoreKey(ctx, secrets, []string{store}); err != nil {
+		t.Fatalf("resumed rotation failed: %v", err)
+	}
+	if _, ok := secrets.secrets[storePreviousKeyName]; ok {
+		t.Error("old key should be removed once the stores are confirmed")
+	}
+}
+
+// TestRotateStoreKeyRefusesStoreInUse tests that rotation won't rewrite a store another process holds
+func TestRotateStoreKeyRefusesStoreInUse(t *testing.T) {
+	ctx := context.Background()
+	secrets := newMemorySecretStore()
+	old, _ := LoadStoreEncryptor(ctx, secrets)
+
+	store := filepath.Join(t.TempDir(), "audit.log")
+	if err := os.WriteFile(store, []byte("plain entry\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := LockStoreShared(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RotateStoreKey(ctx, secrets, []string{store}); !errors.Is(err, ErrStoreInUse) {
+		t.Fatalf("expected ErrStoreInUse, got %v", err)
+	}
+	if data, _ := os.ReadFile(store); string(data) != "plain entry\n" {
+		t.Errorf("store rewritten while in use: %q", data)
+	}
+	if _, ok := secrets.secrets[storeNextKeyName]; ok {
+		t.Error("expected no key to be generated while a store is in use")
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := RotateStoreKey(ctx, secrets, []string{store})
+	if err != nil || keyID == old.KeyID() {
+		t.Errorf("expected rotation to succeed once released, got %q, %v", keyID, err)
+	}
+}
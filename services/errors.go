@@ -6,8 +6,10 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // Sentinel errors for common Apple Notes failures
@@ -18,24 +20,54 @@ var (
 	ErrPermissionDenied   = errors.New("permission denied to access Notes")
 	ErrScriptTimeout      = errors.New("AppleScript execution timeout")
 	ErrInvalidInput       = errors.New("invalid input parameters")
+	ErrConflict           = errors.New("note was modified since it was read")
+	ErrNotSupported       = errors.New("operation not supported by this notes provider")
+	ErrReadOnly           = errors.New("notes are read-only in this configuration")
 )
 
+// TimeoutError is a script that ran out of time, recording how long it ran and the limit it hit
+// It matches ErrScriptTimeout with errors.Is.
+type TimeoutError struct {
+	Elapsed time.Duration
+	Limit   time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%v after %s (limit %s)", ErrScriptTimeout, e.Elapsed, e.Limit)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return ErrScriptTimeout
+}
+
 // noteNotFoundPattern matches various "note not found" error messages
 // Matches "note" followed by anything (non-greedy), then "not found" as a phrase
 var noteNotFoundPattern = regexp.MustCompile(`(?i)note.*?\bnot\s+found\b`)
 
+// cantGetFolderPattern matches AppleScript's "Can't get folder" error, which carries the same -1728
+// code as a Notes app that isn't responding
+var cantGetFolderPattern = regexp.MustCompile(`(?i)can[’']t get folder`)
+
+// cantGetNotePattern matches AppleScript's "Can't get note" error, which is also reported as -1728
+var cantGetNotePattern = regexp.MustCompile(`(?i)can[’']t get note`)
+
 // folderNotFoundPattern matches various "folder not found" error messages
 // Matches "folder" followed by anything (non-greedy), then "not found" as a phrase
 var folderNotFoundPattern = regexp.MustCompile(`(?i)folder.*?\bnot\s+found\b`)
 
 // DetectError analyzes stderr output and context errors to return structured errors
 // Pattern matching:
+// - "Can't get folder" → ErrFolderNotFound, "Can't get note" → ErrNoteNotFound
 // - "-1728" or "event not handled" → ErrNotesAppNotRunning
 // - "note.*not found" (regex) → ErrNoteNotFound
 // - "not allowed" or "-1743" → ErrPermissionDenied
-// - context.DeadlineExceeded → ErrScriptTimeout
+// - context.DeadlineExceeded → ErrScriptTimeout (a *TimeoutError from the executor is kept as is)
 func DetectError(ctx context.Context, stderr string, err error) error {
-	// Check for context deadline exceeded first
+	// Check for timeouts first, keeping the executor's timing when it has any
+	var timeout *TimeoutError
+	if errors.As(err, &timeout) {
+		return timeout
+	}
 	if errors.Is(err, context.DeadlineExceeded) {
 		return ErrScriptTimeout
 	}
@@ -47,6 +79,14 @@ func DetectError(ctx context.Context, stderr string, err error) error {
 
 	stderrLower := strings.ToLower(stderr)
 
+	// Missing folders and notes report -1728 too, so recognize them before the app check
+	if cantGetFolderPattern.MatchString(stderr) {
+		return ErrFolderNotFound
+	}
+	if cantGetNotePattern.MatchString(stderr) {
+		return ErrNoteNotFound
+	}
+
 	// Check for Notes app not running
 	if strings.Contains(stderrLower, "-1728") || strings.Contains(stderrLower, "event not handled") {
 		return ErrNotesAppNotRunning
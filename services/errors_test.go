@@ -196,6 +196,21 @@ func TestDetectErrorWithTimedOutContext(t *testing.T) {
 	}
 }
 
+func TestDetectErrorKeepsTimeoutDetails(t *testing.T) {
+	timeout := &TimeoutError{Elapsed: 9900 * time.Millisecond, Limit: 10 * time.Second}
+
+	got := DetectError(context.Background(), "", timeout)
+	if got != timeout {
+		t.Errorf("DetectError() = %v, want the executor's TimeoutError", got)
+	}
+	if !errors.Is(got, ErrScriptTimeout) {
+		t.Error("expected TimeoutError to match ErrScriptTimeout")
+	}
+	if want := "AppleScript execution timeout after 9.9s (limit 10s)"; got.Error() != want {
+		t.Errorf("Error() = %q, want %q", got.Error(), want)
+	}
+}
+
 func TestSentinelErrorIdentity(t *testing.T) {
 	// Verify that sentinel errors are distinct
 	tests := []struct {
@@ -0,0 +1,344 @@
+// ABOUTME: Batch export of the notes library to a directory of markdown files
+// ABOUTME: Include/exclude globs on "<folder path>/<title>" select notes from metadata before any body is read
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// exportFileExt is the extension of files written by ExportAllNotes
+const exportFileExt = ".md"
+
+// ExportFilter selects notes for a batch export by glob patterns
+// Patterns match a note's folder path and title joined by "/", such as "Work/2024/Plan", case
+// insensitively. "*" and "?" stay within one path segment and "**" spans segments. A pattern that
+// matches a folder path also covers every note below it, so "Personal" works like "Personal/**".
+// A note is exported when it matches some Include pattern (or Include is empty) and no Exclude pattern.
+type ExportFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// ExportAllOptions controls ExportAllNotes
+type ExportAllOptions struct {
+	Filter ExportFilter
+	// Resume continues from the checkpoint manifest in the export directory: notes it lists
+	// with an unchanged modification date are not exported again
+	Resume bool
+	// Since, when set, limits the export to notes modified at or after it
+	Since *time.Time
+	// SinceLast sets Since to the start of the last export the manifest records as complete;
+	// with no complete export recorded, every note is exported
+	SinceLast bool
+}
+
+// ExportCandidate is a note chosen for a batch export, with its folder's full path
+type ExportCandidate struct {
+	Note       Note
+	FolderPath string
+}
+
+// ExportedNote records a note written by a batch export
+type ExportedNote struct {
+	Title string `json:"title"`
+	File  string `json:"file"`
+}
+
+// ExportSkip records a note a batch export left out
+type ExportSkip struct {
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// ExportReport summarizes a batch export
+type ExportReport struct {
+	Dir       string         `json:"dir"`
+	Exported  []ExportedNote `json:"exported"`
+	Resumed   int            `json:"resumed"`   // notes already exported by the run being resumed
+	Unchanged int            `json:"unchanged"` // notes not modified since opts.Since
+	Skipped   []ExportSkip   `json:"skipped"`
+}
+
+// exportMatcher is an ExportFilter with its patterns compiled
+type exportMatcher struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// compile checks and compiles the filter's patterns
+func (f ExportFilter) compile() (*exportMatcher, error) {
+	m := &exportMatcher{}
+	for _, pattern := range f.Include {
+		re, err := compileExportPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.include = append(m.include, re)
+	}
+	for _, pattern := range f.Exclude {
+		re, err := compileExportPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.exclude = append(m.exclude, re)
+	}
+	return m, nil
+}
+
+// compileExportPattern converts a glob to an anchored, case-insensitive regular expression
+func compileExportPattern(pattern string) (*regexp.Regexp, error) {
+	runes := []rune(strings.Trim(strings.TrimSpace(pattern), "/"))
+	if len(runes) == 0 {
+		return nil, fmt.Errorf("%w: export patterns cannot be empty", ErrInvalidInput)
+	}
+
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			if i+2 < len(runes) && runes[i+2] == '/' {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else {
+				b.WriteString(".*")
+				i++
+			}
+		case runes[i] == '*':
+			b.WriteString("[^/]*")
+		case runes[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// matchesAny reports whether a pattern matches the note path or one of its folders
+func matchesAny(patterns []*regexp.Regexp, folderPath, title string) bool {
+	candidates := []string{title}
+	if folderPath != "" {
+		segments := strings.Split(folderPath, "/")
+		candidates = []string{folderPath + "/" + title}
+		for i := range segments {
+			candidates = append(candidates, strings.Join(segments[:i+1], "/"))
+		}
+	}
+	for _, re := range patterns {
+		for _, candidate := range candidates {
+			if re.MatchString(candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Match reports whether the filter selects a note
+func (m *exportMatcher) Match(folderPath, title string) bool {
+	if len(m.include) > 0 && !matchesAny(m.include, folderPath, title) {
+		return false
+	}
+	return !matchesAny(m.exclude, folderPath, title)
+}
+
+// SelectExportNotes lists the notes a filter selects, ordered by folder path and title
+// Only the folder hierarchy and note metadata are read. A folder name that appears more than once
+// in the hierarchy resolves to its first path, since notes only report their folder's name.
+func SelectExportNotes(ctx context.Context, notes NotesService, filter ExportFilter) ([]ExportCandidate, error) {
+	matcher, err := filter.compile()
+	if err != nil {
+		return []ExportCandidate{}, err
+	}
+
+	root, err := notes.GetFolderHierarchy(ctx)
+	if err != nil {
+		return []ExportCandidate{}, fmt.Errorf("failed to read folders to export: %w", err)
+	}
+	folderPaths := map[string]string{}
+	for _, folder := range FlattenFolderTree(root) {
+		name := folder.Path[strings.LastIndex(folder.Path, "/")+1:]
+		if _, ok := folderPaths[name]; !ok {
+			folderPaths[name] = folder.Path
+		}
+	}
+
+	library, err := notes.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return []ExportCandidate{}, fmt.Errorf("failed to list notes to export: %w", err)
+	}
+
+	selected := []ExportCandidate{}
+	for _, note := range library {
+		folderPath, ok := folderPaths[note.Folder]
+		if !ok {
+			folderPath = note.Folder
+		}
+		if matcher.Match(folderPath, note.Title) {
+			selected = append(selected, ExportCandidate{Note: note, FolderPath: folderPath})
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		a, b := strings.ToLower(selected[i].FolderPath), strings.ToLower(selected[j].FolderPath)
+		if a != b {
+			return a < b
+		}
+		return strings.ToLower(selected[i].Note.Title) < strings.ToLower(selected[j].Note.Title)
+	})
+	return selected, nil
+}
+
+// exportDir returns the directory a folder path is exported to, one sanitized segment per folder
+func exportDir(dir, folderPath string) string {
+	parts := []string{dir}
+	if folderPath != "" {
+		for _, segment := range strings.Split(folderPath, "/") {
+			parts = append(parts, SanitizeFileName(segment))
+		}
+	}
+	return filepath.Join(parts...)
+}
+
+// ExportAllNotes writes every selected note to dir as markdown, mirroring the folder hierarchy
+// File names come from sanitized titles, suffixed -2, -3, ... when two notes in a folder collide.
+// Locked notes, and notes that fail to export, are listed as skipped rather than failing the run.
+// Progress is checkpointed to ExportManifestName in dir every few notes and when the run stops,
+// so a run cut short by an error or timeout can be continued with opts.Resume. With opts.Since or
+// opts.SinceLast, notes modified earlier are left as the previous export wrote them.
+func ExportAllNotes(ctx context.Context, notes NotesService, dir string, opts ExportAllOptions, now time.Time) (*ExportReport, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, fmt.Errorf("%w: an export directory is required", ErrInvalidInput)
+	}
+
+	candidates, err := SelectExportNotes(ctx, notes, opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	// Notes finished by an earlier run keep their files and their names stay taken
+	previous := map[string]ExportManifestEntry{}
+	namers := map[string]*FileNamer{}
+	namerFor := func(folderDir string) *FileNamer {
+		namer, ok := namers[folderDir]
+		if !ok {
+			namer = NewFileNamer()
+			namers[folderDir] = namer
+		}
+		return namer
+	}
+	manifest := &ExportManifest{Version: exportManifestVersion, Started: now.UTC(), Notes: []ExportManifestEntry{}}
+	since := opts.Since
+	if opts.Resume || opts.Since != nil || opts.SinceLast {
+		checkpoint, err := LoadExportManifest(dir)
+		if err != nil {
+			return nil, err
+		}
+		if checkpoint != nil {
+			manifest.LastExport = checkpoint.LastExport
+			if opts.SinceLast {
+				since = checkpoint.LastExport
+			}
+			for _, entry := range checkpoint.Notes {
+				previous[entry.ID] = entry
+				file := filepath.Join(dir, filepath.FromSlash(entry.File))
+				namerFor(filepath.Dir(file)).Reserve(filepath.Base(file))
+			}
+		}
+	}
+
+	report := &ExportReport{Dir: dir, Exported: []ExportedNote{}, Skipped: []ExportSkip{}}
+	pending := 0
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return report, checkpointExport(dir, manifest, fmt.Errorf("export interrupted: %w", err))
+		}
+
+		note := candidate.Note
+		if note.PasswordProtected {
+			report.Skipped = append(report.Skipped, ExportSkip{Title: note.Title, Reason: "locked"})
+			continue
+		}
+
+		prior, known := previous[note.ID]
+		known = known && note.ID != ""
+		if since != nil && note.ModificationDate.Before(*since) {
+			if known {
+				manifest.Notes = append(manifest.Notes, prior)
+			}
+			report.Unchanged++
+			continue
+		}
+		if known && sameModification(prior.Modified, note.ModificationDate) {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(prior.File))); err == nil {
+				manifest.Notes = append(manifest.Notes, prior)
+				report.Resumed++
+				continue
+			}
+		}
+
+		var file string
+		if known {
+			file = filepath.Join(dir, filepath.FromSlash(prior.File))
+		} else {
+			folderDir := exportDir(dir, candidate.FolderPath)
+			file = filepath.Join(folderDir, namerFor(folderDir).Name(note.Title, exportFileExt))
+		}
+
+		markdown, err := notes.ExportNoteMarkdown(ctx, note.Title)
+		if err != nil {
+			report.Skipped = append(report.Skipped, ExportSkip{Title: note.Title, Reason: err.Error()})
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+			return report, checkpointExport(dir, manifest, fmt.Errorf("failed to create export directory: %w", err))
+		}
+		if err := os.WriteFile(file, []byte(markdown), 0o600); err != nil {
+			return report, checkpointExport(dir, manifest, fmt.Errorf("failed to write %s: %w", file, err))
+		}
+		report.Exported = append(report.Exported, ExportedNote{Title: note.Title, File: file})
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			rel = file
+		}
+		manifest.Notes = append(manifest.Notes, ExportManifestEntry{
+			ID: note.ID, Title: note.Title, File: filepath.ToSlash(rel), Modified: note.ModificationDate,
+		})
+		if pending++; pending >= exportCheckpointInterval {
+			if err := saveExportManifest(dir, manifest); err != nil {
+				return report, err
+			}
+			pending = 0
+		}
+	}
+
+	started := manifest.Started
+	manifest.Complete = true
+	manifest.LastExport = &started
+	if err := saveExportManifest(dir, manifest); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// checkpointExport saves the manifest of a run that stopped early and returns the reason it stopped
+func checkpointExport(dir string, manifest *ExportManifest, cause error) error {
+	if err := saveExportManifest(dir, manifest); err != nil {
+		return fmt.Errorf("%w (and the checkpoint could not be saved: %v)", cause, err)
+	}
+	return cause
+}
@@ -0,0 +1,210 @@
+// ABOUTME: Unit tests for batch export to markdown files
+// ABOUTME: Covers glob patterns, selection against the folder hierarchy, and the files written
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportFilterMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     ExportFilter
+		folderPath string
+		title      string
+		want       bool
+	}{
+		{"no patterns", ExportFilter{}, "Work", "Plan", true},
+		{"exclude subtree", ExportFilter{Exclude: []string{"Personal/**"}}, "Personal/Health", "Log", false},
+		{"exclude folder name", ExportFilter{Exclude: []string{"personal"}}, "Personal/Health", "Log", false},
+		{"exclude other folder", ExportFilter{Exclude: []string{"Personal/**"}}, "Work", "Personal", true},
+		{"include title glob", ExportFilter{Include: []string{"Work/2024*"}}, "Work", "2024 Roadmap", true},
+		{"include subfolder glob", ExportFilter{Include: []string{"Work/2024*"}}, "Work/2024 Q1", "Plan", true},
+		{"include misses", ExportFilter{Include: []string{"Work/2024*"}}, "Work", "2023 Roadmap", false},
+		{"star stays in segment", ExportFilter{Include: []string{"Work/P*"}}, "Work/Old", "Plan", false},
+		{"double star spans", ExportFilter{Include: []string{"**/Plan"}}, "Work/Old", "Plan", true},
+		{"question mark", ExportFilter{Include: []string{"Notes/Q?"}}, "Notes", "Q3", true},
+		{"exclude wins", ExportFilter{Include: []string{"Work/**"}, Exclude: []string{"**/Draft*"}}, "Work", "Draft 1", false},
+		{"literal characters", ExportFilter{Include: []string{"Notes/a.b (c)"}}, "Notes", "a.b (c)", true},
+		{"literal dot", ExportFilter{Include: []string{"Notes/a.b"}}, "Notes", "axb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := tt.filter.compile()
+			if err != nil {
+				t.Fatalf("compile failed: %v", err)
+			}
+			if got := matcher.Match(tt.folderPath, tt.title); got != tt.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.folderPath, tt.title, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (ExportFilter{Exclude: []string{" / "}}).compile(); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected an empty pattern to be rejected, got %v", err)
+	}
+}
+
+func TestSelectExportNotes(t *testing.T) {
+	ctx := context.Background()
+	notes := newTestMemoryService()
+	_ = notes.CreateFolder(ctx, "Work", "")
+	_ = notes.CreateFolder(ctx, "Archive", "Work")
+	_ = notes.CreateFolder(ctx, "Personal", "")
+	for title, folder := range map[string]string{"Roadmap": "Work", "Old plan": "Archive", "Diary": "Personal", "Inbox": "Notes"} {
+		_, _ = notes.CreateNote(ctx, title, "<div>"+title+"</div>", nil)
+		_ = notes.MoveNote(ctx, title, folder)
+	}
+
+	selected, err := SelectExportNotes(ctx, notes, ExportFilter{Exclude: []string{"Personal"}})
+	if err != nil {
+		t.Fatalf("SelectExportNotes failed: %v", err)
+	}
+	var got []string
+	for _, candidate := range selected {
+		got = append(got, candidate.FolderPath+"/"+candidate.Note.Title)
+	}
+	want := []string{"Notes/Inbox", "Work/Roadmap", "Work/Archive/Old plan"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestExportAllNotes(t *testing.T) {
+	ctx := context.Background()
+	notes := newTestMemoryService()
+	_ = notes.CreateFolder(ctx, "Work: 2024", "")
+	_, _ = notes.CreateNote(ctx, "🚀 Launch/Plan", "<div>go</div>", nil)
+	_, _ = notes.CreateNote(ctx, "Launch-Plan", "<div>again</div>", nil)
+	_ = notes.MoveNote(ctx, "🚀 Launch/Plan", "Work: 2024")
+	_ = notes.MoveNote(ctx, "Launch-Plan", "Work: 2024")
+
+	dir := t.TempDir()
+	report, err := ExportAllNotes(ctx, notes, dir, ExportAllOptions{}, time.Now())
+	if err != nil {
+		t.Fatalf("ExportAllNotes failed: %v", err)
+	}
+	if len(report.Exported) != 2 || len(report.Skipped) != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	for _, name := range []string{"Launch-Plan.md", "Launch-Plan-2.md"} {
+		if _, err := os.Stat(filepath.Join(dir, "Work- 2024", name)); err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+
+	if _, err := ExportAllNotes(ctx, notes, " ", ExportAllOptions{}, time.Now()); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a missing directory to be rejected, got %v", err)
+	}
+}
+
+func TestExportAllNotesResume(t *testing.T) {
+	ctx := context.Background()
+	notes := newTestMemoryService()
+	for _, title := range []string{"Alpha", "Beta", "Gamma"} {
+		_, _ = notes.CreateNote(ctx, title, "<div>"+title+"</div>", nil)
+	}
+	dir := t.TempDir()
+
+	// A run cancelled before it starts still leaves an incomplete checkpoint
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := ExportAllNotes(cancelled, notes, dir, ExportAllOptions{}, time.Now()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled run to fail, got %v", err)
+	}
+	manifest, err := LoadExportManifest(dir)
+	if err != nil || manifest == nil || manifest.Complete || len(manifest.Notes) != 0 {
+		t.Fatalf("expected an empty, incomplete checkpoint, got %+v, %v", manifest, err)
+	}
+
+	if _, err := ExportAllNotes(ctx, notes, dir, ExportAllOptions{}, time.Now()); err != nil {
+		t.Fatalf("ExportAllNotes failed: %v", err)
+	}
+	manifest, err = LoadExportManifest(dir)
+	if err != nil || !manifest.Complete || len(manifest.Notes) != 3 {
+		t.Fatalf("expected a complete checkpoint of 3 notes, got %+v, %v", manifest, err)
+	}
+
+	// Resuming re-exports only the changed note and the one whose file went missing
+	_ = notes.UpdateNote(ctx, "Beta", "<div>Beta v2</div>")
+	_ = os.Remove(filepath.Join(dir, "Notes", "Gamma.md"))
+	report, err := ExportAllNotes(ctx, notes, dir, ExportAllOptions{Resume: true}, time.Now())
+	if err != nil {
+		t.Fatalf("resumed ExportAllNotes failed: %v", err)
+	}
+	if report.Resumed != 1 || len(report.Exported) != 2 {
+		t.Errorf("expected 1 resumed and 2 exported notes, got %+v", report)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "Notes", "Beta.md"))
+	if err != nil || !strings.Contains(string(data), "Beta v2") {
+		t.Errorf("expected Beta to be rewritten in place, got %q, %v", data, err)
+	}
+}
+
+func TestLoadExportManifest(t *testing.T) {
+	dir := t.TempDir()
+	if manifest, err := LoadExportManifest(dir); manifest != nil || err != nil {
+		t.Errorf("expected no manifest in an empty directory, got %+v, %v", manifest, err)
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, ExportManifestName), []byte(`{"version": 99}`), 0o600)
+	if _, err := LoadExportManifest(dir); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a newer manifest version to be rejected, got %v", err)
+	}
+}
+
+func TestExportAllNotesSince(t *testing.T) {
+	ctx := context.Background()
+	notes := newTestMemoryService()
+	for _, title := range []string{"Alpha", "Beta"} {
+		_, _ = notes.CreateNote(ctx, title, "<div>"+title+"</div>", nil)
+	}
+	dir := t.TempDir()
+
+	// With no previous export, --since last exports everything
+	beta, _ := notes.GetNoteMetadata(ctx, "Beta")
+	first := beta.ModificationDate.Add(time.Second)
+	report, err := ExportAllNotes(ctx, notes, dir, ExportAllOptions{SinceLast: true}, first)
+	if err != nil || len(report.Exported) != 2 {
+		t.Fatalf("expected both notes in the first export, got %+v, %v", report, err)
+	}
+
+	_ = notes.UpdateNote(ctx, "Beta", "<div>Beta v2</div>")
+	_, _ = notes.CreateNote(ctx, "Beta?", "<div>another Beta</div>", nil)
+	report, err = ExportAllNotes(ctx, notes, dir, ExportAllOptions{SinceLast: true}, first.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("ExportAllNotes failed: %v", err)
+	}
+	if len(report.Exported) != 2 || report.Unchanged != 1 {
+		t.Errorf("expected the two Beta notes exported and Alpha unchanged, got %+v", report)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Notes", "Beta-2.md")); err != nil {
+		t.Errorf("expected the new note not to overwrite the earlier Beta: %v", err)
+	}
+
+	manifest, err := LoadExportManifest(dir)
+	if err != nil || len(manifest.Notes) != 3 || manifest.LastExport == nil || !manifest.LastExport.Equal(first.Add(time.Hour)) {
+		t.Errorf("expected all three notes and the last export time in the manifest, got %+v, %v", manifest, err)
+	}
+
+	// A fixed time after every modification exports nothing
+	later := first.Add(24 * time.Hour)
+	report, err = ExportAllNotes(ctx, notes, dir, ExportAllOptions{Since: &later}, later)
+	if err != nil || len(report.Exported) != 0 || report.Unchanged != 3 {
+		t.Errorf("expected nothing to export, got %+v, %v", report, err)
+	}
+}
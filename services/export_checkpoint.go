@@ -0,0 +1,94 @@
+// ABOUTME: Checkpoint manifest written alongside a batch export so an interrupted run can resume
+// ABOUTME: Records each exported note's ID, modification date, and file, saved atomically as the export runs
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ExportManifestName is the checkpoint manifest file ExportAllNotes keeps in the export directory
+const ExportManifestName = ".notes-mcp-export.json"
+
+// exportManifestVersion is the manifest format written by this version
+const exportManifestVersion = 1
+
+// exportCheckpointInterval is how many notes are exported between checkpoint saves
+const exportCheckpointInterval = 25
+
+// ExportManifestEntry records one exported note
+type ExportManifestEntry struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	File     string    `json:"file"` // relative to the export directory, with "/" separators
+	Modified time.Time `json:"modified"`
+}
+
+// ExportManifest is the checkpoint of a batch export
+// Complete is false until every selected note has been written or skipped. LastExport is the
+// start of the most recent complete run, carried over by runs that don't finish.
+type ExportManifest struct {
+	Version    int                   `json:"version"`
+	Started    time.Time             `json:"started"`
+	Complete   bool                  `json:"complete"`
+	LastExport *time.Time            `json:"last_export,omitempty"`
+	Notes      []ExportManifestEntry `json:"notes"`
+}
+
+// LoadExportManifest reads the checkpoint manifest in dir; a missing manifest returns nil
+func LoadExportManifest(dir string) (*ExportManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ExportManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export manifest: %w", err)
+	}
+
+	var manifest ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to read export manifest in %s: %w", dir, err)
+	}
+	if manifest.Version > exportManifestVersion {
+		return nil, fmt.Errorf("%w: export manifest version %d is newer than this notes-mcp supports", ErrInvalidInput, manifest.Version)
+	}
+	return &manifest, nil
+}
+
+// saveExportManifest writes the manifest to dir through a temporary file and rename,
+// so a crash mid-write leaves the previous checkpoint intact
+func saveExportManifest(dir string, manifest *ExportManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode export manifest: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ExportManifestName+".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to save export manifest: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // removal after a successful rename is a no-op
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // already failing
+		return fmt.Errorf("failed to save export manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save export manifest: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, ExportManifestName)); err != nil {
+		return fmt.Errorf("failed to save export manifest: %w", err)
+	}
+	return nil
+}
+
+// sameModification reports whether two modification dates match to the second,
+// the precision AppleScript dates survive with
+func sameModification(a, b time.Time) bool {
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
@@ -0,0 +1,104 @@
+// ABOUTME: File name sanitization for exports derived from note titles and attachment names
+// ABOUTME: Drops emoji and control characters, replaces path separators, and suffixes collisions -2, -3
+
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// defaultFileName is used when nothing of a title survives sanitization
+const defaultFileName = "note"
+
+// maxFileNameBytes keeps sanitized names, plus a collision suffix and extension, under the
+// 255-byte limit APFS and most other filesystems place on a path component
+const maxFileNameBytes = 200
+
+// reservedFileNameChars are replaced with '-' because they separate paths or trip other filesystems
+const reservedFileNameChars = `/\:*?"<>|`
+
+// SanitizeFileName turns a note title into a name that is safe to write on any filesystem
+// The title is normalized to NFC; emoji, variation selectors, and control characters are dropped;
+// path separators and other reserved characters become '-'; and runs of spaces or dashes collapse.
+// Leading and trailing dots, dashes, and spaces are trimmed so the result is never hidden or
+// relative, and a title with nothing left becomes "note".
+func SanitizeFileName(title string) string {
+	var b strings.Builder
+	var last rune
+	for _, r := range norm.NFC.String(title) {
+		switch {
+		case strings.ContainsRune(reservedFileNameChars, r):
+			r = '-'
+		case unicode.IsSpace(r):
+			r = ' '
+		case unicode.In(r, unicode.So, unicode.Sk, unicode.Me, unicode.Cc, unicode.Cf, unicode.Variation_Selector):
+			// Emoji, skin tones, keycaps, joiners, and variation selectors
+			continue
+		}
+		if (r == ' ' || r == '-') && r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+
+	name := strings.Trim(b.String(), " .-")
+	if len(name) > maxFileNameBytes {
+		cut := maxFileNameBytes
+		for cut > 0 && !utf8.RuneStart(name[cut]) {
+			cut--
+		}
+		name = strings.TrimRight(name[:cut], " .-")
+	}
+	if name == "" {
+		return defaultFileName
+	}
+	return name
+}
+
+// FileNamer hands out sanitized file names that are unique within one export
+// Names are compared case-insensitively, as the default macOS filesystem does, and a name that
+// was already handed out gets a -2, -3, ... suffix before its extension. A FileNamer is not safe
+// for concurrent use.
+type FileNamer struct {
+	taken map[string]bool
+}
+
+// NewFileNamer creates a FileNamer with no names taken
+func NewFileNamer() *FileNamer {
+	return &FileNamer{taken: make(map[string]bool)}
+}
+
+// Name returns SanitizeFileName(title) plus ext, suffixed when that name is already taken
+// ext includes its leading dot and may be empty.
+func (n *FileNamer) Name(title, ext string) string {
+	base := SanitizeFileName(title)
+	name := base + ext
+	for i := 2; n.taken[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	n.taken[strings.ToLower(name)] = true
+	return name
+}
+
+// FileName returns a unique, sanitized version of an existing file name, keeping its extension
+// Only the base name of path is used, so attachment names can't escape the export directory.
+func (n *FileNamer) FileName(path string) string {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	if "."+SanitizeFileName(ext) != ext {
+		ext = ""
+	}
+	return n.Name(strings.TrimSuffix(base, ext), ext)
+}
+
+// Reserve marks a name as taken, such as a file kept from an earlier run
+func (n *FileNamer) Reserve(name string) {
+	n.taken[strings.ToLower(name)] = true
+}
@@ -0,0 +1,74 @@
+// ABOUTME: Unit tests for export file name sanitization
+// ABOUTME: Covers emoji, reserved characters, length limits, and collision suffixes
+
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Design Doc", "Design Doc"},
+		{"Q1/Q2: Plans?", "Q1-Q2- Plans"},
+		{"🚀 Launch Plan", "Launch Plan"},
+		{"Family 👨‍👩‍👧 Trip ❤️", "Family Trip"},
+		{"Café notes", "Café notes"}, // decomposed é is composed, not dropped
+		{"../secrets", "secrets"},
+		{".hidden", "hidden"},
+		{"a\tb\nc", "a b c"},
+		{"a // b", "a - b"},
+		{"🎉", "note"},
+		{"   ", "note"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeFileName(tt.title); got != tt.want {
+			t.Errorf("SanitizeFileName(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+
+	long := SanitizeFileName(strings.Repeat("é", 300))
+	if len(long) > maxFileNameBytes || !strings.HasPrefix(long, "éé") || strings.ContainsRune(long, '�') {
+		t.Errorf("long title not cut on a rune boundary under %d bytes: %d bytes", maxFileNameBytes, len(long))
+	}
+}
+
+func TestFileNamer(t *testing.T) {
+	namer := NewFileNamer()
+	got := []string{
+		namer.Name("Plan", ".md"),
+		namer.Name("plan", ".md"),
+		namer.Name("Plan?", ".md"),
+		namer.Name("Plan", ".pdf"),
+		namer.Name("🎉", ".md"),
+	}
+	want := []string{"Plan.md", "plan-2.md", "Plan-3.md", "Plan.pdf", "note.md"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("name %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFileNamerFileName(t *testing.T) {
+	namer := NewFileNamer()
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/tmp/x/photo.png", "photo.png"},
+		{"photo.png", "photo-2.png"},
+		{"Screen: shot.jpeg", "Screen- shot.jpeg"},
+		{"archive.tar.gz", "archive.tar.gz"},
+		{"README", "README"},
+	}
+	for _, tt := range tests {
+		if got := namer.FileName(tt.path); got != tt.want {
+			t.Errorf("FileName(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// ABOUTME: Ordering, totals, and flattening for the folder hierarchy
+// ABOUTME: Makes hierarchy output independent of AppleScript iteration order
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Folder hierarchy sort orders
+const (
+	FolderSortNone  = ""      // store order, as AppleScript returns it
+	FolderSortName  = "name"  // alphabetical, case-insensitive
+	FolderSortNotes = "notes" // most notes first, counting descendants, then by name
+)
+
+// FolderPath is one folder in a flattened hierarchy
+type FolderPath struct {
+	ID             string     `json:"id,omitempty"`
+	Path           string     `json:"path"`
+	Depth          int        `json:"depth"`
+	Shared         bool       `json:"shared"`
+	NoteCount      int        `json:"note_count"`
+	TotalNoteCount int        `json:"total_note_count"`
+	LastModified   *time.Time `json:"last_modified,omitempty"`
+}
+
+// ValidateFolderSort checks a folder hierarchy sort order
+func ValidateFolderSort(by string) error {
+	switch by {
+	case FolderSortNone, FolderSortName, FolderSortNotes:
+		return nil
+	default:
+		return fmt.Errorf("%w: sort must be '%s' or '%s'", ErrInvalidInput, FolderSortName, FolderSortNotes)
+	}
+}
+
+// setFolderAccount records the account every folder in the hierarchy belongs to
+func setFolderAccount(node *FolderNode, account string) {
+	node.Account = account
+	for i := range node.Children {
+		setFolderAccount(&node.Children[i], account)
+	}
+}
+
+// countTotalNotes sets TotalNoteCount on node and its descendants and returns node's total
+func countTotalNotes(node *FolderNode) int {
+	total := node.NoteCount
+	for i := range node.Children {
+		total += countTotalNotes(&node.Children[i])
+	}
+	node.TotalNoteCount = total
+	return total
+}
+
+// SortFolderTree orders every level of the hierarchy in place
+// Sorting by notes uses TotalNoteCount, so a folder whose notes live in subfolders still ranks high.
+func SortFolderTree(node *FolderNode, by string) {
+	if by == FolderSortNone {
+		return
+	}
+
+	children := node.Children
+	sort.SliceStable(children, func(i, j int) bool {
+		if by == FolderSortNotes && children[i].TotalNoteCount != children[j].TotalNoteCount {
+			return children[i].TotalNoteCount > children[j].TotalNoteCount
+		}
+		return strings.ToLower(children[i].Name) < strings.ToLower(children[j].Name)
+	})
+
+	for i := range children {
+		SortFolderTree(&children[i], by)
+	}
+}
+
+// FlattenFolderTree lists every folder below the root as a "/"-joined path, parents before children
+// The root (the account) is not included, so top-level folders have depth 0.
+func FlattenFolderTree(root *FolderNode) []FolderPath {
+	paths := []FolderPath{}
+
+	var walk func(node *FolderNode, prefix string, depth int)
+	walk = func(node *FolderNode, prefix string, depth int) {
+		for i := range node.Children {
+			child := &node.Children[i]
+			path := child.Name
+			if prefix != "" {
+				path = prefix + "/" + child.Name
+			}
+
+			paths = append(paths, FolderPath{
+				ID:             child.ID,
+				Path:           path,
+				Depth:          depth,
+				Shared:         child.Shared,
+				NoteCount:      child.NoteCount,
+				TotalNoteCount: child.TotalNoteCount,
+				LastModified:   child.LastModified,
+			})
+			walk(child, path, depth+1)
+		}
+	}
+	walk(root, "", 0)
+
+	return paths
+}
+
+// FolderSubtree returns a folder and every folder below it, parents before children
+// folder is a folder name or a "/"-joined path, matched case-insensitively; when a name appears
+// more than once, the first folder in the hierarchy wins. It reports false when nothing matches.
+func FolderSubtree(root *FolderNode, folder string) ([]FolderPath, bool) {
+	paths := FlattenFolderTree(root)
+	target := strings.ToLower(strings.Trim(folder, "/"))
+
+	start := -1
+	for i, path := range paths {
+		lower := strings.ToLower(path.Path)
+		if lower == target || lower[strings.LastIndex(lower, "/")+1:] == target {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return []FolderPath{}, false
+	}
+
+	subtree := []FolderPath{paths[start]}
+	prefix := paths[start].Path + "/"
+	for _, path := range paths[start+1:] {
+		if strings.HasPrefix(path.Path, prefix) {
+			subtree = append(subtree, path)
+		}
+	}
+	return subtree, true
+}
@@ -0,0 +1,118 @@
+// ABOUTME: Unit tests for folder hierarchy ordering and flattening
+// ABOUTME: Tests cumulative note counts, name and note-count sorting, and path lists
+
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testFolderTree builds an unsorted hierarchy:
+// iCloud / zeta (1) / inner (10), Alpha (4), beta (2)
+func testFolderTree() *FolderNode {
+	root := &FolderNode{Name: "iCloud", Children: []FolderNode{
+		{Name: "zeta", NoteCount: 1, Children: []FolderNode{{Name: "inner", NoteCount: 10}}},
+		{Name: "beta", NoteCount: 2},
+		{Name: "Alpha", NoteCount: 4, Shared: true},
+	}}
+	countTotalNotes(root)
+	return root
+}
+
+// folderNames returns the names of a node's children in order
+func folderNames(node *FolderNode) []string {
+	names := []string{}
+	for _, child := range node.Children {
+		names = append(names, child.Name)
+	}
+	return names
+}
+
+// TestCountTotalNotes tests that totals include every descendant
+func TestCountTotalNotes(t *testing.T) {
+	root := testFolderTree()
+
+	if root.TotalNoteCount != 17 {
+		t.Errorf("root total = %d, want 17", root.TotalNoteCount)
+	}
+	if zeta := root.Children[0]; zeta.TotalNoteCount != 11 || zeta.NoteCount != 1 {
+		t.Errorf("zeta counts = %d/%d, want 1/11", zeta.NoteCount, zeta.TotalNoteCount)
+	}
+}
+
+// TestSortFolderTree tests name and note-count ordering
+func TestSortFolderTree(t *testing.T) {
+	byName := testFolderTree()
+	SortFolderTree(byName, FolderSortName)
+	if got := folderNames(byName); !reflect.DeepEqual(got, []string{"Alpha", "beta", "zeta"}) {
+		t.Errorf("sorted by name = %v", got)
+	}
+
+	byNotes := testFolderTree()
+	SortFolderTree(byNotes, FolderSortNotes)
+	if got := folderNames(byNotes); !reflect.DeepEqual(got, []string{"zeta", "Alpha", "beta"}) {
+		t.Errorf("sorted by notes = %v", got)
+	}
+
+	unsorted := testFolderTree()
+	SortFolderTree(unsorted, FolderSortNone)
+	if got := folderNames(unsorted); !reflect.DeepEqual(got, []string{"zeta", "beta", "Alpha"}) {
+		t.Errorf("store order changed: %v", got)
+	}
+}
+
+// TestFlattenFolderTree tests path lists with depths and counts
+func TestFlattenFolderTree(t *testing.T) {
+	root := testFolderTree()
+	SortFolderTree(root, FolderSortName)
+
+	want := []FolderPath{
+		{Path: "Alpha", Depth: 0, Shared: true, NoteCount: 4, TotalNoteCount: 4},
+		{Path: "beta", Depth: 0, NoteCount: 2, TotalNoteCount: 2},
+		{Path: "zeta", Depth: 0, NoteCount: 1, TotalNoteCount: 11},
+		{Path: "zeta/inner", Depth: 1, NoteCount: 10, TotalNoteCount: 10},
+	}
+	if got := FlattenFolderTree(root); !reflect.DeepEqual(got, want) {
+		t.Errorf("FlattenFolderTree =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+// TestValidateFolderSort tests accepted sort orders
+func TestValidateFolderSort(t *testing.T) {
+	for _, by := range []string{FolderSortNone, FolderSortName, FolderSortNotes} {
+		if err := ValidateFolderSort(by); err != nil {
+			t.Errorf("ValidateFolderSort(%q) = %v", by, err)
+		}
+	}
+	if err := ValidateFolderSort("size"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+// TestFolderSubtree tests finding a folder by name or path with its descendants
+func TestFolderSubtree(t *testing.T) {
+	root := testFolderTree()
+
+	pathsOf := func(folders []FolderPath) []string {
+		paths := []string{}
+		for _, folder := range folders {
+			paths = append(paths, folder.Path)
+		}
+		return paths
+	}
+
+	if subtree, ok := FolderSubtree(root, "ZETA"); !ok || !reflect.DeepEqual(pathsOf(subtree), []string{"zeta", "zeta/inner"}) {
+		t.Errorf("FolderSubtree(ZETA) = %v, %v", pathsOf(subtree), ok)
+	}
+	if subtree, ok := FolderSubtree(root, "zeta/inner"); !ok || !reflect.DeepEqual(pathsOf(subtree), []string{"zeta/inner"}) {
+		t.Errorf("FolderSubtree(zeta/inner) = %v, %v", pathsOf(subtree), ok)
+	}
+	if subtree, ok := FolderSubtree(root, "inner"); !ok || subtree[0].Path != "zeta/inner" {
+		t.Errorf("FolderSubtree(inner) = %v, %v", pathsOf(subtree), ok)
+	}
+	if _, ok := FolderSubtree(root, "missing"); ok {
+		t.Error("expected no subtree for a missing folder")
+	}
+}
@@ -0,0 +1,174 @@
+// ABOUTME: Native fuzz targets for AppleScript escaping and the parsers of osascript output
+// ABOUTME: Seeded with captured script output; run with go test -fuzz=FuzzName ./services
+
+package services
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// unescapeAppleScriptString reads s as the inside of an AppleScript string literal, as osascript
+// would for the escapes escapeForAppleScript produces; ok is false if an unescaped quote ends it early
+func unescapeAppleScriptString(s string) (string, bool) {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return "", false
+		case '\\':
+			if i+1 < len(s) && (s[i+1] == '\\' || s[i+1] == '"') {
+				out.WriteByte(s[i+1])
+				i++
+				continue
+			}
+			return "", false
+		default:
+			out.WriteByte(s[i])
+		}
+	}
+	return out.String(), true
+}
+
+func FuzzEscapeForAppleScript(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"Meeting Notes",
+		`Quote "inside" title`,
+		`C:\Users\test\file.txt`,
+		`\"`,
+		`" & (do shell script "rm -rf ~") & "`,
+		"Line one\nLine two\ttabbed",
+		"Café ☕ 日本語",
+		`trailing backslash \`,
+	} {
+		f.Add(seed)
+	}
+
+	service := NewAppleNotesService(&MockExecutor{})
+	f.Fuzz(func(t *testing.T, input string) {
+		escaped := service.escapeForAppleScript(input)
+		unescaped, ok := unescapeAppleScriptString(escaped)
+		if !ok {
+			t.Fatalf("escapeForAppleScript(%q) = %q lets a quote or stray backslash end the string", input, escaped)
+		}
+		if unescaped != input {
+			t.Fatalf("escapeForAppleScript(%q) round-tripped to %q", input, unescaped)
+		}
+	})
+}
+
+func FuzzParseNoteMetadata(f *testing.F) {
+	for _, seed := range []string{
+		`{id:"x-coredata://clip", name:"Why Plain Text Lasts", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 1, 2024 at 10:00:00 AM", container:"Notes", shared:false, password protected:false}`,
+		`{id:"x-coredata://ABC/ICNote/p42", name:"Budget, Q3 {draft}", creation date:date "Tuesday, March 5, 2024 at 4:15:09 PM", modification date:date "Tuesday, March 5, 2024 at 4:15:09 PM", container:"Work", shared:true, password protected:true}`,
+		`{id:missing value, container:, creation date:date ""}`,
+		`{creation date:date "not a date", modification date:date "Monday, January 1, 2024 at 25:99:00 AM"}`,
+		``,
+	} {
+		f.Add(seed, "Title")
+	}
+
+	service := NewAppleNotesService(&MockExecutor{})
+	f.Fuzz(func(t *testing.T, output, title string) {
+		note, err := service.parseNoteMetadata(output, title)
+		if err != nil {
+			return
+		}
+		if note.Title != title || note.Tags == nil {
+			t.Fatalf("parseNoteMetadata changed the title or left tags nil: %+v", note)
+		}
+		if !note.Created.Equal(note.CreationDate) || !note.Modified.Equal(note.ModificationDate) {
+			t.Fatalf("parseNoteMetadata left the timestamp fields out of sync: %+v", note)
+		}
+	})
+}
+
+func FuzzParseAttachments(f *testing.F) {
+	for _, seed := range []string{
+		attachmentRecord("x-coredata://att1", "Scan {page 1},\n\"final\".pdf", "cid-1", "/Users/test/Library/Group Containers/Scan.pdf", "2024-01-01T10:00:00", "2024-01-15T15:30:00") +
+			attachmentRecord("x-coredata://att3", "legacy.png", "", "file:///Users/test/legacy.png", "missing value", "") + "\n",
+		"truncated" + unitSeparator + "record" + recordSeparator + "\n",
+		recordSeparator + recordSeparator + "\r\n",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, output string) {
+		attachments := parseAttachments(output)
+		if records := strings.Count(output, recordSeparator) + 1; len(attachments) > records {
+			t.Fatalf("parsed %d attachments from %d records", len(attachments), records)
+		}
+		for _, attachment := range attachments {
+			for _, field := range []string{attachment.ID, attachment.Name, attachment.ContentIdentifier, attachment.FilePath} {
+				if strings.Contains(field, unitSeparator) || strings.Contains(field, recordSeparator) {
+					t.Fatalf("separator leaked into attachment %+v", attachment)
+				}
+			}
+		}
+	})
+}
+
+// maxParsedSearchResults is the cap parseSearchResults applies to one script's output
+const maxParsedSearchResults = 100
+
+func FuzzParseSearchResults(f *testing.F) {
+	for _, seed := range []string{
+		"Meeting Notes|||Project Ideas|||Random Thoughts",
+		"Notes|||Work|||Personal\n",
+		"Title, with commas|||  padded  ||||||",
+		"||||",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	service := NewAppleNotesService(&MockExecutor{})
+	f.Fuzz(func(t *testing.T, stdout string) {
+		notes := service.parseSearchResults(stdout)
+		if notes == nil || len(notes) > maxParsedSearchResults {
+			t.Fatalf("parseSearchResults returned %d notes (nil=%v)", len(notes), notes == nil)
+		}
+		for _, note := range notes {
+			if note.Title == "" || note.Title != strings.TrimSpace(note.Title) || strings.Contains(note.Title, "|||") {
+				t.Fatalf("parseSearchResults returned a bad title %q", note.Title)
+			}
+		}
+	})
+}
+
+// fuzzTagPattern matches a complete HTML tag left in converted markdown
+var fuzzTagPattern = regexp.MustCompile(`<[^>]+>`)
+
+func FuzzConvertHTMLToMarkdown(f *testing.F) {
+	for _, seed := range []string{
+		`<div><h1>Trip Plan</h1></div><div><b>Day 1</b>: arrive</div><div><br></div><ul><li>Pack <i>light</i></li><li><a href="https://example.com/map">map</a></li></ul>`,
+		`<div>Line one</div><div>Line two</div><div><br></div><div><br></div><div><br></div><div>After gap</div>`,
+		`<p>Para with <strong>bold</strong> and <em>emphasis</em></p><img src="data:image/png;base64,AAAA" alt="sketch">`,
+		`<a href="x">unclosed <b>tags <<>> & &amp; </div`,
+		"<h2>\xff\xfe invalid utf-8</h2>",
+		``,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, html string) {
+		markdown := convertHTMLToMarkdown(html)
+		if markdown != strings.TrimSpace(markdown) {
+			t.Fatalf("convertHTMLToMarkdown(%q) left surrounding whitespace: %q", html, markdown)
+		}
+		if strings.Contains(markdown, "\n\n\n") {
+			t.Fatalf("convertHTMLToMarkdown(%q) left a run of blank lines: %q", html, markdown)
+		}
+		// Entities decode to literal text, so "&lt;b&gt;" rightly becomes "<b>"
+		if tag := fuzzTagPattern.FindString(markdown); tag != "" && !strings.Contains(html, "&") {
+			t.Fatalf("convertHTMLToMarkdown(%q) left the tag %q", html, tag)
+		}
+		if utf8.ValidString(html) && !utf8.ValidString(markdown) {
+			t.Fatalf("convertHTMLToMarkdown(%q) produced invalid UTF-8", html)
+		}
+	})
+}
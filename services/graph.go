@@ -0,0 +1,223 @@
+// ABOUTME: Note graph of internal note links and shared folders, for visualizing how notes interconnect
+// ABOUTME: Builds nodes and edges from note bodies and renders them as Graphviz DOT or JSON
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MaxGraphNotes caps how many notes one graph export reads
+const MaxGraphNotes = 500
+
+// Graph node kinds
+const (
+	GraphNodeNote   = "note"
+	GraphNodeFolder = "folder"
+)
+
+// Graph edge kinds
+const (
+	GraphEdgeLink   = "link"   // a note links to another note
+	GraphEdgeFolder = "folder" // a note is in a folder; notes sharing a folder share its node
+)
+
+// noteGraphLinkPattern matches internal note links, capturing the href and the link text
+var noteGraphLinkPattern = regexp.MustCompile(`(?is)<a[^>]*href="((?:applenotes:|notes://)[^"]*)"[^>]*>(.*?)</a>`)
+
+// GraphOptions selects the notes BuildNoteGraph includes
+type GraphOptions struct {
+	Folder string // only notes in this folder; empty means every folder
+}
+
+// GraphNode is a note or folder in the graph; notes carry their link and backlink counts
+type GraphNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Label     string `json:"label"`
+	Folder    string `json:"folder,omitempty"`
+	Links     int    `json:"links,omitempty"`
+	Backlinks int    `json:"backlinks,omitempty"`
+}
+
+// GraphEdge connects two nodes; link edges point from the linking note to the linked one
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// NoteGraph is the graph of notes, their folders, and the links between them
+type NoteGraph struct {
+	Nodes           []GraphNode `json:"nodes"`
+	Edges           []GraphEdge `json:"edges"`
+	NotesScanned    int         `json:"notes_scanned"`
+	UnresolvedLinks int         `json:"unresolved_links"`
+	Truncated       bool        `json:"truncated,omitempty"`
+}
+
+// BuildNoteGraph reads every note's body and links notes to the notes they link to and the folders they're in
+// Links resolve by the note identifier in their URL, then by their text as a title; locked notes are left out.
+func BuildNoteGraph(ctx context.Context, notes NoteReader, opts GraphOptions) (*NoteGraph, error) {
+	library, err := notes.ListNotesWithMetadata(ctx, opts.Folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build note graph: %w", err)
+	}
+	graph := &NoteGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	readable := []Note{}
+	for _, note := range library {
+		if !note.PasswordProtected {
+			readable = append(readable, note)
+		}
+	}
+	if len(readable) > MaxGraphNotes {
+		readable = readable[:MaxGraphNotes]
+		graph.Truncated = true
+	}
+
+	bodies := make([]string, len(readable))
+	err = forEachBounded(ctx, len(readable), DefaultConcurrency, func(ctx context.Context, i int) error {
+		body, err := notes.GetNoteContent(ctx, readable[i].Title)
+		bodies[i] = body
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build note graph: %w", err)
+	}
+	graph.NotesScanned = len(readable)
+
+	resolver := newGraphLinkResolver(readable)
+	nodeIndex := map[string]int{}
+	for _, note := range readable {
+		nodeIndex[graphNoteID(note)] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, GraphNode{ID: graphNoteID(note), Kind: GraphNodeNote, Label: note.Title, Folder: note.Folder})
+	}
+
+	edges := map[GraphEdge]bool{}
+	for i, note := range readable {
+		from := graphNoteID(note)
+		for _, match := range noteGraphLinkPattern.FindAllStringSubmatch(bodies[i], -1) {
+			target, ok := resolver.resolve(html.UnescapeString(match[1]), stripHTML(match[2]))
+			if !ok {
+				graph.UnresolvedLinks++
+				continue
+			}
+			edge := GraphEdge{From: from, To: target, Kind: GraphEdgeLink}
+			if target == from || edges[edge] {
+				continue
+			}
+			edges[edge] = true
+			graph.Edges = append(graph.Edges, edge)
+			graph.Nodes[nodeIndex[from]].Links++
+			graph.Nodes[nodeIndex[target]].Backlinks++
+		}
+	}
+
+	for _, note := range readable {
+		if note.Folder == "" {
+			continue
+		}
+		folderID := "folder:" + note.Folder
+		if _, ok := nodeIndex[folderID]; !ok {
+			nodeIndex[folderID] = len(graph.Nodes)
+			graph.Nodes = append(graph.Nodes, GraphNode{ID: folderID, Kind: GraphNodeFolder, Label: note.Folder})
+		}
+		graph.Edges = append(graph.Edges, GraphEdge{From: graphNoteID(note), To: folderID, Kind: GraphEdgeFolder})
+	}
+	return graph, nil
+}
+
+// graphNoteID identifies a note's node by its ID, or by its folder and title when it has none
+func graphNoteID(note Note) string {
+	if note.ID != "" {
+		return note.ID
+	}
+	return "note:" + note.Folder + "/" + note.Title
+}
+
+// graphLinkResolver finds the note an internal link points at
+type graphLinkResolver struct {
+	byID    map[string]string
+	byTitle map[string]string
+	titles  []string
+}
+
+// newGraphLinkResolver indexes notes by ID and by title; the first of several same-titled notes wins
+func newGraphLinkResolver(notes []Note) *graphLinkResolver {
+	r := &graphLinkResolver{byID: map[string]string{}, byTitle: map[string]string{}}
+	for _, note := range notes {
+		if note.ID != "" {
+			r.byID[note.ID] = graphNoteID(note)
+		}
+		key := strings.ToLower(normalizeTitle(note.Title))
+		if _, ok := r.byTitle[key]; !ok {
+			r.byTitle[key] = graphNoteID(note)
+			r.titles = append(r.titles, note.Title)
+		}
+	}
+	return r
+}
+
+// resolve returns the node ID of the note a link with this href and text points at
+func (r *graphLinkResolver) resolve(href, text string) (string, bool) {
+	if id, ok := r.byID[linkIdentifier(href)]; ok {
+		return id, true
+	}
+	if id, ok := r.byTitle[strings.ToLower(normalizeTitle(text))]; ok {
+		return id, true
+	}
+	if title, ok := matchTitleTolerantly(text, r.titles); ok {
+		return r.byTitle[strings.ToLower(normalizeTitle(title))], true
+	}
+	return "", false
+}
+
+// linkIdentifier returns the note identifier in a notes:// link's query or an applenotes: link's path
+func linkIdentifier(href string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if id := u.Query().Get("identifier"); id != "" {
+		return id
+	}
+	path := u.Opaque
+	if path == "" {
+		path = u.Path
+	}
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// DOT renders the graph in Graphviz DOT: notes are boxes, folders are folder shapes joined by dashed lines
+func (g *NoteGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph notes {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, node := range g.Nodes {
+		shape := "box"
+		if node.Kind == GraphNodeFolder {
+			shape = "folder"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(node.ID), dotQuote(node.Label), shape)
+	}
+	for _, edge := range g.Edges {
+		attrs := ""
+		if edge.Kind == GraphEdgeFolder {
+			attrs = " [style=dashed, arrowhead=none]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", dotQuote(edge.From), dotQuote(edge.To), attrs)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote quotes s as a DOT string, escaping quotes, backslashes, and line breaks
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
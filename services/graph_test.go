@@ -0,0 +1,97 @@
+// ABOUTME: Unit tests for the note graph export
+// ABOUTME: Tests link resolution by identifier and title, backlink counts, folder nodes, and DOT rendering
+
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBuildNoteGraph(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	if err := service.CreateFolder(ctx, "Work", ""); err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+	hub, err := service.CreateNote(ctx, "Project Hub", `<div>See <a href="applenotes:note/abc">Meeting "Notes"</a></div>`, nil)
+	if err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	for title, body := range map[string]string{
+		`Meeting "Notes"`: `<div>Back to <a href="notes://showNote?identifier=` + hub.ID + `">the hub</a></div>`,
+		"Ideas":           `<div><a href="applenotes:note/x">project hub!</a> <a href="applenotes:note/x">Project Hub</a> <a href="applenotes:note/y">Gone</a></div>`,
+	} {
+		if _, err := service.CreateNote(ctx, title, body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+	if err := service.MoveNote(ctx, "Ideas", "Work"); err != nil {
+		t.Fatalf("MoveNote failed: %v", err)
+	}
+
+	graph, err := BuildNoteGraph(ctx, service, GraphOptions{})
+	if err != nil {
+		t.Fatalf("BuildNoteGraph failed: %v", err)
+	}
+	if graph.NotesScanned != 3 || graph.UnresolvedLinks != 1 {
+		t.Errorf("unexpected counts %+v", graph)
+	}
+
+	nodes := map[string]GraphNode{}
+	for _, node := range graph.Nodes {
+		nodes[node.Label] = node
+	}
+	if node := nodes["Project Hub"]; node.Links != 1 || node.Backlinks != 2 {
+		t.Errorf("expected the hub to link once and be linked twice, got %+v", node)
+	}
+	if node := nodes["Ideas"]; node.Links != 1 || node.Folder != "Work" {
+		t.Errorf("expected Ideas' two links to the hub to count once, got %+v", node)
+	}
+	if nodes["Work"].Kind != GraphNodeFolder || nodes["Notes"].Kind != GraphNodeFolder {
+		t.Errorf("expected a node per folder, got %+v", graph.Nodes)
+	}
+
+	links, folders := 0, 0
+	for _, edge := range graph.Edges {
+		switch edge.Kind {
+		case GraphEdgeLink:
+			links++
+		case GraphEdgeFolder:
+			folders++
+		}
+	}
+	if links != 3 || folders != 3 {
+		t.Errorf("expected 3 link and 3 folder edges, got %+v", graph.Edges)
+	}
+
+	dot := graph.DOT()
+	for _, want := range []string{"digraph notes {", `[label="Meeting \"Notes\"", shape=box]`, `shape=folder`, `-> "folder:Work" [style=dashed, arrowhead=none]`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("expected DOT to contain %q, got:\n%s", want, dot)
+		}
+	}
+
+	scoped, err := BuildNoteGraph(ctx, service, GraphOptions{Folder: "Work"})
+	if err != nil {
+		t.Fatalf("BuildNoteGraph failed: %v", err)
+	}
+	if scoped.NotesScanned != 1 || scoped.UnresolvedLinks != 3 {
+		t.Errorf("expected links out of the folder to be unresolved, got %+v", scoped)
+	}
+}
+
+func TestLinkIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"applenotes:note/1A2B-3C?ownerIdentifier=xyz":           "1A2B-3C",
+		"notes://showNote?identifier=x-coredata://S/ICNote/p12": "x-coredata://S/ICNote/p12",
+		"notes://":    "",
+		"notes://%zz": "",
+	}
+	for href, want := range tests {
+		if got := linkIdentifier(href); got != want {
+			t.Errorf("linkIdentifier(%q) = %q, want %q", href, got, want)
+		}
+	}
+}
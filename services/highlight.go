@@ -0,0 +1,50 @@
+// ABOUTME: Search match highlighting for retrieved note content
+// ABOUTME: Wraps case-insensitive query matches in **bold** for text/markdown or <mark> for HTML bodies
+
+package services
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// HighlightMarkdown wraps every case-insensitive match of query in text with **bold** markers
+// An empty query returns text unchanged.
+func HighlightMarkdown(text, query string) string {
+	pattern := highlightPattern(query)
+	if pattern == nil {
+		return text
+	}
+	return pattern.ReplaceAllString(text, "**${0}**")
+}
+
+// HighlightHTML wraps every case-insensitive match of query in an HTML body with <mark> elements
+// Only text between tags is highlighted, so tag names and attributes are never rewritten. The
+// query is matched in its HTML-escaped form because that is how the body stores "&" and "<".
+func HighlightHTML(body, query string) string {
+	pattern := highlightPattern(html.EscapeString(strings.TrimSpace(query)))
+	if pattern == nil {
+		return body
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, tag := range htmlTagPattern.FindAllStringIndex(body, -1) {
+		b.WriteString(pattern.ReplaceAllString(body[last:tag[0]], "<mark>${0}</mark>"))
+		b.WriteString(body[tag[0]:tag[1]])
+		last = tag[1]
+	}
+	b.WriteString(pattern.ReplaceAllString(body[last:], "<mark>${0}</mark>"))
+
+	return b.String()
+}
+
+// highlightPattern compiles a case-insensitive literal pattern for query, or nil when it is blank
+func highlightPattern(query string) *regexp.Regexp {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)` + regexp.QuoteMeta(query))
+}
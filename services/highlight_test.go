@@ -0,0 +1,72 @@
+// ABOUTME: Unit tests for search match highlighting
+// ABOUTME: Tests markdown bolding and HTML <mark> wrapping that leaves tags untouched
+
+package services
+
+import "testing"
+
+// TestHighlightMarkdown tests case-insensitive bolding of matches
+func TestHighlightMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		want  string
+	}{
+		{name: "preserves case", text: "Roadmap review: the roadmap is late", query: "ROADMAP", want: "**Roadmap** review: the **roadmap** is late"},
+		{name: "regex characters are literal", text: "cost (est.) $5", query: "(est.)", want: "cost **(est.)** $5"},
+		{name: "blank query", text: "unchanged", query: "  ", want: "unchanged"},
+		{name: "no match", text: "unchanged", query: "missing", want: "unchanged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightMarkdown(tt.text, tt.query); got != tt.want {
+				t.Errorf("HighlightMarkdown = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHighlightHTML tests that only text between tags is wrapped in <mark>
+func TestHighlightHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		query string
+		want  string
+	}{
+		{
+			name:  "text only",
+			body:  `<div class="div">A div in a div</div>`,
+			query: "div",
+			want:  `<div class="div">A <mark>div</mark> in a <mark>div</mark></div>`,
+		},
+		{
+			name:  "escaped query",
+			body:  `<div>R&amp;D budget</div>`,
+			query: "r&d",
+			want:  `<div><mark>R&amp;D</mark> budget</div>`,
+		},
+		{
+			name:  "text outside tags",
+			body:  `Plan<br>plan`,
+			query: "plan",
+			want:  `<mark>Plan</mark><br><mark>plan</mark>`,
+		},
+		{
+			name:  "blank query",
+			body:  `<div>unchanged</div>`,
+			query: "",
+			want:  `<div>unchanged</div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightHTML(tt.body, tt.query); got != tt.want {
+				t.Errorf("HighlightHTML = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// ABOUTME: Version history of note bodies, recorded just before a note is updated or deleted
+// ABOUTME: Kept in a line-oriented local store, optionally encrypted, so earlier versions can be listed and recovered
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHistoryLimit is how many versions a NoteHistory keeps when no limit is given
+const DefaultHistoryLimit = 1000
+
+// NoteVersion is a note's body as it was just before an operation replaced or deleted it
+type NoteVersion struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	NoteID    string    `json:"note_id,omitempty"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+}
+
+// NoteHistory keeps the most recent note versions in a line-oriented file, one JSON record per line
+// When encryptor is set, each line is sealed before it is written.
+type NoteHistory struct {
+	mu        sync.Mutex
+	path      string
+	encryptor *StoreEncryptor
+	limit     int
+	lines     int // versions in the file, or -1 until counted
+}
+
+// NewNoteHistory creates a NoteHistory at path keeping about limit versions.
+// If limit is 0 or negative, defaults to DefaultHistoryLimit.
+func NewNoteHistory(path string, encryptor *StoreEncryptor, limit int) *NoteHistory {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &NoteHistory{path: path, encryptor: encryptor, limit: limit, lines: -1}
+}
+
+// Append adds a version, trimming the file to the newest versions once it grows to twice the limit
+func (h *NoteHistory) Append(version NoteVersion) error {
+	line, err := encodeStoreLine(version, h.encryptor)
+	if err != nil {
+		return fmt.Errorf("failed to record note version: %w", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.lines < 0 {
+		existing, err := readStoreLines(h.path)
+		if err != nil {
+			return fmt.Errorf("failed to read note history: %w", err)
+		}
+		h.lines = len(existing)
+	}
+	if err := appendStoreLine(h.path, line); err != nil {
+		return fmt.Errorf("failed to write note history: %w", err)
+	}
+	h.lines++
+
+	if h.lines < 2*h.limit {
+		return nil
+	}
+	lines, err := readStoreLines(h.path)
+	if err != nil {
+		return fmt.Errorf("failed to trim note history: %w", err)
+	}
+	if len(lines) > h.limit {
+		lines = lines[len(lines)-h.limit:]
+	}
+	if err := replaceStoreLines(h.path, lines); err != nil {
+		return fmt.Errorf("failed to trim note history: %w", err)
+	}
+	h.lines = len(lines)
+	return nil
+}
+
+// Versions returns the recorded versions of the note with the given ID or title (case-insensitive),
+// oldest first; an empty note returns every version
+func (h *NoteHistory) Versions(note string) ([]NoteVersion, error) {
+	h.mu.Lock()
+	lines, err := readStoreLines(h.path)
+	h.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note history: %w", err)
+	}
+
+	versions := []NoteVersion{}
+	for _, line := range lines {
+		var version NoteVersion
+		if err := decodeStoreLine(h.path, line, h.encryptor, &version); err != nil {
+			return nil, fmt.Errorf("failed to read note history: %w", err)
+		}
+		if note == "" || version.NoteID == note || strings.EqualFold(version.Title, note) {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// HistoryMiddleware records a note's current body in history before an update or delete replaces it
+// The version is recorded only once the operation succeeds; failing to read or record it is logged
+// and never blocks the operation.
+func HistoryMiddleware(history *NoteHistory, reader NoteReader, now func() time.Time) ServiceMiddleware {
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			version, ok := previousVersion(ctx, reader, call)
+			result, err := next(ctx, call)
+			if ok && err == nil {
+				version.Time = now()
+				if err := history.Append(version); err != nil {
+					log.Printf("Failed to record note history: %v", err)
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+// previousVersion reads the body an update or delete is about to replace; other calls report false
+func previousVersion(ctx context.Context, reader NoteReader, call *ServiceCall) (NoteVersion, bool) {
+	if len(call.Args) == 0 {
+		return NoteVersion{}, false
+	}
+	key, _ := call.Args[0].(string)
+	version := NoteVersion{Operation: call.Operation}
+
+	var err error
+	switch call.Operation {
+	case "UpdateNote", "UpdateNoteIfUnchanged", "DeleteNote":
+		version.Title = key
+		version.Body, err = reader.GetNoteContent(ctx, key)
+	case "UpdateNoteByID", "DeleteNoteByID":
+		version.NoteID = key
+		if version.Title, err = reader.GetNoteTitleByID(ctx, key); err == nil {
+			version.Body, err = reader.GetNoteContentByID(ctx, key)
+		}
+	default:
+		return NoteVersion{}, false
+	}
+	if err != nil {
+		tracef(ctx, "%s: not recording the previous version: %v", call.Operation, err)
+		return NoteVersion{}, false
+	}
+	return version, true
+}
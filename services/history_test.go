@@ -0,0 +1,83 @@
+// ABOUTME: Unit tests for note version history
+// ABOUTME: Records versions through the middleware and checks lookups, trimming, and encryption
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryMiddleware(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	base := newMiddlewareTestService(t)
+	history := NewNoteHistory(filepath.Join(t.TempDir(), "history.jsonl"), nil, 0)
+	service := DecorateNotesService(base, HistoryMiddleware(history, base, func() time.Time { return now }))
+
+	if err := service.UpdateNote(ctx, "Plan", "<div>Ship it Friday</div>"); err != nil {
+		t.Fatalf("UpdateNote failed: %v", err)
+	}
+	if err := service.UpdateNote(ctx, "Missing", "<div>x</div>"); err == nil {
+		t.Fatal("expected updating a missing note to fail")
+	}
+	note, err := base.GetNoteMetadata(ctx, "Plan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := note.ID
+	if err := service.DeleteNoteByID(ctx, id); err != nil {
+		t.Fatalf("DeleteNoteByID failed: %v", err)
+	}
+
+	versions, err := history.Versions("plan")
+	if err != nil {
+		t.Fatalf("Versions failed: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected two versions, got %+v", versions)
+	}
+	if versions[0].Operation != "UpdateNote" || versions[0].Body != "<div>Ship it</div>" || !versions[0].Time.Equal(now) {
+		t.Errorf("unexpected first version %+v", versions[0])
+	}
+	if versions[1].Operation != "DeleteNoteByID" || versions[1].NoteID != id || versions[1].Body != "<div>Ship it Friday</div>" {
+		t.Errorf("unexpected second version %+v", versions[1])
+	}
+	if byID, err := history.Versions(id); err != nil || len(byID) != 1 {
+		t.Errorf("expected one version by ID, got %+v, %v", byID, err)
+	}
+}
+
+func TestNoteHistoryTrimsAndEncrypts(t *testing.T) {
+	encryptor, err := NewStoreEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	history := NewNoteHistory(path, encryptor, 2)
+	for i := range 4 {
+		if err := history.Append(NoteVersion{Operation: "UpdateNote", Title: "Plan", Body: fmt.Sprintf("secret %d", i)}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	versions, err := history.Versions("")
+	if err != nil || len(versions) != 2 || versions[0].Body != "secret 2" {
+		t.Errorf("expected the newest two versions kept, got %+v, %v", versions, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("secret")) {
+		t.Errorf("history contains plaintext: %q", data)
+	}
+	if _, err := NewNoteHistory(path, nil, 0).Versions(""); err == nil {
+		t.Error("expected reading without the key to fail")
+	}
+}
@@ -0,0 +1,190 @@
+// ABOUTME: Structural edits of note bodies: appending content and replacing a section under a heading
+// ABOUTME: Parses the body with an HTML parser so checklists, tables, and styles keep their markup
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// bodyContext parses note bodies as the contents of a <body> element
+var bodyContext = &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body}
+
+// AppendHTML returns body with fragment added after its last top-level node
+// Both are parsed first, so an unclosed element at the end of the body is closed rather than
+// swallowing the new content, and the fragment can't close the body's elements.
+func AppendHTML(body, fragment string) (string, error) {
+	existing, err := parseBodyNodes(body)
+	if err != nil {
+		return "", err
+	}
+	added, err := parseBodyNodes(fragment)
+	if err != nil {
+		return "", err
+	}
+	return renderBodyNodes(append(existing, added...))
+}
+
+// ReplaceHTMLSection replaces the section whose heading text is heading with section, which should
+// start with its own heading, and reports whether one was found; otherwise section is appended
+// after an empty line. A section runs from a top-level heading (h1-h6, or a line that is all bold,
+// which is how Notes shows a title or heading) to the next one; empty lines just before the next
+// heading are left in place.
+func ReplaceHTMLSection(body, heading, section string) (string, bool, error) {
+	nodes, err := parseBodyNodes(body)
+	if err != nil {
+		return "", false, err
+	}
+	replacement, err := parseBodyNodes(section)
+	if err != nil {
+		return "", false, err
+	}
+
+	start := -1
+	for i, node := range nodes {
+		if text, ok := sectionHeading(node); ok && strings.EqualFold(text, strings.Join(strings.Fields(heading), " ")) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		blank, err := parseBodyNodes("<div><br></div>")
+		if err != nil {
+			return "", false, err
+		}
+		rendered, err := renderBodyNodes(append(append(nodes, blank...), replacement...))
+		return rendered, false, err
+	}
+
+	end := start + 1
+	for end < len(nodes) {
+		if _, ok := sectionHeading(nodes[end]); ok {
+			break
+		}
+		end++
+	}
+	for end > start+1 && isBlankLine(nodes[end-1]) {
+		end--
+	}
+
+	edited := append(append(append([]*nethtml.Node{}, nodes[:start]...), replacement...), nodes[end:]...)
+	rendered, err := renderBodyNodes(edited)
+	return rendered, true, err
+}
+
+// parseBodyNodes parses an HTML fragment into its top-level nodes
+func parseBodyNodes(fragment string) ([]*nethtml.Node, error) {
+	nodes, err := nethtml.ParseFragment(strings.NewReader(fragment), bodyContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse note body: %w", err)
+	}
+	return nodes, nil
+}
+
+// renderBodyNodes renders top-level nodes back into a body
+func renderBodyNodes(nodes []*nethtml.Node) (string, error) {
+	var b strings.Builder
+	for _, node := range nodes {
+		if err := nethtml.Render(&b, node); err != nil {
+			return "", fmt.Errorf("failed to render note body: %w", err)
+		}
+	}
+	return b.String(), nil
+}
+
+// sectionHeading returns the text of a node that starts a section: an h1-h6 element, or an element
+// whose only content, apart from line breaks, is a heading or bold text
+func sectionHeading(node *nethtml.Node) (string, bool) {
+	if node.Type != nethtml.ElementNode {
+		return "", false
+	}
+	switch node.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.B, atom.Strong:
+		text := nodeText(node)
+		return text, text != ""
+	case atom.Div, atom.P:
+		var only *nethtml.Node
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if isIgnorable(child) {
+				continue
+			}
+			if only != nil {
+				return "", false
+			}
+			only = child
+		}
+		if only == nil {
+			return "", false
+		}
+		return sectionHeading(only)
+	}
+	return "", false
+}
+
+// isBlankLine reports whether a node shows as an empty line, such as <div><br></div>
+func isBlankLine(node *nethtml.Node) bool {
+	if isIgnorable(node) {
+		return true
+	}
+	if node.Type != nethtml.ElementNode || (node.DataAtom != atom.Div && node.DataAtom != atom.P) {
+		return false
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if !isIgnorable(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// isIgnorable reports whether a node adds nothing visible to a line: whitespace or a <br>
+func isIgnorable(node *nethtml.Node) bool {
+	switch node.Type {
+	case nethtml.TextNode:
+		return strings.TrimSpace(node.Data) == ""
+	case nethtml.CommentNode:
+		return true
+	case nethtml.ElementNode:
+		return node.DataAtom == atom.Br
+	}
+	return false
+}
+
+// editNoteBody reads a note's body, applies edit, and writes the edited body back
+// The read and the write are separate scripts, so an edit made in between is overwritten.
+func (s *AppleNotesService) editNoteBody(ctx context.Context, title string, edit func(body string) (string, error)) error {
+	body, err := s.GetNoteContent(ctx, title)
+	if err != nil {
+		return err
+	}
+	edited, err := edit(body)
+	if err != nil {
+		return err
+	}
+	return s.setNoteBody(ctx, title, edited)
+}
+
+// setNoteBody replaces a note's body with HTML as is; unlike UpdateNote, newlines are not turned into
+// line breaks, since between elements they are only formatting
+func (s *AppleNotesService) setNoteBody(ctx context.Context, title, body string) error {
+	title = normalizeTitle(title)
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set body of note "%s" to "%s"
+			end tell
+		end tell
+	`, s.iCloudAccount, s.escapeForAppleScript(title), s.escapeForAppleScript(body))
+
+	_, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return s.withNoteSuggestions(ctx, title, detectedErr)
+	}
+	return nil
+}
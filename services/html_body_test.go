@@ -0,0 +1,94 @@
+// ABOUTME: Unit tests for structural edits of note bodies
+// ABOUTME: Covers appending after checklists and tables, unclosed markup, and replacing sections
+
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const checklistBody = `<div><h1>Groceries</h1></div>
+<ul class="checklist"><li class="checked">milk</li><li class="unchecked">eggs</li></ul>
+<table style="border: 1px"><tr><td>a</td><td>b</td></tr></table>
+<div style="color: red">keep <b>this</b></div>`
+
+func TestAppendHTMLPreservesStructure(t *testing.T) {
+	got, err := AppendHTML(checklistBody, "<div>#shopping</div>")
+	if err != nil {
+		t.Fatalf("AppendHTML failed: %v", err)
+	}
+
+	for _, kept := range []string{
+		`<ul class="checklist"><li class="checked">milk</li><li class="unchecked">eggs</li></ul>`,
+		`<td>a</td><td>b</td>`,
+		`<div style="color: red">keep <b>this</b></div>`,
+	} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("expected %q to survive, got %s", kept, got)
+		}
+	}
+	if !strings.HasSuffix(got, "</div><div>#shopping</div>") {
+		t.Errorf("expected the new line as the last sibling, got %s", got)
+	}
+}
+
+func TestAppendHTMLClosesUnclosedMarkup(t *testing.T) {
+	got, err := AppendHTML("<div>one</div><ul><li>open item", "<div>two</div>")
+	if err != nil {
+		t.Fatalf("AppendHTML failed: %v", err)
+	}
+	if want := "<div>one</div><ul><li>open item</li></ul><div>two</div>"; got != want {
+		t.Errorf("AppendHTML() = %s, want %s", got, want)
+	}
+
+	// A stray closing tag in the fragment can't close the body's elements
+	got, err = AppendHTML("<blockquote><div>quoted</div></blockquote>", "</blockquote></div><div>new</div>")
+	if err != nil {
+		t.Fatalf("AppendHTML failed: %v", err)
+	}
+	if want := "<blockquote><div>quoted</div></blockquote><div>new</div>"; got != want {
+		t.Errorf("AppendHTML() = %s, want %s", got, want)
+	}
+}
+
+func TestReplaceHTMLSection(t *testing.T) {
+	body := `<div><h1>Standup</h1></div><div><b>Transcript: memo.m4a</b></div><div>old words</div>` +
+		`<div><br></div><div><b>Decisions</b></div><ul class="checklist"><li class="unchecked">ship</li></ul>`
+	section := `<div><b>Transcript: memo.m4a</b></div><div>new words</div>`
+
+	got, replaced, err := ReplaceHTMLSection(body, "transcript:  memo.m4a", section)
+	if err != nil || !replaced {
+		t.Fatalf("expected a replacement, got %v, %v", replaced, err)
+	}
+	want := `<div><h1>Standup</h1></div><div><b>Transcript: memo.m4a</b></div><div>new words</div>` +
+		`<div><br/></div><div><b>Decisions</b></div><ul class="checklist"><li class="unchecked">ship</li></ul>`
+	if got != want {
+		t.Errorf("ReplaceHTMLSection() =\n%s\nwant\n%s", got, want)
+	}
+
+	got, replaced, err = ReplaceHTMLSection("<div><h1>Standup</h1></div>", "Transcript: memo.m4a", section)
+	if err != nil || replaced {
+		t.Fatalf("expected the section to be appended, got %v, %v", replaced, err)
+	}
+	if want := "<div><h1>Standup</h1></div><div><br/></div>" + section; got != want {
+		t.Errorf("ReplaceHTMLSection() = %s, want %s", got, want)
+	}
+}
+
+func TestEditNoteBodyWritesMarkupAsIs(t *testing.T) {
+	executor := &storedTitleExecutor{titles: []string{"Groceries"}}
+	service := NewAppleNotesService(executor)
+
+	err := service.editNoteBody(context.Background(), "Groceries", func(body string) (string, error) {
+		return body + "\n<div>two</div>", nil
+	})
+	if err != nil {
+		t.Fatalf("editNoteBody failed: %v", err)
+	}
+	written := executor.scripts[len(executor.scripts)-1]
+	if !strings.Contains(written, "<div>Groceries</div>\n<div>two</div>") || strings.Contains(written, "<br>") {
+		t.Errorf("expected the body written without added line breaks, got %s", written)
+	}
+}
@@ -0,0 +1,100 @@
+// ABOUTME: Standalone HTML export that keeps a note's own markup and styling
+// ABOUTME: Inlines images the body references as base64 data URIs so the file has no outside dependencies
+
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxEmbeddedImageSize skips attachments too large to sensibly inline in an HTML file
+const maxEmbeddedImageSize = 10 * 1024 * 1024
+
+// embeddedImageTypes maps image attachment extensions to the MIME types used in data URIs
+var embeddedImageTypes = map[string]string{
+	".jpg": "image/jpeg", ".jpeg": "image/jpeg", ".png": "image/png", ".gif": "image/gif",
+	".heic": "image/heic", ".heif": "image/heif", ".tif": "image/tiff", ".tiff": "image/tiff",
+	".bmp": "image/bmp", ".webp": "image/webp", ".svg": "image/svg+xml",
+}
+
+// imgSrcPattern captures an <img> tag up to its src value, the quote, and the value
+var imgSrcPattern = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*)(["'])(.*?)(["'])`)
+
+// ExportNoteHTML returns a note's HTML body wrapped in a standalone document
+// Images already inline stay as they are; images pointing at one of the note's attachments
+// are replaced with data URIs. References that can't be resolved are left untouched.
+func (s *AppleNotesService) ExportNoteHTML(ctx context.Context, noteTitle string) (string, error) {
+	body, err := s.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to export note as HTML: %w", err)
+	}
+
+	if imgSrcPattern.MatchString(body) {
+		attachments, err := s.GetNoteAttachments(ctx, noteTitle)
+		if err != nil {
+			return "", fmt.Errorf("failed to export note as HTML: %w", err)
+		}
+		body = s.embedAttachmentImages(ctx, body, attachments)
+	}
+
+	return buildPrintableHTML(noteTitle, body), nil
+}
+
+// embedAttachmentImages rewrites <img> sources that refer to an attachment into base64 data URIs
+func (s *AppleNotesService) embedAttachmentImages(ctx context.Context, body string, attachments []Attachment) string {
+	return imgSrcPattern.ReplaceAllStringFunc(body, func(tag string) string {
+		parts := imgSrcPattern.FindStringSubmatch(tag)
+		src := parts[3]
+		if strings.HasPrefix(strings.ToLower(src), "data:") {
+			return tag
+		}
+
+		attachment := findReferencedAttachment(src, attachments)
+		if attachment == nil || attachment.FilePath == "" {
+			return tag
+		}
+		mimeType, ok := embeddedImageTypes[strings.ToLower(filepath.Ext(attachment.FilePath))]
+		if !ok {
+			return tag
+		}
+		data, err := s.GetAttachmentContent(ctx, attachment.FilePath, maxEmbeddedImageSize)
+		if err != nil {
+			return tag
+		}
+
+		dataURI := "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+		return parts[1] + parts[2] + dataURI + parts[4]
+	})
+}
+
+// findReferencedAttachment matches an <img> src against attachment content identifiers
+// ("cid:..."), file paths ("file://..."), and finally file names
+func findReferencedAttachment(src string, attachments []Attachment) *Attachment {
+	if unescaped, err := url.PathUnescape(src); err == nil {
+		src = unescaped
+	}
+	cid := strings.TrimPrefix(src, "cid:")
+	filePath := strings.TrimPrefix(src, "file://")
+	name := path.Base(filePath)
+
+	for i := range attachments {
+		att := &attachments[i]
+		if (att.ContentIdentifier != "" && strings.EqualFold(att.ContentIdentifier, cid)) ||
+			(att.FilePath != "" && att.FilePath == filePath) {
+			return att
+		}
+	}
+	for i := range attachments {
+		if attachments[i].Name != "" && strings.EqualFold(attachments[i].Name, name) {
+			return &attachments[i]
+		}
+	}
+	return nil
+}
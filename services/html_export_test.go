@@ -0,0 +1,80 @@
+// ABOUTME: Unit tests for standalone HTML export
+// ABOUTME: Tests image embedding by content identifier, path, and name, and untouched references
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestExportNoteHTML tests that attachment images become data URIs and everything else is kept
+func TestExportNoteHTML(t *testing.T) {
+	dir := t.TempDir()
+	photo := filepath.Join(dir, "photo.png")
+	sketch := filepath.Join(dir, "Sketch 1.jpg")
+	doc := filepath.Join(dir, "spec.pdf")
+	for _, path := range []string{photo, sketch, doc} {
+		if err := os.WriteFile(path, []byte("img"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	body := `<div><h1>Moodboard</h1></div>` +
+		`<div><img src="cid:ABC-123" alt="photo"></div>` +
+		`<div><img class="wide" src='Sketch%201.jpg'></div>` +
+		`<div><img src="file://` + doc + `"></div>` +
+		`<div><img src="data:image/gif;base64,R0lG"></div>` +
+		`<div><img src="https://example.com/remote.png"></div>`
+	attachments := attachmentRecord("att-1", "photo.png", "abc-123", photo, "", "") +
+		attachmentRecord("att-2", "Sketch 1.jpg", "", sketch, "", "") +
+		attachmentRecord("att-3", "spec.pdf", "", doc, "", "")
+
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: body},        // GetNoteContent
+			{stdout: attachments}, // GetNoteAttachments
+		},
+	}
+	service := NewAppleNotesService(executor)
+	service.containerDir = dir
+
+	html, err := service.ExportNoteHTML(context.Background(), "Moodboard")
+	if err != nil {
+		t.Fatalf("ExportNoteHTML failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<title>Moodboard</title>",
+		`<img src="data:image/png;base64,aW1n" alt="photo">`,
+		`<img class="wide" src='data:image/jpeg;base64,aW1n'>`,
+		`<img src="file://` + doc + `">`,
+		`<img src="data:image/gif;base64,R0lG">`,
+		`<img src="https://example.com/remote.png">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected HTML to contain %q\n%s", want, html)
+		}
+	}
+}
+
+// TestExportNoteHTMLWithoutImages tests that notes without images skip the attachment lookup
+func TestExportNoteHTMLWithoutImages(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{stdout: "<div><b>Plain</b></div>"})
+
+	html, err := service.ExportNoteHTML(context.Background(), "Plain")
+	if err != nil {
+		t.Fatalf("ExportNoteHTML failed: %v", err)
+	}
+	if !strings.Contains(html, "<div><b>Plain</b></div>") {
+		t.Errorf("body missing from %s", html)
+	}
+}
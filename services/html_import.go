@@ -0,0 +1,317 @@
+// ABOUTME: "Read later" import of HTML files such as saved web pages
+// ABOUTME: Sanitizes markup with the HTML tokenizer to the subset Notes renders well and records the source URL at the top
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// allowedHTMLTags maps tags kept by SanitizeHTML to the tag written to the note
+// Anything else is unwrapped: the tag goes, its text stays.
+var allowedHTMLTags = map[string]string{
+	"div": "div", "p": "div", "section": "div", "article": "div", "main": "div", "header": "div",
+	"figure": "div", "figcaption": "div", "pre": "pre",
+	"h1": "h1", "h2": "h2", "h3": "h3", "h4": "h3", "h5": "h3", "h6": "h3",
+	"b": "b", "strong": "b", "i": "i", "em": "i", "u": "u", "s": "s", "strike": "s", "del": "s",
+	"ul": "ul", "ol": "ol", "li": "li", "blockquote": "blockquote", "br": "br", "a": "a",
+	"table": "table", "thead": "thead", "tbody": "tbody", "tr": "tr", "td": "td", "th": "th",
+}
+
+// droppedHTMLElements are removed together with their content: page chrome and things Notes can't show
+var droppedHTMLElements = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true, "iframe": true,
+	"object": true, "svg": true, "canvas": true, "video": true, "audio": true, "form": true,
+	"button": true, "select": true, "textarea": true, "nav": true, "footer": true, "aside": true,
+}
+
+// impliedEndTags lists, for tags that end an open sibling the way HTML parsers do, the open tags they
+// close and the container tags that stop the search
+var impliedEndTags = map[string]struct{ closes, within []string }{
+	"li": {closes: []string{"li"}, within: []string{"ul", "ol"}},
+	"tr": {closes: []string{"tr", "td", "th"}, within: []string{"table", "thead", "tbody"}},
+	"td": {closes: []string{"td", "th"}, within: []string{"tr", "table"}},
+	"th": {closes: []string{"td", "th"}, within: []string{"tr", "table"}},
+}
+
+var (
+	htmlAnyTagPattern    = regexp.MustCompile(`(?s)<(/?)([a-zA-Z][a-zA-Z0-9]*)\b([^>]*)>`)
+	htmlTitlePattern     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlH1Pattern        = regexp.MustCompile(`(?is)<h1[^>]*>(.*?)</h1>`)
+	htmlWhitespace       = regexp.MustCompile(`\s+`)
+	savedFromPattern     = regexp.MustCompile(`saved from url=\(\d+\)(\S+?)\s*-->`)
+	canonicalLinkPattern = regexp.MustCompile(`(?is)<link\b[^>]*\brel=["']canonical["'][^>]*\bhref=["']([^"']+)["']`)
+	ogURLPattern         = regexp.MustCompile(`(?is)<meta\b[^>]*\bproperty=["']og:url["'][^>]*\bcontent=["']([^"']+)["']`)
+)
+
+// htmlTextEscaper escapes text for an element body, leaving quotes readable
+var htmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// openHTMLTag is a tag SanitizeHTML has written and not yet closed, with where it starts in the output
+type openHTMLTag struct {
+	name string
+	pos  int
+}
+
+// htmlSanitizer accumulates SanitizeHTML's output, keeping every tag it writes balanced
+type htmlSanitizer struct {
+	out  bytes.Buffer
+	open []openHTMLTag
+}
+
+// SanitizeHTML reduces an HTML document or fragment to markup Notes renders well
+// The document runs through the HTML tokenizer against an allowlist: only the <body> is kept;
+// scripts, styles, navigation, and media are removed with their content; other tags are unwrapped;
+// every attribute except http(s) and mailto link targets is stripped; and images are dropped.
+// Tags are closed in order, so malformed or misnested input still gives well-formed markup.
+// Whitespace is collapsed everywhere except inside <pre>, and empty blocks are removed.
+func SanitizeHTML(doc string) string {
+	z := nethtml.NewTokenizer(strings.NewReader(doc))
+	var s htmlSanitizer
+	skip, skipDepth := "", 0
+
+	for {
+		tokenType := z.Next()
+		if tokenType == nethtml.ErrorToken {
+			break
+		}
+		token := z.Token()
+
+		// Inside a dropped element, only watch for its end; a <body> also ends an unclosed <head>
+		if skip != "" {
+			switch {
+			case skip == "head" && tokenType == nethtml.StartTagToken && token.Data == "body":
+				skip = ""
+			case token.Data != skip:
+				continue
+			case tokenType == nethtml.StartTagToken:
+				skipDepth++
+				continue
+			case tokenType == nethtml.EndTagToken:
+				if skipDepth--; skipDepth == 0 {
+					skip = ""
+				}
+				continue
+			default:
+				continue
+			}
+		}
+
+		switch tokenType {
+		case nethtml.TextToken:
+			s.writeText(token.Data)
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			if token.Data == "body" {
+				// Only the body is kept; anything written before it was outside
+				s = htmlSanitizer{}
+				continue
+			}
+			if droppedHTMLElements[token.Data] {
+				if tokenType == nethtml.StartTagToken {
+					skip, skipDepth = token.Data, 1
+				}
+				continue
+			}
+			s.start(token)
+		case nethtml.EndTagToken:
+			if token.Data == "body" {
+				return s.finish()
+			}
+			if mapped, ok := allowedHTMLTags[token.Data]; ok && mapped != "br" {
+				s.closeTo(mapped)
+			}
+		}
+	}
+	return s.finish()
+}
+
+// start writes an allowed start tag, ending any open sibling it implies the end of
+func (s *htmlSanitizer) start(token nethtml.Token) {
+	mapped, ok := allowedHTMLTags[token.Data]
+	switch {
+	case !ok:
+		return
+	case mapped == "br":
+		s.out.WriteString("<br>")
+		return
+	}
+
+	if implied, ok := impliedEndTags[mapped]; ok {
+		outermost := -1
+		for i := len(s.open) - 1; i >= 0 && !slices.Contains(implied.within, s.open[i].name); i-- {
+			if slices.Contains(implied.closes, s.open[i].name) {
+				outermost = i
+			}
+		}
+		for outermost >= 0 && len(s.open) > outermost {
+			s.closeLast()
+		}
+	}
+
+	s.open = append(s.open, openHTMLTag{name: mapped, pos: s.out.Len()})
+	if mapped == "a" {
+		for _, attr := range token.Attr {
+			if attr.Namespace == "" && attr.Key == "href" {
+				if href := safeHref(attr.Val); href != "" {
+					s.out.WriteString(`<a href="` + html.EscapeString(href) + `">`)
+					return
+				}
+			}
+		}
+	}
+	s.out.WriteString("<" + mapped + ">")
+}
+
+// closeTo closes open tags down to and including the innermost open name; a name that isn't open is ignored
+// A block left holding nothing but whitespace and line breaks is removed rather than closed.
+func (s *htmlSanitizer) closeTo(name string) {
+	i := len(s.open) - 1
+	for i >= 0 && s.open[i].name != name {
+		i--
+	}
+	if i < 0 {
+		return
+	}
+	for len(s.open) > i {
+		s.closeLast()
+	}
+}
+
+// closeLast closes the innermost open tag
+func (s *htmlSanitizer) closeLast() {
+	tag := s.open[len(s.open)-1]
+	s.open = s.open[:len(s.open)-1]
+
+	if tag.name == "div" || tag.name == "pre" {
+		inner := s.out.String()[tag.pos:]
+		inner = strings.TrimPrefix(inner, "<"+tag.name+">")
+		if strings.TrimSpace(strings.ReplaceAll(inner, "<br>", "")) == "" {
+			s.out.Truncate(tag.pos)
+			return
+		}
+	}
+	s.out.WriteString("</" + tag.name + ">")
+}
+
+// writeText writes escaped text, collapsing whitespace to single spaces outside <pre>
+func (s *htmlSanitizer) writeText(text string) {
+	if s.inPre() {
+		s.out.WriteString(htmlTextEscaper.Replace(text))
+		return
+	}
+	text = htmlWhitespace.ReplaceAllString(text, " ")
+	if strings.HasPrefix(text, " ") && (s.out.Len() == 0 || bytes.HasSuffix(s.out.Bytes(), []byte(" "))) {
+		text = text[1:]
+	}
+	s.out.WriteString(htmlTextEscaper.Replace(text))
+}
+
+// inPre reports whether a <pre> is open
+func (s *htmlSanitizer) inPre() bool {
+	for _, tag := range s.open {
+		if tag.name == "pre" {
+			return true
+		}
+	}
+	return false
+}
+
+// finish closes every open tag and returns the output
+func (s *htmlSanitizer) finish() string {
+	for len(s.open) > 0 {
+		s.closeLast()
+	}
+	return strings.TrimSpace(s.out.String())
+}
+
+// safeHref returns a link target when it uses a scheme safe to keep
+func safeHref(href string) string {
+	href = strings.TrimSpace(href)
+	lower := strings.ToLower(href)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "mailto:") {
+		return href
+	}
+	return ""
+}
+
+// ReadHTMLImport reads an HTML file into a note for ImportNotes
+// The title comes from <title>, then the first <h1>, then the file name. When sourceURL is
+// empty it is taken from the page itself (browser "saved from" comment, canonical link, or og:url).
+func ReadHTMLImport(filePath string, sourceURL string) (ImportedNote, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return ImportedNote{}, fmt.Errorf("failed to read HTML file: %w", err)
+	}
+	if info.Size() > maxImportFileSize {
+		return ImportedNote{}, fmt.Errorf("%w: %s exceeds maximum import size (%d bytes)", ErrInvalidInput, filePath, maxImportFileSize)
+	}
+
+	data, err := os.ReadFile(filePath) // #nosec G304 - path is the file the user asked to import
+	if err != nil {
+		return ImportedNote{}, fmt.Errorf("failed to read HTML file: %w", err)
+	}
+	doc := string(data)
+
+	title := htmlText(firstSubmatch(htmlTitlePattern, doc))
+	if title == "" {
+		title = htmlText(firstSubmatch(htmlH1Pattern, doc))
+	}
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	}
+
+	if sourceURL == "" {
+		sourceURL = pageSourceURL(doc)
+	}
+
+	content := SanitizeHTML(doc)
+	if sourceURL != "" {
+		escaped := html.EscapeString(sourceURL)
+		content = `<div>Source: <a href="` + escaped + `">` + escaped + `</a></div><br>` + content
+	}
+
+	return ImportedNote{Title: title, Content: content, IsHTML: true, SourcePath: filePath}, nil
+}
+
+// ImportHTMLFile imports one HTML file as a note, skipping it if a note with its title exists
+func (s *AppleNotesService) ImportHTMLFile(ctx context.Context, filePath, sourceURL, folder string) (*ImportResult, error) {
+	note, err := ReadHTMLImport(filePath, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportNotes(ctx, []ImportedNote{note}, folder, false)
+}
+
+// pageSourceURL finds the URL a saved web page came from
+func pageSourceURL(doc string) string {
+	for _, pattern := range []*regexp.Regexp{savedFromPattern, canonicalLinkPattern, ogURLPattern} {
+		if url := firstSubmatch(pattern, doc); url != "" {
+			return html.UnescapeString(url)
+		}
+	}
+	return ""
+}
+
+// firstSubmatch returns the first capture group of pattern in s, or ""
+func firstSubmatch(pattern *regexp.Regexp, s string) string {
+	if matches := pattern.FindStringSubmatch(s); matches != nil {
+		return matches[1]
+	}
+	return ""
+}
+
+// htmlText strips tags and entities from a fragment and collapses whitespace
+func htmlText(fragment string) string {
+	text := html.UnescapeString(htmlAnyTagPattern.ReplaceAllString(fragment, ""))
+	return strings.Join(strings.Fields(text), " ")
+}
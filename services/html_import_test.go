@@ -0,0 +1,229 @@
+// ABOUTME: Unit tests for HTML "read later" import
+// ABOUTME: Tests sanitization, title and source URL detection, and the import flow
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// savedPage is a trimmed browser "Save Page As" file
+const savedPage = `<!DOCTYPE html>
+<!-- saved from url=(0038)https://example.com/articles/go-tips -->
+<html>
+<head>
+<title>Ten Go Tips &amp; Tricks | Example Blog</title>
+<style>body { color: red; }</style>
+<script>track();</script>
+</head>
+<body class="article">
+<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+<article id="main">
+<h1 style="font-size: 40px">Ten Go Tips</h1>
+<p onclick="evil()">Use <strong>gofmt</strong> and <em>vet</em>. See <a href="https://go.dev/doc" target="_blank">the docs</a>
+or <a href="javascript:alert(1)">this</a>.</p>
+<h4>Details</h4>
+<ul><li>One</li><li>Two</li></ul>
+<img src="hero.png" alt="hero">
+<p>   </p>
+<div><span class="x">Plain</span> text<br/>after break</div>
+</article>
+<footer>Copyright</footer>
+</body>
+</html>`
+
+// TestSanitizeHTML tests that page chrome and attributes are removed and structure kept
+func TestSanitizeHTML(t *testing.T) {
+	got := SanitizeHTML(savedPage)
+	want := `<div> <h1>Ten Go Tips</h1> <div>Use <b>gofmt</b> and <i>vet</i>. See <a href="https://go.dev/doc">the docs</a> or <a>this</a>.</div> ` +
+		`<h3>Details</h3> <ul><li>One</li><li>Two</li></ul> <div>Plain text<br>after break</div> </div>`
+	if got != want {
+		t.Errorf("SanitizeHTML =\n%s\nwant\n%s", got, want)
+	}
+
+	for _, unwanted := range []string{"track()", "color: red", "Home", "Copyright", "hero", "onclick", "javascript"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("sanitized HTML still contains %q", unwanted)
+		}
+	}
+}
+
+// TestSanitizeHTMLMalformedAndNested tests that broken or deeply nested markup comes out well-formed
+func TestSanitizeHTMLMalformedAndNested(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "misnested inline", in: `<b>bold <i>both</b> italic</i>`, want: `<b>bold <i>both</i></b> italic`},
+		{name: "unclosed block", in: `<div>open <p>para`, want: `<div>open <div>para</div></div>`},
+		{name: "stray end tags", in: `</div>text</b></p>`, want: `text`},
+		{name: "implied list item ends", in: `<ul><li>One<li>Two</ul>`, want: `<ul><li>One</li><li>Two</li></ul>`},
+		{name: "implied cell ends", in: `<table><tr><td>a<td>b<tr><td>c</table>`,
+			want: `<table><tr><td>a</td><td>b</td></tr><tr><td>c</td></tr></table>`},
+		{name: "nested lists", in: `<ul><li>One<ul><li>Sub</li></ul></li></ul>`, want: `<ul><li>One<ul><li>Sub</li></ul></li></ul>`},
+		{name: "nested dropped elements", in: `<nav><nav>menu</nav>more menu</nav>kept`, want: `kept`},
+		{name: "script inside allowed tags", in: `<div><b><script>alert("</b>")</script>safe</b></div>`, want: `<div><b>safe</b></div>`},
+		{name: "tag soup script", in: `<<script>alert(1)//<</script>x`, want: `&lt;x`},
+		{name: "unterminated comment", in: `<div>a</div><!-- never closed <script>x()</script>`, want: `<div>a</div>`},
+		{name: "unclosed head", in: `<html><head><title>T</title><body><p>Body</p>`, want: `<div>Body</div>`},
+		{name: "nested empty blocks", in: `<div><div><p> <br> </p></div></div>after`, want: `after`},
+		{name: "event and style attributes", in: `<p onclick="x()" style="color:red"><a href=" HTTPS://ok.example/ " onmouseover="y()">ok</a></p>`,
+			want: `<div><a href="HTTPS://ok.example/">ok</a></div>`},
+		{name: "unsafe link scheme", in: `<a href="data:text/html,hi">data</a> <a href="vbscript:x">vb</a>`, want: `<a>data</a> <a>vb</a>`},
+		{name: "escaped text", in: `<p>a &lt;b&gt; &amp; "c"</p>`, want: `<div>a &lt;b&gt; &amp; "c"</div>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.in); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) =\n%s\nwant\n%s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSanitizeHTMLKeepsPreWhitespace tests that whitespace inside <pre> survives while text elsewhere is collapsed
+func TestSanitizeHTMLKeepsPreWhitespace(t *testing.T) {
+	in := "<p>Run   this:</p>\n<pre>func main() {\n\tfmt.Println(\"hi\")  // <b>greet</b>\n}</pre>\n<p>done</p>"
+	want := "<div>Run this:</div> <pre>func main() {\n\tfmt.Println(\"hi\")  // <b>greet</b>\n}</pre> <div>done</div>"
+	if got := SanitizeHTML(in); got != want {
+		t.Errorf("SanitizeHTML =\n%q\nwant\n%q", got, want)
+	}
+}
+
+// TestReadHTMLImport tests title and source URL detection
+func TestReadHTMLImport(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	saved := write("page.html", savedPage)
+	note, err := ReadHTMLImport(saved, "")
+	if err != nil {
+		t.Fatalf("ReadHTMLImport failed: %v", err)
+	}
+	if note.Title != "Ten Go Tips & Tricks | Example Blog" || !note.IsHTML {
+		t.Errorf("unexpected note %+v", note)
+	}
+	wantSource := `<div>Source: <a href="https://example.com/articles/go-tips">https://example.com/articles/go-tips</a></div><br>`
+	if !strings.HasPrefix(note.Content, wantSource) {
+		t.Errorf("expected source line first, got %s", note.Content)
+	}
+
+	overridden, err := ReadHTMLImport(saved, "https://mirror.example/tips?a=1&b=2")
+	if err != nil {
+		t.Fatalf("ReadHTMLImport failed: %v", err)
+	}
+	if !strings.Contains(overridden.Content, `href="https://mirror.example/tips?a=1&amp;b=2"`) {
+		t.Errorf("explicit URL not used: %s", overridden.Content)
+	}
+
+	canonical := write("canonical.htm", `<html><head><link rel="canonical" href="https://example.com/c"></head><body><h1>Heading <b>Title</b></h1><p>Body</p></body></html>`)
+	note, err = ReadHTMLImport(canonical, "")
+	if err != nil {
+		t.Fatalf("ReadHTMLImport failed: %v", err)
+	}
+	if note.Title != "Heading Title" || !strings.Contains(note.Content, `href="https://example.com/c"`) {
+		t.Errorf("unexpected note %+v", note)
+	}
+
+	bare := write("Reading List.html", `<p>Just text</p>`)
+	note, err = ReadHTMLImport(bare, "")
+	if err != nil {
+		t.Fatalf("ReadHTMLImport failed: %v", err)
+	}
+	if note.Title != "Reading List" || note.Content != "<div>Just text</div>" {
+		t.Errorf("unexpected note %+v", note)
+	}
+
+	if _, err := ReadHTMLImport(filepath.Join(dir, "missing.html"), ""); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+// TestImportHTMLFile tests that the sanitized page is created as a note
+func TestImportHTMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "page.html")
+	if err := os.WriteFile(path, []byte(savedPage), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := `{id:"x-coredata://1", name:"Ten Go Tips & Tricks | Example Blog", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 1, 2024 at 10:00:00 AM", container:"Reading", shared:false, password protected:false}`
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: "Other note"}, // listAllTitles
+			{stdout: ""},           // CreateNote
+			{stdout: metadata},     // GetNoteMetadata
+			{stdout: ""},           // MoveNote
+		},
+	}
+	service := NewAppleNotesService(executor)
+
+	result, err := service.ImportHTMLFile(context.Background(), path, "", "Reading")
+	if err != nil {
+		t.Fatalf("ImportHTMLFile failed: %v", err)
+	}
+	if len(result.Imported) != 1 || result.Imported[0] != "Ten Go Tips & Tricks | Example Blog" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if executor.callIndex != 4 {
+		t.Errorf("expected create, metadata, and move calls, got %d calls", executor.callIndex)
+	}
+}
+
+// recordingSequentialExecutor records each script before answering it from the wrapped executor
+type recordingSequentialExecutor struct {
+	SequentialMockExecutor
+	scripts []string
+}
+
+func (e *recordingSequentialExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	e.scripts = append(e.scripts, script)
+	return e.SequentialMockExecutor.Execute(ctx, script)
+}
+
+// TestImportHTMLFileKeepsPreWhitespace tests that a <pre> block reaches CreateNote with its indentation and line breaks
+func TestImportHTMLFileKeepsPreWhitespace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snippet.html")
+	page := "<html><head><title>Snippet</title></head><body>\n<p>Run   this:</p>\n<pre>if x {\n    y()\n}</pre>\n</body></html>"
+	if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := `{id:"x-coredata://1", name:"Snippet", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 1, 2024 at 10:00:00 AM", container:"Notes", shared:false, password protected:false}`
+	executor := &recordingSequentialExecutor{}
+	executor.responses = []struct {
+		stdout string
+		stderr string
+		err    error
+	}{
+		{stdout: ""},       // listAllTitles
+		{stdout: ""},       // CreateNote
+		{stdout: metadata}, // GetNoteMetadata
+	}
+	service := NewAppleNotesService(executor)
+
+	if _, err := service.ImportHTMLFile(context.Background(), path, "", ""); err != nil {
+		t.Fatalf("ImportHTMLFile failed: %v", err)
+	}
+	if len(executor.scripts) < 2 {
+		t.Fatalf("expected a CreateNote call, got %d scripts", len(executor.scripts))
+	}
+	create := executor.scripts[1]
+	if want := `<div>Run this:</div> <pre>if x {<br>    y()<br>}</pre>`; !strings.Contains(create, want) {
+		t.Errorf("expected the <pre> block with its whitespace in\n%s\nwant %s", create, want)
+	}
+}
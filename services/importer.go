@@ -0,0 +1,272 @@
+// ABOUTME: Importer for notes exported from Bear or Apple Notes
+// ABOUTME: Reads markdown/text/HTML exports and creates notes with duplicate title detection
+
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxImportFileSize limits how much of a single exported file is read (guards against zip bombs)
+const maxImportFileSize = 10 * 1024 * 1024
+
+// ImportedNote is a note read from an export, ready to be created in Apple Notes
+type ImportedNote struct {
+	Title      string `json:"title"`
+	Content    string `json:"-"`
+	IsHTML     bool   `json:"-"`
+	SourcePath string `json:"source_path"`
+}
+
+// ImportFailure records a note that could not be imported
+type ImportFailure struct {
+	Title string `json:"title"`
+	Error string `json:"error"`
+}
+
+// ImportResult summarizes an import run
+type ImportResult struct {
+	Imported   []string        `json:"imported"`
+	Duplicates []string        `json:"duplicates"`
+	Failed     []ImportFailure `json:"failed"`
+}
+
+// htmlBodyPattern captures the contents of an HTML document's <body>
+var htmlBodyPattern = regexp.MustCompile(`(?is)<body[^>]*>(.*)</body>`)
+
+// ReadImportSource reads notes from a Bear export zip or an exported folder
+func ReadImportSource(sourcePath string) ([]ImportedNote, error) {
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import source: %w", err)
+	}
+
+	if info.IsDir() {
+		return ReadExportDir(sourcePath)
+	}
+	return ReadBearExport(sourcePath)
+}
+
+// ReadBearExport reads a Bear export zip containing markdown files or TextBundles
+// Assets are not imported; image references remain in the markdown text
+func ReadBearExport(zipPath string) ([]ImportedNote, error) {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Bear export: %w", err)
+	}
+	defer reader.Close() //nolint:errcheck // read-only archive close failure is non-critical
+
+	notes := []ImportedNote{}
+	for _, file := range reader.File {
+		name := file.Name
+		if file.FileInfo().IsDir() || strings.HasPrefix(name, "__MACOSX/") {
+			continue
+		}
+
+		stem, ok := importableStem(name)
+		if !ok {
+			continue
+		}
+
+		// TextBundles store the note as text.md inside "<title>.textbundle/"
+		if dir := path.Dir(name); strings.HasSuffix(dir, ".textbundle") {
+			if stem != "text" {
+				continue
+			}
+			stem = strings.TrimSuffix(path.Base(dir), ".textbundle")
+		}
+
+		data, err := readZipFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		notes = append(notes, newImportedNote(stem, name, string(data)))
+	}
+
+	return notes, nil
+}
+
+// ReadExportDir reads notes from a folder of exported .md, .txt, or .html files
+// Subfolders are walked recursively
+func ReadExportDir(dir string) ([]ImportedNote, error) {
+	notes := []ImportedNote{}
+
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		stem, ok := importableStem(entry.Name())
+		if !ok {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxImportFileSize {
+			return fmt.Errorf("%s exceeds maximum import size (%d bytes)", filePath, maxImportFileSize)
+		}
+
+		data, err := os.ReadFile(filePath) // #nosec G304 - path comes from walking the user-selected export folder
+		if err != nil {
+			return err
+		}
+
+		notes = append(notes, newImportedNote(stem, filePath, string(data)))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export folder: %w", err)
+	}
+
+	return notes, nil
+}
+
+// ImportNotes creates the given notes in Apple Notes, optionally moving them into folder
+// Notes whose titles already exist (case-insensitive), or repeat earlier notes in the batch,
+// are reported as duplicates and skipped unless allowDuplicates is set
+func (s *AppleNotesService) ImportNotes(ctx context.Context, notes []ImportedNote, folder string, allowDuplicates bool) (*ImportResult, error) {
+	existing, err := s.listAllTitles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import notes: %w", err)
+	}
+
+	seen := make(map[string]bool, len(existing))
+	for _, title := range existing {
+		seen[strings.ToLower(title)] = true
+	}
+
+	result := &ImportResult{Imported: []string{}, Duplicates: []string{}, Failed: []ImportFailure{}}
+	for _, note := range notes {
+		key := strings.ToLower(note.Title)
+		if seen[key] && !allowDuplicates {
+			result.Duplicates = append(result.Duplicates, note.Title)
+			continue
+		}
+
+		if err := s.importNote(ctx, note, folder); err != nil {
+			result.Failed = append(result.Failed, ImportFailure{Title: note.Title, Error: err.Error()})
+			continue
+		}
+
+		seen[key] = true
+		result.Imported = append(result.Imported, note.Title)
+	}
+
+	return result, nil
+}
+
+// importNote creates a single imported note and moves it into folder when given
+func (s *AppleNotesService) importNote(ctx context.Context, note ImportedNote, folder string) error {
+	if _, err := s.CreateNote(ctx, note.Title, note.Content, nil); err != nil {
+		return err
+	}
+
+	if folder != "" {
+		return s.MoveNote(ctx, note.Title, folder)
+	}
+	return nil
+}
+
+// listAllTitles returns the titles of every note in the account
+func (s *AppleNotesService) listAllTitles(ctx context.Context) ([]string, error) {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set noteList to name of notes
+				set oldDelimiters to AppleScript's text item delimiters
+				set AppleScript's text item delimiters to "|||"
+				set result to noteList as string
+				set AppleScript's text item delimiters to oldDelimiters
+				return result
+			end tell
+		end tell
+	`, s.iCloudAccount)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return nil, fmt.Errorf("failed to list note titles: %w", detectedErr)
+	}
+
+	titles := []string{}
+	for _, title := range strings.Split(strings.TrimSpace(stdout), "|||") {
+		if title = strings.TrimSpace(title); title != "" {
+			titles = append(titles, title)
+		}
+	}
+
+	return titles, nil
+}
+
+// importableStem returns the file name without extension when the file type can be imported
+func importableStem(name string) (string, bool) {
+	ext := strings.ToLower(path.Ext(name))
+	switch ext {
+	case ".md", ".markdown", ".txt", ".html", ".htm":
+		return strings.TrimSuffix(path.Base(filepath.ToSlash(name)), path.Ext(name)), true
+	default:
+		return "", false
+	}
+}
+
+// newImportedNote normalizes an exported file into an ImportedNote
+// Markdown notes starting with a "# Title" heading use it as the title and drop it from the body
+func newImportedNote(stem, sourcePath, data string) ImportedNote {
+	note := ImportedNote{Title: stem, SourcePath: sourcePath}
+
+	ext := strings.ToLower(path.Ext(sourcePath))
+	if ext == ".html" || ext == ".htm" {
+		note.IsHTML = true
+		if matches := htmlBodyPattern.FindStringSubmatch(data); matches != nil {
+			data = matches[1]
+		}
+		// Sanitizing collapses the file's formatting newlines, which CreateNote would turn into <br> breaks
+		note.Content = SanitizeHTML(data)
+		return note
+	}
+
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	firstLine, rest, _ := strings.Cut(data, "\n")
+	if heading := strings.TrimSpace(firstLine); strings.HasPrefix(heading, "# ") {
+		note.Title = strings.TrimSpace(strings.TrimPrefix(heading, "# "))
+		data = rest
+	}
+	note.Content = strings.TrimSpace(data)
+
+	return note
+}
+
+// readZipFile reads a zip entry up to maxImportFileSize bytes
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close() //nolint:errcheck // read-only entry close failure is non-critical
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxImportFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImportFileSize {
+		return nil, fmt.Errorf("exceeds maximum import size (%d bytes)", maxImportFileSize)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,161 @@
+// ABOUTME: Unit tests for the Bear and Apple Notes export importer
+// ABOUTME: Verifies zip/folder reading, title normalization, and duplicate detection
+
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestZip creates a zip archive with the given file contents
+func writeTestZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	zipPath := filepath.Join(t.TempDir(), "export.zip")
+	out, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("failed to create zip: %v", err)
+	}
+
+	writer := zip.NewWriter(out)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+
+	return zipPath
+}
+
+// TestReadBearExport tests reading markdown files and TextBundles from a Bear zip
+func TestReadBearExport(t *testing.T) {
+	zipPath := writeTestZip(t, map[string]string{
+		"Groceries.md":                     "# Groceries\n- milk\n- eggs\n",
+		"Untitled thought.md":              "just text #idea",
+		"Trip.textbundle/text.md":          "# Trip to Lisbon\nItinerary",
+		"Trip.textbundle/info.json":        "{}",
+		"Trip.textbundle/assets/photo.jpg": "binary",
+		"__MACOSX/._Groceries.md":          "resource fork",
+		"Groceries/assets/not-a-note.jpeg": "binary",
+	})
+
+	notes, err := ReadBearExport(zipPath)
+	if err != nil {
+		t.Fatalf("ReadBearExport failed: %v", err)
+	}
+
+	byTitle := map[string]ImportedNote{}
+	for _, note := range notes {
+		byTitle[note.Title] = note
+	}
+
+	if len(notes) != 3 {
+		t.Fatalf("expected 3 notes, got %d: %+v", len(notes), notes)
+	}
+	if got := byTitle["Groceries"].Content; got != "- milk\n- eggs" {
+		t.Errorf("Groceries content = %q, heading should be removed", got)
+	}
+	if got := byTitle["Untitled thought"].Content; got != "just text #idea" {
+		t.Errorf("Untitled thought content = %q", got)
+	}
+	if _, ok := byTitle["Trip to Lisbon"]; !ok {
+		t.Error("expected TextBundle note titled from its heading")
+	}
+}
+
+// TestReadExportDir tests reading an exported folder with text and HTML files
+func TestReadExportDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "Work"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"Plain.txt":        "line one\nline two",
+		"Work/Design.html": "<html><head><title>x</title></head><body><div>Hello</div>\n<div>World</div></body></html>",
+		"Work/image.png":   "binary",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notes, err := ReadImportSource(dir)
+	if err != nil {
+		t.Fatalf("ReadImportSource failed: %v", err)
+	}
+
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+
+	for _, note := range notes {
+		switch note.Title {
+		case "Plain":
+			if note.IsHTML || note.Content != "line one\nline two" {
+				t.Errorf("unexpected plain note: %+v", note)
+			}
+		case "Design":
+			if !note.IsHTML || note.Content != "<div>Hello</div> <div>World</div>" {
+				t.Errorf("unexpected HTML note: %+v", note)
+			}
+		default:
+			t.Errorf("unexpected note title %q", note.Title)
+		}
+	}
+}
+
+// TestImportNotesSkipsDuplicates tests duplicate detection against existing and batch titles
+func TestImportNotesSkipsDuplicates(t *testing.T) {
+	metadata := `{id:"x-coredata://new", name:"Fresh", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 1, 2024 at 10:00:00 AM", container:"Notes", shared:false, password protected:false}`
+
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: "Existing Note|||Other"}, // listAllTitles
+			{stdout: ""},                      // CreateNote "Fresh"
+			{stdout: metadata},                // GetNoteMetadata "Fresh"
+			{stdout: ""},                      // MoveNote "Fresh"
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+
+	notes := []ImportedNote{
+		{Title: "existing note", Content: "dup"},
+		{Title: "Fresh", Content: "new"},
+		{Title: "FRESH", Content: "dup within batch"},
+	}
+
+	result, err := service.ImportNotes(context.Background(), notes, "Imported", false)
+	if err != nil {
+		t.Fatalf("ImportNotes failed: %v", err)
+	}
+
+	if len(result.Imported) != 1 || result.Imported[0] != "Fresh" {
+		t.Errorf("Imported = %v, want [Fresh]", result.Imported)
+	}
+	if len(result.Duplicates) != 2 {
+		t.Errorf("Duplicates = %v, want 2 entries", result.Duplicates)
+	}
+	if len(result.Failed) != 0 {
+		t.Errorf("Failed = %v, want none", result.Failed)
+	}
+}
@@ -0,0 +1,88 @@
+// ABOUTME: Read-only "jxa" provider that reads Apple Notes with JavaScript for Automation
+// ABOUTME: One script fetches every note's properties in bulk, which is faster than AppleScript's per-note loops
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jxaSnapshotScript returns every note in the account as a JSON array
+// Reading each property across all notes at once is one Apple event per property rather than per note.
+const jxaSnapshotScript = `
+const account = Application("Notes").accounts.byName(%s);
+const notes = account.notes;
+const ids = notes.id(), names = notes.name(), bodies = notes.body();
+const created = notes.creationDate(), modified = notes.modificationDate();
+const shared = notes.shared(), locked = notes.passwordProtected();
+const folders = notes.container.name();
+JSON.stringify(ids.map((id, i) => ({
+	id: id, name: names[i], body: locked[i] ? "" : bodies[i], folder: folders[i],
+	created: created[i], modified: modified[i], shared: shared[i], locked: locked[i]
+})));
+`
+
+// jxaNote is a note as jxaSnapshotScript reports it
+type jxaNote struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Body     string    `json:"body"`
+	Folder   string    `json:"folder"`
+	Created  time.Time `json:"created"`
+	Modified time.Time `json:"modified"`
+	Shared   bool      `json:"shared"`
+	Locked   bool      `json:"locked"`
+}
+
+// NewJXAScriptExecutor creates an executor that runs JavaScript for Automation with osascript -l JavaScript
+// If timeout is 0 or negative, defaults to 10 seconds.
+func NewJXAScriptExecutor(timeout time.Duration) *OSAScriptExecutor {
+	executor := NewOSAScriptExecutor(timeout)
+	executor.language = "JavaScript"
+	return executor
+}
+
+// NewJXANotesReader creates a read-only NoteReader for the iCloud account that runs JXA through executor
+func NewJXANotesReader(executor ScriptExecutor) NoteReader {
+	return &snapshotReader{load: func(ctx context.Context) ([]Note, error) {
+		return loadJXANotes(ctx, executor, "iCloud")
+	}}
+}
+
+// loadJXANotes runs jxaSnapshotScript against account and converts its output to notes
+func loadJXANotes(ctx context.Context, executor ScriptExecutor, account string) ([]Note, error) {
+	quoted, err := json.Marshal(account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes with JXA: %w", err)
+	}
+	stdout, stderr, err := executor.Execute(ctx, fmt.Sprintf(jxaSnapshotScript, quoted))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes with JXA: %w", DetectError(ctx, stderr, err))
+	}
+
+	var listed []jxaNote
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &listed); err != nil {
+		return nil, fmt.Errorf("failed to parse JXA notes: %w", err)
+	}
+	notes := make([]Note, len(listed))
+	for i, note := range listed {
+		notes[i] = Note{
+			ID:                note.ID,
+			Title:             note.Name,
+			Content:           note.Body,
+			Tags:              []string{},
+			Created:           note.Created,
+			Modified:          note.Modified,
+			CreationDate:      note.Created,
+			ModificationDate:  note.Modified,
+			Folder:            note.Folder,
+			Shared:            note.Shared,
+			PasswordProtected: note.Locked,
+		}
+	}
+	return notes, nil
+}
@@ -0,0 +1,65 @@
+// ABOUTME: Unit tests for the read-only jxa provider
+// ABOUTME: Answers the bulk JXA script with canned JSON and checks the notes served from it
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// jxaRecordingExecutor answers every script with stdout and records the last one
+type jxaRecordingExecutor struct {
+	stdout string
+	script string
+}
+
+func (e *jxaRecordingExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	e.script = script
+	return e.stdout, "", nil
+}
+
+func TestJXANotesReader(t *testing.T) {
+	executor := &jxaRecordingExecutor{stdout: `[
+		{"id":"x-coredata://1","name":"Standup","body":"<div>Standup</div><div>Notes for today</div>","folder":"Work",
+		 "created":"2024-01-01T10:00:00.000Z","modified":"2024-01-02T10:00:00.000Z","shared":true,"locked":false},
+		{"id":"x-coredata://2","name":"Recipes","body":"<div>Pancakes</div>","folder":"Home",
+		 "created":"2024-01-01T10:00:00.000Z","modified":"2024-01-03T10:00:00.000Z","shared":false,"locked":false}
+	]`}
+	reader := NewJXANotesReader(executor)
+
+	results, err := reader.SearchNotesAdvanced(context.Background(), SearchOptions{Query: "today", SearchIn: SearchInBody, Shared: true})
+	if err != nil {
+		t.Fatalf("SearchNotesAdvanced failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Standup" || !results[0].Shared || results[0].Folder != "Work" {
+		t.Errorf("expected the standup note, got %+v", results)
+	}
+	if !strings.Contains(executor.script, `accounts.byName("iCloud")`) {
+		t.Errorf("expected the iCloud account in the script, got %s", executor.script)
+	}
+
+	recent, err := reader.GetRecentNotes(context.Background(), 1)
+	if err != nil || len(recent) != 1 || recent[0].Title != "Recipes" {
+		t.Errorf("expected the most recently modified note, got %+v, %v", recent, err)
+	}
+
+	if _, err := reader.GetNotesInFolder(context.Background(), "Archive"); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("expected ErrFolderNotFound for a missing folder, got %v", err)
+	}
+}
+
+func TestJXANotesReaderRejectsBadOutput(t *testing.T) {
+	reader := NewJXANotesReader(&MockExecutor{stdout: "not json"})
+	if _, err := reader.GetRecentNotes(context.Background(), 0); err == nil {
+		t.Error("expected an error for unparseable output")
+	}
+}
+
+func TestNewJXAScriptExecutor(t *testing.T) {
+	if executor := NewJXAScriptExecutor(0); executor.language != "JavaScript" || executor.timeout <= 0 {
+		t.Errorf("unexpected executor %+v", executor)
+	}
+}
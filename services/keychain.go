@@ -0,0 +1,112 @@
+// ABOUTME: Secret storage in the macOS Keychain
+// ABOUTME: Reads and writes generic passwords through the security command
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// KeychainService is the Keychain service name under which notes-mcp stores its secrets
+const KeychainService = "notes-mcp"
+
+// ErrSecretNotFound indicates the requested secret is not in the secret store
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore stores small named secrets such as encryption keys
+type SecretStore interface {
+	Get(ctx context.Context, name string) (string, error)
+	Set(ctx context.Context, name, value string) error
+	Delete(ctx context.Context, name string) error
+}
+
+// KeychainSecretStore implements SecretStore with generic passwords in the login Keychain
+type KeychainSecretStore struct {
+	service string
+	timeout time.Duration
+	// command is the security binary, replaced in tests
+	command string
+}
+
+// NewKeychainSecretStore creates a KeychainSecretStore for the notes-mcp Keychain service.
+// If timeout is 0 or negative, defaults to 10 seconds (the Keychain may prompt for access).
+func NewKeychainSecretStore(timeout time.Duration) *KeychainSecretStore {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &KeychainSecretStore{
+		service: KeychainService,
+		timeout: timeout,
+		command: "security",
+	}
+}
+
+// Get returns the secret stored under name, or ErrSecretNotFound
+func (k *KeychainSecretStore) Get(ctx context.Context, name string) (string, error) {
+	stdout, stderr, err := k.run(ctx, "find-generic-password", "-s", k.service, "-a", name, "-w")
+	if err != nil {
+		if strings.Contains(stderr, "could not be found") {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return "", fmt.Errorf("failed to read %s from Keychain: %w: %s", name, err, strings.TrimSpace(stderr))
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// Set stores value under name, replacing any existing secret
+// The value is sent hex-encoded to security's interactive mode on stdin, never as an argument,
+// so it doesn't show up in process listings. Interactive mode exits 0 even when a command fails,
+// so anything on stderr counts as a failure.
+func (k *KeychainSecretStore) Set(ctx context.Context, name, value string) error {
+	if name == "" || strings.ContainsAny(name, " \t\r\n\"'\\") {
+		return fmt.Errorf("%w: invalid secret name %q", ErrInvalidInput, name)
+	}
+
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", k.service, name, hex.EncodeToString([]byte(value)))
+	_, stderr, err := k.runWithInput(ctx, command, "-i")
+	if err == nil && strings.TrimSpace(stderr) != "" {
+		err = errors.New("security reported an error")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s to Keychain: %w: %s", name, err, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+// Delete removes the secret stored under name; deleting a missing secret is not an error
+func (k *KeychainSecretStore) Delete(ctx context.Context, name string) error {
+	_, stderr, err := k.run(ctx, "delete-generic-password", "-s", k.service, "-a", name)
+	if err != nil && !strings.Contains(stderr, "could not be found") {
+		return fmt.Errorf("failed to delete %s from Keychain: %w: %s", name, err, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+// run executes the security command with a timeout
+func (k *KeychainSecretStore) run(ctx context.Context, args ...string) (string, string, error) {
+	return k.runWithInput(ctx, "", args...)
+}
+
+// runWithInput executes the security command with a timeout, writing input to its stdin
+func (k *KeychainSecretStore) runWithInput(ctx context.Context, input string, args ...string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, k.command, args...)
+	cmd.Stdin = strings.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
@@ -0,0 +1,86 @@
+// ABOUTME: Unit tests for the Keychain secret store
+// ABOUTME: Runs a fake security command that records its arguments and stdin
+
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSecurityCommand writes a security stand-in that records its arguments and stdin in dir
+// It prints stderr to standard error, mimicking a failed interactive command.
+func fakeSecurityCommand(t *testing.T, dir, stderr string) string {
+	t.Helper()
+	script := "#!/bin/sh\n" +
+		`printf '%s\n' "$@" > "` + filepath.Join(dir, "args") + "\"\n" +
+		`cat > "` + filepath.Join(dir, "stdin") + "\"\n"
+	if stderr != "" {
+		script += "echo '" + stderr + "' >&2\n"
+	}
+	path := filepath.Join(dir, "security")
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestKeychainSetKeepsValueOutOfArgs tests that Set sends the secret on stdin rather than as an argument
+func TestKeychainSetKeepsValueOutOfArgs(t *testing.T) {
+	dir := t.TempDir()
+	store := NewKeychainSecretStore(0)
+	store.command = fakeSecurityCommand(t, dir, "")
+
+	value := `[{"id":"ab12","hash":"s3cret value"}]`
+	if err := store.Set(context.Background(), "api-tokens", value); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(args), "s3cret") || strings.Contains(string(args), hex.EncodeToString([]byte(value))) {
+		t.Errorf("expected the secret to stay out of the arguments, got %q", args)
+	}
+	if strings.TrimSpace(string(args)) != "-i" {
+		t.Errorf("expected interactive mode, got arguments %q", args)
+	}
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "add-generic-password -U -s notes-mcp -a api-tokens -X " + hex.EncodeToString([]byte(value)) + "\n"
+	if string(stdin) != want {
+		t.Errorf("stdin = %q, want %q", stdin, want)
+	}
+}
+
+// TestKeychainSetReportsInteractiveErrors tests that a message on stderr fails Set even though security exits 0
+func TestKeychainSetReportsInteractiveErrors(t *testing.T) {
+	store := NewKeychainSecretStore(0)
+	store.command = fakeSecurityCommand(t, t.TempDir(), "SecKeychainItemCreateFromContent: denied")
+
+	err := store.Set(context.Background(), "store-key", "secret")
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("expected the stderr message as an error, got %v", err)
+	}
+}
+
+// TestKeychainSetRejectsUnsafeNames tests that names that would break the interactive command line are refused
+func TestKeychainSetRejectsUnsafeNames(t *testing.T) {
+	store := NewKeychainSecretStore(0)
+	store.command = fakeSecurityCommand(t, t.TempDir(), "")
+
+	for _, name := range []string{"", "two words", "line\nbreak", `quo"te`} {
+		if err := store.Set(context.Background(), name, "secret"); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("name %q: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+}
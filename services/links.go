@@ -0,0 +1,234 @@
+// ABOUTME: Broken link checker that finds http(s) links in notes and reports the ones that no longer resolve
+// ABOUTME: Checks each distinct URL once with a HEAD request, a few at a time, falling back to GET when HEAD is refused
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultLinkCheckConcurrency is how many links are checked at once when none is given
+const DefaultLinkCheckConcurrency = 8
+
+// DefaultLinkCheckTimeout bounds each link check when no timeout is given
+const DefaultLinkCheckTimeout = 10 * time.Second
+
+// MaxLinkCheckNotes caps how many notes one link check reads, newest first
+const MaxLinkCheckNotes = 200
+
+// noteURLPattern matches http(s) URLs in a note body once its HTML entities are decoded
+var noteURLPattern = regexp.MustCompile(`(?i)https?://[^\s"'<>` + "`" + `]+`)
+
+// LinkChecker reports whether a URL still resolves
+type LinkChecker interface {
+	// Check returns the HTTP status the URL answered with, or an error if it couldn't be reached
+	Check(ctx context.Context, url string) (int, error)
+}
+
+// HTTPLinkChecker implements LinkChecker with net/http
+type HTTPLinkChecker struct {
+	client *http.Client
+}
+
+// NewHTTPLinkChecker creates an HTTPLinkChecker with the specified per-link timeout.
+// If timeout is 0 or negative, defaults to DefaultLinkCheckTimeout.
+func NewHTTPLinkChecker(timeout time.Duration) *HTTPLinkChecker {
+	if timeout <= 0 {
+		timeout = DefaultLinkCheckTimeout
+	}
+
+	return &HTTPLinkChecker{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Check sends a HEAD request, retrying with GET when the server doesn't allow HEAD
+func (c *HTTPLinkChecker) Check(ctx context.Context, url string) (int, error) {
+	status, err := c.request(ctx, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented || status == http.StatusForbidden) {
+		return c.request(ctx, http.MethodGet, url)
+	}
+	return status, err
+}
+
+// request sends one request and returns its status without reading the body
+func (c *HTTPLinkChecker) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "notes-mcp link checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close() //nolint:errcheck,gosec // response body close failure is non-critical
+	return resp.StatusCode, nil
+}
+
+// LinkCheckOptions selects the notes CheckNoteLinks reads and how links are checked
+type LinkCheckOptions struct {
+	Folder      string        // only notes in this folder; empty means every folder
+	Concurrency int           // links checked at once (default: DefaultLinkCheckConcurrency)
+	Timeout     time.Duration // limit for each link's check (default: DefaultLinkCheckTimeout)
+}
+
+// DeadLink is a link that didn't resolve, with the status or error it failed with
+type DeadLink struct {
+	URL    string `json:"url"`
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NoteLinks lists the dead links in one note
+type NoteLinks struct {
+	Title     string     `json:"title"`
+	Folder    string     `json:"folder,omitempty"`
+	DeadLinks []DeadLink `json:"dead_links"`
+}
+
+// LinkCheckReport is the result of checking the links in a set of notes
+type LinkCheckReport struct {
+	NotesScanned int         `json:"notes_scanned"`
+	LinksChecked int         `json:"links_checked"`
+	DeadCount    int         `json:"dead_count"`
+	Notes        []NoteLinks `json:"notes"`
+	Truncated    bool        `json:"truncated,omitempty"`
+}
+
+// CheckNoteLinks reads the notes in opts.Folder and reports the http(s) links in them that are dead
+// A link is dead when it can't be reached or answers with a 4xx or 5xx status. Each distinct URL is
+// checked once however many notes contain it. Locked notes are skipped, and at most MaxLinkCheckNotes
+// notes are read, newest first, with Truncated set when more were left out.
+func CheckNoteLinks(ctx context.Context, notes NoteReader, checker LinkChecker, opts LinkCheckOptions) (*LinkCheckReport, error) {
+	if opts.Concurrency < 0 || opts.Timeout < 0 {
+		return nil, fmt.Errorf("%w: concurrency and timeout cannot be negative", ErrInvalidInput)
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = DefaultLinkCheckConcurrency
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultLinkCheckTimeout
+	}
+
+	library, err := notes.ListNotesWithMetadata(ctx, opts.Folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check links: %w", err)
+	}
+	report := &LinkCheckReport{Notes: []NoteLinks{}}
+	readable := []Note{}
+	for _, note := range library {
+		if !note.PasswordProtected {
+			readable = append(readable, note)
+		}
+	}
+	if len(readable) > MaxLinkCheckNotes {
+		readable = readable[:MaxLinkCheckNotes]
+		report.Truncated = true
+	}
+
+	bodies := make([]string, len(readable))
+	err = forEachBounded(ctx, len(readable), DefaultConcurrency, func(ctx context.Context, i int) error {
+		body, err := notes.GetNoteContent(ctx, readable[i].Title)
+		bodies[i] = body
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check links: %w", err)
+	}
+	report.NotesScanned = len(readable)
+
+	noteURLs := make([][]string, len(readable))
+	seen := map[string]bool{}
+	urls := []string{}
+	for i, body := range bodies {
+		noteURLs[i] = ExtractNoteURLs(body)
+		for _, u := range noteURLs[i] {
+			if !seen[u] {
+				seen[u] = true
+				urls = append(urls, u)
+			}
+		}
+	}
+	report.LinksChecked = len(urls)
+
+	// Every URL gets a result; one unreachable site must not stop the others
+	results := make([]*DeadLink, len(urls))
+	err = forEachBounded(ctx, len(urls), opts.Concurrency, func(ctx context.Context, i int) error {
+		checkCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		status, err := checker.Check(checkCtx, urls[i])
+		switch {
+		case err != nil:
+			results[i] = &DeadLink{URL: urls[i], Error: err.Error()}
+		case status >= 400:
+			results[i] = &DeadLink{URL: urls[i], Status: status}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check links: %w", err)
+	}
+
+	dead := map[string]DeadLink{}
+	for _, result := range results {
+		if result != nil {
+			dead[result.URL] = *result
+		}
+	}
+	report.DeadCount = len(dead)
+	for i, note := range readable {
+		entry := NoteLinks{Title: note.Title, Folder: note.Folder, DeadLinks: []DeadLink{}}
+		for _, u := range noteURLs[i] {
+			if link, ok := dead[u]; ok {
+				entry.DeadLinks = append(entry.DeadLinks, link)
+			}
+		}
+		if len(entry.DeadLinks) > 0 {
+			report.Notes = append(report.Notes, entry)
+		}
+	}
+	return report, nil
+}
+
+// ExtractNoteURLs returns the distinct http(s) URLs in a note body, sorted
+// Both link targets and URLs written as plain text are found; trailing punctuation is not part of a URL.
+func ExtractNoteURLs(body string) []string {
+	seen := map[string]bool{}
+	urls := []string{}
+	for _, match := range noteURLPattern.FindAllString(html.UnescapeString(body), -1) {
+		u := trimURLPunctuation(match)
+		if u != "" && !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// trimURLPunctuation drops sentence punctuation after a URL, and a closing bracket with no opening one
+func trimURLPunctuation(u string) string {
+	for len(u) > 0 {
+		last := u[len(u)-1]
+		switch {
+		case strings.ContainsRune(".,;:!?'", rune(last)):
+			u = u[:len(u)-1]
+		case last == ')' && strings.Count(u, "(") < strings.Count(u, ")"):
+			u = u[:len(u)-1]
+		case last == ']' && strings.Count(u, "[") < strings.Count(u, "]"):
+			u = u[:len(u)-1]
+		default:
+			return u
+		}
+	}
+	return u
+}
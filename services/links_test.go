@@ -0,0 +1,125 @@
+// ABOUTME: Unit tests for the broken link checker
+// ABOUTME: Tests URL extraction, per-note dead link reports, and the HEAD-then-GET HTTP check
+
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// fakeLinkChecker answers from a map of URL to status; URLs missing from it are unreachable
+type fakeLinkChecker struct {
+	mu       sync.Mutex
+	statuses map[string]int
+	checked  map[string]int
+}
+
+func (f *fakeLinkChecker) Check(ctx context.Context, url string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checked[url]++
+	if status, ok := f.statuses[url]; ok {
+		return status, nil
+	}
+	return 0, errors.New("no such host")
+}
+
+func TestExtractNoteURLs(t *testing.T) {
+	body := `<div>See <a href="https://example.com/a?x=1&amp;y=2">the paper</a>.</div>` +
+		`<div>Also https://example.com/b, and (https://en.wikipedia.org/wiki/Go_(language)).</div>` +
+		`<div>Repeat: https://example.com/b. Not a link: ftp://example.com/c</div>`
+
+	got := ExtractNoteURLs(body)
+	want := []string{
+		"https://en.wikipedia.org/wiki/Go_(language)",
+		"https://example.com/a?x=1&y=2",
+		"https://example.com/b",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractNoteURLs() = %v, want %v", got, want)
+	}
+	if got := ExtractNoteURLs("<div>no links here</div>"); len(got) != 0 {
+		t.Errorf("expected no URLs, got %v", got)
+	}
+}
+
+func TestCheckNoteLinks(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	for title, body := range map[string]string{
+		"Research": `<div><a href="https://good.example/paper">paper</a> https://gone.example/old</div>`,
+		"Reading":  `<div>https://gone.example/old and https://down.example/</div>`,
+		"Plain":    `<div>https://good.example/paper</div>`,
+	} {
+		if _, err := service.CreateNote(ctx, title, body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	checker := &fakeLinkChecker{
+		statuses: map[string]int{"https://good.example/paper": 200, "https://gone.example/old": 404},
+		checked:  map[string]int{},
+	}
+	report, err := CheckNoteLinks(ctx, service, checker, LinkCheckOptions{})
+	if err != nil {
+		t.Fatalf("CheckNoteLinks failed: %v", err)
+	}
+	if report.NotesScanned != 3 || report.LinksChecked != 3 || report.DeadCount != 2 {
+		t.Errorf("unexpected counts %+v", report)
+	}
+	for url, count := range checker.checked {
+		if count != 1 {
+			t.Errorf("expected %s checked once, got %d", url, count)
+		}
+	}
+
+	dead := map[string][]DeadLink{}
+	for _, note := range report.Notes {
+		dead[note.Title] = note.DeadLinks
+	}
+	if len(dead) != 2 || dead["Plain"] != nil {
+		t.Fatalf("expected dead links in Research and Reading only, got %+v", report.Notes)
+	}
+	if links := dead["Research"]; len(links) != 1 || links[0].Status != 404 {
+		t.Errorf("expected the 404 in Research, got %+v", links)
+	}
+	if links := dead["Reading"]; len(links) != 2 || links[0].URL != "https://down.example/" || links[0].Error == "" {
+		t.Errorf("expected the unreachable link first in Reading, got %+v", links)
+	}
+
+	if _, err := CheckNoteLinks(ctx, service, checker, LinkCheckOptions{Concurrency: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for negative concurrency, got %v", err)
+	}
+}
+
+func TestHTTPLinkChecker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewHTTPLinkChecker(0)
+	for path, want := range map[string]int{"/ok": 200, "/no-head": 200, "/missing": 404} {
+		status, err := checker.Check(context.Background(), server.URL+path)
+		if err != nil || status != want {
+			t.Errorf("Check(%s) = %d, %v; want %d", path, status, err, want)
+		}
+	}
+}
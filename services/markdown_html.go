@@ -0,0 +1,132 @@
+// ABOUTME: Markdown to Apple Notes HTML conversion for notes written by agents and the CLI
+// ABOUTME: Covers headings, lists, checkboxes, quotes, code, and inline emphasis and links
+
+package services
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeadingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdCheckboxPattern = regexp.MustCompile(`^[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	mdBulletPattern   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	mdOrderedPattern  = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	mdQuotePattern    = regexp.MustCompile(`^>\s?(.*)$`)
+	mdLinkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldPattern     = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdItalicPattern   = regexp.MustCompile(`\*([^*\s][^*]*?)\*|\b_([^_\s][^_]*?)_\b`)
+	mdStrikePattern   = regexp.MustCompile(`~~(.+?)~~`)
+)
+
+// MarkdownToHTML converts markdown to the HTML Apple Notes stores, one <div> per line
+// Headings deeper than ### become <h3>, the deepest Notes shows, and "- [ ]" tasks become
+// ☐/☑ list items, which ExtractActionItems recognizes. The result has no newlines, so it
+// passes through CreateNote and UpdateNote without gaining line breaks.
+func MarkdownToHTML(markdown string) string {
+	var b strings.Builder
+	list := "" // "ul" or "ol" while inside a list
+	setList := func(tag string) {
+		if list == tag {
+			return
+		}
+		if list != "" {
+			b.WriteString("</" + list + ">")
+		}
+		if tag != "" {
+			b.WriteString("<" + tag + ">")
+		}
+		list = tag
+	}
+
+	fenced := false
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			setList("")
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			if trimmed == "" {
+				b.WriteString("<div><br></div>")
+			} else {
+				b.WriteString("<div><tt>" + html.EscapeString(strings.TrimRight(line, " \t")) + "</tt></div>")
+			}
+			continue
+		}
+
+		if m := mdCheckboxPattern.FindStringSubmatch(trimmed); m != nil {
+			box := "☐ "
+			if m[1] != " " {
+				box = "☑ "
+			}
+			setList("ul")
+			b.WriteString("<li>" + box + markdownInline(m[2]) + "</li>")
+			continue
+		}
+		if m := mdBulletPattern.FindStringSubmatch(trimmed); m != nil {
+			setList("ul")
+			b.WriteString("<li>" + markdownInline(m[1]) + "</li>")
+			continue
+		}
+		if m := mdOrderedPattern.FindStringSubmatch(trimmed); m != nil {
+			setList("ol")
+			b.WriteString("<li>" + markdownInline(m[1]) + "</li>")
+			continue
+		}
+
+		setList("")
+		switch m := mdHeadingPattern.FindStringSubmatch(trimmed); {
+		case m != nil:
+			tag := "h" + string(rune('0'+min(len(m[1]), 3)))
+			b.WriteString("<" + tag + ">" + markdownInline(m[2]) + "</" + tag + ">")
+		case mdQuotePattern.MatchString(trimmed):
+			b.WriteString("<blockquote>" + markdownInline(mdQuotePattern.FindStringSubmatch(trimmed)[1]) + "</blockquote>")
+		case trimmed == "":
+			b.WriteString("<div><br></div>")
+		default:
+			b.WriteString("<div>" + markdownInline(trimmed) + "</div>")
+		}
+	}
+	setList("")
+
+	// Trailing blank lines would show as empty lines at the end of the note
+	result := b.String()
+	for strings.HasSuffix(result, "<div><br></div>") {
+		result = strings.TrimSuffix(result, "<div><br></div>")
+	}
+	return result
+}
+
+// markdownInline converts inline markdown in one line of text to HTML
+// Code spans are kept literal; links are kept only for http(s) and mailto targets.
+func markdownInline(text string) string {
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		escaped := html.EscapeString(part)
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = "<tt>" + escaped + "</tt>"
+			continue
+		}
+		if i%2 == 1 {
+			// An unmatched backtick stays as text
+			escaped = "`" + escaped
+		}
+
+		escaped = mdLinkPattern.ReplaceAllStringFunc(escaped, func(link string) string {
+			m := mdLinkPattern.FindStringSubmatch(link)
+			if href := safeHref(html.UnescapeString(m[2])); href != "" {
+				return `<a href="` + html.EscapeString(href) + `">` + m[1] + "</a>"
+			}
+			return m[1]
+		})
+		escaped = mdBoldPattern.ReplaceAllString(escaped, "<b>$1$2</b>")
+		escaped = mdItalicPattern.ReplaceAllString(escaped, "<i>$1$2</i>")
+		escaped = mdStrikePattern.ReplaceAllString(escaped, "<s>$1</s>")
+		parts[i] = escaped
+	}
+	return strings.Join(parts, "")
+}
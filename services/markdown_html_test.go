@@ -0,0 +1,85 @@
+// ABOUTME: Unit tests for markdown to Notes HTML conversion and content type handling
+// ABOUTME: Covers block and inline markdown, escaping, and FormatNoteBody for each content type
+
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "headings and paragraphs",
+			markdown: "# Plan\n\nShip **v2** by *Friday*\n#### Details",
+			want:     "<h1>Plan</h1><div><br></div><div>Ship <b>v2</b> by <i>Friday</i></div><h3>Details</h3>",
+		},
+		{
+			name:     "lists and checkboxes",
+			markdown: "- milk\n- [ ] call Sam\n- [x] book room\n1. first\n2. second",
+			want:     "<ul><li>milk</li><li>☐ call Sam</li><li>☑ book room</li></ul><ol><li>first</li><li>second</li></ol>",
+		},
+		{
+			name:     "quotes, code, and links",
+			markdown: "> quoted\nRun `go <test>` and see [docs](https://go.dev/?a=1&b=2) or [bad](javascript:void)",
+			want: `<blockquote>quoted</blockquote><div>Run <tt>go &lt;test&gt;</tt> and see ` +
+				`<a href="https://go.dev/?a=1&amp;b=2">docs</a> or bad</div>`,
+		},
+		{
+			name:     "fenced code is literal",
+			markdown: "```\nif a < b {\n\n  **x**\n```\n\n\n",
+			want:     "<div><tt>if a &lt; b {</tt></div><div><br></div><div><tt>  **x**</tt></div>",
+		},
+		{
+			name:     "html is escaped",
+			markdown: `<script>alert("hi")</script> ~~old~~`,
+			want:     "<div>&lt;script&gt;alert(&#34;hi&#34;)&lt;/script&gt; <s>old</s></div>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MarkdownToHTML(tt.markdown)
+			if got != tt.want {
+				t.Errorf("MarkdownToHTML() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if strings.Contains(got, "\n") {
+				t.Error("output should not contain newlines")
+			}
+		})
+	}
+}
+
+func TestMarkdownChecklistFeedsActionItems(t *testing.T) {
+	items := parseActionItems(MarkdownToHTML("- [ ] send invoice\n- [x] file taxes"), "Todo")
+	if len(items) != 2 || items[0].Text != "send invoice" || items[0].Done || !items[1].Done {
+		t.Errorf("unexpected action items: %+v", items)
+	}
+}
+
+func TestFormatNoteBody(t *testing.T) {
+	plain := "line one\nline <two>"
+	if got, err := FormatNoteBody(plain, ""); err != nil || got != plain {
+		t.Errorf("plain content should pass through unchanged, got %q, %v", got, err)
+	}
+	if got, _ := FormatNoteBody("**hi**", "Markdown"); got != "<div><b>hi</b></div>" {
+		t.Errorf("markdown = %q", got)
+	}
+
+	got, err := FormatNoteBody("<p class=\"x\">Hello</p>\n<script>bad()</script>\n<p>World</p>", ContentTypeHTML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "<div>Hello</div> <div>World</div>" {
+		t.Errorf("html = %q", got)
+	}
+
+	if _, err := FormatNoteBody("x", "rtf"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown type, got %v", err)
+	}
+}
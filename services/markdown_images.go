@@ -0,0 +1,198 @@
+// ABOUTME: Image handling for markdown exports: data URIs, copied image files, or attachment placeholders
+// ABOUTME: Rewrites <img> sources before conversion so images become ![name](target) instead of vanishing
+
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// How markdown exports represent images
+const (
+	// MarkdownImagesPlaceholder links each image as attachment:<name>, keeping the export small
+	MarkdownImagesPlaceholder = "placeholder"
+	// MarkdownImagesDataURI inlines each image as a base64 data URI
+	MarkdownImagesDataURI = "data"
+	// MarkdownImagesFiles copies each image into an assets directory and links it by relative path
+	MarkdownImagesFiles = "files"
+)
+
+// MarkdownExportOptions controls ExportNoteMarkdownWithOptions
+type MarkdownExportOptions struct {
+	// Images is MarkdownImagesPlaceholder (the default when empty), MarkdownImagesDataURI, or MarkdownImagesFiles
+	Images string
+	// AssetsDir receives image files with MarkdownImagesFiles; links use the path as given
+	AssetsDir string
+}
+
+// placeholderScheme prefixes image links that only name an attachment
+const placeholderScheme = "attachment:"
+
+var (
+	imgTagPattern      = regexp.MustCompile(`(?is)<img\b[^>]*>`)
+	imgAltPattern      = regexp.MustCompile(`(?is)\balt\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	dataURIPattern     = regexp.MustCompile(`(?is)^data:([a-z0-9.+-]+/[a-z0-9.+-]+)?(;[^,]*)?,(.*)$`)
+	markdownURLEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+)
+
+// validate checks the image mode and that MarkdownImagesFiles has somewhere to write
+func (o MarkdownExportOptions) validate() error {
+	switch o.Images {
+	case "", MarkdownImagesPlaceholder, MarkdownImagesDataURI:
+		return nil
+	case MarkdownImagesFiles:
+		if o.AssetsDir == "" {
+			return fmt.Errorf("%w: an assets directory is required to export images as files", ErrInvalidInput)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: images must be %q, %q, or %q",
+		ErrInvalidInput, MarkdownImagesPlaceholder, MarkdownImagesDataURI, MarkdownImagesFiles)
+}
+
+// resolveMarkdownImages rewrites the <img> sources in body for opts.Images
+// Images that refer to an attachment are read through readFile (nil when attachments can't be
+// read); inline data URIs are kept, written out, or replaced by a numbered placeholder. An image
+// that can't be inlined or copied falls back to its placeholder, and remote images are left alone.
+func resolveMarkdownImages(body string, attachments []Attachment, opts MarkdownExportOptions,
+	readFile func(filePath string) ([]byte, error)) (string, error) {
+
+	count := 0
+	var failure error
+	namer := NewFileNamer()
+	fileNames := make(map[string]string)
+	body = imgSrcPattern.ReplaceAllStringFunc(body, func(tag string) string {
+		parts := imgSrcPattern.FindStringSubmatch(tag)
+		src := html.UnescapeString(parts[3])
+		count++
+
+		var name, target string
+		var data []byte
+		mimeType := ""
+		if matches := dataURIPattern.FindStringSubmatch(src); matches != nil {
+			mimeType = strings.ToLower(matches[1])
+			name = fmt.Sprintf("image-%d%s", count, imageExtension(mimeType))
+			if opts.Images == MarkdownImagesDataURI {
+				return tag
+			}
+			if opts.Images == MarkdownImagesFiles && strings.Contains(strings.ToLower(matches[2]), "base64") {
+				data, _ = base64.StdEncoding.DecodeString(matches[3]) //nolint:errcheck // undecodable images fall back to a placeholder
+			}
+		} else if attachment := findReferencedAttachment(src, attachments); attachment != nil {
+			name = filepath.Base(attachment.Name)
+			mimeType = embeddedImageTypes[strings.ToLower(filepath.Ext(attachment.FilePath))]
+			if opts.Images != "" && opts.Images != MarkdownImagesPlaceholder && readFile != nil && attachment.FilePath != "" {
+				data, _ = readFile(attachment.FilePath) //nolint:errcheck // unreadable images fall back to a placeholder
+			}
+		} else {
+			return tag
+		}
+
+		switch {
+		case opts.Images == MarkdownImagesDataURI && len(data) > 0 && mimeType != "":
+			target = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+		case opts.Images == MarkdownImagesFiles && len(data) > 0:
+			// Each distinct image gets its own safe file name; repeats link the same file
+			fileName, ok := fileNames[src]
+			if !ok {
+				fileName = namer.FileName(name)
+				fileNames[src] = fileName
+			}
+			dest := filepath.Join(opts.AssetsDir, fileName)
+			if err := os.MkdirAll(opts.AssetsDir, 0o750); err != nil && failure == nil {
+				failure = err
+			}
+			if err := os.WriteFile(dest, data, 0o600); err != nil && failure == nil { // #nosec G306 - the caller's export directory
+				failure = err
+			}
+			target = filepath.ToSlash(dest)
+		default:
+			target = placeholderScheme + name
+		}
+		return parts[1] + parts[2] + html.EscapeString(target) + parts[4]
+	})
+	if failure != nil {
+		return "", fmt.Errorf("failed to write image: %w", failure)
+	}
+	return body, nil
+}
+
+// imageExtension returns a file extension for an image MIME type
+func imageExtension(mimeType string) string {
+	for ext, known := range embeddedImageTypes {
+		if known == mimeType && ext != ".jpeg" && ext != ".tif" {
+			return ext
+		}
+	}
+	if exts, err := mime.ExtensionsByType(mimeType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ".img"
+}
+
+// markdownImage converts an <img> tag to ![alt](target); tags without a source are dropped
+// The alt text falls back to the placeholder or file name, and spaces, parentheses, and angle
+// brackets in the target are percent-encoded so the link stays intact.
+func markdownImage(tag string) string {
+	parts := imgSrcPattern.FindStringSubmatch(tag)
+	if parts == nil {
+		return ""
+	}
+	target := html.UnescapeString(parts[3])
+	if target == "" {
+		return ""
+	}
+
+	alt := ""
+	if matches := imgAltPattern.FindStringSubmatch(tag); matches != nil {
+		alt = strings.TrimSpace(html.UnescapeString(matches[1] + matches[2]))
+	}
+	if alt == "" && !strings.HasPrefix(strings.ToLower(target), "data:") {
+		alt = path.Base(strings.TrimPrefix(target, placeholderScheme))
+	}
+	if alt == "" {
+		alt = "image"
+	}
+
+	if !strings.HasPrefix(strings.ToLower(target), "data:") {
+		target = markdownURLEscaper.Replace(target)
+	}
+	return "![" + strings.NewReplacer("[", `\[`, "]", `\]`).Replace(alt) + "](" + target + ")"
+}
+
+// ExportNoteMarkdownWithOptions exports a note as markdown with images handled as opts asks
+// With MarkdownImagesDataURI, attachments are read up to maxEmbeddedImageSize each.
+func (s *AppleNotesService) ExportNoteMarkdownWithOptions(ctx context.Context, noteTitle string, opts MarkdownExportOptions) (string, error) {
+	if err := opts.validate(); err != nil {
+		return "", fmt.Errorf("failed to export note as markdown: %w", err)
+	}
+
+	htmlBody, err := s.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to export note as markdown: %w", err)
+	}
+
+	if imgSrcPattern.MatchString(htmlBody) {
+		attachments, err := s.GetNoteAttachments(ctx, noteTitle)
+		if err != nil {
+			return "", fmt.Errorf("failed to export note as markdown: %w", err)
+		}
+		readFile := func(filePath string) ([]byte, error) {
+			return s.GetAttachmentContent(ctx, filePath, maxEmbeddedImageSize)
+		}
+		if htmlBody, err = resolveMarkdownImages(htmlBody, attachments, opts, readFile); err != nil {
+			return "", fmt.Errorf("failed to export note as markdown: %w", err)
+		}
+	}
+
+	return convertHTMLToMarkdown(htmlBody), nil
+}
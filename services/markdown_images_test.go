@@ -0,0 +1,119 @@
+// ABOUTME: Unit tests for images in markdown exports
+// ABOUTME: Covers placeholder links, inlined data URIs, copied image files, and invalid options
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newMarkdownImageService(t *testing.T) (*AppleNotesService, string) {
+	t.Helper()
+	dir := t.TempDir()
+	photo := filepath.Join(dir, "photo.png")
+	if err := os.WriteFile(photo, []byte("img"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `<div><h1>Moodboard</h1></div>` +
+		`<div><img src="cid:ABC-123" alt="Front door"></div>` +
+		`<div><img src="data:image/gif;base64,R0lG"></div>` +
+		`<div><img src="https://example.com/remote.png"></div>`
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: body}, // GetNoteContent
+			{stdout: attachmentRecord("att-1", "photo.png", "abc-123", photo, "", "")}, // GetNoteAttachments
+		},
+	}
+	service := NewAppleNotesService(executor)
+	service.containerDir = dir
+	return service, dir
+}
+
+func TestExportNoteMarkdownImagePlaceholders(t *testing.T) {
+	service, _ := newMarkdownImageService(t)
+
+	markdown, err := service.ExportNoteMarkdown(context.Background(), "Moodboard")
+	if err != nil {
+		t.Fatalf("ExportNoteMarkdown failed: %v", err)
+	}
+	for _, want := range []string{
+		"![Front door](attachment:photo.png)",
+		"![image-2.gif](attachment:image-2.gif)",
+		"![remote.png](https://example.com/remote.png)",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected markdown to contain %q\n%s", want, markdown)
+		}
+	}
+}
+
+func TestExportNoteMarkdownImageDataURIs(t *testing.T) {
+	service, _ := newMarkdownImageService(t)
+
+	markdown, err := service.ExportNoteMarkdownWithOptions(context.Background(), "Moodboard",
+		MarkdownExportOptions{Images: MarkdownImagesDataURI})
+	if err != nil {
+		t.Fatalf("ExportNoteMarkdownWithOptions failed: %v", err)
+	}
+	for _, want := range []string{
+		"![Front door](data:image/png;base64,aW1n)",
+		"![image](data:image/gif;base64,R0lG)",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected markdown to contain %q\n%s", want, markdown)
+		}
+	}
+}
+
+func TestExportNoteMarkdownImageFiles(t *testing.T) {
+	service, _ := newMarkdownImageService(t)
+	assets := filepath.Join(t.TempDir(), "my assets")
+
+	markdown, err := service.ExportNoteMarkdownWithOptions(context.Background(), "Moodboard",
+		MarkdownExportOptions{Images: MarkdownImagesFiles, AssetsDir: assets})
+	if err != nil {
+		t.Fatalf("ExportNoteMarkdownWithOptions failed: %v", err)
+	}
+
+	for name, want := range map[string]string{"photo.png": "img", "image-2.gif": "GIF"} {
+		data, err := os.ReadFile(filepath.Join(assets, name))
+		if err != nil || !strings.HasPrefix(string(data), want) {
+			t.Errorf("expected %s to be written, got %q, %v", name, data, err)
+		}
+		link := "](" + strings.ReplaceAll(filepath.ToSlash(filepath.Join(assets, name)), " ", "%20") + ")"
+		if !strings.Contains(markdown, link) {
+			t.Errorf("expected markdown to link %s as %q\n%s", name, link, markdown)
+		}
+	}
+}
+
+func TestMarkdownExportOptionsValidate(t *testing.T) {
+	for _, opts := range []MarkdownExportOptions{{Images: "inline"}, {Images: MarkdownImagesFiles}} {
+		if err := opts.validate(); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("validate(%+v) = %v, want ErrInvalidInput", opts, err)
+		}
+	}
+}
+
+func TestMarkdownImage(t *testing.T) {
+	tests := map[string]string{
+		`<img src="a.png" alt="x [1]">`:     `![x \[1\]](a.png)`,
+		`<img src="attachment:Scan 1.jpg">`: `![Scan 1.jpg](attachment:Scan%201.jpg)`,
+		`<img alt="no source">`:             "",
+	}
+	for tag, want := range tests {
+		if got := markdownImage(tag); got != want {
+			t.Errorf("markdownImage(%s) = %q, want %q", tag, got, want)
+		}
+	}
+}
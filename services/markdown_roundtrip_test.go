@@ -0,0 +1,146 @@
+// ABOUTME: Property tests that markdown survives conversion to Notes HTML and back
+// ABOUTME: Generates documents of headings, lists, links, and paragraphs and compares their structure after a round trip
+
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// roundTripWords are the words generated documents are made of, including characters HTML escapes
+var roundTripWords = []string{
+	"plan", "ship", "Friday", "notes", "R&D", "Q3", "a<b", "café", "日本", "budget", "42", "x-ray", `"quoted"`, "it's",
+}
+
+// mdBlock is one block of a generated document
+type mdBlock struct {
+	kind  string // "heading", "bullets", "ordered", or "paragraph"
+	level int    // heading level, 1-6
+	lines []string
+}
+
+// markdownDocument is a generated markdown document with the structure a round trip must keep
+type markdownDocument struct {
+	blocks []mdBlock
+}
+
+// Generate implements quick.Generator with documents of up to size blocks
+func (markdownDocument) Generate(r *rand.Rand, size int) reflect.Value {
+	doc := markdownDocument{}
+	for i := 0; i < 1+r.Intn(max(size/10, 1)+3); i++ {
+		block := mdBlock{kind: []string{"heading", "bullets", "ordered", "paragraph"}[r.Intn(4)], level: 1 + r.Intn(6)}
+		lines := 1
+		if block.kind == "bullets" || block.kind == "ordered" {
+			lines += r.Intn(4)
+		}
+		for j := 0; j < lines; j++ {
+			block.lines = append(block.lines, roundTripText(r))
+		}
+		doc.blocks = append(doc.blocks, block)
+	}
+	return reflect.ValueOf(doc)
+}
+
+// roundTripText generates a line of words, sometimes with a link or bold words
+func roundTripText(r *rand.Rand) string {
+	words := make([]string, 1+r.Intn(5))
+	for i := range words {
+		words[i] = roundTripWords[r.Intn(len(roundTripWords))]
+	}
+	switch r.Intn(4) {
+	case 0:
+		words = append(words, fmt.Sprintf("[%s](https://example.com/%d?a=1&b=2)", roundTripWords[r.Intn(len(roundTripWords))], r.Intn(100)))
+	case 1:
+		words[0] = "**" + words[0] + "**"
+	}
+	return strings.Join(words, " ")
+}
+
+// Markdown renders the document, separating blocks with a blank line
+func (d markdownDocument) Markdown() string {
+	var parts []string
+	for _, block := range d.blocks {
+		var lines []string
+		for i, line := range block.lines {
+			switch block.kind {
+			case "heading":
+				lines = append(lines, strings.Repeat("#", block.level)+" "+line)
+			case "bullets":
+				lines = append(lines, "- "+line)
+			case "ordered":
+				lines = append(lines, fmt.Sprintf("%d. %s", i+1, line))
+			default:
+				lines = append(lines, line)
+			}
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Semantics lists what a round trip must keep: headings with their level (Notes shows at most
+// three), list items, and other lines, in order; ordered lists may come back as bullets
+func (d markdownDocument) Semantics() []string {
+	var semantics []string
+	for _, block := range d.blocks {
+		for _, line := range block.lines {
+			switch block.kind {
+			case "heading":
+				semantics = append(semantics, fmt.Sprintf("h%d %s", min(block.level, 3), line))
+			case "bullets", "ordered":
+				semantics = append(semantics, "li "+line)
+			default:
+				semantics = append(semantics, "p "+line)
+			}
+		}
+	}
+	return semantics
+}
+
+var (
+	roundTripHeading = regexp.MustCompile(`^(#{1,6}) (.*)$`)
+	roundTripItem    = regexp.MustCompile(`^(?:[-*+]|\d+[.)]) (.*)$`)
+)
+
+// markdownSemantics reads the headings, list items, and other lines of converted markdown
+func markdownSemantics(markdown string) []string {
+	semantics := []string{}
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		switch m := roundTripHeading.FindStringSubmatch(line); {
+		case line == "":
+		case m != nil:
+			semantics = append(semantics, fmt.Sprintf("h%d %s", len(m[1]), m[2]))
+		case roundTripItem.MatchString(line):
+			semantics = append(semantics, "li "+roundTripItem.FindStringSubmatch(line)[1])
+		default:
+			semantics = append(semantics, "p "+line)
+		}
+	}
+	return semantics
+}
+
+// TestMarkdownRoundTripProperty checks that markdown→HTML→markdown keeps each document's headings, lists, and links
+func TestMarkdownRoundTripProperty(t *testing.T) {
+	property := func(doc markdownDocument) bool {
+		got := markdownSemantics(convertHTMLToMarkdown(MarkdownToHTML(doc.Markdown())))
+		return reflect.DeepEqual(got, doc.Semantics())
+	}
+
+	config := &quick.Config{MaxCount: 500, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(property, config); err != nil {
+		if failure, ok := err.(*quick.CheckError); ok {
+			doc := failure.In[0].(markdownDocument)
+			got := convertHTMLToMarkdown(MarkdownToHTML(doc.Markdown()))
+			t.Fatalf("round trip changed the document after %d cases\nmarkdown:\n%s\nround trip:\n%s\nwant %q\ngot  %q",
+				failure.Count, doc.Markdown(), got, doc.Semantics(), markdownSemantics(got))
+		}
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,768 @@
+// ABOUTME: In-memory NotesService used by the "memory" provider, for demos and tests without Notes.app
+// ABOUTME: Notes and folders live only as long as the process; Apple-only features return ErrNotSupported
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMemoryFolder is the folder new in-memory notes are created in, like Notes.app's "Notes"
+const defaultMemoryFolder = "Notes"
+
+// memoryAccount names the root of the in-memory folder hierarchy
+const memoryAccount = "Memory"
+
+// MemoryNotesService implements NotesService with notes held in memory
+type MemoryNotesService struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	nextID  int
+	notes   []*Note
+	folders []string
+	parents map[string]string // folder name to parent folder name, "" for top-level folders
+}
+
+// NewMemoryNotesService creates an empty in-memory store with a single "Notes" folder
+func NewMemoryNotesService() *MemoryNotesService {
+	return &MemoryNotesService{
+		now:     time.Now,
+		folders: []string{defaultMemoryFolder},
+		parents: map[string]string{defaultMemoryFolder: ""},
+	}
+}
+
+// findLocked returns the note with the given title; like AppleScript, titles match case-insensitively
+func (m *MemoryNotesService) findLocked(title string) (*Note, error) {
+	i, err := m.indexLocked(title)
+	if err != nil {
+		return nil, err
+	}
+	return m.notes[i], nil
+}
+
+// readLocked is findLocked for reads: when no title matches exactly, a single title differing only
+// in punctuation matches instead and is reported through ctx
+func (m *MemoryNotesService) readLocked(ctx context.Context, title string) (*Note, error) {
+	note, err := m.findLocked(title)
+	if !errors.Is(err, ErrNoteNotFound) {
+		return note, err
+	}
+
+	titles := make([]string, len(m.notes))
+	for i, candidate := range m.notes {
+		titles[i] = candidate.Title
+	}
+	match, ok := matchTitleTolerantly(title, titles)
+	if !ok {
+		return nil, err
+	}
+	reportTitleMatch(ctx, title, match)
+	return m.findLocked(match)
+}
+
+// indexLocked returns the position of the note with the given title, compared in NFC
+func (m *MemoryNotesService) indexLocked(title string) (int, error) {
+	title = normalizeTitle(title)
+	for i, note := range m.notes {
+		if strings.EqualFold(normalizeTitle(note.Title), title) {
+			return i, nil
+		}
+	}
+	return -1, m.noteNotFoundLocked(title, fmt.Errorf("%w: %q", ErrNoteNotFound, title))
+}
+
+// noteNotFoundLocked wraps a note-not-found error with close titles; callers hold m.mu
+func (m *MemoryNotesService) noteNotFoundLocked(title string, err error) error {
+	titles := make([]string, 0, len(m.notes))
+	for _, note := range m.notes {
+		titles = append(titles, note.Title)
+	}
+	return &NoteNotFoundError{Title: title, Suggestions: suggestTitles(title, titles), Err: err}
+}
+
+// hasFolderLocked reports whether a folder exists
+func (m *MemoryNotesService) hasFolderLocked(name string) bool {
+	_, ok := m.parents[name]
+	return ok
+}
+
+// touchLocked marks a note as modified now
+func (m *MemoryNotesService) touchLocked(note *Note) {
+	now := m.now()
+	note.Modified = now
+	note.ModificationDate = now
+}
+
+// listing returns a copy of a note for list results, without its body
+func listing(note *Note) Note {
+	listed := *note
+	listed.Content = ""
+	listed.Tags = append([]string{}, note.Tags...)
+	return listed
+}
+
+// filterLocked returns listings of the notes keep accepts, in creation order
+func (m *MemoryNotesService) filterLocked(keep func(note *Note) bool) []Note {
+	notes := []Note{}
+	for _, note := range m.notes {
+		if keep(note) {
+			notes = append(notes, listing(note))
+		}
+	}
+	return notes
+}
+
+// newestFirst sorts notes by modification date, newest first
+func newestFirst(notes []Note) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].ModificationDate.After(notes[j].ModificationDate)
+	})
+}
+
+// CreateNote adds a note to the "Notes" folder; newlines in content become <br> as with Notes.app
+func (m *MemoryNotesService) CreateNote(ctx context.Context, title, content string, tags []string) (*Note, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	title = normalizeTitle(expandTitleTemplate(ctx, title, now, TitleFormats{}))
+	if strings.TrimSpace(title) == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+
+	body := content
+	if !isHTMLBody(ctx) {
+		body = strings.ReplaceAll(content, "\n", "<br>")
+	}
+
+	m.nextID++
+	note := &Note{
+		ID:               fmt.Sprintf("memory://note/%d", m.nextID),
+		Title:            title,
+		Content:          body,
+		Tags:             append([]string{}, tags...),
+		Created:          now,
+		Modified:         now,
+		CreationDate:     now,
+		ModificationDate: now,
+		Folder:           defaultMemoryFolder,
+	}
+	m.notes = append(m.notes, note)
+
+	created := *note
+	created.Content = content
+	return &created, nil
+}
+
+// SearchNotes finds notes whose title contains query, case-insensitively
+func (m *MemoryNotesService) SearchNotes(ctx context.Context, query string) ([]Note, error) {
+	return m.SearchNotesAdvanced(ctx, SearchOptions{Query: query, SearchIn: SearchInTitle})
+}
+
+// SearchNotesAdvanced matches the query against titles, bodies, or both, then applies the filters
+// Memory notes have no attachments, so that filter matches nothing; the shared and locked filters
+// only match notes loaded from a store that has them, as the read-only providers do.
+func (m *MemoryNotesService) SearchNotesAdvanced(ctx context.Context, opts SearchOptions) ([]Note, error) {
+	query := strings.ToLower(opts.Query)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if opts.Folder != "" && !m.hasFolderLocked(opts.Folder) {
+		return []Note{}, m.folderNotFoundLocked("failed to search notes", opts.Folder)
+	}
+
+	return m.filterLocked(func(note *Note) bool {
+		body := note.Content
+		if !opts.MatchHTML {
+			body = stripHTML(lineBreakPattern.ReplaceAllString(body, " "))
+		}
+
+		var matched bool
+		switch opts.SearchIn {
+		case SearchInBody:
+			matched = strings.Contains(strings.ToLower(body), query)
+		case SearchInBoth:
+			matched = strings.Contains(strings.ToLower(note.Title), query) || strings.Contains(strings.ToLower(body), query)
+		default:
+			matched = strings.Contains(strings.ToLower(note.Title), query)
+		}
+
+		switch {
+		case !matched:
+			return false
+		case opts.Folder != "" && note.Folder != opts.Folder:
+			return false
+		case opts.DateFrom != nil && note.ModificationDate.Before(*opts.DateFrom):
+			return false
+		case opts.DateTo != nil && note.ModificationDate.After(*opts.DateTo):
+			return false
+		case opts.HasAttachments:
+			return false
+		case opts.Shared && !note.Shared, opts.Locked && !note.PasswordProtected:
+			return false
+		case opts.HasChecklist && len(parseActionItems(note.Content, "")) == 0:
+			return false
+		}
+		for _, excluded := range opts.ExcludeFolders {
+			if note.Folder == excluded {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+// GetNoteContent returns a note's HTML body
+func (m *MemoryNotesService) GetNoteContent(ctx context.Context, title string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	note, err := m.readLocked(ctx, title)
+	if err != nil {
+		return "", fmt.Errorf("failed to get note content: %w", err)
+	}
+	return note.Content, nil
+}
+
+// GetNoteMetadata returns a note's metadata without its body
+func (m *MemoryNotesService) GetNoteMetadata(ctx context.Context, title string) (*Note, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	note, err := m.readLocked(ctx, title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note metadata: %w", err)
+	}
+	metadata := listing(note)
+	return &metadata, nil
+}
+
+// GetNoteTitleByID returns the title of the note with the given ID
+func (m *MemoryNotesService) GetNoteTitleByID(ctx context.Context, noteID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, note := range m.notes {
+		if note.ID == noteID {
+			return note.Title, nil
+		}
+	}
+	return "", fmt.Errorf("failed to look up note by ID: %w", ErrNoteNotFound)
+}
+
+// GetNoteContentByID returns the body of the note with the given ID
+func (m *MemoryNotesService) GetNoteContentByID(ctx context.Context, noteID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, note := range m.notes {
+		if note.ID == noteID {
+			return note.Content, nil
+		}
+	}
+	return "", fmt.Errorf("failed to get note content by ID: %w", ErrNoteNotFound)
+}
+
+// UpdateNoteByID replaces the body of the note with the given ID
+func (m *MemoryNotesService) UpdateNoteByID(ctx context.Context, noteID, content string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, note := range m.notes {
+		if note.ID == noteID {
+			note.Content = content
+			m.touchLocked(note)
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to update note by ID: %w", ErrNoteNotFound)
+}
+
+// DeleteNoteByID removes the note with the given ID
+func (m *MemoryNotesService) DeleteNoteByID(ctx context.Context, noteID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, note := range m.notes {
+		if note.ID == noteID {
+			m.notes = append(m.notes[:i], m.notes[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to delete note by ID: %w", ErrNoteNotFound)
+}
+
+// UpdateNote replaces a note's body
+func (m *MemoryNotesService) UpdateNote(ctx context.Context, title, content string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	note, err := m.findLocked(title)
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+	note.Content = content
+	m.touchLocked(note)
+	return nil
+}
+
+// HasNoteChanged compares a note's body against a hash from an earlier read
+func (m *MemoryNotesService) HasNoteChanged(ctx context.Context, title, hash string) (bool, string, error) {
+	body, err := m.GetNoteContent(ctx, title)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to check note: %w", err)
+	}
+
+	current := ContentHash(body)
+	return current != hash, current, nil
+}
+
+// UpdateNoteIfUnchanged checks the precondition and writes under one lock, so unlike Notes.app there is no race
+func (m *MemoryNotesService) UpdateNoteIfUnchanged(ctx context.Context, title, content string, precondition UpdatePrecondition) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	note, err := m.findLocked(title)
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+
+	if precondition.ExpectedModified != nil {
+		expected := precondition.ExpectedModified.Truncate(time.Second)
+		actual := note.ModificationDate.Truncate(time.Second)
+		if !actual.Equal(expected) {
+			return fmt.Errorf("failed to update note: %w: modified at %s, expected %s",
+				ErrConflict, actual.Format(time.RFC3339), expected.Format(time.RFC3339))
+		}
+	}
+	if precondition.ExpectedHash != "" {
+		if actual := ContentHash(note.Content); actual != precondition.ExpectedHash {
+			return fmt.Errorf("failed to update note: %w: content hash is %s", ErrConflict, actual)
+		}
+	}
+
+	note.Content = content
+	m.touchLocked(note)
+	return nil
+}
+
+// DeleteNote removes a note
+func (m *MemoryNotesService) DeleteNote(ctx context.Context, title string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i, err := m.indexLocked(title)
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	m.notes = append(m.notes[:i], m.notes[i+1:]...)
+	return nil
+}
+
+// OpenNote is not supported: there is no app to show the note in
+func (m *MemoryNotesService) OpenNote(ctx context.Context, title string) error {
+	return fmt.Errorf("failed to open note: %w", ErrNotSupported)
+}
+
+// folderNotFoundLocked returns a folder-not-found error suggesting close folder names; callers hold m.mu
+func (m *MemoryNotesService) folderNotFoundLocked(operation, folder string) error {
+	return &FolderNotFoundError{
+		Folder:      folder,
+		Suggestions: SuggestNames(folder, m.folders, maxSuggestions),
+		Err:         fmt.Errorf("%s: %w: %q", operation, ErrFolderNotFound, folder),
+	}
+}
+
+// ListFolders returns folder names in the order they were created
+func (m *MemoryNotesService) ListFolders(ctx context.Context) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string{}, m.folders...), nil
+}
+
+// GetRecentNotes returns up to limit notes, most recently modified first
+func (m *MemoryNotesService) GetRecentNotes(ctx context.Context, limit int) ([]Note, error) {
+	m.mu.Lock()
+	notes := m.filterLocked(func(note *Note) bool { return true })
+	m.mu.Unlock()
+
+	newestFirst(notes)
+	if limit > 0 && len(notes) > limit {
+		notes = notes[:limit]
+	}
+	return notes, nil
+}
+
+// GetNotesInFolder returns the notes in a folder
+func (m *MemoryNotesService) GetNotesInFolder(ctx context.Context, folder string) ([]Note, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.hasFolderLocked(folder) {
+		return []Note{}, m.folderNotFoundLocked("failed to get notes in folder", folder)
+	}
+	return m.filterLocked(func(note *Note) bool { return note.Folder == folder }), nil
+}
+
+// GetRecentNotesInFolder returns up to limit notes in a folder, most recently modified first
+func (m *MemoryNotesService) GetRecentNotesInFolder(ctx context.Context, folder string, limit int) ([]Note, error) {
+	notes, err := m.GetNotesInFolder(ctx, folder)
+	if err != nil {
+		return notes, err
+	}
+
+	newestFirst(notes)
+	if limit > 0 && len(notes) > limit {
+		notes = notes[:limit]
+	}
+	return notes, nil
+}
+
+// ListNotesWithMetadata lists every note, or those in a folder
+func (m *MemoryNotesService) ListNotesWithMetadata(ctx context.Context, folder string) ([]Note, error) {
+	if folder != "" {
+		return m.GetNotesInFolder(ctx, folder)
+	}
+	return m.GetRecentNotes(ctx, 0)
+}
+
+// ListNotesByPrefix lists notes whose titles begin with prefix (case-insensitive), sorted by title
+func (m *MemoryNotesService) ListNotesByPrefix(ctx context.Context, prefix, folder string) ([]Note, error) {
+	if strings.TrimSpace(prefix) == "" {
+		return []Note{}, fmt.Errorf("%w: prefix is required", ErrInvalidInput)
+	}
+
+	notes, err := m.ListNotesWithMetadata(ctx, folder)
+	if err != nil {
+		return notes, err
+	}
+
+	matching := []Note{}
+	for _, note := range notes {
+		if strings.HasPrefix(strings.ToLower(note.Title), strings.ToLower(prefix)) {
+			matching = append(matching, note)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return strings.ToLower(matching[i].Title) < strings.ToLower(matching[j].Title)
+	})
+	return matching, nil
+}
+
+// GetNotesModifiedBetween returns notes modified in [from, to), newest first
+func (m *MemoryNotesService) GetNotesModifiedBetween(ctx context.Context, from, to time.Time) ([]Note, error) {
+	m.mu.Lock()
+	notes := m.filterLocked(func(note *Note) bool {
+		return !note.ModificationDate.Before(from) && note.ModificationDate.Before(to)
+	})
+	m.mu.Unlock()
+
+	newestFirst(notes)
+	return notes, nil
+}
+
+// CreateFolder adds a folder, optionally inside an existing parent folder
+func (m *MemoryNotesService) CreateFolder(ctx context.Context, name string, parentFolder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: folder name is required", ErrInvalidInput)
+	}
+	if m.hasFolderLocked(name) {
+		return fmt.Errorf("%w: folder %q already exists", ErrInvalidInput, name)
+	}
+	if parentFolder != "" && !m.hasFolderLocked(parentFolder) {
+		return m.folderNotFoundLocked("failed to create folder", parentFolder)
+	}
+
+	m.folders = append(m.folders, name)
+	m.parents[name] = parentFolder
+	return nil
+}
+
+// MoveNote moves a note to another folder
+func (m *MemoryNotesService) MoveNote(ctx context.Context, noteTitle string, targetFolder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	note, err := m.findLocked(noteTitle)
+	if err != nil {
+		return fmt.Errorf("failed to move note: %w", err)
+	}
+	if !m.hasFolderLocked(targetFolder) {
+		return m.folderNotFoundLocked("failed to move note", targetFolder)
+	}
+	note.Folder = targetFolder
+	return nil
+}
+
+// MoveNoteByID moves the note with the given ID to another folder
+func (m *MemoryNotesService) MoveNoteByID(ctx context.Context, noteID string, targetFolder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.hasFolderLocked(targetFolder) {
+		return m.folderNotFoundLocked("failed to move note by ID", targetFolder)
+	}
+	for _, note := range m.notes {
+		if note.ID == noteID {
+			note.Folder = targetFolder
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to move note by ID: %w", ErrNoteNotFound)
+}
+
+// GetFolderHierarchy returns the folder tree under a "Memory" root with note counts
+func (m *MemoryNotesService) GetFolderHierarchy(ctx context.Context) (*FolderNode, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var build func(parent string) []FolderNode
+	build = func(parent string) []FolderNode {
+		children := []FolderNode{}
+		for _, name := range m.folders {
+			if m.parents[name] != parent {
+				continue
+			}
+
+			node := FolderNode{Name: name, Account: memoryAccount, Children: build(name)}
+			for _, note := range m.notes {
+				if note.Folder != name {
+					continue
+				}
+				node.NoteCount++
+				if node.LastModified == nil || note.ModificationDate.After(*node.LastModified) {
+					modified := note.ModificationDate
+					node.LastModified = &modified
+				}
+			}
+			children = append(children, node)
+		}
+		return children
+	}
+
+	root := &FolderNode{Name: memoryAccount, Children: build("")}
+	countTotalNotes(root)
+	return root, nil
+}
+
+// GetNoteAttachments returns no attachments; memory notes cannot hold files
+func (m *MemoryNotesService) GetNoteAttachments(ctx context.Context, noteTitle string) ([]Attachment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.readLocked(ctx, noteTitle); err != nil {
+		return []Attachment{}, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return []Attachment{}, nil
+}
+
+// GetAttachmentContent is not supported
+func (m *MemoryNotesService) GetAttachmentContent(ctx context.Context, filePath string, maxSize int64) ([]byte, error) {
+	return nil, fmt.Errorf("failed to read attachment: %w", ErrNotSupported)
+}
+
+// ReadAttachmentChunk is not supported
+func (m *MemoryNotesService) ReadAttachmentChunk(ctx context.Context, filePath string, offset, length int64) (*AttachmentChunk, error) {
+	return nil, fmt.Errorf("failed to read attachment: %w", ErrNotSupported)
+}
+
+// CopyAttachment is not supported
+func (m *MemoryNotesService) CopyAttachment(ctx context.Context, filePath string, destDir string) (*AttachmentCopy, error) {
+	return nil, fmt.Errorf("failed to copy attachment: %w", ErrNotSupported)
+}
+
+// GetAttachmentThumbnail is not supported
+func (m *MemoryNotesService) GetAttachmentThumbnail(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*Thumbnail, error) {
+	return nil, fmt.Errorf("failed to get thumbnail: %w", ErrNotSupported)
+}
+
+// ExportNoteMarkdown converts a note's body to markdown
+func (m *MemoryNotesService) ExportNoteMarkdown(ctx context.Context, noteTitle string) (string, error) {
+	return m.ExportNoteMarkdownWithOptions(ctx, noteTitle, MarkdownExportOptions{})
+}
+
+// ExportNoteMarkdownWithOptions converts a note's body to markdown; stored notes have no
+// attachments, so only inline data URI images can be kept or written out
+func (m *MemoryNotesService) ExportNoteMarkdownWithOptions(ctx context.Context, noteTitle string, opts MarkdownExportOptions) (string, error) {
+	if err := opts.validate(); err != nil {
+		return "", fmt.Errorf("failed to export note as markdown: %w", err)
+	}
+	body, err := m.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to export note as markdown: %w", err)
+	}
+	if body, err = resolveMarkdownImages(body, nil, opts, nil); err != nil {
+		return "", fmt.Errorf("failed to export note as markdown: %w", err)
+	}
+	return convertHTMLToMarkdown(body), nil
+}
+
+// ExportNoteText returns a note's body with markup removed
+func (m *MemoryNotesService) ExportNoteText(ctx context.Context, noteTitle string) (string, error) {
+	body, err := m.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to export note as text: %w", err)
+	}
+	return stripHTML(lineBreakPattern.ReplaceAllString(body, "\n")), nil
+}
+
+// ExportNoteObsidian is not supported
+func (m *MemoryNotesService) ExportNoteObsidian(ctx context.Context, noteTitle string, assetsDir string) (string, error) {
+	return "", fmt.Errorf("failed to export note for Obsidian: %w", ErrNotSupported)
+}
+
+// ImportHTMLFile is not supported
+func (m *MemoryNotesService) ImportHTMLFile(ctx context.Context, filePath, sourceURL, folder string) (*ImportResult, error) {
+	return nil, fmt.Errorf("failed to import HTML: %w", ErrNotSupported)
+}
+
+// TranscribeAttachment is not supported
+func (m *MemoryNotesService) TranscribeAttachment(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*Transcript, error) {
+	return nil, fmt.Errorf("failed to transcribe attachment: %w", ErrNotSupported)
+}
+
+// WithNoteMetrics fills in Metrics from each note's body
+func (m *MemoryNotesService) WithNoteMetrics(ctx context.Context, notes []Note) ([]Note, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	enriched := make([]Note, len(notes))
+	for i, note := range notes {
+		for _, stored := range m.notes {
+			if note.ID != "" && stored.ID == note.ID {
+				note.Metrics = computeNoteMetrics(stored.Content, stored.Title, 0)
+			}
+		}
+		enriched[i] = note
+	}
+	return enriched, nil
+}
+
+// ClipURL is not supported
+func (m *MemoryNotesService) ClipURL(ctx context.Context, pageURL, folder string) (*Note, error) {
+	return nil, fmt.Errorf("failed to clip URL: %w", ErrNotSupported)
+}
+
+// ExtractActionItems parses checklist items from a note's body
+func (m *MemoryNotesService) ExtractActionItems(ctx context.Context, noteTitle string) ([]ActionItem, error) {
+	body, err := m.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return []ActionItem{}, fmt.Errorf("failed to extract action items: %w", err)
+	}
+	return withDueDates(parseActionItems(body, noteTitle), m.now()), nil
+}
+
+// FindActionItems collects action items from notes whose title or body matches query
+func (m *MemoryNotesService) FindActionItems(ctx context.Context, query, folder string) ([]ActionItem, error) {
+	if strings.TrimSpace(query) == "" {
+		return []ActionItem{}, fmt.Errorf("%w: query is required", ErrInvalidInput)
+	}
+
+	notes, err := m.SearchNotesAdvanced(ctx, SearchOptions{Query: query, SearchIn: SearchInBoth, Folder: folder})
+	if err != nil {
+		return []ActionItem{}, fmt.Errorf("failed to find action items: %w", err)
+	}
+
+	items := []ActionItem{}
+	for _, note := range notes {
+		found, err := m.ExtractActionItems(ctx, note.Title)
+		if err != nil {
+			return []ActionItem{}, fmt.Errorf("failed to find action items: %w", err)
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+// GetUpcomingDeadlines collects open action items due within days, soonest first
+func (m *MemoryNotesService) GetUpcomingDeadlines(ctx context.Context, days int, folder string) ([]ActionItem, error) {
+	if days < 1 {
+		return []ActionItem{}, fmt.Errorf("%w: days must be at least 1", ErrInvalidInput)
+	}
+	until := startOfDay(m.now()).AddDate(0, 0, days+1)
+
+	m.mu.Lock()
+	deadlines := []ActionItem{}
+	for _, note := range m.notes {
+		if folder != "" && note.Folder != folder {
+			continue
+		}
+		for _, item := range withDueDates(parseActionItems(note.Content, note.Title), note.Modified) {
+			if !item.Done && item.Due != nil && item.Due.Before(until) {
+				deadlines = append(deadlines, item)
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].Due.Before(*deadlines[j].Due)
+	})
+	return deadlines, nil
+}
+
+// CreateReminder is not supported
+func (m *MemoryNotesService) CreateReminder(ctx context.Context, noteTitle, text, list string, dueDate *time.Time) (*Reminder, error) {
+	return nil, fmt.Errorf("failed to create reminder: %w", ErrNotSupported)
+}
+
+// PushActionItemsToReminders is not supported
+func (m *MemoryNotesService) PushActionItemsToReminders(ctx context.Context, noteTitle, list string) ([]Reminder, error) {
+	return []Reminder{}, fmt.Errorf("failed to create reminders: %w", ErrNotSupported)
+}
+
+// GetTodaysEvents is not supported
+func (m *MemoryNotesService) GetTodaysEvents(ctx context.Context, query string) ([]CalendarEvent, error) {
+	return []CalendarEvent{}, fmt.Errorf("failed to get calendar events: %w", ErrNotSupported)
+}
+
+// PinNote is not supported: memory notes have no pinned state
+func (m *MemoryNotesService) PinNote(ctx context.Context, title string) error {
+	return fmt.Errorf("failed to pin note: %w", ErrNotSupported)
+}
+
+// AddNoteTags adds tags a note doesn't have yet, with or without a leading "#"
+func (m *MemoryNotesService) AddNoteTags(ctx context.Context, title string, tags []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	note, err := m.findLocked(title)
+	if err != nil {
+		return fmt.Errorf("failed to add tags: %w", err)
+	}
+
+	for _, tag := range tags {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" {
+			continue
+		}
+		exists := false
+		for _, existing := range note.Tags {
+			exists = exists || strings.EqualFold(existing, tag)
+		}
+		if !exists {
+			note.Tags = append(note.Tags, tag)
+		}
+	}
+	m.touchLocked(note)
+	return nil
+}
+
+// GenerateWeeklyDigest is not supported
+func (m *MemoryNotesService) GenerateWeeklyDigest(ctx context.Context, weekStart time.Time, digestFolder string) (*Note, error) {
+	return nil, fmt.Errorf("failed to generate digest: %w", ErrNotSupported)
+}
+
+// Compile-time check that MemoryNotesService implements NotesService
+var _ NotesService = (*MemoryNotesService)(nil)
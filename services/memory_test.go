@@ -0,0 +1,121 @@
+// ABOUTME: Unit tests for the in-memory notes service
+// ABOUTME: Tests note and folder operations, search filters, optimistic updates, and unsupported features
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestMemoryService returns an empty store whose clock advances a minute per call
+func newTestMemoryService() *MemoryNotesService {
+	service := NewMemoryNotesService()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	return service
+}
+
+func TestMemoryNotesService(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+
+	note, err := service.CreateNote(ctx, "Plan", "- [ ] ship it\nthen celebrate", []string{"work"})
+	if err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if note.ID == "" || note.Folder != defaultMemoryFolder {
+		t.Errorf("expected an ID and the default folder, got %+v", note)
+	}
+	if _, err := service.CreateNote(ctx, "Groceries", "milk", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	body, err := service.GetNoteContent(ctx, "plan")
+	if err != nil || body != "- [ ] ship it<br>then celebrate" {
+		t.Errorf("expected the stored body by case-insensitive title, got %q, %v", body, err)
+	}
+
+	if err := service.CreateFolder(ctx, "Work", ""); err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+	if err := service.MoveNote(ctx, "Plan", "Work"); err != nil {
+		t.Fatalf("MoveNote failed: %v", err)
+	}
+	if err := service.MoveNote(ctx, "Plan", "Nowhere"); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("expected ErrFolderNotFound, got %v", err)
+	}
+
+	found, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "celebrate", SearchIn: SearchInBody, HasChecklist: true})
+	if err != nil || len(found) != 1 || found[0].Title != "Plan" || found[0].Content != "" {
+		t.Errorf("expected Plan without its body, got %+v, %v", found, err)
+	}
+	found, _ = service.SearchNotesAdvanced(ctx, SearchOptions{Query: "", SearchIn: SearchInTitle, ExcludeFolders: []string{"Work"}})
+	if len(found) != 1 || found[0].Title != "Groceries" {
+		t.Errorf("expected only Groceries outside Work, got %+v", found)
+	}
+
+	hierarchy, err := service.GetFolderHierarchy(ctx)
+	if err != nil || hierarchy.TotalNoteCount != 2 || len(hierarchy.Children) != 2 || hierarchy.Children[1].NoteCount != 1 {
+		t.Errorf("expected two folders with one note each, got %+v, %v", hierarchy, err)
+	}
+
+	if err := service.AddNoteTags(ctx, "Plan", []string{"#work", "q3"}); err != nil {
+		t.Fatalf("AddNoteTags failed: %v", err)
+	}
+	metadata, _ := service.GetNoteMetadata(ctx, "Plan")
+	if len(metadata.Tags) != 2 {
+		t.Errorf("expected tags [work q3], got %v", metadata.Tags)
+	}
+
+	recent, _ := service.GetRecentNotes(ctx, 1)
+	if len(recent) != 1 || recent[0].Title != "Plan" {
+		t.Errorf("expected the tagged note to be most recent, got %+v", recent)
+	}
+
+	if err := service.DeleteNote(ctx, "Groceries"); err != nil {
+		t.Fatalf("DeleteNote failed: %v", err)
+	}
+	if _, err := service.GetNoteContent(ctx, "Groceries"); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("expected ErrNoteNotFound after delete, got %v", err)
+	}
+}
+
+func TestMemoryUpdateNoteIfUnchanged(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+	if _, err := service.CreateNote(ctx, "Plan", "v1", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	stale := ContentHash("v0")
+	err := service.UpdateNoteIfUnchanged(ctx, "Plan", "v2", UpdatePrecondition{ExpectedHash: stale})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict for a stale hash, got %v", err)
+	}
+
+	err = service.UpdateNoteIfUnchanged(ctx, "Plan", "v2", UpdatePrecondition{ExpectedHash: ContentHash("v1")})
+	if err != nil {
+		t.Fatalf("expected the update to succeed, got %v", err)
+	}
+	if changed, _, _ := service.HasNoteChanged(ctx, "Plan", ContentHash("v1")); !changed {
+		t.Error("expected the note to have changed")
+	}
+}
+
+func TestMemoryUnsupportedOperations(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+
+	if err := service.OpenNote(ctx, "Plan"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported from OpenNote, got %v", err)
+	}
+	if _, err := service.ClipURL(ctx, "https://example.com", ""); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported from ClipURL, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+// ABOUTME: Note merging that appends other notes' bodies to a target note and deletes them
+// ABOUTME: Refuses ambiguous titles and notes with attachments, and guards the target against concurrent edits
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NoteMerge reports a merge: the note kept and the notes appended to it and deleted
+type NoteMerge struct {
+	Target  string   `json:"target"`
+	Merged  []string `json:"merged"`
+	Deleted []string `json:"deleted"`
+}
+
+// interElementNewlines matches the formatting newlines Notes puts between body elements
+var interElementNewlines = regexp.MustCompile(`>\s*\n\s*<`)
+
+// MergeNotes appends each source note's body to target, separated by an empty line, then deletes the sources
+// Notes are addressed by title, so every title must belong to exactly one note. Sources with attachments
+// are refused, since their files would not survive the merge. The target is written only if it hasn't
+// changed since it was read; a failed delete stops the merge, reporting what was deleted so far.
+func MergeNotes(ctx context.Context, notes NotesService, target string, sources []string) (*NoteMerge, error) {
+	if strings.TrimSpace(target) == "" || len(sources) == 0 {
+		return nil, fmt.Errorf("%w: a target and at least one source note are required", ErrInvalidInput)
+	}
+
+	library, err := notes.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge notes: %w", err)
+	}
+	titles := map[string]int{}
+	for _, note := range library {
+		titles[strings.ToLower(normalizeTitle(note.Title))]++
+	}
+	seen := map[string]bool{}
+	for _, title := range append([]string{target}, sources...) {
+		key := strings.ToLower(normalizeTitle(title))
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %q is named more than once", ErrInvalidInput, title)
+		}
+		seen[key] = true
+		if titles[key] > 1 {
+			return nil, fmt.Errorf("%w: %d notes are titled %q (ignoring case); rename one so the merge can't pick the wrong note",
+				ErrInvalidInput, titles[key], title)
+		}
+	}
+
+	for _, source := range sources {
+		attachments, err := notes.GetNoteAttachments(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge notes: %w", err)
+		}
+		if len(attachments) > 0 {
+			return nil, fmt.Errorf("%w: %q has attachments, which a merge would lose; merge it in Notes instead", ErrInvalidInput, source)
+		}
+	}
+
+	body, err := notes.GetNoteContent(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge notes: %w", err)
+	}
+	merged := body
+	for _, source := range sources {
+		sourceBody, err := notes.GetNoteContent(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge notes: %w", err)
+		}
+		if merged, err = AppendHTML(merged, "<div><br></div>"+sourceBody); err != nil {
+			return nil, fmt.Errorf("failed to merge notes: %w", err)
+		}
+	}
+
+	// UpdateNote turns newlines into line breaks, so drop the ones that only separate elements
+	merged = interElementNewlines.ReplaceAllString(merged, "><")
+	if err := notes.UpdateNoteIfUnchanged(ctx, target, merged, UpdatePrecondition{ExpectedHash: ContentHash(body)}); err != nil {
+		return nil, fmt.Errorf("failed to merge notes: %w", err)
+	}
+
+	report := &NoteMerge{Target: target, Merged: sources, Deleted: []string{}}
+	for _, source := range sources {
+		if err := notes.DeleteNote(ctx, source); err != nil {
+			return report, fmt.Errorf("merged into %q but failed to delete %q: %w", target, source, err)
+		}
+		report.Deleted = append(report.Deleted, source)
+	}
+	return report, nil
+}
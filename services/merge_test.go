@@ -0,0 +1,58 @@
+// ABOUTME: Unit tests for note merging
+// ABOUTME: Tests appended bodies, deleted sources, and refusals for ambiguous titles and bad input
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMergeNotes(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	for title, body := range map[string]string{
+		"Plan":     "<div>Plan</div>",
+		"Plan old": "<div>Plan old</div><div>Second</div>",
+		"Other":    "<div>Other</div>",
+		"other":    "<div>other</div>",
+	} {
+		if _, err := service.CreateNote(ctx, title, body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	// Notes separates body elements with newlines, which must not become line breaks
+	if err := service.UpdateNote(ctx, "Plan", "<div>Plan</div>\n<div>First</div>"); err != nil {
+		t.Fatalf("UpdateNote failed: %v", err)
+	}
+
+	merge, err := MergeNotes(ctx, service, "Plan", []string{"Plan old"})
+	if err != nil {
+		t.Fatalf("MergeNotes failed: %v", err)
+	}
+	if merge.Target != "Plan" || len(merge.Deleted) != 1 {
+		t.Errorf("unexpected merge report %+v", merge)
+	}
+	body, err := service.GetNoteContent(ctx, "Plan")
+	if err != nil {
+		t.Fatalf("GetNoteContent failed: %v", err)
+	}
+	if want := "<div>Plan</div><div>First</div><div><br/></div><div>Plan old</div><div>Second</div>"; body != want {
+		t.Errorf("merged body = %q, want %q", body, want)
+	}
+	if _, err := service.GetNoteContent(ctx, "Plan old"); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("expected the source deleted, got %v", err)
+	}
+
+	for name, sources := range map[string][]string{
+		"no sources":      nil,
+		"target repeated": {"plan"},
+		"ambiguous title": {"Other"},
+	} {
+		if _, err := MergeNotes(ctx, service, "Plan", sources); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// ABOUTME: Derived note metrics: word count, text length, estimated read time, checklist and attachment flags
+// ABOUTME: Computed for a batch of notes in one AppleScript call so search results can be triaged cheaply
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// wordsPerMinute is the reading speed behind ReadTimeMinutes
+const wordsPerMinute = 200
+
+// NoteMetrics summarizes a note's size and structure without returning its body
+type NoteMetrics struct {
+	WordCount       int  `json:"word_count"`
+	TextLength      int  `json:"text_length"` // characters of text, not counting a first line that repeats the title
+	ReadTimeMinutes int  `json:"read_time_minutes"`
+	HasChecklist    bool `json:"has_checklist"`
+	HasAttachments  bool `json:"has_attachments"`
+	AttachmentCount int  `json:"attachment_count"`
+}
+
+// computeNoteMetrics derives metrics from a note's HTML body, title, and attachment count
+// Read time rounds up, so any note with text takes at least a minute.
+func computeNoteMetrics(body, title string, attachmentCount int) *NoteMetrics {
+	text := html.UnescapeString(htmlTagPattern.ReplaceAllString(lineBreakPattern.ReplaceAllString(body, " "), " "))
+	words := len(strings.Fields(text))
+
+	return &NoteMetrics{
+		WordCount:       words,
+		TextLength:      bodyTextLength(body, title),
+		ReadTimeMinutes: (words + wordsPerMinute - 1) / wordsPerMinute,
+		HasChecklist:    len(parseActionItems(body, "")) > 0,
+		HasAttachments:  attachmentCount > 0,
+		AttachmentCount: attachmentCount,
+	}
+}
+
+// bodyTextLength counts the characters of a note's text with whitespace runs collapsed, skipping
+// a first line that repeats the title, since Notes.app keeps the title as the body's first line
+func bodyTextLength(body, title string) int {
+	lines := strings.Split(stripHTML(lineBreakPattern.ReplaceAllString(body, "\n")), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.EqualFold(strings.Join(strings.Fields(line), " "), strings.Join(strings.Fields(title), " ")) {
+			lines = lines[i+1:]
+		}
+		break
+	}
+	return utf8.RuneCountInString(strings.Join(strings.Fields(strings.Join(lines, " ")), " "))
+}
+
+// WithNoteMetrics fills in Metrics for notes that have an ID, reading every body in one script
+// Notes that no longer exist, or have no ID, are returned without metrics.
+func (s *AppleNotesService) WithNoteMetrics(ctx context.Context, notes []Note) ([]Note, error) {
+	ids := make([]string, 0, len(notes))
+	for _, note := range notes {
+		if note.ID != "" {
+			ids = append(ids, `"`+s.escapeForAppleScript(note.ID)+`"`)
+		}
+	}
+	if len(ids) == 0 {
+		return notes, nil
+	}
+
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set recordSep to (ASCII character 30)
+				set fieldSep to (ASCII character 31)
+				set output to ""
+				repeat with noteID in {%s}
+					try
+						set n to note id noteID
+						set output to output & noteID & fieldSep & (count of attachments of n) & fieldSep & (body of n) & recordSep
+					end try
+				end repeat
+				return output
+			end tell
+		end tell
+	`, s.iCloudAccount, strings.Join(ids, ", "))
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return notes, fmt.Errorf("failed to compute note metrics: %w", detectedErr)
+	}
+
+	titles := map[string]string{}
+	for _, note := range notes {
+		titles[note.ID] = note.Title
+	}
+	metrics := parseNoteMetrics(stdout, titles)
+	enriched := make([]Note, len(notes))
+	for i, note := range notes {
+		note.Metrics = metrics[note.ID]
+		enriched[i] = note
+	}
+	return enriched, nil
+}
+
+// parseNoteMetrics parses "id, attachment count, body" records into metrics keyed by note ID,
+// using titles (by ID) to leave each note's title line out of its text length
+func parseNoteMetrics(output string, titles map[string]string) map[string]*NoteMetrics {
+	metrics := map[string]*NoteMetrics{}
+
+	for _, record := range strings.Split(output, recordSeparator) {
+		record = strings.TrimLeft(record, "\r\n")
+		fields := strings.SplitN(record, unitSeparator, 3)
+		if len(fields) != 3 || fields[0] == "" {
+			continue
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil {
+			continue
+		}
+		metrics[fields[0]] = computeNoteMetrics(fields[2], titles[fields[0]], count)
+	}
+
+	return metrics
+}
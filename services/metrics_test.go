@@ -0,0 +1,72 @@
+// ABOUTME: Unit tests for derived note metrics
+// ABOUTME: Tests word counts, read-time rounding, checklist detection, and batch parsing
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestComputeNoteMetrics(t *testing.T) {
+	long := "<div>" + strings.Repeat("word ", 401) + "</div>"
+
+	tests := []struct {
+		name        string
+		body        string
+		title       string
+		attachments int
+		want        NoteMetrics
+	}{
+		{"empty", "", "", 0, NoteMetrics{}},
+		{"short", "<div>Hello&nbsp;there<br>friend</div>", "", 0, NoteMetrics{WordCount: 3, TextLength: 18, ReadTimeMinutes: 1}},
+		{"rounds up", long, "", 0, NoteMetrics{WordCount: 401, TextLength: 2004, ReadTimeMinutes: 3}},
+		{"checklist", `<ul class="checklist"><li class="unchecked">Buy milk</li></ul>`, "", 0,
+			NoteMetrics{WordCount: 2, TextLength: 8, ReadTimeMinutes: 1, HasChecklist: true}},
+		{"attachments", "<div>Scan</div>", "", 2, NoteMetrics{WordCount: 1, TextLength: 4, ReadTimeMinutes: 1, HasAttachments: true, AttachmentCount: 2}},
+		{"title line", "<div><h1>Weekly  Plan</h1></div><div><br></div><div>Hi</div>", "weekly plan", 0,
+			NoteMetrics{WordCount: 3, TextLength: 2, ReadTimeMinutes: 1}},
+		{"only title", "<div>Groceries</div><div><br></div>", "Groceries", 0, NoteMetrics{WordCount: 1, ReadTimeMinutes: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeNoteMetrics(tt.body, tt.title, tt.attachments)
+			if *got != tt.want {
+				t.Errorf("computeNoteMetrics() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNoteMetrics(t *testing.T) {
+	output := "id1" + unitSeparator + "1" + unitSeparator + "<div>one two three</div>" + recordSeparator +
+		"id2" + unitSeparator + "bad" + unitSeparator + "<div>skipped</div>" + recordSeparator
+	service := NewAppleNotesService(&MockExecutor{stdout: output})
+
+	notes := []Note{{ID: "id1", Title: "One"}, {ID: "id2", Title: "Two"}, {Title: "No ID"}}
+	got, err := service.WithNoteMetrics(context.Background(), notes)
+	if err != nil {
+		t.Fatalf("WithNoteMetrics failed: %v", err)
+	}
+
+	if got[0].Metrics == nil || got[0].Metrics.WordCount != 3 || got[0].Metrics.AttachmentCount != 1 {
+		t.Errorf("note 1 metrics = %+v", got[0].Metrics)
+	}
+	if got[1].Metrics != nil || got[2].Metrics != nil {
+		t.Errorf("expected no metrics for malformed or ID-less notes, got %+v / %+v", got[1].Metrics, got[2].Metrics)
+	}
+	if notes[0].Metrics != nil {
+		t.Error("input notes should not be modified")
+	}
+}
+
+func TestWithNoteMetricsError(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{err: errors.New("boom")})
+
+	if _, err := service.WithNoteMetrics(context.Background(), []Note{{ID: "id1"}}); err == nil {
+		t.Error("expected error")
+	}
+}
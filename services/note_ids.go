@@ -0,0 +1,75 @@
+// ABOUTME: Note operations addressed by ID rather than title, for notes that share a title
+// ABOUTME: AppleScript resolves "note id" exactly, so these never fall back to tolerant title matching
+
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetNoteContentByID retrieves the HTML body of the note with the given ID
+func (s *AppleNotesService) GetNoteContentByID(ctx context.Context, noteID string) (string, error) {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				get body of note id "%s"
+			end tell
+		end tell
+	`, s.iCloudAccount, s.escapeForAppleScript(noteID))
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		return "", fmt.Errorf("failed to get note content by ID: %w", DetectError(ctx, stderr, err))
+	}
+	return stdout, nil
+}
+
+// UpdateNoteByID replaces the body of the note with the given ID; newlines become line breaks as in UpdateNote
+func (s *AppleNotesService) UpdateNoteByID(ctx context.Context, noteID, content string) error {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set body of note id "%s" to "%s"
+			end tell
+		end tell
+	`, s.iCloudAccount, s.escapeForAppleScript(noteID), s.formatContent(content))
+
+	if _, stderr, err := s.executor.Execute(ctx, script); err != nil {
+		return fmt.Errorf("failed to update note by ID: %w", DetectError(ctx, stderr, err))
+	}
+	return nil
+}
+
+// DeleteNoteByID deletes the note with the given ID
+func (s *AppleNotesService) DeleteNoteByID(ctx context.Context, noteID string) error {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				delete note id "%s"
+			end tell
+		end tell
+	`, s.iCloudAccount, s.escapeForAppleScript(noteID))
+
+	if _, stderr, err := s.executor.Execute(ctx, script); err != nil {
+		return fmt.Errorf("failed to delete note by ID: %w", DetectError(ctx, stderr, err))
+	}
+	s.titleCache.invalidate()
+	return nil
+}
+
+// MoveNoteByID moves the note with the given ID to targetFolder
+func (s *AppleNotesService) MoveNoteByID(ctx context.Context, noteID string, targetFolder string) error {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				move note id "%s" to folder "%s"
+			end tell
+		end tell
+	`, s.iCloudAccount, s.escapeForAppleScript(noteID), s.escapeForAppleScript(targetFolder))
+
+	if _, stderr, err := s.executor.Execute(ctx, script); err != nil {
+		return s.withFolderSuggestions(ctx, targetFolder, fmt.Errorf("failed to move note by ID: %w", DetectError(ctx, stderr, err)))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// ABOUTME: Unit tests for note operations addressed by ID
+// ABOUTME: Tests that the generated scripts address notes by escaped ID and that errors are detected
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// idScriptExecutor records scripts and answers each with a fixed body
+type idScriptExecutor struct {
+	scripts []string
+}
+
+func (e *idScriptExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	e.scripts = append(e.scripts, script)
+	return "<div>Plan</div>", "", nil
+}
+
+func TestNoteOperationsByID(t *testing.T) {
+	ctx := context.Background()
+	executor := &idScriptExecutor{}
+	service := NewAppleNotesService(executor)
+	id := `x-coredata://STORE/ICNote/p7"`
+
+	if body, err := service.GetNoteContentByID(ctx, id); err != nil || body != "<div>Plan</div>" {
+		t.Errorf("GetNoteContentByID = %q, %v", body, err)
+	}
+	if err := service.UpdateNoteByID(ctx, id, "line one\nline two"); err != nil {
+		t.Errorf("UpdateNoteByID failed: %v", err)
+	}
+	if err := service.DeleteNoteByID(ctx, id); err != nil {
+		t.Errorf("DeleteNoteByID failed: %v", err)
+	}
+	if err := service.MoveNoteByID(ctx, id, `Work "2024"`); err != nil {
+		t.Errorf("MoveNoteByID failed: %v", err)
+	}
+
+	for i, want := range []string{`get body of note id "x-coredata://STORE/ICNote/p7\""`,
+		`set body of note id "x-coredata://STORE/ICNote/p7\"" to "line one<br>line two"`,
+		`delete note id "x-coredata://STORE/ICNote/p7\""`,
+		`move note id "x-coredata://STORE/ICNote/p7\"" to folder "Work \"2024\""`} {
+		if !strings.Contains(executor.scripts[i], want) {
+			t.Errorf("script %d does not contain %q:\n%s", i, want, executor.scripts[i])
+		}
+	}
+
+	failing := NewAppleNotesService(&MockExecutor{stderr: "Can't get note id", err: errors.New("exit status 1")})
+	if err := failing.DeleteNoteByID(ctx, "missing"); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("expected ErrNoteNotFound, got %v", err)
+	}
+}
+
+// TestCreateNoteReturnsCreatedID tests that CreateNote reports the new note's own ID even when
+// an older note with the same title answers the metadata lookup, and keeps HTML newlines when asked
+func TestCreateNoteReturnsCreatedID(t *testing.T) {
+	older := `{id:"x-coredata://old", name:"Plan", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 1, 2024 at 10:00:00 AM", container:"Notes", shared:false, password protected:false}`
+	executor := &recordingSequentialExecutor{}
+	executor.responses = []struct {
+		stdout string
+		stderr string
+		err    error
+	}{
+		{stdout: "x-coredata://new\n"}, // CreateNote
+		{stdout: older},                // GetNoteMetadata
+	}
+	service := NewAppleNotesService(executor)
+
+	note, err := service.CreateNote(WithHTMLBody(context.Background()), "Plan", "<div>a</div>\n<pre>b\n  c</pre>", nil)
+	if err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if note.ID != "x-coredata://new" {
+		t.Errorf("expected the created note's ID, got %q", note.ID)
+	}
+	if want := `body:"<div>a</div>` + "\n" + `<pre>b` + "\n" + `  c</pre>"`; !strings.Contains(executor.scripts[0], want) {
+		t.Errorf("expected the HTML body as is, got\n%s", executor.scripts[0])
+	}
+}
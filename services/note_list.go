@@ -0,0 +1,206 @@
+// ABOUTME: Bulk note listing with full metadata in a single AppleScript call
+// ABOUTME: Writes note lists as CSV for spreadsheet review of large libraries
+
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListNotesWithMetadata lists every note, or every note in folder, with its id, folder, dates,
+// and shared/locked flags, newest first
+// Unlike SearchNotes, which asks for metadata one note at a time, this reads the whole library
+// in one script so it stays fast on large libraries.
+func (s *AppleNotesService) ListNotesWithMetadata(ctx context.Context, folder string) ([]Note, error) {
+	notes, err := s.listNotes(ctx, s.noteSource(folder, ""))
+	if err != nil {
+		return []Note{}, s.withFolderSuggestions(ctx, folder, err)
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Modified.After(notes[j].Modified)
+	})
+
+	return notes, nil
+}
+
+// ListNotesByPrefix lists notes whose titles begin with prefix, sorted by title, with the same
+// metadata as ListNotesWithMetadata
+// The match runs in a "name begins with" whose-clause, so Notes filters the library itself and
+// only matching notes are read. Matching is case-insensitive, as in AppleScript comparisons.
+func (s *AppleNotesService) ListNotesByPrefix(ctx context.Context, prefix, folder string) ([]Note, error) {
+	if strings.TrimSpace(prefix) == "" {
+		return []Note{}, fmt.Errorf("%w: prefix is required", ErrInvalidInput)
+	}
+
+	notes, err := s.listNotes(ctx, s.noteSource(folder, prefix))
+	if err != nil {
+		return []Note{}, err
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Title < notes[j].Title
+	})
+
+	return notes, nil
+}
+
+// noteSource returns the AppleScript note reference for an optional folder and title prefix
+func (s *AppleNotesService) noteSource(folder, prefix string) string {
+	source := "notes"
+	if folder != "" {
+		source = fmt.Sprintf(`notes in folder "%s"`, s.escapeForAppleScript(folder))
+	}
+	if prefix != "" {
+		source = fmt.Sprintf(`(%s whose name begins with "%s")`, source, s.escapeForAppleScript(prefix))
+	}
+	return source
+}
+
+// listNotes reads id, title, folder, dates, and shared/locked flags for every note in source
+func (s *AppleNotesService) listNotes(ctx context.Context, source string) ([]Note, error) {
+	// Dates are emitted in ISO 8601 («class isot») so parsing does not depend on the system locale
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set output to ""
+				repeat with n in %s
+					set folderName to ""
+					try
+						set folderName to name of container of n
+					end try
+					set createdText to ((creation date of n) as «class isot» as string)
+					set modifiedText to ((modification date of n) as «class isot» as string)
+					set output to output & (id of n as text) & "|||" & (name of n) & "|||" & folderName & "|||" & createdText & "|||" & modifiedText & "|||" & (shared of n) & "|||" & (password protected of n) & linefeed
+				end repeat
+				return output
+			end tell
+		end tell
+	`, s.iCloudAccount, source)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return []Note{}, fmt.Errorf("failed to list notes: %w", detectedErr)
+	}
+
+	return parseNoteListing(stdout), nil
+}
+
+// parseNoteListing parses the seven-field lines of ListNotesWithMetadata
+// The first five fields are those of parseDatedNotes, followed by the shared and password
+// protected booleans; malformed lines are skipped.
+func parseNoteListing(output string) []Note {
+	notes := []Note{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "|||")
+		if len(fields) != 7 {
+			continue
+		}
+
+		parsed := parseDatedNotes(strings.Join(fields[:5], "|||"))
+		if len(parsed) != 1 {
+			continue
+		}
+
+		note := parsed[0]
+		note.Shared = strings.TrimSpace(fields[5]) == "true"
+		note.PasswordProtected = strings.TrimSpace(fields[6]) == "true"
+		notes = append(notes, note)
+	}
+
+	return notes
+}
+
+// noteCSVHeader is the header row of note list CSV exports
+var noteCSVHeader = []string{"id", "title", "folder", "created", "modified", "shared", "locked"}
+
+// noteMetricsCSVHeader names the columns added when notes carry metrics
+var noteMetricsCSVHeader = []string{"word_count", "read_time_minutes", "has_checklist", "has_attachments"}
+
+// WriteNotesCSV writes notes as CSV with id, title, folder, created, modified, shared, and locked columns
+// Dates are RFC 3339; unknown dates are left empty rather than written as the zero time.
+// When any note has Metrics, metric columns follow, left empty for notes without them.
+func WriteNotesCSV(w io.Writer, notes []Note) error {
+	withMetrics := false
+	for _, note := range notes {
+		if note.Metrics != nil {
+			withMetrics = true
+			break
+		}
+	}
+
+	header := noteCSVHeader
+	if withMetrics {
+		header = append(append([]string{}, noteCSVHeader...), noteMetricsCSVHeader...)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		created := note.CreationDate
+		if created.IsZero() {
+			created = note.Created
+		}
+		modified := note.ModificationDate
+		if modified.IsZero() {
+			modified = note.Modified
+		}
+
+		record := []string{
+			note.ID,
+			note.Title,
+			note.Folder,
+			csvDate(created),
+			csvDate(modified),
+			strconv.FormatBool(note.Shared),
+			strconv.FormatBool(note.PasswordProtected),
+		}
+		if withMetrics {
+			record = append(record, metricsCSVFields(note.Metrics)...)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// metricsCSVFields formats metrics as CSV fields, or empty fields when there are none
+func metricsCSVFields(metrics *NoteMetrics) []string {
+	if metrics == nil {
+		return make([]string, len(noteMetricsCSVHeader))
+	}
+	return []string{
+		strconv.Itoa(metrics.WordCount),
+		strconv.Itoa(metrics.ReadTimeMinutes),
+		strconv.FormatBool(metrics.HasChecklist),
+		strconv.FormatBool(metrics.HasAttachments),
+	}
+}
+
+// csvDate formats a date for CSV output, or returns "" for the zero time
+func csvDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
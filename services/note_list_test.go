@@ -0,0 +1,127 @@
+// ABOUTME: Unit tests for bulk note listing and CSV export
+// ABOUTME: Tests parsing of the seven-field listing output and the CSV columns written for it
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestListNotesWithMetadata tests parsing flags and dates, newest first, skipping malformed lines
+func TestListNotesWithMetadata(t *testing.T) {
+	executor := &MockExecutor{stdout: "id1|||Budget|||Work|||2024-01-01T09:00:00|||2024-01-02T10:00:00|||false|||true\n" +
+		"id2|||Shared List|||Home|||2024-01-03T09:00:00|||2024-01-05T08:30:00|||true|||false\n" +
+		"id3|||Broken|||Home|||2024-01-03T09:00:00|||2024-01-05T08:30:00\n"}
+	service := NewAppleNotesService(executor)
+
+	notes, err := service.ListNotesWithMetadata(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListNotesWithMetadata failed: %v", err)
+	}
+
+	if len(notes) != 2 {
+		t.Fatalf("got %d notes, want 2: %+v", len(notes), notes)
+	}
+	if notes[0].Title != "Shared List" || !notes[0].Shared || notes[0].PasswordProtected {
+		t.Errorf("unexpected first note %+v", notes[0])
+	}
+	if notes[1].Title != "Budget" || notes[1].Shared || !notes[1].PasswordProtected || notes[1].Folder != "Work" {
+		t.Errorf("unexpected second note %+v", notes[1])
+	}
+}
+
+// TestListNotesWithMetadataError tests that script failures are detected and wrapped
+func TestListNotesWithMetadataError(t *testing.T) {
+	executor := &MockExecutor{stderr: "execution error: Can't get folder \"Nope\". (-1728)", err: errors.New("exit status 1")}
+	service := NewAppleNotesService(executor)
+
+	if _, err := service.ListNotesWithMetadata(context.Background(), "Nope"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+// TestListNotesByPrefix tests title sorting and prefix validation
+func TestListNotesByPrefix(t *testing.T) {
+	output := "id2|||Project X — Launch|||Work|||2024-01-02T09:00:00|||2024-01-03T09:00:00|||false|||false\n" +
+		"id1|||Project X — Kickoff|||Work|||2024-01-01T09:00:00|||2024-01-05T09:00:00|||true|||false\n"
+	service := NewAppleNotesService(&MockExecutor{stdout: output})
+
+	notes, err := service.ListNotesByPrefix(context.Background(), "Project X —", "Work")
+	if err != nil {
+		t.Fatalf("ListNotesByPrefix failed: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Title != "Project X — Kickoff" || notes[1].Title != "Project X — Launch" {
+		t.Fatalf("expected notes sorted by title, got %+v", notes)
+	}
+	if !notes[0].Shared {
+		t.Error("expected metadata to be parsed")
+	}
+
+	if _, err := service.ListNotesByPrefix(context.Background(), "  ", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for blank prefix, got %v", err)
+	}
+}
+
+// TestNoteSource tests the note reference built for folders and prefixes
+func TestNoteSource(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+
+	tests := []struct {
+		folder, prefix, want string
+	}{
+		{"", "", "notes"},
+		{"Work", "", `notes in folder "Work"`},
+		{"", `Say "X"`, `(notes whose name begins with "Say \"X\"")`},
+		{"Work", "Project", `(notes in folder "Work" whose name begins with "Project")`},
+	}
+	for _, tt := range tests {
+		if got := service.noteSource(tt.folder, tt.prefix); got != tt.want {
+			t.Errorf("noteSource(%q, %q) = %s, want %s", tt.folder, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+// TestWriteNotesCSV tests the header, quoting, and date columns
+func TestWriteNotesCSV(t *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	notes := []Note{
+		{ID: "id1", Title: `Plan, "v2"`, Folder: "Work", CreationDate: created, ModificationDate: created.Add(time.Hour), Shared: true},
+		{ID: "id2", Title: "Undated", PasswordProtected: true},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNotesCSV(&buf, notes); err != nil {
+		t.Fatalf("WriteNotesCSV failed: %v", err)
+	}
+
+	want := "id,title,folder,created,modified,shared,locked\n" +
+		"id1,\"Plan, \"\"v2\"\"\",Work,2024-01-01T09:00:00Z,2024-01-01T10:00:00Z,true,false\n" +
+		"id2,Undated,,,,false,true\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+// TestWriteNotesCSVMetrics tests that metrics columns appear only when a note has metrics
+func TestWriteNotesCSVMetrics(t *testing.T) {
+	notes := []Note{
+		{ID: "id1", Title: "Long", Metrics: &NoteMetrics{WordCount: 900, ReadTimeMinutes: 5, HasAttachments: true, AttachmentCount: 2}},
+		{ID: "id2", Title: "Gone"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNotesCSV(&buf, notes); err != nil {
+		t.Fatalf("WriteNotesCSV failed: %v", err)
+	}
+
+	want := "id,title,folder,created,modified,shared,locked,word_count,read_time_minutes,has_checklist,has_attachments\n" +
+		"id1,Long,,,,false,false,900,5,false,true\n" +
+		"id2,Gone,,,,false,false,,,,\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,112 @@
+// ABOUTME: Custom key/value properties stored per note in a hidden HTML comment at the top of its body
+// ABOUTME: Gives agents a place to keep state on a note, such as processed=true, that Notes doesn't display
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxNotePropertyKeyLength is the longest property key SetNoteProperty accepts
+const MaxNotePropertyKeyLength = 64
+
+// notePropertiesPattern matches the property block at the top of a body, capturing its JSON object
+// The JSON is written with <, >, and & escaped, so it can't contain the "-->" that ends the comment.
+var notePropertiesPattern = regexp.MustCompile(`(?s)^\s*<!--\s*notes-mcp:properties\s+(\{.*?\})\s*-->`)
+
+// notePropertyKeyPattern matches the keys properties may have
+var notePropertyKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ParseNoteProperties splits a body into its properties and the body without the property block
+// A body without a block has no properties; a block that isn't a JSON object of strings is an error.
+func ParseNoteProperties(body string) (map[string]string, string, error) {
+	match := notePropertiesPattern.FindStringSubmatchIndex(body)
+	if match == nil {
+		return map[string]string{}, body, nil
+	}
+
+	properties := map[string]string{}
+	if err := json.Unmarshal([]byte(body[match[2]:match[3]]), &properties); err != nil {
+		return nil, body, fmt.Errorf("%w: the note's property block is malformed: %v", ErrInvalidInput, err)
+	}
+	return properties, body[match[1]:], nil
+}
+
+// renderNoteProperties returns the hidden comment block holding properties, or "" when there are none
+func renderNoteProperties(properties map[string]string) (string, error) {
+	if len(properties) == 0 {
+		return "", nil
+	}
+	// json.Marshal sorts the keys and escapes <, >, and &, so the block is stable and can't end early
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return "", err
+	}
+	return "<!-- notes-mcp:properties " + string(data) + " -->", nil
+}
+
+// GetNoteProperties returns the properties stored on a note, empty if it has none
+func GetNoteProperties(ctx context.Context, notes NoteReader, title string) (map[string]string, error) {
+	body, err := notes.GetNoteContent(ctx, title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note properties: %w", err)
+	}
+	properties, _, err := ParseNoteProperties(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note properties: %w", err)
+	}
+	return properties, nil
+}
+
+// SetNoteProperty sets a property on a note, or removes it when value is empty, returning the note's properties
+// Keys are letters, digits, "_", ".", and "-". The note is written only if it hasn't changed since it was read.
+func SetNoteProperty(ctx context.Context, notes NotesService, title, key, value string) (map[string]string, error) {
+	if !notePropertyKeyPattern.MatchString(key) || len(key) > MaxNotePropertyKeyLength {
+		return nil, fmt.Errorf("%w: property key %q must be 1-%d letters, digits, '_', '.', or '-'",
+			ErrInvalidInput, key, MaxNotePropertyKeyLength)
+	}
+
+	body, err := notes.GetNoteContent(ctx, title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set note property: %w", err)
+	}
+	properties, rest, err := ParseNoteProperties(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set note property: %w", err)
+	}
+
+	if current, ok := properties[key]; (value == "" && !ok) || (value != "" && current == value) {
+		return properties, nil
+	}
+	if value == "" {
+		delete(properties, key)
+	} else {
+		properties[key] = value
+	}
+
+	block, err := renderNoteProperties(properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set note property: %w", err)
+	}
+	// UpdateNote turns newlines into line breaks, so drop the ones that only separate elements
+	updated := interElementNewlines.ReplaceAllString(block+strings.TrimLeft(rest, " \t\r\n"), "><")
+	if err := notes.UpdateNoteIfUnchanged(ctx, title, updated, UpdatePrecondition{ExpectedHash: ContentHash(body)}); err != nil {
+		return nil, fmt.Errorf("failed to set note property: %w", err)
+	}
+	return properties, nil
+}
+
+// SortedPropertyKeys returns the keys of properties in order, for stable listings
+func SortedPropertyKeys(properties map[string]string) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
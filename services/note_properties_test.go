@@ -0,0 +1,89 @@
+// ABOUTME: Unit tests for note properties stored in a hidden comment block
+// ABOUTME: Tests parsing, setting and removing properties, escaping, and invalid keys and blocks
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseNoteProperties(t *testing.T) {
+	properties, rest, err := ParseNoteProperties(`<!-- notes-mcp:properties {"processed":"true"} --><div>Plan</div>`)
+	if err != nil {
+		t.Fatalf("ParseNoteProperties failed: %v", err)
+	}
+	if properties["processed"] != "true" || rest != "<div>Plan</div>" {
+		t.Errorf("got %v and %q", properties, rest)
+	}
+
+	properties, rest, err = ParseNoteProperties("<div>Plan</div><!-- notes-mcp:properties {} -->")
+	if err != nil || len(properties) != 0 || rest != "<div>Plan</div><!-- notes-mcp:properties {} -->" {
+		t.Errorf("a block below the top should be ignored, got %v, %q, %v", properties, rest, err)
+	}
+
+	if _, _, err := ParseNoteProperties(`<!-- notes-mcp:properties {"n":1} --><div>Plan</div>`); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a malformed block, got %v", err)
+	}
+}
+
+func TestSetNoteProperty(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+	if _, err := service.CreateNote(ctx, "Plan", "Plan", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if err := service.UpdateNote(ctx, "Plan", "<div>Plan</div>\n<div>Ship it</div>"); err != nil {
+		t.Fatalf("UpdateNote failed: %v", err)
+	}
+
+	if _, err := SetNoteProperty(ctx, service, "Plan", "processed", "true"); err != nil {
+		t.Fatalf("SetNoteProperty failed: %v", err)
+	}
+	properties, err := SetNoteProperty(ctx, service, "Plan", "summary", "a --> b & <c>")
+	if err != nil {
+		t.Fatalf("SetNoteProperty failed: %v", err)
+	}
+	if len(properties) != 2 {
+		t.Errorf("expected two properties, got %v", properties)
+	}
+
+	body, err := service.GetNoteContent(ctx, "Plan")
+	if err != nil {
+		t.Fatalf("GetNoteContent failed: %v", err)
+	}
+	if !strings.HasPrefix(body, "<!-- notes-mcp:properties ") || !strings.HasSuffix(body, "--><div>Plan</div><div>Ship it</div>") {
+		t.Errorf("unexpected body %q", body)
+	}
+	if got := stripHTML(body); strings.Contains(got, "processed") {
+		t.Errorf("the property block should not show in the note's text, got %q", got)
+	}
+
+	properties, err = GetNoteProperties(ctx, service, "Plan")
+	if err != nil {
+		t.Fatalf("GetNoteProperties failed: %v", err)
+	}
+	if properties["processed"] != "true" || properties["summary"] != "a --> b & <c>" {
+		t.Errorf("unexpected properties %v", properties)
+	}
+
+	for _, key := range []string{"processed", "summary"} {
+		if _, err := SetNoteProperty(ctx, service, "Plan", key, ""); err != nil {
+			t.Fatalf("SetNoteProperty failed: %v", err)
+		}
+	}
+	if body, _ := service.GetNoteContent(ctx, "Plan"); body != "<div>Plan</div><div>Ship it</div>" {
+		t.Errorf("removing every property should drop the block, got %q", body)
+	}
+
+	for _, key := range []string{"", "has space", "-->", strings.Repeat("k", MaxNotePropertyKeyLength+1)} {
+		if _, err := SetNoteProperty(ctx, service, "Plan", key, "x"); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("key %q: expected ErrInvalidInput, got %v", key, err)
+		}
+	}
+	if _, err := GetNoteProperties(ctx, service, "Missing"); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("expected ErrNoteNotFound, got %v", err)
+	}
+}
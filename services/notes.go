@@ -6,18 +6,28 @@ package services
 import (
 	"context"
 	"fmt"
+	"html"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// NotesService defines the interface for notes management
+// NotesService is the full set of notes operations, composed from the focused interfaces below
+// Backends may implement only some of them; ComposeNotesService fills the rest with ErrNotSupported.
 type NotesService interface {
-	// CreateNote creates a new note in Apple Notes
-	CreateNote(ctx context.Context, title, content string, tags []string) (*Note, error)
+	NoteReader
+	NoteWriter
+	FolderManager
+	AttachmentReader
+	Exporter
+	AppIntegrations
+}
 
+// NoteReader finds and reads notes; every notes backend implements it
+type NoteReader interface {
 	// SearchNotes searches for notes by title query
 	SearchNotes(ctx context.Context, query string) ([]Note, error)
 
@@ -30,14 +40,14 @@ type NotesService interface {
 	// GetNoteMetadata retrieves full metadata for a note including dates, folder, and sharing info
 	GetNoteMetadata(ctx context.Context, title string) (*Note, error)
 
-	// UpdateNote updates an existing note's content by title
-	UpdateNote(ctx context.Context, title, content string) error
+	// GetNoteTitleByID returns the current title of the note with the given ID
+	GetNoteTitleByID(ctx context.Context, noteID string) (string, error)
 
-	// DeleteNote deletes a note by title
-	DeleteNote(ctx context.Context, title string) error
+	// GetNoteContentByID retrieves the body of the note with the given ID, for notes sharing a title
+	GetNoteContentByID(ctx context.Context, noteID string) (string, error)
 
-	// ListFolders lists all folders in Apple Notes
-	ListFolders(ctx context.Context) ([]string, error)
+	// HasNoteChanged reports whether a note's body no longer matches a previously returned content hash
+	HasNoteChanged(ctx context.Context, title, hash string) (bool, string, error)
 
 	// GetRecentNotes retrieves recently modified notes
 	GetRecentNotes(ctx context.Context, limit int) ([]Note, error)
@@ -45,41 +55,151 @@ type NotesService interface {
 	// GetNotesInFolder retrieves all notes in a specific folder
 	GetNotesInFolder(ctx context.Context, folder string) ([]Note, error)
 
+	// GetRecentNotesInFolder retrieves the most recently modified notes in a folder, newest first
+	GetRecentNotesInFolder(ctx context.Context, folder string, limit int) ([]Note, error)
+
+	// ListNotesWithMetadata lists all notes, or those in a folder, with ids, dates, and shared/locked flags
+	ListNotesWithMetadata(ctx context.Context, folder string) ([]Note, error)
+
+	// ListNotesByPrefix lists notes whose titles begin with prefix, optionally within a folder, sorted by title
+	ListNotesByPrefix(ctx context.Context, prefix, folder string) ([]Note, error)
+
+	// GetNotesModifiedBetween retrieves notes modified in the half-open range [from, to), newest first
+	GetNotesModifiedBetween(ctx context.Context, from, to time.Time) ([]Note, error)
+
+	// WithNoteMetrics adds word count, read time, and checklist/attachment flags to notes
+	WithNoteMetrics(ctx context.Context, notes []Note) ([]Note, error)
+
+	// ExtractActionItems parses checklist items from a note's body
+	ExtractActionItems(ctx context.Context, noteTitle string) ([]ActionItem, error)
+
+	// FindActionItems collects action items from every note whose title or body matches query
+	FindActionItems(ctx context.Context, query, folder string) ([]ActionItem, error)
+
+	// GetUpcomingDeadlines collects open action items with due dates in the next days days, soonest first
+	GetUpcomingDeadlines(ctx context.Context, days int, folder string) ([]ActionItem, error)
+}
+
+// NoteWriter creates, changes, and deletes notes
+type NoteWriter interface {
+	// CreateNote creates a new note in Apple Notes
+	CreateNote(ctx context.Context, title, content string, tags []string) (*Note, error)
+
+	// UpdateNote updates an existing note's content by title
+	UpdateNote(ctx context.Context, title, content string) error
+
+	// UpdateNoteIfUnchanged updates a note only if it still matches the caller's precondition
+	UpdateNoteIfUnchanged(ctx context.Context, title, content string, precondition UpdatePrecondition) error
+
+	// DeleteNote deletes a note by title
+	DeleteNote(ctx context.Context, title string) error
+
+	// UpdateNoteByID replaces the body of the note with the given ID, for notes sharing a title
+	UpdateNoteByID(ctx context.Context, noteID, content string) error
+
+	// DeleteNoteByID deletes the note with the given ID, for notes sharing a title
+	DeleteNoteByID(ctx context.Context, noteID string) error
+
+	// PinNote pins a note, via Shortcuts when enabled with AppleScript fallback
+	PinNote(ctx context.Context, title string) error
+
+	// AddNoteTags adds tags to a note, via Shortcuts when enabled with AppleScript fallback
+	AddNoteTags(ctx context.Context, title string, tags []string) error
+
+	// ImportHTMLFile creates a note from a sanitized HTML file with its source URL at the top
+	ImportHTMLFile(ctx context.Context, filePath, sourceURL, folder string) (*ImportResult, error)
+
+	// ClipURL creates a note from the readable article on a web page
+	ClipURL(ctx context.Context, pageURL, folder string) (*Note, error)
+
+	// GenerateWeeklyDigest saves a digest of the week's notes, outlines, and action items as a new note
+	GenerateWeeklyDigest(ctx context.Context, weekStart time.Time, digestFolder string) (*Note, error)
+}
+
+// FolderManager lists and organizes folders
+type FolderManager interface {
+	// ListFolders lists all folders in Apple Notes
+	ListFolders(ctx context.Context) ([]string, error)
+
 	// CreateFolder creates a new folder in Apple Notes
 	CreateFolder(ctx context.Context, name string, parentFolder string) error
 
 	// MoveNote moves a note to a different folder
 	MoveNote(ctx context.Context, noteTitle string, targetFolder string) error
 
+	// MoveNoteByID moves the note with the given ID to a different folder, for notes sharing a title
+	MoveNoteByID(ctx context.Context, noteID string, targetFolder string) error
+
 	// GetFolderHierarchy retrieves the complete folder hierarchy with note counts
 	GetFolderHierarchy(ctx context.Context) (*FolderNode, error)
+}
 
+// AttachmentReader lists and reads the files attached to notes
+type AttachmentReader interface {
 	// GetNoteAttachments retrieves all attachments for a note
 	GetNoteAttachments(ctx context.Context, noteTitle string) ([]Attachment, error)
 
 	// GetAttachmentContent retrieves the content of an attachment from its file path
 	GetAttachmentContent(ctx context.Context, filePath string, maxSize int64) ([]byte, error)
 
+	// ReadAttachmentChunk reads a byte range of an attachment file
+	ReadAttachmentChunk(ctx context.Context, filePath string, offset, length int64) (*AttachmentChunk, error)
+
+	// CopyAttachment copies an attachment file into a directory without loading it into memory
+	CopyAttachment(ctx context.Context, filePath string, destDir string) (*AttachmentCopy, error)
+
+	// GetAttachmentThumbnail downsizes a note's image attachment to a small JPEG
+	GetAttachmentThumbnail(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*Thumbnail, error)
+
+	// TranscribeAttachment transcribes a note's audio attachment, optionally appending the text to the note
+	TranscribeAttachment(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*Transcript, error)
+}
+
+// Exporter converts notes to markdown and plain text
+type Exporter interface {
 	// ExportNoteMarkdown exports a note as markdown by converting HTML body to markdown
 	ExportNoteMarkdown(ctx context.Context, noteTitle string) (string, error)
 
+	// ExportNoteMarkdownWithOptions exports a note as markdown with images as placeholders, data URIs, or files
+	ExportNoteMarkdownWithOptions(ctx context.Context, noteTitle string, opts MarkdownExportOptions) (string, error)
+
 	// ExportNoteText exports a note as plain text using AppleScript plaintext property
 	ExportNoteText(ctx context.Context, noteTitle string) (string, error)
+
+	// ExportNoteObsidian exports a note as Obsidian-flavored markdown, copying attachments into assetsDir
+	ExportNoteObsidian(ctx context.Context, noteTitle string, assetsDir string) (string, error)
+}
+
+// AppIntegrations reaches apps beside the notes store: Notes.app itself, Reminders, and Calendar
+type AppIntegrations interface {
+	// OpenNote shows a note in Notes.app and brings the app to the front
+	OpenNote(ctx context.Context, title string) error
+
+	// CreateReminder creates an Apple Reminders item that references the given note
+	CreateReminder(ctx context.Context, noteTitle, text, list string, dueDate *time.Time) (*Reminder, error)
+
+	// PushActionItemsToReminders creates reminders for all unchecked action items in a note
+	PushActionItemsToReminders(ctx context.Context, noteTitle, list string) ([]Reminder, error)
+
+	// GetTodaysEvents retrieves today's Apple Calendar events whose title contains the query
+	GetTodaysEvents(ctx context.Context, query string) ([]CalendarEvent, error)
 }
 
 // Note represents a note entity
 type Note struct {
-	ID                string    `json:"id"`
-	Title             string    `json:"title"`
-	Content           string    `json:"content,omitempty"`
-	Tags              []string  `json:"tags"`
-	Created           time.Time `json:"created"`
-	Modified          time.Time `json:"modified"`
-	CreationDate      time.Time `json:"creation_date"`
-	ModificationDate  time.Time `json:"modification_date"`
-	Folder            string    `json:"folder"`
-	Shared            bool      `json:"shared"`
-	PasswordProtected bool      `json:"password_protected"`
+	ID                string       `json:"id"`
+	Title             string       `json:"title"`
+	Content           string       `json:"content,omitempty"`
+	Tags              []string     `json:"tags"`
+	Created           time.Time    `json:"created"`
+	Modified          time.Time    `json:"modified"`
+	CreationDate      time.Time    `json:"creation_date"`
+	ModificationDate  time.Time    `json:"modification_date"`
+	Folder            string       `json:"folder"`
+	Shared            bool         `json:"shared"`
+	PasswordProtected bool         `json:"password_protected"`
+	ContentHash       string       `json:"content_hash,omitempty"`
+	Metrics           *NoteMetrics `json:"metrics,omitempty"`
 }
 
 // Attachment represents a file attachment in a note
@@ -94,10 +214,19 @@ type Attachment struct {
 
 // FolderNode represents a folder in the hierarchical structure
 type FolderNode struct {
+	ID        string       `json:"id,omitempty"`
 	Name      string       `json:"name"`
+	Account   string       `json:"account,omitempty"`
 	Shared    bool         `json:"shared"`
 	Children  []FolderNode `json:"children,omitempty"`
 	NoteCount int          `json:"note_count"`
+
+	// LastModified is the newest modification date of the folder's own notes
+	// Notes.app exposes no dates for folders themselves, so this is nil for empty folders
+	LastModified *time.Time `json:"last_modified,omitempty"`
+
+	// TotalNoteCount includes the notes in every descendant folder
+	TotalNoteCount int `json:"total_note_count"`
 }
 
 // SearchOptions contains parameters for advanced note search
@@ -107,6 +236,23 @@ type SearchOptions struct {
 	Folder   string     // optional: limit to folder
 	DateFrom *time.Time // optional: filter by date range
 	DateTo   *time.Time // optional: filter by date range
+	Backend  string     // optional: "applescript" (default) or "spotlight"
+
+	// MatchHTML matches body queries against the raw HTML body instead of its plain text,
+	// so tag names and attributes can match
+	MatchHTML bool
+
+	// Property filters: when set, only notes with the property match
+	HasAttachments bool
+	HasChecklist   bool
+	Shared         bool
+	Locked         bool
+
+	// ExcludeFolders skips notes whose folder has one of these names (e.g. "Recently Deleted")
+	ExcludeFolders []string
+
+	// shardFolderID limits a body search to one folder, by ID, when a whole-library search is sharded
+	shardFolderID string
 }
 
 // Search location constants
@@ -120,6 +266,38 @@ const (
 type AppleNotesService struct {
 	executor      ScriptExecutor
 	iCloudAccount string
+
+	// Spotlight index used when SearchOptions.Backend is SearchBackendSpotlight
+	spotlight SpotlightSearcher
+
+	// Image downsizing for GetAttachmentThumbnail
+	resizer ImageResizer
+
+	// Notes group container searched for attachment files AppleScript doesn't give a path for
+	containerDir string
+
+	// Downloads web pages for ClipURL
+	fetcher PageFetcher
+
+	// Optional speech-to-text for TranscribeAttachment (see SetTranscriber)
+	transcriber Transcriber
+
+	// Optional Shortcuts routing for operations AppleScript handles poorly (see UseShortcuts)
+	shortcuts          ShortcutRunner
+	shortcutOperations map[string]bool
+
+	// Formats for {{date}}, {{time}}, and {{week}} placeholders in CreateNote titles
+	titleFormats TitleFormats
+
+	// How many per-note scripts multi-note operations run at once (see SetConcurrency)
+	concurrency int
+
+	// Most notes a body search may read through AppleScript; 0 means no limit (see SetMaxBodySearchNotes)
+	maxBodySearchNotes int
+
+	// Recently listed folder names and note titles, for did-you-mean suggestions on not-found errors
+	folderCache nameCache
+	titleCache  nameCache
 }
 
 // NewAppleNotesService creates a new AppleNotesService with the provided executor
@@ -127,7 +305,20 @@ func NewAppleNotesService(executor ScriptExecutor) *AppleNotesService {
 	return &AppleNotesService{
 		executor:      executor,
 		iCloudAccount: "iCloud",
+		spotlight:     NewMDFindSearcher(10 * time.Second),
+		resizer:       NewSipsResizer(15 * time.Second),
+		containerDir:  defaultContainerDir(),
+		fetcher:       NewHTTPPageFetcher(20 * time.Second),
+	}
+}
+
+// defaultContainerDir returns the Notes group container, or "" when there is no home directory
+func defaultContainerDir() string {
+	dir, err := NotesContainerDir()
+	if err != nil {
+		return ""
 	}
+	return dir
 }
 
 // escapeForAppleScript escapes special characters for use in AppleScript strings
@@ -155,16 +346,24 @@ func (s *AppleNotesService) formatContent(content string) string {
 // Returns Note with full metadata including creation/modification dates, folder, and sharing status
 // Tags are stored in the Note struct but not passed to AppleScript (matching TypeScript behavior)
 func (s *AppleNotesService) CreateNote(ctx context.Context, title, content string, tags []string) (*Note, error) {
+	// Expand {{date}}-style placeholders when the caller asked for it, and store the title
+	// composed (NFC) so later lookups typed on a keyboard match it exactly
+	title = normalizeTitle(expandTitleTemplate(ctx, title, time.Now(), s.titleFormats))
+
 	// Format content and escape title
 	formattedContent := s.formatContent(content)
+	if isHTMLBody(ctx) {
+		formattedContent = s.escapeForAppleScript(content)
+	}
 	safeTitle := s.escapeForAppleScript(title)
 
-	// Generate AppleScript to create note
+	// Generate AppleScript to create note, returning its ID so a note sharing the title isn't mistaken for it
 	// Note: tags are not passed to AppleScript as the API doesn't support them
 	script := fmt.Sprintf(`
 		tell application "Notes"
 			tell account "%s"
-				make new note with properties {name:"%s", body:"%s"}
+				set newNote to make new note with properties {name:"%s", body:"%s"}
+				return id of newNote
 			end tell
 		end tell
 	`, s.iCloudAccount, safeTitle, formattedContent)
@@ -177,8 +376,7 @@ func (s *AppleNotesService) CreateNote(ctx context.Context, title, content strin
 		return nil, fmt.Errorf("failed to create note: %w", detectedErr)
 	}
 
-	// Log success (stdout might contain confirmation)
-	_ = stdout
+	s.titleCache.invalidate()
 
 	// Get full metadata for the newly created note
 	note, err := s.GetNoteMetadata(ctx, title)
@@ -187,6 +385,9 @@ func (s *AppleNotesService) CreateNote(ctx context.Context, title, content strin
 	}
 
 	// Populate fields not available from metadata
+	if id := strings.TrimSpace(stdout); id != "" {
+		note.ID = id
+	}
 	note.Content = content
 	note.Tags = tags
 
@@ -196,7 +397,7 @@ func (s *AppleNotesService) CreateNote(ctx context.Context, title, content strin
 // SearchNotes searches for notes containing the query string in their title
 // Returns notes with full metadata but empty Content (search doesn't retrieve full bodies)
 func (s *AppleNotesService) SearchNotes(ctx context.Context, query string) ([]Note, error) {
-	safeQuery := s.escapeForAppleScript(query)
+	safeQuery := s.escapeForAppleScript(normalizeTitle(query))
 
 	// Generate AppleScript to search notes
 	// Use custom delimiter to avoid issues with note titles containing commas
@@ -232,21 +433,32 @@ func (s *AppleNotesService) SearchNotes(ctx context.Context, query string) ([]No
 	}
 
 	// Parse delimiter-separated output
-	titles := strings.Split(stdout, "|||")
-	notes := make([]Note, 0, len(titles))
-
-	// Get full metadata for each note
-	for _, title := range titles {
-		title = strings.TrimSpace(title)
-		if title == "" {
-			continue
+	titles := []string{}
+	for _, title := range strings.Split(stdout, "|||") {
+		if title = strings.TrimSpace(title); title != "" {
+			titles = append(titles, title)
 		}
+	}
 
-		// Get metadata for this note
-		note, err := s.GetNoteMetadata(ctx, title)
+	// Get full metadata for each note, a few at a time
+	found := make([]*Note, len(titles))
+	err = forEachBounded(ctx, len(titles), s.workerLimit(), func(ctx context.Context, i int) error {
+		note, err := s.GetNoteMetadata(ctx, titles[i])
 		if err != nil {
-			// Log error but continue with other notes
+			// Skip this note but continue with the others
 			// Return partial results rather than failing completely
+			return nil
+		}
+		found[i] = note
+		return nil
+	})
+	if err != nil {
+		return []Note{}, fmt.Errorf("failed to search notes: %w", err)
+	}
+
+	notes := make([]Note, 0, len(titles))
+	for _, note := range found {
+		if note == nil {
 			continue
 		}
 
@@ -262,6 +474,7 @@ func (s *AppleNotesService) SearchNotes(ctx context.Context, query string) ([]No
 
 // GetNoteContent retrieves the full HTML body content of a note by its title
 func (s *AppleNotesService) GetNoteContent(ctx context.Context, title string) (string, error) {
+	title = normalizeTitle(title)
 	safeTitle := s.escapeForAppleScript(title)
 
 	// Generate AppleScript to get note content
@@ -278,7 +491,10 @@ func (s *AppleNotesService) GetNoteContent(ctx context.Context, title string) (s
 	if err != nil {
 		// Detect and wrap the error
 		detectedErr := DetectError(ctx, stderr, err)
-		return "", fmt.Errorf("failed to get note content: %w", detectedErr)
+		if actual, ok := s.tolerantTitle(ctx, title, detectedErr); ok {
+			return s.GetNoteContent(ctx, actual)
+		}
+		return "", s.withNoteSuggestions(ctx, title, fmt.Errorf("failed to get note content: %w", detectedErr))
 	}
 
 	return stdout, nil
@@ -287,6 +503,7 @@ func (s *AppleNotesService) GetNoteContent(ctx context.Context, title string) (s
 // UpdateNote updates the content of an existing note by its title
 func (s *AppleNotesService) UpdateNote(ctx context.Context, title, content string) error {
 	// Format content and escape title
+	title = normalizeTitle(title)
 	formattedContent := s.formatContent(content)
 	safeTitle := s.escapeForAppleScript(title)
 
@@ -304,7 +521,7 @@ func (s *AppleNotesService) UpdateNote(ctx context.Context, title, content strin
 	if err != nil {
 		// Detect and wrap the error appropriately
 		detectedErr := DetectError(ctx, stderr, err)
-		return fmt.Errorf("failed to update note: %w", detectedErr)
+		return s.withNoteSuggestions(ctx, title, fmt.Errorf("failed to update note: %w", detectedErr))
 	}
 
 	// Log success (stdout might contain confirmation)
@@ -313,9 +530,39 @@ func (s *AppleNotesService) UpdateNote(ctx context.Context, title, content strin
 	return nil
 }
 
+// OpenNote activates Notes.app and shows the note with the given title
+func (s *AppleNotesService) OpenNote(ctx context.Context, title string) error {
+	title = normalizeTitle(title)
+	safeTitle := s.escapeForAppleScript(title)
+
+	// Generate AppleScript to show the note; activate afterwards so the window comes to the front
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set theNote to note "%s"
+			end tell
+			show theNote
+			activate
+		end tell
+	`, s.iCloudAccount, safeTitle)
+
+	// Execute the script
+	_, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		if actual, ok := s.tolerantTitle(ctx, title, detectedErr); ok {
+			return s.OpenNote(ctx, actual)
+		}
+		return s.withNoteSuggestions(ctx, title, fmt.Errorf("failed to open note: %w", detectedErr))
+	}
+
+	return nil
+}
+
 // DeleteNote deletes a note by its title
 func (s *AppleNotesService) DeleteNote(ctx context.Context, title string) error {
 	// Escape title
+	title = normalizeTitle(title)
 	safeTitle := s.escapeForAppleScript(title)
 
 	// Generate AppleScript to delete note
@@ -332,12 +579,13 @@ func (s *AppleNotesService) DeleteNote(ctx context.Context, title string) error
 	if err != nil {
 		// Detect and wrap the error appropriately
 		detectedErr := DetectError(ctx, stderr, err)
-		return fmt.Errorf("failed to delete note: %w", detectedErr)
+		return s.withNoteSuggestions(ctx, title, fmt.Errorf("failed to delete note: %w", detectedErr))
 	}
 
 	// Log success (stdout might contain confirmation)
 	_ = stdout
 
+	s.titleCache.invalidate()
 	return nil
 }
 
@@ -388,6 +636,7 @@ func (s *AppleNotesService) ListFolders(ctx context.Context) ([]string, error) {
 		result = append(result, folder)
 	}
 
+	s.folderCache.set(result)
 	return result, nil
 }
 
@@ -465,7 +714,7 @@ func (s *AppleNotesService) GetNotesInFolder(ctx context.Context, folder string)
 	if err != nil {
 		// Detect and wrap the error
 		detectedErr := DetectError(ctx, stderr, err)
-		return []Note{}, fmt.Errorf("failed to get notes in folder: %w", detectedErr)
+		return []Note{}, s.withFolderSuggestions(ctx, folder, fmt.Errorf("failed to get notes in folder: %w", detectedErr))
 	}
 
 	// If output is empty, return empty slice
@@ -498,9 +747,127 @@ func (s *AppleNotesService) GetNotesInFolder(ctx context.Context, folder string)
 	return notes, nil
 }
 
+// GetRecentNotesInFolder retrieves the most recently modified notes in a folder with their real dates
+// Notes are sorted newest first; a limit of 0 or less returns every note in the folder
+func (s *AppleNotesService) GetRecentNotesInFolder(ctx context.Context, folder string, limit int) ([]Note, error) {
+	safeFolder := s.escapeForAppleScript(folder)
+
+	// Dates are emitted in ISO 8601 («class isot») so parsing does not depend on the system locale
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set output to ""
+				repeat with n in notes in folder "%s"
+					set createdText to ((creation date of n) as «class isot» as string)
+					set modifiedText to ((modification date of n) as «class isot» as string)
+					set output to output & (id of n as text) & "|||" & (name of n) & "|||" & "%s" & "|||" & createdText & "|||" & modifiedText & linefeed
+				end repeat
+				return output
+			end tell
+		end tell
+	`, s.iCloudAccount, safeFolder, safeFolder)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return []Note{}, s.withFolderSuggestions(ctx, folder, fmt.Errorf("failed to get recent notes in folder: %w", detectedErr))
+	}
+
+	notes := parseDatedNotes(stdout)
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Modified.After(notes[j].Modified)
+	})
+
+	if limit > 0 && len(notes) > limit {
+		notes = notes[:limit]
+	}
+
+	return notes, nil
+}
+
+// GetNotesModifiedBetween retrieves notes modified at or after from and before to, newest first
+func (s *AppleNotesService) GetNotesModifiedBetween(ctx context.Context, from, to time.Time) ([]Note, error) {
+	if !to.After(from) {
+		return []Note{}, fmt.Errorf("%w: end of date range must be after its start", ErrInvalidInput)
+	}
+
+	script := appleScriptDate("rangeStart", from) + appleScriptDate("rangeEnd", to) + fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set output to ""
+				set candidateNotes to notes whose modification date ≥ rangeStart and modification date < rangeEnd
+				repeat with n in candidateNotes
+					set folderName to ""
+					try
+						set folderName to name of container of n
+					end try
+					set createdText to ((creation date of n) as «class isot» as string)
+					set modifiedText to ((modification date of n) as «class isot» as string)
+					set output to output & (id of n as text) & "|||" & (name of n) & "|||" & folderName & "|||" & createdText & "|||" & modifiedText & linefeed
+				end repeat
+				return output
+			end tell
+		end tell
+	`, s.iCloudAccount)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return []Note{}, fmt.Errorf("failed to get notes modified in range: %w", detectedErr)
+	}
+
+	notes := parseDatedNotes(stdout)
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Modified.After(notes[j].Modified)
+	})
+
+	return notes, nil
+}
+
+// parseDatedNotes parses linefeed/"|||" delimited id, name, folder, creation and modification dates
+// Lines that do not have five fields or valid ISO 8601 dates are skipped
+func parseDatedNotes(output string) []Note {
+	notes := []Note{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "|||")
+		if len(fields) != 5 {
+			continue
+		}
+
+		created, err := time.ParseInLocation("2006-01-02T15:04:05", fields[3], time.Local)
+		if err != nil {
+			continue
+		}
+		modified, err := time.ParseInLocation("2006-01-02T15:04:05", fields[4], time.Local)
+		if err != nil {
+			continue
+		}
+
+		notes = append(notes, Note{
+			ID:               fields[0],
+			Title:            fields[1],
+			Tags:             []string{},
+			Created:          created,
+			Modified:         modified,
+			CreationDate:     created,
+			ModificationDate: modified,
+			Folder:           fields[2],
+		})
+	}
+
+	return notes
+}
+
 // GetNoteMetadata retrieves full metadata for a note including dates, folder, and sharing info
 // This method ensures both timestamp field sets are synchronized (Created/CreationDate, Modified/ModificationDate)
 func (s *AppleNotesService) GetNoteMetadata(ctx context.Context, title string) (*Note, error) {
+	title = normalizeTitle(title)
 	safeTitle := s.escapeForAppleScript(title)
 
 	// Generate AppleScript to get note metadata
@@ -525,7 +892,10 @@ func (s *AppleNotesService) GetNoteMetadata(ctx context.Context, title string) (
 	if err != nil {
 		// Detect and wrap the error
 		detectedErr := DetectError(ctx, stderr, err)
-		return nil, fmt.Errorf("failed to get note metadata: %w", detectedErr)
+		if actual, ok := s.tolerantTitle(ctx, title, detectedErr); ok {
+			return s.GetNoteMetadata(ctx, actual)
+		}
+		return nil, s.withNoteSuggestions(ctx, title, fmt.Errorf("failed to get note metadata: %w", detectedErr))
 	}
 
 	// Parse the AppleScript record output
@@ -672,14 +1042,16 @@ func (s *AppleNotesService) CreateFolder(ctx context.Context, name string, paren
 	if err != nil {
 		// Detect and wrap the error
 		detectedErr := DetectError(ctx, stderr, err)
-		return fmt.Errorf("failed to create folder: %w", detectedErr)
+		return s.withFolderSuggestions(ctx, parentFolder, fmt.Errorf("failed to create folder: %w", detectedErr))
 	}
 
+	s.folderCache.invalidate()
 	return nil
 }
 
 // MoveNote moves a note to a different folder
 func (s *AppleNotesService) MoveNote(ctx context.Context, noteTitle string, targetFolder string) error {
+	noteTitle = normalizeTitle(noteTitle)
 	safeTitle := s.escapeForAppleScript(noteTitle)
 	safeFolder := s.escapeForAppleScript(targetFolder)
 
@@ -699,7 +1071,8 @@ func (s *AppleNotesService) MoveNote(ctx context.Context, noteTitle string, targ
 	if err != nil {
 		// Detect and wrap the error
 		detectedErr := DetectError(ctx, stderr, err)
-		return fmt.Errorf("failed to move note: %w", detectedErr)
+		return s.withNoteSuggestions(ctx, noteTitle,
+			s.withFolderSuggestions(ctx, targetFolder, fmt.Errorf("failed to move note: %w", detectedErr)))
 	}
 
 	return nil
@@ -712,7 +1085,13 @@ func (s *AppleNotesService) GetFolderHierarchy(ctx context.Context) (*FolderNode
 		tell application "Notes"
 			tell account "%s"
 				on getFolderInfo(fld)
-					set folderInfo to {name:(name of fld), shared:(shared of fld), noteCount:(count of notes in fld), children:{}}
+					set newest to missing value
+					repeat with modified in (modification date of notes in fld)
+						if newest is missing value or (contents of modified) > newest then set newest to contents of modified
+					end repeat
+					set lastModified to ""
+					if newest is not missing value then set lastModified to (newest as «class isot» as string)
+					set folderInfo to {id:(id of fld as text), name:(name of fld), shared:(shared of fld), noteCount:(count of notes in fld), lastModified:lastModified, children:{}}
 					set childFolders to {}
 					repeat with childFld in (folders of fld)
 						copy (my getFolderInfo(childFld)) to end of childFolders
@@ -745,6 +1124,8 @@ func (s *AppleNotesService) GetFolderHierarchy(ctx context.Context) (*FolderNode
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse folder hierarchy: %w", err)
 	}
+	countTotalNotes(hierarchy)
+	setFolderAccount(hierarchy, s.iCloudAccount)
 
 	return hierarchy, nil
 }
@@ -852,11 +1233,29 @@ func (s *AppleNotesService) parseRecordField(input string, start int, node *Fold
 func (s *AppleNotesService) parseFieldValue(input string, pos int, key string, node *FolderNode) (int, error) {
 	var err error
 	switch key {
+	case "id":
+		node.ID, pos, err = s.parseString(input, pos)
+		if err != nil {
+			return pos, fmt.Errorf("failed to parse id: %w", err)
+		}
 	case "name":
 		node.Name, pos, err = s.parseString(input, pos)
 		if err != nil {
 			return pos, fmt.Errorf("failed to parse name: %w", err)
 		}
+	case "lastModified":
+		var value string
+		value, pos, err = s.parseString(input, pos)
+		if err != nil {
+			return pos, fmt.Errorf("failed to parse lastModified: %w", err)
+		}
+		if value != "" {
+			modified, err := time.ParseInLocation("2006-01-02T15:04:05", value, time.Local)
+			if err != nil {
+				return pos, fmt.Errorf("failed to parse lastModified: %w", err)
+			}
+			node.LastModified = &modified
+		}
 	case "shared":
 		node.Shared, pos, err = s.parseBool(input, pos)
 		if err != nil {
@@ -1019,22 +1418,48 @@ func (s *AppleNotesService) parseChildRecords(input string, start int) ([]Folder
 	return children, pos, nil
 }
 
+// Delimiters for script output whose values may hold any text: ASCII record and unit
+// separators can't appear in note bodies, attachment names, or paths, unlike the newlines,
+// braces, and commas that broke parsing of AppleScript record text
+const (
+	recordSeparator = "\x1e"
+	unitSeparator   = "\x1f"
+)
+
+// attachmentFieldCount is the number of fields in each GetNoteAttachments record
+const attachmentFieldCount = 6
+
 // GetNoteAttachments retrieves all attachments for a note
 func (s *AppleNotesService) GetNoteAttachments(ctx context.Context, noteTitle string) ([]Attachment, error) {
 	safeTitle := s.escapeForAppleScript(noteTitle)
 
-	// Generate AppleScript to get note attachments
+	// Each attachment is one record of id, name, content identifier, POSIX path, created, modified.
+	// contents is a file reference only for files Notes has saved locally, so it falls back to "".
 	script := fmt.Sprintf(`
 		tell application "Notes"
 			tell account "%s"
 				set theNote to note "%s"
-				set attList to attachments of theNote
-				set result to ""
-				repeat with att in attList
-					set attInfo to {id:(id of att as text), name:(name of att), contents:(contents of att), creation date:(creation date of att), modification date:(modification date of att)}
-					set result to result & attInfo & linefeed
+				set recordSep to (ASCII character 30)
+				set fieldSep to (ASCII character 31)
+				set output to ""
+				repeat with att in attachments of theNote
+					set attName to ""
+					try
+						set attName to name of att
+					end try
+					set attIdentifier to ""
+					try
+						set attIdentifier to content identifier of att
+					end try
+					set attPath to ""
+					try
+						set attPath to POSIX path of (contents of att)
+					end try
+					set createdText to ((creation date of att) as «class isot» as string)
+					set modifiedText to ((modification date of att) as «class isot» as string)
+					set output to output & (id of att as text) & fieldSep & attName & fieldSep & attIdentifier & fieldSep & attPath & fieldSep & createdText & fieldSep & modifiedText & recordSep
 				end repeat
-				return result
+				return output
 			end tell
 		end tell
 	`, s.iCloudAccount, safeTitle)
@@ -1047,78 +1472,57 @@ func (s *AppleNotesService) GetNoteAttachments(ctx context.Context, noteTitle st
 		return []Attachment{}, fmt.Errorf("failed to get note attachments: %w", detectedErr)
 	}
 
-	// Parse attachments
-	attachments, err := s.parseAttachments(stdout)
-	if err != nil {
-		return []Attachment{}, fmt.Errorf("failed to parse attachments: %w", err)
-	}
-
+	attachments := parseAttachments(stdout)
+	resolveAttachmentPaths(s.containerDir, attachments)
 	return attachments, nil
 }
 
-// parseAttachments parses AppleScript attachment output into Attachment slice
-// Each line contains attachment metadata in record format
-func (s *AppleNotesService) parseAttachments(output string) ([]Attachment, error) {
-	output = strings.TrimSpace(output)
-	if output == "" {
-		return []Attachment{}, nil
-	}
+// parseAttachments parses separator-delimited attachment records into an Attachment slice
+// Field values are kept verbatim, so names may contain newlines, braces, or quotes.
+// Records with the wrong number of fields are skipped.
+func parseAttachments(output string) []Attachment {
+	attachments := []Attachment{}
 
-	lines := strings.Split(output, "\n")
-	attachments := make([]Attachment, 0, len(lines))
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	for _, record := range strings.Split(output, recordSeparator) {
+		// osascript appends a newline after the last record
+		record = strings.Trim(record, "\r\n")
+		if record == "" {
 			continue
 		}
 
-		attachment := Attachment{}
-
-		// Parse ID
-		if id := extractField(line, "id"); id != "" {
-			attachment.ID = id
-			attachment.ContentIdentifier = id
+		fields := strings.Split(record, unitSeparator)
+		if len(fields) != attachmentFieldCount {
+			continue
 		}
 
-		// Parse name
-		if name := extractField(line, "name"); name != "" {
-			attachment.Name = name
+		attachment := Attachment{
+			ID:                fields[0],
+			Name:              fields[1],
+			ContentIdentifier: fields[2],
+			FilePath:          strings.TrimPrefix(fields[3], "file://"),
 		}
-
-		// Parse contents (file path)
-		if contents := extractField(line, "contents"); contents != "" {
-			// Remove file:// prefix if present
-			filePath := strings.TrimPrefix(contents, "file://")
-			attachment.FilePath = filePath
+		if created, err := time.ParseInLocation("2006-01-02T15:04:05", fields[4], time.Local); err == nil {
+			attachment.CreationDate = created
 		}
-
-		// Parse creation date
-		if creationDateStr := extractDateField(line, "creation date"); creationDateStr != "" {
-			creationDate, err := s.parseAppleScriptDate(creationDateStr)
-			if err == nil {
-				attachment.CreationDate = creationDate
-			}
-		}
-
-		// Parse modification date
-		if modificationDateStr := extractDateField(line, "modification date"); modificationDateStr != "" {
-			modificationDate, err := s.parseAppleScriptDate(modificationDateStr)
-			if err == nil {
-				attachment.ModificationDate = modificationDate
-			}
+		if modified, err := time.ParseInLocation("2006-01-02T15:04:05", fields[5], time.Local); err == nil {
+			attachment.ModificationDate = modified
 		}
 
 		attachments = append(attachments, attachment)
 	}
 
-	return attachments, nil
+	return attachments
 }
 
 // SearchNotesAdvanced searches for notes with advanced filtering options
 // Supports searching in title, body, or both, with optional folder and date range filters
 // For performance: when searching body, folder/date filters are applied first to reduce dataset
 func (s *AppleNotesService) SearchNotesAdvanced(ctx context.Context, opts SearchOptions) ([]Note, error) {
+	return s.searchNotesAdvanced(ctx, opts, nil)
+}
+
+// searchNotesAdvanced runs an advanced search, recording how it ran in explain when it isn't nil
+func (s *AppleNotesService) searchNotesAdvanced(ctx context.Context, opts SearchOptions, explain *SearchExplanation) ([]Note, error) {
 	// Validate and normalize SearchIn parameter
 	searchIn := opts.SearchIn
 	if searchIn == "" {
@@ -1128,9 +1532,55 @@ func (s *AppleNotesService) SearchNotesAdvanced(ctx context.Context, opts Search
 		return []Note{}, err
 	}
 
+	// Try the Spotlight index first when requested; fall through to AppleScript if it can't answer
+	fallback := ""
+	switch opts.Backend {
+	case "", SearchBackendAppleScript:
+	case SearchBackendSpotlight:
+		var notes []Note
+		if notes, fallback = s.trySpotlight(ctx, searchIn, opts, explain); fallback == "" {
+			return notes, nil
+		}
+		tracef(ctx, "Spotlight search fell back to AppleScript: %s", fallback)
+	default:
+		return []Note{}, fmt.Errorf("%w: invalid search backend %q (must be '%s' or '%s')",
+			ErrInvalidInput, opts.Backend, SearchBackendAppleScript, SearchBackendSpotlight)
+	}
+
+	// Whole-library body searches run as concurrent per-folder scripts when the library is large enough;
+	// each folder script is held to the body search limit, so this comes before the whole-library guard
+	if searchIn != SearchInTitle && opts.Folder == "" && s.workerLimit() > 1 {
+		notes, sharded, err := s.searchShards(ctx, searchIn, opts, explain)
+		if err != nil {
+			return []Note{}, err
+		}
+		if sharded {
+			return notes, nil
+		}
+	}
+
+	// Body searches read every candidate's body, so large ones switch to Spotlight or are refused
+	if searchIn != SearchInTitle && s.maxBodySearchNotes > 0 {
+		notes, switched, err := s.guardBodySearch(ctx, searchIn, opts, fallback, explain)
+		if err != nil {
+			return []Note{}, err
+		}
+		if switched {
+			return notes, nil
+		}
+	}
+
 	// Build and execute search script
 	script := s.buildSearchScript(searchIn, opts)
+	if explain != nil {
+		explain.Strategy = searchStrategy(searchIn, opts)
+		explain.Script = script
+	}
+	start := time.Now()
 	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if explain != nil {
+		explain.Timings.ScriptMS = durationMS(time.Since(start))
+	}
 	if err != nil {
 		detectedErr := DetectError(ctx, stderr, err)
 		return []Note{}, fmt.Errorf("failed to search notes: %w", detectedErr)
@@ -1151,28 +1601,105 @@ func (s *AppleNotesService) validateSearchIn(searchIn string) error {
 // buildSearchScript builds the appropriate AppleScript based on search requirements
 func (s *AppleNotesService) buildSearchScript(searchIn string, opts SearchOptions) string {
 	safeQuery := s.escapeForAppleScript(opts.Query)
-	needsFiltering := opts.Folder != "" || opts.DateFrom != nil || opts.DateTo != nil
-	isBodySearch := searchIn == SearchInBody || searchIn == SearchInBoth
 
-	// Strategy: For body search with filters, apply folder/date filters first to reduce dataset
-	if isBodySearch && needsFiltering {
+	switch searchStrategy(searchIn, opts) {
+	case SearchStrategyFilteredBody:
+		// For body search with filters, apply folder/date filters first to reduce dataset
 		return s.buildFilteredBodySearch(safeQuery, searchIn, opts)
-	}
-
-	// Use switch for cleaner code
-	switch searchIn {
-	case SearchInTitle:
-		return s.buildTitleSearch(safeQuery, opts)
-	case SearchInBody:
+	case SearchStrategyBodyScan:
 		return s.buildBodySearch(safeQuery, opts)
-	case SearchInBoth:
+	case SearchStrategyBothScan:
 		return s.buildBothSearch(safeQuery, opts)
 	default:
-		// This should never happen due to validation, but handle defensively
 		return s.buildTitleSearch(safeQuery, opts)
 	}
 }
 
+// bodyProperty returns the note property body queries are matched against
+// The plaintext property keeps queries from hitting markup and finds text that formatting splits
+func (opts SearchOptions) bodyProperty() string {
+	if opts.MatchHTML {
+		return "body"
+	}
+	return "plaintext"
+}
+
+// hasNoteFilters reports whether any per-note filter is set: excluded folders or the
+// attachment, checklist, shared, and locked properties
+func (opts SearchOptions) hasNoteFilters() bool {
+	return len(opts.ExcludeFolders) > 0 || opts.HasAttachments || opts.HasChecklist || opts.Shared || opts.Locked
+}
+
+// checklistMarkup is the class attribute markup that marks checklist items in a Notes HTML body,
+// the same "checked" and "unchecked" classes parseActionItems reads
+// Matching the attribute rather than the bare word keeps notes that only mention "checked" out;
+// text in the body is HTML-escaped, so it can't produce the quoted attribute.
+var checklistMarkup = []string{`class="checked"`, `class="unchecked"`}
+
+// hasChecklistMarkup reports whether an HTML body contains checklist markup, as the AppleScript filter checks it
+func hasChecklistMarkup(body string) bool {
+	for _, markup := range checklistMarkup {
+		if strings.Contains(body, markup) {
+			return true
+		}
+	}
+	return false
+}
+
+// keepNoteScript wraps inner, AppleScript run for the current note n, in if blocks that only run it
+// when n is inside the date range, outside every excluded folder, and has every filtered property.
+// Cheap property reads come first, joined with "and" so AppleScript stops at the first that fails;
+// the checklist check reads the HTML body once, only for notes that pass the rest.
+func (s *AppleNotesService) keepNoteScript(opts SearchOptions, inner string) string {
+	var conditions []string
+	if opts.DateFrom != nil {
+		conditions = append(conditions, "modification date of n >= filterFrom")
+	}
+	if opts.DateTo != nil {
+		conditions = append(conditions, "modification date of n <= filterTo")
+	}
+	if len(opts.ExcludeFolders) > 0 {
+		names := make([]string, len(opts.ExcludeFolders))
+		for i, folder := range opts.ExcludeFolders {
+			names[i] = `"` + s.escapeForAppleScript(folder) + `"`
+		}
+		conditions = append(conditions, "not ({"+strings.Join(names, ", ")+"} contains (name of container of n))")
+	}
+	if opts.Locked {
+		conditions = append(conditions, "(password protected of n)")
+	}
+	if opts.Shared {
+		conditions = append(conditions, "(shared of n)")
+	}
+	if opts.HasAttachments {
+		conditions = append(conditions, "(count of attachments of n) > 0")
+	}
+
+	if opts.HasChecklist {
+		markup := make([]string, len(checklistMarkup))
+		for i, m := range checklistMarkup {
+			markup[i] = `noteBody contains "` + s.escapeForAppleScript(m) + `"`
+		}
+		inner = fmt.Sprintf(`
+					set noteBody to body of n
+					if %s then
+						%s
+					end if
+		`, strings.Join(markup, " or "), strings.TrimSpace(inner))
+	}
+	if len(conditions) == 0 {
+		return inner
+	}
+	return fmt.Sprintf(`
+					if %s then
+						%s
+					end if
+		`, strings.Join(conditions, " and "), strings.TrimSpace(inner))
+}
+
+// maxSearchResultCount is the most notes a search returns
+const maxSearchResultCount = 100
+
 // parseSearchResults parses delimiter-separated output from AppleScript into Note slice
 // Uses "|||" delimiter to avoid issues with note titles containing commas
 func (s *AppleNotesService) parseSearchResults(stdout string) []Note {
@@ -1203,7 +1730,7 @@ func (s *AppleNotesService) parseSearchResults(stdout string) []Note {
 		})
 
 		count++
-		if count >= 100 {
+		if count >= maxSearchResultCount {
 			break
 		}
 	}
@@ -1213,7 +1740,7 @@ func (s *AppleNotesService) parseSearchResults(stdout string) []Note {
 
 // buildTitleSearch builds AppleScript for title-only search with optional filters
 func (s *AppleNotesService) buildTitleSearch(safeQuery string, opts SearchOptions) string {
-	if opts.Folder == "" && opts.DateFrom == nil && opts.DateTo == nil {
+	if opts.Folder == "" && opts.DateFrom == nil && opts.DateTo == nil && !opts.hasNoteFilters() {
 		// Simple title search (fast path)
 		// Use custom delimiter to avoid issues with note titles containing commas
 		return fmt.Sprintf(`
@@ -1235,7 +1762,7 @@ func (s *AppleNotesService) buildTitleSearch(safeQuery string, opts SearchOption
 	}
 
 	// Title search with filters
-	script := fmt.Sprintf(`
+	script := opts.dateFilterScript() + fmt.Sprintf(`
 		tell application "Notes"
 			tell account "%s"
 				set matchedNotes to {}
@@ -1254,31 +1781,14 @@ func (s *AppleNotesService) buildTitleSearch(safeQuery string, opts SearchOption
 		`, safeQuery)
 	}
 
-	// Apply date filters if present
+	// Apply date and property filters if present
 	// Date filtering creates an inclusive range: notes modified >= DateFrom AND <= DateTo
-	// Implementation: skip notes outside the range (< DateFrom or > DateTo)
-	if opts.DateFrom != nil || opts.DateTo != nil {
+	if opts.DateFrom != nil || opts.DateTo != nil || opts.hasNoteFilters() {
 		script += `
 				repeat with n in candidateNotes
 		`
-		if opts.DateFrom != nil {
-			dateStr := s.formatAppleScriptDate(*opts.DateFrom)
-			script += fmt.Sprintf(`
-					if modification date of n < date "%s" then
-						next repeat
-					end if
-			`, dateStr)
-		}
-		if opts.DateTo != nil {
-			dateStr := s.formatAppleScriptDate(*opts.DateTo)
-			script += fmt.Sprintf(`
-					if modification date of n > date "%s" then
-						next repeat
-					end if
-			`, dateStr)
-		}
+		script += s.keepNoteScript(opts, "copy name of n to end of matchedNotes")
 		script += `
-					copy name of n to end of matchedNotes
 				end repeat
 				set oldDelimiters to AppleScript's text item delimiters
 				set AppleScript's text item delimiters to "|||"
@@ -1315,7 +1825,7 @@ func (s *AppleNotesService) buildBodySearch(safeQuery string, opts SearchOptions
 				set matchedNotes to {}
 				set allNotes to notes
 				repeat with n in allNotes
-					if body of n contains "%s" then
+					if %s of n contains "%s" then
 						copy name of n to end of matchedNotes
 					end if
 				end repeat
@@ -1326,7 +1836,7 @@ func (s *AppleNotesService) buildBodySearch(safeQuery string, opts SearchOptions
 				return result
 			end tell
 		end tell
-	`, s.iCloudAccount, safeQuery)
+	`, s.iCloudAccount, opts.bodyProperty(), safeQuery)
 }
 
 // buildBothSearch builds AppleScript for searching both title and body (no filters)
@@ -1337,7 +1847,7 @@ func (s *AppleNotesService) buildBothSearch(safeQuery string, opts SearchOptions
 				set matchedNotes to {}
 				set allNotes to notes
 				repeat with n in allNotes
-					if (name of n contains "%s") or (body of n contains "%s") then
+					if (name of n contains "%s") or (%s of n contains "%s") then
 						copy name of n to end of matchedNotes
 					end if
 				end repeat
@@ -1348,20 +1858,25 @@ func (s *AppleNotesService) buildBothSearch(safeQuery string, opts SearchOptions
 				return result
 			end tell
 		end tell
-	`, s.iCloudAccount, safeQuery, safeQuery)
+	`, s.iCloudAccount, safeQuery, opts.bodyProperty(), safeQuery)
 }
 
 // buildFilteredBodySearch builds AppleScript for body search with pre-filtering
 // This is the performance optimization: filter by folder/date FIRST, then search body in subset
 func (s *AppleNotesService) buildFilteredBodySearch(safeQuery string, searchIn string, opts SearchOptions) string {
-	script := fmt.Sprintf(`
+	script := opts.dateFilterScript() + fmt.Sprintf(`
 		tell application "Notes"
 			tell account "%s"
 				set matchedNotes to {}
 	`, s.iCloudAccount)
 
-	// Get initial candidate set (folder filter)
-	if opts.Folder != "" {
+	// Get initial candidate set (folder filter, or one folder shard of a whole-library search)
+	if opts.shardFolderID != "" {
+		script += fmt.Sprintf(`
+				set targetFolder to folder id "%s"
+				set candidateNotes to notes of targetFolder
+		`, s.escapeForAppleScript(opts.shardFolderID))
+	} else if opts.Folder != "" {
 		safeFolder := s.escapeForAppleScript(opts.Folder)
 		script += fmt.Sprintf(`
 				set targetFolder to folder "%s"
@@ -1373,45 +1888,26 @@ func (s *AppleNotesService) buildFilteredBodySearch(safeQuery string, searchIn s
 		`
 	}
 
-	// Filter by date and search body
+	// Filter by date and properties, then search the body (and title if "both") of the notes that pass
 	// Date filtering creates an inclusive range: notes modified >= DateFrom AND <= DateTo
-	// Implementation: skip notes outside the range (< DateFrom or > DateTo)
-	script += `
-				repeat with n in candidateNotes
-	`
-
-	// Apply date filters
-	if opts.DateFrom != nil {
-		dateStr := s.formatAppleScriptDate(*opts.DateFrom)
-		script += fmt.Sprintf(`
-					if modification date of n < date "%s" then
-						next repeat
-					end if
-		`, dateStr)
-	}
-	if opts.DateTo != nil {
-		dateStr := s.formatAppleScriptDate(*opts.DateTo)
-		script += fmt.Sprintf(`
-					if modification date of n > date "%s" then
-						next repeat
-					end if
-		`, dateStr)
-	}
-
-	// Search in body (and title if "both")
+	var match string
 	if searchIn == "both" {
-		script += fmt.Sprintf(`
-					if (name of n contains "%s") or (body of n contains "%s") then
+		match = fmt.Sprintf(`
+					if (name of n contains "%s") or (%s of n contains "%s") then
 						copy name of n to end of matchedNotes
 					end if
-		`, safeQuery, safeQuery)
+		`, safeQuery, opts.bodyProperty(), safeQuery)
 	} else {
-		script += fmt.Sprintf(`
-					if body of n contains "%s" then
+		match = fmt.Sprintf(`
+					if %s of n contains "%s" then
 						copy name of n to end of matchedNotes
 					end if
-		`, safeQuery)
+		`, opts.bodyProperty(), safeQuery)
 	}
+	script += `
+				repeat with n in candidateNotes
+	`
+	script += s.keepNoteScript(opts, match)
 
 	script += `
 				end repeat
@@ -1427,10 +1923,34 @@ func (s *AppleNotesService) buildFilteredBodySearch(safeQuery string, searchIn s
 	return script
 }
 
-// formatAppleScriptDate formats a time.Time into AppleScript date string
-// AppleScript dates: "Monday, January 1, 2024 at 10:00:00 AM"
-func (s *AppleNotesService) formatAppleScriptDate(t time.Time) string {
-	return t.Format("Monday, January 2, 2006 at 3:04:05 PM")
+// appleScriptDate returns statements setting the AppleScript variable name to t in the Mac's local time
+// The date is built field by field rather than parsed from text like date "Monday, January 1, 2024",
+// whose format AppleScript reads by the system locale and can misparse outside the US. The day is
+// set to 1 first so moving from the 31st into a shorter month can't overflow. The statements use
+// Standard Additions, so they belong before any tell block.
+func appleScriptDate(name string, t time.Time) string {
+	t = t.Local()
+	clock := t.Hour()*3600 + t.Minute()*60 + t.Second()
+	return fmt.Sprintf(`
+		set %[1]s to current date
+		set day of %[1]s to 1
+		set year of %[1]s to %[2]d
+		set month of %[1]s to %[3]d
+		set day of %[1]s to %[4]d
+		set time of %[1]s to %[5]d
+	`, name, t.Year(), int(t.Month()), t.Day(), clock)
+}
+
+// dateFilterScript returns statements setting filterFrom and filterTo for the date range in opts
+func (opts SearchOptions) dateFilterScript() string {
+	script := ""
+	if opts.DateFrom != nil {
+		script += appleScriptDate("filterFrom", *opts.DateFrom)
+	}
+	if opts.DateTo != nil {
+		script += appleScriptDate("filterTo", *opts.DateTo)
+	}
+	return script
 }
 
 // GetAttachmentContent retrieves the content of an attachment from its file path
@@ -1488,27 +2008,19 @@ func (s *AppleNotesService) ExportNoteText(ctx context.Context, noteTitle string
 // ExportNoteMarkdown exports a note as markdown by converting HTML body to markdown
 // This is a stateless method that returns the markdown content without writing to filesystem
 // Uses basic HTML to markdown conversion for common elements
+// Images become attachment:<name> placeholder links; see ExportNoteMarkdownWithOptions.
 func (s *AppleNotesService) ExportNoteMarkdown(ctx context.Context, noteTitle string) (string, error) {
-	// Get the HTML body content first
-	htmlBody, err := s.GetNoteContent(ctx, noteTitle)
-	if err != nil {
-		return "", fmt.Errorf("failed to export note as markdown: %w", err)
-	}
-
-	// Convert HTML to markdown using basic conversion
-	markdown := s.convertHTMLToMarkdown(htmlBody)
-
-	return markdown, nil
+	return s.ExportNoteMarkdownWithOptions(ctx, noteTitle, MarkdownExportOptions{})
 }
 
 // convertHTMLToMarkdown performs basic HTML to markdown conversion
-// Handles common HTML elements like bold, italic, headings, lists, and links
-func (s *AppleNotesService) convertHTMLToMarkdown(html string) string {
-	if html == "" {
+// Handles common HTML elements like bold, italic, headings, lists, and links, and decodes entities
+func convertHTMLToMarkdown(body string) string {
+	if body == "" {
 		return ""
 	}
 
-	result := html
+	result := body
 
 	// Convert headings (h1-h6)
 	result = regexp.MustCompile(`<h1[^>]*>(.*?)</h1>`).ReplaceAllString(result, "# $1\n")
@@ -1519,15 +2031,18 @@ func (s *AppleNotesService) convertHTMLToMarkdown(html string) string {
 	result = regexp.MustCompile(`<h6[^>]*>(.*?)</h6>`).ReplaceAllString(result, "###### $1\n")
 
 	// Convert bold
-	result = regexp.MustCompile(`<b[^>]*>(.*?)</b>`).ReplaceAllString(result, "**$1**")
+	result = regexp.MustCompile(`<b(?:\s[^>]*)?>(.*?)</b>`).ReplaceAllString(result, "**$1**")
 	result = regexp.MustCompile(`<strong[^>]*>(.*?)</strong>`).ReplaceAllString(result, "**$1**")
 
 	// Convert italic
-	result = regexp.MustCompile(`<i[^>]*>(.*?)</i>`).ReplaceAllString(result, "*$1*")
+	result = regexp.MustCompile(`<i(?:\s[^>]*)?>(.*?)</i>`).ReplaceAllString(result, "*$1*")
 	result = regexp.MustCompile(`<em[^>]*>(.*?)</em>`).ReplaceAllString(result, "*$1*")
 
 	// Convert links
-	result = regexp.MustCompile(`<a[^>]*href="([^"]*)"[^>]*>(.*?)</a>`).ReplaceAllString(result, "[$2]($1)")
+	result = regexp.MustCompile(`<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`).ReplaceAllString(result, "[$2]($1)")
+
+	// Convert images, which would otherwise be stripped with the other tags
+	result = imgTagPattern.ReplaceAllStringFunc(result, markdownImage)
 
 	// Convert list items
 	result = regexp.MustCompile(`<li[^>]*>(.*?)</li>`).ReplaceAllString(result, "- $1\n")
@@ -1536,10 +2051,14 @@ func (s *AppleNotesService) convertHTMLToMarkdown(html string) string {
 	result = regexp.MustCompile(`<br\s*/?>`).ReplaceAllString(result, "\n")
 
 	// Convert paragraphs
-	result = regexp.MustCompile(`<p[^>]*>(.*?)</p>`).ReplaceAllString(result, "$1\n\n")
+	result = regexp.MustCompile(`<p(?:\s[^>]*)?>(.*?)</p>`).ReplaceAllString(result, "$1\n\n")
+
+	// Apple Notes stores each line as a <div>, so closing divs end a line
+	result = regexp.MustCompile(`</div>`).ReplaceAllString(result, "\n")
 
-	// Remove remaining HTML tags (div, ul, ol, etc.)
+	// Remove remaining HTML tags (div, ul, ol, etc.), then decode entities such as &amp; in the text left
 	result = regexp.MustCompile(`<[^>]+>`).ReplaceAllString(result, "")
+	result = html.UnescapeString(result)
 
 	// Clean up multiple newlines
 	result = regexp.MustCompile(`\n{3,}`).ReplaceAllString(result, "\n\n")
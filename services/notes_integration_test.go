@@ -489,6 +489,58 @@ func TestSearchNotesAdvanced_DateFilterIntegration(t *testing.T) {
 	t.Log("Manual cleanup required in Apple Notes")
 }
 
+// TestDateFiltersAcrossZonesIntegration checks date filters against a note created now, with the range
+// expressed in zones other than the Mac's so a locale or timezone misparse would move the window
+func TestDateFiltersAcrossZonesIntegration(t *testing.T) {
+	executor := NewOSAScriptExecutor(30 * time.Second)
+	service := NewAppleNotesService(executor)
+	ctx := context.Background()
+
+	noteTitle := uniqueTestName("IntTest_DateZones")
+	if _, err := service.CreateNote(ctx, noteTitle, "Content for date zone test", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	created := time.Now()
+	defer service.DeleteNote(ctx, noteTitle) //nolint:errcheck // best-effort cleanup
+
+	// Fixtures: the same instants seen from zones on both sides of UTC, and a window that ended yesterday
+	zones := []*time.Location{time.UTC, time.FixedZone("UTC+13", 13*3600), time.FixedZone("UTC-11", -11*3600)}
+	for _, zone := range zones {
+		from := created.Add(-10 * time.Minute).In(zone)
+		to := created.Add(10 * time.Minute).In(zone)
+
+		notes, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: noteTitle, SearchIn: SearchInTitle, DateFrom: &from, DateTo: &to})
+		if err != nil {
+			t.Fatalf("SearchNotesAdvanced in %s failed: %v", zone, err)
+		}
+		if len(notes) != 1 {
+			t.Errorf("expected the new note inside the window in %s, got %d notes", zone, len(notes))
+		}
+
+		modified, err := service.GetNotesModifiedBetween(ctx, from, to)
+		if err != nil {
+			t.Fatalf("GetNotesModifiedBetween in %s failed: %v", zone, err)
+		}
+		found := false
+		for _, note := range modified {
+			found = found || note.Title == noteTitle
+		}
+		if !found {
+			t.Errorf("expected GetNotesModifiedBetween to include the new note in %s", zone)
+		}
+	}
+
+	yesterday := created.AddDate(0, 0, -1)
+	dayBefore := created.AddDate(0, 0, -2)
+	notes, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: noteTitle, SearchIn: SearchInTitle, DateFrom: &dayBefore, DateTo: &yesterday})
+	if err != nil {
+		t.Fatalf("SearchNotesAdvanced (past window) failed: %v", err)
+	}
+	if len(notes) != 0 {
+		t.Errorf("expected no notes in a window that ended yesterday, got %d", len(notes))
+	}
+}
+
 // TestGetNoteAttachmentsIntegration tests retrieving attachments from a note
 // Note: This test requires manual setup - create a note with attachments before running
 func TestGetNoteAttachmentsIntegration(t *testing.T) {
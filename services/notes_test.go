@@ -6,9 +6,11 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -32,9 +34,12 @@ type SequentialMockExecutor struct {
 		err    error
 	}
 	callIndex int
+	mu        sync.Mutex
 }
 
 func (m *SequentialMockExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.callIndex >= len(m.responses) {
 		return "", "", fmt.Errorf("unexpected call to Execute (call %d, only %d responses configured)", m.callIndex, len(m.responses))
 	}
@@ -371,8 +376,8 @@ func TestCreateNote(t *testing.T) {
 			stderr string
 			err    error
 		}{
-			{stdout: "note created", stderr: "", err: nil},   // CreateNote response
-			{stdout: metadataResponse, stderr: "", err: nil}, // GetNoteMetadata response
+			{stdout: "x-coredata://12345", stderr: "", err: nil}, // CreateNote response
+			{stdout: metadataResponse, stderr: "", err: nil},     // GetNoteMetadata response
 		},
 	}
 
@@ -517,6 +522,7 @@ func TestSearchNotes(t *testing.T) {
 	}
 
 	service := NewAppleNotesService(executor)
+	service.SetConcurrency(1) // metadata responses are consumed in call order
 	ctx := context.Background()
 
 	notes, err := service.SearchNotes(ctx, "notes")
@@ -795,6 +801,35 @@ func TestDeleteNote(t *testing.T) {
 	}
 }
 
+// TestOpenNote tests showing a note in Notes.app
+func TestOpenNote(t *testing.T) {
+	executor := &MockExecutor{}
+
+	service := NewAppleNotesService(executor)
+
+	if err := service.OpenNote(context.Background(), "Test Note"); err != nil {
+		t.Fatalf("OpenNote failed: %v", err)
+	}
+}
+
+// TestOpenNoteNotFound tests note not found error when opening a note
+func TestOpenNoteNotFound(t *testing.T) {
+	executor := &MockExecutor{
+		stderr: `Can't get note "Missing". (-1728)`,
+		err:    errors.New("exit status 1"),
+	}
+
+	service := NewAppleNotesService(executor)
+
+	err := service.OpenNote(context.Background(), "Missing")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "failed to open note") {
+		t.Errorf("Expected wrapped open error, got %v", err)
+	}
+}
+
 // TestDeleteNoteWithSpecialCharacters tests escaping in note deletion
 func TestDeleteNoteWithSpecialCharacters(t *testing.T) {
 	executor := &MockExecutor{
@@ -907,6 +942,125 @@ func TestListFoldersEmpty(t *testing.T) {
 	}
 }
 
+// TestGetRecentNotesInFolder tests that folder notes are sorted newest first with real dates
+func TestGetRecentNotesInFolder(t *testing.T) {
+	executor := &MockExecutor{
+		stdout: "id-1|||Old Plan|||Project X|||2024-01-01T09:00:00|||2024-01-02T09:00:00\n" +
+			"id-2|||Standup|||Project X|||2024-01-03T09:00:00|||2024-01-05T17:30:00\n" +
+			"malformed line\n" +
+			"id-3|||Retro|||Project X|||2024-01-04T09:00:00|||2024-01-04T12:00:00\n",
+	}
+
+	service := NewAppleNotesService(executor)
+	notes, err := service.GetRecentNotesInFolder(context.Background(), "Project X", 2)
+	if err != nil {
+		t.Fatalf("GetRecentNotesInFolder failed: %v", err)
+	}
+
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Title != "Standup" || notes[1].Title != "Retro" {
+		t.Errorf("unexpected order: %q, %q", notes[0].Title, notes[1].Title)
+	}
+
+	want := time.Date(2024, 1, 5, 17, 30, 0, 0, time.Local)
+	if !notes[0].Modified.Equal(want) || !notes[0].ModificationDate.Equal(want) {
+		t.Errorf("Modified = %v, want %v", notes[0].Modified, want)
+	}
+	if notes[0].ID != "id-2" || notes[0].Folder != "Project X" {
+		t.Errorf("unexpected note fields: %+v", notes[0])
+	}
+}
+
+// TestGetNotesModifiedBetween tests date-range listing with per-note folders
+func TestGetNotesModifiedBetween(t *testing.T) {
+	executor := &MockExecutor{
+		stdout: "id-1|||Plan|||Work|||2024-01-01T09:00:00|||2024-01-02T09:00:00\n" +
+			"id-2|||Groceries||||||2024-01-03T09:00:00|||2024-01-04T08:15:00\n",
+	}
+
+	service := NewAppleNotesService(executor)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 7)
+
+	notes, err := service.GetNotesModifiedBetween(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("GetNotesModifiedBetween failed: %v", err)
+	}
+
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Title != "Groceries" || notes[0].Folder != "" {
+		t.Errorf("unexpected first note: %+v", notes[0])
+	}
+	if notes[1].Folder != "Work" {
+		t.Errorf("Folder = %q, want %q", notes[1].Folder, "Work")
+	}
+}
+
+// TestAppleScriptDate tests that dates are built field by field in local time, not parsed from text
+func TestAppleScriptDate(t *testing.T) {
+	// 23:30 UTC on Jan 31 falls on a different local day in most zones; the script must use the local fields
+	instant := time.Date(2024, 1, 31, 23, 30, 5, 0, time.UTC)
+	local := instant.Local()
+	script := appleScriptDate("d", instant)
+
+	for _, want := range []string{
+		"set d to current date",
+		"set day of d to 1\n",
+		fmt.Sprintf("set year of d to %d", local.Year()),
+		fmt.Sprintf("set month of d to %d", int(local.Month())),
+		fmt.Sprintf("set day of d to %d", local.Day()),
+		fmt.Sprintf("set time of d to %d", local.Hour()*3600+local.Minute()*60+local.Second()),
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected %q in:\n%s", want, script)
+		}
+	}
+	if strings.Index(script, "set day of d to 1\n") > strings.Index(script, "set month of d") {
+		t.Error("the day must be reset before the month changes")
+	}
+}
+
+// TestDateFiltersAvoidDateLiterals tests that date-filtered scripts never parse locale-formatted date strings
+func TestDateFiltersAvoidDateLiterals(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2024, 3, 31, 23, 59, 59, 0, time.Local)
+	executor := &storedTitleExecutor{}
+	service := NewAppleNotesService(executor)
+	service.SetConcurrency(1) // one script per search, without folder sharding
+	ctx := context.Background()
+
+	_, _ = service.GetNotesModifiedBetween(ctx, from, to)
+	for _, searchIn := range []string{SearchInTitle, SearchInBody} {
+		_, _ = service.SearchNotesAdvanced(ctx, SearchOptions{Query: "plan", SearchIn: searchIn, DateFrom: &from, DateTo: &to})
+	}
+	if len(executor.scripts) != 3 {
+		t.Fatalf("expected 3 scripts, got %d", len(executor.scripts))
+	}
+	for _, script := range executor.scripts {
+		if strings.Contains(script, `date "`) {
+			t.Errorf("script parses a date string:\n%s", script)
+		}
+		if !strings.Contains(script, "set month of") || strings.Index(script, "current date") > strings.Index(script, `tell application "Notes"`) {
+			t.Errorf("expected dates built before the tell block:\n%s", script)
+		}
+	}
+}
+
+// TestGetNotesModifiedBetweenInvalidRange tests that an empty or inverted range is rejected
+func TestGetNotesModifiedBetweenInvalidRange(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+	_, err := service.GetNotesModifiedBetween(context.Background(), day, day)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
 // TestListFoldersWithError tests error handling in folder listing
 func TestListFoldersWithError(t *testing.T) {
 	executor := &MockExecutor{
@@ -1250,11 +1404,39 @@ func TestGetFolderHierarchy(t *testing.T) {
 	}
 }
 
+// TestGetFolderHierarchyMetadata tests folder IDs, the account, and last-modified dates
+func TestGetFolderHierarchyMetadata(t *testing.T) {
+	appleScriptOutput := `{name:"iCloud", shared:false, noteCount:0, children:{{id:"x-coredata://folder/p1", name:"Work", shared:false, noteCount:2, lastModified:"2024-03-05T14:30:00", children:{{id:"x-coredata://folder/p2", name:"Empty", shared:false, noteCount:0, lastModified:"", children:{}}}}}}`
+
+	service := NewAppleNotesService(&MockExecutor{stdout: appleScriptOutput})
+	hierarchy, err := service.GetFolderHierarchy(context.Background())
+	if err != nil {
+		t.Fatalf("GetFolderHierarchy failed: %v", err)
+	}
+
+	work := hierarchy.Children[0]
+	if work.ID != "x-coredata://folder/p1" || work.Account != "iCloud" {
+		t.Errorf("Work id/account = %q/%q", work.ID, work.Account)
+	}
+	want := time.Date(2024, 3, 5, 14, 30, 0, 0, time.Local)
+	if work.LastModified == nil || !work.LastModified.Equal(want) {
+		t.Errorf("Work last modified = %v, want %v", work.LastModified, want)
+	}
+
+	empty := work.Children[0]
+	if empty.LastModified != nil {
+		t.Errorf("expected no last modified date for an empty folder, got %v", empty.LastModified)
+	}
+	if empty.Account != "iCloud" {
+		t.Errorf("nested folder account = %q", empty.Account)
+	}
+}
+
 // TestGetNoteAttachments tests retrieval of attachments for a note
 func TestGetNoteAttachments(t *testing.T) {
-	// AppleScript returns attachment list
-	appleScriptOutput := `{id:"x-coredata://att1", name:"document.pdf", contents:"file:///Users/test/document.pdf", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 15, 2024 at 3:30:00 PM"}
-{id:"x-coredata://att2", name:"image.png", contents:"file:///Users/test/image.png", creation date:date "Monday, January 2, 2024 at 11:00:00 AM", modification date:date "Monday, January 16, 2024 at 4:30:00 PM"}`
+	// AppleScript returns separator-delimited attachment records
+	appleScriptOutput := attachmentRecord("x-coredata://att1", "document.pdf", "cid-1", "/Users/test/document.pdf", "2024-01-01T10:00:00", "2024-01-15T15:30:00") +
+		attachmentRecord("x-coredata://att2", "image.png", "cid-2", "/Users/test/image.png", "2024-01-02T11:00:00", "2024-01-16T16:30:00") + "\n"
 
 	executor := &MockExecutor{
 		stdout: appleScriptOutput,
@@ -1293,6 +1475,50 @@ func TestGetNoteAttachments(t *testing.T) {
 	}
 }
 
+// attachmentRecord builds one record of GetNoteAttachments script output
+func attachmentRecord(fields ...string) string {
+	return strings.Join(fields, unitSeparator) + recordSeparator
+}
+
+// TestParseAttachments tests names and paths that broke the old line-per-record format
+func TestParseAttachments(t *testing.T) {
+	output := attachmentRecord("x-coredata://att1", "Scan {page 1},\n\"final\".pdf", "cid-1", "/Users/test/Library/Group Containers/Scan.pdf", "2024-01-01T10:00:00", "2024-01-15T15:30:00") +
+		attachmentRecord("x-coredata://att2", "", "cid-2", "", "2024-01-02T11:00:00", "2024-01-16T16:30:00") +
+		attachmentRecord("x-coredata://att3", "legacy.png", "", "file:///Users/test/legacy.png", "missing value", "") +
+		"truncated" + unitSeparator + "record" + recordSeparator + "\n"
+
+	attachments := parseAttachments(output)
+	if len(attachments) != 3 {
+		t.Fatalf("expected 3 attachments, got %d: %+v", len(attachments), attachments)
+	}
+
+	first := attachments[0]
+	if first.Name != "Scan {page 1},\n\"final\".pdf" {
+		t.Errorf("name = %q", first.Name)
+	}
+	if first.ContentIdentifier != "cid-1" || first.ID != "x-coredata://att1" {
+		t.Errorf("identifiers = %q/%q", first.ID, first.ContentIdentifier)
+	}
+	if first.FilePath != "/Users/test/Library/Group Containers/Scan.pdf" {
+		t.Errorf("file path = %q", first.FilePath)
+	}
+	if want := time.Date(2024, 1, 15, 15, 30, 0, 0, time.Local); !first.ModificationDate.Equal(want) {
+		t.Errorf("modification date = %v, want %v", first.ModificationDate, want)
+	}
+
+	if attachments[1].FilePath != "" || attachments[1].Name != "" {
+		t.Errorf("expected empty name and path, got %+v", attachments[1])
+	}
+
+	legacy := attachments[2]
+	if legacy.FilePath != "/Users/test/legacy.png" {
+		t.Errorf("file:// prefix not stripped: %q", legacy.FilePath)
+	}
+	if !legacy.CreationDate.IsZero() || !legacy.ModificationDate.IsZero() {
+		t.Errorf("expected zero dates for unparseable values, got %+v", legacy)
+	}
+}
+
 // TestGetNoteAttachmentsNoAttachments tests note with no attachments
 func TestGetNoteAttachmentsNoAttachments(t *testing.T) {
 	executor := &MockExecutor{
@@ -1552,6 +1778,138 @@ func TestSearchNotesAdvanced_BodySearchWithFilters(t *testing.T) {
 	}
 }
 
+// TestSearchNotesAdvanced_BodyProperty tests that body search matches plain text unless MatchHTML is set
+func TestSearchNotesAdvanced_BodyProperty(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+	dateFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, searchIn := range []string{SearchInBody, SearchInBoth} {
+		for _, opts := range []SearchOptions{
+			{Query: "div", SearchIn: searchIn},
+			{Query: "div", SearchIn: searchIn, Folder: "Work", DateFrom: &dateFrom},
+		} {
+			script := service.buildSearchScript(searchIn, opts)
+			if !strings.Contains(script, `plaintext of n contains "div"`) || strings.Contains(script, "body of n") {
+				t.Errorf("%s search should match plaintext:\n%s", searchIn, script)
+			}
+
+			opts.MatchHTML = true
+			script = service.buildSearchScript(searchIn, opts)
+			if !strings.Contains(script, `body of n contains "div"`) || strings.Contains(script, "plaintext") {
+				t.Errorf("%s search with MatchHTML should match the HTML body:\n%s", searchIn, script)
+			}
+		}
+	}
+}
+
+// TestSearchNotesAdvanced_PropertyFilters tests that property filters leave the fast path and wrap the match
+// in if blocks, with no "next repeat" (which AppleScript doesn't have)
+func TestSearchNotesAdvanced_PropertyFilters(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+	dateFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	plain := service.buildSearchScript(SearchInTitle, SearchOptions{Query: "plan"})
+	if strings.Contains(plain, "repeat with n in candidateNotes") {
+		t.Errorf("unfiltered title search should use the fast path:\n%s", plain)
+	}
+
+	for _, searchIn := range []string{SearchInTitle, SearchInBody, SearchInBoth} {
+		opts := SearchOptions{Query: "plan", SearchIn: searchIn, DateFrom: &dateFrom,
+			HasAttachments: true, HasChecklist: true, Shared: true, Locked: true}
+		script := service.buildSearchScript(searchIn, opts)
+		for _, want := range []string{
+			"if modification date of n >= filterFrom and (password protected of n) and (shared of n) and (count of attachments of n) > 0 then",
+			"set noteBody to body of n",
+			`if noteBody contains "class=\"checked\"" or noteBody contains "class=\"unchecked\"" then`,
+		} {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s search missing %q:\n%s", searchIn, want, script)
+			}
+		}
+		assertBalancedAppleScript(t, script)
+	}
+
+	script := service.buildSearchScript(SearchInTitle, SearchOptions{Query: "plan", Shared: true})
+	if !strings.Contains(script, "shared of n") || strings.Contains(script, "attachments of n") || strings.Contains(script, "noteBody") {
+		t.Errorf("only the requested filters should be applied:\n%s", script)
+	}
+}
+
+// assertBalancedAppleScript checks that every if and repeat block in a script is closed, and that
+// it never uses "next repeat"
+func assertBalancedAppleScript(t *testing.T, script string) {
+	t.Helper()
+	ifs, repeats := 0, 0
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "next repeat"):
+			t.Errorf("AppleScript has no next repeat:\n%s", script)
+		case strings.HasPrefix(line, "if ") && strings.HasSuffix(line, " then"):
+			ifs++
+		case line == "end if":
+			ifs--
+		case strings.HasPrefix(line, "repeat "):
+			repeats++
+		case line == "end repeat":
+			repeats--
+		}
+		if ifs < 0 || repeats < 0 {
+			t.Fatalf("block closed before it was opened:\n%s", script)
+		}
+	}
+	if ifs != 0 || repeats != 0 {
+		t.Errorf("unbalanced blocks (%d if, %d repeat left open):\n%s", ifs, repeats, script)
+	}
+}
+
+// TestHasChecklistMarkup tests the checklist check against Notes checklist HTML and prose that mentions "checked"
+func TestHasChecklistMarkup(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "unchecked item", body: `<div>Trip</div><ul><li class="unchecked">Pack</li></ul>`, want: true},
+		{name: "checked item", body: `<ul><li class="checked">Book hotel</li></ul>`, want: true},
+		{name: "checklist list", body: `<ul class="checklist"><li class="unchecked">Pack</li></ul>`, want: true},
+		{name: "prose", body: `<div>I checked the flights and unchecked the box</div>`, want: false},
+		{name: "quoted markup in text", body: `<div>class=&quot;checked&quot;</div>`, want: false},
+		{name: "plain list", body: `<ul><li>Milk</li></ul>`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasChecklistMarkup(tt.body); got != tt.want {
+				t.Errorf("hasChecklistMarkup(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+			if tt.want && len(parseActionItems(tt.body, "")) == 0 {
+				t.Errorf("expected parseActionItems to find the checklist in %q", tt.body)
+			}
+		})
+	}
+}
+
+// TestSearchNotesAdvanced_ExcludeFolders tests that excluded folders are skipped in every search path
+func TestSearchNotesAdvanced_ExcludeFolders(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+	dateFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := `not ({"Recently Deleted", "Say \"Hi\""} contains (name of container of n))`
+
+	for _, searchIn := range []string{SearchInTitle, SearchInBody, SearchInBoth} {
+		for _, opts := range []SearchOptions{
+			{Query: "plan", SearchIn: searchIn},
+			{Query: "plan", SearchIn: searchIn, Folder: "Work", DateFrom: &dateFrom},
+		} {
+			opts.ExcludeFolders = []string{"Recently Deleted", `Say "Hi"`}
+			script := service.buildSearchScript(searchIn, opts)
+			if !strings.Contains(script, want) {
+				t.Errorf("%s search missing folder exclusion:\n%s", searchIn, script)
+			}
+			assertBalancedAppleScript(t, script)
+		}
+	}
+}
+
 // TestSearchNotesAdvanced_InvalidSearchIn tests error handling for invalid SearchIn value
 func TestSearchNotesAdvanced_InvalidSearchIn(t *testing.T) {
 	executor := &MockExecutor{}
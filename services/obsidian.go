@@ -0,0 +1,163 @@
+// ABOUTME: Obsidian-flavored markdown export for Apple Notes
+// ABOUTME: Adds YAML front matter, [[wikilinks]] for note links, and copied attachment embeds
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// internalNoteLinkPattern matches anchors pointing at other Apple Notes notes
+var internalNoteLinkPattern = regexp.MustCompile(`(?is)<a[^>]*href="(?:applenotes:|notes://)[^"]*"[^>]*>(.*?)</a>`)
+
+// hashtagPattern matches inline #tags in note text
+var hashtagPattern = regexp.MustCompile(`(?:^|\s)#([\p{L}][\p{L}\p{N}_/-]*)`)
+
+// ExportNoteObsidian exports a note as Obsidian-flavored markdown
+// The output starts with YAML front matter (title, created, modified, tags, source id, folder),
+// links to other notes become [[wikilinks]], and attachments are embedded as ![[name]].
+// When assetsDir is non-empty, attachment files are copied into it; otherwise only embeds are written.
+func (s *AppleNotesService) ExportNoteObsidian(ctx context.Context, noteTitle string, assetsDir string) (string, error) {
+	note, err := s.GetNoteMetadata(ctx, noteTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to export note as obsidian markdown: %w", err)
+	}
+
+	htmlBody, err := s.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to export note as obsidian markdown: %w", err)
+	}
+
+	attachments, err := s.GetNoteAttachments(ctx, noteTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to export note as obsidian markdown: %w", err)
+	}
+
+	// Convert internal note links to wikilinks before the generic link conversion runs
+	htmlBody = internalNoteLinkPattern.ReplaceAllStringFunc(htmlBody, func(match string) string {
+		text := stripHTML(internalNoteLinkPattern.FindStringSubmatch(match)[1])
+		return "[[" + text + "]]"
+	})
+	// Give every attachment a safe, unique file name so embeds match the copied files
+	namer := NewFileNamer()
+	fileNames := make(map[string]string, len(attachments))
+	for _, attachment := range attachments {
+		if _, ok := fileNames[attachment.Name]; !ok {
+			fileNames[attachment.Name] = namer.FileName(attachment.Name)
+		}
+	}
+
+	// Embed images where they appear; those attachments aren't embedded again at the end
+	embedded := make(map[string]bool)
+	htmlBody = imgTagPattern.ReplaceAllStringFunc(htmlBody, func(tag string) string {
+		parts := imgSrcPattern.FindStringSubmatch(tag)
+		if parts == nil {
+			return tag
+		}
+		attachment := findReferencedAttachment(html.UnescapeString(parts[3]), attachments)
+		if attachment == nil {
+			return tag
+		}
+		embedded[attachment.Name] = true
+		return "![[" + fileNames[attachment.Name] + "]]"
+	})
+	markdown := convertHTMLToMarkdown(htmlBody)
+
+	note.Tags = extractHashtags(markdown)
+
+	var b strings.Builder
+	b.WriteString(formatObsidianFrontMatter(note))
+	b.WriteString(markdown)
+
+	// Copy attachments and append embeds
+	if len(attachments) > 0 {
+		b.WriteString("\n\n")
+		for _, attachment := range attachments {
+			name := fileNames[attachment.Name]
+			if assetsDir != "" && attachment.FilePath != "" {
+				if err := copyAttachmentFile(attachment.FilePath, filepath.Join(assetsDir, name)); err != nil {
+					return "", fmt.Errorf("failed to copy attachment %q: %w", attachment.Name, err)
+				}
+			}
+			if !embedded[attachment.Name] {
+				fmt.Fprintf(&b, "![[%s]]\n", name)
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
+}
+
+// formatObsidianFrontMatter renders a note's metadata as YAML front matter
+func formatObsidianFrontMatter(note *Note) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %q\n", note.Title)
+	if !note.CreationDate.IsZero() {
+		fmt.Fprintf(&b, "created: %s\n", note.CreationDate.Format(time.RFC3339))
+	}
+	if !note.ModificationDate.IsZero() {
+		fmt.Fprintf(&b, "modified: %s\n", note.ModificationDate.Format(time.RFC3339))
+	}
+	if len(note.Tags) == 0 {
+		b.WriteString("tags: []\n")
+	} else {
+		b.WriteString("tags:\n")
+		for _, tag := range note.Tags {
+			fmt.Fprintf(&b, "  - %q\n", tag)
+		}
+	}
+	fmt.Fprintf(&b, "source_id: %q\n", note.ID)
+	if note.Folder != "" {
+		fmt.Fprintf(&b, "folder: %q\n", note.Folder)
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+// extractHashtags returns the unique #tags found in text, in order of first appearance
+func extractHashtags(text string) []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, match := range hashtagPattern.FindAllStringSubmatch(text, -1) {
+		tag := match[1]
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// copyAttachmentFile copies an attachment file to dest, creating the destination directory if needed
+func copyAttachmentFile(src, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src) // #nosec G304 - src comes from Apple Notes attachment API
+	if err != nil {
+		return err
+	}
+	defer in.Close() //nolint:errcheck // read-only file close failure is non-critical
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) // #nosec G304 - dest is inside the caller's assets dir
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close() //nolint:errcheck,gosec // already returning the copy error
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,99 @@
+// ABOUTME: Unit tests for Obsidian-flavored markdown export
+// ABOUTME: Verifies front matter, wikilinks, hashtags, and attachment copying
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestExportNoteObsidian tests the full Obsidian export with an attachment
+func TestExportNoteObsidian(t *testing.T) {
+	tempDir := t.TempDir()
+	sourcePath := filepath.Join(tempDir, "source.png")
+	if err := os.WriteFile(sourcePath, []byte("png-bytes"), 0o600); err != nil {
+		t.Fatalf("failed to write source attachment: %v", err)
+	}
+	assetsDir := filepath.Join(tempDir, "assets")
+
+	metadata := `{id:"x-coredata://note/7", name:"Roadmap", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Tuesday, January 2, 2024 at 11:00:00 AM", container:"Work", shared:false, password protected:false}`
+	body := `<div>Plan for #q1 and #launch</div><div>See <a href="applenotes:note/ABC-123">Budget 2024</a> and <a href="https://example.com">site</a></div><div><img src="cid:CID-1"></div><div>after</div>`
+	attachments := attachmentRecord("att-1", "diagram.png", "cid-1", sourcePath, "2024-01-01T10:00:00", "2024-01-01T10:00:00")
+
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: metadata},
+			{stdout: body},
+			{stdout: attachments},
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+
+	markdown, err := service.ExportNoteObsidian(context.Background(), "Roadmap", assetsDir)
+	if err != nil {
+		t.Fatalf("ExportNoteObsidian failed: %v", err)
+	}
+
+	expectedParts := []string{
+		"---\ntitle: \"Roadmap\"\n",
+		"created: 2024-01-01T10:00:00Z\n",
+		"modified: 2024-01-02T11:00:00Z\n",
+		"tags:\n  - \"q1\"\n  - \"launch\"\n",
+		"source_id: \"x-coredata://note/7\"\n",
+		"folder: \"Work\"\n---\n",
+		"[[Budget 2024]]",
+		"[site](https://example.com)",
+		"![[diagram.png]]",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(markdown, part) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", part, markdown)
+		}
+	}
+
+	if !strings.Contains(markdown, "![[diagram.png]]\nafter") || strings.Count(markdown, "![[diagram.png]]") != 1 {
+		t.Errorf("expected the image embedded once where it appears, got:\n%s", markdown)
+	}
+
+	copied, err := os.ReadFile(filepath.Join(assetsDir, "diagram.png"))
+	if err != nil {
+		t.Fatalf("expected attachment to be copied: %v", err)
+	}
+	if string(copied) != "png-bytes" {
+		t.Errorf("copied attachment content = %q, want %q", copied, "png-bytes")
+	}
+}
+
+// TestFormatObsidianFrontMatterNoTags tests front matter for notes without tags or dates
+func TestFormatObsidianFrontMatterNoTags(t *testing.T) {
+	frontMatter := formatObsidianFrontMatter(&Note{ID: "id-1", Title: `Quote "this"`})
+
+	expected := "---\ntitle: \"Quote \\\"this\\\"\"\ntags: []\nsource_id: \"id-1\"\n---\n\n"
+	if frontMatter != expected {
+		t.Errorf("front matter = %q, want %q", frontMatter, expected)
+	}
+}
+
+// TestExtractHashtags tests hashtag detection and de-duplication
+func TestExtractHashtags(t *testing.T) {
+	tags := extractHashtags("# Heading\n#work and #work again, url.com/#anchor, #café-notes")
+
+	expected := []string{"work", "café-notes"}
+	if len(tags) != len(expected) {
+		t.Fatalf("tags = %v, want %v", tags, expected)
+	}
+	for i := range expected {
+		if tags[i] != expected[i] {
+			t.Errorf("tags[%d] = %q, want %q", i, tags[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// ABOUTME: PDF export for notes through the macOS printing pipeline
+// ABOUTME: Wraps a note's HTML body in a printable document and renders it with cupsfilter
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PDFRenderer renders a standalone HTML file to a PDF file
+type PDFRenderer interface {
+	Render(ctx context.Context, htmlPath, pdfPath string) error
+}
+
+// CUPSFilterRenderer implements PDFRenderer using the macOS cupsfilter command
+type CUPSFilterRenderer struct {
+	timeout time.Duration
+}
+
+// NewCUPSFilterRenderer creates a CUPSFilterRenderer with the specified timeout.
+// If timeout is 0 or negative, defaults to 30 seconds since large notes with images render slowly.
+func NewCUPSFilterRenderer(timeout time.Duration) *CUPSFilterRenderer {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &CUPSFilterRenderer{
+		timeout: timeout,
+	}
+}
+
+// Render runs cupsfilter on the HTML file and writes its PDF output to pdfPath
+func (r *CUPSFilterRenderer) Render(ctx context.Context, htmlPath, pdfPath string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "cupsfilter", "-i", "text/html", "-m", "application/pdf", htmlPath)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cupsfilter failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if !bytes.HasPrefix(stdout.Bytes(), []byte("%PDF")) {
+		return fmt.Errorf("cupsfilter did not produce a PDF: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return os.WriteFile(pdfPath, stdout.Bytes(), 0o644)
+}
+
+// ExportNotePDF renders a note's HTML body, including inline images, to a PDF at outputPath
+func (s *AppleNotesService) ExportNotePDF(ctx context.Context, noteTitle, outputPath string, renderer PDFRenderer) error {
+	body, err := s.GetNoteContent(ctx, noteTitle)
+	if err != nil {
+		return fmt.Errorf("failed to export note to PDF: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "notes-mcp-pdf-")
+	if err != nil {
+		return fmt.Errorf("failed to export note to PDF: %w", err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck // temp dir cleanup failure is non-critical
+
+	htmlPath := filepath.Join(dir, "note.html")
+	if err := os.WriteFile(htmlPath, []byte(buildPrintableHTML(noteTitle, body)), 0o600); err != nil {
+		return fmt.Errorf("failed to export note to PDF: %w", err)
+	}
+
+	if err := renderer.Render(ctx, htmlPath, outputPath); err != nil {
+		return fmt.Errorf("failed to export note to PDF: %w", err)
+	}
+
+	return nil
+}
+
+// buildPrintableHTML wraps a note body in a complete HTML document styled like Notes.app
+// The note body already starts with its title, so the title only goes in <title>
+func buildPrintableHTML(title, body string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+  body { font-family: -apple-system, "Helvetica Neue", sans-serif; font-size: 12pt; line-height: 1.4; margin: 0.75in; }
+  img { max-width: 100%%; height: auto; }
+  table { border-collapse: collapse; }
+  td, th { border: 1px solid #ccc; padding: 4px 8px; }
+  ul, ol { padding-left: 1.5em; }
+</style>
+</head>
+<body>
+%s
+</body>
+</html>
+`, html.EscapeString(title), body)
+}
@@ -0,0 +1,94 @@
+// ABOUTME: Unit tests for PDF export
+// ABOUTME: Tests printable HTML generation and renderer invocation with a mock renderer
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mockPDFRenderer records the HTML it was asked to render
+type mockPDFRenderer struct {
+	html    string
+	pdfPath string
+	err     error
+}
+
+func (m *mockPDFRenderer) Render(ctx context.Context, htmlPath, pdfPath string) error {
+	data, err := os.ReadFile(htmlPath)
+	if err != nil {
+		return err
+	}
+	m.html = string(data)
+	m.pdfPath = pdfPath
+	return m.err
+}
+
+// TestBuildPrintableHTML tests that the note body is wrapped in a styled document
+func TestBuildPrintableHTML(t *testing.T) {
+	body := `<div><h1>Trip</h1></div><div><img src="data:image/png;base64,AAAA"></div>`
+	doc := buildPrintableHTML(`Trip <Paris> & "Rome"`, body)
+
+	for _, want := range []string{
+		`<meta charset="utf-8">`,
+		"<title>Trip &lt;Paris&gt; &amp; &#34;Rome&#34;</title>",
+		"img { max-width: 100%; height: auto; }",
+		body,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document missing %q:\n%s", want, doc)
+		}
+	}
+}
+
+// TestExportNotePDF tests that the note's HTML is handed to the renderer
+func TestExportNotePDF(t *testing.T) {
+	executor := &MockExecutor{stdout: "<div><h1>Trip</h1></div><div>Pack bags</div>"}
+	service := NewAppleNotesService(executor)
+	renderer := &mockPDFRenderer{}
+	outputPath := filepath.Join(t.TempDir(), "Trip.pdf")
+
+	if err := service.ExportNotePDF(context.Background(), "Trip", outputPath, renderer); err != nil {
+		t.Fatalf("ExportNotePDF failed: %v", err)
+	}
+
+	if renderer.pdfPath != outputPath {
+		t.Errorf("pdfPath = %q, want %q", renderer.pdfPath, outputPath)
+	}
+	if !strings.Contains(renderer.html, "<div>Pack bags</div>") {
+		t.Errorf("rendered HTML missing note body:\n%s", renderer.html)
+	}
+}
+
+// TestExportNotePDFErrors tests that lookup and render failures are wrapped
+func TestExportNotePDFErrors(t *testing.T) {
+	t.Run("note not found", func(t *testing.T) {
+		executor := &MockExecutor{stderr: "note 'Missing' not found", err: ErrNoteNotFound}
+		service := NewAppleNotesService(executor)
+		renderer := &mockPDFRenderer{}
+
+		err := service.ExportNotePDF(context.Background(), "Missing", "out.pdf", renderer)
+		if !errors.Is(err, ErrNoteNotFound) {
+			t.Errorf("expected ErrNoteNotFound, got %v", err)
+		}
+		if renderer.pdfPath != "" {
+			t.Error("renderer should not run when the note is missing")
+		}
+	})
+
+	t.Run("render failure", func(t *testing.T) {
+		executor := &MockExecutor{stdout: "<div>Body</div>"}
+		service := NewAppleNotesService(executor)
+		renderer := &mockPDFRenderer{err: errors.New("cupsfilter failed")}
+
+		err := service.ExportNotePDF(context.Background(), "Trip", "out.pdf", renderer)
+		if err == nil || !strings.Contains(err.Error(), "failed to export note to PDF") {
+			t.Errorf("expected wrapped render error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+// ABOUTME: Read-only Automation permission pre-flight for Apple Notes
+// ABOUTME: Triggers the macOS consent dialog early and reports whether scripting Notes is allowed
+
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Permission statuses reported by CheckPermission and health checks
+const (
+	PermissionUnchecked   = "unchecked"
+	PermissionGranted     = "granted"
+	PermissionDenied      = "denied"
+	PermissionNotRunning  = "notes_not_running"
+	PermissionTimedOut    = "timed_out"
+	PermissionError       = "error"
+	PermissionNotRequired = "not_required"
+)
+
+// PermissionCheck is the outcome of a permission pre-flight
+type PermissionCheck struct {
+	Status    string    `json:"status"`
+	Detail    string    `json:"detail,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// PermissionChecker is implemented by providers that need an OS permission to reach their notes
+type PermissionChecker interface {
+	CheckPermission(ctx context.Context) PermissionCheck
+}
+
+// CheckPermission runs a minimal read-only script (counting accounts) so macOS shows the
+// Automation consent dialog now rather than in the middle of a tool call
+// A pending dialog blocks the script, so callers should bound ctx; a timeout is reported as such.
+func (s *AppleNotesService) CheckPermission(ctx context.Context) PermissionCheck {
+	_, stderr, err := s.executor.Execute(ctx, `tell application "Notes" to count of accounts`)
+	check := PermissionCheck{Status: PermissionGranted, CheckedAt: time.Now()}
+	if err == nil {
+		return check
+	}
+
+	detected := DetectError(ctx, stderr, err)
+	switch {
+	case errors.Is(detected, ErrPermissionDenied):
+		check.Status = PermissionDenied
+		check.Detail = "grant access in System Settings > Privacy & Security > Automation"
+	case errors.Is(detected, ErrNotesAppNotRunning):
+		check.Status = PermissionNotRunning
+		check.Detail = detected.Error()
+	case errors.Is(detected, ErrScriptTimeout):
+		check.Status = PermissionTimedOut
+		check.Detail = "no answer from Notes; a permission dialog may be waiting"
+	default:
+		check.Status = PermissionError
+		check.Detail = detected.Error()
+	}
+	return check
+}
@@ -0,0 +1,38 @@
+// ABOUTME: Unit tests for the Automation permission pre-flight
+// ABOUTME: Maps script failures to permission statuses using a mock executor
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCheckPermission(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		err    error
+		want   string
+	}{
+		{name: "granted", want: PermissionGranted},
+		{name: "denied", stderr: "Not authorized to send Apple events to Notes. (-1743)", err: errors.New("exit status 1"), want: PermissionDenied},
+		{name: "not running", stderr: "execution error: (-1728)", err: errors.New("exit status 1"), want: PermissionNotRunning},
+		{name: "timeout", err: context.DeadlineExceeded, want: PermissionTimedOut},
+		{name: "other", stderr: "syntax error", err: errors.New("exit status 1"), want: PermissionError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAppleNotesService(&MockExecutor{stdout: "1", stderr: tt.stderr, err: tt.err})
+			check := service.CheckPermission(context.Background())
+			if check.Status != tt.want {
+				t.Errorf("status = %q, want %q (detail %q)", check.Status, tt.want, check.Detail)
+			}
+			if check.CheckedAt.IsZero() {
+				t.Error("expected the check time to be recorded")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// ABOUTME: Processed markers for recurring agent jobs, built on note properties
+// ABOUTME: Marks a note as handled under a job's key and lists the notes a job hasn't handled yet
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultProcessedKey is the property that marks a note as processed when no key is given
+const DefaultProcessedKey = "processed"
+
+// DefaultUnprocessedLimit is how many notes FindUnprocessedNotes returns when no limit is given
+const DefaultUnprocessedLimit = 50
+
+// UnprocessedNoteOptions selects the notes FindUnprocessedNotes checks
+type UnprocessedNoteOptions struct {
+	Query  string // only notes whose title or body contains this; empty means every note
+	Folder string // only notes in this folder; empty means every folder
+	Key    string // the processed marker to look for (default: DefaultProcessedKey)
+	Limit  int    // maximum notes returned (default: DefaultUnprocessedLimit)
+}
+
+// MarkNoteProcessed records that a job handled a note by setting its key property to the time, in UTC
+// Each recurring job can use its own key, such as "summarized", to track its notes separately.
+func MarkNoteProcessed(ctx context.Context, notes NotesService, title, key string, at time.Time) (map[string]string, error) {
+	if key == "" {
+		key = DefaultProcessedKey
+	}
+	return SetNoteProperty(ctx, notes, title, key, at.UTC().Format(time.RFC3339))
+}
+
+// FindUnprocessedNotes lists the notes matching opts that don't have the processed key property
+// Locked notes can't be read and are left out. Results keep the order of the search or listing.
+func FindUnprocessedNotes(ctx context.Context, notes NoteReader, opts UnprocessedNoteOptions) ([]Note, error) {
+	if opts.Limit < 0 {
+		return []Note{}, fmt.Errorf("%w: limit cannot be negative", ErrInvalidInput)
+	}
+	if opts.Limit == 0 {
+		opts.Limit = DefaultUnprocessedLimit
+	}
+	if opts.Key == "" {
+		opts.Key = DefaultProcessedKey
+	}
+
+	var candidates []Note
+	var err error
+	if opts.Query != "" {
+		candidates, err = notes.SearchNotesAdvanced(ctx, SearchOptions{Query: opts.Query, SearchIn: SearchInBoth, Folder: opts.Folder})
+	} else {
+		candidates, err = notes.ListNotesWithMetadata(ctx, opts.Folder)
+	}
+	if err != nil {
+		return []Note{}, fmt.Errorf("failed to find unprocessed notes: %w", err)
+	}
+
+	readable := []Note{}
+	for _, note := range candidates {
+		if !note.PasswordProtected {
+			readable = append(readable, note)
+		}
+	}
+
+	// Read by ID where there is one, since notes sharing a title would otherwise read the same body
+	processed := make([]bool, len(readable))
+	err = forEachBounded(ctx, len(readable), DefaultConcurrency, func(ctx context.Context, i int) error {
+		var body string
+		var err error
+		if readable[i].ID != "" {
+			body, err = notes.GetNoteContentByID(ctx, readable[i].ID)
+		} else {
+			body, err = notes.GetNoteContent(ctx, readable[i].Title)
+		}
+		if err != nil {
+			return err
+		}
+		// A malformed property block counts as unprocessed, so the note is listed rather than lost
+		properties, _, _ := ParseNoteProperties(body)
+		_, processed[i] = properties[opts.Key]
+		return nil
+	})
+	if err != nil {
+		return []Note{}, fmt.Errorf("failed to find unprocessed notes: %w", err)
+	}
+
+	unprocessed := []Note{}
+	for i, note := range readable {
+		if !processed[i] && len(unprocessed) < opts.Limit {
+			unprocessed = append(unprocessed, note)
+		}
+	}
+	return unprocessed, nil
+}
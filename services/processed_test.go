@@ -0,0 +1,62 @@
+// ABOUTME: Unit tests for processed markers
+// ABOUTME: Tests marking notes under default and custom keys and listing the ones not yet marked
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFindUnprocessedNotes(t *testing.T) {
+	ctx := context.Background()
+	service := newTestMemoryService()
+	for _, title := range []string{"Meeting Monday", "Meeting Tuesday", "Meeting Wednesday", "Groceries"} {
+		if _, err := service.CreateNote(ctx, title, title, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("PDT", -7*60*60))
+	properties, err := MarkNoteProcessed(ctx, service, "Meeting Monday", "", at)
+	if err != nil {
+		t.Fatalf("MarkNoteProcessed failed: %v", err)
+	}
+	if properties[DefaultProcessedKey] != "2024-05-01T19:00:00Z" {
+		t.Errorf("expected the UTC time under the default key, got %v", properties)
+	}
+	if _, err := MarkNoteProcessed(ctx, service, "Meeting Tuesday", "summarized", at); err != nil {
+		t.Fatalf("MarkNoteProcessed failed: %v", err)
+	}
+
+	notes, err := FindUnprocessedNotes(ctx, service, UnprocessedNoteOptions{Query: "meeting"})
+	if err != nil {
+		t.Fatalf("FindUnprocessedNotes failed: %v", err)
+	}
+	if got := unprocessedTitles(notes); len(got) != 2 || !got["Meeting Tuesday"] || !got["Meeting Wednesday"] {
+		t.Errorf("unprocessed = %v, want Meeting Tuesday and Meeting Wednesday", got)
+	}
+
+	notes, err = FindUnprocessedNotes(ctx, service, UnprocessedNoteOptions{Key: "summarized", Limit: 2})
+	if err != nil {
+		t.Fatalf("FindUnprocessedNotes failed: %v", err)
+	}
+	if got := unprocessedTitles(notes); len(got) != 2 || got["Meeting Tuesday"] {
+		t.Errorf("expected two notes other than Meeting Tuesday, got %v", got)
+	}
+
+	if _, err := FindUnprocessedNotes(ctx, service, UnprocessedNoteOptions{Limit: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+// unprocessedTitles returns the set of the notes' titles
+func unprocessedTitles(notes []Note) map[string]bool {
+	titles := map[string]bool{}
+	for _, note := range notes {
+		titles[note.Title] = true
+	}
+	return titles
+}
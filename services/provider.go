@@ -0,0 +1,167 @@
+// ABOUTME: Registry of NotesService providers (AppleScript, in-memory, ...) selected by name
+// ABOUTME: Each provider declares capabilities the MCP server uses to decide which tools to offer
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultProvider is the provider used when none is configured
+const DefaultProvider = "applescript"
+
+// ProviderCapabilities describes what a provider's notes store can do
+// Tools needing a capability a provider lacks are not offered to MCP clients.
+type ProviderCapabilities struct {
+	SupportsTags    bool `json:"supports_tags"`
+	SupportsFolders bool `json:"supports_folders"`
+	ReadOnly        bool `json:"read_only"`
+}
+
+// ProviderConfig is the configuration passed to a provider's constructor
+type ProviderConfig struct {
+	// ScriptTimeout bounds a single script invocation for providers that run scripts
+	ScriptTimeout time.Duration
+	// Executor runs scripts for providers that run them; nil means osascript with ScriptTimeout
+	Executor ScriptExecutor
+	// JXAExecutor runs JavaScript for the jxa provider; nil means osascript -l JavaScript with ScriptTimeout
+	JXAExecutor ScriptExecutor
+	// QueryExecutor runs SQL for the sqlite provider; nil means sqlite3 on Database with ScriptTimeout
+	QueryExecutor ScriptExecutor
+	// Database is the Notes database the sqlite provider reads; empty means the one in the Notes group container
+	Database string
+}
+
+// Provider is a named notes backend
+// New may return a backend implementing only some of the focused interfaces beyond NoteReader;
+// ComposeNotesService makes it a full NotesService, and tools needing the rest aren't offered.
+type Provider struct {
+	Name         string
+	Description  string
+	Capabilities ProviderCapabilities
+	New          func(config ProviderConfig) (NoteReader, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Provider{}
+)
+
+// RegisterProvider makes a provider available by name
+// Like database/sql drivers, registering a name twice or without a constructor is a programming error and panics.
+func RegisterProvider(provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	name := strings.ToLower(provider.Name)
+	if name == "" || provider.New == nil {
+		panic("services: RegisterProvider needs a name and a constructor")
+	}
+	if _, exists := providers[name]; exists {
+		panic("services: RegisterProvider called twice for provider " + name)
+	}
+	provider.Name = name
+	providers[name] = provider
+}
+
+// LookupProvider returns the provider registered under name (case-insensitive); blank means DefaultProvider
+func LookupProvider(name string) (Provider, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultProvider
+	}
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	provider, ok := providers[name]
+	if !ok {
+		return Provider{}, fmt.Errorf("%w: unknown notes provider %q (available: %s)",
+			ErrInvalidInput, name, strings.Join(providerNamesLocked(), ", "))
+	}
+	return provider, nil
+}
+
+// ProviderNames returns the registered provider names, sorted
+func ProviderNames() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return providerNamesLocked()
+}
+
+// providerNamesLocked lists provider names; callers hold providersMu
+func providerNamesLocked() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterProvider(Provider{
+		Name:        "applescript",
+		Description: "Apple Notes through AppleScript (osascript)",
+		Capabilities: ProviderCapabilities{
+			SupportsTags:    true,
+			SupportsFolders: true,
+		},
+		New: func(config ProviderConfig) (NoteReader, error) {
+			if config.Executor != nil {
+				return NewAppleNotesService(config.Executor), nil
+			}
+			return NewAppleNotesService(NewOSAScriptExecutor(config.ScriptTimeout)), nil
+		},
+	})
+
+	RegisterProvider(Provider{
+		Name:        "jxa",
+		Description: "Apple Notes read through JavaScript for Automation (osascript -l JavaScript), read-only",
+		Capabilities: ProviderCapabilities{
+			SupportsFolders: true,
+			ReadOnly:        true,
+		},
+		New: func(config ProviderConfig) (NoteReader, error) {
+			if config.JXAExecutor != nil {
+				return NewJXANotesReader(config.JXAExecutor), nil
+			}
+			return NewJXANotesReader(NewJXAScriptExecutor(config.ScriptTimeout)), nil
+		},
+	})
+
+	RegisterProvider(Provider{
+		Name:        "sqlite",
+		Description: "Apple Notes read straight from its database (NoteStore.sqlite) with sqlite3, read-only",
+		Capabilities: ProviderCapabilities{
+			SupportsFolders: true,
+			ReadOnly:        true,
+		},
+		New: func(config ProviderConfig) (NoteReader, error) {
+			if config.QueryExecutor != nil {
+				return NewSQLiteNotesReader(config.QueryExecutor), nil
+			}
+			executor, err := NewSQLiteExecutor(config.Database, config.ScriptTimeout)
+			if err != nil {
+				return nil, err
+			}
+			return NewSQLiteNotesReader(executor), nil
+		},
+	})
+
+	RegisterProvider(Provider{
+		Name:        "memory",
+		Description: "In-memory notes that last for the life of the process, for demos and testing",
+		Capabilities: ProviderCapabilities{
+			SupportsTags:    true,
+			SupportsFolders: true,
+		},
+		New: func(config ProviderConfig) (NoteReader, error) {
+			return NewMemoryNotesService(), nil
+		},
+	})
+}
@@ -0,0 +1,57 @@
+// ABOUTME: Unit tests for the notes provider registry
+// ABOUTME: Tests default and case-insensitive lookup, the read-only providers, unknown names, and duplicate registration
+
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLookupProvider(t *testing.T) {
+	provider, err := LookupProvider("")
+	if err != nil || provider.Name != DefaultProvider {
+		t.Fatalf("expected the default provider, got %q, %v", provider.Name, err)
+	}
+
+	provider, err = LookupProvider(" Memory ")
+	if err != nil || provider.Name != "memory" {
+		t.Fatalf("expected the memory provider, got %q, %v", provider.Name, err)
+	}
+	service, err := provider.New(ProviderConfig{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, ok := service.(*MemoryNotesService); !ok {
+		t.Errorf("expected a MemoryNotesService, got %T", service)
+	}
+
+	for _, name := range []string{"jxa", "sqlite"} {
+		provider, err := LookupProvider(name)
+		if err != nil || !provider.Capabilities.ReadOnly || provider.Capabilities.SupportsTags {
+			t.Errorf("expected a read-only %s provider without tags, got %+v, %v", name, provider.Capabilities, err)
+		}
+		service, err := provider.New(ProviderConfig{Database: "/nonexistent/NoteStore.sqlite"})
+		if err != nil {
+			t.Fatalf("%s New failed: %v", name, err)
+		}
+		if _, ok := service.(NoteWriter); ok {
+			t.Errorf("expected the %s provider to be read-only, got %T", name, service)
+		}
+	}
+
+	_, err = LookupProvider("evernote")
+	if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "applescript, jxa, memory, sqlite") {
+		t.Errorf("expected an invalid input error listing providers, got %v", err)
+	}
+}
+
+func TestRegisterProviderTwicePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a duplicate provider to panic")
+		}
+	}()
+	RegisterProvider(Provider{Name: "AppleScript", New: func(ProviderConfig) (NoteReader, error) { return nil, nil }})
+}
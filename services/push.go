@@ -0,0 +1,462 @@
+// ABOUTME: One-way export bridge that pushes notes to Notion databases or Google Keep through their APIs
+// ABOUTME: Field mappings name the destination properties that receive each note's title, folder, tags, and dates
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Push targets
+const (
+	PushTargetNotion = "notion"
+	PushTargetKeep   = "keep"
+)
+
+// API endpoints and limits of the push targets
+const (
+	notionAPIURL  = "https://api.notion.com/v1"
+	notionVersion = "2022-06-28"
+	keepAPIURL    = "https://keep.googleapis.com/v1"
+
+	// notionTextLimit is the most characters Notion accepts in one rich text object
+	notionTextLimit = 2000
+	// notionBlockBatch is the most child blocks Notion accepts in one request
+	notionBlockBatch = 100
+	// keepTextLimit is the most characters Google Keep accepts in a note body
+	keepTextLimit = 20000
+)
+
+// PushFieldMapping names the destination field each note field is written to; empty names are skipped
+// Notion fields are database properties (title, select, multi_select, date, and rich_text types);
+// Keep has no custom fields, so mapped values become "Name: value" lines at the top of the note.
+type PushFieldMapping struct {
+	Title    string `json:"title,omitempty"`
+	Folder   string `json:"folder,omitempty"`
+	Tags     string `json:"tags,omitempty"`
+	Created  string `json:"created,omitempty"`
+	Modified string `json:"modified,omitempty"`
+	SourceID string `json:"source_id,omitempty"`
+}
+
+// NotionPushConfig configures pushes to a Notion database
+type NotionPushConfig struct {
+	Token      string           `json:"-"`
+	DatabaseID string           `json:"database_id"`
+	Fields     PushFieldMapping `json:"fields"`
+	BaseURL    string           `json:"-"` // API base URL override for tests
+}
+
+// KeepPushConfig configures pushes to Google Keep
+type KeepPushConfig struct {
+	Token   string           `json:"-"` // OAuth access token with the Keep API scope
+	Fields  PushFieldMapping `json:"fields"`
+	BaseURL string           `json:"-"` // API base URL override for tests
+}
+
+// PushNote is a note ready to send to another service
+type PushNote struct {
+	ID       string
+	Title    string
+	Folder   string
+	Tags     []string
+	Created  time.Time
+	Modified time.Time
+	Markdown string
+}
+
+// PushTarget creates notes in another service
+type PushTarget interface {
+	// Name identifies the target in reports
+	Name() string
+
+	// Push creates the note and returns a link to, or the ID of, the created copy
+	Push(ctx context.Context, note PushNote) (string, error)
+}
+
+// PushSelection picks the notes to push: every note in Folder, the notes named in Titles, or both
+type PushSelection struct {
+	Folder string
+	Titles []string
+}
+
+// PushedNote records a note that was pushed
+type PushedNote struct {
+	Title       string `json:"title"`
+	Destination string `json:"destination"`
+}
+
+// PushFailure records a note that could not be pushed
+type PushFailure struct {
+	Title string `json:"title"`
+	Error string `json:"error"`
+}
+
+// PushReport summarizes a push run
+type PushReport struct {
+	Target string        `json:"target"`
+	Pushed []PushedNote  `json:"pushed"`
+	Failed []PushFailure `json:"failed"`
+}
+
+// SelectPushNotes returns the notes a selection names, without duplicates
+func SelectPushNotes(ctx context.Context, notes NotesService, selection PushSelection) ([]Note, error) {
+	if selection.Folder == "" && len(selection.Titles) == 0 {
+		return []Note{}, fmt.Errorf("%w: choose a folder or at least one note title to push", ErrInvalidInput)
+	}
+
+	selected := []Note{}
+	seen := map[string]bool{}
+	if selection.Folder != "" {
+		inFolder, err := notes.ListNotesWithMetadata(ctx, selection.Folder)
+		if err != nil {
+			return []Note{}, fmt.Errorf("failed to list notes to push: %w", err)
+		}
+		for _, note := range inFolder {
+			seen[note.Title] = true
+			selected = append(selected, note)
+		}
+	}
+
+	for _, title := range selection.Titles {
+		if seen[title] {
+			continue
+		}
+		note, err := notes.GetNoteMetadata(ctx, title)
+		if err != nil {
+			return []Note{}, fmt.Errorf("failed to find note %q to push: %w", title, err)
+		}
+		seen[title] = true
+		selected = append(selected, *note)
+	}
+
+	return selected, nil
+}
+
+// PushNotes copies the selected notes to target as markdown
+// A note that fails to export or push is recorded in the report and the rest still go out.
+func PushNotes(ctx context.Context, notes NotesService, target PushTarget, selection PushSelection) (*PushReport, error) {
+	selected, err := SelectPushNotes(ctx, notes, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PushReport{Target: target.Name(), Pushed: []PushedNote{}, Failed: []PushFailure{}}
+	for _, note := range selected {
+		markdown, err := notes.ExportNoteMarkdown(ctx, note.Title)
+		if err != nil {
+			report.Failed = append(report.Failed, PushFailure{Title: note.Title, Error: err.Error()})
+			continue
+		}
+
+		destination, err := target.Push(ctx, PushNote{
+			ID:       note.ID,
+			Title:    note.Title,
+			Folder:   note.Folder,
+			Tags:     note.Tags,
+			Created:  note.CreationDate,
+			Modified: note.ModificationDate,
+			Markdown: markdown,
+		})
+		if err != nil {
+			report.Failed = append(report.Failed, PushFailure{Title: note.Title, Error: err.Error()})
+			continue
+		}
+		report.Pushed = append(report.Pushed, PushedNote{Title: note.Title, Destination: destination})
+	}
+
+	return report, nil
+}
+
+// NotionTarget pushes notes as pages of a Notion database
+type NotionTarget struct {
+	config NotionPushConfig
+	client *http.Client
+}
+
+// NewNotionTarget creates a NotionTarget; the title is written to the "Name" property unless mapped elsewhere
+func NewNotionTarget(config NotionPushConfig) (*NotionTarget, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("%w: a Notion integration token is required", ErrInvalidInput)
+	}
+	if config.DatabaseID == "" {
+		return nil, fmt.Errorf("%w: a Notion database_id is required", ErrInvalidInput)
+	}
+	if config.Fields.Title == "" {
+		config.Fields.Title = "Name"
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = notionAPIURL
+	}
+
+	return &NotionTarget{config: config, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// Name returns "notion"
+func (n *NotionTarget) Name() string {
+	return PushTargetNotion
+}
+
+// Push creates a database page with the mapped properties and the note's markdown as blocks
+func (n *NotionTarget) Push(ctx context.Context, note PushNote) (string, error) {
+	blocks := notionBlocks(note.Markdown)
+	first := blocks
+	if len(first) > notionBlockBatch {
+		first = first[:notionBlockBatch]
+	}
+
+	var page struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	err := n.call(ctx, http.MethodPost, "/pages", map[string]any{
+		"parent":     map[string]any{"database_id": n.config.DatabaseID},
+		"properties": n.properties(note),
+		"children":   first,
+	}, &page)
+	if err != nil {
+		return "", err
+	}
+
+	// Notion caps children per request, so long notes are appended in batches
+	for start := notionBlockBatch; start < len(blocks); start += notionBlockBatch {
+		end := min(start+notionBlockBatch, len(blocks))
+		if err := n.call(ctx, http.MethodPatch, "/blocks/"+page.ID+"/children",
+			map[string]any{"children": blocks[start:end]}, nil); err != nil {
+			return "", fmt.Errorf("page %s created but not completed: %w", page.ID, err)
+		}
+	}
+
+	if page.URL != "" {
+		return page.URL, nil
+	}
+	return page.ID, nil
+}
+
+// properties builds the page properties from the field mapping
+func (n *NotionTarget) properties(note PushNote) map[string]any {
+	fields := n.config.Fields
+	properties := map[string]any{
+		fields.Title: map[string]any{"title": notionRichText(note.Title)},
+	}
+
+	if fields.Folder != "" && note.Folder != "" {
+		// Select option names cannot contain commas
+		properties[fields.Folder] = map[string]any{"select": map[string]any{"name": strings.ReplaceAll(note.Folder, ",", " ")}}
+	}
+	if fields.Tags != "" {
+		options := []map[string]any{}
+		for _, tag := range note.Tags {
+			options = append(options, map[string]any{"name": strings.ReplaceAll(tag, ",", " ")})
+		}
+		properties[fields.Tags] = map[string]any{"multi_select": options}
+	}
+	if fields.Created != "" && !note.Created.IsZero() {
+		properties[fields.Created] = map[string]any{"date": map[string]any{"start": note.Created.Format(time.RFC3339)}}
+	}
+	if fields.Modified != "" && !note.Modified.IsZero() {
+		properties[fields.Modified] = map[string]any{"date": map[string]any{"start": note.Modified.Format(time.RFC3339)}}
+	}
+	if fields.SourceID != "" && note.ID != "" {
+		properties[fields.SourceID] = map[string]any{"rich_text": notionRichText(note.ID)}
+	}
+	return properties
+}
+
+// call sends a Notion API request and decodes the response into out when it is not nil
+func (n *NotionTarget) call(ctx context.Context, method, path string, payload any, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode Notion request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, n.config.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Notion request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+n.config.Token)
+	req.Header.Set("Notion-Version", notionVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	return doPushRequest(n.client, req, "Notion", out)
+}
+
+// notionBlocks converts markdown into Notion blocks: headings, to-dos, list items, quotes, and paragraphs
+func notionBlocks(markdown string) []map[string]any {
+	blocks := []map[string]any{}
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		blockType, text, checked := "paragraph", trimmed, false
+		switch {
+		case strings.HasPrefix(trimmed, "### "):
+			blockType, text = "heading_3", trimmed[4:]
+		case strings.HasPrefix(trimmed, "## "):
+			blockType, text = "heading_2", trimmed[3:]
+		case strings.HasPrefix(trimmed, "# "):
+			blockType, text = "heading_1", trimmed[2:]
+		case strings.HasPrefix(trimmed, "- [ ] "), strings.HasPrefix(trimmed, "* [ ] "):
+			blockType, text = "to_do", trimmed[6:]
+		case strings.HasPrefix(strings.ToLower(trimmed), "- [x] "), strings.HasPrefix(strings.ToLower(trimmed), "* [x] "):
+			blockType, text, checked = "to_do", trimmed[6:], true
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			blockType, text = "bulleted_list_item", trimmed[2:]
+		case strings.HasPrefix(trimmed, "> "):
+			blockType, text = "quote", trimmed[2:]
+		default:
+			if number, rest, ok := strings.Cut(trimmed, ". "); ok && number != "" && strings.Trim(number, "0123456789") == "" {
+				blockType, text = "numbered_list_item", rest
+			}
+		}
+
+		content := map[string]any{"rich_text": notionRichText(text)}
+		if blockType == "to_do" {
+			content["checked"] = checked
+		}
+		blocks = append(blocks, map[string]any{"object": "block", "type": blockType, blockType: content})
+	}
+	return blocks
+}
+
+// notionRichText splits text into rich text objects within Notion's per-object length limit
+func notionRichText(text string) []map[string]any {
+	parts := []map[string]any{}
+	for text != "" {
+		chunk := text
+		if utf8.RuneCountInString(chunk) > notionTextLimit {
+			chunk = string([]rune(chunk)[:notionTextLimit])
+		}
+		parts = append(parts, map[string]any{"type": "text", "text": map[string]any{"content": chunk}})
+		text = text[len(chunk):]
+	}
+	return parts
+}
+
+// KeepTarget pushes notes to Google Keep
+type KeepTarget struct {
+	config KeepPushConfig
+	client *http.Client
+}
+
+// NewKeepTarget creates a KeepTarget
+func NewKeepTarget(config KeepPushConfig) (*KeepTarget, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("%w: a Google OAuth access token with the Keep scope is required", ErrInvalidInput)
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = keepAPIURL
+	}
+
+	return &KeepTarget{config: config, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// Name returns "keep"
+func (k *KeepTarget) Name() string {
+	return PushTargetKeep
+}
+
+// Push creates a Keep note with the mapped fields as header lines above the markdown
+func (k *KeepTarget) Push(ctx context.Context, note PushNote) (string, error) {
+	text := k.header(note) + note.Markdown
+	if utf8.RuneCountInString(text) > keepTextLimit {
+		return "", fmt.Errorf("%w: note is longer than Google Keep's %d character limit", ErrInvalidInput, keepTextLimit)
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"title": note.Title,
+		"body":  map[string]any{"text": map[string]any{"text": text}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode Keep request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.config.BaseURL+"/notes", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create Keep request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+k.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	var created struct {
+		Name string `json:"name"`
+	}
+	if err := doPushRequest(k.client, req, "Google Keep", &created); err != nil {
+		return "", err
+	}
+	return created.Name, nil
+}
+
+// header renders the mapped fields as "Name: value" lines, followed by a blank line
+func (k *KeepTarget) header(note PushNote) string {
+	fields := k.config.Fields
+	var lines []string
+	add := func(name, value string) {
+		if name != "" && value != "" {
+			lines = append(lines, name+": "+value)
+		}
+	}
+
+	add(fields.Folder, note.Folder)
+	add(fields.Tags, strings.Join(note.Tags, ", "))
+	if !note.Created.IsZero() {
+		add(fields.Created, note.Created.Format(time.RFC3339))
+	}
+	if !note.Modified.IsZero() {
+		add(fields.Modified, note.Modified.Format(time.RFC3339))
+	}
+	add(fields.SourceID, note.ID)
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
+
+// doPushRequest sends a push API request, turning non-2xx responses into errors with the API's message
+func doPushRequest(client *http.Client, req *http.Request, service string, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", service, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // response body close failure is non-critical
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", service, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiError struct {
+			Message string `json:"message"`
+			Error   struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(body, &apiError) //nolint:errcheck // the status code alone is reported if the body isn't JSON
+		message := apiError.Message + apiError.Error.Message
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		return fmt.Errorf("%s returned status %d: %s", service, resp.StatusCode, message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", service, err)
+	}
+	return nil
+}
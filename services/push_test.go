@@ -0,0 +1,178 @@
+// ABOUTME: Unit tests for pushing notes to Notion and Google Keep
+// ABOUTME: Uses httptest servers for the APIs and the in-memory service as the note source
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotionTargetPush(t *testing.T) {
+	var requests []map[string]any
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") != notionVersion {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		_, _ = io.WriteString(w, `{"id": "page1", "url": "https://notion.so/page1"}`)
+	}))
+	defer server.Close()
+
+	target, err := NewNotionTarget(NotionPushConfig{
+		Token:      "secret",
+		DatabaseID: "db1",
+		BaseURL:    server.URL,
+		Fields:     PushFieldMapping{Folder: "Folder", Tags: "Tags", SourceID: "Apple ID"},
+	})
+	if err != nil {
+		t.Fatalf("NewNotionTarget failed: %v", err)
+	}
+
+	lines := []string{"# Plan", "- [x] scoped", "- [ ] ship"}
+	for i := 0; i < notionBlockBatch; i++ {
+		lines = append(lines, fmt.Sprintf("%d. step", i+1))
+	}
+	destination, err := target.Push(context.Background(), PushNote{
+		ID: "x-coredata://1", Title: "Plan", Folder: "Work", Tags: []string{"q3"}, Markdown: strings.Join(lines, "\n"),
+	})
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if destination != "https://notion.so/page1" {
+		t.Errorf("expected the page URL, got %q", destination)
+	}
+
+	if len(paths) != 2 || paths[0] != "POST /pages" || paths[1] != "PATCH /blocks/page1/children" {
+		t.Fatalf("expected a page create then one block append, got %v", paths)
+	}
+	properties := requests[0]["properties"].(map[string]any)
+	for _, name := range []string{"Name", "Folder", "Tags", "Apple ID"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("expected property %q, got %v", name, properties)
+		}
+	}
+	children := requests[0]["children"].([]any)
+	if len(children) != notionBlockBatch || children[1].(map[string]any)["type"] != "to_do" {
+		t.Errorf("expected a full first batch starting with a heading and to-dos, got %d blocks", len(children))
+	}
+	if appended := requests[1]["children"].([]any); len(appended) != 3 {
+		t.Errorf("expected the 3 remaining blocks to be appended, got %d", len(appended))
+	}
+}
+
+func TestNotionTargetReportsAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"object": "error", "message": "Folder is not a property that exists."}`)
+	}))
+	defer server.Close()
+
+	target, _ := NewNotionTarget(NotionPushConfig{Token: "t", DatabaseID: "db", BaseURL: server.URL})
+	_, err := target.Push(context.Background(), PushNote{Title: "Plan"})
+	if err == nil || !strings.Contains(err.Error(), "Folder is not a property") {
+		t.Errorf("expected the API message in the error, got %v", err)
+	}
+
+	if _, err := NewNotionTarget(NotionPushConfig{DatabaseID: "db"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a missing token to be rejected, got %v", err)
+	}
+}
+
+func TestKeepTargetPush(t *testing.T) {
+	var body struct {
+		Title string `json:"title"`
+		Body  struct {
+			Text struct {
+				Text string `json:"text"`
+			} `json:"text"`
+		} `json:"body"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/notes" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = io.WriteString(w, `{"name": "notes/abc"}`)
+	}))
+	defer server.Close()
+
+	target, err := NewKeepTarget(KeepPushConfig{Token: "t", BaseURL: server.URL, Fields: PushFieldMapping{Folder: "Folder"}})
+	if err != nil {
+		t.Fatalf("NewKeepTarget failed: %v", err)
+	}
+	name, err := target.Push(context.Background(), PushNote{Title: "Plan", Folder: "Work", Markdown: "ship it"})
+	if err != nil || name != "notes/abc" {
+		t.Fatalf("expected notes/abc, got %q, %v", name, err)
+	}
+	if body.Title != "Plan" || body.Body.Text.Text != "Folder: Work\n\nship it" {
+		t.Errorf("unexpected Keep note: %+v", body)
+	}
+
+	_, err = target.Push(context.Background(), PushNote{Title: "Long", Markdown: strings.Repeat("a", keepTextLimit+1)})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected notes over the Keep limit to be rejected, got %v", err)
+	}
+}
+
+// recordingTarget collects pushed notes and fails for one title
+type recordingTarget struct {
+	pushed []PushNote
+	fail   string
+}
+
+func (r *recordingTarget) Name() string { return "recording" }
+
+func (r *recordingTarget) Push(ctx context.Context, note PushNote) (string, error) {
+	if note.Title == r.fail {
+		return "", errors.New("rejected")
+	}
+	r.pushed = append(r.pushed, note)
+	return "dest/" + note.Title, nil
+}
+
+func TestPushNotes(t *testing.T) {
+	ctx := context.Background()
+	notes := NewMemoryNotesService()
+	notes.now = func() time.Time { return time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC) }
+	for _, title := range []string{"Plan", "Retro", "Groceries"} {
+		if _, err := notes.CreateNote(ctx, title, "<h1>"+title+"</h1>", nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+	_ = notes.CreateFolder(ctx, "Work", "")
+	_ = notes.MoveNote(ctx, "Plan", "Work")
+	_ = notes.MoveNote(ctx, "Retro", "Work")
+
+	target := &recordingTarget{fail: "Retro"}
+	report, err := PushNotes(ctx, notes, target, PushSelection{Folder: "Work", Titles: []string{"Plan", "Groceries"}})
+	if err != nil {
+		t.Fatalf("PushNotes failed: %v", err)
+	}
+
+	if len(report.Pushed) != 2 || report.Pushed[1].Destination != "dest/Groceries" {
+		t.Errorf("expected Plan and Groceries pushed once each, got %+v", report.Pushed)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Title != "Retro" {
+		t.Errorf("expected Retro to fail, got %+v", report.Failed)
+	}
+	if target.pushed[0].Markdown != "# Plan" || target.pushed[0].Folder != "Work" {
+		t.Errorf("expected markdown and folder for Plan, got %+v", target.pushed[0])
+	}
+
+	if _, err := PushNotes(ctx, notes, target, PushSelection{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected an empty selection to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+// ABOUTME: Apple Reminders integration for turning note content into reminders
+// ABOUTME: Creates Reminders.app items via AppleScript that reference their source note
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Reminder represents a reminder created in Apple Reminders from a note
+type Reminder struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	List         string     `json:"list,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	SourceNote   string     `json:"source_note"`
+	SourceNoteID string     `json:"source_note_id"`
+}
+
+// CreateReminder creates a reminder in Apple Reminders referencing the given note
+// If text is empty the note title is used as the reminder name
+// If list is empty the user's default Reminders list is used
+func (s *AppleNotesService) CreateReminder(ctx context.Context, noteTitle, text, list string, dueDate *time.Time) (*Reminder, error) {
+	note, err := s.GetNoteMetadata(ctx, noteTitle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reminder: %w", err)
+	}
+
+	if text == "" {
+		text = note.Title
+	}
+
+	return s.createReminder(ctx, note, text, list, dueDate)
+}
+
+// PushActionItemsToReminders creates a reminder for every unchecked action item in a note
+// Returns the reminders that were created; stops at the first Reminders failure
+func (s *AppleNotesService) PushActionItemsToReminders(ctx context.Context, noteTitle, list string) ([]Reminder, error) {
+	items, err := s.ExtractActionItems(ctx, noteTitle)
+	if err != nil {
+		return []Reminder{}, err
+	}
+
+	note, err := s.GetNoteMetadata(ctx, noteTitle)
+	if err != nil {
+		return []Reminder{}, fmt.Errorf("failed to push action items: %w", err)
+	}
+
+	reminders := []Reminder{}
+	for _, item := range items {
+		if item.Done {
+			continue
+		}
+
+		reminder, err := s.createReminder(ctx, note, item.Text, list, nil)
+		if err != nil {
+			return reminders, err
+		}
+		reminders = append(reminders, *reminder)
+	}
+
+	return reminders, nil
+}
+
+// createReminder runs the Reminders.app AppleScript for an already-resolved source note
+func (s *AppleNotesService) createReminder(ctx context.Context, note *Note, text, list string, dueDate *time.Time) (*Reminder, error) {
+	script := s.buildReminderScript(note, text, list, dueDate)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return nil, fmt.Errorf("failed to create reminder: %w", detectedErr)
+	}
+
+	return &Reminder{
+		ID:           strings.TrimSpace(stdout),
+		Name:         text,
+		List:         list,
+		DueDate:      dueDate,
+		SourceNote:   note.Title,
+		SourceNoteID: note.ID,
+	}, nil
+}
+
+// buildReminderScript builds the AppleScript that creates a reminder in Reminders.app
+// The reminder body records the source note title and ID so it can be traced back
+func (s *AppleNotesService) buildReminderScript(note *Note, text, list string, dueDate *time.Time) string {
+	safeName := s.escapeForAppleScript(text)
+	safeBody := s.escapeForAppleScript(fmt.Sprintf("From note: %s (%s)", note.Title, note.ID))
+
+	targetList := "default list"
+	if list != "" {
+		targetList = fmt.Sprintf(`list "%s"`, s.escapeForAppleScript(list))
+	}
+
+	properties := fmt.Sprintf(`{name:"%s", body:"%s"}`, safeName, safeBody)
+	dueScript := ""
+	if dueDate != nil {
+		properties = fmt.Sprintf(`{name:"%s", body:"%s", due date:dueDate}`, safeName, safeBody)
+		dueScript = appleScriptDate("dueDate", *dueDate)
+	}
+
+	return dueScript + fmt.Sprintf(`
+		tell application "Reminders"
+			set targetList to %s
+			tell targetList
+				set newReminder to make new reminder with properties %s
+			end tell
+			return id of newReminder
+		end tell
+	`, targetList, properties)
+}
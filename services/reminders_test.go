@@ -0,0 +1,114 @@
+// ABOUTME: Unit tests for the Apple Reminders integration
+// ABOUTME: Verifies reminder script generation and push of unchecked action items
+
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+const reminderTestMetadata = `{id:"x-coredata://note/1", name:"Planning", creation date:date "Monday, January 1, 2024 at 10:00:00 AM", modification date:date "Monday, January 1, 2024 at 11:00:00 AM", container:"Work", shared:false, password protected:false}`
+
+// TestBuildReminderScript tests the generated Reminders AppleScript
+func TestBuildReminderScript(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+	note := &Note{ID: "x-coredata://note/1", Title: `Plan "Q1"`}
+
+	t.Run("default list", func(t *testing.T) {
+		script := service.buildReminderScript(note, "Call Bob", "", nil)
+
+		if !strings.Contains(script, `tell application "Reminders"`) {
+			t.Error("script should target Reminders")
+		}
+		if !strings.Contains(script, "set targetList to default list") {
+			t.Error("script should use the default list when none is given")
+		}
+		if !strings.Contains(script, `From note: Plan \"Q1\" (x-coredata://note/1)`) {
+			t.Errorf("script should reference the escaped source note, got:\n%s", script)
+		}
+		if strings.Contains(script, "due date") {
+			t.Error("script should not set a due date when none is given")
+		}
+	})
+
+	t.Run("named list with due date", func(t *testing.T) {
+		due := time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local)
+		script := service.buildReminderScript(note, "Call Bob", "Work", &due)
+
+		if !strings.Contains(script, `set targetList to list "Work"`) {
+			t.Error("script should target the named list")
+		}
+		for _, want := range []string{"set month of dueDate to 7", "set time of dueDate to 32400", "due date:dueDate"} {
+			if !strings.Contains(script, want) {
+				t.Errorf("script should set the due date (%s), got:\n%s", want, script)
+			}
+		}
+	})
+}
+
+// TestCreateReminder tests creating a reminder from a note
+func TestCreateReminder(t *testing.T) {
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: reminderTestMetadata},
+			{stdout: "x-apple-reminder://ABC\n"},
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+
+	reminder, err := service.CreateReminder(context.Background(), "Planning", "", "", nil)
+	if err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+
+	if reminder.ID != "x-apple-reminder://ABC" {
+		t.Errorf("ID = %q, want %q", reminder.ID, "x-apple-reminder://ABC")
+	}
+	if reminder.Name != "Planning" {
+		t.Errorf("Name = %q, want note title when text is empty", reminder.Name)
+	}
+	if reminder.SourceNoteID != "x-coredata://note/1" {
+		t.Errorf("SourceNoteID = %q, want %q", reminder.SourceNoteID, "x-coredata://note/1")
+	}
+}
+
+// TestPushActionItemsToReminders tests that only unchecked items become reminders
+func TestPushActionItemsToReminders(t *testing.T) {
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: `<div>- [ ] First</div><div>- [x] Done</div><div>- [ ] Second</div>`},
+			{stdout: reminderTestMetadata},
+			{stdout: "x-apple-reminder://1"},
+			{stdout: "x-apple-reminder://2"},
+		},
+	}
+
+	service := NewAppleNotesService(executor)
+
+	reminders, err := service.PushActionItemsToReminders(context.Background(), "Planning", "Work")
+	if err != nil {
+		t.Fatalf("PushActionItemsToReminders failed: %v", err)
+	}
+
+	if len(reminders) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(reminders))
+	}
+	if reminders[0].Name != "First" || reminders[1].Name != "Second" {
+		t.Errorf("unexpected reminder names: %q, %q", reminders[0].Name, reminders[1].Name)
+	}
+	if reminders[1].List != "Work" {
+		t.Errorf("List = %q, want %q", reminders[1].List, "Work")
+	}
+}
@@ -0,0 +1,33 @@
+// ABOUTME: Request ID propagation through context for log correlation
+// ABOUTME: Lets the MCP server tag every script execution with the tool call that caused it
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// requestIDKey is the context key for request IDs
+type requestIDKey struct{}
+
+// NewRequestID returns a random 12 character hex request ID
+func NewRequestID() string {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// WithRequestID returns a copy of ctx carrying the given request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,28 @@
+// ABOUTME: Unit tests for request ID context helpers
+// ABOUTME: Tests ID generation and round-tripping through context
+
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestIDContext(t *testing.T) {
+	if id := RequestIDFromContext(context.Background()); id != "" {
+		t.Errorf("expected empty request ID, got %q", id)
+	}
+
+	id := NewRequestID()
+	if len(id) != 12 {
+		t.Errorf("expected 12 character ID, got %q", id)
+	}
+	if id == NewRequestID() {
+		t.Error("expected unique request IDs")
+	}
+
+	ctx := WithRequestID(context.Background(), id)
+	if got := RequestIDFromContext(ctx); got != id {
+		t.Errorf("got %q, want %q", got, id)
+	}
+}
@@ -0,0 +1,278 @@
+// ABOUTME: Audit trail of generated AppleScript for debugging injection and unexpected behavior
+// ABOUTME: Hashes every script with the operation that generated it and keeps the most recent ones in a local log
+
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// DefaultScriptLogLimit is how many scripts a ScriptLog keeps when no limit is given
+const DefaultScriptLogLimit = 200
+
+// ScriptHash returns the hex SHA-256 of a script, identifying it without revealing note content
+func ScriptHash(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScriptRecord describes one script run: what generated it, its hash, and how it went
+type ScriptRecord struct {
+	Time       time.Time `json:"time"`
+	Operation  string    `json:"operation"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Hash       string    `json:"hash"`
+	Script     string    `json:"script,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditingExecutor wraps a ScriptExecutor, logging and recording every script it runs
+type AuditingExecutor struct {
+	next        ScriptExecutor
+	includeText bool
+	record      func(ScriptRecord)
+}
+
+// NewAuditingExecutor creates an AuditingExecutor that passes each script's record to record, if not nil
+// Records and log lines always carry the script's hash; the full text only when includeText is set.
+func NewAuditingExecutor(next ScriptExecutor, includeText bool, record func(ScriptRecord)) *AuditingExecutor {
+	return &AuditingExecutor{next: next, includeText: includeText, record: record}
+}
+
+// Execute runs the script with the wrapped executor, then logs and records it
+func (e *AuditingExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	entry := ScriptRecord{
+		Time:      time.Now(),
+		Operation: scriptOperation(),
+		RequestID: RequestIDFromContext(ctx),
+		Hash:      ScriptHash(script),
+	}
+
+	stdout, stderr, err := e.next.Execute(ctx, script)
+	entry.DurationMS = time.Since(entry.Time).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	prefix := ""
+	if entry.RequestID != "" {
+		prefix = "[" + entry.RequestID + "] "
+	}
+	if e.includeText {
+		entry.Script = script
+		log.Printf("%sscript %s for %s:\n%s", prefix, entry.Hash[:12], entry.Operation, script)
+	} else {
+		log.Printf("%sscript %s for %s", prefix, entry.Hash[:12], entry.Operation)
+	}
+	if e.record != nil {
+		e.record(entry)
+	}
+	return stdout, stderr, err
+}
+
+// scriptOperation names the exported method that generated the script being run, such as
+// "AppleNotesService.GetNoteContent", by walking up the stack past unexported helpers
+func scriptOperation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if name := operationName(frame.Function); name != "" {
+			return name
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// operationName turns a function's full name into "Type.Method" when it is an exported method or
+// function, dropping the package path and closure suffixes; anything else returns ""
+func operationName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	if _, rest, ok := strings.Cut(name, "."); ok {
+		name = rest
+	}
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+
+	parts := []string{}
+	for _, part := range strings.Split(name, ".") {
+		if strings.HasPrefix(part, "func") || part == "" {
+			break
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	method := parts[len(parts)-1]
+	if !unicode.IsUpper([]rune(method)[0]) || method == "Execute" {
+		return ""
+	}
+	return strings.Join(parts, ".")
+}
+
+// ScriptLog keeps the most recent script records in a line-oriented file, one JSON record per line
+// When encryptor is set, each line is sealed before it is written.
+type ScriptLog struct {
+	mu        sync.Mutex
+	path      string
+	encryptor *StoreEncryptor
+	limit     int
+	lines     int // records in the file, or -1 until counted
+}
+
+// NewScriptLog creates a ScriptLog at path keeping about limit records.
+// If limit is 0 or negative, defaults to DefaultScriptLogLimit.
+func NewScriptLog(path string, encryptor *StoreEncryptor, limit int) *ScriptLog {
+	if limit <= 0 {
+		limit = DefaultScriptLogLimit
+	}
+	return &ScriptLog{path: path, encryptor: encryptor, limit: limit, lines: -1}
+}
+
+// Append adds a record to the log, trimming it to the newest records once it grows to twice the limit
+func (l *ScriptLog) Append(record ScriptRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to record script: %w", err)
+	}
+	if l.encryptor != nil {
+		if line, err = l.encryptor.Seal(line); err != nil {
+			return fmt.Errorf("failed to encrypt script record: %w", err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lines < 0 {
+		existing, err := l.readLines()
+		if err != nil {
+			return err
+		}
+		l.lines = len(existing)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create script log directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open script log: %w", err)
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write script log: %w", err)
+	}
+	l.lines++
+
+	if l.lines >= 2*l.limit {
+		return l.trim()
+	}
+	return nil
+}
+
+// Last returns up to n of the most recent records, oldest first
+func (l *ScriptLog) Last(n int) ([]ScriptRecord, error) {
+	l.mu.Lock()
+	lines, err := l.readLines()
+	l.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	records := make([]ScriptRecord, 0, len(lines))
+	for _, line := range lines {
+		if IsEncryptedRecord(line) {
+			if l.encryptor == nil {
+				return nil, fmt.Errorf("script log %s is encrypted; set NOTES_MCP_ENCRYPT_STORES=true to read it", l.path)
+			}
+			if line, err = l.encryptor.Open(line); err != nil {
+				return nil, fmt.Errorf("%s: %w", l.path, err)
+			}
+		}
+		var record ScriptRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("%s: malformed script record: %w", l.path, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// readLines returns the non-empty lines of the log file; callers hold l.mu
+func (l *ScriptLog) readLines() ([][]byte, error) {
+	data, err := os.ReadFile(l.path) // #nosec G304 - path is a configured local store
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script log: %w", err)
+	}
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read script log: %w", err)
+	}
+	return lines, nil
+}
+
+// trim rewrites the log with only the newest limit records, replacing it atomically; callers hold l.mu
+func (l *ScriptLog) trim() error {
+	lines, err := l.readLines()
+	if err != nil {
+		return err
+	}
+	if len(lines) > l.limit {
+		lines = lines[len(lines)-l.limit:]
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), "."+filepath.Base(l.path)+".trim-")
+	if err != nil {
+		return fmt.Errorf("failed to trim script log: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // removal after a successful rename is a no-op
+
+	_, err = tmp.Write(append(bytes.Join(lines, []byte("\n")), '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), l.path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to trim script log: %w", err)
+	}
+	l.lines = len(lines)
+	return nil
+}
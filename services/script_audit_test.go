@@ -0,0 +1,111 @@
+// ABOUTME: Unit tests for the AppleScript audit trail
+// ABOUTME: Tests script hashing, operation names from the calling method, and the trimmed, optionally encrypted script log
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditingExecutor(t *testing.T) {
+	var records []ScriptRecord
+	executor := NewAuditingExecutor(&MockExecutor{stdout: "<div>Body</div>"}, false, func(r ScriptRecord) {
+		records = append(records, r)
+	})
+	service := NewAppleNotesService(executor)
+
+	ctx := WithRequestID(context.Background(), "req-7")
+	if _, err := service.GetNoteContent(ctx, "Plan"); err != nil {
+		t.Fatalf("GetNoteContent failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	record := records[0]
+	if record.Operation != "AppleNotesService.GetNoteContent" || record.RequestID != "req-7" {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if len(record.Hash) != 64 || record.Script != "" {
+		t.Errorf("expected a hash without the script text, got %+v", record)
+	}
+
+	full := NewAuditingExecutor(&MockExecutor{err: errors.New("boom")}, true, func(r ScriptRecord) {
+		records = append(records, r)
+	})
+	if _, _, err := full.Execute(context.Background(), `tell application "Notes" to quit`); err == nil {
+		t.Fatal("expected the wrapped executor's error")
+	}
+	last := records[len(records)-1]
+	if last.Script != `tell application "Notes" to quit` || last.Hash != ScriptHash(last.Script) || last.Error != "boom" {
+		t.Errorf("unexpected full record %+v", last)
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	tests := map[string]string{
+		"github.com/harper/notes-mcp/services.(*AppleNotesService).GetNoteContent":  "AppleNotesService.GetNoteContent",
+		"github.com/harper/notes-mcp/services.(*AppleNotesService).ListNotes.func1": "AppleNotesService.ListNotes",
+		"github.com/harper/notes-mcp/services.(*AppleNotesService).getNoteMetadata": "",
+		"github.com/harper/notes-mcp/services.CheckNoteLinks.func2":                 "CheckNoteLinks",
+		"github.com/harper/notes-mcp/services.forEachBounded[...].func1":            "",
+		"github.com/harper/notes-mcp/services.(*AuditingExecutor).Execute":          "",
+	}
+	for function, want := range tests {
+		if got := operationName(function); got != want {
+			t.Errorf("operationName(%q) = %q, want %q", function, got, want)
+		}
+	}
+}
+
+func TestScriptLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug", "scripts.jsonl")
+	scripts := NewScriptLog(path, nil, 3)
+
+	if records, err := scripts.Last(5); err != nil || len(records) != 0 {
+		t.Fatalf("expected an empty log, got %v, %v", records, err)
+	}
+	for _, op := range []string{"a", "b", "c", "d", "e", "f"} {
+		if err := scripts.Append(ScriptRecord{Operation: op, Hash: ScriptHash(op)}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	records, err := scripts.Last(10)
+	if err != nil {
+		t.Fatalf("Last failed: %v", err)
+	}
+	var ops []string
+	for _, record := range records {
+		ops = append(ops, record.Operation)
+	}
+	if strings.Join(ops, "") != "def" {
+		t.Errorf("expected the log trimmed to the newest 3, got %v", ops)
+	}
+	if records, _ := scripts.Last(2); len(records) != 2 || records[1].Operation != "f" {
+		t.Errorf("expected the newest 2 oldest first, got %+v", records)
+	}
+
+	encryptor, err := NewStoreEncryptor(make([]byte, 32))
+	if err != nil {
+		t.Fatalf("NewStoreEncryptor failed: %v", err)
+	}
+	sealedPath := filepath.Join(t.TempDir(), "scripts.jsonl")
+	sealed := NewScriptLog(sealedPath, encryptor, 0)
+	if err := sealed.Append(ScriptRecord{Operation: "secret", Script: "get body of note \"Diary\""}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if data, _ := os.ReadFile(sealedPath); strings.Contains(string(data), "Diary") {
+		t.Error("expected the encrypted log not to contain the script text")
+	}
+	if records, err := sealed.Last(1); err != nil || records[0].Script != "get body of note \"Diary\"" {
+		t.Errorf("expected the record decrypted, got %+v, %v", records, err)
+	}
+	if _, err := NewScriptLog(sealedPath, nil, 0).Last(1); err == nil {
+		t.Error("expected an error reading an encrypted log without a key")
+	}
+}
@@ -0,0 +1,148 @@
+// ABOUTME: Explain mode for advanced search, reporting how a search ran for users debugging slow or missing results
+// ABOUTME: Records the strategy, the generated AppleScript or Spotlight query, candidate and result counts, and timings
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Search strategies reported by ExplainSearch
+const (
+	SearchStrategySpotlight     = "spotlight"            // answered from the Spotlight index
+	SearchStrategyTitleFastPath = "title-fast-path"      // one "whose name contains" query
+	SearchStrategyTitleFiltered = "title-filtered"       // title query, then folder, date, and note filters
+	SearchStrategyBodyScan      = "body-scan"            // every note's body read and matched
+	SearchStrategyBothScan      = "both-scan"            // every note's title and body read and matched
+	SearchStrategyFilteredBody  = "filtered-body-search" // folder, date, and note filters before any body is read
+	SearchStrategyShardedBody   = "sharded-body-search"  // a whole-library body search run as concurrent folder scripts
+)
+
+// SearchExplanation reports how an advanced search ran
+type SearchExplanation struct {
+	Strategy       string        `json:"strategy"`
+	Backend        string        `json:"backend"`
+	SearchIn       string        `json:"search_in"`
+	Script         string        `json:"script,omitempty"`          // the AppleScript run; empty when Spotlight answered
+	SpotlightQuery string        `json:"spotlight_query,omitempty"` // the mdfind query, when Spotlight was tried
+	Fallback       string        `json:"fallback,omitempty"`        // why Spotlight couldn't answer, when it was tried
+	Shards         int           `json:"shards,omitempty"`          // folder scripts a sharded search ran; Script is the first
+	Candidates     int           `json:"candidates"`                // notes in the folder and date range, before matching
+	Results        int           `json:"results"`
+	Matches        []string      `json:"matches"`
+	Timings        SearchTimings `json:"timings"`
+}
+
+// SearchTimings breaks down how long each step of an explained search took, in milliseconds
+type SearchTimings struct {
+	CountMS     float64 `json:"count_ms"`
+	SpotlightMS float64 `json:"spotlight_ms,omitempty"`
+	ScriptMS    float64 `json:"script_ms,omitempty"`
+	TotalMS     float64 `json:"total_ms"`
+}
+
+// SearchExplainer is implemented by providers that can report how they run an advanced search
+type SearchExplainer interface {
+	ExplainSearch(ctx context.Context, opts SearchOptions) (*SearchExplanation, []Note, error)
+}
+
+// ExplainSearch runs an advanced search and reports how it ran along with its results
+// Candidates are counted with a separate script first, so the search itself is timed on its own.
+func (s *AppleNotesService) ExplainSearch(ctx context.Context, opts SearchOptions) (*SearchExplanation, []Note, error) {
+	explain := &SearchExplanation{Backend: opts.Backend, SearchIn: opts.SearchIn, Matches: []string{}}
+	if explain.Backend == "" {
+		explain.Backend = SearchBackendAppleScript
+	}
+	if explain.SearchIn == "" {
+		explain.SearchIn = SearchInTitle
+	}
+	if err := s.validateSearchIn(explain.SearchIn); err != nil {
+		return nil, []Note{}, err
+	}
+
+	start := time.Now()
+	candidates, err := s.countSearchCandidates(ctx, opts)
+	if err != nil {
+		return nil, []Note{}, err
+	}
+	explain.Candidates = candidates
+	explain.Timings.CountMS = durationMS(time.Since(start))
+
+	notes, err := s.searchNotesAdvanced(ctx, opts, explain)
+	if err != nil {
+		return nil, []Note{}, err
+	}
+	explain.Results = len(notes)
+	for _, note := range notes {
+		explain.Matches = append(explain.Matches, note.Title)
+	}
+	explain.Timings.TotalMS = durationMS(time.Since(start))
+	return explain, notes, nil
+}
+
+// searchStrategy returns the strategy an AppleScript search with these options uses
+func searchStrategy(searchIn string, opts SearchOptions) string {
+	needsFiltering := opts.Folder != "" || opts.DateFrom != nil || opts.DateTo != nil || opts.hasNoteFilters()
+	switch {
+	case searchIn != SearchInTitle && needsFiltering:
+		return SearchStrategyFilteredBody
+	case searchIn == SearchInBody:
+		return SearchStrategyBodyScan
+	case searchIn == SearchInBoth:
+		return SearchStrategyBothScan
+	case needsFiltering:
+		return SearchStrategyTitleFiltered
+	default:
+		return SearchStrategyTitleFastPath
+	}
+}
+
+// countSearchCandidates counts the notes in a search's folder and date range, which a body search reads
+func (s *AppleNotesService) countSearchCandidates(ctx context.Context, opts SearchOptions) (int, error) {
+	stdout, stderr, err := s.executor.Execute(ctx, s.buildCandidateCountScript(opts))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count search candidates: %w", DetectError(ctx, stderr, err))
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count search candidates: unexpected output %q", strings.TrimSpace(stdout))
+	}
+	return count, nil
+}
+
+// buildCandidateCountScript builds AppleScript counting the notes in a search's folder and date range
+func (s *AppleNotesService) buildCandidateCountScript(opts SearchOptions) string {
+	scope := "notes"
+	if opts.Folder != "" {
+		scope = fmt.Sprintf(`notes of folder "%s"`, s.escapeForAppleScript(opts.Folder))
+	}
+
+	var conditions []string
+	if opts.DateFrom != nil {
+		conditions = append(conditions, "modification date >= filterFrom")
+	}
+	if opts.DateTo != nil {
+		conditions = append(conditions, "modification date <= filterTo")
+	}
+	if len(conditions) > 0 {
+		scope = "(" + scope + " whose " + strings.Join(conditions, " and ") + ")"
+	}
+
+	return opts.dateFilterScript() + fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				return count of %s
+			end tell
+		end tell
+	`, s.iCloudAccount, scope)
+}
+
+// durationMS returns d in milliseconds, rounded to a tenth
+func durationMS(d time.Duration) float64 {
+	return float64(d.Round(100*time.Microsecond)) / float64(time.Millisecond)
+}
@@ -0,0 +1,101 @@
+// ABOUTME: Unit tests for explaining advanced searches
+// ABOUTME: Tests the reported strategy, script, candidate count, Spotlight fallback, and results
+
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingSearchExecutor answers candidate count scripts with count and other scripts with results
+type countingSearchExecutor struct {
+	count   string
+	results string
+	scripts []string
+}
+
+func (e *countingSearchExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	e.scripts = append(e.scripts, script)
+	if strings.Contains(script, "return count of") {
+		return e.count, "", nil
+	}
+	return e.results, "", nil
+}
+
+func TestSearchStrategy(t *testing.T) {
+	from := time.Now()
+	tests := []struct {
+		searchIn string
+		opts     SearchOptions
+		want     string
+	}{
+		{SearchInTitle, SearchOptions{}, SearchStrategyTitleFastPath},
+		{SearchInTitle, SearchOptions{Folder: "Work"}, SearchStrategyTitleFiltered},
+		{SearchInBody, SearchOptions{}, SearchStrategyBodyScan},
+		{SearchInBoth, SearchOptions{}, SearchStrategyBothScan},
+		{SearchInBody, SearchOptions{DateFrom: &from}, SearchStrategyFilteredBody},
+		{SearchInBoth, SearchOptions{Shared: true}, SearchStrategyFilteredBody},
+	}
+	for _, tt := range tests {
+		if got := searchStrategy(tt.searchIn, tt.opts); got != tt.want {
+			t.Errorf("searchStrategy(%q, %+v) = %q, want %q", tt.searchIn, tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestExplainSearch(t *testing.T) {
+	executor := &countingSearchExecutor{count: "42\n", results: "Roadmap|||Roadmap 2024"}
+	service := NewAppleNotesService(executor)
+	service.spotlight = &mockSpotlightSearcher{}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+	explain, notes, err := service.ExplainSearch(context.Background(), SearchOptions{
+		Query: "roadmap", SearchIn: SearchInBody, Folder: "Work", DateFrom: &from, Backend: SearchBackendSpotlight,
+	})
+	if err != nil {
+		t.Fatalf("ExplainSearch failed: %v", err)
+	}
+	if len(notes) != 2 || explain.Results != 2 || len(explain.Matches) != 2 {
+		t.Errorf("expected two results, got %d notes and %+v", len(notes), explain)
+	}
+	if explain.Candidates != 42 || explain.Strategy != SearchStrategyFilteredBody || explain.Backend != SearchBackendSpotlight {
+		t.Errorf("unexpected explanation %+v", explain)
+	}
+	if explain.Fallback == "" || explain.SpotlightQuery == "" {
+		t.Errorf("expected the Spotlight fallback to be reported, got %+v", explain)
+	}
+	if !strings.Contains(explain.Script, `notes of targetFolder`) || explain.Script != executor.scripts[1] {
+		t.Errorf("expected the search script to be reported, got %q", explain.Script)
+	}
+	if count := executor.scripts[0]; !strings.Contains(count, `count of (notes of folder "Work" whose modification date >= filterFrom)`) {
+		t.Errorf("unexpected count script %q", count)
+	}
+
+	executor.count = "many"
+	if _, _, err := service.ExplainSearch(context.Background(), SearchOptions{Query: "roadmap"}); err == nil {
+		t.Error("expected unreadable count output to fail")
+	}
+	if _, _, err := service.ExplainSearch(context.Background(), SearchOptions{Query: "roadmap", SearchIn: "everywhere"}); err == nil {
+		t.Error("expected an invalid search_in to fail")
+	}
+}
+
+func TestExplainSearchSpotlightHit(t *testing.T) {
+	executor := &countingSearchExecutor{count: "7"}
+	service := NewAppleNotesService(executor)
+	service.spotlight = &mockSpotlightSearcher{titles: []string{"Groceries"}}
+
+	explain, _, err := service.ExplainSearch(context.Background(), SearchOptions{Query: "g", Backend: SearchBackendSpotlight})
+	if err != nil {
+		t.Fatalf("ExplainSearch failed: %v", err)
+	}
+	if explain.Strategy != SearchStrategySpotlight || explain.Script != "" || explain.Fallback != "" || explain.Results != 1 {
+		t.Errorf("unexpected explanation %+v", explain)
+	}
+	if len(executor.scripts) != 1 {
+		t.Errorf("expected only the count script to run, got %d scripts", len(executor.scripts))
+	}
+}
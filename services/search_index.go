@@ -0,0 +1,395 @@
+// ABOUTME: Local full-text index of note bodies answering searches without reading every note
+// ABOUTME: Kept as one JSON record per note, updated incrementally from modification dates and optionally encrypted
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SearchBackendIndex answers searches from the local full-text index kept by SearchIndex
+const SearchBackendIndex = "index"
+
+// IndexedNote is one note's entry in the search index: its listing and plain-text body
+type IndexedNote struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Folder   string    `json:"folder"`
+	Modified time.Time `json:"modified"`
+	Text     string    `json:"text"`
+}
+
+// IndexProgress reports that done of total notes have been indexed, the last being title
+type IndexProgress func(done, total int, title string)
+
+// IndexStatus describes how an index compares with the notes it covers
+type IndexStatus struct {
+	Path      string    `json:"path"`
+	Notes     int       `json:"notes"`
+	Stale     int       `json:"stale"`    // notes created or modified since they were indexed
+	Prunable  int       `json:"prunable"` // indexed notes that no longer exist
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// IndexUpdate counts what an update, rebuild, or prune changed
+type IndexUpdate struct {
+	Indexed int `json:"indexed"`
+	Pruned  int `json:"pruned"`
+	Total   int `json:"total"`
+}
+
+// SearchIndex is a full-text index of note bodies in a line-oriented file, one JSON record per note
+// When encryptor is set, each line is sealed before it is written.
+type SearchIndex struct {
+	mu        sync.Mutex
+	path      string
+	encryptor *StoreEncryptor
+}
+
+// NewSearchIndex creates a SearchIndex kept at path
+func NewSearchIndex(path string, encryptor *StoreEncryptor) *SearchIndex {
+	return &SearchIndex{path: path, encryptor: encryptor}
+}
+
+// Status compares the index with reader's current listing
+func (x *SearchIndex) Status(ctx context.Context, reader NoteReader) (*IndexStatus, error) {
+	listing, err := reader.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check search index: %w", err)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	entries, err := x.load()
+	if err != nil {
+		return nil, err
+	}
+	status := &IndexStatus{Path: x.path, Notes: len(entries), Stale: len(staleNotes(entries, listing))}
+	status.Prunable = len(entries) - len(keepListed(entries, listing))
+	if info, err := os.Stat(x.path); err == nil {
+		status.UpdatedAt = info.ModTime()
+	}
+	return status, nil
+}
+
+// Update indexes notes created or modified since they were indexed and drops notes that no longer exist
+// Only changed notes' bodies are read, so an up-to-date index costs one listing.
+func (x *SearchIndex) Update(ctx context.Context, reader NoteReader, progress IndexProgress) (*IndexUpdate, error) {
+	listing, err := reader.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update search index: %w", err)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	entries, err := x.load()
+	if err != nil {
+		return nil, err
+	}
+	kept := keepListed(entries, listing)
+	update := &IndexUpdate{Pruned: len(entries) - len(kept)}
+	indexed, err := indexNotes(ctx, reader, staleNotes(kept, listing), progress)
+	for id, entry := range indexed {
+		kept[id] = entry
+	}
+	update.Indexed, update.Total = len(indexed), len(kept)
+
+	// Keep whatever was indexed before a failure, so the next update resumes where this one stopped
+	if update.Indexed > 0 || update.Pruned > 0 {
+		if saveErr := x.save(kept); saveErr != nil {
+			return nil, saveErr
+		}
+	}
+	return update, err
+}
+
+// Rebuild discards the index and indexes every note again
+func (x *SearchIndex) Rebuild(ctx context.Context, reader NoteReader, progress IndexProgress) (*IndexUpdate, error) {
+	listing, err := reader.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	previous, err := x.load()
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := indexNotes(ctx, reader, listing, progress)
+	if err != nil {
+		return nil, err
+	}
+	if err := x.save(indexed); err != nil {
+		return nil, err
+	}
+	return &IndexUpdate{Indexed: len(indexed), Pruned: len(keepMissing(previous, indexed)), Total: len(indexed)}, nil
+}
+
+// Prune drops indexed notes that no longer exist without indexing anything new
+func (x *SearchIndex) Prune(ctx context.Context, reader NoteReader) (*IndexUpdate, error) {
+	listing, err := reader.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune search index: %w", err)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	entries, err := x.load()
+	if err != nil {
+		return nil, err
+	}
+	kept := keepListed(entries, listing)
+	update := &IndexUpdate{Pruned: len(entries) - len(kept), Total: len(kept)}
+	if update.Pruned > 0 {
+		if err := x.save(kept); err != nil {
+			return nil, err
+		}
+	}
+	return update, nil
+}
+
+// ApplyChanges brings the index up to date with changes a Watcher detected, reading only the changed notes
+func (x *SearchIndex) ApplyChanges(ctx context.Context, reader NoteReader, changes []NoteChange) (*IndexUpdate, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	entries, err := x.load()
+	if err != nil {
+		return nil, err
+	}
+
+	update := &IndexUpdate{}
+	var failed error
+	for _, change := range changes {
+		if change.Type == ChangeDeleted {
+			if _, ok := entries[change.Note.ID]; ok {
+				delete(entries, change.Note.ID)
+				update.Pruned++
+			}
+			continue
+		}
+		listed := Note{ID: change.Note.ID, Title: change.Note.Title, Folder: change.Note.Folder, ModificationDate: change.Note.Modified}
+		entry, err := indexNote(ctx, reader, listed)
+		if err != nil {
+			failed = err
+			break
+		}
+		entries[entry.ID] = entry
+		update.Indexed++
+	}
+	update.Total = len(entries)
+	if update.Indexed > 0 || update.Pruned > 0 {
+		if err := x.save(entries); err != nil {
+			return nil, err
+		}
+	}
+	return update, failed
+}
+
+// Search matches opts against the indexed titles and bodies, most recently modified first
+// Filters the index can't answer (attachments, checklists, shared, locked, raw HTML) are refused.
+func (x *SearchIndex) Search(opts SearchOptions) ([]Note, error) {
+	if opts.HasAttachments || opts.HasChecklist || opts.Shared || opts.Locked || opts.MatchHTML {
+		return []Note{}, fmt.Errorf("%w: the %s search backend can't filter by attachments, checklists, sharing, locking, or HTML",
+			ErrInvalidInput, SearchBackendIndex)
+	}
+	searchIn := opts.SearchIn
+	if searchIn == "" {
+		searchIn = SearchInTitle
+	}
+	if searchIn != SearchInTitle && searchIn != SearchInBody && searchIn != SearchInBoth {
+		return []Note{}, fmt.Errorf("%w: invalid SearchIn value: %q (must be 'title', 'body', or 'both')", ErrInvalidInput, searchIn)
+	}
+
+	x.mu.Lock()
+	entries, err := x.load()
+	x.mu.Unlock()
+	if err != nil {
+		return []Note{}, err
+	}
+
+	query := strings.ToLower(opts.Query)
+	notes := []Note{}
+	for _, entry := range entries {
+		inTitle := strings.Contains(strings.ToLower(entry.Title), query)
+		inBody := strings.Contains(strings.ToLower(entry.Text), query)
+		switch {
+		case searchIn == SearchInTitle && !inTitle, searchIn == SearchInBody && !inBody, !inTitle && !inBody:
+			continue
+		case opts.Folder != "" && entry.Folder != opts.Folder:
+			continue
+		case opts.DateFrom != nil && entry.Modified.Before(*opts.DateFrom):
+			continue
+		case opts.DateTo != nil && entry.Modified.After(*opts.DateTo):
+			continue
+		case slices.Contains(opts.ExcludeFolders, entry.Folder):
+			continue
+		}
+		notes = append(notes, Note{
+			ID:               entry.ID,
+			Title:            entry.Title,
+			Tags:             []string{},
+			Folder:           entry.Folder,
+			Modified:         entry.Modified,
+			ModificationDate: entry.Modified,
+		})
+	}
+	newestFirst(notes)
+	return notes, nil
+}
+
+// UpdateAndSearch runs Update and then Search, so results reflect notes changed since the index was last updated
+func (x *SearchIndex) UpdateAndSearch(ctx context.Context, reader NoteReader, opts SearchOptions) ([]Note, error) {
+	if _, err := x.Update(ctx, reader, nil); err != nil {
+		return []Note{}, err
+	}
+	return x.Search(opts)
+}
+
+// listedModified returns a listed note's modification date, whichever field the backend filled in
+func listedModified(note Note) time.Time {
+	if !note.ModificationDate.IsZero() {
+		return note.ModificationDate
+	}
+	return note.Modified
+}
+
+// staleNotes returns the listed notes that are missing from entries or modified since they were indexed
+func staleNotes(entries map[string]IndexedNote, listing []Note) []Note {
+	stale := []Note{}
+	for _, note := range listing {
+		entry, ok := entries[note.ID]
+		if !ok || !entry.Modified.Equal(listedModified(note)) || entry.Title != note.Title || entry.Folder != note.Folder {
+			stale = append(stale, note)
+		}
+	}
+	return stale
+}
+
+// keepListed returns the entries whose notes are still listed
+func keepListed(entries map[string]IndexedNote, listing []Note) map[string]IndexedNote {
+	kept := make(map[string]IndexedNote, len(listing))
+	for _, note := range listing {
+		if entry, ok := entries[note.ID]; ok {
+			kept[note.ID] = entry
+		}
+	}
+	return kept
+}
+
+// keepMissing returns the entries of previous that aren't in current
+func keepMissing(previous, current map[string]IndexedNote) map[string]IndexedNote {
+	missing := map[string]IndexedNote{}
+	for id, entry := range previous {
+		if _, ok := current[id]; !ok {
+			missing[id] = entry
+		}
+	}
+	return missing
+}
+
+// indexNotes reads the bodies of notes, reporting progress after each
+// On failure it returns the notes indexed so far along with the error.
+func indexNotes(ctx context.Context, reader NoteReader, notes []Note, progress IndexProgress) (map[string]IndexedNote, error) {
+	indexed := make(map[string]IndexedNote, len(notes))
+	for i, note := range notes {
+		entry, err := indexNote(ctx, reader, note)
+		if err != nil {
+			return indexed, err
+		}
+		indexed[entry.ID] = entry
+		if progress != nil {
+			progress(i+1, len(notes), note.Title)
+		}
+	}
+	return indexed, nil
+}
+
+// indexNote reads one listed note's body by ID and makes its index entry
+func indexNote(ctx context.Context, reader NoteReader, note Note) (IndexedNote, error) {
+	body, err := reader.GetNoteContentByID(ctx, note.ID)
+	if err != nil {
+		return IndexedNote{}, fmt.Errorf("failed to index %q: %w", note.Title, err)
+	}
+	return IndexedNote{
+		ID:       note.ID,
+		Title:    note.Title,
+		Folder:   note.Folder,
+		Modified: listedModified(note),
+		Text:     stripHTML(lineBreakPattern.ReplaceAllString(body, " ")),
+	}, nil
+}
+
+// load reads every entry, keyed by note ID; a missing file is an empty index. Callers hold x.mu
+func (x *SearchIndex) load() (map[string]IndexedNote, error) {
+	lines, err := readStoreLines(x.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+
+	entries := make(map[string]IndexedNote, len(lines))
+	for _, line := range lines {
+		var entry IndexedNote
+		if err := decodeStoreLine(x.path, line, x.encryptor, &entry); err != nil {
+			return nil, fmt.Errorf("failed to read search index: %w", err)
+		}
+		entries[entry.ID] = entry
+	}
+	return entries, nil
+}
+
+// save replaces the index file with entries, sorted by note ID, atomically; callers hold x.mu
+func (x *SearchIndex) save(entries map[string]IndexedNote) error {
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	lines := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		line, err := encodeStoreLine(entries[id], x.encryptor)
+		if err != nil {
+			return fmt.Errorf("failed to write search index: %w", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := replaceStoreLines(x.path, lines); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	return nil
+}
+
+// SearchIndexMiddleware answers SearchNotesAdvanced calls asking for SearchBackendIndex from the index open
+// returns, first updating it from reader with whatever was modified since; other calls pass through
+func SearchIndexMiddleware(open func() (*SearchIndex, error), reader NoteReader) ServiceMiddleware {
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			if call.Operation != "SearchNotesAdvanced" || len(call.Args) != 1 {
+				return next(ctx, call)
+			}
+			opts, ok := call.Args[0].(SearchOptions)
+			if !ok || opts.Backend != SearchBackendIndex {
+				return next(ctx, call)
+			}
+
+			index, err := open()
+			if err != nil {
+				return []Note{}, fmt.Errorf("failed to search notes: %w", err)
+			}
+			return index.UpdateAndSearch(ctx, reader, opts)
+		}
+	}
+}
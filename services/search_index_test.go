@@ -0,0 +1,210 @@
+// ABOUTME: Unit tests for the full-text search index
+// ABOUTME: Indexes an in-memory library and checks incremental updates, pruning, searches, and encryption
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingReader counts body reads on top of the in-memory service
+type countingReader struct {
+	*MemoryNotesService
+	bodyReads int
+}
+
+func (r *countingReader) GetNoteContentByID(ctx context.Context, noteID string) (string, error) {
+	r.bodyReads++
+	return r.MemoryNotesService.GetNoteContentByID(ctx, noteID)
+}
+
+// newIndexedLibrary returns a memory library with two notes whose clock advances a minute per change
+func newIndexedLibrary(t *testing.T) (*countingReader, map[string]string) {
+	t.Helper()
+	clock := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	memory := NewMemoryNotesService()
+	memory.now = func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+
+	ids := map[string]string{}
+	for title, body := range map[string]string{"Roadmap": "Ship the <b>index</b> in Q2", "Groceries": "Milk\nEggs"} {
+		note, err := memory.CreateNote(context.Background(), title, body, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[title] = note.ID
+	}
+	return &countingReader{MemoryNotesService: memory}, ids
+}
+
+func TestSearchIndexUpdateIsIncremental(t *testing.T) {
+	ctx := context.Background()
+	reader, ids := newIndexedLibrary(t)
+	index := NewSearchIndex(filepath.Join(t.TempDir(), "index.jsonl"), nil)
+
+	var progress []string
+	update, err := index.Update(ctx, reader, func(done, total int, title string) {
+		progress = append(progress, title)
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if update.Indexed != 2 || update.Total != 2 || len(progress) != 2 {
+		t.Errorf("expected both notes indexed with progress, got %+v and %v", update, progress)
+	}
+
+	reader.bodyReads = 0
+	if update, err := index.Update(ctx, reader, nil); err != nil || update.Indexed != 0 || reader.bodyReads != 0 {
+		t.Errorf("expected an up-to-date index to read no bodies, got %+v, %d reads, %v", update, reader.bodyReads, err)
+	}
+
+	if err := reader.UpdateNoteByID(ctx, ids["Groceries"], "Milk\nEggs\nCoffee beans"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.DeleteNoteByID(ctx, ids["Roadmap"]); err != nil {
+		t.Fatal(err)
+	}
+	status, err := index.Status(ctx, reader)
+	if err != nil || status.Stale != 1 || status.Prunable != 1 || status.UpdatedAt.IsZero() {
+		t.Errorf("expected one stale and one prunable note, got %+v, %v", status, err)
+	}
+
+	update, err = index.Update(ctx, reader, nil)
+	if err != nil || update.Indexed != 1 || update.Pruned != 1 || reader.bodyReads != 1 {
+		t.Errorf("expected only the modified note re-read and the deleted one dropped, got %+v, %d reads, %v", update, reader.bodyReads, err)
+	}
+	results, err := index.Search(SearchOptions{Query: "coffee", SearchIn: SearchInBody})
+	if err != nil || len(results) != 1 || results[0].Title != "Groceries" {
+		t.Errorf("expected the updated body to be searchable, got %+v, %v", results, err)
+	}
+}
+
+func TestSearchIndexSearch(t *testing.T) {
+	ctx := context.Background()
+	reader, _ := newIndexedLibrary(t)
+	index := NewSearchIndex(filepath.Join(t.TempDir(), "index.jsonl"), nil)
+	if _, err := index.Rebuild(ctx, reader, nil); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want []string
+	}{
+		{name: "title by default", opts: SearchOptions{Query: "road"}, want: []string{"Roadmap"}},
+		{name: "body text without markup", opts: SearchOptions{Query: "the index", SearchIn: SearchInBody}, want: []string{"Roadmap"}},
+		{name: "title only misses body", opts: SearchOptions{Query: "milk"}, want: []string{}},
+		{name: "both", opts: SearchOptions{Query: "milk", SearchIn: SearchInBoth}, want: []string{"Groceries"}},
+		{name: "excluded folder", opts: SearchOptions{Query: "", SearchIn: SearchInBoth, ExcludeFolders: []string{"Notes"}}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := index.Search(tt.opts)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			titles := []string{}
+			for _, note := range results {
+				titles = append(titles, note.Title)
+			}
+			if strings.Join(titles, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Search = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+
+	if _, err := index.Search(SearchOptions{Query: "x", HasAttachments: true}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an attachment filter, got %v", err)
+	}
+}
+
+func TestSearchIndexPruneAndApplyChanges(t *testing.T) {
+	ctx := context.Background()
+	reader, ids := newIndexedLibrary(t)
+	index := NewSearchIndex(filepath.Join(t.TempDir(), "index.jsonl"), nil)
+	if _, err := index.Rebuild(ctx, reader, nil); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	if err := reader.DeleteNoteByID(ctx, ids["Roadmap"]); err != nil {
+		t.Fatal(err)
+	}
+	created, err := reader.CreateNote(ctx, "Ideas", "Try a trie", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update, err := index.Prune(ctx, reader)
+	if err != nil || update.Pruned != 1 || update.Indexed != 0 || update.Total != 1 {
+		t.Errorf("expected prune to drop only the deleted note, got %+v, %v", update, err)
+	}
+
+	update, err = index.ApplyChanges(ctx, reader, []NoteChange{
+		{Type: ChangeCreated, Note: NoteState{ID: created.ID, Title: "Ideas", Folder: "Notes", Modified: created.ModificationDate}},
+		{Type: ChangeDeleted, Note: NoteState{ID: ids["Groceries"], Title: "Groceries"}},
+	})
+	if err != nil || update.Indexed != 1 || update.Pruned != 1 {
+		t.Fatalf("expected one note indexed and one dropped, got %+v, %v", update, err)
+	}
+	results, err := index.Search(SearchOptions{Query: "trie", SearchIn: SearchInBody})
+	if err != nil || len(results) != 1 || results[0].ID != created.ID {
+		t.Errorf("expected the created note, got %+v, %v", results, err)
+	}
+}
+
+func TestSearchIndexEncrypted(t *testing.T) {
+	ctx := context.Background()
+	reader, _ := newIndexedLibrary(t)
+	encryptor, err := NewStoreEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	if _, err := NewSearchIndex(path, encryptor).Rebuild(ctx, reader, nil); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("Milk")) {
+		t.Errorf("index contains plaintext: %q", data)
+	}
+	if _, err := NewSearchIndex(path, nil).Search(SearchOptions{Query: "milk"}); err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Errorf("expected reading without the key to fail, got %v", err)
+	}
+	results, err := NewSearchIndex(path, encryptor).Search(SearchOptions{Query: "milk", SearchIn: SearchInBody})
+	if err != nil || len(results) != 1 {
+		t.Errorf("expected the encrypted index to be searchable with the key, got %+v, %v", results, err)
+	}
+}
+
+func TestSearchIndexMiddleware(t *testing.T) {
+	ctx := context.Background()
+	reader, _ := newIndexedLibrary(t)
+	index := NewSearchIndex(filepath.Join(t.TempDir(), "index.jsonl"), nil)
+	opened := 0
+	service := DecorateNotesService(reader.MemoryNotesService, SearchIndexMiddleware(func() (*SearchIndex, error) {
+		opened++
+		return index, nil
+	}, reader))
+
+	results, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "eggs", SearchIn: SearchInBody, Backend: SearchBackendIndex})
+	if err != nil || len(results) != 1 || results[0].Title != "Groceries" {
+		t.Errorf("expected the index to be built and searched, got %+v, %v", results, err)
+	}
+
+	if _, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "eggs", SearchIn: SearchInBody}); err != nil || opened != 1 {
+		t.Errorf("expected other backends to skip the index, opened %d times, %v", opened, err)
+	}
+}
@@ -0,0 +1,135 @@
+// ABOUTME: Unit tests for sharding whole-library body searches by folder
+// ABOUTME: Tests merged results, skipped and excluded folders, fallbacks to one script, and shard failures
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// shardExecutor answers the folder listing with folders, candidate counts with its first line, and each
+// folder's search with its titles
+type shardExecutor struct {
+	mu      sync.Mutex
+	folders string
+	titles  map[string]string // folder ID to "|||"-separated titles; "fail" fails the script
+	scripts []string
+}
+
+func (e *shardExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.scripts = append(e.scripts, script)
+
+	if strings.Contains(script, "repeat with f in folders") {
+		return e.folders, "", nil
+	}
+	if strings.Contains(script, "return count of") {
+		return strings.SplitN(e.folders, "\n", 2)[0], "", nil
+	}
+	for id, titles := range e.titles {
+		if strings.Contains(script, `folder id "`+id+`"`) {
+			if titles == "fail" {
+				return "", "Notes got an error", errors.New("exit status 1")
+			}
+			return titles, "", nil
+		}
+	}
+	return "Whole library", "", nil
+}
+
+func TestSearchShards(t *testing.T) {
+	ctx := context.Background()
+	folders := "250\nf1\t150\tWork\nf2\t90\tHome\nf3\t0\tEmpty\nf4\t10\tArchive"
+	executor := &shardExecutor{folders: folders, titles: map[string]string{"f1": "Roadmap|||Road trip", "f2": "Road salt", "f4": "Old road"}}
+	service := NewAppleNotesService(executor)
+
+	notes, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBody, ExcludeFolders: []string{"Archive"}})
+	if err != nil {
+		t.Fatalf("SearchNotesAdvanced failed: %v", err)
+	}
+	var titles []string
+	for _, note := range notes {
+		titles = append(titles, note.Title)
+	}
+	if strings.Join(titles, ",") != "Roadmap,Road trip,Road salt" {
+		t.Errorf("expected results merged in folder order, got %v", titles)
+	}
+	// The listing plus Work and Home; the empty and excluded folders aren't searched
+	if len(executor.scripts) != 3 {
+		t.Errorf("expected 3 scripts, got %d", len(executor.scripts))
+	}
+
+	explain, _, err := service.ExplainSearch(ctx, SearchOptions{Query: "road", SearchIn: SearchInBoth})
+	if err != nil {
+		t.Fatalf("ExplainSearch failed: %v", err)
+	}
+	if explain.Strategy != SearchStrategyShardedBody || explain.Shards != 3 || explain.Results != 4 || !strings.Contains(explain.Script, `folder id "f1"`) {
+		t.Errorf("unexpected explanation %+v", explain)
+	}
+
+	executor.titles["f2"] = "fail"
+	if _, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBody}); err == nil || !strings.Contains(err.Error(), `"Home"`) {
+		t.Errorf("expected a failed shard to fail the search, got %v", err)
+	}
+}
+
+func TestSearchShardsFallBackToOneScript(t *testing.T) {
+	for name, folders := range map[string]string{
+		"small library":      "150\nf1\t100\tWork\nf2\t50\tHome",
+		"one folder":         "500\nf1\t500\tWork\nf2\t0\tHome",
+		"uncounted notes":    "500\nf1\t300\tWork\nf2\t100\tHome",
+		"unexpected listing": "lots of notes",
+	} {
+		t.Run(name, func(t *testing.T) {
+			executor := &shardExecutor{folders: folders, titles: map[string]string{}}
+			service := NewAppleNotesService(executor)
+
+			notes, err := service.SearchNotesAdvanced(context.Background(), SearchOptions{Query: "road", SearchIn: SearchInBody})
+			if err != nil {
+				t.Fatalf("SearchNotesAdvanced failed: %v", err)
+			}
+			if len(notes) != 1 || notes[0].Title != "Whole library" || len(executor.scripts) != 2 {
+				t.Errorf("expected one whole-library script after the listing, got %+v after %d scripts", notes, len(executor.scripts))
+			}
+		})
+	}
+
+	// Folder-scoped searches, title searches, and a concurrency of one never list folders
+	executor := &shardExecutor{folders: "500\nf1\t250\tWork\nf2\t250\tHome"}
+	service := NewAppleNotesService(executor)
+	_, _ = service.SearchNotesAdvanced(context.Background(), SearchOptions{Query: "road", SearchIn: SearchInBody, Folder: "Work"})
+	_, _ = service.SearchNotesAdvanced(context.Background(), SearchOptions{Query: "road"})
+	service.SetConcurrency(1)
+	_, _ = service.SearchNotesAdvanced(context.Background(), SearchOptions{Query: "road", SearchIn: SearchInBoth})
+	if len(executor.scripts) != 3 {
+		t.Errorf("expected 3 scripts without a folder listing, got %d", len(executor.scripts))
+	}
+}
+
+func TestSearchShardsHoldEachFolderToTheLimit(t *testing.T) {
+	ctx := context.Background()
+	executor := &shardExecutor{folders: "5000\nf1\t2000\tWork\nf2\t1800\tHome\nf3\t1200\tArchive",
+		titles: map[string]string{"f1": "Roadmap", "f2": "Road salt", "f3": "Old road"}}
+	service := NewAppleNotesService(executor)
+	service.SetMaxBodySearchNotes(2000)
+	service.spotlight = &mockSpotlightSearcher{err: errors.New("index unavailable")}
+
+	notes, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBody})
+	if err != nil {
+		t.Fatalf("expected a library over the limit to be searched folder by folder, got %v", err)
+	}
+	if len(notes) != 3 || len(executor.scripts) != 4 {
+		t.Errorf("expected three folder results after the listing, got %+v after %d scripts", notes, len(executor.scripts))
+	}
+
+	// A folder over the limit leaves the search to the whole-library guard, which refuses it
+	executor.folders = "5000\nf1\t4000\tWork\nf2\t1000\tHome"
+	if _, err := service.SearchNotesAdvanced(ctx, SearchOptions{Query: "road", SearchIn: SearchInBody}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a folder over the limit to be refused, got %v", err)
+	}
+}
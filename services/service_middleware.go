@@ -0,0 +1,591 @@
+// ABOUTME: Middleware chain decorating every NotesService operation, configured in one place
+// ABOUTME: Provides tracing, metrics, read-only policy, result caching, and retries of transient failures
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OperationKind says whether a NotesService operation only reads or also changes notes
+type OperationKind int
+
+// Operation kinds
+const (
+	OperationRead OperationKind = iota
+	OperationWrite
+)
+
+// ServiceCall is one NotesService operation passing through the middleware chain
+type ServiceCall struct {
+	Operation string        // method name, such as "GetNoteContent"
+	Kind      OperationKind // whether the operation changes notes
+	Args      []any         // arguments after ctx, for cache keys and policy checks
+	run       func(ctx context.Context) (any, error)
+}
+
+// ServiceHandler runs a call and returns the operation's result
+type ServiceHandler func(ctx context.Context, call *ServiceCall) (any, error)
+
+// ServiceMiddleware decorates every NotesService operation, such as for logging, caching, or retries
+type ServiceMiddleware func(next ServiceHandler) ServiceHandler
+
+// DecorateNotesService wraps service so each operation runs through middleware, the first outermost
+// The result still reports the interfaces of the backend it decorates.
+func DecorateNotesService(service NotesService, middleware ...ServiceMiddleware) NotesService {
+	if len(middleware) == 0 {
+		return service
+	}
+
+	handler := ServiceHandler(func(ctx context.Context, call *ServiceCall) (any, error) {
+		return call.run(ctx)
+	})
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return &decoratedNotesService{base: service, handler: handler}
+}
+
+// decoratedNotesService runs each NotesService method through a middleware chain before base
+type decoratedNotesService struct {
+	base    NotesService
+	handler ServiceHandler
+}
+
+// UndecoratedNotesService returns the service DecorateNotesService wrapped, or service itself
+// Callers use it to reach optional interfaces of the backend, such as PermissionChecker.
+func UndecoratedNotesService(service NotesService) NotesService {
+	if decorated, ok := service.(*decoratedNotesService); ok {
+		return decorated.base
+	}
+	return service
+}
+
+// backendInterfaces reports the interfaces of the decorated backend
+func (s *decoratedNotesService) backendInterfaces() BackendInterfaces {
+	return InterfacesOf(s.base)
+}
+
+// invoke runs one typed operation through the chain
+func invoke[T any](ctx context.Context, s *decoratedNotesService, operation string, kind OperationKind, args []any,
+	run func(ctx context.Context) (T, error)) (T, error) {
+	call := &ServiceCall{Operation: operation, Kind: kind, Args: args, run: func(ctx context.Context) (any, error) {
+		return run(ctx)
+	}}
+	result, err := s.handler(ctx, call)
+	value, _ := result.(T)
+	return value, err
+}
+
+// TraceMiddleware reports each operation's duration and outcome to the trace carried by ctx
+func TraceMiddleware() ServiceMiddleware {
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, call)
+			if err != nil {
+				tracef(ctx, "%s failed after %s: %v", call.Operation, time.Since(start).Round(time.Millisecond), err)
+			} else {
+				tracef(ctx, "%s completed in %s", call.Operation, time.Since(start).Round(time.Millisecond))
+			}
+			return result, err
+		}
+	}
+}
+
+// OperationStats summarizes the calls to one NotesService operation
+type OperationStats struct {
+	Operation  string  `json:"operation"`
+	Calls      int     `json:"calls"`
+	Errors     int     `json:"errors"`
+	AverageMS  float64 `json:"average_ms"`
+	totalNanos int64
+}
+
+// ServiceMetrics counts calls, errors, and time spent per NotesService operation
+type ServiceMetrics struct {
+	mu    sync.Mutex
+	stats map[string]*OperationStats
+}
+
+// NewServiceMetrics creates an empty metrics collector
+func NewServiceMetrics() *ServiceMetrics {
+	return &ServiceMetrics{stats: map[string]*OperationStats{}}
+}
+
+// Snapshot returns the stats of every operation called so far, sorted by operation
+func (m *ServiceMetrics) Snapshot() []OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make([]OperationStats, 0, len(m.stats))
+	for _, stats := range m.stats {
+		entry := *stats
+		entry.AverageMS = float64(stats.totalNanos) / float64(stats.Calls) / float64(time.Millisecond)
+		snapshot = append(snapshot, entry)
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Operation < snapshot[j].Operation })
+	return snapshot
+}
+
+// record adds one call's outcome
+func (m *ServiceMetrics) record(operation string, elapsed time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.stats[operation]
+	if !ok {
+		stats = &OperationStats{Operation: operation}
+		m.stats[operation] = stats
+	}
+	stats.Calls++
+	stats.totalNanos += int64(elapsed)
+	if err != nil {
+		stats.Errors++
+	}
+}
+
+// MetricsMiddleware records every operation's duration and outcome in metrics
+func MetricsMiddleware(metrics *ServiceMetrics) ServiceMiddleware {
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, call)
+			metrics.record(call.Operation, time.Since(start), err)
+			return result, err
+		}
+	}
+}
+
+// ReadOnlyMiddleware refuses every operation that would change notes or folders
+func ReadOnlyMiddleware() ServiceMiddleware {
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			if call.Kind == OperationWrite {
+				return nil, fmt.Errorf("failed to run %s: %w", call.Operation, ErrReadOnly)
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// isTransient reports whether an error may clear up if the operation is simply run again
+func isTransient(err error) bool {
+	return errors.Is(err, ErrScriptTimeout) || errors.Is(err, ErrNotesAppNotRunning)
+}
+
+// RetryMiddleware runs reads that fail with a transient error up to retries more times
+// Waits grow by backoff per attempt. Writes are never retried, since a timed-out write may have landed.
+func RetryMiddleware(retries int, backoff time.Duration) ServiceMiddleware {
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			result, err := next(ctx, call)
+			for attempt := 1; attempt <= retries && err != nil && call.Kind == OperationRead && isTransient(err); attempt++ {
+				tracef(ctx, "%s failed (%v); retry %d of %d", call.Operation, err, attempt, retries)
+				select {
+				case <-ctx.Done():
+					return result, err
+				case <-time.After(time.Duration(attempt) * backoff):
+				}
+				result, err = next(ctx, call)
+			}
+			return result, err
+		}
+	}
+}
+
+// cacheableOperations are the reads whose results are safe to share: strings and cloned string lists
+var cacheableOperations = map[string]bool{
+	"GetNoteContent":     true,
+	"GetNoteContentByID": true,
+	"ExportNoteMarkdown": true,
+	"ExportNoteText":     true,
+	"ListFolders":        true,
+}
+
+// cachedResult is a cached read and when it stops being trusted
+type cachedResult struct {
+	value   any
+	expires time.Time
+}
+
+// CacheMiddleware remembers note bodies, exports, and folder lists for ttl
+// Any write clears the whole cache, so a session sees its own changes at once; edits made in
+// Notes.app show up once the ttl passes. Errors are never cached. When file is not nil, results
+// are also kept there, so other processes sharing the file are served and cleared by them too.
+func CacheMiddleware(ttl time.Duration, now func() time.Time, file *CacheFile) ServiceMiddleware {
+	var (
+		mu    sync.Mutex
+		cache = map[string]cachedResult{}
+	)
+
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			if call.Kind == OperationWrite {
+				result, err := next(ctx, call)
+				mu.Lock()
+				clear(cache)
+				mu.Unlock()
+				if file != nil {
+					if err := file.clear(); err != nil {
+						tracef(ctx, "cache file not cleared: %v", err)
+					}
+				}
+				return result, err
+			}
+			if !cacheableOperations[call.Operation] {
+				return next(ctx, call)
+			}
+
+			key := fmt.Sprintf("%s%q", call.Operation, call.Args)
+			mu.Lock()
+			entry, ok := cache[key]
+			mu.Unlock()
+			if ok && now().Before(entry.expires) {
+				tracef(ctx, "%s served from cache", call.Operation)
+				return cloneCached(entry.value), nil
+			}
+			if file != nil {
+				value, expires, ok, err := file.get(key, now())
+				if err != nil {
+					tracef(ctx, "cache file not read: %v", err)
+				}
+				if ok {
+					tracef(ctx, "%s served from the cache file", call.Operation)
+					mu.Lock()
+					cache[key] = cachedResult{value: cloneCached(value), expires: expires}
+					mu.Unlock()
+					return value, nil
+				}
+			}
+
+			result, err := next(ctx, call)
+			if err == nil {
+				expires := now().Add(ttl)
+				mu.Lock()
+				cache[key] = cachedResult{value: cloneCached(result), expires: expires}
+				mu.Unlock()
+				if file != nil {
+					if err := file.put(key, result, expires, now()); err != nil {
+						tracef(ctx, "cache file not written: %v", err)
+					}
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+// cloneCached copies slices so callers can't change a cached result
+func cloneCached(value any) any {
+	if list, ok := value.([]string); ok {
+		return slices.Clone(list)
+	}
+	return value
+}
+
+func (s *decoratedNotesService) SearchNotes(ctx context.Context, query string) ([]Note, error) {
+	return invoke(ctx, s, "SearchNotes", OperationRead, []any{query}, func(ctx context.Context) ([]Note, error) {
+		return s.base.SearchNotes(ctx, query)
+	})
+}
+
+func (s *decoratedNotesService) SearchNotesAdvanced(ctx context.Context, opts SearchOptions) ([]Note, error) {
+	return invoke(ctx, s, "SearchNotesAdvanced", OperationRead, []any{opts}, func(ctx context.Context) ([]Note, error) {
+		return s.base.SearchNotesAdvanced(ctx, opts)
+	})
+}
+
+func (s *decoratedNotesService) GetNoteContent(ctx context.Context, title string) (string, error) {
+	return invoke(ctx, s, "GetNoteContent", OperationRead, []any{title}, func(ctx context.Context) (string, error) {
+		return s.base.GetNoteContent(ctx, title)
+	})
+}
+
+func (s *decoratedNotesService) GetNoteContentByID(ctx context.Context, noteID string) (string, error) {
+	return invoke(ctx, s, "GetNoteContentByID", OperationRead, []any{noteID}, func(ctx context.Context) (string, error) {
+		return s.base.GetNoteContentByID(ctx, noteID)
+	})
+}
+
+func (s *decoratedNotesService) GetNoteMetadata(ctx context.Context, title string) (*Note, error) {
+	return invoke(ctx, s, "GetNoteMetadata", OperationRead, []any{title}, func(ctx context.Context) (*Note, error) {
+		return s.base.GetNoteMetadata(ctx, title)
+	})
+}
+
+func (s *decoratedNotesService) GetNoteTitleByID(ctx context.Context, noteID string) (string, error) {
+	return invoke(ctx, s, "GetNoteTitleByID", OperationRead, []any{noteID}, func(ctx context.Context) (string, error) {
+		return s.base.GetNoteTitleByID(ctx, noteID)
+	})
+}
+
+func (s *decoratedNotesService) HasNoteChanged(ctx context.Context, title, hash string) (bool, string, error) {
+	type changeResult struct {
+		changed bool
+		hash    string
+	}
+	result, err := invoke(ctx, s, "HasNoteChanged", OperationRead, []any{title, hash}, func(ctx context.Context) (changeResult, error) {
+		changed, current, err := s.base.HasNoteChanged(ctx, title, hash)
+		return changeResult{changed, current}, err
+	})
+	return result.changed, result.hash, err
+}
+
+func (s *decoratedNotesService) GetRecentNotes(ctx context.Context, limit int) ([]Note, error) {
+	return invoke(ctx, s, "GetRecentNotes", OperationRead, []any{limit}, func(ctx context.Context) ([]Note, error) {
+		return s.base.GetRecentNotes(ctx, limit)
+	})
+}
+
+func (s *decoratedNotesService) GetNotesInFolder(ctx context.Context, folder string) ([]Note, error) {
+	return invoke(ctx, s, "GetNotesInFolder", OperationRead, []any{folder}, func(ctx context.Context) ([]Note, error) {
+		return s.base.GetNotesInFolder(ctx, folder)
+	})
+}
+
+func (s *decoratedNotesService) GetRecentNotesInFolder(ctx context.Context, folder string, limit int) ([]Note, error) {
+	return invoke(ctx, s, "GetRecentNotesInFolder", OperationRead, []any{folder, limit}, func(ctx context.Context) ([]Note, error) {
+		return s.base.GetRecentNotesInFolder(ctx, folder, limit)
+	})
+}
+
+func (s *decoratedNotesService) ListNotesWithMetadata(ctx context.Context, folder string) ([]Note, error) {
+	return invoke(ctx, s, "ListNotesWithMetadata", OperationRead, []any{folder}, func(ctx context.Context) ([]Note, error) {
+		return s.base.ListNotesWithMetadata(ctx, folder)
+	})
+}
+
+func (s *decoratedNotesService) ListNotesByPrefix(ctx context.Context, prefix, folder string) ([]Note, error) {
+	return invoke(ctx, s, "ListNotesByPrefix", OperationRead, []any{prefix, folder}, func(ctx context.Context) ([]Note, error) {
+		return s.base.ListNotesByPrefix(ctx, prefix, folder)
+	})
+}
+
+func (s *decoratedNotesService) GetNotesModifiedBetween(ctx context.Context, from, to time.Time) ([]Note, error) {
+	return invoke(ctx, s, "GetNotesModifiedBetween", OperationRead, []any{from, to}, func(ctx context.Context) ([]Note, error) {
+		return s.base.GetNotesModifiedBetween(ctx, from, to)
+	})
+}
+
+func (s *decoratedNotesService) WithNoteMetrics(ctx context.Context, notes []Note) ([]Note, error) {
+	return invoke(ctx, s, "WithNoteMetrics", OperationRead, []any{notes}, func(ctx context.Context) ([]Note, error) {
+		return s.base.WithNoteMetrics(ctx, notes)
+	})
+}
+
+func (s *decoratedNotesService) ExtractActionItems(ctx context.Context, noteTitle string) ([]ActionItem, error) {
+	return invoke(ctx, s, "ExtractActionItems", OperationRead, []any{noteTitle}, func(ctx context.Context) ([]ActionItem, error) {
+		return s.base.ExtractActionItems(ctx, noteTitle)
+	})
+}
+
+func (s *decoratedNotesService) FindActionItems(ctx context.Context, query, folder string) ([]ActionItem, error) {
+	return invoke(ctx, s, "FindActionItems", OperationRead, []any{query, folder}, func(ctx context.Context) ([]ActionItem, error) {
+		return s.base.FindActionItems(ctx, query, folder)
+	})
+}
+
+func (s *decoratedNotesService) GetUpcomingDeadlines(ctx context.Context, days int, folder string) ([]ActionItem, error) {
+	return invoke(ctx, s, "GetUpcomingDeadlines", OperationRead, []any{days, folder}, func(ctx context.Context) ([]ActionItem, error) {
+		return s.base.GetUpcomingDeadlines(ctx, days, folder)
+	})
+}
+
+func (s *decoratedNotesService) CreateNote(ctx context.Context, title, content string, tags []string) (*Note, error) {
+	return invoke(ctx, s, "CreateNote", OperationWrite, []any{title, content, tags}, func(ctx context.Context) (*Note, error) {
+		return s.base.CreateNote(ctx, title, content, tags)
+	})
+}
+
+func (s *decoratedNotesService) UpdateNote(ctx context.Context, title, content string) error {
+	_, err := invoke(ctx, s, "UpdateNote", OperationWrite, []any{title, content}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.UpdateNote(ctx, title, content)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) UpdateNoteIfUnchanged(ctx context.Context, title, content string, precondition UpdatePrecondition) error {
+	_, err := invoke(ctx, s, "UpdateNoteIfUnchanged", OperationWrite, []any{title, content, precondition}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.UpdateNoteIfUnchanged(ctx, title, content, precondition)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) DeleteNote(ctx context.Context, title string) error {
+	_, err := invoke(ctx, s, "DeleteNote", OperationWrite, []any{title}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.DeleteNote(ctx, title)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) UpdateNoteByID(ctx context.Context, noteID, content string) error {
+	_, err := invoke(ctx, s, "UpdateNoteByID", OperationWrite, []any{noteID, content}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.UpdateNoteByID(ctx, noteID, content)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) DeleteNoteByID(ctx context.Context, noteID string) error {
+	_, err := invoke(ctx, s, "DeleteNoteByID", OperationWrite, []any{noteID}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.DeleteNoteByID(ctx, noteID)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) PinNote(ctx context.Context, title string) error {
+	_, err := invoke(ctx, s, "PinNote", OperationWrite, []any{title}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.PinNote(ctx, title)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) AddNoteTags(ctx context.Context, title string, tags []string) error {
+	_, err := invoke(ctx, s, "AddNoteTags", OperationWrite, []any{title, tags}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.AddNoteTags(ctx, title, tags)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) ImportHTMLFile(ctx context.Context, filePath, sourceURL, folder string) (*ImportResult, error) {
+	return invoke(ctx, s, "ImportHTMLFile", OperationWrite, []any{filePath, sourceURL, folder}, func(ctx context.Context) (*ImportResult, error) {
+		return s.base.ImportHTMLFile(ctx, filePath, sourceURL, folder)
+	})
+}
+
+func (s *decoratedNotesService) ClipURL(ctx context.Context, pageURL, folder string) (*Note, error) {
+	return invoke(ctx, s, "ClipURL", OperationWrite, []any{pageURL, folder}, func(ctx context.Context) (*Note, error) {
+		return s.base.ClipURL(ctx, pageURL, folder)
+	})
+}
+
+func (s *decoratedNotesService) GenerateWeeklyDigest(ctx context.Context, weekStart time.Time, digestFolder string) (*Note, error) {
+	return invoke(ctx, s, "GenerateWeeklyDigest", OperationWrite, []any{weekStart, digestFolder}, func(ctx context.Context) (*Note, error) {
+		return s.base.GenerateWeeklyDigest(ctx, weekStart, digestFolder)
+	})
+}
+
+func (s *decoratedNotesService) ListFolders(ctx context.Context) ([]string, error) {
+	return invoke(ctx, s, "ListFolders", OperationRead, nil, func(ctx context.Context) ([]string, error) {
+		return s.base.ListFolders(ctx)
+	})
+}
+
+func (s *decoratedNotesService) CreateFolder(ctx context.Context, name string, parentFolder string) error {
+	_, err := invoke(ctx, s, "CreateFolder", OperationWrite, []any{name, parentFolder}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.CreateFolder(ctx, name, parentFolder)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) MoveNote(ctx context.Context, noteTitle string, targetFolder string) error {
+	_, err := invoke(ctx, s, "MoveNote", OperationWrite, []any{noteTitle, targetFolder}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.MoveNote(ctx, noteTitle, targetFolder)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) MoveNoteByID(ctx context.Context, noteID string, targetFolder string) error {
+	_, err := invoke(ctx, s, "MoveNoteByID", OperationWrite, []any{noteID, targetFolder}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.MoveNoteByID(ctx, noteID, targetFolder)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) GetFolderHierarchy(ctx context.Context) (*FolderNode, error) {
+	return invoke(ctx, s, "GetFolderHierarchy", OperationRead, nil, func(ctx context.Context) (*FolderNode, error) {
+		return s.base.GetFolderHierarchy(ctx)
+	})
+}
+
+func (s *decoratedNotesService) GetNoteAttachments(ctx context.Context, noteTitle string) ([]Attachment, error) {
+	return invoke(ctx, s, "GetNoteAttachments", OperationRead, []any{noteTitle}, func(ctx context.Context) ([]Attachment, error) {
+		return s.base.GetNoteAttachments(ctx, noteTitle)
+	})
+}
+
+func (s *decoratedNotesService) GetAttachmentContent(ctx context.Context, filePath string, maxSize int64) ([]byte, error) {
+	return invoke(ctx, s, "GetAttachmentContent", OperationRead, []any{filePath, maxSize}, func(ctx context.Context) ([]byte, error) {
+		return s.base.GetAttachmentContent(ctx, filePath, maxSize)
+	})
+}
+
+func (s *decoratedNotesService) ReadAttachmentChunk(ctx context.Context, filePath string, offset, length int64) (*AttachmentChunk, error) {
+	return invoke(ctx, s, "ReadAttachmentChunk", OperationRead, []any{filePath, offset, length}, func(ctx context.Context) (*AttachmentChunk, error) {
+		return s.base.ReadAttachmentChunk(ctx, filePath, offset, length)
+	})
+}
+
+func (s *decoratedNotesService) CopyAttachment(ctx context.Context, filePath string, destDir string) (*AttachmentCopy, error) {
+	// Copying writes a file to disk, so read-only configurations refuse it
+	return invoke(ctx, s, "CopyAttachment", OperationWrite, []any{filePath, destDir}, func(ctx context.Context) (*AttachmentCopy, error) {
+		return s.base.CopyAttachment(ctx, filePath, destDir)
+	})
+}
+
+func (s *decoratedNotesService) GetAttachmentThumbnail(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*Thumbnail, error) {
+	return invoke(ctx, s, "GetAttachmentThumbnail", OperationRead, []any{noteTitle, attachmentName, maxPx}, func(ctx context.Context) (*Thumbnail, error) {
+		return s.base.GetAttachmentThumbnail(ctx, noteTitle, attachmentName, maxPx)
+	})
+}
+
+func (s *decoratedNotesService) TranscribeAttachment(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*Transcript, error) {
+	return invoke(ctx, s, "TranscribeAttachment", OperationWrite, []any{noteTitle, attachmentName, appendToNote}, func(ctx context.Context) (*Transcript, error) {
+		return s.base.TranscribeAttachment(ctx, noteTitle, attachmentName, appendToNote)
+	})
+}
+
+func (s *decoratedNotesService) ExportNoteMarkdown(ctx context.Context, noteTitle string) (string, error) {
+	return invoke(ctx, s, "ExportNoteMarkdown", OperationRead, []any{noteTitle}, func(ctx context.Context) (string, error) {
+		return s.base.ExportNoteMarkdown(ctx, noteTitle)
+	})
+}
+
+func (s *decoratedNotesService) ExportNoteMarkdownWithOptions(ctx context.Context, noteTitle string, opts MarkdownExportOptions) (string, error) {
+	return invoke(ctx, s, "ExportNoteMarkdownWithOptions", OperationRead, []any{noteTitle, opts}, func(ctx context.Context) (string, error) {
+		return s.base.ExportNoteMarkdownWithOptions(ctx, noteTitle, opts)
+	})
+}
+
+func (s *decoratedNotesService) ExportNoteText(ctx context.Context, noteTitle string) (string, error) {
+	return invoke(ctx, s, "ExportNoteText", OperationRead, []any{noteTitle}, func(ctx context.Context) (string, error) {
+		return s.base.ExportNoteText(ctx, noteTitle)
+	})
+}
+
+func (s *decoratedNotesService) ExportNoteObsidian(ctx context.Context, noteTitle string, assetsDir string) (string, error) {
+	return invoke(ctx, s, "ExportNoteObsidian", OperationRead, []any{noteTitle, assetsDir}, func(ctx context.Context) (string, error) {
+		return s.base.ExportNoteObsidian(ctx, noteTitle, assetsDir)
+	})
+}
+
+func (s *decoratedNotesService) OpenNote(ctx context.Context, title string) error {
+	_, err := invoke(ctx, s, "OpenNote", OperationRead, []any{title}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.base.OpenNote(ctx, title)
+	})
+	return err
+}
+
+func (s *decoratedNotesService) CreateReminder(ctx context.Context, noteTitle, text, list string, dueDate *time.Time) (*Reminder, error) {
+	return invoke(ctx, s, "CreateReminder", OperationRead, []any{noteTitle, text, list, dueDate}, func(ctx context.Context) (*Reminder, error) {
+		return s.base.CreateReminder(ctx, noteTitle, text, list, dueDate)
+	})
+}
+
+func (s *decoratedNotesService) PushActionItemsToReminders(ctx context.Context, noteTitle, list string) ([]Reminder, error) {
+	return invoke(ctx, s, "PushActionItemsToReminders", OperationRead, []any{noteTitle, list}, func(ctx context.Context) ([]Reminder, error) {
+		return s.base.PushActionItemsToReminders(ctx, noteTitle, list)
+	})
+}
+
+func (s *decoratedNotesService) GetTodaysEvents(ctx context.Context, query string) ([]CalendarEvent, error) {
+	return invoke(ctx, s, "GetTodaysEvents", OperationRead, []any{query}, func(ctx context.Context) ([]CalendarEvent, error) {
+		return s.base.GetTodaysEvents(ctx, query)
+	})
+}
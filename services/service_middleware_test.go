@@ -0,0 +1,191 @@
+// ABOUTME: Unit tests for the NotesService middleware chain
+// ABOUTME: Tests decorator order, retries of transient reads, cache invalidation, read-only policy, and metrics
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flakyNotesService fails the first reads of each note body with a script timeout
+type flakyNotesService struct {
+	NotesService
+	failures int
+	reads    int
+}
+
+func (f *flakyNotesService) GetNoteContent(ctx context.Context, title string) (string, error) {
+	f.reads++
+	if f.reads <= f.failures {
+		return "", ErrScriptTimeout
+	}
+	return f.NotesService.GetNoteContent(ctx, title)
+}
+
+func (f *flakyNotesService) DeleteNote(ctx context.Context, title string) error {
+	return ErrScriptTimeout
+}
+
+// recordingMiddleware appends its label to calls before and after the rest of the chain
+func recordingMiddleware(label string, calls *[]string) ServiceMiddleware {
+	return func(next ServiceHandler) ServiceHandler {
+		return func(ctx context.Context, call *ServiceCall) (any, error) {
+			*calls = append(*calls, label+">"+call.Operation)
+			result, err := next(ctx, call)
+			*calls = append(*calls, label+"<")
+			return result, err
+		}
+	}
+}
+
+// newMiddlewareTestService returns an in-memory service holding one note
+func newMiddlewareTestService(t *testing.T) *MemoryNotesService {
+	t.Helper()
+	service := NewMemoryNotesService()
+	if _, err := service.CreateNote(context.Background(), "Plan", "<div>Ship it</div>", nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	return service
+}
+
+func TestDecorateNotesServiceOrder(t *testing.T) {
+	base := newMiddlewareTestService(t)
+	if DecorateNotesService(base) != NotesService(base) {
+		t.Error("expected no middleware to leave the service undecorated")
+	}
+
+	var calls []string
+	service := DecorateNotesService(base, recordingMiddleware("outer", &calls), recordingMiddleware("inner", &calls))
+	content, err := service.GetNoteContent(context.Background(), "Plan")
+	if err != nil || content != "<div>Ship it</div>" {
+		t.Fatalf("GetNoteContent() = %q, %v", content, err)
+	}
+	if got := strings.Join(calls, " "); got != "outer>GetNoteContent inner>GetNoteContent inner< outer<" {
+		t.Errorf("unexpected call order %s", got)
+	}
+
+	changed, hash, err := service.HasNoteChanged(context.Background(), "Plan", "stale")
+	if err != nil || !changed || hash == "" {
+		t.Errorf("expected multi-value results passed through, got %v, %q, %v", changed, hash, err)
+	}
+
+	if InterfacesOf(service) != AllBackendInterfaces || UndecoratedNotesService(service) != NotesService(base) {
+		t.Error("expected the decorated service to report and unwrap to its base")
+	}
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	ctx := context.Background()
+	flaky := &flakyNotesService{NotesService: newMiddlewareTestService(t), failures: 2}
+	service := DecorateNotesService(flaky, RetryMiddleware(2, time.Millisecond))
+
+	if content, err := service.GetNoteContent(ctx, "Plan"); err != nil || content != "<div>Ship it</div>" {
+		t.Errorf("expected the third read to succeed, got %q, %v", content, err)
+	}
+	if flaky.reads != 3 {
+		t.Errorf("expected 3 reads, got %d", flaky.reads)
+	}
+
+	flaky.reads, flaky.failures = 0, 5
+	if _, err := service.GetNoteContent(ctx, "Plan"); !errors.Is(err, ErrScriptTimeout) || flaky.reads != 3 {
+		t.Errorf("expected retries to stop after 2, got %d reads, %v", flaky.reads, err)
+	}
+
+	if err := service.DeleteNote(ctx, "Plan"); !errors.Is(err, ErrScriptTimeout) {
+		t.Errorf("expected the write's error, got %v", err)
+	}
+}
+
+func TestCacheMiddleware(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var calls []string
+	base := newMiddlewareTestService(t)
+	service := DecorateNotesService(base, CacheMiddleware(time.Minute, func() time.Time { return now }, nil),
+		recordingMiddleware("backend", &calls))
+
+	read := func() string {
+		t.Helper()
+		content, err := service.GetNoteContent(ctx, "Plan")
+		if err != nil {
+			t.Fatalf("GetNoteContent failed: %v", err)
+		}
+		return content
+	}
+	backendReads := func() int {
+		return strings.Count(strings.Join(calls, " "), "backend>GetNoteContent")
+	}
+
+	read()
+	read()
+	if backendReads() != 1 {
+		t.Errorf("expected the second read cached, got %d backend reads", backendReads())
+	}
+
+	if err := service.UpdateNote(ctx, "Plan", "<div>Ship it Friday</div>"); err != nil {
+		t.Fatalf("UpdateNote failed: %v", err)
+	}
+	if content := read(); content != "<div>Ship it Friday</div>" || backendReads() != 2 {
+		t.Errorf("expected a write to clear the cache, got %q after %d reads", content, backendReads())
+	}
+
+	now = now.Add(2 * time.Minute)
+	read()
+	if backendReads() != 3 {
+		t.Errorf("expected an expired entry read again, got %d backend reads", backendReads())
+	}
+
+	folders, err := service.ListFolders(ctx)
+	if err != nil {
+		t.Fatalf("ListFolders failed: %v", err)
+	}
+	folders[0] = "changed"
+	if again, _ := service.ListFolders(ctx); len(again) == 0 || again[0] == "changed" {
+		t.Errorf("expected cached folder lists protected from callers, got %v", again)
+	}
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	ctx := context.Background()
+	service := DecorateNotesService(newMiddlewareTestService(t), ReadOnlyMiddleware())
+
+	if _, err := service.GetNoteContent(ctx, "Plan"); err != nil {
+		t.Errorf("expected reads allowed, got %v", err)
+	}
+	if err := service.DeleteNote(ctx, "Plan"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly deleting, got %v", err)
+	}
+	if err := service.MoveNote(ctx, "Plan", "Archive"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly moving, got %v", err)
+	}
+	if _, err := service.GetNoteContent(ctx, "Plan"); err != nil {
+		t.Errorf("expected the note to survive the refused delete, got %v", err)
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	ctx := context.Background()
+	metrics := NewServiceMetrics()
+	flaky := &flakyNotesService{NotesService: newMiddlewareTestService(t), failures: 1}
+	service := DecorateNotesService(flaky, MetricsMiddleware(metrics), RetryMiddleware(1, time.Millisecond))
+
+	if _, err := service.GetNoteContent(ctx, "Plan"); err != nil {
+		t.Fatalf("GetNoteContent failed: %v", err)
+	}
+	if _, err := service.GetNoteContent(ctx, "Missing"); err == nil {
+		t.Fatal("expected an error for a missing note")
+	}
+	_ = service.DeleteNote(ctx, "Plan")
+
+	stats := metrics.Snapshot()
+	if len(stats) != 2 || stats[0].Operation != "DeleteNote" || stats[1].Operation != "GetNoteContent" {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats[1].Calls != 2 || stats[1].Errors != 1 || stats[0].Errors != 1 {
+		t.Errorf("expected retries counted inside one call, got %+v", stats)
+	}
+}
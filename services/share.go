@@ -0,0 +1,169 @@
+// ABOUTME: Sharing a note as a markdown message to Messages (via AppleScript) or Slack (via an incoming webhook)
+// ABOUTME: Long notes are cut at a paragraph or line boundary with a marker saying how much was left out
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Share targets
+const (
+	ShareTargetMessages = "messages"
+	ShareTargetSlack    = "slack"
+)
+
+// DefaultShareMaxChars is the longest message ShareNote sends when no limit is given
+// Slack truncates message text past 40,000 characters but recommends staying under 4,000.
+const DefaultShareMaxChars = 4000
+
+// shareTruncationMarker ends a truncated message with the number of characters left out
+const shareTruncationMarker = "\n\n… (%d more characters in the note)"
+
+// minShareMaxChars is the shortest limit that leaves room for some text and the truncation marker
+const minShareMaxChars = 100
+
+// ShareTarget sends a text message somewhere
+type ShareTarget interface {
+	// Name identifies the target in reports
+	Name() string
+
+	// Send delivers the message
+	Send(ctx context.Context, message string) error
+}
+
+// SharedNote reports a shared note: where it went, how long the message was, and whether it was cut
+type SharedNote struct {
+	Title      string `json:"title"`
+	Target     string `json:"target"`
+	Characters int    `json:"characters"`
+	Truncated  bool   `json:"truncated"`
+}
+
+// TruncateForShare shortens text to at most maxChars characters, marking how many were left out
+// The cut falls on the last paragraph break, else line break, else space, in the second half of
+// the kept text, so messages don't end mid-word where that can be helped.
+func TruncateForShare(text string, maxChars int) (string, bool) {
+	total := utf8.RuneCountInString(text)
+	if total <= maxChars {
+		return text, false
+	}
+
+	// Reserve room for the marker, sized for the largest count it could report
+	marker := fmt.Sprintf(shareTruncationMarker, total)
+	runes := []rune(text)
+	kept := string(runes[:max(maxChars-utf8.RuneCountInString(marker), 0)])
+	for _, boundary := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(kept, boundary); i > len(kept)/2 {
+			kept = kept[:i]
+			break
+		}
+	}
+	kept = strings.TrimRight(kept, " \t\n")
+	return kept + fmt.Sprintf(shareTruncationMarker, total-utf8.RuneCountInString(kept)), true
+}
+
+// ShareNote sends a note's markdown to target, truncated to maxChars (default: DefaultShareMaxChars)
+func ShareNote(ctx context.Context, notes NotesService, target ShareTarget, title string, maxChars int) (*SharedNote, error) {
+	if maxChars == 0 {
+		maxChars = DefaultShareMaxChars
+	}
+	if maxChars < minShareMaxChars {
+		return nil, fmt.Errorf("%w: max_chars must be at least %d", ErrInvalidInput, minShareMaxChars)
+	}
+
+	markdown, err := notes.ExportNoteMarkdown(ctx, title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to share note: %w", err)
+	}
+	message, truncated := TruncateForShare(strings.TrimSpace(markdown), maxChars)
+	if err := target.Send(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to share note to %s: %w", target.Name(), err)
+	}
+	return &SharedNote{Title: title, Target: target.Name(), Characters: utf8.RuneCountInString(message), Truncated: truncated}, nil
+}
+
+// MessagesTarget sends messages to one recipient through Messages.app
+type MessagesTarget struct {
+	executor  ScriptExecutor
+	recipient string
+}
+
+// NewMessagesTarget creates a MessagesTarget for a phone number, email address, or contact handle
+func NewMessagesTarget(executor ScriptExecutor, recipient string) (*MessagesTarget, error) {
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" {
+		return nil, fmt.Errorf("%w: a Messages recipient (phone number or email) is required", ErrInvalidInput)
+	}
+	return &MessagesTarget{executor: executor, recipient: recipient}, nil
+}
+
+// Name returns "messages"
+func (m *MessagesTarget) Name() string {
+	return ShareTargetMessages
+}
+
+// Send sends the message over iMessage to the recipient
+func (m *MessagesTarget) Send(ctx context.Context, message string) error {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	script := fmt.Sprintf(`
+		tell application "Messages"
+			set targetService to 1st account whose service type = iMessage
+			set targetBuddy to participant "%s" of targetService
+			send "%s" to targetBuddy
+		end tell
+	`, escape.Replace(m.recipient), escape.Replace(message))
+
+	_, stderr, err := m.executor.Execute(ctx, script)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", DetectError(ctx, stderr, err))
+	}
+	return nil
+}
+
+// SlackShareConfig configures sharing to Slack
+type SlackShareConfig struct {
+	WebhookURL string `json:"webhook_url"` // incoming webhook URL, which decides the channel
+}
+
+// SlackTarget posts messages to a Slack channel through an incoming webhook
+type SlackTarget struct {
+	config SlackShareConfig
+	client *http.Client
+}
+
+// NewSlackTarget creates a SlackTarget with a 10 second HTTP timeout
+func NewSlackTarget(config SlackShareConfig) (*SlackTarget, error) {
+	if config.WebhookURL == "" {
+		return nil, fmt.Errorf("%w: a Slack webhook_url is required", ErrInvalidInput)
+	}
+	return &SlackTarget{config: config, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// Name returns "slack"
+func (s *SlackTarget) Name() string {
+	return ShareTargetSlack
+}
+
+// Send posts the message as the webhook's text
+func (s *SlackTarget) Send(ctx context.Context, message string) error {
+	body, err := json.Marshal(map[string]string{"text": message})
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doPushRequest(s.client, req, "Slack", nil)
+}
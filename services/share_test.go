@@ -0,0 +1,140 @@
+// ABOUTME: Unit tests for sharing notes to Messages and Slack
+// ABOUTME: Tests truncation, the Messages script, the Slack webhook payload, and ShareNote with a recording target
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// recordingShareTarget keeps the messages sent to it
+type recordingShareTarget struct {
+	messages []string
+}
+
+func (r *recordingShareTarget) Name() string { return "recording" }
+
+func (r *recordingShareTarget) Send(ctx context.Context, message string) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+// scriptRecordingExecutor keeps the last script it was asked to run
+type scriptRecordingExecutor struct {
+	script string
+}
+
+func (e *scriptRecordingExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	e.script = script
+	return "", "", nil
+}
+
+func TestTruncateForShare(t *testing.T) {
+	if text, truncated := TruncateForShare("short", 100); text != "short" || truncated {
+		t.Errorf("short text should be unchanged, got %q, %v", text, truncated)
+	}
+
+	text := strings.Repeat("first paragraph words ", 5) + "\n\n" + strings.Repeat("second paragraph ", 10)
+	got, truncated := TruncateForShare(text, 150)
+	if !truncated || utf8.RuneCountInString(got) > 150 {
+		t.Fatalf("expected at most 150 characters, got %d: %q", utf8.RuneCountInString(got), got)
+	}
+	if !strings.HasPrefix(got, strings.TrimSpace(strings.Repeat("first paragraph words ", 5))+"\n\n…") {
+		t.Errorf("expected the cut at the paragraph break, got %q", got)
+	}
+	if !strings.HasSuffix(got, "(173 more characters in the note)") {
+		t.Errorf("expected the count of characters left out, got %q", got)
+	}
+}
+
+func TestShareNote(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	if _, err := service.CreateNote(ctx, "Plan", "Plan\n"+strings.Repeat("line of the plan\n", 20), nil); err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+
+	target := &recordingShareTarget{}
+	shared, err := ShareNote(ctx, service, target, "Plan", 0)
+	if err != nil {
+		t.Fatalf("ShareNote failed: %v", err)
+	}
+	if shared.Truncated || len(target.messages) != 1 || !strings.HasPrefix(target.messages[0], "Plan\n") {
+		t.Errorf("expected the whole note sent, got %+v and %q", shared, target.messages)
+	}
+
+	shared, err = ShareNote(ctx, service, target, "Plan", 120)
+	if err != nil {
+		t.Fatalf("ShareNote failed: %v", err)
+	}
+	if !shared.Truncated || shared.Characters > 120 {
+		t.Errorf("expected a truncated message, got %+v", shared)
+	}
+
+	if _, err := ShareNote(ctx, service, target, "Plan", 10); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a tiny limit, got %v", err)
+	}
+	if _, err := ShareNote(ctx, service, target, "Missing", 0); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("expected ErrNoteNotFound, got %v", err)
+	}
+}
+
+func TestMessagesTarget(t *testing.T) {
+	if _, err := NewMessagesTarget(&scriptRecordingExecutor{}, " "); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without a recipient, got %v", err)
+	}
+
+	executor := &scriptRecordingExecutor{}
+	target, err := NewMessagesTarget(executor, "+15555550100")
+	if err != nil {
+		t.Fatalf("NewMessagesTarget failed: %v", err)
+	}
+	if err := target.Send(context.Background(), `say "hi"`); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if !strings.Contains(executor.script, `participant "+15555550100"`) || !strings.Contains(executor.script, `send "say \"hi\""`) {
+		t.Errorf("unexpected script %s", executor.script)
+	}
+}
+
+func TestSlackTarget(t *testing.T) {
+	if _, err := NewSlackTarget(SlackShareConfig{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without a webhook URL, got %v", err)
+	}
+
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		if r.URL.Path == "/bad" {
+			http.Error(w, "invalid_payload", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	target, err := NewSlackTarget(SlackShareConfig{WebhookURL: server.URL + "/hook"})
+	if err != nil {
+		t.Fatalf("NewSlackTarget failed: %v", err)
+	}
+	if err := target.Send(context.Background(), "# Plan"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if payload["text"] != "# Plan" {
+		t.Errorf("unexpected payload %v", payload)
+	}
+
+	target, _ = NewSlackTarget(SlackShareConfig{WebhookURL: server.URL + "/bad"})
+	if err := target.Send(context.Background(), "# Plan"); err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("expected the status in the error, got %v", err)
+	}
+}
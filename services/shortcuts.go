@@ -0,0 +1,292 @@
+// ABOUTME: macOS Shortcuts execution path for operations AppleScript handles poorly
+// ABOUTME: Runs bundled Shortcuts via the shortcuts CLI with automatic fallback to AppleScript
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Operations that can be routed through Shortcuts instead of AppleScript
+const (
+	ShortcutOperationPin  = "pin"
+	ShortcutOperationTags = "tags"
+)
+
+// ShortcutDefinition describes a Shortcut that notes-mcp expects to find in the Shortcuts app
+type ShortcutDefinition struct {
+	Operation   string   `json:"operation"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Input       string   `json:"input"`
+	Steps       []string `json:"steps"`
+}
+
+// BundledShortcuts lists the Shortcuts notes-mcp can drive, with the actions needed to build each one.
+// Shortcuts exports are signed per user, so the definitions are shipped as steps rather than .shortcut files.
+var BundledShortcuts = []ShortcutDefinition{
+	{
+		Operation:   ShortcutOperationPin,
+		Name:        "notes-mcp Pin Note",
+		Description: "Pins a note to the top of its folder",
+		Input:       "Text: the note title",
+		Steps: []string{
+			"Receive Text input from Quick Actions (if no input: Stop and respond)",
+			"Find Notes where Name is Shortcut Input (limit 1)",
+			"Pin Notes: Notes",
+		},
+	},
+	{
+		Operation:   ShortcutOperationTags,
+		Name:        "notes-mcp Tag Note",
+		Description: "Adds native Apple Notes tags to a note",
+		Input:       `Text: JSON {"title": "...", "tags": ["tag", ...]}`,
+		Steps: []string{
+			"Receive Text input from Quick Actions (if no input: Stop and respond)",
+			"Get Dictionary from Shortcut Input",
+			"Get Value for title in Dictionary",
+			"Find Notes where Name is Dictionary Value (limit 1)",
+			"Get Value for tags in Dictionary",
+			"Repeat with each item in Dictionary Value: Add Tag (Repeat Item) to Notes",
+		},
+	},
+}
+
+// ShortcutRunner defines the interface for running a named Shortcut with text input
+type ShortcutRunner interface {
+	Run(ctx context.Context, name string, input string) (stdout string, stderr string, err error)
+}
+
+// ShortcutsCLIRunner implements ShortcutRunner using the macOS shortcuts command
+type ShortcutsCLIRunner struct {
+	timeout time.Duration
+}
+
+// NewShortcutsCLIRunner creates a ShortcutsCLIRunner with the specified timeout.
+// If timeout is 0 or negative, defaults to 10 seconds.
+func NewShortcutsCLIRunner(timeout time.Duration) *ShortcutsCLIRunner {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &ShortcutsCLIRunner{
+		timeout: timeout,
+	}
+}
+
+// Run executes `shortcuts run` with input passed through a temporary file and returns the Shortcut's output
+func (r *ShortcutsCLIRunner) Run(ctx context.Context, name string, input string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "notes-mcp-shortcut-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck // temp dir cleanup failure is non-critical
+
+	inputPath := filepath.Join(dir, "input.txt")
+	outputPath := filepath.Join(dir, "output.txt")
+	if err := os.WriteFile(inputPath, []byte(input), 0o600); err != nil {
+		return "", "", err
+	}
+
+	cmd := exec.CommandContext(ctx, "shortcuts", "run", name, "--input-path", inputPath, "--output-path", outputPath)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), stderr.String(), err
+	}
+
+	// Shortcuts without a final output action leave no output file
+	if output, err := os.ReadFile(outputPath); err == nil {
+		return string(output), stderr.String(), nil
+	}
+	return stdout.String(), stderr.String(), nil
+}
+
+// ListInstalled returns the names of the Shortcuts installed for the current user
+func (r *ShortcutsCLIRunner) ListInstalled(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "shortcuts", "list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shortcuts: %w", err)
+	}
+
+	names := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// UseShortcuts routes the given operations through Shortcuts using runner.
+// Each routed operation falls back to AppleScript when its Shortcut is missing or fails.
+func (s *AppleNotesService) UseShortcuts(runner ShortcutRunner, operations []string) {
+	s.shortcuts = runner
+	s.shortcutOperations = make(map[string]bool, len(operations))
+	for _, operation := range operations {
+		s.shortcutOperations[operation] = true
+	}
+}
+
+// ParseShortcutOperations parses a comma-separated operation list; "all" selects every bundled operation
+func ParseShortcutOperations(value string) ([]string, error) {
+	operations := []string{}
+	for _, operation := range strings.Split(value, ",") {
+		operation = strings.ToLower(strings.TrimSpace(operation))
+		if operation == "" {
+			continue
+		}
+		if operation == "all" {
+			operations = operations[:0]
+			for _, def := range BundledShortcuts {
+				operations = append(operations, def.Operation)
+			}
+			return operations, nil
+		}
+		if _, ok := bundledShortcut(operation); !ok {
+			return nil, fmt.Errorf("%w: unknown Shortcuts operation %q", ErrInvalidInput, operation)
+		}
+		operations = append(operations, operation)
+	}
+	return operations, nil
+}
+
+// bundledShortcut returns the bundled definition for an operation
+func bundledShortcut(operation string) (ShortcutDefinition, bool) {
+	for _, def := range BundledShortcuts {
+		if def.Operation == operation {
+			return def, true
+		}
+	}
+	return ShortcutDefinition{}, false
+}
+
+// runShortcut runs the Shortcut for operation if that operation is routed through Shortcuts.
+// It reports whether the Shortcut ran successfully; callers fall back to AppleScript otherwise.
+func (s *AppleNotesService) runShortcut(ctx context.Context, operation, input string) bool {
+	if s.shortcuts == nil || !s.shortcutOperations[operation] {
+		return false
+	}
+
+	def, ok := bundledShortcut(operation)
+	if !ok {
+		return false
+	}
+
+	_, _, err := s.shortcuts.Run(ctx, def.Name, input)
+	if err != nil || ctx.Err() != nil {
+		tracef(ctx, "shortcut %q failed (%v); falling back to AppleScript", def.Name, err)
+		return false
+	}
+	tracef(ctx, "ran shortcut %q", def.Name)
+	return true
+}
+
+// PinNote pins a note, using the "notes-mcp Pin Note" Shortcut when enabled.
+// The AppleScript fallback clicks File > Pin Note, which needs Accessibility permission.
+func (s *AppleNotesService) PinNote(ctx context.Context, title string) error {
+	// Resolve the note first; Shortcuts silently does nothing for missing notes
+	if _, err := s.GetNoteMetadata(ctx, title); err != nil {
+		return fmt.Errorf("failed to pin note: %w", err)
+	}
+
+	if s.runShortcut(ctx, ShortcutOperationPin, title) {
+		return nil
+	}
+
+	safeTitle := s.escapeForAppleScript(title)
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set theNote to note "%s"
+			end tell
+			show theNote
+			activate
+		end tell
+		delay 0.5
+		tell application "System Events"
+			tell process "Notes"
+				if exists menu item "Pin Note" of menu "File" of menu bar 1 then
+					click menu item "Pin Note" of menu "File" of menu bar 1
+				end if
+			end tell
+		end tell
+	`, s.iCloudAccount, safeTitle)
+
+	_, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return fmt.Errorf("failed to pin note: %w", detectedErr)
+	}
+
+	return nil
+}
+
+// AddNoteTags adds tags to a note, using the "notes-mcp Tag Note" Shortcut when enabled.
+// The AppleScript fallback appends the tags as #hashtag text, which Notes does not convert into native tags.
+func (s *AppleNotesService) AddNoteTags(ctx context.Context, title string, tags []string) error {
+	normalized := normalizeTags(tags)
+	if len(normalized) == 0 {
+		return fmt.Errorf("%w: at least one tag is required", ErrInvalidInput)
+	}
+
+	if _, err := s.GetNoteMetadata(ctx, title); err != nil {
+		return fmt.Errorf("failed to tag note: %w", err)
+	}
+
+	input, err := json.Marshal(map[string]any{"title": title, "tags": normalized})
+	if err != nil {
+		return fmt.Errorf("failed to tag note: %w", err)
+	}
+	if s.runShortcut(ctx, ShortcutOperationTags, string(input)) {
+		return nil
+	}
+
+	hashtags := make([]string, 0, len(normalized))
+	for _, tag := range normalized {
+		hashtags = append(hashtags, "#"+tag)
+	}
+
+	line := "<div>" + html.EscapeString(strings.Join(hashtags, " ")) + "</div>"
+	err = s.editNoteBody(ctx, title, func(body string) (string, error) {
+		return AppendHTML(body, line)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag note: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeTags strips leading '#', replaces inner whitespace with '-', and drops empty or duplicate tags
+func normalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.TrimLeft(strings.TrimSpace(tag), "#")), "-")
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
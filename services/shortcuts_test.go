@@ -0,0 +1,166 @@
+// ABOUTME: Unit tests for the macOS Shortcuts execution path
+// ABOUTME: Tests per-operation routing, AppleScript fallback, and tag normalization
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// mockShortcutRunner records Shortcut invocations and returns a fixed error
+type mockShortcutRunner struct {
+	calls  []string
+	inputs []string
+	err    error
+}
+
+func (m *mockShortcutRunner) Run(ctx context.Context, name string, input string) (string, string, error) {
+	m.calls = append(m.calls, name)
+	m.inputs = append(m.inputs, input)
+	return "", "", m.err
+}
+
+// newShortcutTestExecutor returns an executor that answers the metadata lookup and then n more scripts
+func newShortcutTestExecutor(scripts int) *SequentialMockExecutor {
+	executor := &SequentialMockExecutor{}
+	executor.responses = append(executor.responses, struct {
+		stdout string
+		stderr string
+		err    error
+	}{stdout: reminderTestMetadata})
+	for i := 0; i < scripts; i++ {
+		executor.responses = append(executor.responses, struct {
+			stdout string
+			stderr string
+			err    error
+		}{})
+	}
+	return executor
+}
+
+func TestPinNoteUsesShortcutWhenEnabled(t *testing.T) {
+	executor := newShortcutTestExecutor(0)
+	runner := &mockShortcutRunner{}
+
+	service := NewAppleNotesService(executor)
+	service.UseShortcuts(runner, []string{ShortcutOperationPin})
+
+	if err := service.PinNote(context.Background(), "Planning"); err != nil {
+		t.Fatalf("PinNote failed: %v", err)
+	}
+	if !reflect.DeepEqual(runner.calls, []string{"notes-mcp Pin Note"}) {
+		t.Errorf("unexpected shortcut calls: %v", runner.calls)
+	}
+	if executor.callIndex != 1 {
+		t.Errorf("expected only the metadata script to run, got %d scripts", executor.callIndex)
+	}
+}
+
+func TestPinNoteFallsBackToAppleScript(t *testing.T) {
+	executor := newShortcutTestExecutor(1)
+	runner := &mockShortcutRunner{err: errors.New("shortcut not found")}
+
+	service := NewAppleNotesService(executor)
+	service.UseShortcuts(runner, []string{ShortcutOperationPin})
+
+	if err := service.PinNote(context.Background(), "Planning"); err != nil {
+		t.Fatalf("PinNote failed: %v", err)
+	}
+	if len(runner.calls) != 1 {
+		t.Errorf("expected the shortcut to be tried once, got %v", runner.calls)
+	}
+	if executor.callIndex != 2 {
+		t.Errorf("expected AppleScript fallback to run, got %d scripts", executor.callIndex)
+	}
+}
+
+func TestAddNoteTagsSkipsShortcutWhenNotRouted(t *testing.T) {
+	executor := newShortcutTestExecutor(2) // read and rewrite the body
+	runner := &mockShortcutRunner{}
+
+	service := NewAppleNotesService(executor)
+	service.UseShortcuts(runner, []string{ShortcutOperationPin})
+
+	if err := service.AddNoteTags(context.Background(), "Planning", []string{"#work"}); err != nil {
+		t.Fatalf("AddNoteTags failed: %v", err)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("expected no shortcut calls, got %v", runner.calls)
+	}
+}
+
+func TestAddNoteTagsShortcutInput(t *testing.T) {
+	executor := newShortcutTestExecutor(0)
+	runner := &mockShortcutRunner{}
+
+	service := NewAppleNotesService(executor)
+	service.UseShortcuts(runner, []string{ShortcutOperationTags})
+
+	if err := service.AddNoteTags(context.Background(), "Planning", []string{"#work", "q3 goals"}); err != nil {
+		t.Fatalf("AddNoteTags failed: %v", err)
+	}
+
+	var input struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(runner.inputs[0]), &input); err != nil {
+		t.Fatalf("shortcut input is not JSON: %v", err)
+	}
+	if input.Title != "Planning" || !reflect.DeepEqual(input.Tags, []string{"work", "q3-goals"}) {
+		t.Errorf("unexpected shortcut input: %+v", input)
+	}
+}
+
+func TestAddNoteTagsRequiresTags(t *testing.T) {
+	service := NewAppleNotesService(&SequentialMockExecutor{})
+
+	err := service.AddNoteTags(context.Background(), "Planning", []string{" # "})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestParseShortcutOperations(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "single", value: "pin", want: []string{"pin"}},
+		{name: "list with spaces", value: " Pin , tags ", want: []string{"pin", "tags"}},
+		{name: "all", value: "all", want: []string{"pin", "tags"}},
+		{name: "unknown", value: "pin,archive", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseShortcutOperations(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("expected ErrInvalidInput, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := normalizeTags([]string{"#Work", "work", "  ", "two  words", "##x"})
+	want := []string{"Work", "two-words", "x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,187 @@
+// ABOUTME: NoteReader for backends that can only read the whole library at once (JXA, the Notes database)
+// ABOUTME: Each call loads a fresh snapshot and answers it with the in-memory service's queries
+
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// snapshotReader serves NoteReader from a snapshot of every note, bodies included, taken by load on each call
+// Loading everything keeps results as fresh as AppleScript's at the cost of one bulk read per call.
+type snapshotReader struct {
+	load func(ctx context.Context) ([]Note, error)
+}
+
+// snapshot loads the notes into an in-memory service whose folders are the snapshot's folders
+func (r *snapshotReader) snapshot(ctx context.Context) (*MemoryNotesService, error) {
+	notes, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MemoryNotesService{now: time.Now, parents: map[string]string{}}
+	for i := range notes {
+		note := notes[i]
+		m.notes = append(m.notes, &note)
+		if note.Folder != "" && !m.hasFolderLocked(note.Folder) {
+			m.folders = append(m.folders, note.Folder)
+			m.parents[note.Folder] = ""
+		}
+	}
+	return m, nil
+}
+
+// SearchNotes searches the snapshot's titles and bodies
+func (r *snapshotReader) SearchNotes(ctx context.Context, query string) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.SearchNotes(ctx, query)
+}
+
+// SearchNotesAdvanced searches the snapshot with folder, date, and field filters
+func (r *snapshotReader) SearchNotesAdvanced(ctx context.Context, opts SearchOptions) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.SearchNotesAdvanced(ctx, opts)
+}
+
+// GetNoteContent returns the HTML body of the note with the given title
+func (r *snapshotReader) GetNoteContent(ctx context.Context, title string) (string, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+	return m.GetNoteContent(ctx, title)
+}
+
+// GetNoteMetadata returns the note with the given title, without its body
+func (r *snapshotReader) GetNoteMetadata(ctx context.Context, title string) (*Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetNoteMetadata(ctx, title)
+}
+
+// GetNoteTitleByID returns the title of the note with the given ID
+func (r *snapshotReader) GetNoteTitleByID(ctx context.Context, noteID string) (string, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+	return m.GetNoteTitleByID(ctx, noteID)
+}
+
+// GetNoteContentByID returns the HTML body of the note with the given ID
+func (r *snapshotReader) GetNoteContentByID(ctx context.Context, noteID string) (string, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+	return m.GetNoteContentByID(ctx, noteID)
+}
+
+// HasNoteChanged compares a note's current content hash with hash
+func (r *snapshotReader) HasNoteChanged(ctx context.Context, title, hash string) (bool, string, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	return m.HasNoteChanged(ctx, title, hash)
+}
+
+// GetRecentNotes returns up to limit notes, most recently modified first
+func (r *snapshotReader) GetRecentNotes(ctx context.Context, limit int) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.GetRecentNotes(ctx, limit)
+}
+
+// GetNotesInFolder returns the notes in a folder
+func (r *snapshotReader) GetNotesInFolder(ctx context.Context, folder string) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.GetNotesInFolder(ctx, folder)
+}
+
+// GetRecentNotesInFolder returns up to limit notes in a folder, most recently modified first
+func (r *snapshotReader) GetRecentNotesInFolder(ctx context.Context, folder string, limit int) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.GetRecentNotesInFolder(ctx, folder, limit)
+}
+
+// ListNotesWithMetadata lists every note, or those in a folder
+func (r *snapshotReader) ListNotesWithMetadata(ctx context.Context, folder string) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.ListNotesWithMetadata(ctx, folder)
+}
+
+// ListNotesByPrefix lists notes whose titles begin with prefix, sorted by title
+func (r *snapshotReader) ListNotesByPrefix(ctx context.Context, prefix, folder string) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.ListNotesByPrefix(ctx, prefix, folder)
+}
+
+// GetNotesModifiedBetween returns notes modified in [from, to), newest first
+func (r *snapshotReader) GetNotesModifiedBetween(ctx context.Context, from, to time.Time) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []Note{}, err
+	}
+	return m.GetNotesModifiedBetween(ctx, from, to)
+}
+
+// WithNoteMetrics fills in Metrics from each note's body
+func (r *snapshotReader) WithNoteMetrics(ctx context.Context, notes []Note) ([]Note, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return notes, err
+	}
+	return m.WithNoteMetrics(ctx, notes)
+}
+
+// ExtractActionItems parses checklist items from a note's body
+func (r *snapshotReader) ExtractActionItems(ctx context.Context, noteTitle string) ([]ActionItem, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []ActionItem{}, err
+	}
+	return m.ExtractActionItems(ctx, noteTitle)
+}
+
+// FindActionItems collects checklist items across notes matching query, optionally in one folder
+func (r *snapshotReader) FindActionItems(ctx context.Context, query, folder string) ([]ActionItem, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []ActionItem{}, err
+	}
+	return m.FindActionItems(ctx, query, folder)
+}
+
+// GetUpcomingDeadlines returns open action items due within days
+func (r *snapshotReader) GetUpcomingDeadlines(ctx context.Context, days int, folder string) ([]ActionItem, error) {
+	m, err := r.snapshot(ctx)
+	if err != nil {
+		return []ActionItem{}, err
+	}
+	return m.GetUpcomingDeadlines(ctx, days, folder)
+}
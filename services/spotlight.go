@@ -0,0 +1,147 @@
+// ABOUTME: Spotlight-backed search fast path using the mdfind command
+// ABOUTME: Finds note titles via the Spotlight index before falling back to AppleScript
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Search backends selectable through SearchOptions.Backend
+const (
+	SearchBackendAppleScript = "applescript"
+	SearchBackendSpotlight   = "spotlight"
+)
+
+// SpotlightSearcher finds note titles matching a query in the Spotlight index
+type SpotlightSearcher interface {
+	Search(ctx context.Context, query string, searchIn string) ([]string, error)
+}
+
+// MDFindSearcher implements SpotlightSearcher using the mdfind command
+type MDFindSearcher struct {
+	timeout time.Duration
+}
+
+// NewMDFindSearcher creates an MDFindSearcher with the specified timeout.
+// If timeout is 0 or negative, defaults to 10 seconds.
+func NewMDFindSearcher(timeout time.Duration) *MDFindSearcher {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &MDFindSearcher{
+		timeout: timeout,
+	}
+}
+
+// Search runs mdfind restricted to Notes items and returns the matching note titles
+func (m *MDFindSearcher) Search(ctx context.Context, query string, searchIn string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "mdfind", "-attr", "kMDItemTitle", buildSpotlightQuery(query, searchIn))
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("mdfind failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseSpotlightTitles(stdout.String()), nil
+}
+
+// buildSpotlightQuery builds a case- and diacritic-insensitive mdfind query over Notes items
+func buildSpotlightQuery(query, searchIn string) string {
+	value := escapeSpotlightValue(query)
+	titleClause := fmt.Sprintf(`kMDItemTitle == "*%s*"cd`, value)
+	bodyClause := fmt.Sprintf(`kMDItemTextContent == "*%s*"cd`, value)
+
+	var match string
+	switch searchIn {
+	case SearchInBody:
+		match = bodyClause
+	case SearchInBoth:
+		match = fmt.Sprintf("(%s || %s)", titleClause, bodyClause)
+	default:
+		match = titleClause
+	}
+
+	return fmt.Sprintf(`kMDItemContentType == "com.apple.notes*" && %s`, match)
+}
+
+// escapeSpotlightValue escapes characters that are special inside a quoted mdfind value
+func escapeSpotlightValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `*`, `\*`, `?`, `\?`)
+	return replacer.Replace(value)
+}
+
+// parseSpotlightTitles extracts titles from `mdfind -attr kMDItemTitle` output
+// Each line looks like "<path>   kMDItemTitle = <title>"; unset titles print as "(null)"
+func parseSpotlightTitles(output string) []string {
+	const marker = "kMDItemTitle = "
+
+	seen := map[string]bool{}
+	titles := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		idx := strings.LastIndex(line, marker)
+		if idx < 0 {
+			continue
+		}
+
+		title := strings.TrimSpace(line[idx+len(marker):])
+		if title == "" || title == "(null)" || seen[title] {
+			continue
+		}
+		seen[title] = true
+		titles = append(titles, title)
+	}
+
+	return titles
+}
+
+// searchSpotlight tries the Spotlight fast path for a search.
+// When Spotlight cannot serve the search (folder, date, or per-note filters, mdfind errors, or no
+// hits, which may just mean a stale index) it returns why, and the caller falls back to AppleScript.
+func (s *AppleNotesService) searchSpotlight(ctx context.Context, searchIn string, opts SearchOptions) ([]Note, string) {
+	if s.spotlight == nil {
+		return nil, "no Spotlight searcher is configured"
+	}
+	if opts.Folder != "" || opts.DateFrom != nil || opts.DateTo != nil || opts.hasNoteFilters() {
+		return nil, "Spotlight can't apply folder, date, or note filters"
+	}
+
+	titles, err := s.spotlight.Search(ctx, opts.Query, searchIn)
+	switch {
+	case err != nil:
+		return nil, err.Error()
+	case ctx.Err() != nil:
+		return nil, ctx.Err().Error()
+	case len(titles) == 0:
+		return nil, "Spotlight found nothing, which may mean a stale index"
+	}
+
+	return s.parseSearchResults(strings.Join(titles, "|||")), ""
+}
+
+// trySpotlight runs searchSpotlight, recording the query, its timing, and any fallback in explain when it isn't nil
+func (s *AppleNotesService) trySpotlight(ctx context.Context, searchIn string, opts SearchOptions, explain *SearchExplanation) ([]Note, string) {
+	start := time.Now()
+	notes, fallback := s.searchSpotlight(ctx, searchIn, opts)
+	if explain != nil {
+		explain.SpotlightQuery = buildSpotlightQuery(opts.Query, searchIn)
+		explain.Timings.SpotlightMS = durationMS(time.Since(start))
+		explain.Fallback = fallback
+		if fallback == "" {
+			explain.Strategy = SearchStrategySpotlight
+		}
+	}
+	return notes, fallback
+}
@@ -0,0 +1,136 @@
+// ABOUTME: Unit tests for the Spotlight search fast path
+// ABOUTME: Tests mdfind query building, output parsing, and fallback to AppleScript
+
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// mockSpotlightSearcher returns fixed titles and records whether it was called
+type mockSpotlightSearcher struct {
+	titles []string
+	err    error
+	called bool
+}
+
+func (m *mockSpotlightSearcher) Search(ctx context.Context, query string, searchIn string) ([]string, error) {
+	m.called = true
+	return m.titles, m.err
+}
+
+func TestBuildSpotlightQuery(t *testing.T) {
+	tests := []struct {
+		searchIn string
+		want     string
+	}{
+		{SearchInTitle, `kMDItemContentType == "com.apple.notes*" && kMDItemTitle == "*a \"b\" \*c*"cd`},
+		{SearchInBody, `kMDItemContentType == "com.apple.notes*" && kMDItemTextContent == "*a \"b\" \*c*"cd`},
+		{SearchInBoth, `kMDItemContentType == "com.apple.notes*" && (kMDItemTitle == "*a \"b\" \*c*"cd || kMDItemTextContent == "*a \"b\" \*c*"cd)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.searchIn, func(t *testing.T) {
+			if got := buildSpotlightQuery(`a "b" *c`, tt.searchIn); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpotlightTitles(t *testing.T) {
+	output := "/path/one   kMDItemTitle = Groceries\n" +
+		"/path/two   kMDItemTitle = (null)\n" +
+		"/path/three   kMDItemTitle = Groceries\n" +
+		"garbage\n" +
+		"/path/four   kMDItemTitle = Trip = Plan\n"
+
+	got := parseSpotlightTitles(output)
+	want := []string{"Groceries", "Trip = Plan"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSearchNotesAdvancedSpotlightHit(t *testing.T) {
+	executor := &SequentialMockExecutor{}
+	service := NewAppleNotesService(executor)
+	service.spotlight = &mockSpotlightSearcher{titles: []string{"Groceries", "Garden"}}
+
+	notes, err := service.SearchNotesAdvanced(context.Background(), SearchOptions{
+		Query: "g", SearchIn: SearchInBody, Backend: SearchBackendSpotlight,
+	})
+	if err != nil {
+		t.Fatalf("SearchNotesAdvanced failed: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Title != "Groceries" {
+		t.Errorf("unexpected notes: %+v", notes)
+	}
+	if executor.callIndex != 0 {
+		t.Errorf("expected no AppleScript calls, got %d", executor.callIndex)
+	}
+}
+
+func TestSearchNotesAdvancedSpotlightFallback(t *testing.T) {
+	tests := []struct {
+		name       string
+		searcher   *mockSpotlightSearcher
+		opts       SearchOptions
+		wantCalled bool
+	}{
+		{
+			name:       "mdfind error",
+			searcher:   &mockSpotlightSearcher{err: errors.New("mdfind not found")},
+			opts:       SearchOptions{Query: "g", Backend: SearchBackendSpotlight},
+			wantCalled: true,
+		},
+		{
+			name:       "no hits",
+			searcher:   &mockSpotlightSearcher{},
+			opts:       SearchOptions{Query: "g", Backend: SearchBackendSpotlight},
+			wantCalled: true,
+		},
+		{
+			name:       "folder filter",
+			searcher:   &mockSpotlightSearcher{titles: []string{"Ignored"}},
+			opts:       SearchOptions{Query: "g", Folder: "Work", Backend: SearchBackendSpotlight},
+			wantCalled: false,
+		},
+		{
+			name:       "applescript backend",
+			searcher:   &mockSpotlightSearcher{titles: []string{"Ignored"}},
+			opts:       SearchOptions{Query: "g"},
+			wantCalled: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAppleNotesService(&MockExecutor{stdout: "Garden"})
+			service.spotlight = tt.searcher
+
+			notes, err := service.SearchNotesAdvanced(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("SearchNotesAdvanced failed: %v", err)
+			}
+			if len(notes) != 1 || notes[0].Title != "Garden" {
+				t.Errorf("expected AppleScript result, got %+v", notes)
+			}
+			if tt.searcher.called != tt.wantCalled {
+				t.Errorf("spotlight called = %v, want %v", tt.searcher.called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestSearchNotesAdvancedInvalidBackend(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{})
+
+	_, err := service.SearchNotesAdvanced(context.Background(), SearchOptions{Query: "g", Backend: "sqlite"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
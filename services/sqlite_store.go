@@ -0,0 +1,220 @@
+// ABOUTME: Read-only "sqlite" provider that reads the Notes database (NoteStore.sqlite) directly
+// ABOUTME: Runs sqlite3 in read-only mode and decodes note bodies from their gzipped protobuf blobs
+
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// NotesDatabaseName is the Notes database file inside the Notes group container
+const NotesDatabaseName = "NoteStore.sqlite"
+
+// coreDataEpoch is the reference date Core Data timestamps count seconds from
+var coreDataEpoch = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// sqliteSnapshotQuery lists every note not marked for deletion with its folder and body blob
+// Bodies are hex-encoded since sqlite3's JSON output can't carry binary.
+const sqliteSnapshotQuery = `SELECT
+	(SELECT Z_UUID FROM Z_METADATA) AS store,
+	n.Z_PK AS pk,
+	n.ZTITLE1 AS title,
+	f.ZTITLE2 AS folder,
+	n.ZCREATIONDATE1 AS created,
+	n.ZMODIFICATIONDATE1 AS modified,
+	COALESCE(n.ZISPASSWORDPROTECTED, 0) AS locked,
+	hex(d.ZDATA) AS data
+FROM ZICCLOUDSYNCINGOBJECT n
+LEFT JOIN ZICNOTEDATA d ON d.ZNOTE = n.Z_PK
+LEFT JOIN ZICCLOUDSYNCINGOBJECT f ON f.Z_PK = n.ZFOLDER
+WHERE n.ZTITLE1 IS NOT NULL AND COALESCE(n.ZMARKEDFORDELETION, 0) = 0`
+
+// sqliteNote is a row of sqliteSnapshotQuery
+type sqliteNote struct {
+	Store    string  `json:"store"`
+	PK       int64   `json:"pk"`
+	Title    string  `json:"title"`
+	Folder   string  `json:"folder"`
+	Created  float64 `json:"created"`
+	Modified float64 `json:"modified"`
+	Locked   int     `json:"locked"`
+	Data     string  `json:"data"`
+}
+
+// SQLiteExecutor runs SQL queries against a database with the sqlite3 command, read-only, printing JSON rows
+// It is a ScriptExecutor whose scripts are SQL.
+type SQLiteExecutor struct {
+	path    string
+	timeout time.Duration
+}
+
+// NewSQLiteExecutor creates an executor for the database at path; an empty path means the Notes database.
+// If timeout is 0 or negative, defaults to 10 seconds.
+func NewSQLiteExecutor(path string, timeout time.Duration) (*SQLiteExecutor, error) {
+	if path == "" {
+		dir, err := NotesContainerDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, NotesDatabaseName)
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &SQLiteExecutor{path: path, timeout: timeout}, nil
+}
+
+// Execute runs query with sqlite3 -readonly -json; running out of time returns a *TimeoutError
+func (e *SQLiteExecutor) Execute(ctx context.Context, query string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sqlite3", "-readonly", "-json", e.path, query) // #nosec G204 - path is the configured Notes database
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = &TimeoutError{Elapsed: time.Since(start).Round(100 * time.Millisecond), Limit: e.timeout}
+	}
+	if err != nil {
+		tracef(ctx, "sqlite3 failed after %s: %v", time.Since(start).Round(time.Millisecond), err)
+	} else {
+		tracef(ctx, "sqlite3 completed in %s", time.Since(start).Round(time.Millisecond))
+	}
+	return stdout.String(), stderr.String(), err
+}
+
+// NewSQLiteNotesReader creates a read-only NoteReader that queries the Notes database through executor
+// Note IDs match the x-coredata IDs AppleScript reports; password-protected notes have empty bodies.
+func NewSQLiteNotesReader(executor ScriptExecutor) NoteReader {
+	return &snapshotReader{load: func(ctx context.Context) ([]Note, error) {
+		return loadSQLiteNotes(ctx, executor)
+	}}
+}
+
+// loadSQLiteNotes runs sqliteSnapshotQuery and converts its rows to notes
+func loadSQLiteNotes(ctx context.Context, executor ScriptExecutor) ([]Note, error) {
+	stdout, stderr, err := executor.Execute(ctx, sqliteSnapshotQuery)
+	if err != nil {
+		if stderr != "" {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+		}
+		return nil, fmt.Errorf("failed to read the Notes database: %w", err)
+	}
+
+	rows := []sqliteNote{}
+	if out := strings.TrimSpace(stdout); out != "" {
+		if err := json.Unmarshal([]byte(out), &rows); err != nil {
+			return nil, fmt.Errorf("failed to parse the Notes database rows: %w", err)
+		}
+	}
+
+	notes := make([]Note, 0, len(rows))
+	for _, row := range rows {
+		created, modified := coreDataTime(row.Created), coreDataTime(row.Modified)
+		note := Note{
+			ID:                fmt.Sprintf("x-coredata://%s/ICNote/p%d", row.Store, row.PK),
+			Title:             row.Title,
+			Tags:              []string{},
+			Created:           created,
+			Modified:          modified,
+			CreationDate:      created,
+			ModificationDate:  modified,
+			Folder:            row.Folder,
+			PasswordProtected: row.Locked != 0,
+		}
+		if !note.PasswordProtected && row.Data != "" {
+			text, err := decodeNoteData(row.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode the body of %q: %w", row.Title, err)
+			}
+			note.Content = noteTextToHTML(text)
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// coreDataTime converts seconds since the Core Data epoch to a time
+func coreDataTime(seconds float64) time.Time {
+	return coreDataEpoch.Add(time.Duration(seconds * float64(time.Second))).Local()
+}
+
+// decodeNoteData returns the text of a hex-encoded, gzipped ZICNOTEDATA.ZDATA blob
+// The blob is a protobuf whose field 2 is the document, the document's field 3 the note, and the note's field 2 its text.
+func decodeNoteData(hexData string) (string, error) {
+	compressed, err := hex.DecodeString(hexData)
+	if err != nil {
+		return "", err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	for _, field := range []protowire.Number{2, 3, 2} {
+		if data, err = protoField(data, field); err != nil {
+			return "", err
+		}
+	}
+	return string(data), nil
+}
+
+// protoField returns the first length-delimited field numbered want in a protobuf message, skipping the rest
+func protoField(message []byte, want protowire.Number) ([]byte, error) {
+	for len(message) > 0 {
+		number, kind, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+		if number == want && kind == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(message)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			return value, nil
+		}
+		n = protowire.ConsumeFieldValue(number, kind, message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+	}
+	return nil, fmt.Errorf("protobuf field %d not found", want)
+}
+
+// noteTextToHTML renders plain note text as Notes.app bodies look, one <div> per line
+// Attachment placeholders (U+FFFC) are dropped since the database keeps attachments elsewhere.
+func noteTextToHTML(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\ufffc", ""), "\n") {
+		if line == "" {
+			b.WriteString("<div><br></div>")
+			continue
+		}
+		b.WriteString("<div>" + html.EscapeString(line) + "</div>")
+	}
+	return b.String()
+}
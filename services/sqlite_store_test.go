@@ -0,0 +1,145 @@
+// ABOUTME: Unit tests for the read-only sqlite provider
+// ABOUTME: Builds note body blobs like Notes.app's and reads them back from canned rows and a real sqlite3 database
+
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// noteDataBlob encodes text the way ZICNOTEDATA.ZDATA stores it: a gzipped protobuf nesting the note text
+func noteDataBlob(t *testing.T, text string) []byte {
+	t.Helper()
+	var note []byte
+	note = protowire.AppendTag(note, 1, protowire.VarintType)
+	note = protowire.AppendVarint(note, 0)
+	note = protowire.AppendTag(note, 2, protowire.BytesType)
+	note = protowire.AppendString(note, text)
+
+	var document []byte
+	document = protowire.AppendTag(document, 2, protowire.VarintType)
+	document = protowire.AppendVarint(document, 0)
+	document = protowire.AppendTag(document, 3, protowire.BytesType)
+	document = protowire.AppendBytes(document, note)
+
+	var store []byte
+	store = protowire.AppendTag(store, 2, protowire.BytesType)
+	store = protowire.AppendBytes(store, document)
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(store); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return compressed.Bytes()
+}
+
+func TestDecodeNoteData(t *testing.T) {
+	text, err := decodeNoteData(hex.EncodeToString(noteDataBlob(t, "Groceries\nMilk & eggs")))
+	if err != nil {
+		t.Fatalf("decodeNoteData failed: %v", err)
+	}
+	if text != "Groceries\nMilk & eggs" {
+		t.Errorf("decodeNoteData = %q", text)
+	}
+
+	if _, err := decodeNoteData("00ff"); err == nil {
+		t.Error("expected an error for data that isn't gzipped")
+	}
+}
+
+func TestNoteTextToHTML(t *testing.T) {
+	got := noteTextToHTML("Title\n\nA <b> line￼")
+	if want := "<div>Title</div><div><br></div><div>A &lt;b&gt; line</div>"; got != want {
+		t.Errorf("noteTextToHTML = %q, want %q", got, want)
+	}
+}
+
+// TestSQLiteNotesReader tests that rows become notes with AppleScript-style IDs, Core Data dates, and HTML bodies
+func TestSQLiteNotesReader(t *testing.T) {
+	rows := fmt.Sprintf(`[
+		{"store":"ABC","pk":7,"title":"Groceries","folder":"Home","created":700000000,"modified":700000100,"locked":0,"data":"%s"},
+		{"store":"ABC","pk":8,"title":"Diary","folder":"Home","created":700000000,"modified":700000200,"locked":1,"data":"00"}
+	]`, hex.EncodeToString(noteDataBlob(t, "Groceries\nMilk")))
+	reader := NewSQLiteNotesReader(&MockExecutor{stdout: rows})
+
+	content, err := reader.GetNoteContentByID(context.Background(), "x-coredata://ABC/ICNote/p7")
+	if err != nil {
+		t.Fatalf("GetNoteContentByID failed: %v", err)
+	}
+	if content != "<div>Groceries</div><div>Milk</div>" {
+		t.Errorf("unexpected body %q", content)
+	}
+
+	recent, err := reader.GetRecentNotes(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("GetRecentNotes failed: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Title != "Diary" || !recent[0].PasswordProtected {
+		t.Fatalf("expected the locked note first, got %+v", recent)
+	}
+	if want := coreDataEpoch.Add(700000100 * time.Second); !recent[1].ModificationDate.Equal(want) {
+		t.Errorf("modification date = %v, want %v", recent[1].ModificationDate, want)
+	}
+
+	inFolder, err := reader.GetNotesInFolder(context.Background(), "Home")
+	if err != nil || len(inFolder) != 2 {
+		t.Errorf("expected both notes in Home, got %v, %v", inFolder, err)
+	}
+}
+
+func TestSQLiteNotesReaderReportsQueryErrors(t *testing.T) {
+	reader := NewSQLiteNotesReader(&MockExecutor{stderr: "Error: unable to open database", err: fmt.Errorf("exit status 1")})
+	_, err := reader.SearchNotes(context.Background(), "milk")
+	if err == nil || !strings.Contains(err.Error(), "unable to open database") {
+		t.Errorf("expected the sqlite3 error, got %v", err)
+	}
+}
+
+// TestSQLiteExecutorQueriesDatabase runs the snapshot query with sqlite3 against a database shaped like the Notes one
+func TestSQLiteExecutorQueriesDatabase(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+	path := filepath.Join(t.TempDir(), NotesDatabaseName)
+	schema := fmt.Sprintf(`
+		CREATE TABLE Z_METADATA (Z_VERSION INTEGER, Z_UUID VARCHAR);
+		CREATE TABLE ZICCLOUDSYNCINGOBJECT (Z_PK INTEGER PRIMARY KEY, ZTITLE1 VARCHAR, ZTITLE2 VARCHAR, ZFOLDER INTEGER,
+			ZCREATIONDATE1 TIMESTAMP, ZMODIFICATIONDATE1 TIMESTAMP, ZISPASSWORDPROTECTED INTEGER, ZMARKEDFORDELETION INTEGER);
+		CREATE TABLE ZICNOTEDATA (Z_PK INTEGER PRIMARY KEY, ZNOTE INTEGER, ZDATA BLOB);
+		INSERT INTO Z_METADATA VALUES (1, 'STORE-UUID');
+		INSERT INTO ZICCLOUDSYNCINGOBJECT VALUES (1, NULL, 'Work', NULL, NULL, NULL, NULL, 0);
+		INSERT INTO ZICCLOUDSYNCINGOBJECT VALUES (2, 'Plan', NULL, 1, 700000000, 700000050, 0, 0);
+		INSERT INTO ZICCLOUDSYNCINGOBJECT VALUES (3, 'Gone', NULL, 1, 700000000, 700000050, 0, 1);
+		INSERT INTO ZICNOTEDATA VALUES (1, 2, X'%s');
+	`, hex.EncodeToString(noteDataBlob(t, "Plan\nShip it")))
+	if out, err := exec.Command("sqlite3", path, schema).CombinedOutput(); err != nil {
+		t.Fatalf("failed to create database: %v: %s", err, out)
+	}
+
+	executor, err := NewSQLiteExecutor(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes, err := NewSQLiteNotesReader(executor).ListNotesWithMetadata(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListNotesWithMetadata failed: %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != "x-coredata://STORE-UUID/ICNote/p2" || notes[0].Folder != "Work" {
+		t.Errorf("expected the one live note in Work, got %+v", notes)
+	}
+}
@@ -0,0 +1,72 @@
+// ABOUTME: Stale note report listing notes nobody has modified in a given number of days
+// ABOUTME: Gives cleanup reviews concrete candidates, oldest first, with their folder and word count
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultStaleDays is how long a note must go unmodified to count as stale when no age is given
+const DefaultStaleDays = 90
+
+// StaleNoteOptions selects the notes FindStaleNotes reports
+type StaleNoteOptions struct {
+	Days     int    // unmodified for at least this many days (default: DefaultStaleDays)
+	Folder   string // only notes in this folder; empty means every folder
+	MinWords int    // only notes with at least this many words, skipping stubs
+	Limit    int    // at most this many notes, oldest kept; zero means no limit
+}
+
+// StaleNote is a note in the stale report with how long it has gone unmodified
+type StaleNote struct {
+	Note
+	DaysStale int `json:"days_stale"`
+}
+
+// FindStaleNotes lists notes not modified in opts.Days days before now, oldest first
+// Each note's metrics give its size; locked notes have none and are left out when MinWords is set.
+func FindStaleNotes(ctx context.Context, notes NoteReader, opts StaleNoteOptions, now time.Time) ([]StaleNote, error) {
+	if opts.Days < 0 || opts.MinWords < 0 || opts.Limit < 0 {
+		return []StaleNote{}, fmt.Errorf("%w: days, min words, and limit cannot be negative", ErrInvalidInput)
+	}
+	if opts.Days == 0 {
+		opts.Days = DefaultStaleDays
+	}
+
+	library, err := notes.ListNotesWithMetadata(ctx, opts.Folder)
+	if err != nil {
+		return []StaleNote{}, fmt.Errorf("failed to find stale notes: %w", err)
+	}
+
+	cutoff := now.AddDate(0, 0, -opts.Days)
+	candidates := []Note{}
+	for _, note := range library {
+		if !note.ModificationDate.IsZero() && note.ModificationDate.Before(cutoff) {
+			candidates = append(candidates, note)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ModificationDate.Before(candidates[j].ModificationDate)
+	})
+
+	// Word counts need every candidate's body, read in one batch
+	if candidates, err = notes.WithNoteMetrics(ctx, candidates); err != nil {
+		return []StaleNote{}, fmt.Errorf("failed to find stale notes: %w", err)
+	}
+
+	stale := []StaleNote{}
+	for _, note := range candidates {
+		if opts.MinWords > 0 && (note.Metrics == nil || note.Metrics.WordCount < opts.MinWords) {
+			continue
+		}
+		stale = append(stale, StaleNote{Note: note, DaysStale: int(now.Sub(note.ModificationDate).Hours() / 24)})
+		if opts.Limit > 0 && len(stale) == opts.Limit {
+			break
+		}
+	}
+	return stale, nil
+}
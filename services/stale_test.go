@@ -0,0 +1,75 @@
+// ABOUTME: Unit tests for the stale note report
+// ABOUTME: Tests the age cutoff, oldest-first order, word count filter, folder scope, and limit
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindStaleNotes(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	create := func(title string, age time.Duration, words int) {
+		t.Helper()
+		service.now = func() time.Time { return now.Add(-age) }
+		body := "<div>" + strings.TrimSpace(strings.Repeat("word ", words)) + "</div>"
+		if _, err := service.CreateNote(ctx, title, body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+	day := 24 * time.Hour
+	create("Fresh", 10*day, 50)
+	create("Old plan", 120*day, 40)
+	create("Ancient stub", 400*day, 2)
+	create("Quarter old", 91*day, 30)
+
+	stale, err := FindStaleNotes(ctx, service, StaleNoteOptions{}, now)
+	if err != nil {
+		t.Fatalf("FindStaleNotes failed: %v", err)
+	}
+	titles := []string{}
+	for _, note := range stale {
+		titles = append(titles, note.Title)
+	}
+	if strings.Join(titles, ",") != "Ancient stub,Old plan,Quarter old" {
+		t.Fatalf("expected notes older than 90 days, oldest first, got %v", titles)
+	}
+	if stale[0].DaysStale != 400 || stale[0].Metrics == nil || stale[0].Metrics.WordCount != 2 {
+		t.Errorf("unexpected stale entry %+v (metrics %+v)", stale[0], stale[0].Metrics)
+	}
+
+	stale, err = FindStaleNotes(ctx, service, StaleNoteOptions{Days: 100, MinWords: 10}, now)
+	if err != nil {
+		t.Fatalf("FindStaleNotes failed: %v", err)
+	}
+	if len(stale) != 1 || stale[0].Title != "Old plan" {
+		t.Errorf("expected only Old plan past 100 days with 10+ words, got %+v", stale)
+	}
+
+	stale, err = FindStaleNotes(ctx, service, StaleNoteOptions{Limit: 1}, now)
+	if err != nil || len(stale) != 1 || stale[0].Title != "Ancient stub" {
+		t.Errorf("expected the limit to keep the oldest note, got %+v, %v", stale, err)
+	}
+
+	if err := service.CreateFolder(ctx, "Archive", ""); err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+	if err := service.MoveNote(ctx, "Quarter old", "Archive"); err != nil {
+		t.Fatalf("MoveNote failed: %v", err)
+	}
+	stale, err = FindStaleNotes(ctx, service, StaleNoteOptions{Folder: "Archive"}, now)
+	if err != nil || len(stale) != 1 || stale[0].Folder != "Archive" {
+		t.Errorf("expected only the Archive note, got %+v, %v", stale, err)
+	}
+
+	if _, err := FindStaleNotes(ctx, service, StaleNoteOptions{Days: -1}, now); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for negative days, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// ABOUTME: File helpers shared by the line-oriented local stores (history, cache, search index)
+// ABOUTME: Reads and writes one JSON record per line, sealing and opening records when encryption is on
+
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// readStoreLines returns the non-empty lines of a store file as stored; a missing file has none
+func readStoreLines(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is a configured local store
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// decodeStoreLine opens a line if it is sealed and unmarshals its JSON record into v
+// A sealed line without an encryptor is an error rather than being skipped, so nothing is silently lost.
+func decodeStoreLine(path string, line []byte, encryptor *StoreEncryptor, v any) error {
+	if IsEncryptedRecord(line) {
+		if encryptor == nil {
+			return fmt.Errorf("%s is encrypted; set NOTES_MCP_ENCRYPT_STORES=true to read it", path)
+		}
+		var err error
+		if line, err = encryptor.Open(line); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := json.Unmarshal(line, v); err != nil {
+		return fmt.Errorf("%s: malformed record: %w", path, err)
+	}
+	return nil
+}
+
+// encodeStoreLine marshals a record as a JSON line, sealed when encryptor is set
+func encodeStoreLine(v any, encryptor *StoreEncryptor) ([]byte, error) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if encryptor != nil {
+		return encryptor.Seal(line)
+	}
+	return line, nil
+}
+
+// appendStoreLine adds one line to a store file, creating it and its directory if needed
+func appendStoreLine(path string, line []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304 - path is a configured local store
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// replaceStoreLines replaces a store file with lines atomically, creating its directory if needed
+func replaceStoreLines(path string, lines [][]byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".write-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // removal after a successful rename is a no-op
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	_, err = tmp.Write(buf.Bytes())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
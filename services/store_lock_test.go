@@ -0,0 +1,47 @@
+// ABOUTME: Unit tests for local store locks
+// ABOUTME: Checks that shared locks coexist and keep an exclusive lock out until released
+
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestStoreLocks tests that writers share a store and rotation's exclusive lock waits for none of them
+func TestStoreLocks(t *testing.T) {
+	store := filepath.Join(t.TempDir(), "nested", "audit.log")
+
+	first, err := LockStoreShared(store)
+	if err != nil {
+		t.Fatalf("LockStoreShared failed: %v", err)
+	}
+	second, err := LockStoreShared(store)
+	if err != nil {
+		t.Fatalf("second shared lock failed: %v", err)
+	}
+
+	if _, err := lockStoreExclusive(store); !errors.Is(err, ErrStoreInUse) {
+		t.Errorf("expected ErrStoreInUse while shared locks are held, got %v", err)
+	}
+
+	_ = first.Release()
+	if _, err := lockStoreExclusive(store); !errors.Is(err, ErrStoreInUse) {
+		t.Errorf("expected ErrStoreInUse while one shared lock is held, got %v", err)
+	}
+
+	_ = second.Release()
+	exclusive, err := lockStoreExclusive(store)
+	if err != nil {
+		t.Fatalf("expected the exclusive lock once released, got %v", err)
+	}
+	if err := exclusive.Release(); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+
+	var none *StoreLock
+	if err := none.Release(); err != nil {
+		t.Errorf("releasing a nil lock should do nothing, got %v", err)
+	}
+}
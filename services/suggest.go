@@ -0,0 +1,240 @@
+// ABOUTME: Did-you-mean suggestions for note titles and folder names that don't exist
+// ABOUTME: Ranks known names by edit distance and keeps short-lived caches of the title and folder lists
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSuggestions is how many close matches a not-found error lists
+const maxSuggestions = 5
+
+// nameCacheTTL is how long a cached name list is trusted before it is fetched again
+const nameCacheTTL = 5 * time.Minute
+
+// suggestionLookupTimeout bounds fetching names for suggestions, so a failed call isn't slowed much
+const suggestionLookupTimeout = 3 * time.Second
+
+// FolderNotFoundError is a missing folder along with existing folder names that are close to it
+// It wraps the original error, so errors.Is(err, ErrFolderNotFound) still holds.
+type FolderNotFoundError struct {
+	Folder      string
+	Suggestions []string
+	Err         error
+}
+
+func (e *FolderNotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FolderNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// NoteNotFoundError is a missing note along with existing titles that are close to the one asked for
+// Like FolderNotFoundError, it wraps the original ErrNoteNotFound error.
+type NoteNotFoundError struct {
+	Title       string
+	Suggestions []string
+	Err         error
+}
+
+func (e *NoteNotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NoteNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// SuggestNames returns up to limit candidates close to target, closest first
+// Case-insensitive prefix and substring matches always qualify; other candidates must be within
+// an edit distance of about a third of the longer name.
+func SuggestNames(target string, candidates []string, limit int) []string {
+	target = strings.ToLower(strings.TrimSpace(target))
+	if target == "" {
+		return []string{}
+	}
+
+	type scored struct {
+		name  string
+		score int
+	}
+	matches := []scored{}
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		if seen[lower] || lower == "" {
+			continue
+		}
+		seen[lower] = true
+
+		distance := editDistance(target, lower)
+		switch {
+		case lower == target:
+			distance = 0
+		case strings.HasPrefix(lower, target) || strings.HasPrefix(target, lower):
+			distance = min(distance, 1)
+		case strings.Contains(lower, target) || strings.Contains(target, lower):
+			distance = min(distance, 2)
+		case distance*3 > max(utf8.RuneCountInString(target), utf8.RuneCountInString(lower)):
+			continue
+		}
+		matches = append(matches, scored{name: candidate, score: distance})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	names := []string{}
+	for _, match := range matches {
+		if len(names) == limit {
+			break
+		}
+		names = append(names, match.name)
+	}
+	return names
+}
+
+// editDistance returns the edit distance between two strings in runes, counting an adjacent
+// transposition ("wrok" for "work") as a single edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// rows[0], rows[1], and rows[2] hold the distances for prefixes of a of length i-2, i-1, and i
+	rows := [3][]int{make([]int, len(rb)+1), make([]int, len(rb)+1), make([]int, len(rb)+1)}
+	for j := range rows[1] {
+		rows[1][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current, previous, before := rows[2], rows[1], rows[0]
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				current[j] = min(current[j], before[j-2]+1)
+			}
+		}
+		rows[0], rows[1], rows[2] = previous, current, before
+	}
+	return rows[1][len(rb)]
+}
+
+// nameCache remembers a list of names (such as folders) for a short time
+type nameCache struct {
+	mu      sync.Mutex
+	names   []string
+	fetched time.Time
+}
+
+// set replaces the cached names
+func (c *nameCache) set(names []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names = append([]string{}, names...)
+	c.fetched = time.Now()
+}
+
+// invalidate forgets the cached names so the next lookup fetches them again
+func (c *nameCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names = nil
+	c.fetched = time.Time{}
+}
+
+// get returns the cached names, calling fetch when the cache is empty or stale
+func (c *nameCache) get(ctx context.Context, fetch func(ctx context.Context) ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	if !c.fetched.IsZero() && time.Since(c.fetched) < nameCacheTTL {
+		names := c.names
+		c.mu.Unlock()
+		return names, nil
+	}
+	c.mu.Unlock()
+
+	names, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.set(names)
+	return names, nil
+}
+
+// withFolderSuggestions adds close folder names to a folder-not-found error; other errors pass through
+// The folder list comes from the cache when fresh. A lookup that fails leaves the error as it was.
+func (s *AppleNotesService) withFolderSuggestions(ctx context.Context, folder string, err error) error {
+	var existing *FolderNotFoundError
+	if !errors.Is(err, ErrFolderNotFound) || errors.As(err, &existing) {
+		return err
+	}
+
+	// The operation's context may be spent; suggestions get their own short deadline
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), suggestionLookupTimeout)
+	defer cancel()
+	folders, lookupErr := s.folderCache.get(lookupCtx, s.ListFolders)
+	if lookupErr != nil {
+		return err
+	}
+	return &FolderNotFoundError{Folder: folder, Suggestions: SuggestNames(folder, folders, maxSuggestions), Err: err}
+}
+
+// withNoteSuggestions adds close note titles to a note-not-found error; other errors pass through
+func (s *AppleNotesService) withNoteSuggestions(ctx context.Context, title string, err error) error {
+	var existing *NoteNotFoundError
+	if !errors.Is(err, ErrNoteNotFound) || errors.As(err, &existing) {
+		return err
+	}
+
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), suggestionLookupTimeout)
+	defer cancel()
+	titles, lookupErr := s.titleCache.get(lookupCtx, s.listNoteTitles)
+	if lookupErr != nil {
+		return err
+	}
+	return &NoteNotFoundError{Title: title, Suggestions: suggestTitles(title, titles), Err: err}
+}
+
+// listNoteTitles returns the title of every note in one script
+func (s *AppleNotesService) listNoteTitles(ctx context.Context) ([]string, error) {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set output to ""
+				repeat with noteName in (get name of notes)
+					set output to output & noteName & linefeed
+				end repeat
+				return output
+			end tell
+		end tell
+	`, s.iCloudAccount)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list note titles: %w", DetectError(ctx, stderr, err))
+	}
+
+	titles := []string{}
+	for _, line := range strings.Split(stdout, "\n") {
+		if title := strings.TrimSpace(line); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles, nil
+}
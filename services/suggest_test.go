@@ -0,0 +1,125 @@
+// ABOUTME: Unit tests for did-you-mean suggestions on missing notes and folders
+// ABOUTME: Covers name ranking, the folder cache, and suggestions from both providers
+
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSuggestNames(t *testing.T) {
+	folders := []string{"Work", "Work Projects", "Personal", "Recipes", "Archive", "Wok Recipes"}
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"work", []string{"Work", "Work Projects"}},
+		{"Wrok", []string{"Work"}},
+		{"Personnal", []string{"Personal"}},
+		{"recipe", []string{"Recipes", "Wok Recipes"}},
+		{"Taxes", []string{}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		if got := SuggestNames(tt.target, folders, maxSuggestions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SuggestNames(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+
+	if got := SuggestNames("a", []string{"a1", "a2", "a3"}, 2); len(got) != 2 {
+		t.Errorf("expected the limit to apply, got %v", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	if d := editDistance("kitten", "sitting"); d != 3 {
+		t.Errorf("editDistance(kitten, sitting) = %d, want 3", d)
+	}
+	if d := editDistance("wrok", "work"); d != 1 {
+		t.Errorf("expected a transposition to count once, got %d", d)
+	}
+	if d := editDistance("café", "cafe"); d != 1 {
+		t.Errorf("expected distances in runes, got %d", d)
+	}
+}
+
+func TestMoveNoteSuggestsFolders(t *testing.T) {
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stderr: `Notes got an error: Can't get folder "Wrok". (-1728)`, err: errors.New("exit status 1")}, // MoveNote
+			{stdout: "Notes|||Work|||Personal"}, // ListFolders for suggestions
+			{stderr: `Notes got an error: Can't get folder "Persnal". (-1728)`, err: errors.New("exit status 1")}, // MoveNote, suggestions from cache
+		},
+	}
+	service := NewAppleNotesService(executor)
+
+	err := service.MoveNote(context.Background(), "Plan", "Wrok")
+	var folderErr *FolderNotFoundError
+	if !errors.Is(err, ErrFolderNotFound) || !errors.As(err, &folderErr) {
+		t.Fatalf("expected a FolderNotFoundError, got %v", err)
+	}
+	if !reflect.DeepEqual(folderErr.Suggestions, []string{"Work"}) {
+		t.Errorf("suggestions = %v, want [Work]", folderErr.Suggestions)
+	}
+
+	err = service.MoveNote(context.Background(), "Plan", "Persnal")
+	if !errors.As(err, &folderErr) || !reflect.DeepEqual(folderErr.Suggestions, []string{"Personal"}) {
+		t.Errorf("expected cached folders to be suggested, got %v", err)
+	}
+	if executor.callIndex != 3 {
+		t.Errorf("expected the folder list to be fetched once, got %d scripts", executor.callIndex)
+	}
+}
+
+func TestMemoryServiceSuggestsFolders(t *testing.T) {
+	notes := newTestMemoryService()
+	_ = notes.CreateFolder(context.Background(), "Projects", "")
+
+	_, err := notes.GetNotesInFolder(context.Background(), "Project")
+	var folderErr *FolderNotFoundError
+	if !errors.As(err, &folderErr) || !reflect.DeepEqual(folderErr.Suggestions, []string{"Projects"}) {
+		t.Errorf("expected Projects to be suggested, got %v", err)
+	}
+}
+
+func TestGetNoteContentSuggestsTitles(t *testing.T) {
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stderr: `Notes got an error: Can't get note "Meeting Note". (-1728)`, err: errors.New("exit status 1")}, // GetNoteContent
+			{stdout: "Meeting Notes\nMeeting Notes 2024\nGroceries\n"},                                               // titles for suggestions
+		},
+	}
+	service := NewAppleNotesService(executor)
+
+	_, err := service.GetNoteContent(context.Background(), "Meeting Note")
+	var noteErr *NoteNotFoundError
+	if !errors.Is(err, ErrNoteNotFound) || !errors.As(err, &noteErr) {
+		t.Fatalf("expected a NoteNotFoundError, got %v", err)
+	}
+	if want := []string{"Meeting Notes", "Meeting Notes 2024"}; !reflect.DeepEqual(noteErr.Suggestions, want) {
+		t.Errorf("suggestions = %v, want %v", noteErr.Suggestions, want)
+	}
+}
+
+func TestMemoryServiceSuggestsTitles(t *testing.T) {
+	notes := newTestMemoryService()
+	_, _ = notes.CreateNote(context.Background(), "Quarterly Plan", "<div>q3</div>", nil)
+
+	_, err := notes.GetNoteContent(context.Background(), "Quartely Plan")
+	var noteErr *NoteNotFoundError
+	if !errors.As(err, &noteErr) || !reflect.DeepEqual(noteErr.Suggestions, []string{"Quarterly Plan"}) {
+		t.Errorf("expected Quarterly Plan to be suggested, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+// ABOUTME: Markdown rendering for terminals, giving notes a formatted read-only view on the command line
+// ABOUTME: Styles headings, lists, checkboxes, quotes, code, and inline emphasis with ANSI codes, wrapping to a width
+
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI styles used by RenderTerminalMarkdown
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiFaint     = "\x1b[2m"
+	ansiItalic    = "\x1b[3m"
+	ansiUnderline = "\x1b[4m"
+	ansiStrike    = "\x1b[9m"
+	ansiCyan      = "\x1b[36m"
+	ansiMagenta   = "\x1b[35m"
+	ansiYellow    = "\x1b[33m"
+	ansiGreen     = "\x1b[32m"
+)
+
+var (
+	mdImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdRulePattern  = regexp.MustCompile(`^(?:-{3,}|\*{3,}|_{3,})$`)
+	ansiPattern    = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// TerminalRenderOptions controls how RenderTerminalMarkdown formats markdown
+type TerminalRenderOptions struct {
+	Color bool // style text with ANSI escape codes; without it only the layout and symbols remain
+	Width int  // wrap paragraphs and list items to this many columns; 0 disables wrapping
+}
+
+// RenderTerminalMarkdown formats markdown for reading in a terminal
+// Markers such as "#", "**", and "- [ ]" are replaced by styling and symbols (•, ☐, ☑, │), and
+// fenced code is kept verbatim. Lines are handled one at a time, like MarkdownToHTML.
+func RenderTerminalMarkdown(markdown string, opts TerminalRenderOptions) string {
+	style := func(text string, codes ...string) string {
+		if !opts.Color || text == "" {
+			return text
+		}
+		return strings.Join(codes, "") + text + ansiReset
+	}
+
+	var b strings.Builder
+	fenced := false
+	blank := true // start as if after a blank line, so leading blank lines are dropped
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			b.WriteString("    " + style(line, ansiYellow) + "\n")
+			blank = false
+			continue
+		}
+		if trimmed == "" {
+			if !blank {
+				b.WriteString("\n")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+
+		indent := strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " \t")))
+		if m := mdCheckboxPattern.FindStringSubmatch(trimmed); m != nil {
+			if m[1] == " " {
+				b.WriteString(wrapTerminalLine(indent+style("☐", ansiCyan)+" ", terminalInline(m[2], style), opts.Width))
+			} else {
+				b.WriteString(wrapTerminalLine(indent+style("☑", ansiGreen)+" ", style(terminalInline(m[2], style), ansiFaint), opts.Width))
+			}
+			continue
+		}
+		if m := mdBulletPattern.FindStringSubmatch(trimmed); m != nil {
+			b.WriteString(wrapTerminalLine(indent+style("•", ansiCyan)+" ", terminalInline(m[1], style), opts.Width))
+			continue
+		}
+		if m := mdOrderedPattern.FindStringSubmatch(trimmed); m != nil {
+			number := strings.TrimSuffix(trimmed, m[1])
+			b.WriteString(wrapTerminalLine(indent+style(strings.TrimSpace(number), ansiCyan)+" ", terminalInline(m[1], style), opts.Width))
+			continue
+		}
+
+		switch m := mdHeadingPattern.FindStringSubmatch(trimmed); {
+		case m != nil:
+			text := terminalInline(m[2], style)
+			switch len(m[1]) {
+			case 1:
+				b.WriteString(style(strings.ToUpper(text), ansiBold, ansiUnderline, ansiMagenta) + "\n")
+			case 2:
+				b.WriteString(style(text, ansiBold, ansiMagenta) + "\n")
+			default:
+				b.WriteString(style(text, ansiBold) + "\n")
+			}
+		case mdRulePattern.MatchString(trimmed):
+			b.WriteString(style(strings.Repeat("─", max(opts.Width, 3)), ansiFaint) + "\n")
+		case mdQuotePattern.MatchString(trimmed):
+			quote := mdQuotePattern.FindStringSubmatch(trimmed)[1]
+			b.WriteString(wrapTerminalLine(style("│", ansiFaint)+" ", style(terminalInline(quote, style), ansiItalic), opts.Width))
+		default:
+			b.WriteString(wrapTerminalLine(indent, terminalInline(trimmed, style), opts.Width))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// terminalInline replaces inline markdown in one line with styled text
+// Code spans are kept literal; links show their text followed by the target, and images their alt text.
+func terminalInline(text string, style func(string, ...string) string) string {
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = style(part, ansiYellow)
+			continue
+		}
+		if i%2 == 1 {
+			// An unmatched backtick stays as text
+			part = "`" + part
+		}
+
+		part = mdImagePattern.ReplaceAllStringFunc(part, func(image string) string {
+			m := mdImagePattern.FindStringSubmatch(image)
+			return style("[image: "+m[1]+"]", ansiFaint)
+		})
+		part = mdLinkPattern.ReplaceAllStringFunc(part, func(link string) string {
+			m := mdLinkPattern.FindStringSubmatch(link)
+			if m[1] == m[2] {
+				return style(m[1], ansiUnderline, ansiCyan)
+			}
+			return style(m[1], ansiUnderline) + " " + style("("+m[2]+")", ansiFaint)
+		})
+		part = mdBoldPattern.ReplaceAllStringFunc(part, func(bold string) string {
+			m := mdBoldPattern.FindStringSubmatch(bold)
+			return style(m[1]+m[2], ansiBold)
+		})
+		part = mdItalicPattern.ReplaceAllStringFunc(part, func(italic string) string {
+			m := mdItalicPattern.FindStringSubmatch(italic)
+			return style(m[1]+m[2], ansiItalic)
+		})
+		part = mdStrikePattern.ReplaceAllStringFunc(part, func(strike string) string {
+			return style(mdStrikePattern.FindStringSubmatch(strike)[1], ansiStrike)
+		})
+		parts[i] = part
+	}
+	return strings.Join(parts, "")
+}
+
+// wrapTerminalLine writes prefix and text, wrapping text at spaces to width columns
+// Continuation lines are indented to line up under the text; escape codes take up no columns.
+func wrapTerminalLine(prefix, text string, width int) string {
+	prefixWidth := terminalWidth(prefix)
+	if width <= 0 || prefixWidth+terminalWidth(text) <= width {
+		return prefix + text + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	column := prefixWidth
+	for i, word := range strings.Split(text, " ") {
+		wordWidth := terminalWidth(word)
+		if i > 0 && column+1+wordWidth > width && column > prefixWidth {
+			b.WriteString("\n" + strings.Repeat(" ", prefixWidth))
+			column = prefixWidth
+		} else if i > 0 {
+			b.WriteString(" ")
+			column++
+		}
+		b.WriteString(word)
+		column += wordWidth
+	}
+	return b.String() + "\n"
+}
+
+// terminalWidth returns how many columns text takes up, not counting ANSI escape codes
+func terminalWidth(text string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(text, ""))
+}
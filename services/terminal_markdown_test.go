@@ -0,0 +1,42 @@
+// ABOUTME: Unit tests for rendering markdown in a terminal
+// ABOUTME: Tests the plain layout of each block type, ANSI styling, and wrapping to a width
+
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTerminalMarkdown(t *testing.T) {
+	markdown := "\n# Plan\n\nShip **the** *beta* and read [the doc](https://example.com).\n\n\n## Tasks\n" +
+		"- [ ] Write `notes`\n- [x] Review\n- Loose end\n  - Nested\n1. First\n> Quoted ~~text~~\n---\n" +
+		"```\n**literal**\n```\n![Diagram](attachment:diagram.png)\n"
+
+	got := RenderTerminalMarkdown(markdown, TerminalRenderOptions{Width: 20})
+	want := "PLAN\n\nShip the beta and\nread the doc\n(https://example.com).\n\nTasks\n" +
+		"☐ Write notes\n☑ Review\n• Loose end\n  • Nested\n1. First\n│ Quoted text\n" + strings.Repeat("─", 20) + "\n" +
+		"    **literal**\n[image: Diagram]\n"
+	if got != want {
+		t.Errorf("RenderTerminalMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+
+	colored := RenderTerminalMarkdown("## Tasks\n- [ ] Write **now**", TerminalRenderOptions{Color: true})
+	for _, code := range []string{ansiBold + ansiMagenta + "Tasks" + ansiReset, ansiCyan + "☐" + ansiReset, ansiBold + "now" + ansiReset} {
+		if !strings.Contains(colored, code) {
+			t.Errorf("expected %q in %q", code, colored)
+		}
+	}
+}
+
+func TestWrapTerminalLine(t *testing.T) {
+	if got := wrapTerminalLine("• ", "one two three four", 10); got != "• one two\n  three\n  four\n" {
+		t.Errorf("wrapTerminalLine() = %q", got)
+	}
+	if got := wrapTerminalLine("", "unbreakablewordthatislong", 5); got != "unbreakablewordthatislong\n" {
+		t.Errorf("a word longer than the width should stay whole, got %q", got)
+	}
+	if got := terminalWidth(ansiBold + "café" + ansiReset); got != 4 {
+		t.Errorf("terminalWidth() = %d, want 4", got)
+	}
+}
@@ -0,0 +1,126 @@
+// ABOUTME: Thumbnail generation for image attachments using the macOS sips command
+// ABOUTME: Downsizes an attachment to a small JPEG so image context stays cheap to send to an LLM
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultThumbnailSize is the longest edge, in pixels, of thumbnails when no size is given
+const DefaultThumbnailSize = 256
+
+// MaxThumbnailSize caps the longest edge of a thumbnail so payloads stay small
+const MaxThumbnailSize = 1024
+
+// thumbnailImageExtensions lists the attachment file types sips can downsize
+var thumbnailImageExtensions = map[string]bool{
+	".jpg": true, ".jpeg": true, ".png": true, ".heic": true, ".heif": true,
+	".gif": true, ".tif": true, ".tiff": true, ".bmp": true, ".webp": true,
+}
+
+// ImageResizer writes a copy of an image scaled so its longest edge is at most maxPx, as JPEG
+type ImageResizer interface {
+	Resize(ctx context.Context, srcPath, dstPath string, maxPx int) error
+}
+
+// SipsResizer implements ImageResizer using the macOS sips command
+type SipsResizer struct {
+	timeout time.Duration
+}
+
+// NewSipsResizer creates a SipsResizer with the specified timeout.
+// If timeout is 0 or negative, defaults to 15 seconds.
+func NewSipsResizer(timeout time.Duration) *SipsResizer {
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+
+	return &SipsResizer{
+		timeout: timeout,
+	}
+}
+
+// Resize runs sips to scale srcPath down and convert it to JPEG at dstPath
+func (r *SipsResizer) Resize(ctx context.Context, srcPath, dstPath string, maxPx int) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sips", "-Z", fmt.Sprintf("%d", maxPx), "-s", "format", "jpeg", srcPath, "--out", dstPath)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sips failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// Thumbnail is a downsized copy of an image attachment
+type Thumbnail struct {
+	Name     string `json:"name"`
+	MIMEType string `json:"mime_type"`
+	MaxPx    int    `json:"max_px"`
+	Data     []byte `json:"data"`
+}
+
+// GetAttachmentThumbnail downsizes a note's image attachment so its longest edge is at most maxPx
+// The attachment is matched by name, case-insensitively. maxPx defaults to DefaultThumbnailSize
+// and may not exceed MaxThumbnailSize; images already smaller are re-encoded but not enlarged.
+func (s *AppleNotesService) GetAttachmentThumbnail(ctx context.Context, noteTitle, attachmentName string, maxPx int) (*Thumbnail, error) {
+	if maxPx == 0 {
+		maxPx = DefaultThumbnailSize
+	}
+	if maxPx < 16 || maxPx > MaxThumbnailSize {
+		return nil, fmt.Errorf("%w: max_px must be between 16 and %d", ErrInvalidInput, MaxThumbnailSize)
+	}
+
+	attachments, err := s.GetNoteAttachments(ctx, noteTitle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment thumbnail: %w", err)
+	}
+
+	var attachment *Attachment
+	for i := range attachments {
+		if strings.EqualFold(attachments[i].Name, attachmentName) {
+			attachment = &attachments[i]
+			break
+		}
+	}
+	if attachment == nil {
+		return nil, fmt.Errorf("%w: note %q has no attachment named %q", ErrInvalidInput, noteTitle, attachmentName)
+	}
+	if attachment.FilePath == "" {
+		return nil, fmt.Errorf("failed to get attachment thumbnail: attachment %q has no file on disk", attachment.Name)
+	}
+	if !thumbnailImageExtensions[strings.ToLower(filepath.Ext(attachment.FilePath))] {
+		return nil, fmt.Errorf("%w: attachment %q is not an image", ErrInvalidInput, attachment.Name)
+	}
+
+	dir, err := os.MkdirTemp("", "notes-mcp-thumb-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment thumbnail: %w", err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck // temp dir cleanup failure is non-critical
+
+	thumbPath := filepath.Join(dir, "thumbnail.jpg")
+	if err := s.resizer.Resize(ctx, attachment.FilePath, thumbPath, maxPx); err != nil {
+		return nil, fmt.Errorf("failed to get attachment thumbnail: %w", err)
+	}
+
+	data, err := os.ReadFile(thumbPath) // #nosec G304 - path is inside our own temp dir
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment thumbnail: %w", err)
+	}
+
+	return &Thumbnail{Name: attachment.Name, MIMEType: "image/jpeg", MaxPx: maxPx, Data: data}, nil
+}
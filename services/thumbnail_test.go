@@ -0,0 +1,84 @@
+// ABOUTME: Unit tests for attachment thumbnail generation
+// ABOUTME: Uses a fake resizer to test attachment lookup, size validation, and reading the result
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+// fakeResizer records the resize request and writes fixed output
+type fakeResizer struct {
+	srcPath string
+	maxPx   int
+	err     error
+}
+
+func (f *fakeResizer) Resize(ctx context.Context, srcPath, dstPath string, maxPx int) error {
+	f.srcPath = srcPath
+	f.maxPx = maxPx
+	if f.err != nil {
+		return f.err
+	}
+	return os.WriteFile(dstPath, []byte("jpeg"), 0o600)
+}
+
+var thumbnailAttachments = attachmentRecord("x-coredata://att1", "document.pdf", "cid-1", "/Users/test/document.pdf", "2024-01-01T10:00:00", "2024-01-15T15:30:00") +
+	attachmentRecord("x-coredata://att2", "Whiteboard.HEIC", "cid-2", "/Users/test/Whiteboard.HEIC", "2024-01-02T11:00:00", "2024-01-16T16:30:00")
+
+// TestGetAttachmentThumbnail tests downsizing an image attachment found by name
+func TestGetAttachmentThumbnail(t *testing.T) {
+	service := NewAppleNotesService(&MockExecutor{stdout: thumbnailAttachments})
+	resizer := &fakeResizer{}
+	service.resizer = resizer
+
+	thumb, err := service.GetAttachmentThumbnail(context.Background(), "Meeting", "whiteboard.heic", 0)
+	if err != nil {
+		t.Fatalf("GetAttachmentThumbnail failed: %v", err)
+	}
+
+	if resizer.srcPath != "/Users/test/Whiteboard.HEIC" {
+		t.Errorf("resized %q", resizer.srcPath)
+	}
+	if resizer.maxPx != DefaultThumbnailSize || thumb.MaxPx != DefaultThumbnailSize {
+		t.Errorf("max px = %d/%d, want %d", resizer.maxPx, thumb.MaxPx, DefaultThumbnailSize)
+	}
+	if thumb.Name != "Whiteboard.HEIC" || thumb.MIMEType != "image/jpeg" || string(thumb.Data) != "jpeg" {
+		t.Errorf("unexpected thumbnail %+v", thumb)
+	}
+}
+
+// TestGetAttachmentThumbnailErrors tests size limits, unknown names, non-images, and resize failures
+func TestGetAttachmentThumbnailErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment string
+		maxPx      int
+		resizeErr  error
+		wantErr    error
+	}{
+		{name: "too small", attachment: "Whiteboard.HEIC", maxPx: 8, wantErr: ErrInvalidInput},
+		{name: "too large", attachment: "Whiteboard.HEIC", maxPx: MaxThumbnailSize + 1, wantErr: ErrInvalidInput},
+		{name: "unknown attachment", attachment: "missing.png", wantErr: ErrInvalidInput},
+		{name: "not an image", attachment: "document.pdf", wantErr: ErrInvalidInput},
+		{name: "resize failure", attachment: "Whiteboard.HEIC", resizeErr: errors.New("sips failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAppleNotesService(&MockExecutor{stdout: thumbnailAttachments})
+			service.resizer = &fakeResizer{err: tt.resizeErr}
+
+			_, err := service.GetAttachmentThumbnail(context.Background(), "Meeting", tt.attachment, tt.maxPx)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// ABOUTME: Detection of titles shared by more than one note, for callers that must not act on an arbitrary match
+// ABOUTME: Notes looks titles up ignoring case, so notes whose titles differ only in case count as the same title
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AmbiguousTitleError is a title that more than one note answers to, along with those notes
+// It wraps ErrInvalidInput, since the caller has to pick a note another way.
+type AmbiguousTitleError struct {
+	Title      string
+	Candidates []Note
+}
+
+func (e *AmbiguousTitleError) Error() string {
+	return fmt.Sprintf("%v: %d notes are titled %q (ignoring case)", ErrInvalidInput, len(e.Candidates), e.Title)
+}
+
+func (e *AmbiguousTitleError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// FindNotesTitled returns every note a lookup of title could pick, newest first
+func FindNotesTitled(ctx context.Context, notes NoteReader, title string) ([]Note, error) {
+	library, err := notes.ListNotesWithMetadata(ctx, "")
+	if err != nil {
+		return []Note{}, fmt.Errorf("failed to look up title: %w", err)
+	}
+
+	key := strings.ToLower(normalizeTitle(title))
+	matches := []Note{}
+	for _, note := range library {
+		if strings.ToLower(normalizeTitle(note.Title)) == key {
+			matches = append(matches, note)
+		}
+	}
+	return matches, nil
+}
+
+// RequireUniqueTitle returns an AmbiguousTitleError if more than one note is titled title
+// A title no note has passes, leaving the not-found error to the operation itself.
+func RequireUniqueTitle(ctx context.Context, notes NoteReader, title string) error {
+	matches, err := FindNotesTitled(ctx, notes, title)
+	if err != nil {
+		return err
+	}
+	if len(matches) > 1 {
+		return &AmbiguousTitleError{Title: title, Candidates: matches}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// ABOUTME: Unit tests for detecting titles shared by more than one note
+// ABOUTME: Tests case-insensitive matching, the candidates reported, and titles no note has
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequireUniqueTitle(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	for _, title := range []string{"Plan", "plan", "Other"} {
+		if _, err := service.CreateNote(ctx, title, title, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	for _, title := range []string{"Other", "Missing"} {
+		if err := RequireUniqueTitle(ctx, service, title); err != nil {
+			t.Errorf("%q: expected no error, got %v", title, err)
+		}
+	}
+
+	err := RequireUniqueTitle(ctx, service, "PLAN")
+	var ambiguous *AmbiguousTitleError
+	if !errors.As(err, &ambiguous) || !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected an AmbiguousTitleError, got %v", err)
+	}
+	if len(ambiguous.Candidates) != 2 {
+		t.Errorf("expected both notes as candidates, got %+v", ambiguous.Candidates)
+	}
+	for _, candidate := range ambiguous.Candidates {
+		if candidate.Title != "Plan" && candidate.Title != "plan" {
+			t.Errorf("unexpected candidate %q", candidate.Title)
+		}
+	}
+}
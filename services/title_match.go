@@ -0,0 +1,124 @@
+// ABOUTME: Unicode normalization and punctuation-tolerant matching for note titles
+// ABOUTME: Read lookups that miss retry with the one existing title differing only in quotes, dashes, or case
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeTitle returns title in Unicode NFC, the composed form macOS keyboards produce
+// "é" typed as e plus a combining accent and "é" as one code point then compare equal.
+func normalizeTitle(title string) string {
+	return norm.NFC.String(title)
+}
+
+// tolerantTitleKey reduces a title to what survives the usual punctuation variants
+// It normalizes to NFC, lowercases, drops emoji variation selectors, and treats punctuation
+// (straight and curly quotes, dashes, ellipses) like whitespace, collapsing runs to one space.
+func tolerantTitleKey(title string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(normalizeTitle(title)) {
+		switch {
+		case r == '\uFE0E', r == '\uFE0F':
+			continue
+		case unicode.IsPunct(r), unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// matchTitleTolerantly returns the one candidate whose tolerant key equals title's
+// Titles that reduce to an empty key, or that match several candidates, are not matched.
+func matchTitleTolerantly(title string, candidates []string) (string, bool) {
+	key := tolerantTitleKey(title)
+	if key == "" {
+		return "", false
+	}
+
+	match := ""
+	for _, candidate := range candidates {
+		if tolerantTitleKey(candidate) != key || candidate == match {
+			continue
+		}
+		if match != "" {
+			return "", false
+		}
+		match = candidate
+	}
+	return match, match != ""
+}
+
+// titleMatchKey is the context key for the title match function
+type titleMatchKey struct{}
+
+// TitleMatchFunc is told that a lookup for requested was answered by the note titled actual
+type TitleMatchFunc func(requested, actual string)
+
+// WithTitleMatchReport returns a copy of ctx whose tolerant title matches are reported to fn,
+// so callers can tell users which note they actually got
+func WithTitleMatchReport(ctx context.Context, fn TitleMatchFunc) context.Context {
+	return context.WithValue(ctx, titleMatchKey{}, fn)
+}
+
+// reportTitleMatch tells the title match function carried by ctx, if any, that actual answered requested
+func reportTitleMatch(ctx context.Context, requested, actual string) {
+	tracef(ctx, "note %q not found; using %q", requested, actual)
+	if fn, ok := ctx.Value(titleMatchKey{}).(TitleMatchFunc); ok && fn != nil {
+		fn(requested, actual)
+	}
+}
+
+// tolerantTitle finds the existing title a note-not-found lookup for title probably meant
+// Only reads retry with it: the title list may be minutes old, so writes, moves, and deletes
+// fail instead and offer the match as a suggestion. It reports false for other errors, when no
+// single title matches, and when the match is title itself, so retrying with the result can't loop.
+func (s *AppleNotesService) tolerantTitle(ctx context.Context, title string, err error) (string, bool) {
+	if !errors.Is(err, ErrNoteNotFound) {
+		return "", false
+	}
+
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), suggestionLookupTimeout)
+	defer cancel()
+	titles, lookupErr := s.titleCache.get(lookupCtx, s.listNoteTitles)
+	if lookupErr != nil {
+		return "", false
+	}
+
+	match, ok := matchTitleTolerantly(title, titles)
+	if !ok || match == title {
+		return "", false
+	}
+	reportTitleMatch(ctx, title, match)
+	return match, true
+}
+
+// suggestTitles returns close titles for a missing one, leading with the title it tolerantly matches
+func suggestTitles(title string, titles []string) []string {
+	suggestions := SuggestNames(title, titles, maxSuggestions)
+	match, ok := matchTitleTolerantly(title, titles)
+	if !ok {
+		return suggestions
+	}
+
+	ranked := []string{match}
+	for _, suggestion := range suggestions {
+		if suggestion != match && len(ranked) < maxSuggestions {
+			ranked = append(ranked, suggestion)
+		}
+	}
+	return ranked
+}
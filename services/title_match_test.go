@@ -0,0 +1,158 @@
+// ABOUTME: Unit tests for Unicode normalization and tolerant title matching
+// ABOUTME: Covers tolerant keys, unique matching, retrying reads, and refusing to retry writes in both providers
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTolerantTitleKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Harper’s “Plan”", `Harper's "Plan"`},
+		{"Café notes", "Café notes"}, // composed vs decomposed é
+		{"Q3 — Roadmap…", "q3 - roadmap..."},
+		{"Ideas ❤️", "Ideas ❤"},
+		{"  Two   spaces ", "two spaces"},
+	}
+	for _, tt := range tests {
+		if a, b := tolerantTitleKey(tt.a), tolerantTitleKey(tt.b); a != b {
+			t.Errorf("tolerantTitleKey(%q) = %q, tolerantTitleKey(%q) = %q; want equal", tt.a, a, tt.b, b)
+		}
+	}
+
+	if tolerantTitleKey("Café") == tolerantTitleKey("Cafe") {
+		t.Error("accents are not punctuation and should not be dropped")
+	}
+}
+
+func TestMatchTitleTolerantly(t *testing.T) {
+	candidates := []string{"Harper’s Plan", "Harpers Plan", "Groceries", "“Groceries”"}
+
+	if match, ok := matchTitleTolerantly("Harper's Plan", candidates[:1]); !ok || match != "Harper’s Plan" {
+		t.Errorf("expected a unique match, got %q, %v", match, ok)
+	}
+	if _, ok := matchTitleTolerantly("Groceries!", candidates); ok {
+		t.Error("a title matching two candidates should not match")
+	}
+	if _, ok := matchTitleTolerantly("...", candidates); ok {
+		t.Error("a title that is only punctuation should not match")
+	}
+}
+
+// storedTitleExecutor answers scripts the way Notes would for a fixed set of stored titles
+type storedTitleExecutor struct {
+	titles  []string
+	scripts []string
+}
+
+func (e *storedTitleExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	e.scripts = append(e.scripts, script)
+	if strings.Contains(script, "get name of notes") {
+		return strings.Join(e.titles, "\n") + "\n", "", nil
+	}
+	for _, title := range e.titles {
+		if strings.Contains(script, `note "`+title+`"`) {
+			return "<div>" + title + "</div>", "", nil
+		}
+	}
+	return "", `Notes got an error: Can't get note. (-1728)`, errors.New("exit status 1")
+}
+
+func TestGetNoteContentMatchesTolerantly(t *testing.T) {
+	executor := &storedTitleExecutor{titles: []string{"Harper’s “Plan”", "Groceries"}}
+	service := NewAppleNotesService(executor)
+
+	var requested, actual string
+	ctx := WithTitleMatchReport(context.Background(), func(r, a string) { requested, actual = r, a })
+	body, err := service.GetNoteContent(ctx, `Harper's "Plan"`)
+	if err != nil {
+		t.Fatalf("expected the curly-quoted note to be found, got %v", err)
+	}
+	if body != "<div>Harper’s “Plan”</div>" {
+		t.Errorf("body = %q", body)
+	}
+	if len(executor.scripts) != 3 {
+		t.Errorf("expected lookup, title list, and retry scripts, got %d", len(executor.scripts))
+	}
+	if requested != `Harper's "Plan"` || actual != "Harper’s “Plan”" {
+		t.Errorf("expected the match to be reported, got %q -> %q", requested, actual)
+	}
+}
+
+func TestWritesDoNotMatchTolerantly(t *testing.T) {
+	executor := &storedTitleExecutor{titles: []string{"Harper’s “Plan”", "Harpers Planning"}}
+	service := NewAppleNotesService(executor)
+	ctx := context.Background()
+
+	writes := map[string]func() error{
+		"delete": func() error { return service.DeleteNote(ctx, `Harper's "Plan"`) },
+		"update": func() error { return service.UpdateNote(ctx, `Harper's "Plan"`, "new") },
+		"move":   func() error { return service.MoveNote(ctx, `Harper's "Plan"`, "Archive") },
+	}
+	for name, write := range writes {
+		executor.scripts = nil
+		var noteErr *NoteNotFoundError
+		if err := write(); !errors.As(err, &noteErr) {
+			t.Fatalf("%s: expected a not-found error with suggestions, got %v", name, err)
+		}
+		if len(noteErr.Suggestions) == 0 || noteErr.Suggestions[0] != "Harper’s “Plan”" {
+			t.Errorf("%s: expected the tolerant match to be suggested first, got %v", name, noteErr.Suggestions)
+		}
+		for _, script := range executor.scripts[1:] {
+			if strings.Contains(script, "Harper’s “Plan”") {
+				t.Errorf("%s: the tolerant match must not be written to, ran %q", name, script)
+			}
+		}
+	}
+}
+
+func TestGetNoteContentNormalizesTitle(t *testing.T) {
+	executor := &storedTitleExecutor{titles: []string{"Café"}}
+	service := NewAppleNotesService(executor)
+
+	if _, err := service.GetNoteContent(context.Background(), "Café"); err != nil {
+		t.Fatalf("expected a decomposed title to match the composed one, got %v", err)
+	}
+	if len(executor.scripts) != 1 {
+		t.Errorf("expected the normalized title to match without a fallback, got %d scripts", len(executor.scripts))
+	}
+}
+
+func TestGetNoteContentTolerantMissStillSuggests(t *testing.T) {
+	executor := &storedTitleExecutor{titles: []string{"Plan A", "Plan-A!"}}
+	service := NewAppleNotesService(executor)
+
+	_, err := service.GetNoteContent(context.Background(), "Plan: A")
+	var noteErr *NoteNotFoundError
+	if !errors.As(err, &noteErr) {
+		t.Fatalf("an ambiguous tolerant match should fail with suggestions, got %v", err)
+	}
+}
+
+func TestMemoryServiceMatchesTolerantly(t *testing.T) {
+	notes := newTestMemoryService()
+	ctx := context.Background()
+	_, _ = notes.CreateNote(ctx, "Café — “Ideas”", "<div>menu</div>", nil)
+
+	note, err := notes.GetNoteMetadata(ctx, "Café - Ideas")
+	if err != nil {
+		t.Fatalf("expected a tolerant match, got %v", err)
+	}
+	if note.Title != "Café — “Ideas”" {
+		t.Errorf("expected the stored title in NFC, got %q", note.Title)
+	}
+
+	var noteErr *NoteNotFoundError
+	if err := notes.DeleteNote(ctx, `Café — "Ideas"`); !errors.As(err, &noteErr) || noteErr.Suggestions[0] != "Café — “Ideas”" {
+		t.Fatalf("expected delete to fail suggesting the tolerant match, got %v", err)
+	}
+	if _, err := notes.GetNoteContent(ctx, "Café — “Ideas”"); err != nil {
+		t.Errorf("expected the note to be kept, got %v", err)
+	}
+}
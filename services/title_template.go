@@ -0,0 +1,93 @@
+// ABOUTME: Placeholder expansion for note titles on create, when the caller asks for it
+// ABOUTME: Expands {{date}}, {{time}}, and {{week}} using configurable formats
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Default formats for title placeholders
+const (
+	DefaultTitleDateFormat = "2006-01-02"
+	DefaultTitleTimeFormat = "15:04"
+	// DefaultTitleWeekFormat renders the ISO 8601 week, e.g. "2024-W05"
+	DefaultTitleWeekFormat = "%G-W%V"
+)
+
+// TitleFormats configures how title placeholders are rendered
+// Date and Time are Go time layouts; Week uses the strftime ISO week directives
+// %G (ISO year) and %V (zero-padded ISO week number).
+// Empty fields use the defaults.
+type TitleFormats struct {
+	Date string
+	Time string
+	Week string
+}
+
+// titleTemplateKey is the context key marking creates whose title placeholders are expanded
+type titleTemplateKey struct{}
+
+// WithTitleTemplate returns a copy of ctx whose CreateNote calls expand placeholders in the title
+// Without it titles are kept as given, so imported, clipped, and restored notes are never rewritten.
+func WithTitleTemplate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, titleTemplateKey{}, true)
+}
+
+// expandTitleTemplate expands title's placeholders when ctx asks for it, and returns it unchanged otherwise
+func expandTitleTemplate(ctx context.Context, title string, now time.Time, formats TitleFormats) string {
+	if expand, _ := ctx.Value(titleTemplateKey{}).(bool); !expand {
+		return title
+	}
+	return ExpandTitleTemplate(title, now, formats)
+}
+
+// titlePlaceholderPattern matches {{name}} or {{name:format}} placeholders
+var titlePlaceholderPattern = regexp.MustCompile(`\{\{\s*(date|time|week)\s*(?::([^}]*))?\}\}`)
+
+// ExpandTitleTemplate replaces {{date}}, {{time}}, and {{week}} in title with values for now
+// A placeholder may carry its own format, e.g. {{date:Jan 2}}, which overrides formats.
+// Unrecognized placeholders are left unchanged.
+func ExpandTitleTemplate(title string, now time.Time, formats TitleFormats) string {
+	if !strings.Contains(title, "{{") {
+		return title
+	}
+
+	return titlePlaceholderPattern.ReplaceAllStringFunc(title, func(match string) string {
+		parts := titlePlaceholderPattern.FindStringSubmatch(match)
+		name, format := parts[1], parts[2]
+
+		switch name {
+		case "date":
+			return now.Format(firstNonEmpty(format, formats.Date, DefaultTitleDateFormat))
+		case "time":
+			return now.Format(firstNonEmpty(format, formats.Time, DefaultTitleTimeFormat))
+		default:
+			year, week := now.ISOWeek()
+			pattern := firstNonEmpty(format, formats.Week, DefaultTitleWeekFormat)
+			return strings.NewReplacer(
+				"%G", fmt.Sprintf("%d", year),
+				"%V", fmt.Sprintf("%02d", week),
+			).Replace(pattern)
+		}
+	})
+}
+
+// SetTitleFormats configures the formats used when expanding placeholders in CreateNote titles
+func (s *AppleNotesService) SetTitleFormats(formats TitleFormats) {
+	s.titleFormats = formats
+}
+
+// firstNonEmpty returns the first non-empty string in values
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+// ABOUTME: Unit tests for title placeholder expansion
+// ABOUTME: Tests default, configured, and inline formats, and that creates expand only when asked
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestExpandTitleTemplate tests placeholder expansion across format sources
+func TestExpandTitleTemplate(t *testing.T) {
+	// Friday of ISO week 1 of 2021 — the ISO year differs from the calendar year
+	now := time.Date(2021, 1, 1, 9, 5, 0, 0, time.UTC)
+	weekNow := time.Date(2024, 1, 30, 14, 45, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		title   string
+		now     time.Time
+		formats TitleFormats
+		want    string
+	}{
+		{name: "no placeholders", title: "Groceries", now: now, want: "Groceries"},
+		{name: "default date", title: "Standup {{date}}", now: weekNow, want: "Standup 2024-01-30"},
+		{name: "default time", title: "Call {{time}}", now: weekNow, want: "Call 14:45"},
+		{name: "default week", title: "Review {{week}}", now: weekNow, want: "Review 2024-W05"},
+		{name: "iso year", title: "{{week}}", now: now, want: "2020-W53"},
+		{name: "whitespace", title: "{{ date }}", now: weekNow, want: "2024-01-30"},
+		{
+			name:    "configured formats",
+			title:   "{{date}} {{time}} {{week}}",
+			now:     weekNow,
+			formats: TitleFormats{Date: "Jan 2", Time: "3:04PM", Week: "Week %V"},
+			want:    "Jan 30 2:45PM Week 05",
+		},
+		{
+			name:    "inline format overrides configured",
+			title:   "Journal {{date:Monday}}",
+			now:     weekNow,
+			formats: TitleFormats{Date: "2006"},
+			want:    "Journal Tuesday",
+		},
+		{name: "unknown placeholder", title: "{{author}} {{date}}", now: weekNow, want: "{{author}} 2024-01-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandTitleTemplate(tt.title, tt.now, tt.formats); got != tt.want {
+				t.Errorf("ExpandTitleTemplate(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateNoteExpandsTitleOnlyWhenAsked tests that imported titles containing "{{date}}" are kept as given
+func TestCreateNoteExpandsTitleOnlyWhenAsked(t *testing.T) {
+	notes := newTestMemoryService()
+
+	kept, err := notes.CreateNote(context.Background(), "Template: {{date}}", "", nil)
+	if err != nil || kept.Title != "Template: {{date}}" {
+		t.Errorf("expected the title kept as given, got %+v, %v", kept, err)
+	}
+
+	expanded, err := notes.CreateNote(WithTitleTemplate(context.Background()), "Standup {{date}}", "", nil)
+	if err != nil || expanded.Title != "Standup 2024-03-01" {
+		t.Errorf("expected the placeholder expanded, got %+v, %v", expanded, err)
+	}
+}
@@ -0,0 +1,131 @@
+// ABOUTME: Finder for notes whose titles are variants of one another, a frequent artifact of iCloud sync conflicts
+// ABOUTME: Clusters titles differing only in case, punctuation, emoji, or "copy" and "(2)" suffixes into merge candidates
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// titleVariantSuffix matches a trailing "(2)", "copy", or "copy 3" that duplicating or a sync conflict adds
+var titleVariantSuffix = regexp.MustCompile(`(?i)[\s\p{Pd}.,:;_]*(?:\(\s*\d+\s*\)|\bcopy(?:\s+\d+)?)\s*$`)
+
+// TitleVariantSet is a group of notes whose titles are variants of one title, newest first
+// Target is the suggested note to merge the others into: the one with the plainest title.
+type TitleVariantSet struct {
+	Target string `json:"target"`
+	Notes  []Note `json:"notes"`
+}
+
+// Sources returns the titles of the set's notes other than its target
+func (s TitleVariantSet) Sources() []string {
+	sources := []string{}
+	for _, note := range s.Notes {
+		if note.Title != s.Target {
+			sources = append(sources, note.Title)
+		}
+	}
+	return sources
+}
+
+// TitleVariantMerges reports merging title variant sets
+type TitleVariantMerges struct {
+	Merged  []NoteMerge   `json:"merged"`
+	Skipped []SkippedNote `json:"skipped"`
+}
+
+// titleVariantKey reduces a title to what its variants share: emoji and copy suffixes are dropped,
+// then case and punctuation are ignored as in tolerantTitleKey
+func titleVariantKey(title string) string {
+	title = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r) || r == '\u200D' {
+			return -1
+		}
+		return r
+	}, normalizeTitle(title))
+
+	for {
+		trimmed := titleVariantSuffix.ReplaceAllString(title, "")
+		if trimmed == title {
+			break
+		}
+		title = trimmed
+	}
+	return tolerantTitleKey(title)
+}
+
+// FindTitleVariants groups the notes in folder (or every folder) whose titles are variants of each other
+// Only groups with at least two different titles are returned; notes sharing one exact title are
+// duplicates rather than variants. Sets are sorted by target title.
+func FindTitleVariants(ctx context.Context, notes NoteReader, folder string) ([]TitleVariantSet, error) {
+	library, err := notes.ListNotesWithMetadata(ctx, folder)
+	if err != nil {
+		return []TitleVariantSet{}, fmt.Errorf("failed to find title variants: %w", err)
+	}
+
+	groups := map[string][]Note{}
+	keys := []string{}
+	for _, note := range library {
+		key := titleVariantKey(note.Title)
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], note)
+	}
+
+	sets := []TitleVariantSet{}
+	for _, key := range keys {
+		group := groups[key]
+		distinct := map[string]bool{}
+		for _, note := range group {
+			distinct[note.Title] = true
+		}
+		if len(distinct) < 2 {
+			continue
+		}
+
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].ModificationDate.After(group[j].ModificationDate)
+		})
+		target := group[0].Title
+		for _, note := range group[1:] {
+			if utf8.RuneCountInString(note.Title) < utf8.RuneCountInString(target) {
+				target = note.Title
+			}
+		}
+		sets = append(sets, TitleVariantSet{Target: target, Notes: group})
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		return strings.ToLower(sets[i].Target) < strings.ToLower(sets[j].Target)
+	})
+	return sets, nil
+}
+
+// MergeTitleVariants merges each set's other notes into its target with MergeNotes
+// A set MergeNotes refuses, such as one whose titles differ only in case, is skipped with the reason;
+// one merged but not fully deleted is reported under both.
+func MergeTitleVariants(ctx context.Context, notes NotesService, sets []TitleVariantSet) (*TitleVariantMerges, error) {
+	report := &TitleVariantMerges{Merged: []NoteMerge{}, Skipped: []SkippedNote{}}
+	for _, set := range sets {
+		if err := ctx.Err(); err != nil {
+			return report, fmt.Errorf("failed to merge title variants: %w", err)
+		}
+		merge, err := MergeNotes(ctx, notes, set.Target, set.Sources())
+		if merge != nil {
+			report.Merged = append(report.Merged, *merge)
+		}
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedNote{Title: set.Target, Reason: err.Error()})
+		}
+	}
+	return report, nil
+}
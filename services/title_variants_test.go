@@ -0,0 +1,90 @@
+// ABOUTME: Unit tests for the title variant finder
+// ABOUTME: Tests variant keys, clustering with target choice, and merging sets while skipping case-only variants
+
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTitleVariantKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"Trip Plan", "trip plan", true},
+		{"Trip Plan", "Trip-Plan!", true},
+		{"Trip Plan", "✈️ Trip Plan", true},
+		{"Trip Plan", "Trip Plan copy", true},
+		{"Trip Plan", "Trip Plan - Copy 2", true},
+		{"Trip Plan", "Trip Plan (2)", true},
+		{"Trip Plan", "Trip Plan (3) copy", true},
+		{"Chapter 2", "Chapter 3", false},
+		{"Photocopy", "Photo", false},
+		{"Trip Plan", "Trip Plans", false},
+	}
+	for _, tt := range tests {
+		if got := titleVariantKey(tt.a) == titleVariantKey(tt.b); got != tt.same {
+			t.Errorf("titleVariantKey(%q) == titleVariantKey(%q) is %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+	if key := titleVariantKey("📌 (2)"); key != "" {
+		t.Errorf("expected a title of only emoji and a suffix to have no key, got %q", key)
+	}
+}
+
+func TestFindAndMergeTitleVariants(t *testing.T) {
+	ctx := context.Background()
+	service := NewMemoryNotesService()
+	for title, body := range map[string]string{
+		"Trip Plan":       "<div>Trip Plan</div><div>Book flights</div>",
+		"Trip Plan (2)":   "<div>Trip Plan (2)</div><div>Pack light</div>",
+		"✈️ Trip Plan":    "<div>Trip Plan</div><div>Window seat</div>",
+		"Budget":          "<div>Budget</div>",
+		"budget copy":     "<div>budget copy</div>",
+		"BUDGET":          "<div>BUDGET</div>",
+		"Unrelated Note":  "<div>Alone</div>",
+		"Unrelated Notes": "<div>Also alone</div>",
+	} {
+		if _, err := service.CreateNote(ctx, title, body, nil); err != nil {
+			t.Fatalf("CreateNote failed: %v", err)
+		}
+	}
+
+	sets, err := FindTitleVariants(ctx, service, "")
+	if err != nil {
+		t.Fatalf("FindTitleVariants failed: %v", err)
+	}
+	if len(sets) != 2 || !strings.EqualFold(sets[0].Target, "budget") || sets[1].Target != "Trip Plan" {
+		t.Fatalf("expected the Budget and Trip Plan sets, got %+v", sets)
+	}
+	if len(sets[1].Notes) != 3 || len(sets[1].Sources()) != 2 {
+		t.Errorf("expected three Trip Plan variants, got %+v", sets[1])
+	}
+
+	report, err := MergeTitleVariants(ctx, service, sets)
+	if err != nil {
+		t.Fatalf("MergeTitleVariants failed: %v", err)
+	}
+	if len(report.Merged) != 1 || report.Merged[0].Target != "Trip Plan" || len(report.Merged[0].Deleted) != 2 {
+		t.Errorf("expected only Trip Plan merged, got %+v", report.Merged)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Title != sets[0].Target {
+		t.Errorf("expected the case-only Budget set skipped, got %+v", report.Skipped)
+	}
+
+	body, err := service.GetNoteContent(ctx, "Trip Plan")
+	if err != nil {
+		t.Fatalf("GetNoteContent failed: %v", err)
+	}
+	for _, want := range []string{"Book flights", "Pack light", "Window seat"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the merged note to contain %q, got %s", want, body)
+		}
+	}
+	if remaining, _ := FindTitleVariants(ctx, service, ""); len(remaining) != 1 {
+		t.Errorf("expected only the Budget set left, got %+v", remaining)
+	}
+}
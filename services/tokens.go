@@ -0,0 +1,160 @@
+// ABOUTME: API tokens for authenticating clients of the HTTP transport
+// ABOUTME: Keeps only SHA-256 hashes of tokens, stored in a SecretStore such as the Keychain
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// apiTokensSecretName is the secret holding the JSON list of token records
+const apiTokensSecretName = "api-tokens"
+
+// apiTokenPrefix makes notes-mcp tokens recognizable in configs and secret scanners
+const apiTokenPrefix = "nmcp_"
+
+// ErrInvalidToken indicates a presented API token is unknown or revoked
+var ErrInvalidToken = errors.New("invalid API token")
+
+// APIToken describes an issued API token; the token itself is never stored
+type APIToken struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Hash    string    `json:"hash"`
+	Created time.Time `json:"created"`
+}
+
+// TokenStore issues, lists, revokes, and validates API tokens
+type TokenStore struct {
+	secrets SecretStore
+}
+
+// NewTokenStore creates a TokenStore backed by secrets
+func NewTokenStore(secrets SecretStore) *TokenStore {
+	return &TokenStore{secrets: secrets}
+}
+
+// Create issues a new token with a human-readable name and returns the token, which is shown only once
+func (t *TokenStore) Create(ctx context.Context, name string) (string, *APIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, fmt.Errorf("%w: token name is required", ErrInvalidInput)
+	}
+
+	tokens, err := t.List(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	record := APIToken{
+		ID:      hex.EncodeToString(id),
+		Name:    name,
+		Hash:    hashAPIToken(token),
+		Created: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := t.save(ctx, append(tokens, record)); err != nil {
+		return "", nil, err
+	}
+	return token, &record, nil
+}
+
+// Revoke deletes the token with the given ID
+func (t *TokenStore) Revoke(ctx context.Context, id string) error {
+	tokens, err := t.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	kept := make([]APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		if token.ID != id {
+			kept = append(kept, token)
+		}
+	}
+	if len(kept) == len(tokens) {
+		return fmt.Errorf("%w: no token with ID %q", ErrInvalidInput, id)
+	}
+
+	return t.save(ctx, kept)
+}
+
+// List returns the issued tokens, oldest first
+func (t *TokenStore) List(ctx context.Context) ([]APIToken, error) {
+	value, err := t.secrets.Get(ctx, apiTokensSecretName)
+	if errors.Is(err, ErrSecretNotFound) {
+		return []APIToken{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API tokens: %w", err)
+	}
+
+	tokens := []APIToken{}
+	if err := json.Unmarshal([]byte(value), &tokens); err != nil {
+		return nil, fmt.Errorf("failed to load API tokens: %w", err)
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].Created.Before(tokens[j].Created)
+	})
+	return tokens, nil
+}
+
+// Validate returns the record for a presented token, or ErrInvalidToken
+func (t *TokenStore) Validate(ctx context.Context, token string) (*APIToken, error) {
+	if !strings.HasPrefix(token, apiTokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	tokens, err := t.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := []byte(hashAPIToken(token))
+	for i := range tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(tokens[i].Hash)) == 1 {
+			return &tokens[i], nil
+		}
+	}
+	return nil, ErrInvalidToken
+}
+
+// save writes the token records back to the secret store
+func (t *TokenStore) save(ctx context.Context, tokens []APIToken) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to save API tokens: %w", err)
+	}
+	if err := t.secrets.Set(ctx, apiTokensSecretName, string(data)); err != nil {
+		return fmt.Errorf("failed to save API tokens: %w", err)
+	}
+	return nil
+}
+
+// hashAPIToken returns the hex SHA-256 of a token
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
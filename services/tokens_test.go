@@ -0,0 +1,68 @@
+// ABOUTME: Unit tests for HTTP transport API tokens
+// ABOUTME: Tests creation, validation, listing, and revocation against an in-memory secret store
+
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestTokenStoreLifecycle tests that created tokens validate until revoked
+func TestTokenStoreLifecycle(t *testing.T) {
+	ctx := context.Background()
+	secrets := newMemorySecretStore()
+	store := NewTokenStore(secrets)
+
+	token, record, err := store.Create(ctx, "laptop")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !strings.HasPrefix(token, apiTokenPrefix) || record.Name != "laptop" || record.ID == "" {
+		t.Fatalf("unexpected token %q / record %+v", token, record)
+	}
+	if strings.Contains(secrets.secrets[apiTokensSecretName], token) {
+		t.Error("the token itself must not be stored")
+	}
+
+	if _, _, err := store.Create(ctx, "phone"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	validated, err := store.Validate(ctx, token)
+	if err != nil || validated.ID != record.ID {
+		t.Fatalf("Validate = %+v, %v; want record %s", validated, err, record.ID)
+	}
+
+	tokens, err := store.List(ctx)
+	if err != nil || len(tokens) != 2 {
+		t.Fatalf("List = %d tokens, %v; want 2", len(tokens), err)
+	}
+
+	if err := store.Revoke(ctx, record.ID); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if _, err := store.Validate(ctx, token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken after revoke, got %v", err)
+	}
+}
+
+// TestTokenStoreErrors tests input validation and unknown tokens
+func TestTokenStoreErrors(t *testing.T) {
+	ctx := context.Background()
+	store := NewTokenStore(newMemorySecretStore())
+
+	if _, _, err := store.Create(ctx, "  "); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for empty name, got %v", err)
+	}
+	if err := store.Revoke(ctx, "missing"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for unknown ID, got %v", err)
+	}
+	for _, token := range []string{"", "nmcp_unknown", "Bearer-ish"} {
+		if _, err := store.Validate(ctx, token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Validate(%q): expected ErrInvalidToken, got %v", token, err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// ABOUTME: Verbose tracing of script timings and service decisions through context
+// ABOUTME: Lets the CLI's --verbose flag report what ran, how long it took, and which fallbacks were taken
+
+package services
+
+import "context"
+
+// traceKey is the context key for the trace function
+type traceKey struct{}
+
+// TraceFunc receives one diagnostic message, formatted like fmt.Printf
+type TraceFunc func(format string, args ...any)
+
+// WithTrace returns a copy of ctx whose service calls report timings and decisions to fn
+func WithTrace(ctx context.Context, fn TraceFunc) context.Context {
+	return context.WithValue(ctx, traceKey{}, fn)
+}
+
+// tracef reports a diagnostic message to the trace function carried by ctx, if any
+func tracef(ctx context.Context, format string, args ...any) {
+	if fn, ok := ctx.Value(traceKey{}).(TraceFunc); ok && fn != nil {
+		fn(format, args...)
+	}
+}
@@ -0,0 +1,23 @@
+// ABOUTME: Unit tests for verbose tracing through context
+// ABOUTME: Covers delivery to the trace function and the no-op without one
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestTracef(t *testing.T) {
+	tracef(context.Background(), "no trace function: %d", 1)
+
+	var got []string
+	ctx := WithTrace(context.Background(), func(format string, args ...any) {
+		got = append(got, fmt.Sprintf(format, args...))
+	})
+	tracef(ctx, "osascript completed in %s", "12ms")
+	if len(got) != 1 || got[0] != "osascript completed in 12ms" {
+		t.Errorf("unexpected trace messages: %v", got)
+	}
+}
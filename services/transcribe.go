@@ -0,0 +1,232 @@
+// ABOUTME: Transcription of audio attachments through a Speech framework helper or a Whisper endpoint
+// ABOUTME: Returns the transcript or appends it to the note, for voice-memo-in-notes workflows
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// audioExtensions lists the attachment file types sent for transcription
+var audioExtensions = map[string]bool{
+	".m4a": true, ".mp3": true, ".wav": true, ".aac": true, ".caf": true, ".aif": true,
+	".aiff": true, ".flac": true, ".ogg": true, ".webm": true, ".mp4": true,
+}
+
+// Transcriber converts an audio file to text
+type Transcriber interface {
+	Transcribe(ctx context.Context, audioPath string) (string, error)
+}
+
+// SpeechHelperTranscriber runs an external helper that transcribes with the macOS Speech framework
+// The helper is called as "<command> <audio-path>" and prints the transcript to stdout.
+type SpeechHelperTranscriber struct {
+	command string
+	timeout time.Duration
+}
+
+// NewSpeechHelperTranscriber creates a SpeechHelperTranscriber for the helper at command.
+// If timeout is 0 or negative, defaults to 5 minutes.
+func NewSpeechHelperTranscriber(command string, timeout time.Duration) *SpeechHelperTranscriber {
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	return &SpeechHelperTranscriber{
+		command: command,
+		timeout: timeout,
+	}
+}
+
+// Transcribe runs the helper on audioPath
+func (t *SpeechHelperTranscriber) Transcribe(ctx context.Context, audioPath string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, t.command, audioPath) // #nosec G204 - helper path comes from server configuration
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("speech helper failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// WhisperTranscriber posts audio to an OpenAI-compatible /audio/transcriptions endpoint
+type WhisperTranscriber struct {
+	endpoint string
+	apiKey   string
+	model    string
+	client   *http.Client
+}
+
+// NewWhisperTranscriber creates a WhisperTranscriber for endpoint, the full transcriptions URL.
+// apiKey is optional for self-hosted servers; model defaults to "whisper-1".
+// If timeout is 0 or negative, defaults to 5 minutes.
+func NewWhisperTranscriber(endpoint, apiKey, model string, timeout time.Duration) *WhisperTranscriber {
+	if model == "" {
+		model = "whisper-1"
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	return &WhisperTranscriber{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		model:    model,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Transcribe uploads audioPath as multipart form data and returns the "text" of the response
+func (t *WhisperTranscriber) Transcribe(ctx context.Context, audioPath string) (string, error) {
+	audio, err := os.Open(audioPath) // #nosec G304 - path comes from Apple Notes attachment API
+	if err != nil {
+		return "", fmt.Errorf("failed to read audio file: %w", err)
+	}
+	defer audio.Close() //nolint:errcheck // read-only file
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("model", t.model); err != nil {
+		return "", fmt.Errorf("failed to build transcription request: %w", err)
+	}
+	if err := form.WriteField("response_format", "json"); err != nil {
+		return "", fmt.Errorf("failed to build transcription request: %w", err)
+	}
+	part, err := form.CreateFormFile("file", filepath.Base(audioPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to build transcription request: %w", err)
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return "", fmt.Errorf("failed to read audio file: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return "", fmt.Errorf("failed to build transcription request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create transcription request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("transcription request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // response body close failure is non-critical
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read transcription response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("transcription endpoint returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse transcription response: %w", err)
+	}
+
+	return strings.TrimSpace(result.Text), nil
+}
+
+// Transcript is the text of an audio attachment
+type Transcript struct {
+	NoteTitle  string `json:"note_title"`
+	Attachment string `json:"attachment"`
+	Text       string `json:"text"`
+	Appended   bool   `json:"appended"`
+}
+
+// SetTranscriber configures how TranscribeAttachment converts audio to text
+func (s *AppleNotesService) SetTranscriber(transcriber Transcriber) {
+	s.transcriber = transcriber
+}
+
+// TranscribeAttachment transcribes a note's audio attachment, matched by name case-insensitively
+// With appendToNote the transcript is added to the end of the note under a heading naming the attachment.
+func (s *AppleNotesService) TranscribeAttachment(ctx context.Context, noteTitle, attachmentName string, appendToNote bool) (*Transcript, error) {
+	if s.transcriber == nil {
+		return nil, fmt.Errorf("%w: no transcriber is configured", ErrInvalidInput)
+	}
+
+	attachments, err := s.GetNoteAttachments(ctx, noteTitle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transcribe attachment: %w", err)
+	}
+
+	var attachment *Attachment
+	for i := range attachments {
+		if strings.EqualFold(attachments[i].Name, attachmentName) {
+			attachment = &attachments[i]
+			break
+		}
+	}
+	if attachment == nil {
+		return nil, fmt.Errorf("%w: note %q has no attachment named %q", ErrInvalidInput, noteTitle, attachmentName)
+	}
+	if attachment.FilePath == "" {
+		return nil, fmt.Errorf("failed to transcribe attachment: attachment %q has no file on disk", attachment.Name)
+	}
+	if !audioExtensions[strings.ToLower(filepath.Ext(attachment.FilePath))] {
+		return nil, fmt.Errorf("%w: attachment %q is not audio", ErrInvalidInput, attachment.Name)
+	}
+
+	text, err := s.transcriber.Transcribe(ctx, attachment.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transcribe attachment: %w", err)
+	}
+
+	transcript := &Transcript{NoteTitle: noteTitle, Attachment: attachment.Name, Text: text}
+	if appendToNote && text != "" {
+		if err := s.appendTranscript(ctx, noteTitle, attachment.Name, text); err != nil {
+			return nil, err
+		}
+		transcript.Appended = true
+	}
+
+	return transcript, nil
+}
+
+// appendTranscript adds a transcript section to the end of a note's body
+// Transcribing the same attachment again replaces its earlier section instead of adding another.
+func (s *AppleNotesService) appendTranscript(ctx context.Context, noteTitle, attachmentName, text string) error {
+	heading := "Transcript: " + attachmentName
+	lines := strings.Split(html.EscapeString(text), "\n")
+	section := "<div><b>" + html.EscapeString(heading) + "</b></div><div>" + strings.Join(lines, "<br>") + "</div>"
+
+	err := s.editNoteBody(ctx, noteTitle, func(body string) (string, error) {
+		edited, _, err := ReplaceHTMLSection(body, heading, section)
+		return edited, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append transcript: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// ABOUTME: Unit tests for audio attachment transcription
+// ABOUTME: Tests attachment checks, appending transcripts, and the Whisper request format
+
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeTranscriber returns canned text and records the file it was given
+type fakeTranscriber struct {
+	text string
+	path string
+}
+
+func (f *fakeTranscriber) Transcribe(ctx context.Context, audioPath string) (string, error) {
+	f.path = audioPath
+	return f.text, nil
+}
+
+var transcribeAttachments = attachmentRecord("att-1", "Voice Memo.m4a", "", "/Users/test/Voice Memo.m4a", "", "") +
+	attachmentRecord("att-2", "photo.jpg", "", "/Users/test/photo.jpg", "", "")
+
+// TestTranscribeAttachment tests transcription with and without appending to the note
+func TestTranscribeAttachment(t *testing.T) {
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: transcribeAttachments},                        // GetNoteAttachments
+			{stdout: "<div><h1>Errands</h1></div><div><br></div>"}, // note body
+			{stdout: ""}, // write body with the transcript
+		},
+	}
+	service := NewAppleNotesService(executor)
+	transcriber := &fakeTranscriber{text: "Buy milk.\nCall Sam."}
+	service.SetTranscriber(transcriber)
+
+	transcript, err := service.TranscribeAttachment(context.Background(), "Errands", "voice memo.M4A", true)
+	if err != nil {
+		t.Fatalf("TranscribeAttachment failed: %v", err)
+	}
+	if transcript.Text != "Buy milk.\nCall Sam." || transcript.Attachment != "Voice Memo.m4a" || !transcript.Appended {
+		t.Errorf("unexpected transcript %+v", transcript)
+	}
+	if transcriber.path != "/Users/test/Voice Memo.m4a" {
+		t.Errorf("transcribed %q", transcriber.path)
+	}
+	if executor.callIndex != 3 {
+		t.Errorf("expected the transcript to be appended, got %d calls", executor.callIndex)
+	}
+
+	service = NewAppleNotesService(&MockExecutor{stdout: transcribeAttachments})
+	service.SetTranscriber(transcriber)
+	if transcript, err := service.TranscribeAttachment(context.Background(), "Errands", "Voice Memo.m4a", false); err != nil || transcript.Appended {
+		t.Errorf("expected returned-only transcript, got %+v, %v", transcript, err)
+	}
+}
+
+// TestTranscribeAttachmentErrors tests missing configuration and unsuitable attachments
+func TestTranscribeAttachmentErrors(t *testing.T) {
+	ctx := context.Background()
+
+	unconfigured := NewAppleNotesService(&MockExecutor{stdout: transcribeAttachments})
+	if _, err := unconfigured.TranscribeAttachment(ctx, "Errands", "Voice Memo.m4a", false); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without a transcriber, got %v", err)
+	}
+
+	service := NewAppleNotesService(&MockExecutor{stdout: transcribeAttachments})
+	service.SetTranscriber(&fakeTranscriber{})
+	for _, name := range []string{"photo.jpg", "missing.m4a"} {
+		if _, err := service.TranscribeAttachment(ctx, "Errands", name, false); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+}
+
+// TestWhisperTranscriber tests the multipart upload and response parsing
+func TestWhisperTranscriber(t *testing.T) {
+	audio := filepath.Join(t.TempDir(), "memo.m4a")
+	if err := os.WriteFile(audio, []byte("audio bytes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil || header.Filename != "memo.m4a" || r.FormValue("model") != "whisper-1" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		file.Close()                                          //nolint:errcheck // test server
+		w.Write([]byte(`{"text": " Hello from the memo. "}`)) //nolint:errcheck // test server
+	}))
+	defer server.Close()
+
+	text, err := NewWhisperTranscriber(server.URL, "secret", "", 0).Transcribe(context.Background(), audio)
+	if err != nil {
+		t.Fatalf("Transcribe failed: %v", err)
+	}
+	if text != "Hello from the memo." {
+		t.Errorf("text = %q", text)
+	}
+
+	if _, err := NewWhisperTranscriber(server.URL, "wrong", "", 0).Transcribe(context.Background(), audio); err == nil {
+		t.Error("expected error for rejected request")
+	}
+}
@@ -0,0 +1,185 @@
+// ABOUTME: Polling watcher that detects created, modified, and deleted notes
+// ABOUTME: Compares successive snapshots of note IDs and modification dates
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Note change types reported by the watcher
+const (
+	ChangeCreated  = "created"
+	ChangeModified = "modified"
+	ChangeDeleted  = "deleted"
+)
+
+// NoteState is the lightweight per-note state used to detect changes
+type NoteState struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Folder   string    `json:"folder"`
+	Modified time.Time `json:"modified"`
+}
+
+// NoteChange describes a single detected change to a note
+type NoteChange struct {
+	Type string    `json:"type"`
+	Note NoteState `json:"note"`
+}
+
+// ListNoteStates retrieves the ID, title, folder, and modification date of every note
+// Dates are emitted in ISO 8601 («class isot») so parsing does not depend on the system locale
+func (s *AppleNotesService) ListNoteStates(ctx context.Context) ([]NoteState, error) {
+	script := fmt.Sprintf(`
+		tell application "Notes"
+			tell account "%s"
+				set output to ""
+				repeat with n in notes
+					set folderName to ""
+					try
+						set folderName to name of container of n
+					end try
+					set modifiedText to ((modification date of n) as «class isot» as string)
+					set output to output & (id of n as text) & "|||" & (name of n) & "|||" & folderName & "|||" & modifiedText & linefeed
+				end repeat
+				return output
+			end tell
+		end tell
+	`, s.iCloudAccount)
+
+	stdout, stderr, err := s.executor.Execute(ctx, script)
+	if err != nil {
+		detectedErr := DetectError(ctx, stderr, err)
+		return []NoteState{}, fmt.Errorf("failed to list note states: %w", detectedErr)
+	}
+
+	return parseNoteStates(stdout), nil
+}
+
+// parseNoteStates parses linefeed/"|||" delimited note state output; malformed lines are skipped
+func parseNoteStates(output string) []NoteState {
+	states := []NoteState{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "|||")
+		if len(fields) != 4 {
+			continue
+		}
+
+		modified, err := time.ParseInLocation("2006-01-02T15:04:05", fields[3], time.Local)
+		if err != nil {
+			continue
+		}
+
+		states = append(states, NoteState{
+			ID:       fields[0],
+			Title:    fields[1],
+			Folder:   fields[2],
+			Modified: modified,
+		})
+	}
+
+	return states
+}
+
+// DiffNoteStates compares two snapshots and returns the changes between them
+// Changes are ordered by type (created, modified, deleted) and then by title
+func DiffNoteStates(previous, current []NoteState) []NoteChange {
+	before := make(map[string]NoteState, len(previous))
+	for _, state := range previous {
+		before[state.ID] = state
+	}
+
+	changes := []NoteChange{}
+	for _, state := range current {
+		old, existed := before[state.ID]
+		switch {
+		case !existed:
+			changes = append(changes, NoteChange{Type: ChangeCreated, Note: state})
+		case !old.Modified.Equal(state.Modified):
+			changes = append(changes, NoteChange{Type: ChangeModified, Note: state})
+		}
+		delete(before, state.ID)
+	}
+
+	for _, state := range before {
+		changes = append(changes, NoteChange{Type: ChangeDeleted, Note: state})
+	}
+
+	order := map[string]int{ChangeCreated: 0, ChangeModified: 1, ChangeDeleted: 2}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if order[changes[i].Type] != order[changes[j].Type] {
+			return order[changes[i].Type] < order[changes[j].Type]
+		}
+		return changes[i].Note.Title < changes[j].Note.Title
+	})
+
+	return changes
+}
+
+// Watcher polls Apple Notes at a fixed interval and reports changes between polls
+type Watcher struct {
+	service  *AppleNotesService
+	interval time.Duration
+	onChange func(ctx context.Context, changes []NoteChange)
+	onError  func(err error)
+}
+
+// NewWatcher creates a Watcher that calls onChange with each non-empty batch of changes
+// Poll errors are passed to onError (if non-nil) and the watcher keeps running
+func NewWatcher(service *AppleNotesService, interval time.Duration,
+	onChange func(ctx context.Context, changes []NoteChange), onError func(err error)) *Watcher {
+	return &Watcher{
+		service:  service,
+		interval: interval,
+		onChange: onChange,
+		onError:  onError,
+	}
+}
+
+// Run polls until ctx is cancelled. The first successful poll establishes the baseline
+// and does not report changes.
+func (w *Watcher) Run(ctx context.Context) error {
+	var previous []NoteState
+	haveBaseline := false
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		current, err := w.service.ListNoteStates(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.onError != nil {
+				w.onError(err)
+			}
+		case !haveBaseline:
+			previous = current
+			haveBaseline = true
+		default:
+			if changes := DiffNoteStates(previous, current); len(changes) > 0 {
+				w.onChange(ctx, changes)
+			}
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
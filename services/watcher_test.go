@@ -0,0 +1,89 @@
+// ABOUTME: Unit tests for the polling note watcher
+// ABOUTME: Tests note state parsing, snapshot diffing, and the baseline poll
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseNoteStates(t *testing.T) {
+	output := "x-coredata://1|||Groceries|||Notes|||2025-03-01T09:30:00\n" +
+		"malformed line\n" +
+		"x-coredata://2|||Plan|||Work|||not-a-date\n" +
+		"x-coredata://3|||Standup|||Work|||2025-03-02T10:00:00\n"
+
+	states := parseNoteStates(output)
+	if len(states) != 2 {
+		t.Fatalf("expected 2 states, got %d: %+v", len(states), states)
+	}
+	if states[0].Title != "Groceries" || states[0].Folder != "Notes" {
+		t.Errorf("unexpected first state: %+v", states[0])
+	}
+	if states[1].Modified.Hour() != 10 {
+		t.Errorf("expected modified hour 10, got %v", states[1].Modified)
+	}
+}
+
+func TestDiffNoteStates(t *testing.T) {
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	previous := []NoteState{
+		{ID: "1", Title: "Unchanged", Modified: base},
+		{ID: "2", Title: "Edited", Modified: base},
+		{ID: "3", Title: "Removed", Modified: base},
+	}
+	current := []NoteState{
+		{ID: "1", Title: "Unchanged", Modified: base},
+		{ID: "2", Title: "Edited", Modified: base.Add(time.Minute)},
+		{ID: "4", Title: "Added", Modified: base},
+	}
+
+	changes := DiffNoteStates(previous, current)
+	want := []struct{ typ, title string }{
+		{ChangeCreated, "Added"},
+		{ChangeModified, "Edited"},
+		{ChangeDeleted, "Removed"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(want), len(changes), changes)
+	}
+	for i, w := range want {
+		if changes[i].Type != w.typ || changes[i].Note.Title != w.title {
+			t.Errorf("change %d: expected %s %q, got %s %q", i, w.typ, w.title, changes[i].Type, changes[i].Note.Title)
+		}
+	}
+}
+
+func TestWatcherRunReportsChangesAfterBaseline(t *testing.T) {
+	executor := &SequentialMockExecutor{
+		responses: []struct {
+			stdout string
+			stderr string
+			err    error
+		}{
+			{stdout: "1|||First|||Notes|||2025-03-01T09:00:00\n"},
+			{stdout: "1|||First|||Notes|||2025-03-01T09:00:00\n2|||Second|||Notes|||2025-03-01T09:05:00\n"},
+		},
+	}
+	service := NewAppleNotesService(executor)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var reported []NoteChange
+	watcher := NewWatcher(service, 10*time.Millisecond, func(ctx context.Context, changes []NoteChange) {
+		reported = append(reported, changes...)
+		cancel()
+	}, nil)
+
+	err := watcher.Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(reported) != 1 || reported[0].Type != ChangeCreated || reported[0].Note.Title != "Second" {
+		t.Errorf("expected a single created change for Second, got %+v", reported)
+	}
+}
@@ -0,0 +1,132 @@
+// ABOUTME: Webhook notifications for note changes detected by the watcher
+// ABOUTME: Filters changes and POSTs HMAC-SHA256 signed JSON payloads to a configured URL
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader carries the hex HMAC-SHA256 of the request body, prefixed with "sha256="
+const WebhookSignatureHeader = "X-Notes-MCP-Signature"
+
+// WebhookConfig configures where and when change notifications are sent
+type WebhookConfig struct {
+	URL           string
+	Secret        string   // optional: HMAC signing secret
+	Folder        string   // optional: only notes in this folder
+	TitleContains string   // optional: only notes whose title contains this (case-insensitive)
+	Events        []string // optional: change types to send (default: created, modified)
+}
+
+// WebhookPayload is the JSON body POSTed to the webhook URL
+type WebhookPayload struct {
+	Event     string       `json:"event"`
+	Timestamp time.Time    `json:"timestamp"`
+	Changes   []NoteChange `json:"changes"`
+}
+
+// WebhookNotifier sends matching note changes to a webhook
+type WebhookNotifier struct {
+	config WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier with a 10 second HTTP timeout
+func NewWebhookNotifier(config WebhookConfig) *WebhookNotifier {
+	if len(config.Events) == 0 {
+		config.Events = []string{ChangeCreated, ChangeModified}
+	}
+
+	return &WebhookNotifier{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Filter returns the changes that match the webhook's folder, title, and event filters
+func (n *WebhookNotifier) Filter(changes []NoteChange) []NoteChange {
+	matched := []NoteChange{}
+	query := strings.ToLower(n.config.TitleContains)
+
+	for _, change := range changes {
+		if !containsString(n.config.Events, change.Type) {
+			continue
+		}
+		if n.config.Folder != "" && change.Note.Folder != n.config.Folder {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(change.Note.Title), query) {
+			continue
+		}
+		matched = append(matched, change)
+	}
+
+	return matched
+}
+
+// Notify POSTs the matching changes to the webhook URL; nothing is sent if no changes match
+func (n *WebhookNotifier) Notify(ctx context.Context, changes []NoteChange) error {
+	matched := n.Filter(changes)
+	if len(matched) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:     "notes.changed",
+		Timestamp: time.Now().UTC(),
+		Changes:   matched,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.config.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(n.config.Secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()               //nolint:errcheck // response body close failure is non-critical
+	_, _ = io.Copy(io.Discard, resp.Body) //nolint:errcheck // drain for connection reuse
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the hex-encoded HMAC-SHA256 of body using secret
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// containsString reports whether values contains target
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+// ABOUTME: Unit tests for webhook notifications
+// ABOUTME: Tests change filtering, HMAC signing, and HTTP delivery
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookNotifierFilter(t *testing.T) {
+	changes := []NoteChange{
+		{Type: ChangeCreated, Note: NoteState{Title: "Meeting: Launch", Folder: "Work"}},
+		{Type: ChangeModified, Note: NoteState{Title: "meeting: budget", Folder: "Work"}},
+		{Type: ChangeModified, Note: NoteState{Title: "Meeting: Dinner", Folder: "Personal"}},
+		{Type: ChangeDeleted, Note: NoteState{Title: "Meeting: Old", Folder: "Work"}},
+		{Type: ChangeCreated, Note: NoteState{Title: "Todo", Folder: "Work"}},
+	}
+
+	notifier := NewWebhookNotifier(WebhookConfig{URL: "http://example.invalid", Folder: "Work", TitleContains: "MEETING"})
+	matched := notifier.Filter(changes)
+
+	if len(matched) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %+v", len(matched), matched)
+	}
+	if matched[0].Note.Title != "Meeting: Launch" || matched[1].Note.Title != "meeting: budget" {
+		t.Errorf("unexpected matches: %+v", matched)
+	}
+}
+
+func TestWebhookNotifierNotifySignsPayload(t *testing.T) {
+	var gotBody []byte
+	var gotSignature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(WebhookSignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(WebhookConfig{URL: server.URL, Secret: "s3cret"})
+	err := notifier.Notify(context.Background(), []NoteChange{
+		{Type: ChangeCreated, Note: NoteState{ID: "1", Title: "New"}},
+	})
+	if err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if want := "sha256=" + SignWebhookPayload("s3cret", gotBody); gotSignature != want {
+		t.Errorf("expected signature %q, got %q", want, gotSignature)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.Event != "notes.changed" || len(payload.Changes) != 1 || payload.Changes[0].Note.Title != "New" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestWebhookNotifierNotifySkipsWhenNothingMatches(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(WebhookConfig{URL: server.URL})
+	if err := notifier.Notify(context.Background(), []NoteChange{{Type: ChangeDeleted}}); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if called {
+		t.Error("expected no request when no changes match")
+	}
+}
+
+func TestWebhookNotifierNotifyReportsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(WebhookConfig{URL: server.URL})
+	if err := notifier.Notify(context.Background(), []NoteChange{{Type: ChangeCreated}}); err == nil {
+		t.Error("expected error for non-2xx status")
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Known HMAC-SHA256 vector for key "key" and the classic pangram
+	got := SignWebhookPayload("key", []byte("The quick brown fox jumps over the lazy dog"))
+	want := "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
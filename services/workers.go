@@ -0,0 +1,77 @@
+// ABOUTME: Bounded worker pool for operations that need one AppleScript call per note
+// ABOUTME: Runs per-note calls a few at a time instead of strictly one after another
+
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is how many per-note AppleScript calls run at once when none is configured
+// Notes.app serializes much of its scripting work, so a small pool captures most of the gain.
+const DefaultConcurrency = 3
+
+// SetConcurrency sets how many per-note scripts multi-note operations run at once; n <= 0 means DefaultConcurrency
+func (s *AppleNotesService) SetConcurrency(n int) {
+	s.concurrency = n
+}
+
+// workerLimit returns the configured concurrency, or DefaultConcurrency when unset
+func (s *AppleNotesService) workerLimit() int {
+	if s.concurrency <= 0 {
+		return DefaultConcurrency
+	}
+	return s.concurrency
+}
+
+// forEachBounded calls fn for every index in [0, n) using at most limit goroutines
+// After the first error, or once ctx is done, no new calls start; the first error is returned.
+// fn must only write to state owned by its index, such as slots of a pre-sized slice.
+func forEachBounded(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	if limit <= 0 {
+		limit = 1
+	}
+	limit = min(limit, n)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	indexes := make(chan int)
+
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
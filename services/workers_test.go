@@ -0,0 +1,107 @@
+// ABOUTME: Unit tests for the bounded worker pool
+// ABOUTME: Checks the concurrency limit, per-index results, and stopping after an error
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachBoundedLimitsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	results := make([]int, 10)
+
+	err := forEachBounded(context.Background(), len(results), 3, func(ctx context.Context, i int) error {
+		now := running.Add(1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		results[i] = i * i
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEachBounded failed: %v", err)
+	}
+
+	if peak.Load() > 3 || peak.Load() < 2 {
+		t.Errorf("expected up to 3 calls at once, peak was %d", peak.Load())
+	}
+	for i, got := range results {
+		if got != i*i {
+			t.Errorf("results[%d] = %d, want %d", i, got, i*i)
+		}
+	}
+}
+
+func TestForEachBoundedStopsAfterError(t *testing.T) {
+	failure := errors.New("boom")
+	var calls atomic.Int32
+
+	err := forEachBounded(context.Background(), 100, 2, func(ctx context.Context, i int) error {
+		calls.Add(1)
+		if i == 0 {
+			return failure
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("expected the first error, got %v", err)
+	}
+	if calls.Load() >= 100 {
+		t.Errorf("expected remaining work to be abandoned, got %d calls", calls.Load())
+	}
+
+	if err := forEachBounded(context.Background(), 0, 3, func(ctx context.Context, i int) error {
+		t.Error("expected no calls for zero items")
+		return nil
+	}); err != nil {
+		t.Errorf("expected no error for zero items, got %v", err)
+	}
+}
+
+// titleKeyedExecutor answers a search with titles, then answers each metadata lookup for the
+// title it names, finishing later lookups first to shuffle completion order
+type titleKeyedExecutor struct {
+	titles []string
+}
+
+func (e *titleKeyedExecutor) Execute(ctx context.Context, script string) (string, string, error) {
+	for i, title := range e.titles {
+		if strings.Contains(script, `note "`+title+`"`) {
+			time.Sleep(time.Duration(len(e.titles)-i) * 2 * time.Millisecond)
+			return fmt.Sprintf(`{id:"x-coredata://%d", name:"%s", container:"Notes", shared:false, password protected:false}`, i, title), "", nil
+		}
+	}
+	return strings.Join(e.titles, "|||"), "", nil
+}
+
+func TestSearchNotesKeepsOrderWithConcurrentMetadata(t *testing.T) {
+	titles := []string{"Alpha", "Bravo", "Charlie", "Delta"}
+	service := NewAppleNotesService(&titleKeyedExecutor{titles: titles})
+	service.SetConcurrency(4)
+
+	notes, err := service.SearchNotes(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("SearchNotes failed: %v", err)
+	}
+	if len(notes) != len(titles) {
+		t.Fatalf("expected %d notes, got %+v", len(titles), notes)
+	}
+	for i, note := range notes {
+		if note.Title != titles[i] || note.ID != fmt.Sprintf("x-coredata://%d", i) {
+			t.Errorf("note %d = %s (%s), want %s in search order", i, note.Title, note.ID, titles[i])
+		}
+	}
+}
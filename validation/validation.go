@@ -0,0 +1,157 @@
+// ABOUTME: Input validation for values sent to Apple Notes: titles, folder names, and note content
+// ABOUTME: Reports each problem as a FieldError so CLI and MCP callers can show or return the details
+
+// Package validation checks user input against the constraints Apple Notes and osascript impose,
+// so bad input is rejected up front instead of failing inside an AppleScript.
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+// Limits on input sizes. Scripts reach osascript as a command-line argument, which macOS caps
+// at about 1 MB, and escaping can double the size of content.
+const (
+	// MaxTitleLength is the longest note title accepted, in characters
+	MaxTitleLength = 500
+	// MaxFolderNameLength is the longest folder name accepted, in characters
+	MaxFolderNameLength = 255
+	// MaxContentBytes is the largest note body accepted, in bytes
+	MaxContentBytes = 512 * 1024
+)
+
+// Rules a FieldError can report
+const (
+	RuleRequired          = "required"
+	RuleTooLong           = "too_long"
+	RuleControlCharacters = "control_characters"
+	RuleInvalidUTF8       = "invalid_utf8"
+)
+
+// FieldError describes why one input field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Limit   int    `json:"limit,omitempty"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// Errors is every problem found in one request; it matches services.ErrInvalidInput with errors.Is
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return services.ErrInvalidInput.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e Errors) Unwrap() error {
+	return services.ErrInvalidInput
+}
+
+// Check collects the failed checks into Errors, returning nil when every check passed
+func Check(checks ...*FieldError) error {
+	var errs Errors
+	for _, check := range checks {
+		if check != nil {
+			errs = append(errs, check)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Optional applies check only when value is set, for fields that may be left empty
+func Optional(check func(field, value string) *FieldError, field, value string) *FieldError {
+	if value == "" {
+		return nil
+	}
+	return check(field, value)
+}
+
+// Title checks a note title: present after trimming, at most MaxTitleLength characters, on one line
+func Title(field, value string) *FieldError {
+	return name(field, value, MaxTitleLength)
+}
+
+// FolderName checks a folder name the same way as a title, with the MaxFolderNameLength limit
+func FolderName(field, value string) *FieldError {
+	return name(field, value, MaxFolderNameLength)
+}
+
+// Content checks a note body: present, at most MaxContentBytes, and free of control characters
+// other than tabs and line breaks
+func Content(field, value string) *FieldError {
+	if strings.TrimSpace(value) == "" {
+		return &FieldError{Field: field, Rule: RuleRequired, Message: "is required"}
+	}
+	if !utf8.ValidString(value) {
+		return &FieldError{Field: field, Rule: RuleInvalidUTF8, Message: "is not valid UTF-8"}
+	}
+	if len(value) > MaxContentBytes {
+		return &FieldError{
+			Field:   field,
+			Rule:    RuleTooLong,
+			Message: fmt.Sprintf("is %d bytes, more than the %d allowed", len(value), MaxContentBytes),
+			Limit:   MaxContentBytes,
+		}
+	}
+	if r, ok := controlCharacter(value, "\t\n\r"); ok {
+		return controlCharacterError(field, r)
+	}
+	return nil
+}
+
+// name checks a single-line name against a length limit in characters
+func name(field, value string, limit int) *FieldError {
+	if strings.TrimSpace(value) == "" {
+		return &FieldError{Field: field, Rule: RuleRequired, Message: "is required"}
+	}
+	if !utf8.ValidString(value) {
+		return &FieldError{Field: field, Rule: RuleInvalidUTF8, Message: "is not valid UTF-8"}
+	}
+	if length := utf8.RuneCountInString(value); length > limit {
+		return &FieldError{
+			Field:   field,
+			Rule:    RuleTooLong,
+			Message: fmt.Sprintf("is %d characters, more than the %d allowed", length, limit),
+			Limit:   limit,
+		}
+	}
+	if r, ok := controlCharacter(value, ""); ok {
+		return controlCharacterError(field, r)
+	}
+	return nil
+}
+
+// controlCharacter returns the first control character in value that isn't in allowed
+// A NUL would also cut the script short, since it ends the osascript argument.
+func controlCharacter(value, allowed string) (rune, bool) {
+	for _, r := range value {
+		if unicode.IsControl(r) && !strings.ContainsRune(allowed, r) {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+func controlCharacterError(field string, r rune) *FieldError {
+	return &FieldError{
+		Field:   field,
+		Rule:    RuleControlCharacters,
+		Message: fmt.Sprintf("contains the control character %U", r),
+	}
+}
@@ -0,0 +1,84 @@
+// ABOUTME: Unit tests for input validation of titles, folder names, and content
+// ABOUTME: Covers each rule, the length limits, and how failures combine into Errors
+
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harper/notes-mcp/services"
+)
+
+func TestTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		rule  string
+	}{
+		{"valid", "Meeting Notes ☕", ""},
+		{"empty", "", RuleRequired},
+		{"only whitespace", "  \t ", RuleRequired},
+		{"at the limit", strings.Repeat("é", MaxTitleLength), ""},
+		{"too long", strings.Repeat("a", MaxTitleLength+1), RuleTooLong},
+		{"newline", "Line one\nLine two", RuleControlCharacters},
+		{"NUL", "Bad\x00Title", RuleControlCharacters},
+		{"invalid UTF-8", "Bad \xff title", RuleInvalidUTF8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Title("title", tt.value)
+			if tt.rule == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Rule != tt.rule {
+				t.Fatalf("expected rule %q, got %v", tt.rule, err)
+			}
+		})
+	}
+}
+
+func TestFolderNameLimit(t *testing.T) {
+	err := FolderName("folder", strings.Repeat("f", MaxFolderNameLength+1))
+	if err == nil || err.Rule != RuleTooLong || err.Limit != MaxFolderNameLength {
+		t.Fatalf("expected a too_long error with the limit, got %+v", err)
+	}
+}
+
+func TestContent(t *testing.T) {
+	if err := Content("content", "Line one\n\tindented\r\nLine three"); err != nil {
+		t.Errorf("tabs and line breaks should be allowed, got %v", err)
+	}
+	if err := Content("content", "bell\a"); err == nil || err.Rule != RuleControlCharacters {
+		t.Errorf("expected a control character error, got %v", err)
+	}
+	if err := Content("content", strings.Repeat("x", MaxContentBytes+1)); err == nil || err.Rule != RuleTooLong {
+		t.Errorf("expected a too_long error, got %v", err)
+	}
+	if err := Content("content", ""); err == nil || err.Rule != RuleRequired {
+		t.Errorf("expected a required error, got %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(Title("title", "Fine"), Optional(FolderName, "folder", "")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err := Check(Title("title", ""), Content("content", "ok"), Optional(FolderName, "folder", "a\tb"))
+	if !errors.Is(err, services.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected two field errors, got %v", err)
+	}
+	want := "invalid input parameters: title is required; folder contains the control character U+0009"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}